@@ -1,7 +1,11 @@
 package explorer
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/opt"
@@ -150,3 +154,9 @@ type iterator interface {
 type blockChainTxIndexer interface {
 	ReadTxLookupEntry(txID common.Hash) (common.Hash, uint64, uint64)
 }
+
+// InternalTransferTracer is the interface to trace the internal transfers of a block.
+// Implemented by *hmy.Harmony
+type InternalTransferTracer interface {
+	TraceInternalTransfers(ctx context.Context, block *types.Block) ([]hmy.InternalTransfer, error)
+}
@@ -71,10 +71,11 @@ func writeVersion(db databaseWriter, ver *goversion.Version) error {
 }
 
 var (
-	addrPrefix                = []byte("addr")
-	txnPrefix                 = []byte("tx")
-	addrNormalTxnIndexPrefix  = []byte("at")
-	addrStakingTxnIndexPrefix = []byte("stk")
+	addrPrefix                 = []byte("addr")
+	txnPrefix                  = []byte("tx")
+	addrNormalTxnIndexPrefix   = []byte("at")
+	addrStakingTxnIndexPrefix  = []byte("stk")
+	addrInternalTxnIndexPrefix = []byte("ai")
 )
 
 // bPool is the sync pool for reusing the memory for allocating db keys
@@ -308,6 +309,77 @@ func writeStakingTxnIndex(db databaseWriter, entry stakingTxnIndex, tt TxType) e
 	return db.Put(key, []byte{byte(tt)})
 }
 
+// internalTxnIndex is a single entry of address-transaction index for the transactions
+// having transferred ONE to or from the address in an internal call. The key of the entry
+// has the same layout as the one of normalTxnIndex.
+type internalTxnIndex struct {
+	addr        oneAddress
+	blockNumber uint64
+	txnIndex    uint64
+	txnHash     common.Hash
+}
+
+func (index internalTxnIndex) key() []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrInternalTxnIndexPrefix)
+	_, _ = b.Write([]byte(index.addr))
+	_ = binary.Write(b, binary.BigEndian, index.blockNumber)
+	_ = binary.Write(b, binary.BigEndian, index.txnIndex)
+	_, _ = b.Write(index.txnHash[:])
+	return b.Bytes()
+}
+
+func internalTxnIndexPrefixByAddr(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrInternalTxnIndexPrefix)
+	_, _ = b.Write([]byte(addr))
+	return b.Bytes()
+}
+
+func txnHashFromInternalTxnIndexKey(key []byte) (common.Hash, error) {
+	txStart := len(addrInternalTxnIndexPrefix) + oneAddrByteLen + 8 + 8
+	expSize := txStart + common.HashLength
+	if len(key) < expSize {
+		return common.Hash{}, errors.New("unexpected key size")
+	}
+	var txHash common.Hash
+	copy(txHash[:], key[txStart:expSize])
+	return txHash, nil
+}
+
+func getInternalTxnHashesByAccount(db databaseReader, addr oneAddress) ([]common.Hash, []TxType, error) {
+	var (
+		txHashes []common.Hash
+		tts      []TxType
+	)
+	prefix := internalTxnIndexPrefixByAddr(addr)
+	err := forEachAtPrefix(db, prefix, func(key, val []byte) error {
+		txHash, err := txnHashFromInternalTxnIndexKey(key)
+		if err != nil {
+			return err
+		}
+		if len(val) < 1 {
+			return errors.New("val size not expected")
+		}
+		tts = append(tts, TxType(val[0]))
+		txHashes = append(txHashes, txHash)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return txHashes, tts, nil
+}
+
+func writeInternalTxnIndex(db databaseWriter, entry internalTxnIndex, tt TxType) error {
+	key := entry.key()
+	return db.Put(key, []byte{byte(tt)})
+}
+
 func forEachAtPrefix(db databaseReader, prefix []byte, f func(key, val []byte) error) error {
 	it := db.NewPrefixIterator(prefix)
 	defer it.Release()
@@ -183,6 +183,47 @@ func TestGetStakingTxnHashesByAccount(t *testing.T) {
 	}
 }
 
+func TestGetInternalTxnHashesByAccount(t *testing.T) {
+	db := newMemDB()
+
+	for _, index := range makeTestNormalIndexes(10) {
+		internal := internalTxnIndex(index)
+		if err := writeInternalTxnIndex(db, internal, txReceived); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(internal.key(), internalTxnIndexPrefixByAddr(index.addr)) {
+			t.Errorf("does not have prefix")
+		}
+		txHash, err := txnHashFromInternalTxnIndexKey(internal.key())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if txHash != index.txnHash {
+			t.Errorf("unexpected txn hash %x / %x", txHash, index.txnHash)
+		}
+	}
+	// Checking results.
+	for i := 0; i != 10; i++ {
+		addr := makeOneAddress(i)
+		hashes, tts, err := getInternalTxnHashesByAccount(db, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := i; exp != len(hashes) || exp != len(tts) {
+			t.Errorf("get internal txn hashes not expected: %v / %v", len(hashes), exp)
+		}
+		for _, tt := range tts {
+			if tt != txReceived {
+				t.Errorf("unexpected type")
+			}
+		}
+		// internal transfers are not part of the normal transaction history
+		if hashes, _, _ := getNormalTxnHashesByAccount(db, addr); len(hashes) != 0 {
+			t.Errorf("unexpected normal txn hashes: %v", len(hashes))
+		}
+	}
+}
+
 func TestGetAllAddresses(t *testing.T) {
 	db := newMemDB()
 	addrs := makeAddresses(10)
@@ -51,10 +51,11 @@ type Service struct {
 	backend     hmy.NodeAPI
 }
 
-// New returns explorer service.
-func New(selfPeer *p2p.Peer, bc *core.BlockChain, backend hmy.NodeAPI) *Service {
+// New returns explorer service. The internal transfers of the blocks are indexed
+// with the tracer, if not nil.
+func New(selfPeer *p2p.Peer, bc *core.BlockChain, backend hmy.NodeAPI, tracer InternalTransferTracer) *Service {
 	dbPath := defaultDBPath(selfPeer.IP, selfPeer.Port)
-	storage, err := newStorage(bc, dbPath)
+	storage, err := newStorage(bc, tracer, dbPath)
 	if err != nil {
 		utils.Logger().Fatal().Err(err).Msg("cannot open explorer DB")
 	}
@@ -216,6 +217,12 @@ func (s *Service) GetStakingTxHashesByAccount(address string) ([]ethCommon.Hash,
 	return s.storage.GetStakingTxsByAddress(address)
 }
 
+// GetInternalTxHashesByAccount get the hashes of the transactions having transferred
+// ONE to or from the account in an internal call
+func (s *Service) GetInternalTxHashesByAccount(address string) ([]ethCommon.Hash, []TxType, error) {
+	return s.storage.GetInternalTxsByAddress(address)
+}
+
 // DumpNewBlock instruct the explorer storage to dump block data in explorer DB
 func (s *Service) DumpNewBlock(b *types.Block) {
 	s.storage.DumpNewBlock(b)
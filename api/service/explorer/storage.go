@@ -2,6 +2,7 @@ package explorer
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -29,8 +30,9 @@ var ErrExplorerNotReady = errors.New("explorer db not ready")
 
 type (
 	storage struct {
-		db database
-		bc *core.BlockChain
+		db     database
+		bc     *core.BlockChain
+		tracer InternalTransferTracer
 
 		// TODO: optimize this with priority queue
 		tm      *taskManager
@@ -47,7 +49,7 @@ type (
 	}
 )
 
-func newStorage(bc *core.BlockChain, dbPath string) (*storage, error) {
+func newStorage(bc *core.BlockChain, tracer InternalTransferTracer, dbPath string) (*storage, error) {
 	utils.Logger().Info().Msg("explorer storage folder: " + dbPath)
 	db, err := newLvlDB(dbPath)
 	if err != nil {
@@ -57,6 +59,7 @@ func newStorage(bc *core.BlockChain, dbPath string) (*storage, error) {
 	return &storage{
 		db:        db,
 		bc:        bc,
+		tracer:    tracer,
 		tm:        newTaskManager(),
 		resultC:   make(chan blockResult, numWorker),
 		available: abool.New(),
@@ -113,6 +116,13 @@ func (s *storage) GetStakingTxsByAddress(addr string) ([]common.Hash, []TxType,
 	return getStakingTxnHashesByAccount(s.db, oneAddress(addr))
 }
 
+func (s *storage) GetInternalTxsByAddress(addr string) ([]common.Hash, []TxType, error) {
+	if !s.available.IsSet() {
+		return nil, nil, ErrExplorerNotReady
+	}
+	return getInternalTxnHashesByAccount(s.db, oneAddress(addr))
+}
+
 func (s *storage) run() {
 	if is, err := isVersionV100(s.db); !is || err != nil {
 		s.available.UnSet()
@@ -210,6 +220,7 @@ func (s *storage) makeWorkersAndStart() {
 			tm:      s.tm,
 			db:      s.db,
 			bc:      s.bc,
+			tracer:  s.tracer,
 			resultC: s.resultC,
 			closeC:  s.closeC,
 			log:     s.log.With().Int("worker", i).Logger(),
@@ -224,6 +235,7 @@ type blockComputer struct {
 	tm      *taskManager
 	db      database
 	bc      blockChainTxIndexer
+	tracer  InternalTransferTracer
 	resultC chan blockResult
 	closeC  chan struct{}
 	log     zerolog.Logger
@@ -275,6 +287,7 @@ func (bc *blockComputer) computeBlock(b *types.Block) (*blockResult, error) {
 	for _, stk := range b.StakingTransactions() {
 		bc.computeStakingTx(btc, b, stk)
 	}
+	bc.computeInternalTxs(btc, b)
 	_ = writeCheckpoint(btc, b.NumberU64())
 	return &blockResult{
 		btc: btc,
@@ -342,6 +355,39 @@ func (bc *blockComputer) computeStakingTx(btc batch, b *types.Block, tx *staking
 	}, txReceived)
 }
 
+// computeInternalTxs indexes the transactions of the block by the addresses they have
+// transferred ONE to or from in internal calls. The block is still indexed if it cannot
+// be traced, missing its internal transfers.
+func (bc *blockComputer) computeInternalTxs(btc batch, b *types.Block) {
+	if bc.tracer == nil {
+		return
+	}
+	transfers, err := bc.tracer.TraceInternalTransfers(context.Background(), b)
+	if err != nil {
+		bc.log.Warn().Err(err).Uint64("number", b.NumberU64()).
+			Msg("explorer failed to trace internal transactions")
+		return
+	}
+	for _, transfer := range transfers {
+		for _, entry := range []struct {
+			addr common.Address
+			tt   TxType
+		}{{transfer.From, txSent}, {transfer.To, txReceived}} {
+			if entry.addr == (common.Address{}) {
+				continue
+			}
+			addr := ethToOneAddress(entry.addr)
+			_ = writeAddressEntry(btc, addr)
+			_ = writeInternalTxnIndex(btc, internalTxnIndex{
+				addr:        addr,
+				blockNumber: b.NumberU64(),
+				txnIndex:    transfer.TxIndex,
+				txnHash:     transfer.TxHash,
+			}, entry.tt)
+		}
+	}
+}
+
 func ethToOneAddress(ethAddr common.Address) oneAddress {
 	raw, _ := common2.AddressToBech32(ethAddr)
 	return oneAddress(raw)
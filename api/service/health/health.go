@@ -0,0 +1,179 @@
+package health
+
+import (
+	"fmt"
+	"time"
+)
+
+// Level is the health level of a node or of one of its checks
+type Level string
+
+// Constants for Level, from the best to the worst
+const (
+	Ready     Level = "ready"
+	Degraded  Level = "degraded"
+	Unhealthy Level = "unhealthy"
+)
+
+func (l Level) rank() int {
+	switch l {
+	case Ready:
+		return 0
+	case Degraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// worse returns the worse of the two levels
+func worse(a, b Level) Level {
+	if b.rank() > a.rank() {
+		return b
+	}
+	return a
+}
+
+// Thresholds maps the measured values to the health levels. A value beyond the
+// degraded threshold makes the node degraded, beyond the unhealthy threshold
+// unhealthy. A zero threshold disables the corresponding level.
+type Thresholds struct {
+	// SyncLag is the number of blocks behind the sync target
+	DegradedSyncLag, UnhealthySyncLag uint64
+	// BlockAge is the time since the timestamp of the head block
+	DegradedBlockAge, UnhealthyBlockAge time.Duration
+	// Peers is the minimum number of connected peers
+	DegradedPeers, UnhealthyPeers int
+	// TxPoolSize is the number of pending and queued transactions
+	DegradedTxPoolSize, UnhealthyTxPoolSize int
+	// TraceIndexLag is the number of blocks the trace index is behind
+	DegradedTraceIndexLag, UnhealthyTraceIndexLag uint64
+	// DiskFree is the minimum number of bytes available on the data disk
+	DegradedDiskFree, UnhealthyDiskFree uint64
+}
+
+// ShardStatus is the chain status of a shard followed by the node
+type ShardStatus struct {
+	ShardID   uint32
+	InSync    bool
+	Target    uint64 // block number of the sync target, 0 if unknown
+	Lag       uint64 // number of blocks behind the sync target
+	Head      uint64
+	BlockTime time.Time // timestamp of the head block
+}
+
+// Status is the measured status of a node
+type Status struct {
+	Shards        []ShardStatus
+	Peers         int
+	TxPoolPending int
+	TxPoolQueued  int
+	// TraceIndexLag is nil if the trace index is disabled
+	TraceIndexLag *uint64
+	// DiskFree and DiskTotal are zero if the disk usage is unknown
+	DiskFree  uint64
+	DiskTotal uint64
+}
+
+// Check is the result of a single health check
+type Check struct {
+	Name    string      `json:"name"`
+	Status  Level       `json:"status"`
+	Value   interface{} `json:"value"`
+	Message string      `json:"message,omitempty"`
+}
+
+// Report is the health report of a node
+type Report struct {
+	Status Level   `json:"status"`
+	Time   int64   `json:"time"`
+	Checks []Check `json:"checks"`
+}
+
+// Evaluate checks the status against the thresholds at the given time
+func (t Thresholds) Evaluate(status Status, now time.Time) Report {
+	var checks []Check
+	for _, shard := range status.Shards {
+		check := Check{
+			Name:  fmt.Sprintf("sync_lag/shard_%d", shard.ShardID),
+			Value: shard.Lag,
+		}
+		if !shard.InSync && shard.Target == 0 {
+			check.Status = Degraded
+			check.Message = "sync target unknown"
+		} else {
+			check.Status = levelAbove(shard.Lag, t.DegradedSyncLag, t.UnhealthySyncLag)
+		}
+		checks = append(checks, check)
+
+		age := now.Sub(shard.BlockTime)
+		if age < 0 {
+			age = 0
+		}
+		checks = append(checks, Check{
+			Name:    fmt.Sprintf("block_age/shard_%d", shard.ShardID),
+			Status:  levelAbove(uint64(age), uint64(t.DegradedBlockAge), uint64(t.UnhealthyBlockAge)),
+			Value:   int64(age / time.Second),
+			Message: fmt.Sprintf("head block %d", shard.Head),
+		})
+	}
+	checks = append(checks, Check{
+		Name:   "peers",
+		Status: levelBelow(uint64(status.Peers), uint64(t.DegradedPeers), uint64(t.UnhealthyPeers)),
+		Value:  status.Peers,
+	})
+	txPoolSize := status.TxPoolPending + status.TxPoolQueued
+	checks = append(checks, Check{
+		Name:    "txpool_size",
+		Status:  levelAbove(uint64(txPoolSize), uint64(t.DegradedTxPoolSize), uint64(t.UnhealthyTxPoolSize)),
+		Value:   txPoolSize,
+		Message: fmt.Sprintf("%d pending, %d queued", status.TxPoolPending, status.TxPoolQueued),
+	})
+	if status.TraceIndexLag != nil {
+		checks = append(checks, Check{
+			Name:   "trace_index_lag",
+			Status: levelAbove(*status.TraceIndexLag, t.DegradedTraceIndexLag, t.UnhealthyTraceIndexLag),
+			Value:  *status.TraceIndexLag,
+		})
+	}
+	if status.DiskTotal != 0 {
+		checks = append(checks, Check{
+			Name:    "disk_free",
+			Status:  levelBelow(status.DiskFree, t.DegradedDiskFree, t.UnhealthyDiskFree),
+			Value:   status.DiskFree,
+			Message: fmt.Sprintf("%d of %d bytes available", status.DiskFree, status.DiskTotal),
+		})
+	}
+
+	report := Report{
+		Status: Ready,
+		Time:   now.Unix(),
+		Checks: checks,
+	}
+	for _, check := range checks {
+		report.Status = worse(report.Status, check.Status)
+	}
+	return report
+}
+
+// levelAbove returns the level of a value which is worse when higher
+func levelAbove(value, degraded, unhealthy uint64) Level {
+	switch {
+	case unhealthy != 0 && value > unhealthy:
+		return Unhealthy
+	case degraded != 0 && value > degraded:
+		return Degraded
+	}
+	return Ready
+}
+
+// levelBelow returns the level of a value which is worse when lower
+func levelBelow(value, degraded, unhealthy uint64) Level {
+	switch {
+	case unhealthy != 0 && value < unhealthy:
+		return Unhealthy
+	case degraded != 0 && value < degraded:
+		return Degraded
+	}
+	return Ready
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"testing"
+	"time"
+)
+
+var testThresholds = Thresholds{
+	DegradedSyncLag:        10,
+	UnhealthySyncLag:       100,
+	DegradedBlockAge:       30 * time.Second,
+	UnhealthyBlockAge:      2 * time.Minute,
+	DegradedPeers:          4,
+	UnhealthyPeers:         1,
+	DegradedTraceIndexLag:  8192,
+	UnhealthyTraceIndexLag: 40960,
+	DegradedDiskFree:       20 << 30,
+	UnhealthyDiskFree:      5 << 30,
+}
+
+func TestThresholdsEvaluate(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	makeStatus := func(update func(*Status)) Status {
+		status := Status{
+			Shards: []ShardStatus{
+				{ShardID: 1, InSync: true, Target: 1000, Head: 1000, BlockTime: now.Add(-2 * time.Second)},
+				{ShardID: 0, InSync: true, Target: 2000, Head: 2000, BlockTime: now.Add(-2 * time.Second)},
+			},
+			Peers:     20,
+			DiskFree:  100 << 30,
+			DiskTotal: 200 << 30,
+		}
+		if update != nil {
+			update(&status)
+		}
+		return status
+	}
+	lag := func(n uint64) *uint64 { return &n }
+
+	tests := []struct {
+		status    Status
+		expStatus Level
+		expCheck  string
+	}{
+		{makeStatus(nil), Ready, ""},
+		{makeStatus(func(s *Status) { s.Shards[1].InSync, s.Shards[1].Lag = false, 50 }), Degraded, "sync_lag/shard_0"},
+		{makeStatus(func(s *Status) { s.Shards[0].InSync, s.Shards[0].Lag = false, 500 }), Unhealthy, "sync_lag/shard_1"},
+		{makeStatus(func(s *Status) { s.Shards[0].InSync, s.Shards[0].Target = false, 0 }), Degraded, "sync_lag/shard_1"},
+		{makeStatus(func(s *Status) { s.Shards[0].BlockTime = now.Add(-time.Minute) }), Degraded, "block_age/shard_1"},
+		{makeStatus(func(s *Status) { s.Shards[1].BlockTime = now.Add(-time.Hour) }), Unhealthy, "block_age/shard_0"},
+		{makeStatus(func(s *Status) { s.Peers = 2 }), Degraded, "peers"},
+		{makeStatus(func(s *Status) { s.Peers = 0 }), Unhealthy, "peers"},
+		{makeStatus(func(s *Status) { s.TxPoolPending = 1 << 20 }), Ready, ""},
+		{makeStatus(func(s *Status) { s.TraceIndexLag = lag(4096) }), Ready, ""},
+		{makeStatus(func(s *Status) { s.TraceIndexLag = lag(50000) }), Unhealthy, "trace_index_lag"},
+		{makeStatus(func(s *Status) { s.DiskFree = 10 << 30 }), Degraded, "disk_free"},
+		{makeStatus(func(s *Status) { s.DiskFree = 1 << 30 }), Unhealthy, "disk_free"},
+		{makeStatus(func(s *Status) { s.DiskFree, s.DiskTotal = 0, 0 }), Ready, ""},
+	}
+	for i, test := range tests {
+		report := testThresholds.Evaluate(test.status, now)
+		if report.Status != test.expStatus {
+			t.Errorf("Test %v: unexpected status %v / %v", i, report.Status, test.expStatus)
+		}
+		for _, check := range report.Checks {
+			if check.Name == test.expCheck {
+				if check.Status != test.expStatus {
+					t.Errorf("Test %v: unexpected status of %v: %v / %v", i, check.Name, check.Status, test.expStatus)
+				}
+			} else if check.Status != Ready {
+				t.Errorf("Test %v: unexpected failed check %v: %+v", i, check.Name, check)
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package health defines a service reporting whether a node is ready to serve
+// requests, to be probed by the load balancers in front of the RPC nodes.
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// Config is the config for the health service
+type Config struct {
+	Enabled    bool
+	IP         string
+	Port       int
+	Thresholds Thresholds
+}
+
+func (c Config) String() string {
+	return fmt.Sprintf("%v, %v:%v, %+v", c.Enabled, c.IP, c.Port, c.Thresholds)
+}
+
+// Backend provides the status of the node
+type Backend interface {
+	HealthStatus() Status
+}
+
+// Service serves the health report of the node on /health, answering 503 when the
+// node is unhealthy, and on /ready, answering 503 unless the node is ready.
+type Service struct {
+	config  Config
+	backend Backend
+	server  *http.Server
+}
+
+// NewService creates the health service
+func NewService(cfg Config, backend Backend) *Service {
+	s := &Service{
+		config:  cfg,
+		backend: backend,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.healthHandler)
+	mux.HandleFunc("/ready", s.readyHandler)
+	s.server = &http.Server{
+		Addr:         fmt.Sprintf("%s:%d", cfg.IP, cfg.Port),
+		Handler:      mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	utils.Logger().Debug().Str("cfg", cfg.String()).Msg("health")
+	return s
+}
+
+// Start starts the health service
+func (s *Service) Start() error {
+	go func() {
+		utils.Logger().Info().Str("address", s.server.Addr).Msg("Starting health service")
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			utils.Logger().Error().Err(err).Str("address", s.server.Addr).Msg("health service failed")
+		}
+	}()
+	return nil
+}
+
+// Stop stops the health service
+func (s *Service) Stop() error {
+	return s.server.Close()
+}
+
+// Report returns the current health report of the node
+func (s *Service) Report() Report {
+	return s.config.Thresholds.Evaluate(s.backend.HealthStatus(), time.Now())
+}
+
+func (s *Service) healthHandler(w http.ResponseWriter, r *http.Request) {
+	report := s.Report()
+	code := http.StatusOK
+	if report.Status == Unhealthy {
+		code = http.StatusServiceUnavailable
+	}
+	writeReport(w, code, report)
+}
+
+func (s *Service) readyHandler(w http.ResponseWriter, r *http.Request) {
+	report := s.Report()
+	code := http.StatusOK
+	if report.Status != Ready {
+		code = http.StatusServiceUnavailable
+	}
+	writeReport(w, code, report)
+}
+
+func writeReport(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		utils.Logger().Warn().Err(err).Msg("cannot JSON-encode health report")
+	}
+}
+
+// DiskUsage returns the available and total bytes of the file system of the path
+func DiskUsage(path string) (free, total uint64, err error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	return st.Bavail * uint64(st.Bsize), st.Blocks * uint64(st.Bsize), nil
+}
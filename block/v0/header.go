@@ -406,6 +406,18 @@ type headerMarshaling struct {
 	Hash       common.Hash `json:"hash"` // adds call to Hash() in MarshalJSON
 }
 
+// BaseFee is the EIP-1559 base fee per gas, which V0 headers do not have.
+func (h *Header) BaseFee() *big.Int {
+	return nil
+}
+
+// SetBaseFee sets the EIP-1559 base fee per gas.
+func (h *Header) SetBaseFee(newBaseFee *big.Int) {
+	h.Logger(utils.Logger()).Warn().
+		Str("baseFee", newBaseFee.String()).
+		Msg("cannot store base fee in V0 header")
+}
+
 // Hash returns the block hash of the header, which is simply the keccak256 hash of its
 // RLP encoding.
 func (h *Header) Hash() common.Hash {
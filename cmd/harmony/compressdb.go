@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/cli"
+)
+
+var compressAlgoFlag = cli.StringFlag{
+	Name:     "algo",
+	Usage:    "compression of the rewritten receipts and state diffs (none, snappy)",
+	DefValue: rawdb.CompressionSnappy,
+}
+
+var compressDBCmd = &cobra.Command{
+	Use:   "compress db",
+	Short: "rewrite the receipts and state diffs of a stopped node's db compressed.",
+	Long: "rewrite the block receipts and indexed state diffs of a stopped node's db with the " +
+		"given compression, then compact the db. Values are read whatever compression they " +
+		"were written with, so --db.compression can be changed before or after the rewrite.",
+	Example: "harmony db compress /data/harmony_db_0 --algo snappy",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		algo := cli.GetStringFlagValue(cmd, compressAlgoFlag)
+		if err := compressDBMain(args[0], algo); err != nil {
+			fmt.Println("compress db error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+func compressDBMain(dbDir, algo string) error {
+	db, err := ethRawDB.NewLevelDBDatabase(dbDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	stats, err := rawdb.Recompress(db, algo, func(stats *rawdb.RecompressStats) {
+		fmt.Printf("rewritten %d values, %v -> %v\n", stats.Values, stats.Before, stats.After)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("rewrote %d values from %v to %v in %v, compacting db...\n",
+		stats.Values, stats.Before, stats.After, stats.Elapsed)
+	return db.Compact(nil, nil)
+}
@@ -8,9 +8,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/internal/cli"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/shardchain"
 	"github.com/pelletier/go-toml"
 	"github.com/spf13/cobra"
 )
@@ -51,6 +53,39 @@ func validateHarmonyConfig(config harmonyconfig.HarmonyConfig) error {
 		return fmt.Errorf("flag --run.offline must have p2p IP be %v", nodeconfig.DefaultLocalListenIP)
 	}
 
+	if _, err := time.ParseDuration(config.RPCOpt.TraceTimeout); err != nil {
+		return fmt.Errorf("invalid --rpc.trace.timeout: %v", err)
+	}
+	if config.RPCOpt.TraceIndex && !config.General.IsArchival {
+		return errors.New("flag --rpc.trace.index requires an archival node (--run.archive)")
+	}
+
+	if _, err := time.ParseDuration(config.TxPool.Rejournal); err != nil {
+		return fmt.Errorf("invalid --txpool.rejournal: %v", err)
+	}
+
+	if config.Health != nil {
+		if _, err := time.ParseDuration(config.Health.DegradedBlockAge); err != nil {
+			return fmt.Errorf("invalid Health.DegradedBlockAge: %v", err)
+		}
+		if _, err := time.ParseDuration(config.Health.UnhealthyBlockAge); err != nil {
+			return fmt.Errorf("invalid Health.UnhealthyBlockAge: %v", err)
+		}
+	}
+
+	if config.DB.Engine == "pebble" {
+		return errors.New("flag --db.engine pebble is not supported, the chain databases only run on leveldb")
+	}
+	accepts = []string{shardchain.EngineLevelDB}
+	if err := checkStringAccepted("--db.engine", config.DB.Engine, accepts); err != nil {
+		return err
+	}
+
+	accepts = []string{rawdb.CompressionNone, rawdb.CompressionSnappy}
+	if err := checkStringAccepted("--db.compression", config.DB.Compression, accepts); err != nil {
+		return err
+	}
+
 	if !config.Sync.Downloader && !config.DNSSync.Client {
 		// There is no module up for sync
 		return errors.New("either --sync.downloader or --sync.legacy.client shall be enabled")
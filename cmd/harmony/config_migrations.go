@@ -229,4 +229,224 @@ func init() {
 		confTree.Set("Version", "2.5.1")
 		return confTree
 	}
+
+	migrations["2.5.1"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.TraceTimeout") == nil {
+			confTree.Set("RPCOpt.TraceTimeout", defaultConfig.RPCOpt.TraceTimeout)
+		}
+		if confTree.Get("RPCOpt.MaxConcurrentTraces") == nil {
+			confTree.Set("RPCOpt.MaxConcurrentTraces", defaultConfig.RPCOpt.MaxConcurrentTraces)
+		}
+
+		confTree.Set("Version", "2.5.2")
+		return confTree
+	}
+
+	migrations["2.5.2"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.TraceCacheSize") == nil {
+			confTree.Set("RPCOpt.TraceCacheSize", defaultConfig.RPCOpt.TraceCacheSize)
+		}
+
+		confTree.Set("Version", "2.5.3")
+		return confTree
+	}
+
+	migrations["2.5.3"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("HTTP.GraphQLEnabled") == nil {
+			confTree.Set("HTTP.GraphQLEnabled", defaultConfig.HTTP.GraphQLEnabled)
+		}
+		if confTree.Get("HTTP.GraphQLPort") == nil {
+			confTree.Set("HTTP.GraphQLPort", defaultConfig.HTTP.GraphQLPort)
+		}
+
+		confTree.Set("Version", "2.5.4")
+		return confTree
+	}
+
+	migrations["2.5.4"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.GasCap") == nil {
+			confTree.Set("RPCOpt.GasCap", defaultConfig.RPCOpt.GasCap)
+		}
+		if confTree.Get("RPCOpt.BatchRequestLimit") == nil {
+			confTree.Set("RPCOpt.BatchRequestLimit", defaultConfig.RPCOpt.BatchRequestLimit)
+		}
+
+		confTree.Set("Version", "2.5.5")
+		return confTree
+	}
+
+	migrations["2.5.5"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.LogsBlockRange") == nil {
+			confTree.Set("RPCOpt.LogsBlockRange", defaultConfig.RPCOpt.LogsBlockRange)
+		}
+		if confTree.Get("RPCOpt.LogsResultLimit") == nil {
+			confTree.Set("RPCOpt.LogsResultLimit", defaultConfig.RPCOpt.LogsResultLimit)
+		}
+
+		confTree.Set("Version", "2.5.6")
+		return confTree
+	}
+
+	migrations["2.5.6"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("StatePrune.Enabled") == nil {
+			confTree.Set("StatePrune.Enabled", defaultConfig.StatePrune.Enabled)
+		}
+		if confTree.Get("StatePrune.Retain") == nil {
+			confTree.Set("StatePrune.Retain", defaultConfig.StatePrune.Retain)
+		}
+		if confTree.Get("StatePrune.BloomSize") == nil {
+			confTree.Set("StatePrune.BloomSize", defaultConfig.StatePrune.BloomSize)
+		}
+
+		confTree.Set("Version", "2.5.7")
+		return confTree
+	}
+
+	migrations["2.5.7"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("Snapshot.Enabled") == nil {
+			confTree.Set("Snapshot.Enabled", defaultConfig.Snapshot.Enabled)
+		}
+		if confTree.Get("Snapshot.Cache") == nil {
+			confTree.Set("Snapshot.Cache", defaultConfig.Snapshot.Cache)
+		}
+
+		confTree.Set("Version", "2.5.8")
+		return confTree
+	}
+
+	migrations["2.5.8"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("Freezer.Enabled") == nil {
+			confTree.Set("Freezer.Enabled", defaultConfig.Freezer.Enabled)
+		}
+		if confTree.Get("Freezer.Threshold") == nil {
+			confTree.Set("Freezer.Threshold", defaultConfig.Freezer.Threshold)
+		}
+
+		confTree.Set("Version", "2.5.9")
+		return confTree
+	}
+
+	migrations["2.5.9"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("StateDiff.Enabled") == nil {
+			confTree.Set("StateDiff.Enabled", defaultConfig.StateDiff.Enabled)
+		}
+
+		confTree.Set("Version", "2.5.10")
+		return confTree
+	}
+
+	migrations["2.5.10"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("DB.Engine") == nil {
+			confTree.Set("DB.Engine", defaultConfig.DB.Engine)
+		}
+
+		confTree.Set("Version", "2.5.11")
+		return confTree
+	}
+
+	migrations["2.5.11"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.TraceIndex") == nil {
+			confTree.Set("RPCOpt.TraceIndex", defaultConfig.RPCOpt.TraceIndex)
+		}
+
+		confTree.Set("Version", "2.5.12")
+		return confTree
+	}
+
+	migrations["2.5.12"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("DB.Compression") == nil {
+			confTree.Set("DB.Compression", defaultConfig.DB.Compression)
+		}
+
+		confTree.Set("Version", "2.5.13")
+		return confTree
+	}
+
+	migrations["2.5.13"] = func(confTree *toml.Tree) *toml.Tree {
+		// Consensus is only set for validator nodes
+		if confTree.Get("Consensus") != nil && confTree.Get("Consensus.DeactivateOnDoubleSign") == nil {
+			confTree.Set("Consensus.DeactivateOnDoubleSign", defaultConsensusConfig.DeactivateOnDoubleSign)
+		}
+
+		confTree.Set("Version", "2.5.14")
+		return confTree
+	}
+
+	migrations["2.5.14"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RewardHistory.Enabled") == nil {
+			confTree.Set("RewardHistory.Enabled", defaultConfig.RewardHistory.Enabled)
+		}
+
+		confTree.Set("Version", "2.5.15")
+		return confTree
+	}
+
+	migrations["2.5.15"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("Sync.Staged") == nil {
+			confTree.Set("Sync.Staged", defaultConfig.Sync.Staged)
+		}
+
+		confTree.Set("Version", "2.5.16")
+		return confTree
+	}
+
+	migrations["2.5.16"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("P2P.PeerScoreFile") == nil {
+			confTree.Set("P2P.PeerScoreFile", defaultConfig.P2P.PeerScoreFile)
+		}
+
+		confTree.Set("Version", "2.5.17")
+		return confTree
+	}
+
+	migrations["2.5.17"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("Sync.SnapSync") == nil {
+			confTree.Set("Sync.SnapSync", defaultConfig.Sync.SnapSync)
+		}
+
+		confTree.Set("Version", "2.5.18")
+		return confTree
+	}
+
+	migrations["2.5.18"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("TxPool.Journal") == nil {
+			confTree.Set("TxPool.Journal", defaultConfig.TxPool.Journal)
+		}
+		if confTree.Get("TxPool.Rejournal") == nil {
+			confTree.Set("TxPool.Rejournal", defaultConfig.TxPool.Rejournal)
+		}
+
+		confTree.Set("Version", "2.5.19")
+		return confTree
+	}
+
+	migrations["2.5.19"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("TxPool.PriceBump") == nil {
+			confTree.Set("TxPool.PriceBump", defaultConfig.TxPool.PriceBump)
+		}
+
+		confTree.Set("Version", "2.5.20")
+		return confTree
+	}
+
+	migrations["2.5.20"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.TraceMaxReexec") == nil {
+			confTree.Set("RPCOpt.TraceMaxReexec", defaultConfig.RPCOpt.TraceMaxReexec)
+		}
+
+		confTree.Set("Version", "2.5.21")
+		return confTree
+	}
+
+	migrations["2.5.21"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("HTTP.AdminEnabled") == nil {
+			confTree.Set("HTTP.AdminEnabled", defaultConfig.HTTP.AdminEnabled)
+		}
+		if confTree.Get("HTTP.AdminPort") == nil {
+			confTree.Set("HTTP.AdminPort", defaultConfig.HTTP.AdminPort)
+		}
+
+		confTree.Set("Version", "2.5.22")
+		return confTree
+	}
 }
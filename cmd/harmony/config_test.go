@@ -8,7 +8,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/harmony-one/harmony/internal/cli"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	"github.com/spf13/cobra"
 
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 )
@@ -189,3 +191,74 @@ func TestPersistConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigReloader(t *testing.T) {
+	testDir := filepath.Join(testBaseDir, t.Name())
+	os.RemoveAll(testDir)
+	os.MkdirAll(testDir, 0777)
+	file := filepath.Join(testDir, "test.conf")
+
+	config := makeTestConfig("mainnet", nil)
+	if err := writeHarmonyConfigToFile(config, file); err != nil {
+		t.Fatal(err)
+	}
+	var reload func() (harmonyconfig.HarmonyConfig, error)
+	cmd := makeTestCommand(func(cmd *cobra.Command, args []string) {
+		reload = getConfigReloader(cmd)
+	})
+	if err := cli.RegisterFlags(cmd, getRootFlags()); err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetArgs([]string{"--config", file, "--rpc.gascap", "2000"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if reload == nil {
+		t.Fatal("no reloader for a node started with a config file")
+	}
+
+	config.Log.Verbosity = 4
+	config.RPCOpt.GasCap = 3000
+	if err := writeHarmonyConfigToFile(config, file); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Log.Verbosity != 4 {
+		t.Errorf("unexpected log verbosity %v / %v", reloaded.Log.Verbosity, 4)
+	}
+	// the command line flags still override the config file
+	if reloaded.RPCOpt.GasCap != 2000 {
+		t.Errorf("unexpected gas cap %v / %v", reloaded.RPCOpt.GasCap, 2000)
+	}
+
+	config.RPCOpt.TraceTimeout = "not a duration"
+	if err := writeHarmonyConfigToFile(config, file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reload(); err == nil {
+		t.Errorf("expect error for an invalid config file")
+	}
+}
+
+func TestValidateDBEngine(t *testing.T) {
+	tests := []struct {
+		engine string
+		valid  bool
+	}{
+		{"leveldb", true},
+		{"pebble", false},
+		{"rocksdb", false},
+	}
+	for i, test := range tests {
+		cfg := makeTestConfig(nodeconfig.Mainnet, func(config *harmonyconfig.HarmonyConfig) {
+			config.DB.Engine = test.engine
+		})
+		err := validateHarmonyConfig(cfg)
+		if (err == nil) != test.valid {
+			t.Errorf("Test %v: engine %v, unexpected error %v", i, test.engine, err)
+		}
+	}
+}
@@ -5,7 +5,7 @@ import (
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 )
 
-const tomlConfigVersion = "2.5.1" // bump from 2.5.0 for AccountSlots
+const tomlConfigVersion = "2.5.22" // bump from 2.5.21 for the admin rpc endpoint
 
 const (
 	defNetworkType = nodeconfig.Mainnet
@@ -29,6 +29,7 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		KeyFile:         "./.hmykey",
 		DiscConcurrency: nodeconfig.DefaultP2PConcurrency,
 		MaxConnsPerIP:   nodeconfig.DefaultMaxConnPerIP,
+		PeerScoreFile:   "./.hmypeerscore",
 	},
 	HTTP: harmonyconfig.HttpConfig{
 		Enabled:        true,
@@ -36,7 +37,11 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		IP:             "127.0.0.1",
 		Port:           nodeconfig.DefaultRPCPort,
 		AuthPort:       nodeconfig.DefaultAuthRPCPort,
+		AdminEnabled:   false,
+		AdminPort:      nodeconfig.DefaultAdminRPCPort,
 		RosettaPort:    nodeconfig.DefaultRosettaPort,
+		GraphQLEnabled: false,
+		GraphQLPort:    nodeconfig.DefaultGraphQLPort,
 	},
 	WS: harmonyconfig.WsConfig{
 		Enabled:  true,
@@ -48,6 +53,18 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		DebugEnabled:      false,
 		RateLimterEnabled: true,
 		RequestsPerSecond: nodeconfig.DefaultRPCRateLimit,
+
+		TraceTimeout:        nodeconfig.DefaultTraceTimeout,
+		MaxConcurrentTraces: nodeconfig.DefaultMaxConcurrentTraces,
+		TraceCacheSize:      nodeconfig.DefaultTraceCacheSize,
+		TraceMaxReexec:      nodeconfig.DefaultTraceMaxReexec,
+		TraceIndex:          false,
+
+		GasCap:            nodeconfig.DefaultRPCGasCap,
+		BatchRequestLimit: nodeconfig.DefaultRPCBatchRequestLimit,
+
+		LogsBlockRange:  nodeconfig.DefaultLogsBlockRange,
+		LogsResultLimit: nodeconfig.DefaultLogsResultLimit,
 	},
 	BLSKeys: harmonyconfig.BlsConfig{
 		KeyDir:   "./.hmy/blskeys",
@@ -66,6 +83,9 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		BlacklistFile:  "./.hmy/blacklist.txt",
 		RosettaFixFile: "",
 		AccountSlots:   16,
+		Journal:        "transactions.rlp",
+		Rejournal:      "1h",
+		PriceBump:      0,
 	},
 	Sync: getDefaultSyncConfig(defNetworkType),
 	Pprof: harmonyconfig.PprofConfig{
@@ -95,6 +115,29 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		CacheTime:       10,
 		CacheSize:       512,
 	},
+	StatePrune: harmonyconfig.StatePruneConfig{
+		Enabled:   false,
+		Retain:    128,
+		BloomSize: 512,
+	},
+	Snapshot: harmonyconfig.SnapshotConfig{
+		Enabled: false,
+		Cache:   256,
+	},
+	Freezer: harmonyconfig.FreezerConfig{
+		Enabled:   false,
+		Threshold: 90000,
+	},
+	StateDiff: harmonyconfig.StateDiffConfig{
+		Enabled: false,
+	},
+	RewardHistory: harmonyconfig.RewardHistoryConfig{
+		Enabled: false,
+	},
+	DB: harmonyconfig.DBConfig{
+		Engine:      "leveldb",
+		Compression: "none",
+	},
 }
 
 var defaultSysConfig = harmonyconfig.SysConfig{
@@ -119,8 +162,9 @@ var defaultLogContext = harmonyconfig.LogContext{
 }
 
 var defaultConsensusConfig = harmonyconfig.ConsensusConfig{
-	MinPeers:     6,
-	AggregateSig: true,
+	MinPeers:               6,
+	AggregateSig:           true,
+	DeactivateOnDoubleSign: false,
 }
 
 var defaultPrometheusConfig = harmonyconfig.PrometheusConfig{
@@ -131,6 +175,24 @@ var defaultPrometheusConfig = harmonyconfig.PrometheusConfig{
 	Gateway:    "https://gateway.harmony.one",
 }
 
+var defaultHealthConfig = harmonyconfig.HealthConfig{
+	Enabled:                true,
+	IP:                     "0.0.0.0",
+	Port:                   nodeconfig.DefaultHealthPort,
+	DegradedSyncLag:        10,
+	UnhealthySyncLag:       100,
+	DegradedBlockAge:       "30s",
+	UnhealthyBlockAge:      "2m",
+	DegradedPeers:          4,
+	UnhealthyPeers:         1,
+	DegradedTxPoolSize:     0,
+	UnhealthyTxPoolSize:    0,
+	DegradedTraceIndexLag:  8192,
+	UnhealthyTraceIndexLag: 40960,
+	DegradedDiskFreeMB:     20480,
+	UnhealthyDiskFreeMB:    5120,
+}
+
 var (
 	defaultMainnetSyncConfig = harmonyconfig.SyncConfig{
 		Enabled:        false,
@@ -142,6 +204,8 @@ var (
 		DiscHardLowCap: 6,
 		DiscHighCap:    128,
 		DiscBatch:      8,
+		Staged:         false,
+		SnapSync:       false,
 	}
 
 	defaultTestNetSyncConfig = harmonyconfig.SyncConfig{
@@ -154,6 +218,8 @@ var (
 		DiscHardLowCap: 2,
 		DiscHighCap:    1024,
 		DiscBatch:      3,
+		Staged:         false,
+		SnapSync:       false,
 	}
 
 	defaultLocalNetSyncConfig = harmonyconfig.SyncConfig{
@@ -166,6 +232,8 @@ var (
 		DiscHardLowCap: 2,
 		DiscHighCap:    1024,
 		DiscBatch:      3,
+		Staged:         false,
+		SnapSync:       false,
 	}
 
 	defaultElseSyncConfig = harmonyconfig.SyncConfig{
@@ -178,6 +246,8 @@ var (
 		DiscHardLowCap: 4,
 		DiscHighCap:    1024,
 		DiscBatch:      8,
+		Staged:         false,
+		SnapSync:       false,
 	}
 )
 
@@ -229,6 +299,11 @@ func getDefaultPrometheusConfigCopy() harmonyconfig.PrometheusConfig {
 	return config
 }
 
+func getDefaultHealthConfigCopy() harmonyconfig.HealthConfig {
+	config := defaultHealthConfig
+	return config
+}
+
 const (
 	nodeTypeValidator = "validator"
 	nodeTypeExplorer  = "explorer"
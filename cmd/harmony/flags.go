@@ -48,6 +48,7 @@ var (
 	networkFlags = []cli.Flag{
 		networkTypeFlag,
 		bootNodeFlag,
+		dnsNodeListFlag,
 		legacyNetworkTypeFlag,
 	}
 
@@ -59,6 +60,7 @@ var (
 		p2pDiscoveryConcurrencyFlag,
 		legacyKeyFileFlag,
 		maxConnPerIPFlag,
+		p2pPeerScoreFileFlag,
 	}
 
 	httpFlags = []cli.Flag{
@@ -67,7 +69,11 @@ var (
 		httpIPFlag,
 		httpPortFlag,
 		httpAuthPortFlag,
+		httpAdminEnabledFlag,
+		httpAdminPortFlag,
 		httpRosettaPortFlag,
+		httpGraphQLEnabledFlag,
+		httpGraphQLPortFlag,
 	}
 
 	wsFlags = []cli.Flag{
@@ -81,6 +87,15 @@ var (
 		rpcDebugEnabledFlag,
 		rpcRateLimiterEnabledFlag,
 		rpcRateLimitFlag,
+		rpcTraceTimeoutFlag,
+		rpcMaxConcurrentTracesFlag,
+		rpcTraceCacheSizeFlag,
+		rpcTraceMaxReexecFlag,
+		rpcTraceIndexFlag,
+		rpcGasCapFlag,
+		rpcBatchRequestLimitFlag,
+		rpcLogsBlockRangeFlag,
+		rpcLogsResultLimitFlag,
 	}
 
 	blsFlags = append(newBLSFlags, legacyBLSFlags...)
@@ -113,6 +128,7 @@ var (
 	consensusValidFlags = []cli.Flag{
 		consensusMinPeersFlag,
 		consensusAggregateSigFlag,
+		consensusDeactivateOnDoubleSignFlag,
 		legacyConsensusMinPeersFlag,
 	}
 
@@ -124,6 +140,9 @@ var (
 
 	txPoolFlags = []cli.Flag{
 		tpAccountSlotsFlag,
+		tpJournalFlag,
+		tpRejournalFlag,
+		tpPriceBumpFlag,
 		rosettaFixFileFlag,
 		tpBlacklistFileFlag,
 		legacyTPBlacklistFileFlag,
@@ -203,6 +222,12 @@ var (
 		prometheusEnablePushFlag,
 	}
 
+	healthFlags = []cli.Flag{
+		healthEnabledFlag,
+		healthIPFlag,
+		healthPortFlag,
+	}
+
 	syncFlags = []cli.Flag{
 		syncStreamEnabledFlag,
 		syncDownloaderFlag,
@@ -213,6 +238,8 @@ var (
 		syncDiscHardLowFlag,
 		syncDiscHighFlag,
 		syncDiscBatchFlag,
+		syncStagedFlag,
+		syncSnapFlag,
 	}
 
 	shardDataFlags = []cli.Flag{
@@ -222,6 +249,35 @@ var (
 		cacheTimeFlag,
 		cacheSizeFlag,
 	}
+
+	statePruneFlags = []cli.Flag{
+		statePruneEnabledFlag,
+		statePruneRetainFlag,
+		statePruneBloomSizeFlag,
+	}
+
+	snapshotFlags = []cli.Flag{
+		snapshotEnabledFlag,
+		snapshotCacheFlag,
+	}
+
+	freezerFlags = []cli.Flag{
+		freezerEnabledFlag,
+		freezerThresholdFlag,
+	}
+
+	stateDiffFlags = []cli.Flag{
+		stateDiffEnabledFlag,
+	}
+
+	rewardHistoryFlags = []cli.Flag{
+		rewardHistoryEnabledFlag,
+	}
+
+	dbFlags = []cli.Flag{
+		dbEngineFlag,
+		dbCompressionFlag,
+	}
 )
 
 var (
@@ -320,8 +376,15 @@ func getRootFlags() []cli.Flag {
 	flags = append(flags, revertFlags...)
 	flags = append(flags, legacyMiscFlags...)
 	flags = append(flags, prometheusFlags...)
+	flags = append(flags, healthFlags...)
 	flags = append(flags, syncFlags...)
 	flags = append(flags, shardDataFlags...)
+	flags = append(flags, statePruneFlags...)
+	flags = append(flags, snapshotFlags...)
+	flags = append(flags, freezerFlags...)
+	flags = append(flags, stateDiffFlags...)
+	flags = append(flags, rewardHistoryFlags...)
+	flags = append(flags, dbFlags...)
 
 	return flags
 }
@@ -382,6 +445,10 @@ var (
 		Name:  "bootnodes",
 		Usage: "a list of bootnode multiaddress (delimited by ,)",
 	}
+	dnsNodeListFlag = cli.StringSliceFlag{
+		Name:  "bootnodes.dns",
+		Usage: "a list of EIP-1459 DNS node list URLs enrtree://<key>@<domain> to discover peers (delimited by ,)",
+	}
 	legacyDNSZoneFlag = cli.StringFlag{
 		Name:       "dns_zone",
 		Usage:      "use peers from the zone for state syncing",
@@ -503,6 +570,10 @@ func applyNetworkFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, bootNodeFlag) {
 		cfg.Network.BootNodes = cli.GetStringSliceFlagValue(cmd, bootNodeFlag)
 	}
+
+	if cli.IsFlagChanged(cmd, dnsNodeListFlag) {
+		cfg.Network.DNSNodeLists = cli.GetStringSliceFlagValue(cmd, dnsNodeListFlag)
+	}
 }
 
 // p2p flags
@@ -544,6 +615,11 @@ var (
 		Usage:    "maximum number of connections allowed per node",
 		DefValue: defaultConfig.P2P.MaxConnsPerIP,
 	}
+	p2pPeerScoreFileFlag = cli.StringFlag{
+		Name:     "p2p.security.peer-score-file",
+		Usage:    "the file persisting the peer scores and bans across restarts (empty to disable)",
+		DefValue: defaultConfig.P2P.PeerScoreFile,
+	}
 )
 
 func applyP2PFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -575,6 +651,10 @@ func applyP2PFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, maxConnPerIPFlag) {
 		config.P2P.MaxConnsPerIP = cli.GetIntFlagValue(cmd, maxConnPerIPFlag)
 	}
+
+	if cli.IsFlagChanged(cmd, p2pPeerScoreFileFlag) {
+		config.P2P.PeerScoreFile = cli.GetStringFlagValue(cmd, p2pPeerScoreFileFlag)
+	}
 }
 
 // http flags
@@ -599,6 +679,16 @@ var (
 		Usage:    "rpc port to listen for auth HTTP requests",
 		DefValue: defaultConfig.HTTP.AuthPort,
 	}
+	httpAdminEnabledFlag = cli.BoolFlag{
+		Name:     "http.admin",
+		Usage:    "enable the admin apis changing the node, served on localhost only",
+		DefValue: defaultConfig.HTTP.AdminEnabled,
+	}
+	httpAdminPortFlag = cli.IntFlag{
+		Name:     "http.admin.port",
+		Usage:    "port of the localhost admin endpoint",
+		DefValue: defaultConfig.HTTP.AdminPort,
+	}
 	httpRosettaEnabledFlag = cli.BoolFlag{
 		Name:     "http.rosetta",
 		Usage:    "enable HTTP / Rosetta requests",
@@ -609,10 +699,20 @@ var (
 		Usage:    "rosetta port to listen for HTTP requests",
 		DefValue: defaultConfig.HTTP.RosettaPort,
 	}
+	httpGraphQLEnabledFlag = cli.BoolFlag{
+		Name:     "http.graphql",
+		Usage:    "enable HTTP / GraphQL requests",
+		DefValue: defaultConfig.HTTP.GraphQLEnabled,
+	}
+	httpGraphQLPortFlag = cli.IntFlag{
+		Name:     "http.graphql.port",
+		Usage:    "graphql port to listen for HTTP requests",
+		DefValue: defaultConfig.HTTP.GraphQLPort,
+	}
 )
 
 func applyHTTPFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
-	var isRPCSpecified, isRosettaSpecified bool
+	var isRPCSpecified, isRosettaSpecified, isGraphQLSpecified bool
 
 	if cli.IsFlagChanged(cmd, httpIPFlag) {
 		config.HTTP.IP = cli.GetStringFlagValue(cmd, httpIPFlag)
@@ -629,6 +729,15 @@ func applyHTTPFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		isRPCSpecified = true
 	}
 
+	if cli.IsFlagChanged(cmd, httpAdminPortFlag) {
+		config.HTTP.AdminPort = cli.GetIntFlagValue(cmd, httpAdminPortFlag)
+		config.HTTP.AdminEnabled = true
+	}
+
+	if cli.IsFlagChanged(cmd, httpAdminEnabledFlag) {
+		config.HTTP.AdminEnabled = cli.GetBoolFlagValue(cmd, httpAdminEnabledFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, httpRosettaPortFlag) {
 		config.HTTP.RosettaPort = cli.GetIntFlagValue(cmd, httpRosettaPortFlag)
 		isRosettaSpecified = true
@@ -640,6 +749,17 @@ func applyHTTPFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		config.HTTP.RosettaEnabled = true
 	}
 
+	if cli.IsFlagChanged(cmd, httpGraphQLPortFlag) {
+		config.HTTP.GraphQLPort = cli.GetIntFlagValue(cmd, httpGraphQLPortFlag)
+		isGraphQLSpecified = true
+	}
+
+	if cli.IsFlagChanged(cmd, httpGraphQLEnabledFlag) {
+		config.HTTP.GraphQLEnabled = cli.GetBoolFlagValue(cmd, httpGraphQLEnabledFlag)
+	} else if isGraphQLSpecified {
+		config.HTTP.GraphQLEnabled = true
+	}
+
 	if cli.IsFlagChanged(cmd, httpEnabledFlag) {
 		config.HTTP.Enabled = cli.GetBoolFlagValue(cmd, httpEnabledFlag)
 	} else if isRPCSpecified {
@@ -707,6 +827,60 @@ var (
 		Usage:    "the number of requests per second for RPCs",
 		DefValue: defaultConfig.RPCOpt.RequestsPerSecond,
 	}
+
+	rpcTraceTimeoutFlag = cli.StringFlag{
+		Name:     "rpc.trace.timeout",
+		Usage:    "execution timeout of a single trace request",
+		DefValue: defaultConfig.RPCOpt.TraceTimeout,
+	}
+
+	rpcMaxConcurrentTracesFlag = cli.IntFlag{
+		Name:     "rpc.trace.concurrency",
+		Usage:    "maximum number of trace requests executed at the same time",
+		DefValue: defaultConfig.RPCOpt.MaxConcurrentTraces,
+	}
+
+	rpcTraceCacheSizeFlag = cli.IntFlag{
+		Name:     "rpc.trace.cache",
+		Usage:    "memory budget in MB of the block trace result cache, 0 to disable",
+		DefValue: defaultConfig.RPCOpt.TraceCacheSize,
+	}
+
+	rpcTraceMaxReexecFlag = cli.IntFlag{
+		Name:     "rpc.trace.reexec",
+		Usage:    "maximum number of blocks re-executed by a trace request to regenerate a missing state, 0 for no limit",
+		DefValue: int(defaultConfig.RPCOpt.TraceMaxReexec),
+	}
+
+	rpcTraceIndexFlag = cli.BoolFlag{
+		Name:     "rpc.trace.index",
+		Usage:    "index the trace addresses of every block to speed up trace_filter (archival node only)",
+		DefValue: defaultConfig.RPCOpt.TraceIndex,
+	}
+
+	rpcGasCapFlag = cli.IntFlag{
+		Name:     "rpc.gascap",
+		Usage:    "global gas cap of eth_call and eth_estimateGas, 0 for no cap",
+		DefValue: int(defaultConfig.RPCOpt.GasCap),
+	}
+
+	rpcBatchRequestLimitFlag = cli.IntFlag{
+		Name:     "rpc.batchlimit",
+		Usage:    "maximum number of requests in a JSON-RPC batch, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.BatchRequestLimit,
+	}
+
+	rpcLogsBlockRangeFlag = cli.IntFlag{
+		Name:     "rpc.logs.range",
+		Usage:    "maximum number of blocks spanned by a log query, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.LogsBlockRange,
+	}
+
+	rpcLogsResultLimitFlag = cli.IntFlag{
+		Name:     "rpc.logs.limit",
+		Usage:    "maximum number of logs returned by a log query, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.LogsResultLimit,
+	}
 )
 
 func applyRPCOptFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -719,6 +893,41 @@ func applyRPCOptFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, rpcRateLimitFlag) {
 		config.RPCOpt.RequestsPerSecond = cli.GetIntFlagValue(cmd, rpcRateLimitFlag)
 	}
+	if cli.IsFlagChanged(cmd, rpcTraceTimeoutFlag) {
+		config.RPCOpt.TraceTimeout = cli.GetStringFlagValue(cmd, rpcTraceTimeoutFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcMaxConcurrentTracesFlag) {
+		config.RPCOpt.MaxConcurrentTraces = cli.GetIntFlagValue(cmd, rpcMaxConcurrentTracesFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcTraceCacheSizeFlag) {
+		config.RPCOpt.TraceCacheSize = cli.GetIntFlagValue(cmd, rpcTraceCacheSizeFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcTraceMaxReexecFlag) {
+		value := cli.GetIntFlagValue(cmd, rpcTraceMaxReexecFlag)
+		if value < 0 {
+			panic("Must provide non-negative for rpc.trace.reexec")
+		}
+		config.RPCOpt.TraceMaxReexec = uint64(value)
+	}
+	if cli.IsFlagChanged(cmd, rpcTraceIndexFlag) {
+		config.RPCOpt.TraceIndex = cli.GetBoolFlagValue(cmd, rpcTraceIndexFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcGasCapFlag) {
+		value := cli.GetIntFlagValue(cmd, rpcGasCapFlag) // int, so fits in uint64 when positive
+		if value < 0 {
+			panic("Must provide non-negative for rpc.gascap")
+		}
+		config.RPCOpt.GasCap = uint64(value)
+	}
+	if cli.IsFlagChanged(cmd, rpcBatchRequestLimitFlag) {
+		config.RPCOpt.BatchRequestLimit = cli.GetIntFlagValue(cmd, rpcBatchRequestLimitFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcLogsBlockRangeFlag) {
+		config.RPCOpt.LogsBlockRange = cli.GetIntFlagValue(cmd, rpcLogsBlockRangeFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcLogsResultLimitFlag) {
+		config.RPCOpt.LogsResultLimit = cli.GetIntFlagValue(cmd, rpcLogsResultLimitFlag)
+	}
 
 }
 
@@ -949,6 +1158,11 @@ var (
 		Usage:    "(multi-key) aggregate bls signatures before sending",
 		DefValue: defaultConsensusConfig.AggregateSig,
 	}
+	consensusDeactivateOnDoubleSignFlag = cli.BoolFlag{
+		Name:     "consensus.deactivate-on-double-sign",
+		Usage:    "stop signing with the bls keys seen signing conflicting blocks",
+		DefValue: defaultConsensusConfig.DeactivateOnDoubleSign,
+	}
 	legacyDelayCommitFlag = cli.StringFlag{
 		Name:       "delay_commit",
 		Usage:      "how long to delay sending commit messages in consensus, ex: 500ms, 1s",
@@ -983,6 +1197,10 @@ func applyConsensusFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig
 	if cli.IsFlagChanged(cmd, consensusAggregateSigFlag) {
 		config.Consensus.AggregateSig = cli.GetBoolFlagValue(cmd, consensusAggregateSigFlag)
 	}
+
+	if cli.IsFlagChanged(cmd, consensusDeactivateOnDoubleSignFlag) {
+		config.Consensus.DeactivateOnDoubleSign = cli.GetBoolFlagValue(cmd, consensusDeactivateOnDoubleSignFlag)
+	}
 }
 
 // transaction pool flags
@@ -997,6 +1215,21 @@ var (
 		Usage:    "file of blacklisted wallet addresses",
 		DefValue: defaultConfig.TxPool.BlacklistFile,
 	}
+	tpJournalFlag = cli.StringFlag{
+		Name:     "txpool.journal",
+		Usage:    "journal of the transactions submitted to the node, replayed on restart, relative to the data dir (empty to disable)",
+		DefValue: defaultConfig.TxPool.Journal,
+	}
+	tpRejournalFlag = cli.StringFlag{
+		Name:     "txpool.rejournal",
+		Usage:    "interval to regenerate the transaction journal, e.g. 1h",
+		DefValue: defaultConfig.TxPool.Rejournal,
+	}
+	tpPriceBumpFlag = cli.IntFlag{
+		Name:     "txpool.pricebump",
+		Usage:    "minimum gas price bump in percent to replace a transaction of the same nonce (0 for the network default)",
+		DefValue: int(defaultConfig.TxPool.PriceBump),
+	}
 	rosettaFixFileFlag = cli.StringFlag{
 		Name:     "txpool.rosettafixfile",
 		Usage:    "file of rosetta fix file",
@@ -1021,6 +1254,19 @@ func applyTxPoolFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		}
 		config.TxPool.AccountSlots = uint64(cli.GetIntFlagValue(cmd, tpAccountSlotsFlag))
 	}
+	if cli.IsFlagChanged(cmd, tpJournalFlag) {
+		config.TxPool.Journal = cli.GetStringFlagValue(cmd, tpJournalFlag)
+	}
+	if cli.IsFlagChanged(cmd, tpRejournalFlag) {
+		config.TxPool.Rejournal = cli.GetStringFlagValue(cmd, tpRejournalFlag)
+	}
+	if cli.IsFlagChanged(cmd, tpPriceBumpFlag) {
+		value := cli.GetIntFlagValue(cmd, tpPriceBumpFlag)
+		if value < 0 {
+			panic("Must provide non-negative for txpool.pricebump")
+		}
+		config.TxPool.PriceBump = uint64(value)
+	}
 	if cli.IsFlagChanged(cmd, tpBlacklistFileFlag) {
 		config.TxPool.BlacklistFile = cli.GetStringFlagValue(cmd, tpBlacklistFileFlag)
 	} else if cli.IsFlagChanged(cmd, legacyTPBlacklistFileFlag) {
@@ -1419,7 +1665,9 @@ func applyLegacyMiscFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfi
 		config.P2P.Port = legacyPort
 		config.HTTP.Port = nodeconfig.GetRPCHTTPPortFromBase(legacyPort)
 		config.HTTP.AuthPort = nodeconfig.GetRPCAuthHTTPPortFromBase(legacyPort)
+		config.HTTP.AdminPort = nodeconfig.GetRPCAdminHTTPPortFromBase(legacyPort)
 		config.HTTP.RosettaPort = nodeconfig.GetRosettaHTTPPortFromBase(legacyPort)
+		config.HTTP.GraphQLPort = nodeconfig.GetGraphQLHTTPPortFromBase(legacyPort)
 		config.WS.Port = nodeconfig.GetWSPortFromBase(legacyPort)
 		config.WS.AuthPort = nodeconfig.GetWSAuthPortFromBase(legacyPort)
 
@@ -1529,6 +1777,46 @@ func applyPrometheusFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfi
 	}
 }
 
+var (
+	healthEnabledFlag = cli.BoolFlag{
+		Name:     "health",
+		Usage:    "enable the HTTP health endpoint",
+		DefValue: false,
+	}
+	healthIPFlag = cli.StringFlag{
+		Name:     "health.ip",
+		Usage:    "ip address to listen for health requests",
+		DefValue: defaultHealthConfig.IP,
+	}
+	healthPortFlag = cli.IntFlag{
+		Name:     "health.port",
+		Usage:    "health port to listen for HTTP requests",
+		DefValue: defaultHealthConfig.Port,
+	}
+)
+
+// applyHealthFlags only creates the health config if a health flag is set, the
+// thresholds are set in the config file
+func applyHealthFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if config.Health == nil {
+		if !cli.HasFlagsChanged(cmd, healthFlags) {
+			return
+		}
+		cfg := getDefaultHealthConfigCopy()
+		config.Health = &cfg
+	}
+
+	if cli.IsFlagChanged(cmd, healthIPFlag) {
+		config.Health.IP = cli.GetStringFlagValue(cmd, healthIPFlag)
+	}
+	if cli.IsFlagChanged(cmd, healthPortFlag) {
+		config.Health.Port = cli.GetIntFlagValue(cmd, healthPortFlag)
+	}
+	if cli.IsFlagChanged(cmd, healthEnabledFlag) {
+		config.Health.Enabled = cli.GetBoolFlagValue(cmd, healthEnabledFlag)
+	}
+}
+
 var (
 	syncStreamEnabledFlag = cli.BoolFlag{
 		Name:     "sync",
@@ -1577,6 +1865,18 @@ var (
 		Usage:  "batch size of the sync discovery",
 		Hidden: true,
 	}
+	syncStagedFlag = cli.BoolFlag{
+		Name:     "sync.staged",
+		Usage:    "Do the initial sync in pipelined stages with resumable checkpoints",
+		Hidden:   true,
+		DefValue: false,
+	}
+	syncSnapFlag = cli.BoolFlag{
+		Name:     "sync.snap",
+		Usage:    "Bootstrap a new node by downloading the state of a recent pivot block",
+		Hidden:   true,
+		DefValue: false,
+	}
 )
 
 // applySyncFlags apply the sync flags.
@@ -1616,6 +1916,14 @@ func applySyncFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, syncDiscBatchFlag) {
 		config.Sync.DiscBatch = cli.GetIntFlagValue(cmd, syncDiscBatchFlag)
 	}
+
+	if cli.IsFlagChanged(cmd, syncStagedFlag) {
+		config.Sync.Staged = cli.GetBoolFlagValue(cmd, syncStagedFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, syncSnapFlag) {
+		config.Sync.SnapSync = cli.GetBoolFlagValue(cmd, syncSnapFlag)
+	}
 }
 
 // shard data flags
@@ -1664,3 +1972,149 @@ func applyShardDataFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
 		cfg.ShardData.CacheSize = cli.GetIntFlagValue(cmd, cacheSizeFlag)
 	}
 }
+
+// state prune flags
+var (
+	statePruneEnabledFlag = cli.BoolFlag{
+		Name:     "prune.state",
+		Usage:    "prune the historical states in the background (non archival nodes only)",
+		DefValue: defaultConfig.StatePrune.Enabled,
+	}
+	statePruneRetainFlag = cli.IntFlag{
+		Name:     "prune.retain",
+		Usage:    "number of recent block states kept by the state pruner",
+		DefValue: int(defaultConfig.StatePrune.Retain),
+	}
+	statePruneBloomSizeFlag = cli.IntFlag{
+		Name:     "prune.bloomsize",
+		Usage:    "memory (MB) of the bloom filter marking the states kept by the state pruner",
+		DefValue: int(defaultConfig.StatePrune.BloomSize),
+	}
+)
+
+func applyStatePruneFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, statePruneEnabledFlag) {
+		cfg.StatePrune.Enabled = cli.GetBoolFlagValue(cmd, statePruneEnabledFlag)
+	}
+	if cli.IsFlagChanged(cmd, statePruneRetainFlag) {
+		retain := cli.GetIntFlagValue(cmd, statePruneRetainFlag)
+		if retain <= 0 {
+			panic("Must provide positive for prune.retain")
+		}
+		cfg.StatePrune.Retain = uint64(retain)
+	}
+	if cli.IsFlagChanged(cmd, statePruneBloomSizeFlag) {
+		bloomSize := cli.GetIntFlagValue(cmd, statePruneBloomSizeFlag)
+		if bloomSize <= 0 {
+			panic("Must provide positive for prune.bloomsize")
+		}
+		cfg.StatePrune.BloomSize = uint64(bloomSize)
+	}
+}
+
+// state snapshot flags
+var (
+	snapshotEnabledFlag = cli.BoolFlag{
+		Name:     "snapshot",
+		Usage:    "read the state from a flat snapshot layer, generated in the background",
+		DefValue: defaultConfig.Snapshot.Enabled,
+	}
+	snapshotCacheFlag = cli.IntFlag{
+		Name:     "snapshot.cache",
+		Usage:    "memory (MB) of the state snapshot cache",
+		DefValue: defaultConfig.Snapshot.Cache,
+	}
+)
+
+func applySnapshotFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, snapshotEnabledFlag) {
+		cfg.Snapshot.Enabled = cli.GetBoolFlagValue(cmd, snapshotEnabledFlag)
+	}
+	if cli.IsFlagChanged(cmd, snapshotCacheFlag) {
+		cache := cli.GetIntFlagValue(cmd, snapshotCacheFlag)
+		if cache <= 0 {
+			panic("Must provide positive for snapshot.cache")
+		}
+		cfg.Snapshot.Cache = cache
+	}
+}
+
+// freezer flags
+var (
+	freezerEnabledFlag = cli.BoolFlag{
+		Name:     "freezer",
+		Usage:    "move the old block bodies and receipts out of the database into flat freezer files",
+		DefValue: defaultConfig.Freezer.Enabled,
+	}
+	freezerThresholdFlag = cli.IntFlag{
+		Name:     "freezer.threshold",
+		Usage:    "number of recent blocks kept in the database, older ones are frozen",
+		DefValue: int(defaultConfig.Freezer.Threshold),
+	}
+)
+
+func applyFreezerFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, freezerEnabledFlag) {
+		cfg.Freezer.Enabled = cli.GetBoolFlagValue(cmd, freezerEnabledFlag)
+	}
+	if cli.IsFlagChanged(cmd, freezerThresholdFlag) {
+		threshold := cli.GetIntFlagValue(cmd, freezerThresholdFlag)
+		if threshold <= 0 {
+			panic("Must provide positive for freezer.threshold")
+		}
+		cfg.Freezer.Threshold = uint64(threshold)
+	}
+}
+
+// state diff flags
+var (
+	stateDiffEnabledFlag = cli.BoolFlag{
+		Name:     "statediff",
+		Usage:    "index the state changes of every imported block for the state diff queries",
+		DefValue: defaultConfig.StateDiff.Enabled,
+	}
+)
+
+func applyStateDiffFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, stateDiffEnabledFlag) {
+		cfg.StateDiff.Enabled = cli.GetBoolFlagValue(cmd, stateDiffEnabledFlag)
+	}
+}
+
+// reward history flags
+var (
+	rewardHistoryEnabledFlag = cli.BoolFlag{
+		Name:     "rewardhistory",
+		Usage:    "index the rewards paid to every delegation for the reward history queries",
+		DefValue: defaultConfig.RewardHistory.Enabled,
+	}
+)
+
+func applyRewardHistoryFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, rewardHistoryEnabledFlag) {
+		cfg.RewardHistory.Enabled = cli.GetBoolFlagValue(cmd, rewardHistoryEnabledFlag)
+	}
+}
+
+// db flags
+var (
+	dbEngineFlag = cli.StringFlag{
+		Name:     "db.engine",
+		Usage:    "key-value engine of the chain databases (leveldb)",
+		DefValue: defaultConfig.DB.Engine,
+	}
+	dbCompressionFlag = cli.StringFlag{
+		Name:     "db.compression",
+		Usage:    "compression of the stored receipts and state diffs (none, snappy)",
+		DefValue: defaultConfig.DB.Compression,
+	}
+)
+
+func applyDBFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, dbEngineFlag) {
+		cfg.DB.Engine = cli.GetStringFlagValue(cmd, dbEngineFlag)
+	}
+	if cli.IsFlagChanged(cmd, dbCompressionFlag) {
+		cfg.DB.Compression = cli.GetStringFlagValue(cmd, dbCompressionFlag)
+	}
+}
@@ -63,19 +63,34 @@ func TestHarmonyFlags(t *testing.T) {
 					KeyFile:         defaultConfig.P2P.KeyFile,
 					DiscConcurrency: 5,
 					MaxConnsPerIP:   5,
+					PeerScoreFile:   defaultConfig.P2P.PeerScoreFile,
 				},
 				HTTP: harmonyconfig.HttpConfig{
 					Enabled:        true,
 					IP:             "127.0.0.1",
 					Port:           9500,
 					AuthPort:       9501,
+					AdminPort:      9502,
 					RosettaEnabled: false,
 					RosettaPort:    9700,
+					GraphQLEnabled: false,
+					GraphQLPort:    9600,
 				},
 				RPCOpt: harmonyconfig.RpcOptConfig{
 					DebugEnabled:      false,
 					RateLimterEnabled: true,
 					RequestsPerSecond: 1000,
+
+					TraceTimeout:        "30s",
+					MaxConcurrentTraces: 4,
+					TraceCacheSize:      128,
+					TraceMaxReexec:      1024,
+
+					GasCap:            50000000,
+					BatchRequestLimit: 1000,
+
+					LogsBlockRange:  1024,
+					LogsResultLimit: 10000,
 				},
 				WS: harmonyconfig.WsConfig{
 					Enabled:  true,
@@ -84,8 +99,9 @@ func TestHarmonyFlags(t *testing.T) {
 					AuthPort: 9801,
 				},
 				Consensus: &harmonyconfig.ConsensusConfig{
-					MinPeers:     6,
-					AggregateSig: true,
+					MinPeers:               6,
+					AggregateSig:           true,
+					DeactivateOnDoubleSign: false,
 				},
 				BLSKeys: harmonyconfig.BlsConfig{
 					KeyDir:           "./.hmy/blskeys",
@@ -103,6 +119,9 @@ func TestHarmonyFlags(t *testing.T) {
 					BlacklistFile:  "./.hmy/blacklist.txt",
 					RosettaFixFile: "",
 					AccountSlots:   16,
+					Journal:        "transactions.rlp",
+					Rejournal:      "1h",
+					PriceBump:      0,
 				},
 				Pprof: harmonyconfig.PprofConfig{
 					Enabled:            false,
@@ -148,6 +167,29 @@ func TestHarmonyFlags(t *testing.T) {
 					CacheTime:       10,
 					CacheSize:       512,
 				},
+				StatePrune: harmonyconfig.StatePruneConfig{
+					Enabled:   false,
+					Retain:    128,
+					BloomSize: 512,
+				},
+				Snapshot: harmonyconfig.SnapshotConfig{
+					Enabled: false,
+					Cache:   256,
+				},
+				Freezer: harmonyconfig.FreezerConfig{
+					Enabled:   false,
+					Threshold: 90000,
+				},
+				StateDiff: harmonyconfig.StateDiffConfig{
+					Enabled: false,
+				},
+				RewardHistory: harmonyconfig.RewardHistoryConfig{
+					Enabled: false,
+				},
+				DB: harmonyconfig.DBConfig{
+					Engine:      "leveldb",
+					Compression: "none",
+				},
 			},
 		},
 	}
@@ -327,6 +369,17 @@ func TestNetworkFlags(t *testing.T) {
 				},
 			},
 		},
+		{
+			args: []string{"--bootnodes.dns", "enrtree://AKA3AM6LPBYEUDMVNU3BSVQJ5AD45Y7YPOHJLEF6W26QOE4VTUDPE@nodes.example.org"},
+			expConfig: harmonyconfig.HarmonyConfig{
+				Network: harmonyconfig.NetworkConfig{
+					NetworkType:  defNetworkType,
+					BootNodes:    nodeconfig.GetDefaultBootNodes(defNetworkType),
+					DNSNodeLists: []string{"enrtree://AKA3AM6LPBYEUDMVNU3BSVQJ5AD45Y7YPOHJLEF6W26QOE4VTUDPE@nodes.example.org"},
+				},
+				DNSSync: getDefaultDNSSyncConfig(defNetworkType),
+			},
+		},
 	}
 	for i, test := range tests {
 		neededFlags := make([]cli.Flag, 0)
@@ -380,6 +433,7 @@ func TestP2PFlags(t *testing.T) {
 				KeyFile:       "./key.file",
 				DHTDataStore:  &defDataStore,
 				MaxConnsPerIP: 10,
+				PeerScoreFile: defaultConfig.P2P.PeerScoreFile,
 			},
 		},
 		{
@@ -389,16 +443,19 @@ func TestP2PFlags(t *testing.T) {
 				IP:            nodeconfig.DefaultPublicListenIP,
 				KeyFile:       "./key.file",
 				MaxConnsPerIP: 10,
+				PeerScoreFile: defaultConfig.P2P.PeerScoreFile,
 			},
 		},
 		{
-			args: []string{"--p2p.port", "9001", "--p2p.disc.concurrency", "5", "--p2p.security.max-conn-per-ip", "5"},
+			args: []string{"--p2p.port", "9001", "--p2p.disc.concurrency", "5", "--p2p.security.max-conn-per-ip", "5",
+				"--p2p.security.peer-score-file", "./peers.json"},
 			expConfig: harmonyconfig.P2pConfig{
 				Port:            9001,
 				IP:              nodeconfig.DefaultPublicListenIP,
 				KeyFile:         "./.hmykey",
 				DiscConcurrency: 5,
 				MaxConnsPerIP:   5,
+				PeerScoreFile:   "./peers.json",
 			},
 		},
 	}
@@ -443,7 +500,9 @@ func TestRPCFlags(t *testing.T) {
 				IP:             defaultConfig.HTTP.IP,
 				Port:           defaultConfig.HTTP.Port,
 				AuthPort:       defaultConfig.HTTP.AuthPort,
+				AdminPort:      defaultConfig.HTTP.AdminPort,
 				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				GraphQLPort:    defaultConfig.HTTP.GraphQLPort,
 			},
 		},
 		{
@@ -454,7 +513,9 @@ func TestRPCFlags(t *testing.T) {
 				IP:             "8.8.8.8",
 				Port:           9001,
 				AuthPort:       defaultConfig.HTTP.AuthPort,
+				AdminPort:      defaultConfig.HTTP.AdminPort,
 				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				GraphQLPort:    defaultConfig.HTTP.GraphQLPort,
 			},
 		},
 		{
@@ -465,7 +526,9 @@ func TestRPCFlags(t *testing.T) {
 				IP:             "8.8.8.8",
 				Port:           defaultConfig.HTTP.Port,
 				AuthPort:       9001,
+				AdminPort:      defaultConfig.HTTP.AdminPort,
 				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				GraphQLPort:    defaultConfig.HTTP.GraphQLPort,
 			},
 		},
 		{
@@ -476,7 +539,9 @@ func TestRPCFlags(t *testing.T) {
 				IP:             "8.8.8.8",
 				Port:           9001,
 				AuthPort:       defaultConfig.HTTP.AuthPort,
+				AdminPort:      defaultConfig.HTTP.AdminPort,
 				RosettaPort:    10001,
+				GraphQLPort:    defaultConfig.HTTP.GraphQLPort,
 			},
 		},
 		{
@@ -487,7 +552,9 @@ func TestRPCFlags(t *testing.T) {
 				IP:             "8.8.8.8",
 				Port:           defaultConfig.HTTP.Port,
 				AuthPort:       defaultConfig.HTTP.AuthPort,
+				AdminPort:      defaultConfig.HTTP.AdminPort,
 				RosettaPort:    10001,
+				GraphQLPort:    defaultConfig.HTTP.GraphQLPort,
 			},
 		},
 		{
@@ -498,7 +565,51 @@ func TestRPCFlags(t *testing.T) {
 				IP:             nodeconfig.DefaultPublicListenIP,
 				Port:           9501,
 				AuthPort:       9502,
+				AdminPort:      9503,
 				RosettaPort:    9701,
+				GraphQLPort:    9601,
+			},
+		},
+		{
+			args: []string{"--http.admin"},
+			expConfig: harmonyconfig.HttpConfig{
+				Enabled:        true,
+				RosettaEnabled: false,
+				AdminEnabled:   true,
+				IP:             defaultConfig.HTTP.IP,
+				Port:           defaultConfig.HTTP.Port,
+				AuthPort:       defaultConfig.HTTP.AuthPort,
+				AdminPort:      defaultConfig.HTTP.AdminPort,
+				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				GraphQLPort:    defaultConfig.HTTP.GraphQLPort,
+			},
+		},
+		{
+			args: []string{"--http.ip", "0.0.0.0", "--http.admin.port", "10003"},
+			expConfig: harmonyconfig.HttpConfig{
+				Enabled:        true,
+				RosettaEnabled: false,
+				AdminEnabled:   true,
+				IP:             "0.0.0.0",
+				Port:           defaultConfig.HTTP.Port,
+				AuthPort:       defaultConfig.HTTP.AuthPort,
+				AdminPort:      10003,
+				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				GraphQLPort:    defaultConfig.HTTP.GraphQLPort,
+			},
+		},
+		{
+			args: []string{"--http.graphql.port", "10002"},
+			expConfig: harmonyconfig.HttpConfig{
+				Enabled:        true,
+				RosettaEnabled: false,
+				GraphQLEnabled: true,
+				IP:             defaultConfig.HTTP.IP,
+				Port:           defaultConfig.HTTP.Port,
+				AuthPort:       defaultConfig.HTTP.AuthPort,
+				AdminPort:      defaultConfig.HTTP.AdminPort,
+				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				GraphQLPort:    10002,
 			},
 		},
 	}
@@ -608,6 +719,17 @@ func TestRPCOptFlags(t *testing.T) {
 				DebugEnabled:      true,
 				RateLimterEnabled: true,
 				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
 			},
 		},
 
@@ -617,6 +739,17 @@ func TestRPCOptFlags(t *testing.T) {
 				DebugEnabled:      false,
 				RateLimterEnabled: true,
 				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
 			},
 		},
 
@@ -626,6 +759,17 @@ func TestRPCOptFlags(t *testing.T) {
 				DebugEnabled:      false,
 				RateLimterEnabled: true,
 				RequestsPerSecond: 2000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
 			},
 		},
 
@@ -635,6 +779,138 @@ func TestRPCOptFlags(t *testing.T) {
 				DebugEnabled:      false,
 				RateLimterEnabled: false,
 				RequestsPerSecond: 2000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
+			},
+		},
+
+		{
+			args: []string{"--rpc.trace.timeout", "1m", "--rpc.trace.concurrency", "8"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:      false,
+				RateLimterEnabled: true,
+				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "1m",
+				MaxConcurrentTraces: 8,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
+			},
+		},
+
+		{
+			args: []string{"--rpc.trace.cache", "0"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:      false,
+				RateLimterEnabled: true,
+				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      0,
+				TraceMaxReexec:      1024,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
+			},
+		},
+
+		{
+			args: []string{"--rpc.trace.reexec", "0"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:      false,
+				RateLimterEnabled: true,
+				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      0,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
+			},
+		},
+
+		{
+			args: []string{"--rpc.trace.index"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:      false,
+				RateLimterEnabled: true,
+				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+				TraceIndex:          true,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
+			},
+		},
+
+		{
+			args: []string{"--rpc.gascap", "0", "--rpc.batchlimit", "50"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:      false,
+				RateLimterEnabled: true,
+				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+
+				GasCap:            0,
+				BatchRequestLimit: 50,
+
+				LogsBlockRange:  1024,
+				LogsResultLimit: 10000,
+			},
+		},
+
+		{
+			args: []string{"--rpc.logs.range", "5000", "--rpc.logs.limit", "0"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:      false,
+				RateLimterEnabled: true,
+				RequestsPerSecond: 1000,
+
+				TraceTimeout:        "30s",
+				MaxConcurrentTraces: 4,
+				TraceCacheSize:      128,
+				TraceMaxReexec:      1024,
+
+				GasCap:            50000000,
+				BatchRequestLimit: 1000,
+
+				LogsBlockRange:  5000,
+				LogsResultLimit: 0,
 			},
 		},
 	}
@@ -757,6 +1033,14 @@ func TestConsensusFlags(t *testing.T) {
 				AggregateSig: true,
 			},
 		},
+		{
+			args: []string{"--consensus.deactivate-on-double-sign"},
+			expConfig: &harmonyconfig.ConsensusConfig{
+				MinPeers:               6,
+				AggregateSig:           true,
+				DeactivateOnDoubleSign: true,
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, consensusFlags, applyConsensusFlags)
@@ -789,6 +1073,9 @@ func TestTxPoolFlags(t *testing.T) {
 				BlacklistFile:  defaultConfig.TxPool.BlacklistFile,
 				RosettaFixFile: defaultConfig.TxPool.RosettaFixFile,
 				AccountSlots:   defaultConfig.TxPool.AccountSlots,
+				Journal:        defaultConfig.TxPool.Journal,
+				Rejournal:      defaultConfig.TxPool.Rejournal,
+				PriceBump:      defaultConfig.TxPool.PriceBump,
 			},
 		},
 		{
@@ -797,6 +1084,9 @@ func TestTxPoolFlags(t *testing.T) {
 				BlacklistFile:  "blacklist.file",
 				RosettaFixFile: "rosettafix.file",
 				AccountSlots:   16, // default
+				Journal:        defaultConfig.TxPool.Journal,
+				Rejournal:      defaultConfig.TxPool.Rejournal,
+				PriceBump:      defaultConfig.TxPool.PriceBump,
 			},
 		},
 		{
@@ -805,6 +1095,9 @@ func TestTxPoolFlags(t *testing.T) {
 				BlacklistFile:  "blacklist.file",
 				RosettaFixFile: "rosettafix.file",
 				AccountSlots:   16, // default
+				Journal:        defaultConfig.TxPool.Journal,
+				Rejournal:      defaultConfig.TxPool.Rejournal,
+				PriceBump:      defaultConfig.TxPool.PriceBump,
 			},
 		},
 		{
@@ -813,6 +1106,20 @@ func TestTxPoolFlags(t *testing.T) {
 				AccountSlots:   5,
 				BlacklistFile:  "blacklist.file",
 				RosettaFixFile: "rosettafix.file",
+				Journal:        defaultConfig.TxPool.Journal,
+				Rejournal:      defaultConfig.TxPool.Rejournal,
+				PriceBump:      defaultConfig.TxPool.PriceBump,
+			},
+		},
+		{
+			args: []string{"--txpool.journal", "", "--txpool.rejournal", "10m", "--txpool.pricebump", "25"},
+			expConfig: harmonyconfig.TxPoolConfig{
+				BlacklistFile:  defaultConfig.TxPool.BlacklistFile,
+				RosettaFixFile: defaultConfig.TxPool.RosettaFixFile,
+				AccountSlots:   defaultConfig.TxPool.AccountSlots,
+				Journal:        "",
+				Rejournal:      "10m",
+				PriceBump:      25,
 			},
 		},
 	}
@@ -1149,6 +1456,53 @@ func TestRevertFlags(t *testing.T) {
 	}
 }
 
+func TestHealthFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig *harmonyconfig.HealthConfig
+	}{
+		{
+			args:      []string{},
+			expConfig: nil,
+		},
+		{
+			args: []string{"--health"},
+			expConfig: func() *harmonyconfig.HealthConfig {
+				cfg := getDefaultHealthConfigCopy()
+				return &cfg
+			}(),
+		},
+		{
+			args: []string{"--health.ip", "127.0.0.1", "--health.port", "9951"},
+			expConfig: func() *harmonyconfig.HealthConfig {
+				cfg := getDefaultHealthConfigCopy()
+				cfg.IP = "127.0.0.1"
+				cfg.Port = 9951
+				return &cfg
+			}(),
+		},
+		{
+			args: []string{"--health=false"},
+			expConfig: func() *harmonyconfig.HealthConfig {
+				cfg := getDefaultHealthConfigCopy()
+				cfg.Enabled = false
+				return &cfg
+			}(),
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, healthFlags, applyHealthFlags)
+		hc, err := ts.run(test.args)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if !reflect.DeepEqual(hc.Health, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Health, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
 func TestDNSSyncFlags(t *testing.T) {
 	tests := []struct {
 		args      []string
@@ -1214,7 +1568,7 @@ func TestSyncFlags(t *testing.T) {
 			args: []string{"--sync", "--sync.downloader", "--sync.concurrency", "10", "--sync.min-peers", "10",
 				"--sync.init-peers", "10", "--sync.disc.soft-low-cap", "10",
 				"--sync.disc.hard-low-cap", "10", "--sync.disc.hi-cap", "10",
-				"--sync.disc.batch", "10",
+				"--sync.disc.batch", "10", "--sync.staged", "--sync.snap",
 			},
 			network: "mainnet",
 			expConfig: func() harmonyconfig.SyncConfig {
@@ -1228,6 +1582,8 @@ func TestSyncFlags(t *testing.T) {
 				cfgSync.DiscHardLowCap = 10
 				cfgSync.DiscHighCap = 10
 				cfgSync.DiscBatch = 10
+				cfgSync.Staged = true
+				cfgSync.SnapSync = true
 				return cfgSync
 			}(),
 		},
@@ -1298,6 +1654,228 @@ func TestShardDataFlags(t *testing.T) {
 	}
 }
 
+func TestStatePruneFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.StatePruneConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.StatePrune,
+		},
+		{
+			args: []string{"--prune.state",
+				"--prune.retain", "1024",
+				"--prune.bloomsize", "2048",
+			},
+			expConfig: harmonyconfig.StatePruneConfig{
+				Enabled:   true,
+				Retain:    1024,
+				BloomSize: 2048,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, statePruneFlags, applyStatePruneFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.StatePrune, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.StatePrune, test.expConfig)
+		}
+
+		ts.tearDown()
+	}
+}
+
+func TestSnapshotFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.SnapshotConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.Snapshot,
+		},
+		{
+			args: []string{"--snapshot", "--snapshot.cache", "1024"},
+			expConfig: harmonyconfig.SnapshotConfig{
+				Enabled: true,
+				Cache:   1024,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, snapshotFlags, applySnapshotFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.Snapshot, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Snapshot, test.expConfig)
+		}
+
+		ts.tearDown()
+	}
+}
+
+func TestFreezerFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.FreezerConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.Freezer,
+		},
+		{
+			args: []string{"--freezer", "--freezer.threshold", "1000000"},
+			expConfig: harmonyconfig.FreezerConfig{
+				Enabled:   true,
+				Threshold: 1000000,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, freezerFlags, applyFreezerFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.Freezer, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Freezer, test.expConfig)
+		}
+
+		ts.tearDown()
+	}
+}
+
+func TestStateDiffFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.StateDiffConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.StateDiff,
+		},
+		{
+			args: []string{"--statediff"},
+			expConfig: harmonyconfig.StateDiffConfig{
+				Enabled: true,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, stateDiffFlags, applyStateDiffFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.StateDiff, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.StateDiff, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
+func TestRewardHistoryFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.RewardHistoryConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.RewardHistory,
+		},
+		{
+			args: []string{"--rewardhistory"},
+			expConfig: harmonyconfig.RewardHistoryConfig{
+				Enabled: true,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, rewardHistoryFlags, applyRewardHistoryFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.RewardHistory, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.RewardHistory, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
+func TestDBFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.DBConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultConfig.DB,
+		},
+		{
+			args: []string{"--db.engine", "leveldb"},
+			expConfig: harmonyconfig.DBConfig{
+				Engine:      "leveldb",
+				Compression: "none",
+			},
+		},
+		{
+			args: []string{"--db.compression", "snappy"},
+			expConfig: harmonyconfig.DBConfig{
+				Engine:      "leveldb",
+				Compression: "snappy",
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, dbFlags, applyDBFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.DB, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.DB, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
 type flagTestSuite struct {
 	t *testing.T
 
@@ -28,6 +28,7 @@ import (
 	"github.com/harmony-one/bls/ffi/go/bls"
 
 	"github.com/harmony-one/harmony/api/service"
+	"github.com/harmony-one/harmony/api/service/health"
 	"github.com/harmony-one/harmony/api/service/pprof"
 	"github.com/harmony-one/harmony/api/service/prometheus"
 	"github.com/harmony-one/harmony/api/service/synchronize"
@@ -36,6 +37,7 @@ import (
 	"github.com/harmony-one/harmony/consensus"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/hmy/downloader"
 	"github.com/harmony-one/harmony/internal/cli"
 	"github.com/harmony-one/harmony/internal/common"
@@ -102,6 +104,9 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(dumpConfigLegacyCmd)
 	rootCmd.AddCommand(dumpDBCmd)
+	rootCmd.AddCommand(pruneStateCmd)
+	rootCmd.AddCommand(dbCmd)
+	rootCmd.AddCommand(traceCmd)
 
 	if err := registerRootCmdFlags(); err != nil {
 		os.Exit(2)
@@ -112,6 +117,15 @@ func init() {
 	if err := registerDumpDBFlags(); err != nil {
 		os.Exit(2)
 	}
+	if err := registerPruneStateFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerDBFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerTraceFlags(); err != nil {
+		os.Exit(2)
+	}
 }
 
 func main() {
@@ -141,7 +155,7 @@ func runHarmonyNode(cmd *cobra.Command, args []string) {
 	}
 
 	setupNodeLog(cfg)
-	setupNodeAndRun(cfg)
+	setupNodeAndRun(cfg, getConfigReloader(cmd))
 }
 
 func prepareRootCmd(cmd *cobra.Command) error {
@@ -214,6 +228,28 @@ func getHarmonyConfig(cmd *cobra.Command) (harmonyconfig.HarmonyConfig, error) {
 	return config, nil
 }
 
+// getConfigReloader returns the function loading the config file again, with the
+// command line flags applied on top of it. Nil is returned if the node is not
+// started with a config file.
+func getConfigReloader(cmd *cobra.Command) func() (harmonyconfig.HarmonyConfig, error) {
+	if !cli.IsFlagChanged(cmd, configFlag) {
+		return nil
+	}
+	configFile := cli.GetStringFlagValue(cmd, configFlag)
+	return func() (harmonyconfig.HarmonyConfig, error) {
+		config, _, err := loadHarmonyConfig(configFile)
+		if err != nil {
+			return harmonyconfig.HarmonyConfig{}, err
+		}
+		applyRootFlags(cmd, &config)
+		if err := validateHarmonyConfig(config); err != nil {
+			return harmonyconfig.HarmonyConfig{}, err
+		}
+		sanityFixHarmonyConfig(&config)
+		return config, nil
+	}
+}
+
 func applyRootFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	// Misc flags shall be applied first since legacy ip / port is overwritten
 	// by new ip / port flags
@@ -234,8 +270,15 @@ func applyRootFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	applyDevnetFlags(cmd, config)
 	applyRevertFlags(cmd, config)
 	applyPrometheusFlags(cmd, config)
+	applyHealthFlags(cmd, config)
 	applySyncFlags(cmd, config)
 	applyShardDataFlags(cmd, config)
+	applyStatePruneFlags(cmd, config)
+	applySnapshotFlags(cmd, config)
+	applyFreezerFlags(cmd, config)
+	applyStateDiffFlags(cmd, config)
+	applyRewardHistoryFlags(cmd, config)
+	applyDBFlags(cmd, config)
 }
 
 func setupNodeLog(config harmonyconfig.HarmonyConfig) {
@@ -254,7 +297,7 @@ func setupNodeLog(config harmonyconfig.HarmonyConfig) {
 	}
 }
 
-func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
+func setupNodeAndRun(hc harmonyconfig.HarmonyConfig, reloadConfig func() (harmonyconfig.HarmonyConfig, error)) {
 	var err error
 
 	nodeconfigSetShardSchedule(hc)
@@ -324,6 +367,8 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 		HTTPIp:             hc.HTTP.IP,
 		HTTPPort:           hc.HTTP.Port,
 		HTTPAuthPort:       hc.HTTP.AuthPort,
+		AdminEnabled:       hc.HTTP.AdminEnabled,
+		AdminPort:          hc.HTTP.AdminPort,
 		WSEnabled:          hc.WS.Enabled,
 		WSIp:               hc.WS.IP,
 		WSPort:             hc.WS.Port,
@@ -331,7 +376,21 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 		DebugEnabled:       hc.RPCOpt.DebugEnabled,
 		RateLimiterEnabled: hc.RPCOpt.RateLimterEnabled,
 		RequestsPerSecond:  hc.RPCOpt.RequestsPerSecond,
+
+		MaxConcurrentTraces: hc.RPCOpt.MaxConcurrentTraces,
+		TraceCacheSize:      hc.RPCOpt.TraceCacheSize,
+		TraceMaxReexec:      hc.RPCOpt.TraceMaxReexec,
+		TraceIndex:          hc.RPCOpt.TraceIndex,
+
+		GasCap:            hc.RPCOpt.GasCap,
+		BatchRequestLimit: hc.RPCOpt.BatchRequestLimit,
+		MethodRateLimits:  hc.RPCOpt.MethodRateLimits,
+
+		LogsBlockRange:  hc.RPCOpt.LogsBlockRange,
+		LogsResultLimit: hc.RPCOpt.LogsResultLimit,
 	}
+	// TraceTimeout is already validated in validateHarmonyConfig
+	nodeConfig.RPCServer.TraceTimeout, _ = time.ParseDuration(hc.RPCOpt.TraceTimeout)
 
 	// Parse rosetta config
 	nodeConfig.RosettaServer = nodeconfig.RosettaServerConfig{
@@ -340,6 +399,13 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 		HTTPPort:    hc.HTTP.RosettaPort,
 	}
 
+	// Parse graphql config
+	nodeConfig.GraphQLServer = nodeconfig.GraphQLServerConfig{
+		HTTPEnabled: hc.HTTP.GraphQLEnabled,
+		HTTPIp:      hc.HTTP.IP,
+		HTTPPort:    hc.HTTP.GraphQLPort,
+	}
+
 	if hc.Revert != nil && hc.Revert.RevertBefore != 0 && hc.Revert.RevertTo != 0 {
 		chain := currentNode.Blockchain()
 		if hc.Revert.RevertBeacon {
@@ -410,6 +476,9 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 	if hc.Prometheus.Enabled {
 		setupPrometheusService(currentNode, hc, nodeConfig.ShardID)
 	}
+	if hc.Health != nil && hc.Health.Enabled {
+		setupHealthService(currentNode, *hc.Health)
+	}
 
 	if hc.DNSSync.Server && !hc.General.IsOffline {
 		utils.Logger().Info().Msg("support gRPC sync server")
@@ -437,7 +506,16 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 			Msg("Start Rosetta failed")
 	}
 
+	if err := currentNode.StartGraphQL(); err != nil {
+		utils.Logger().Warn().
+			Err(err).
+			Msg("Start GraphQL failed")
+	}
+
 	go listenOSSigAndShutDown(currentNode)
+	if reloadConfig != nil {
+		go listenOSSigAndReload(currentNode, reloadConfig)
+	}
 
 	if !hc.General.IsOffline {
 		if err := myHost.Start(); err != nil {
@@ -597,9 +675,11 @@ func createGlobalConfig(hc harmonyconfig.HarmonyConfig) (*nodeconfig.ConfigType,
 		Self:            &selfPeer,
 		BLSKey:          nodeConfig.P2PPriKey,
 		BootNodes:       hc.Network.BootNodes,
+		DNSNodeLists:    hc.Network.DNSNodeLists,
 		DataStoreFile:   hc.P2P.DHTDataStore,
 		DiscConcurrency: hc.P2P.DiscConcurrency,
 		MaxConnPerIP:    hc.P2P.MaxConnsPerIP,
+		PeerScoreFile:   hc.P2P.PeerScoreFile,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot create P2P network host")
@@ -643,16 +723,19 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 
 	// Parse minPeers from harmonyconfig.HarmonyConfig
 	var minPeers int
-	var aggregateSig bool
+	var aggregateSig, deactivateOnDoubleSign bool
 	if hc.Consensus != nil {
 		minPeers = hc.Consensus.MinPeers
 		aggregateSig = hc.Consensus.AggregateSig
+		deactivateOnDoubleSign = hc.Consensus.DeactivateOnDoubleSign
 	} else {
 		minPeers = defaultConsensusConfig.MinPeers
 		aggregateSig = defaultConsensusConfig.AggregateSig
+		deactivateOnDoubleSign = defaultConsensusConfig.DeactivateOnDoubleSign
 	}
 	currentConsensus.MinPeers = minPeers
 	currentConsensus.AggregateSig = aggregateSig
+	currentConsensus.SetDoubleSignDeactivation(deactivateOnDoubleSign)
 
 	blacklist, err := setupBlacklist(hc)
 	if err != nil {
@@ -660,17 +743,29 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 	}
 
 	// Current node.
+	if err := rawdb.SetCompression(hc.DB.Compression); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error :%v \n", err)
+		os.Exit(1)
+	}
 	var chainDBFactory shardchain.DBFactory
+	var freezerThreshold uint64
+	if hc.Freezer.Enabled {
+		freezerThreshold = hc.Freezer.Threshold
+	}
 	if hc.ShardData.EnableShardData {
 		chainDBFactory = &shardchain.LDBShardFactory{
-			RootDir:    nodeConfig.DBDir,
-			DiskCount:  hc.ShardData.DiskCount,
-			ShardCount: hc.ShardData.ShardCount,
-			CacheTime:  hc.ShardData.CacheTime,
-			CacheSize:  hc.ShardData.CacheSize,
+			RootDir:          nodeConfig.DBDir,
+			DiskCount:        hc.ShardData.DiskCount,
+			ShardCount:       hc.ShardData.ShardCount,
+			CacheTime:        hc.ShardData.CacheTime,
+			CacheSize:        hc.ShardData.CacheSize,
+			FreezerThreshold: freezerThreshold,
 		}
 	} else {
-		chainDBFactory = &shardchain.LDBFactory{RootDir: nodeConfig.DBDir}
+		chainDBFactory = &shardchain.LDBFactory{
+			RootDir:          nodeConfig.DBDir,
+			FreezerThreshold: freezerThreshold,
+		}
 	}
 
 	currentNode := node.New(myHost, currentConsensus, chainDBFactory, blacklist, nodeConfig.ArchiveModes(), &hc)
@@ -781,6 +876,32 @@ func setupPrometheusService(node *node.Node, hc harmonyconfig.HarmonyConfig, sid
 	node.RegisterService(service.Prometheus, p)
 }
 
+func setupHealthService(node *node.Node, hc harmonyconfig.HealthConfig) {
+	// the durations are checked by validateHarmonyConfig
+	degradedBlockAge, _ := time.ParseDuration(hc.DegradedBlockAge)
+	unhealthyBlockAge, _ := time.ParseDuration(hc.UnhealthyBlockAge)
+	healthConfig := health.Config{
+		Enabled: hc.Enabled,
+		IP:      hc.IP,
+		Port:    hc.Port,
+		Thresholds: health.Thresholds{
+			DegradedSyncLag:        hc.DegradedSyncLag,
+			UnhealthySyncLag:       hc.UnhealthySyncLag,
+			DegradedBlockAge:       degradedBlockAge,
+			UnhealthyBlockAge:      unhealthyBlockAge,
+			DegradedPeers:          hc.DegradedPeers,
+			UnhealthyPeers:         hc.UnhealthyPeers,
+			DegradedTxPoolSize:     hc.DegradedTxPoolSize,
+			UnhealthyTxPoolSize:    hc.UnhealthyTxPoolSize,
+			DegradedTraceIndexLag:  hc.DegradedTraceIndexLag,
+			UnhealthyTraceIndexLag: hc.UnhealthyTraceIndexLag,
+			DegradedDiskFree:       hc.DegradedDiskFreeMB * MB,
+			UnhealthyDiskFree:      hc.UnhealthyDiskFreeMB * MB,
+		},
+	}
+	node.RegisterService(service.Health, health.NewService(healthConfig, node))
+}
+
 func setupSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyConfig) {
 	blockchains := []*core.BlockChain{node.Blockchain()}
 	if !node.IsRunningBeaconChain() {
@@ -793,6 +914,8 @@ func setupSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyCo
 		Concurrency:  hc.Sync.Concurrency,
 		MinStreams:   hc.Sync.MinPeers,
 		InitStreams:  hc.Sync.InitStreams,
+		Staged:       hc.Sync.Staged,
+		SnapSync:     hc.Sync.SnapSync,
 		SmSoftLowCap: hc.Sync.DiscSoftLowCap,
 		SmHardLowCap: hc.Sync.DiscHardLowCap,
 		SmHiCap:      hc.Sync.DiscHighCap,
@@ -838,6 +961,26 @@ func setupBlacklist(hc harmonyconfig.HarmonyConfig) (map[ethCommon.Address]struc
 	return addrMap, nil
 }
 
+// listenOSSigAndReload reloads the config file on SIGHUP. Only the runtime settings
+// are applied, the other changes of the file need a restart.
+func listenOSSigAndReload(node *node.Node, reloadConfig func() (harmonyconfig.HarmonyConfig, error)) {
+	osSignal := make(chan os.Signal, 1)
+	signal.Notify(osSignal, syscall.SIGHUP)
+	for range osSignal {
+		utils.Logger().Info().Msg("Got SIGHUP signal, reloading the runtime config")
+		config, err := reloadConfig()
+		if err != nil {
+			utils.Logger().Warn().Err(err).Msg("Failed to reload the config file")
+			continue
+		}
+		if err := node.SetRuntimeConfig(config.RuntimeConfig()); err != nil {
+			utils.Logger().Warn().Err(err).Msg("Failed to apply the runtime config")
+			continue
+		}
+		utils.Logger().Info().Msg("Runtime config reloaded, other settings require a restart")
+	}
+}
+
 func listenOSSigAndShutDown(node *node.Node) {
 	// Prepare for graceful shutdown from os signals
 	osSignal := make(chan os.Signal)
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state/pruner"
+	"github.com/harmony-one/harmony/internal/cli"
+)
+
+var pruneRetainFlag = cli.IntFlag{
+	Name:     "retain",
+	Usage:    "number of recent block states kept",
+	DefValue: int(defaultConfig.StatePrune.Retain),
+}
+
+var pruneBloomSizeFlag = cli.IntFlag{
+	Name:     "bloomsize",
+	Usage:    "memory (MB) of the bloom filter marking the kept states",
+	DefValue: int(defaultConfig.StatePrune.BloomSize),
+}
+
+var pruneStateCmd = &cobra.Command{
+	Use:   "prunestate db",
+	Short: "prune the historical states of a stopped node's db.",
+	Long: "prune the historical states of a stopped node's db, keeping the states of the " +
+		"most recent blocks, starting from the newest one available on disk.",
+	Example: "harmony prunestate /data/harmony_db_0 --retain 128",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		retain := cli.GetIntFlagValue(cmd, pruneRetainFlag)
+		bloomSize := cli.GetIntFlagValue(cmd, pruneBloomSizeFlag)
+		if retain <= 0 || bloomSize <= 0 {
+			fmt.Println("retain and bloomsize must be positive")
+			os.Exit(128)
+		}
+		if err := pruneStateMain(args[0], uint64(retain), uint64(bloomSize)); err != nil {
+			fmt.Println("prune state error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+func registerPruneStateFlags() error {
+	return cli.RegisterFlags(pruneStateCmd, []cli.Flag{pruneRetainFlag, pruneBloomSizeFlag})
+}
+
+func pruneStateMain(dbDir string, retain, bloomSize uint64) error {
+	db, err := ethRawDB.NewLevelDBDatabase(dbDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	roots, err := retainedRoots(db, retain)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("pruning state, keeping %d states from root %s\n", len(roots), roots[0].Hex())
+	stats, err := pruner.NewPruner(db, bloomSize).Prune(trie.NewDatabase(db), roots)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("pruned %d nodes (%v) in %v, compacting db...\n", stats.Nodes, stats.Size, stats.Elapsed)
+	return db.Compact(nil, nil)
+}
+
+// retainedRoots returns the state roots of the retain most recent canonical blocks,
+// starting from the newest block whose state is on disk.
+func retainedRoots(db ethdb.Database, retain uint64) ([]common.Hash, error) {
+	headHash := rawdb.ReadHeadBlockHash(db)
+	if headHash == (common.Hash{}) {
+		return nil, fmt.Errorf("empty head block hash")
+	}
+	headNumber := rawdb.ReadHeaderNumber(db, headHash)
+	if headNumber == nil {
+		return nil, fmt.Errorf("head block %s not found", headHash.Hex())
+	}
+	var roots []common.Hash
+	for number := int64(*headNumber); number >= 0 && uint64(len(roots)) < retain; number-- {
+		hash := rawdb.ReadCanonicalHash(db, uint64(number))
+		header := rawdb.ReadHeader(db, hash, uint64(number))
+		if header == nil {
+			return nil, fmt.Errorf("canonical header %d not found", number)
+		}
+		if len(roots) == 0 {
+			if ok, _ := db.Has(header.Root().Bytes()); !ok {
+				continue
+			}
+			fmt.Println("newest block with state:", number, hash.Hex())
+		}
+		roots = append(roots, header.Root())
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no block state found on disk")
+	}
+	return roots, nil
+}
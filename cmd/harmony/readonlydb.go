@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+var errReadOnlyAncient = errors.New("ancient store is read only")
+
+// readOnlyDB keeps the writes to a db in memory, so that the offline tools can open
+// a blockchain on a stopped node's db without changing it. Opening and closing a
+// blockchain writes to its db, e.g. to rewind a head block without state. The
+// writes are visible to the reads of the db, but not to its iterators.
+type readOnlyDB struct {
+	ethdb.Database
+
+	overlay map[string]*[]byte // nil value for a deleted key
+	lock    sync.RWMutex
+}
+
+func newReadOnlyDB(db ethdb.Database) *readOnlyDB {
+	return &readOnlyDB{
+		Database: db,
+		overlay:  make(map[string]*[]byte),
+	}
+}
+
+// Has retrieves if a key is present in the key-value data store.
+func (db *readOnlyDB) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	value, ok := db.overlay[string(key)]
+	db.lock.RUnlock()
+
+	if ok {
+		return value != nil, nil
+	}
+	return db.Database.Has(key)
+}
+
+// Get retrieves the given key if it's present in the key-value data store.
+func (db *readOnlyDB) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	value, ok := db.overlay[string(key)]
+	db.lock.RUnlock()
+
+	if !ok {
+		return db.Database.Get(key)
+	}
+	if value == nil {
+		return nil, errors.New("not found")
+	}
+	return append([]byte{}, *value...), nil
+}
+
+// Put inserts the given value into the memory overlay.
+func (db *readOnlyDB) Put(key []byte, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	v := append([]byte{}, value...)
+	db.overlay[string(key)] = &v
+	return nil
+}
+
+// Delete removes the key from the memory overlay.
+func (db *readOnlyDB) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.overlay[string(key)] = nil
+	return nil
+}
+
+// NewBatch creates a batch writing to the memory overlay.
+func (db *readOnlyDB) NewBatch() ethdb.Batch {
+	return &readOnlyBatch{db: db}
+}
+
+// AppendAncient is not supported, the ancient store is left untouched.
+func (db *readOnlyDB) AppendAncient(number uint64, hash, header, body, receipt, td []byte) error {
+	return errReadOnlyAncient
+}
+
+// TruncateAncients is not supported, the ancient store is left untouched.
+func (db *readOnlyDB) TruncateAncients(n uint64) error {
+	return errReadOnlyAncient
+}
+
+// Sync does nothing, nothing is written to the db.
+func (db *readOnlyDB) Sync() error {
+	return nil
+}
+
+// Compact does nothing, nothing is written to the db.
+func (db *readOnlyDB) Compact(start []byte, limit []byte) error {
+	return nil
+}
+
+// readOnlyBatch is a batch of writes to the memory overlay of a readOnlyDB.
+type readOnlyBatch struct {
+	db     *readOnlyDB
+	writes []readOnlyWrite
+	size   int
+}
+
+type readOnlyWrite struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+func (b *readOnlyBatch) Put(key, value []byte) error {
+	b.writes = append(b.writes, readOnlyWrite{
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+	b.size += len(value)
+	return nil
+}
+
+func (b *readOnlyBatch) Delete(key []byte) error {
+	b.writes = append(b.writes, readOnlyWrite{key: append([]byte{}, key...), delete: true})
+	b.size++
+	return nil
+}
+
+func (b *readOnlyBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *readOnlyBatch) Write() error {
+	return b.Replay(b.db)
+}
+
+func (b *readOnlyBatch) Reset() {
+	b.writes = b.writes[:0]
+	b.size = 0
+}
+
+func (b *readOnlyBatch) Replay(w ethdb.KeyValueWriter) error {
+	for _, write := range b.writes {
+		var err error
+		if write.delete {
+			err = w.Delete(write.key)
+		} else {
+			err = w.Put(write.key, write.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestReadOnlyDB(t *testing.T) {
+	disk := ethRawDB.NewMemoryDatabase()
+	disk.Put([]byte("a"), []byte("disk-a"))
+	disk.Put([]byte("b"), []byte("disk-b"))
+	db := newReadOnlyDB(disk)
+
+	db.Put([]byte("a"), []byte("new-a"))
+	db.Delete([]byte("b"))
+	batch := db.NewBatch()
+	batch.Put([]byte("c"), []byte("new-c"))
+	batch.Delete([]byte("a"))
+	if has, _ := db.Has([]byte("c")); has {
+		t.Errorf("batch visible before written")
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key      string
+		expValue string // empty if not found
+		expDisk  string
+	}{
+		{"a", "", "disk-a"},
+		{"b", "", "disk-b"},
+		{"c", "new-c", ""},
+	}
+	for _, test := range tests {
+		value, err := db.Get([]byte(test.key))
+		if test.expValue == "" {
+			if has, _ := db.Has([]byte(test.key)); err == nil || has {
+				t.Errorf("key %v: expect not found", test.key)
+			}
+		} else if !bytes.Equal(value, []byte(test.expValue)) {
+			t.Errorf("key %v: unexpected value %s / %s", test.key, value, test.expValue)
+		}
+		diskValue, _ := disk.Get([]byte(test.key))
+		if !bytes.Equal(diskValue, []byte(test.expDisk)) {
+			t.Errorf("key %v: unexpected disk value %s / %s", test.key, diskValue, test.expDisk)
+		}
+	}
+}
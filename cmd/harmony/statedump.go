@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state/statedump"
+	"github.com/harmony-one/harmony/internal/cli"
+)
+
+var dumpStateBlockFlag = cli.IntFlag{
+	Name:     "block",
+	Usage:    "number of the block whose state is dumped, the head block if negative",
+	DefValue: -1,
+}
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "offline tools of a stopped node's db.",
+}
+
+var dumpStateCmd = &cobra.Command{
+	Use:   "dump-state db file",
+	Short: "dump the state of a shard at a block to a file.",
+	Long: "dump all the accounts, codes and storage slots of the state of a stopped node's " +
+		"shard at a canonical block to a file, which import-state rebuilds the state from.",
+	Example: "harmony db dump-state /data/harmony_db_0 state.dump --block 1000",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		number := cli.GetIntFlagValue(cmd, dumpStateBlockFlag)
+		if err := dumpStateMain(args[0], args[1], int64(number)); err != nil {
+			fmt.Println("dump state error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+var importStateCmd = &cobra.Command{
+	Use:   "import-state db file",
+	Short: "rebuild a state dumped by dump-state into a db.",
+	Long: "rebuild the state trie dumped by dump-state into a db, checking that the rebuilt " +
+		"state root matches the dumped one. Only the state is written, not the chain.",
+	Example: "harmony db import-state /data/harmony_db_0 state.dump",
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importStateMain(args[0], args[1]); err != nil {
+			fmt.Println("import state error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+func registerDBFlags() error {
+	dbCmd.AddCommand(dumpStateCmd)
+	dbCmd.AddCommand(importStateCmd)
+	dbCmd.AddCommand(verifyDBCmd)
+	dbCmd.AddCommand(compressDBCmd)
+	if err := cli.RegisterFlags(verifyDBCmd, []cli.Flag{verifyBlocksFlag}); err != nil {
+		return err
+	}
+	if err := cli.RegisterFlags(compressDBCmd, []cli.Flag{compressAlgoFlag}); err != nil {
+		return err
+	}
+	return cli.RegisterFlags(dumpStateCmd, []cli.Flag{dumpStateBlockFlag})
+}
+
+func dumpStateMain(dbDir, file string, number int64) error {
+	db, err := ethRawDB.NewLevelDBDatabase(dbDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if number < 0 {
+		headHash := rawdb.ReadHeadBlockHash(db)
+		headNumber := rawdb.ReadHeaderNumber(db, headHash)
+		if headNumber == nil {
+			return fmt.Errorf("head block %s not found", headHash.Hex())
+		}
+		number = int64(*headNumber)
+	}
+	hash := rawdb.ReadCanonicalHash(db, uint64(number))
+	header := rawdb.ReadHeader(db, hash, uint64(number))
+	if header == nil {
+		return fmt.Errorf("canonical header %d not found", number)
+	}
+	triedb := trie.NewDatabase(db)
+	if _, err := triedb.Node(header.Root()); err != nil {
+		return fmt.Errorf("state of block %d not found: %v", number, err)
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	fmt.Printf("dumping state of shard %d block %d root %s\n", header.ShardID(), number, header.Root().Hex())
+	stats, err := statedump.Export(out, triedb, statedump.Header{
+		ShardID: header.ShardID(),
+		Number:  uint64(number),
+		Hash:    hash,
+		Root:    header.Root(),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("dumped %d accounts and %d storage slots in %v\n", stats.Accounts, stats.Slots, stats.Elapsed)
+	return out.Sync()
+}
+
+func importStateMain(dbDir, file string) error {
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	db, err := ethRawDB.NewLevelDBDatabase(dbDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	header, stats, err := statedump.Import(in, trie.NewDatabase(db))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported state of shard %d block %d root %s: %d accounts and %d storage slots in %v\n",
+		header.ShardID, header.Number, header.Root.Hex(), stats.Accounts, stats.Slots, stats.Elapsed)
+	return nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/cli"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/shard"
+)
+
+var (
+	traceBlockFlag = cli.IntFlag{
+		Name:     "block",
+		Usage:    "number of the canonical block traced",
+		DefValue: -1,
+	}
+	traceTxFlag = cli.StringFlag{
+		Name:     "tx",
+		Usage:    "hash of the transaction traced, instead of the whole block",
+		DefValue: "",
+	}
+	traceTracerFlag = cli.StringFlag{
+		Name:     "tracer",
+		Usage:    "tracer name, e.g. callTracer, or file of a JavaScript tracer; the struct logger if empty",
+		DefValue: "",
+	}
+	traceTimeoutFlag = cli.StringFlag{
+		Name:     "timeout",
+		Usage:    "execution timeout of a transaction traced by a JavaScript tracer, e.g. 1m",
+		DefValue: "",
+	}
+	traceReexecFlag = cli.IntFlag{
+		Name:     "reexec",
+		Usage:    "number of blocks re-executed to rebuild a state missing on disk",
+		DefValue: 128,
+	}
+)
+
+var traceCmd = &cobra.Command{
+	Use:   "trace db",
+	Short: "trace a block or a transaction from a stopped node's db.",
+	Long: "trace a block or a transaction from a stopped node's db, printing the traces as " +
+		"JSON like the debug_traceBlockByNumber and debug_traceTransaction RPCs. The db is " +
+		"not modified, the states missing on disk are re-executed in memory.",
+	Example: "harmony trace /data/harmony_db_0 --block 1000 --tracer callTracer",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		number := int64(cli.GetIntFlagValue(cmd, traceBlockFlag))
+		txHash := cli.GetStringFlagValue(cmd, traceTxFlag)
+		if number < 0 && txHash == "" {
+			fmt.Println("one of --block and --tx must be given")
+			os.Exit(128)
+		}
+		config, err := getTraceConfig(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(128)
+		}
+		if err := traceMain(args[0], getNetworkType(cmd), number, txHash, config); err != nil {
+			fmt.Println("trace error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+func registerTraceFlags() error {
+	return cli.RegisterFlags(traceCmd, []cli.Flag{
+		traceBlockFlag, traceTxFlag, traceTracerFlag, traceTimeoutFlag, traceReexecFlag, networkTypeFlag,
+	})
+}
+
+func getTraceConfig(cmd *cobra.Command) (*hmy.TraceConfig, error) {
+	config := &hmy.TraceConfig{}
+	if tracer := cli.GetStringFlagValue(cmd, traceTracerFlag); tracer != "" {
+		if !hmy.IsKnownTracer(tracer) {
+			code, err := ioutil.ReadFile(tracer)
+			if err != nil {
+				return nil, fmt.Errorf("unknown tracer %v, neither a builtin tracer nor a file", tracer)
+			}
+			tracer = string(code)
+		}
+		config.Tracer = &tracer
+	}
+	if timeout := cli.GetStringFlagValue(cmd, traceTimeoutFlag); timeout != "" {
+		config.Timeout = &timeout
+	}
+	reexec := cli.GetIntFlagValue(cmd, traceReexecFlag)
+	if reexec < 0 {
+		return nil, fmt.Errorf("reexec must not be negative")
+	}
+	r := uint64(reexec)
+	config.Reexec = &r
+	return config, nil
+}
+
+func traceMain(dbDir string, nt nodeconfig.NetworkType, number int64, txHash string, config *hmy.TraceConfig) error {
+	schedule := getShardSchedule(nt)
+	if schedule == nil {
+		return fmt.Errorf("unsupported network type %v", nt)
+	}
+	shard.Schedule = schedule
+	nodeconfig.SetNetworkType(nt)
+
+	disk, err := ethRawDB.NewLevelDBDatabase(dbDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+	if err != nil {
+		return err
+	}
+	defer disk.Close()
+	bc, err := openOfflineChain(newReadOnlyDB(disk), nt)
+	if err != nil {
+		return err
+	}
+	defer bc.Stop()
+
+	var (
+		harmony = hmy.NewOffline(bc)
+		ctx     = context.Background()
+		result  interface{}
+	)
+	if txHash != "" {
+		result, err = traceOfflineTx(ctx, harmony, common.HexToHash(txHash), number, config)
+	} else {
+		block := bc.GetBlockByNumber(uint64(number))
+		if block == nil {
+			return fmt.Errorf("canonical block %d not found", number)
+		}
+		result, err = harmony.TraceBlock(ctx, block, config)
+	}
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// openOfflineChain opens the blockchain of the shard of the db
+func openOfflineChain(db ethdb.Database, nt nodeconfig.NetworkType) (*core.BlockChain, error) {
+	headHash := rawdb.ReadHeadBlockHash(db)
+	headNumber := rawdb.ReadHeaderNumber(db, headHash)
+	if headNumber == nil {
+		return nil, fmt.Errorf("head block %s not found", headHash.Hex())
+	}
+	head := rawdb.ReadHeader(db, headHash, *headNumber)
+	if head == nil {
+		return nil, fmt.Errorf("head header %d not found", *headNumber)
+	}
+	chainConfig := nt.ChainConfig()
+	if head.ShardID() == shard.BeaconChainShardID {
+		chainConfig.EthCompatibleChainID = big.NewInt(chainConfig.EthCompatibleShard0ChainID.Int64())
+	}
+	engine := chain.NewEngine()
+	bc, err := core.NewBlockChain(db, nil, &chainConfig, engine, vm.Config{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if head.ShardID() == shard.BeaconChainShardID {
+		engine.SetBeaconchain(bc)
+	}
+	return bc, nil
+}
+
+func traceOfflineTx(ctx context.Context, harmony *hmy.Harmony, hash common.Hash, number int64, config *hmy.TraceConfig) (interface{}, error) {
+	tx, blockHash, blockNumber, index := rawdb.ReadTransaction(harmony.ChainDb(), hash)
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not found", hash)
+	}
+	if number >= 0 && uint64(number) != blockNumber {
+		return nil, fmt.Errorf("transaction %#x is in block %d, not %d", hash, blockNumber, number)
+	}
+	block := harmony.BlockChain.GetBlockByHash(blockHash)
+	if block == nil {
+		return nil, fmt.Errorf("block %#x not found", blockHash)
+	}
+	msg, vmctx, statedb, err := harmony.ComputeTxEnv(block, int(index), *config.Reexec)
+	if err != nil {
+		return nil, err
+	}
+	return harmony.TraceTx(ctx, msg, vmctx, statedb, config)
+}
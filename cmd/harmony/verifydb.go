@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/verify"
+	"github.com/harmony-one/harmony/internal/cli"
+)
+
+var verifyBlocksFlag = cli.IntFlag{
+	Name:     "blocks",
+	Usage:    "number of most recent blocks verified",
+	DefValue: 128,
+}
+
+var verifyDBCmd = &cobra.Command{
+	Use:   "verify db",
+	Short: "verify the recent blocks and state tries of a stopped node's db.",
+	Long: "verify the headers, bodies and receipts of the most recent blocks of a stopped " +
+		"node's db, and walk the state trie of the newest of them whose state is on disk, " +
+		"reporting the missing and corrupt items. Missing trie nodes can be re-fetched from " +
+		"peers with the admin_verifyTries RPC of a running node started with --http.admin.",
+	Example: "harmony db verify /data/harmony_db_0 --blocks 128",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		blocks := cli.GetIntFlagValue(cmd, verifyBlocksFlag)
+		if blocks <= 0 {
+			fmt.Println("blocks must be positive")
+			os.Exit(128)
+		}
+		healthy, err := verifyDBMain(args[0], uint64(blocks))
+		if err != nil {
+			fmt.Println("verify db error:", err)
+			os.Exit(-1)
+		}
+		if !healthy {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	},
+}
+
+func verifyDBMain(dbDir string, blocks uint64) (bool, error) {
+	db, err := ethRawDB.NewLevelDBDatabase(dbDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "")
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	headHash := rawdb.ReadHeadBlockHash(db)
+	headNumber := rawdb.ReadHeaderNumber(db, headHash)
+	if headNumber == nil {
+		return false, fmt.Errorf("head block %s not found", headHash.Hex())
+	}
+	res, err := verify.New(db, trie.NewDatabase(db), nil).Verify(context.Background(), *headNumber, blocks, false)
+	if err != nil {
+		return false, err
+	}
+	fmt.Printf("verified blocks %d to %d, state of block %d (root %s, %d nodes) in %v\n",
+		res.From, res.To, res.StateBlock, res.StateRoot.Hex(), res.Nodes, res.Elapsed)
+	for _, p := range res.Problems {
+		status := "missing"
+		if p.Corrupt {
+			status = "corrupt"
+		}
+		fmt.Printf("block %d: %s %s %s: %s\n", p.Number, status, p.Kind, p.Hash.Hex(), p.Detail)
+	}
+	if !res.Healthy() {
+		fmt.Printf("found %d problems\n", len(res.Problems))
+		return false, nil
+	}
+	fmt.Println("no problem found")
+	return true, nil
+}
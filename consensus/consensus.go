@@ -120,6 +120,10 @@ type Consensus struct {
 	disableViewChange bool
 	// Have a dedicated reader thread pull from this chan, like in node
 	SlashChan chan slash.Record
+	// Double signs of the keys of this node, read by a dedicated thread in node
+	DoubleSignAlertChan chan DoubleSignAlert
+	// signingGuard prevents the keys of this node from double signing
+	signingGuard *signingGuard
 	// How long in second the leader needs to wait to propose a new block.
 	BlockPeriod time.Duration
 	// The time due for next block proposal
@@ -227,6 +231,8 @@ func New(
 	consensus.syncReadyChan = make(chan struct{})
 	consensus.syncNotReadyChan = make(chan struct{})
 	consensus.SlashChan = make(chan slash.Record)
+	consensus.DoubleSignAlertChan = make(chan DoubleSignAlert, doubleSignAlertBuffer)
+	consensus.signingGuard = newSigningGuard()
 	consensus.ReadySignal = make(chan ProposalType)
 	consensus.CommitSigChannel = make(chan []byte)
 	// channel for receiving newly generated VDF
@@ -528,7 +528,11 @@ func (consensus *Consensus) selfCommit(payload []byte) error {
 	consensus.prepareBitmap = mask
 	commitPayload := signature.ConstructCommitPayload(consensus.Blockchain,
 		block.Epoch(), block.Hash(), block.NumberU64(), block.Header().ViewID().Uint64())
-	for i, key := range consensus.priKey {
+	priKeys := consensus.guardSigning(
+		msg_pb.MessageType_COMMIT, block.NumberU64(), block.Header().ViewID().Uint64(),
+		block.Hash(), consensus.ownPriKeys(),
+	)
+	for i, key := range priKeys {
 		if err := consensus.commitBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Error().
 				Err(err).
@@ -92,6 +92,10 @@ func (consensus *Consensus) HandleMessageUpdate(ctx context.Context, msg *msg_pb
 		return errors.Wrapf(err, "unable to parse consensus msg with type: %s", msg.Type)
 	}
 
+	if msg.Type == msg_pb.MessageType_PREPARE || msg.Type == msg_pb.MessageType_COMMIT {
+		consensus.watchOwnKeys(fbftMsg)
+	}
+
 	canHandleViewChange := true
 	intendedForValidator, intendedForLeader :=
 		!consensus.IsLeader(),
@@ -0,0 +1,233 @@
+package consensus
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	"github.com/harmony-one/harmony/consensus/signature"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// signingGuardWindow is the number of blocks below the highest one seen whose
+// signatures are remembered by the signing guard
+const signingGuardWindow = 16
+
+// doubleSignAlertBuffer is the number of alerts queued on DoubleSignAlertChan
+// before new ones are dropped
+const doubleSignAlertBuffer = 64
+
+// DoubleSignAlert is raised when a key run by this node signs two different blocks
+// at the same height and view, whether by this node or another one running the
+// same key, so that operators can react before the double sign is slashed.
+type DoubleSignAlert struct {
+	Phase       string                  `json:"phase"`
+	BlockNum    uint64                  `json:"block-number"`
+	ViewID      uint64                  `json:"view-id"`
+	Key         bls.SerializedPublicKey `json:"bls-public-key"`
+	FirstHash   common.Hash             `json:"first-block-hash"`
+	SecondHash  common.Hash             `json:"second-block-hash"`
+	Deactivated bool                    `json:"deactivated"`
+}
+
+type signingKey struct {
+	phase    msg_pb.MessageType
+	blockNum uint64
+	viewID   uint64
+	key      bls.SerializedPublicKey
+}
+
+// signingGuard remembers the blocks signed by the keys of this node, to refuse
+// signing a block conflicting with a previous signature and to detect the keys
+// running on another node signing conflicting blocks.
+type signingGuard struct {
+	mu          sync.Mutex
+	deactivate  bool
+	highest     uint64
+	signed      map[signingKey]common.Hash
+	deactivated map[bls.SerializedPublicKey]struct{}
+}
+
+func newSigningGuard() *signingGuard {
+	return &signingGuard{
+		signed:      make(map[signingKey]common.Hash),
+		deactivated: make(map[bls.SerializedPublicKey]struct{}),
+	}
+}
+
+// observe records the block as signed by the key, returning an alert if the key
+// already signed another block at the same height and view.
+func (g *signingGuard) observe(
+	phase msg_pb.MessageType, blockNum, viewID uint64, key bls.SerializedPublicKey, hash common.Hash,
+) *DoubleSignAlert {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.observeLocked(signingKey{phase, blockNum, viewID, key}, hash)
+}
+
+func (g *signingGuard) observeLocked(sk signingKey, hash common.Hash) *DoubleSignAlert {
+	if first, ok := g.signed[sk]; ok {
+		if first == hash {
+			return nil
+		}
+		alert := &DoubleSignAlert{
+			Phase:      sk.phase.String(),
+			BlockNum:   sk.blockNum,
+			ViewID:     sk.viewID,
+			Key:        sk.key,
+			FirstHash:  first,
+			SecondHash: hash,
+		}
+		if g.deactivate {
+			g.deactivated[sk.key] = struct{}{}
+			alert.Deactivated = true
+		}
+		return alert
+	}
+	if sk.blockNum+signingGuardWindow < g.highest {
+		return nil
+	}
+	g.signed[sk] = hash
+	if sk.blockNum > g.highest {
+		g.highest = sk.blockNum
+		for k := range g.signed {
+			if k.blockNum+signingGuardWindow < g.highest {
+				delete(g.signed, k)
+			}
+		}
+	}
+	return nil
+}
+
+// allow returns the keys which may sign the block, that is the keys which are not
+// deactivated and have not signed another block at the same height and view, and
+// records the block as signed by them. An alert is returned for each refused key
+// which signed another block.
+func (g *signingGuard) allow(
+	phase msg_pb.MessageType, blockNum, viewID uint64, hash common.Hash, priKeys []*bls.PrivateKeyWrapper,
+) ([]*bls.PrivateKeyWrapper, []*DoubleSignAlert) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	allowed, alerts := make([]*bls.PrivateKeyWrapper, 0, len(priKeys)), []*DoubleSignAlert{}
+	for _, key := range priKeys {
+		if _, ok := g.deactivated[key.Pub.Bytes]; ok {
+			continue
+		}
+		if alert := g.observeLocked(signingKey{phase, blockNum, viewID, key.Pub.Bytes}, hash); alert != nil {
+			alerts = append(alerts, alert)
+			continue
+		}
+		allowed = append(allowed, key)
+	}
+	return allowed, alerts
+}
+
+// SetDoubleSignDeactivation sets whether the keys of this node seen double signing
+// are deactivated, so that the node stops signing with them.
+func (consensus *Consensus) SetDoubleSignDeactivation(deactivate bool) {
+	consensus.signingGuard.mu.Lock()
+	defer consensus.signingGuard.mu.Unlock()
+	consensus.signingGuard.deactivate = deactivate
+}
+
+// guardSigning returns the keys allowed to sign the block, raising an alert for
+// each key refused because it signed another block at the same height and view.
+func (consensus *Consensus) guardSigning(
+	phase msg_pb.MessageType, blockNum, viewID uint64, hash common.Hash, priKeys []*bls.PrivateKeyWrapper,
+) []*bls.PrivateKeyWrapper {
+	allowed, alerts := consensus.signingGuard.allow(phase, blockNum, viewID, hash, priKeys)
+	for _, alert := range alerts {
+		consensus.raiseDoubleSignAlert(alert)
+	}
+	return allowed
+}
+
+// ownPriKeys returns all the keys of this node, which the leader signs its own
+// votes with.
+func (consensus *Consensus) ownPriKeys() []*bls.PrivateKeyWrapper {
+	priKeys := make([]*bls.PrivateKeyWrapper, len(consensus.priKey))
+	for i := range consensus.priKey {
+		priKeys[i] = &consensus.priKey[i]
+	}
+	return priKeys
+}
+
+// watchOwnKeys raises an alert when the prepare or commit message is signed by a
+// key of this node for another block than the one it signed at the same height
+// and view, which happens when the key also runs on another node.
+func (consensus *Consensus) watchOwnKeys(msg *FBFTMessage) {
+	own := []bls.SerializedPublicKey{}
+	for _, sender := range msg.SenderPubkeys {
+		for _, key := range consensus.priKey {
+			if key.Pub.Bytes == sender.Bytes {
+				own = append(own, sender.Bytes)
+			}
+		}
+	}
+	if len(own) == 0 || !consensus.verifyVoteSignature(msg) {
+		return
+	}
+	for _, key := range own {
+		if alert := consensus.signingGuard.observe(
+			msg.MessageType, msg.BlockNum, msg.ViewID, key, msg.BlockHash,
+		); alert != nil {
+			consensus.raiseDoubleSignAlert(alert)
+		}
+	}
+}
+
+// verifyVoteSignature reports whether the signature of the prepare or commit
+// message is valid, so that forged messages do not raise alerts. The block being
+// unknown, the commit payload is built for the current epoch or the next one.
+func (consensus *Consensus) verifyVoteSignature(msg *FBFTMessage) bool {
+	var sign bls_core.Sign
+	if err := sign.Deserialize(msg.Payload); err != nil {
+		return false
+	}
+	signerPubKey := &bls_core.PublicKey{}
+	if msg.HasSingleSender() {
+		signerPubKey = msg.SenderPubkeys[0].Object
+	} else {
+		for _, pubKey := range msg.SenderPubkeys {
+			signerPubKey.Add(pubKey.Object)
+		}
+	}
+	if msg.MessageType == msg_pb.MessageType_PREPARE {
+		return sign.VerifyHash(signerPubKey, msg.BlockHash[:])
+	}
+	epoch := consensus.Blockchain.CurrentHeader().Epoch()
+	for _, e := range []int64{0, 1} {
+		payload := signature.ConstructCommitPayload(consensus.Blockchain,
+			new(big.Int).Add(epoch, big.NewInt(e)), msg.BlockHash, msg.BlockNum, msg.ViewID)
+		if sign.VerifyHash(signerPubKey, payload) {
+			return true
+		}
+	}
+	return false
+}
+
+// raiseDoubleSignAlert logs and counts the alert and notifies it on
+// DoubleSignAlertChan.
+func (consensus *Consensus) raiseDoubleSignAlert(alert *DoubleSignAlert) {
+	consensus.getLogger().Error().
+		Str("phase", alert.Phase).
+		Uint64("blockNum", alert.BlockNum).
+		Uint64("viewID", alert.ViewID).
+		Str("key", alert.Key.Hex()).
+		Str("firstHash", alert.FirstHash.Hex()).
+		Str("secondHash", alert.SecondHash.Hex()).
+		Bool("deactivated", alert.Deactivated).
+		Msg("[DoubleSignGuard] key of this node signed conflicting blocks")
+	consensusDoubleSignCounterVec.With(prometheus.Labels{"phase": alert.Phase}).Inc()
+	select {
+	case consensus.DoubleSignAlertChan <- *alert:
+	default:
+		consensus.getLogger().Warn().
+			Str("key", alert.Key.Hex()).
+			Msg("[DoubleSignGuard] alert channel full, dropping the notification")
+	}
+}
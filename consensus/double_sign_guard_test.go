@@ -0,0 +1,104 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	msg_pb "github.com/harmony-one/harmony/api/proto/message"
+	"github.com/harmony-one/harmony/crypto/bls"
+)
+
+func makeGuardTestKey(b byte) *bls.PrivateKeyWrapper {
+	var key bls.SerializedPublicKey
+	key[0] = b
+	return &bls.PrivateKeyWrapper{Pub: &bls.PublicKeyWrapper{Bytes: key}}
+}
+
+func TestSigningGuardObserve(t *testing.T) {
+	g := newSigningGuard()
+	key := makeGuardTestKey(1).Pub.Bytes
+	commit := msg_pb.MessageType_COMMIT
+
+	if alert := g.observe(commit, 10, 3, key, common.Hash{1}); alert != nil {
+		t.Fatalf("unexpected alert on first signature: %+v", alert)
+	}
+	if alert := g.observe(commit, 10, 3, key, common.Hash{1}); alert != nil {
+		t.Fatalf("unexpected alert on same signature: %+v", alert)
+	}
+	// Other views and phases do not conflict
+	if alert := g.observe(commit, 10, 4, key, common.Hash{2}); alert != nil {
+		t.Fatalf("unexpected alert on another view: %+v", alert)
+	}
+	if alert := g.observe(msg_pb.MessageType_PREPARE, 10, 3, key, common.Hash{2}); alert != nil {
+		t.Fatalf("unexpected alert on another phase: %+v", alert)
+	}
+	alert := g.observe(commit, 10, 3, key, common.Hash{2})
+	if alert == nil {
+		t.Fatal("double sign not detected")
+	}
+	if alert.FirstHash != (common.Hash{1}) || alert.SecondHash != (common.Hash{2}) ||
+		alert.BlockNum != 10 || alert.ViewID != 3 || alert.Key != key || alert.Deactivated {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+
+	// Old signatures are forgotten
+	g.observe(commit, 10+signingGuardWindow+1, 20, key, common.Hash{3})
+	if alert := g.observe(commit, 10, 3, key, common.Hash{2}); alert != nil {
+		t.Errorf("unexpected alert on forgotten signature: %+v", alert)
+	}
+}
+
+func TestSigningGuardAllow(t *testing.T) {
+	g := newSigningGuard()
+	g.deactivate = true
+	key1, key2 := makeGuardTestKey(1), makeGuardTestKey(2)
+	prepare := msg_pb.MessageType_PREPARE
+
+	// Another node running key1 signed a block first
+	g.observe(prepare, 5, 1, key1.Pub.Bytes, common.Hash{1})
+
+	allowed, alerts := g.allow(prepare, 5, 1, common.Hash{1}, []*bls.PrivateKeyWrapper{key1, key2})
+	if len(allowed) != 2 || len(alerts) != 0 {
+		t.Fatalf("unexpected allowed keys %d or alerts %d", len(allowed), len(alerts))
+	}
+	allowed, alerts = g.allow(prepare, 5, 1, common.Hash{2}, []*bls.PrivateKeyWrapper{key1, key2})
+	if len(allowed) != 0 || len(alerts) != 2 {
+		t.Fatalf("unexpected allowed keys %d or alerts %d", len(allowed), len(alerts))
+	}
+	if !alerts[0].Deactivated {
+		t.Errorf("key not deactivated")
+	}
+	// Deactivated keys never sign again
+	allowed, alerts = g.allow(prepare, 6, 1, common.Hash{3}, []*bls.PrivateKeyWrapper{key1, key2, makeGuardTestKey(3)})
+	if len(allowed) != 1 || allowed[0].Pub.Bytes != makeGuardTestKey(3).Pub.Bytes || len(alerts) != 0 {
+		t.Errorf("unexpected allowed keys %d or alerts %d", len(allowed), len(alerts))
+	}
+}
+
+func TestRaiseDoubleSignAlertNonBlocking(t *testing.T) {
+	consensus := &Consensus{DoubleSignAlertChan: make(chan DoubleSignAlert, 1)}
+	first := &DoubleSignAlert{BlockNum: 1, FirstHash: common.Hash{1}, SecondHash: common.Hash{2}}
+	second := &DoubleSignAlert{BlockNum: 2, FirstHash: common.Hash{3}, SecondHash: common.Hash{4}}
+
+	// Alerts raised while nobody reads the full channel are dropped
+	done := make(chan struct{})
+	go func() {
+		consensus.raiseDoubleSignAlert(first)
+		consensus.raiseDoubleSignAlert(second)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("raising an alert blocked on the full channel")
+	}
+	if alert := <-consensus.DoubleSignAlertChan; alert != *first {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+	select {
+	case alert := <-consensus.DoubleSignAlertChan:
+		t.Errorf("unexpected alert not dropped: %+v", alert)
+	default:
+	}
+}
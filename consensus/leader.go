@@ -57,7 +57,11 @@ func (consensus *Consensus) announce(block *types.Block) {
 	consensus.FBFTLog.AddBlock(block)
 
 	// Leader sign the block hash itself
-	for i, key := range consensus.priKey {
+	priKeys := consensus.guardSigning(
+		msg_pb.MessageType_PREPARE, block.NumberU64(), block.Header().ViewID().Uint64(),
+		blockHash, consensus.ownPriKeys(),
+	)
+	for i, key := range priKeys {
 		if err := consensus.prepareBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Warn().Err(err).Msgf(
 				"[Announce] Leader prepareBitmap SetKey failed for key at index %d", i,
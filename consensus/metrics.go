@@ -82,6 +82,20 @@ var (
 		},
 	)
 
+	// consensusDoubleSignCounterVec is used to keep track of the double signs of
+	// the keys of this node
+	consensusDoubleSignCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "consensus",
+			Name:      "double_sign",
+			Help:      "counter of conflicting signatures of the keys of this node",
+		},
+		[]string{
+			"phase",
+		},
+	)
+
 	onceMetrics sync.Once
 
 	// TODO: add last consensus timestamp, add view ID
@@ -122,6 +136,7 @@ func initMetrics() {
 			consensusGaugeVec,
 			consensusPubkeyVec,
 			consensusFinalityHistogram,
+			consensusDoubleSignCounterVec,
 		)
 	})
 }
@@ -51,7 +51,11 @@ func (consensus *Consensus) didReachPrepareQuorum() error {
 
 	// so by this point, everyone has committed to the blockhash of this block
 	// in prepare and so this is the actual block.
-	for i, key := range consensus.priKey {
+	priKeys := consensus.guardSigning(
+		msg_pb.MessageType_COMMIT, blockObj.NumberU64(), blockObj.Header().ViewID().Uint64(),
+		blockObj.Hash(), consensus.ownPriKeys(),
+	)
+	for i, key := range priKeys {
 		if err := consensus.commitBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Warn().Msgf("[OnPrepare] Leader commit bitmap set failed for key at index %d", i)
 			continue
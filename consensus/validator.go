@@ -144,7 +144,10 @@ func (consensus *Consensus) prepare() {
 		return
 	}
 
-	priKeys := consensus.getPriKeysInCommittee()
+	priKeys := consensus.guardSigning(
+		msg_pb.MessageType_PREPARE, consensus.blockNum, consensus.GetCurBlockViewID(),
+		consensus.blockHash, consensus.getPriKeysInCommittee(),
+	)
 
 	p2pMsgs := consensus.constructP2pMessages(msg_pb.MessageType_PREPARE, nil, priKeys)
 
@@ -163,7 +166,10 @@ func (consensus *Consensus) sendCommitMessages(blockObj *types.Block) {
 		return
 	}
 
-	priKeys := consensus.getPriKeysInCommittee()
+	priKeys := consensus.guardSigning(
+		msg_pb.MessageType_COMMIT, blockObj.NumberU64(), blockObj.Header().ViewID().Uint64(),
+		blockObj.Hash(), consensus.getPriKeysInCommittee(),
+	)
 
 	// Sign commit signature on the received block and construct the p2p messages
 	commitPayload := signature.ConstructCommitPayload(consensus.Blockchain,
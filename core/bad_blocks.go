@@ -0,0 +1,79 @@
+package core
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// BadBlock is a block that failed validation, along with the reject reason and
+// the state root computed locally when the block was fully processed.
+type BadBlock struct {
+	Block     *types.Block
+	Reason    error
+	LocalRoot common.Hash // zero if processing aborted before the state root was computed
+	Time      time.Time   // time the block was rejected
+}
+
+// MarshalJSON ..
+func (b BadBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Block     *block.Header `json:"header"`
+		Reason    string        `json:"error-cause"`
+		LocalRoot common.Hash   `json:"local-root"`
+		Time      time.Time     `json:"rejected-at"`
+	}{
+		b.Block.Header(),
+		b.Reason.Error(),
+		b.LocalRoot,
+		b.Time,
+	})
+}
+
+// badBlockRing is a fixed size ring buffer of the most recently rejected blocks.
+// A block rejected again replaces its previous entry.
+type badBlockRing struct {
+	mu     sync.RWMutex
+	blocks []BadBlock
+	next   int
+}
+
+func newBadBlockRing(size int) *badBlockRing {
+	return &badBlockRing{blocks: make([]BadBlock, 0, size)}
+}
+
+// add records a bad block, evicting the oldest entry when the ring is full.
+func (r *badBlockRing) add(bad BadBlock) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash := bad.Block.Hash()
+	for i := range r.blocks {
+		if r.blocks[i].Block.Hash() == hash {
+			r.blocks[i] = bad
+			return
+		}
+	}
+	if len(r.blocks) < cap(r.blocks) {
+		r.blocks = append(r.blocks, bad)
+		return
+	}
+	r.blocks[r.next] = bad
+	r.next = (r.next + 1) % len(r.blocks)
+}
+
+// list returns the recorded bad blocks, the most recent first.
+func (r *badBlockRing) list() []BadBlock {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	blocks := make([]BadBlock, 0, len(r.blocks))
+	for i := len(r.blocks) - 1; i >= 0; i-- {
+		blocks = append(blocks, r.blocks[(r.next+i)%len(r.blocks)])
+	}
+	return blocks
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func TestBadBlockRing(t *testing.T) {
+	newBad := func(n int64) BadBlock {
+		header := blockfactory.NewTestHeader().With().Number(big.NewInt(n)).Header()
+		return BadBlock{Block: types.NewBlockWithHeader(header), Reason: errors.New("bad")}
+	}
+	numbers := func(blocks []BadBlock) []uint64 {
+		var nums []uint64
+		for _, bad := range blocks {
+			nums = append(nums, bad.Block.NumberU64())
+		}
+		return nums
+	}
+	ring := newBadBlockRing(3)
+	if len(ring.list()) != 0 {
+		t.Fatalf("expected empty ring")
+	}
+	for n := int64(1); n <= 5; n++ {
+		ring.add(newBad(n))
+	}
+	if have, want := numbers(ring.list()), []uint64{5, 4, 3}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("ring content mismatch: have %v, want %v", have, want)
+	}
+	// re-reporting a block replaces its entry instead of evicting another one
+	ring.add(newBad(4))
+	if have, want := numbers(ring.list()), []uint64{5, 4, 3}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("ring content mismatch: have %v, want %v", have, want)
+	}
+}
@@ -70,6 +70,12 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 	}
 	// Header validity is known at this point, check the uncles and transactions
 	header := block.Header()
+	if v.config.IsLondon(header.Epoch()) {
+		parent := v.bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+		if err := VerifyEIP1559Header(v.config, parent, header); err != nil {
+			return err
+		}
+	}
 	//if err := v.engine.VerifyUncles(v.bc, block); err != nil {
 	//	return err
 	//}
@@ -19,7 +19,6 @@ package core
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
@@ -37,12 +36,15 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
 	consensus_engine "github.com/harmony-one/harmony/consensus/engine"
 	"github.com/harmony-one/harmony/consensus/reward"
 	"github.com/harmony-one/harmony/consensus/votepower"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/pruner"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/internal/params"
@@ -109,12 +111,29 @@ const (
 	pendingCLCacheKey = "pendingCLs"
 )
 
+// statePruneSafetyWindow is the number of states kept by the state pruner on top of
+// the configured ones, so that reorgs and in-flight traces can still access them.
+const statePruneSafetyWindow = 2 * triesInMemory
+
 // CacheConfig contains the configuration values for the trie caching/pruning
 // that's resident in a blockchain.
 type CacheConfig struct {
 	Disabled      bool          // Whether to disable trie write caching (archive node)
 	TrieNodeLimit int           // Memory limit (MB) at which to flush the current in-memory trie to disk
 	TrieTimeLimit time.Duration // Time limit after which to flush the current in-memory trie to disk
+
+	PruneRetain    uint64 // Number of recent states kept by the background state pruner, 0 to disable it
+	PruneBloomSize uint64 // Memory (MB) of the bloom filter marking the states kept by the pruner
+
+	SnapshotLimit int // Memory (MB) of the state snapshot cache, 0 to disable the state snapshot
+}
+
+// DefaultCacheConfig returns the trie caching configuration of non archival nodes.
+func DefaultCacheConfig() *CacheConfig {
+	return &CacheConfig{
+		TrieNodeLimit: 256 * 1024 * 1024,
+		TrieTimeLimit: 2 * time.Minute,
+	}
 }
 
 // BlockChain represents the canonical chain given a database with a genesis
@@ -135,11 +154,17 @@ type BlockChain struct {
 	chainConfig            *params.ChainConfig // Chain & network configuration
 	cacheConfig            *CacheConfig        // Cache configuration for pruning
 	pruneBeaconChainEnable bool                // pruneBeaconChainEnable is enable prune BeaconChain feature
+	stateDiffIndex         bool                // stateDiffIndex indexes the state changes of every inserted block
+	rewardHistoryIndex     bool                // rewardHistoryIndex indexes the rewards paid to the delegations by every inserted block
 
 	db     ethdb.Database // Low level persistent database to store final content in
 	triegc *prque.Prque   // Priority queue mapping block numbers to tries to gc
 	gcproc time.Duration  // Accumulates canonical block processing for trie dumping
 
+	statePruner  *pruner.Pruner // Background pruner of the historical states, nil if disabled
+	statePruneCh chan struct{}  // Channel requesting a state pruning
+	snaps        *snapshot.Tree // Flat snapshot of the recent states, nil if disabled
+
 	hc            *HeaderChain
 	rmLogsFeed    event.Feed
 	chainFeed     event.Feed
@@ -184,7 +209,7 @@ type BlockChain struct {
 	processor              Processor // block processor interface
 	validator              Validator // block and state validator interface
 	vmConfig               vm.Config
-	badBlocks              *lru.Cache              // Bad block cache
+	badBlocks              *badBlockRing           // Bad block ring buffer
 	shouldPreserve         func(*types.Block) bool // Function used to determine whether should preserve the given block.
 	pendingSlashes         slash.Records
 	maxGarbCollectedBlkNum int64
@@ -199,17 +224,13 @@ func NewBlockChain(
 	shouldPreserve func(block *types.Block) bool,
 ) (*BlockChain, error) {
 	if cacheConfig == nil {
-		cacheConfig = &CacheConfig{
-			TrieNodeLimit: 256 * 1024 * 1024,
-			TrieTimeLimit: 2 * time.Minute,
-		}
+		cacheConfig = DefaultCacheConfig()
 	}
 	bodyCache, _ := lru.New(bodyCacheLimit)
 	bodyRLPCache, _ := lru.New(bodyCacheLimit)
 	receiptsCache, _ := lru.New(receiptsCacheLimit)
 	blockCache, _ := lru.New(blockCacheLimit)
 	futureBlocks, _ := lru.New(maxFutureBlocks)
-	badBlocks, _ := lru.New(badBlockLimit)
 	shardCache, _ := lru.New(shardCacheLimit)
 	commitsCache, _ := lru.New(commitsCacheLimit)
 	epochCache, _ := lru.New(epochCacheLimit)
@@ -247,10 +268,16 @@ func NewBlockChain(
 		blockchainPruner:              newBlockchainPruner(db),
 		engine:                        engine,
 		vmConfig:                      vmConfig,
-		badBlocks:                     badBlocks,
+		badBlocks:                     newBadBlockRing(badBlockLimit),
 		pendingSlashes:                slash.Records{},
 		maxGarbCollectedBlkNum:        -1,
 	}
+	if cacheConfig.PruneRetain > 0 && !cacheConfig.Disabled {
+		// Trie nodes are written through the pruner to protect them from a concurrent pruning
+		bc.statePruner = pruner.NewPruner(db, cacheConfig.PruneBloomSize)
+		bc.statePruneCh = make(chan struct{}, 1)
+		bc.stateCache = state.NewDatabase(bc.statePruner.Database())
+	}
 	bc.SetValidator(NewBlockValidator(chainConfig, bc, engine))
 	bc.SetProcessor(NewStateProcessor(chainConfig, bc, engine))
 
@@ -269,17 +296,31 @@ func NewBlockChain(
 	if err := bc.loadLastState(); err != nil {
 		return nil, err
 	}
+	if cacheConfig.SnapshotLimit > 0 {
+		bc.snaps = snapshot.New(
+			bc.db, bc.stateCache.TrieDB(), cacheConfig.SnapshotLimit, bc.CurrentBlock().Root(),
+		)
+	}
 	// Take ownership of this particular state
 	go bc.update()
+	if bc.statePruner != nil {
+		go bc.statePruneLoop()
+	}
 	return bc, nil
 }
 
 // ValidateNewBlock validates new block.
 func (bc *BlockChain) ValidateNewBlock(block *types.Block) error {
-	state, err := state.New(bc.CurrentBlock().Root(), bc.stateCache)
+	state, err := state.NewWithSnapshots(bc.CurrentBlock().Root(), bc.stateCache, bc.snaps)
 	if err != nil {
 		return err
 	}
+	if bc.stateDiffIndex {
+		state.RecordStateDiffs()
+	}
+	if bc.rewardHistoryIndex {
+		state.RecordRewards()
+	}
 
 	// NOTE Order of mutating state here matters.
 	// Process block using the parent state as reference point.
@@ -288,7 +329,7 @@ func (bc *BlockChain) ValidateNewBlock(block *types.Block) error {
 		block, state, bc.vmConfig, false,
 	)
 	if err != nil {
-		bc.reportBlock(block, receipts, err)
+		bc.reportBlock(block, receipts, common.Hash{}, err)
 		return err
 	}
 
@@ -296,7 +337,7 @@ func (bc *BlockChain) ValidateNewBlock(block *types.Block) error {
 	if err := bc.Validator().ValidateState(
 		block, state, receipts, cxReceipts, usedGas,
 	); err != nil {
-		bc.reportBlock(block, receipts, err)
+		bc.reportBlock(block, receipts, state.IntermediateRoot(bc.chainConfig.IsS3(block.Epoch())), err)
 		return err
 	}
 	return nil
@@ -440,6 +481,10 @@ func (bc *BlockChain) SetHead(head uint64) error {
 			headBlockGauge.Update(int64(bc.genesisBlock.NumberU64()))
 		}
 	}
+	// Regenerate the snapshot if the new head is below the snapshot layers
+	if root := bc.CurrentBlock().Root(); bc.snaps != nil && bc.snaps.Snapshot(root) == nil {
+		bc.snaps.Rebuild(root)
+	}
 	// Rewind the fast block in a simpleton way to the target head
 	if currentFastBlock := bc.CurrentFastBlock(); currentFastBlock != nil && currentHeader.Number().Uint64() < currentFastBlock.NumberU64() {
 		newHeadFastBlock := bc.GetBlock(currentHeader.Hash(), currentHeader.Number().Uint64())
@@ -526,7 +571,13 @@ func (bc *BlockChain) State() (*state.DB, error) {
 
 // StateAt returns a new mutable state based on a particular point in time.
 func (bc *BlockChain) StateAt(root common.Hash) (*state.DB, error) {
-	return state.New(root, bc.stateCache)
+	return state.NewWithSnapshots(root, bc.stateCache, bc.snaps)
+}
+
+// ContractCode retrieves a blob of contract code by its hash, either from the
+// in-memory tries or from the database.
+func (bc *BlockChain) ContractCode(hash common.Hash) ([]byte, error) {
+	return bc.stateCache.ContractCode(common.Hash{}, hash)
 }
 
 // Reset purges the entire blockchain, restoring it to its genesis state.
@@ -865,6 +916,12 @@ func (bc *BlockChain) TrieNode(hash common.Hash) ([]byte, error) {
 	return bc.stateCache.TrieDB().Node(hash)
 }
 
+// TrieDB returns the trie database of the states, holding the recent tries not
+// yet flushed to disk.
+func (bc *BlockChain) TrieDB() *trie.Database {
+	return bc.stateCache.TrieDB()
+}
+
 // Stop stops the blockchain service. If any imports are currently in progress
 // it will abort them using the procInterrupt.
 func (bc *BlockChain) Stop() {
@@ -883,6 +940,13 @@ func (bc *BlockChain) Stop() {
 	bc.scope.Close()
 	close(bc.quit)
 	atomic.StoreInt32(&bc.procInterrupt, 1)
+	if bc.statePruner != nil {
+		bc.statePruner.Stop()
+	}
+	// Persist the snapshot diff layers, for the snapshot to be reused on restart
+	if bc.snaps != nil {
+		bc.snaps.Stop(bc.CurrentBlock().Root())
+	}
 
 	// Ensure the state of a recent block is also stored to disk before exiting.
 	// We're writing three different states to catch different restart scenarios:
@@ -1160,6 +1224,106 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 
 var lastWrite uint64
 
+// InsertSnapSyncChain stores the verified blocks preceding the pivot of a snap
+// sync without executing them. The blocks become canonical and the head of the
+// fast sync chain, along with their commit signatures, the shard states of the
+// new epochs and the spent incoming receipts. The head block is left untouched
+// until the state of the pivot is committed with SnapSyncCommitHead. No receipts
+// nor states are available for these blocks.
+func (bc *BlockChain) InsertSnapSyncChain(blocks types.Blocks) (int, error) {
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+
+	for i, block := range blocks {
+		head := bc.CurrentFastBlock()
+		if block.NumberU64() <= head.NumberU64() &&
+			rawdb.ReadCanonicalHash(bc.db, block.NumberU64()) == block.Hash() {
+			continue
+		}
+		if block.ParentHash() != head.Hash() {
+			return i, fmt.Errorf("non contiguous snap sync insert: #%d [%x…] after #%d [%x…]",
+				block.NumberU64(), block.Hash().Bytes()[:4], head.NumberU64(), head.Hash().Bytes()[:4])
+		}
+		batch := bc.db.NewBatch()
+		if err := rawdb.WriteBlock(batch, block); err != nil {
+			return i, err
+		}
+		if err := rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64()); err != nil {
+			return i, err
+		}
+		if err := rawdb.WriteBlockTxLookUpEntries(batch, block); err != nil {
+			return i, err
+		}
+		if err := rawdb.WriteBlockStxLookUpEntries(batch, block); err != nil {
+			return i, err
+		}
+		if err := bc.WriteCXReceiptsProofSpent(batch, block.IncomingReceipts()); err != nil {
+			return i, err
+		}
+		if block.NumberU64() > 0 {
+			lastSig := block.Header().LastCommitSignature()
+			sigAndBitmap := append(lastSig[:], block.Header().LastCommitBitmap()...)
+			if err := rawdb.WriteBlockCommitSig(batch, block.NumberU64()-1, sigAndBitmap); err != nil {
+				return i, err
+			}
+		}
+		if sig := block.GetCurrentCommitSig(); len(sig) > 0 {
+			if err := rawdb.WriteBlockCommitSig(batch, block.NumberU64(), sig); err != nil {
+				return i, err
+			}
+		}
+		if block.IsLastBlockInEpoch() {
+			nextEpoch, err := bc.getNextBlockEpoch(block.Header())
+			if err != nil {
+				return i, err
+			}
+			if _, err := bc.WriteShardStateBytes(batch, nextEpoch, block.Header().ShardState()); err != nil {
+				return i, err
+			}
+		}
+		if err := rawdb.WriteHeadFastBlockHash(batch, block.Hash()); err != nil {
+			return i, err
+		}
+		if err := batch.Write(); err != nil {
+			return i, err
+		}
+		bc.lastCommitsCache.Remove(block.NumberU64() - 1)
+		bc.lastCommitsCache.Remove(block.NumberU64())
+
+		bc.currentFastBlock.Store(block)
+		headFastBlockGauge.Update(int64(block.NumberU64()))
+	}
+	return len(blocks), nil
+}
+
+// SnapSyncCommitHead sets the block stored with InsertSnapSyncChain as the head
+// of the chain, once its state has been downloaded.
+func (bc *BlockChain) SnapSyncCommitHead(hash common.Hash) error {
+	block := bc.GetBlockByHash(hash)
+	if block == nil {
+		return fmt.Errorf("non existent block [%x…]", hash[:4])
+	}
+	if _, err := trie.NewSecure(block.Root(), bc.stateCache.TrieDB()); err != nil {
+		return err
+	}
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if err := bc.writeHeadBlock(block); err != nil {
+		return err
+	}
+	if err := bc.hc.SetCurrentHeader(block.Header()); err != nil {
+		return errors.Wrap(err, "HeaderChain SetCurrentHeader")
+	}
+	utils.Logger().Info().
+		Uint64("number", block.NumberU64()).
+		Str("hash", hash.Hex()).
+		Msg("Committed snap synced head block")
+	return nil
+}
+
 // WriteBlockWithoutState writes only the block and its metadata to the database,
 // but does not write any state. This is used to construct competing side forks
 // up to the point where they exceed the canonical total difficulty.
@@ -1210,6 +1374,13 @@ func (bc *BlockChain) WriteBlockWithState(
 			}
 			return NonStatTy, err
 		}
+		// Prune the historical states once per epoch, now that a recent state is on disk
+		if bc.statePruner != nil {
+			select {
+			case bc.statePruneCh <- struct{}{}:
+			default:
+			}
+		}
 	} else {
 		// Full but not archive node, do proper garbage collection
 		triedb.Reference(root, common.Hash{}) // metadata reference to keep trie alive
@@ -1289,6 +1460,18 @@ func (bc *BlockChain) WriteBlockWithState(
 	if err := rawdb.WritePreimages(batch, block.NumberU64(), state.Preimages()); err != nil {
 		return NonStatTy, err
 	}
+	// The state changes are only recorded when the state diff index is enabled
+	if diffs := state.StateDiffs(); diffs != nil {
+		if err := rawdb.WriteStateDiffs(batch, block.Hash(), block.NumberU64(), diffs); err != nil {
+			return NonStatTy, err
+		}
+	}
+	// The rewards are only recorded when the reward history index is enabled
+	if rewards := state.Rewards(); rewards != nil {
+		if err := bc.writeRewardHistory(batch, block, rewards); err != nil {
+			return NonStatTy, err
+		}
+	}
 
 	if bc.IsEnablePruneBeaconChainFeature() {
 		if block.Number().Cmp(big.NewInt(pruneBeaconChainBlockBefore)) > 0 && block.Epoch().Cmp(big.NewInt(pruneBeaconChainBeforeEpoch)) > 0 {
@@ -1476,7 +1659,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifyHeaders bool) (int,
 			}
 
 		case err != nil:
-			bc.reportBlock(block, nil, err)
+			bc.reportBlock(block, nil, common.Hash{}, err)
 			return i, events, coalescedLogs, err
 		}
 
@@ -1488,10 +1671,16 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifyHeaders bool) (int,
 		} else {
 			parent = chain[i-1]
 		}
-		state, err := state.New(parent.Root(), bc.stateCache)
+		state, err := state.NewWithSnapshots(parent.Root(), bc.stateCache, bc.snaps)
 		if err != nil {
 			return i, events, coalescedLogs, err
 		}
+		if bc.stateDiffIndex {
+			state.RecordStateDiffs()
+		}
+		if bc.rewardHistoryIndex {
+			state.RecordRewards()
+		}
 
 		// Process block using the parent state as reference point.
 		substart := time.Now()
@@ -1500,7 +1689,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifyHeaders bool) (int,
 		)
 		state = newState // update state in case the new state is cached.
 		if err != nil {
-			bc.reportBlock(block, receipts, err)
+			bc.reportBlock(block, receipts, common.Hash{}, err)
 			return i, events, coalescedLogs, err
 		}
 
@@ -1519,7 +1708,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifyHeaders bool) (int,
 		if err := bc.Validator().ValidateState(
 			block, state, receipts, cxReceipts, usedGas,
 		); err != nil {
-			bc.reportBlock(block, receipts, err)
+			bc.reportBlock(block, receipts, state.IntermediateRoot(bc.chainConfig.IsS3(block.Epoch())), err)
 			return i, events, coalescedLogs, err
 		}
 		proctime := time.Since(bstart)
@@ -1676,45 +1865,60 @@ func (bc *BlockChain) update() {
 	}
 }
 
-// BadBlock ..
-type BadBlock struct {
-	Block  *types.Block
-	Reason error
+// statePruneLoop prunes the historical states when requested, until the chain is stopped.
+func (bc *BlockChain) statePruneLoop() {
+	for {
+		select {
+		case <-bc.statePruneCh:
+			bc.pruneState()
+		case <-bc.quit:
+			return
+		}
+	}
 }
 
-// MarshalJSON ..
-func (b BadBlock) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct {
-		Block  *block.Header `json:"header"`
-		Reason string        `json:"error-cause"`
-	}{
-		b.Block.Header(),
-		b.Reason.Error(),
-	})
+// pruneState deletes the trie nodes unreachable from the states of the most recent
+// blocks, the configured number of them plus the safety window.
+func (bc *BlockChain) pruneState() {
+	head := bc.CurrentHeader().Number().Uint64()
+	retain := bc.cacheConfig.PruneRetain + statePruneSafetyWindow
+	roots := make([]common.Hash, 0, retain)
+	for i := uint64(0); i < retain && i <= head; i++ {
+		header := bc.GetHeaderByNumber(head - i)
+		if header == nil {
+			break
+		}
+		roots = append(roots, header.Root())
+	}
+	if _, err := bc.statePruner.Prune(bc.stateCache.TrieDB(), roots); err != nil {
+		utils.Logger().Warn().Err(err).
+			Uint64("head", head).
+			Msg("[StatePruner] failed to prune state")
+	}
 }
 
-// BadBlocks returns a list of the last 'bad blocks' that
-// the client has seen on the network
+// BadBlocks returns the last 'bad blocks' that the client
+// has seen on the network, the most recent first
 func (bc *BlockChain) BadBlocks() []BadBlock {
-	blocks := make([]BadBlock, bc.badBlocks.Len())
-	for _, hash := range bc.badBlocks.Keys() {
-		if blk, exist := bc.badBlocks.Peek(hash); exist {
-			blocks = append(blocks, blk.(BadBlock))
-		}
-	}
-	return blocks
+	return bc.badBlocks.list()
 }
 
-// addBadBlock adds a bad block to the bad-block LRU cache
-func (bc *BlockChain) addBadBlock(block *types.Block, reason error) {
-	bc.badBlocks.Add(block.Hash(), BadBlock{block, reason})
+// addBadBlock adds a bad block to the bad-block ring buffer
+func (bc *BlockChain) addBadBlock(block *types.Block, reason error, localRoot common.Hash) {
+	bc.badBlocks.add(BadBlock{
+		Block:     block,
+		Reason:    reason,
+		LocalRoot: localRoot,
+		Time:      time.Now(),
+	})
 }
 
-// reportBlock logs a bad block error.
+// reportBlock logs a bad block error. The local root is the state root
+// computed for the block, zero if processing did not complete.
 func (bc *BlockChain) reportBlock(
-	block *types.Block, receipts types.Receipts, err error,
+	block *types.Block, receipts types.Receipts, localRoot common.Hash, err error,
 ) {
-	bc.addBadBlock(block, err)
+	bc.addBadBlock(block, err, localRoot)
 	var receiptString string
 	for _, receipt := range receipts {
 		receiptString += fmt.Sprintf("\t%v\n", receipt)
@@ -2142,6 +2346,135 @@ func (bc *BlockChain) ReadPendingSlashingCandidates() slash.Records {
 	return append(bc.pendingSlashes[0:0], bc.pendingSlashes...)
 }
 
+// writeSlashHistory writes the slashes applied to the state by the block into the
+// slashing history, along with their records.
+func (bc *BlockChain) writeSlashHistory(
+	batch rawdb.DatabaseWriter, block *types.Block, records slash.Records, state *state.DB,
+) error {
+	byHash := make(map[common.Hash]slash.Record, len(records))
+	for _, record := range records {
+		byHash[record.Hash()] = record
+	}
+	for _, app := range state.SlashApplications() {
+		record, ok := byHash[app.Record]
+		if !ok {
+			return errors.Errorf("applied slash %s not found in block", app.Record.Hex())
+		}
+		data, err := rlp.EncodeToBytes(&slash.HistoryEntry{
+			Record:      record,
+			Epoch:       block.Epoch(),
+			BlockNumber: block.NumberU64(),
+			Application: *app,
+		})
+		if err != nil {
+			return err
+		}
+		if err := rawdb.WriteSlashHistoryEntry(
+			batch, block.Epoch().Uint64(), block.NumberU64(), app.Record, data,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSlashHistory retrieves the slashes applied from epoch from to epoch to,
+// inclusive, ordered by the block applying them.
+func (bc *BlockChain) ReadSlashHistory(from, to uint64) ([]*slash.HistoryEntry, error) {
+	entries, err := rawdb.ReadSlashHistoryEntries(bc.db, from, to)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*slash.HistoryEntry, 0, len(entries))
+	for _, data := range entries {
+		entry := &slash.HistoryEntry{}
+		if err := rlp.DecodeBytes(data, entry); err != nil {
+			return nil, errors.Wrap(err, "invalid slash history entry")
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// writeRewardHistory adds the rewards paid to the delegations by the block to
+// their reward history of the block epoch. The rewards of a block already counted
+// are not added again.
+func (bc *BlockChain) writeRewardHistory(
+	batch rawdb.DatabaseWriter, block *types.Block, rewards []*staking.DelegationReward,
+) error {
+	type rewardKey struct {
+		delegator, validator common.Address
+	}
+	number, epoch := block.NumberU64(), block.Epoch().Uint64()
+	// The entries already counting the block are nil
+	entries := make(map[rewardKey]*staking.RewardHistoryEntry)
+	keys := []rewardKey{}
+	for _, reward := range rewards {
+		key := rewardKey{reward.Delegator, reward.Validator}
+		entry, ok := entries[key]
+		if !ok {
+			entry = &staking.RewardHistoryEntry{
+				Validator:  reward.Validator,
+				Delegator:  reward.Delegator,
+				Epoch:      block.Epoch(),
+				Reward:     new(big.Int),
+				Commission: new(big.Int),
+			}
+			if data, err := rawdb.ReadRewardHistoryEntry(
+				bc.db, reward.Delegator, epoch, reward.Validator,
+			); err == nil && len(data) > 0 {
+				if err := rlp.DecodeBytes(data, entry); err != nil {
+					return errors.Wrap(err, "invalid reward history entry")
+				}
+				if entry.LastBlock >= number {
+					entry = nil
+				}
+			}
+			entries[key] = entry
+			keys = append(keys, key)
+		}
+		if entry != nil {
+			entry.Add(reward, number)
+		}
+	}
+	for _, key := range keys {
+		entry := entries[key]
+		if entry == nil {
+			continue
+		}
+		data, err := rlp.EncodeToBytes(entry)
+		if err != nil {
+			return err
+		}
+		if err := rawdb.WriteRewardHistoryEntry(
+			batch, key.delegator, epoch, key.validator, data,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadRewardHistory retrieves the rewards paid to the delegator from epoch from to
+// epoch to, inclusive, by epoch and validator.
+func (bc *BlockChain) ReadRewardHistory(
+	delegator common.Address, from, to uint64,
+) ([]*staking.RewardHistoryEntry, error) {
+	entries, err := rawdb.ReadRewardHistoryEntries(bc.db, delegator, from, to)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*staking.RewardHistoryEntry, 0, len(entries))
+	for _, data := range entries {
+		entry := &staking.RewardHistoryEntry{}
+		if err := rlp.DecodeBytes(data, entry); err != nil {
+			return nil, errors.Wrap(err, "invalid reward history entry")
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
 // ReadPendingCrossLinks retrieves pending crosslinks
 func (bc *BlockChain) ReadPendingCrossLinks() ([]types.CrossLink, error) {
 	cls := []types.CrossLink{}
@@ -3136,6 +3469,30 @@ func (bc *BlockChain) IsEnablePruneBeaconChainFeature() bool {
 	return bc.pruneBeaconChainEnable
 }
 
+// EnableStateDiffIndex enables the index of the state changes made by the
+// transactions of every inserted block.
+func (bc *BlockChain) EnableStateDiffIndex() {
+	bc.stateDiffIndex = true
+}
+
+// EnableRewardHistoryIndex enables the index of the rewards paid to the
+// delegations by every inserted block.
+func (bc *BlockChain) EnableRewardHistoryIndex() {
+	bc.rewardHistoryIndex = true
+}
+
+// IsRewardHistoryIndexEnabled returns whether the rewards paid to the delegations
+// are indexed.
+func (bc *BlockChain) IsRewardHistoryIndexEnabled() bool {
+	return bc.rewardHistoryIndex
+}
+
+// GetStateDiffs retrieves the indexed state changes made by the transactions of
+// the given block, or nil if the block is not indexed.
+func (bc *BlockChain) GetStateDiffs(hash common.Hash, number uint64) []*types.StateDiff {
+	return rawdb.ReadStateDiffs(bc.db, hash, number)
+}
+
 var (
 	leveldbErrSpec         = "leveldb"
 	tooManyOpenFilesErrStr = "Too many open files"
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/crypto"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
 	"github.com/harmony-one/harmony/core/types"
 	staking "github.com/harmony-one/harmony/staking/types"
 )
@@ -83,3 +85,55 @@ func signedCollectRewardsStakingTxn(key *ecdsa.PrivateKey) *staking.StakingTrans
 	signed, _ := staking.Sign(stx, staking.NewEIP155Signer(stx.ChainID()), key)
 	return signed
 }
+
+func TestWriteRewardHistory(t *testing.T) {
+	bc := &BlockChain{db: ethRawDB.NewMemoryDatabase()}
+	validator, delegator := common.Address{1}, common.Address{2}
+	rewards := []*staking.DelegationReward{
+		{Validator: validator, Delegator: validator, Reward: big.NewInt(5), Commission: big.NewInt(10)},
+		{Validator: validator, Delegator: delegator, Reward: big.NewInt(20), Commission: big.NewInt(0)},
+		{Validator: validator, Delegator: delegator, Reward: big.NewInt(7), Commission: big.NewInt(0)},
+	}
+	write := func(number int64) {
+		header := blockfactory.NewTestHeader().With().
+			Number(big.NewInt(number)).Epoch(big.NewInt(3)).Header()
+		block := types.NewBlockWithHeader(header)
+		batch := bc.db.NewBatch()
+		if err := bc.writeRewardHistory(batch, block, rewards); err != nil {
+			t.Fatal(err)
+		}
+		if err := batch.Write(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(10)
+	// The rewards of a block written again are not counted twice
+	write(10)
+	write(11)
+
+	history, err := bc.ReadRewardHistory(delegator, 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("history length mismatch: have %d, want 1", len(history))
+	}
+	entry := history[0]
+	if entry.Validator != validator || entry.Epoch.Uint64() != 3 || entry.LastBlock != 11 {
+		t.Errorf("entry mismatch: %+v", entry)
+	}
+	if entry.Reward.Cmp(big.NewInt(54)) != 0 || entry.Commission.Sign() != 0 {
+		t.Errorf("reward mismatch: have %v, %v, want 54, 0", entry.Reward, entry.Commission)
+	}
+	history, err = bc.ReadRewardHistory(validator, 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Reward.Cmp(big.NewInt(10)) != 0 ||
+		history[0].Commission.Cmp(big.NewInt(20)) != 0 {
+		t.Errorf("validator reward mismatch: %+v", history)
+	}
+	if history, _ := bc.ReadRewardHistory(delegator, 4, 5); len(history) != 0 {
+		t.Errorf("unexpected history out of range: %+v", history)
+	}
+}
@@ -235,7 +235,7 @@ func makeHeader(chain consensus_engine.ChainReader, parent *types.Block, state *
 		time = new(big.Int).Add(parent.Time(), big.NewInt(10)) // block time is fixed at 10 seconds
 	}
 
-	return factory.NewHeader(parent.Epoch()).With().
+	header := factory.NewHeader(parent.Epoch()).With().
 		Root(state.IntermediateRoot(chain.Config().IsS3(parent.Epoch()))).
 		ParentHash(parent.Hash()).
 		Coinbase(parent.Coinbase()).
@@ -243,6 +243,10 @@ func makeHeader(chain consensus_engine.ChainReader, parent *types.Block, state *
 		Number(new(big.Int).Add(parent.Number(), common.Big1)).
 		Time(time).
 		Header()
+	if chain.Config().IsLondon(parent.Epoch()) {
+		header.SetBaseFee(CalcBaseFee(chain.Config(), parent.Header()))
+	}
+	return header
 }
 
 type fakeChainReader struct {
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+// VerifyEIP1559Header verifies the base fee of a London header against its parent.
+func VerifyEIP1559Header(config *params.ChainConfig, parent, header *block.Header) error {
+	if header.BaseFee() == nil {
+		return fmt.Errorf("header is missing baseFee")
+	}
+	expectedBaseFee := CalcBaseFee(config, parent)
+	if header.BaseFee().Cmp(expectedBaseFee) != 0 {
+		return fmt.Errorf("invalid baseFee: have %s, want %s, parentBaseFee %v, parentGasUsed %d",
+			header.BaseFee(), expectedBaseFee, parent.BaseFee(), parent.GasUsed())
+	}
+	return nil
+}
+
+// CalcBaseFee calculates the base fee of the header following parent. The base
+// fee moves towards keeping blocks at half of their gas limit, by at most
+// 1/BaseFeeChangeDenominator per block.
+func CalcBaseFee(config *params.ChainConfig, parent *block.Header) *big.Int {
+	// If the current block is the first EIP-1559 block, return the InitialBaseFee.
+	parentBaseFee := parent.BaseFee()
+	if !config.IsLondon(parent.Epoch()) || parentBaseFee == nil {
+		return new(big.Int).SetUint64(params.InitialBaseFee)
+	}
+
+	var (
+		parentGasTarget          = parent.GasLimit() / params.ElasticityMultiplier
+		parentGasTargetBig       = new(big.Int).SetUint64(parentGasTarget)
+		baseFeeChangeDenominator = new(big.Int).SetUint64(params.BaseFeeChangeDenominator)
+	)
+	// If the parent gasUsed is the same as the target, the baseFee remains unchanged.
+	if parent.GasUsed() == parentGasTarget {
+		return parentBaseFee
+	}
+	if parent.GasUsed() > parentGasTarget {
+		// If the parent block used more gas than its target, the baseFee should increase.
+		gasUsedDelta := new(big.Int).SetUint64(parent.GasUsed() - parentGasTarget)
+		x := new(big.Int).Mul(parentBaseFee, gasUsedDelta)
+		y := x.Div(x, parentGasTargetBig)
+		baseFeeDelta := math.BigMax(
+			x.Div(y, baseFeeChangeDenominator),
+			common.Big1,
+		)
+
+		return x.Add(parentBaseFee, baseFeeDelta)
+	}
+	// Otherwise if the parent block used less gas than its target, the baseFee should decrease.
+	gasUsedDelta := new(big.Int).SetUint64(parentGasTarget - parent.GasUsed())
+	x := new(big.Int).Mul(parentBaseFee, gasUsedDelta)
+	y := x.Div(x, parentGasTargetBig)
+	baseFeeDelta := x.Div(y, baseFeeChangeDenominator)
+
+	return math.BigMax(
+		x.Sub(parentBaseFee, baseFeeDelta),
+		common.Big0,
+	)
+}
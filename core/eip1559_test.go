@@ -0,0 +1,53 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+func TestCalcBaseFee(t *testing.T) {
+	config := params.TestChainConfig
+	tests := []struct {
+		parentBaseFee   int64
+		parentGasLimit  uint64
+		parentGasUsed   uint64
+		expectedBaseFee int64
+	}{
+		{1000000000, 20000000, 10000000, 1000000000}, // usage == target
+		{1000000000, 20000000, 9000000, 987500000},   // usage below target
+		{1000000000, 20000000, 11000000, 1012500000}, // usage above target
+	}
+	for i, test := range tests {
+		parent := blockfactory.ForTest.NewHeader(big.NewInt(1)).With().
+			Number(big.NewInt(32)).
+			GasLimit(test.parentGasLimit).
+			GasUsed(test.parentGasUsed).
+			BaseFee(big.NewInt(test.parentBaseFee)).
+			Header()
+		if have, want := CalcBaseFee(config, parent), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d  want %d, ", i, have, want)
+		}
+	}
+}
+
+func TestVerifyEIP1559Header(t *testing.T) {
+	config := params.TestChainConfig
+	parent := blockfactory.ForTest.NewHeader(big.NewInt(1)).With().
+		GasLimit(20000000).
+		GasUsed(10000000).
+		BaseFee(new(big.Int).SetUint64(params.InitialBaseFee)).
+		Header()
+	newHeader := func(baseFee *big.Int) *block.Header {
+		return blockfactory.ForTest.NewHeader(big.NewInt(1)).With().BaseFee(baseFee).Header()
+	}
+	if err := VerifyEIP1559Header(config, parent, newHeader(new(big.Int).SetUint64(params.InitialBaseFee))); err != nil {
+		t.Errorf("expected valid base fee, got %v", err)
+	}
+	if err := VerifyEIP1559Header(config, parent, newHeader(new(big.Int).SetUint64(params.InitialBaseFee+1))); err == nil {
+		t.Errorf("expected invalid base fee to be rejected")
+	}
+}
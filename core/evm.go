@@ -76,12 +76,17 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		vrfAndProof := header.Vrf()
 		copy(vrf[:], vrfAndProof[:32])
 	}
+	var baseFee *big.Int
+	if header.BaseFee() != nil {
+		baseFee = new(big.Int).Set(header.BaseFee())
+	}
 	return vm.Context{
 		CanTransfer:     CanTransfer,
 		Transfer:        Transfer,
 		IsValidator:     IsValidator,
 		GetHash:         GetHashFn(header, chain),
 		GetVRF:          GetVRFFn(header, chain),
+		GetCrossLink:    GetCrossLinkFn(header, chain),
 		CreateValidator: CreateValidatorFn(header, chain),
 		EditValidator:   EditValidatorFn(header, chain),
 		Delegate:        DelegateFn(header, chain),
@@ -94,6 +99,7 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		BlockNumber:           header.Number(),
 		EpochNumber:           header.Epoch(),
 		VRF:                   vrf,
+		BaseFee:               baseFee,
 		Time:                  header.Time(),
 		GasLimit:              header.GasLimit(),
 		GasPrice:              new(big.Int).Set(msg.GasPrice()),
@@ -149,7 +155,11 @@ func EditValidatorFn(ref *block.Header, chain ChainContext) vm.EditValidatorFunc
 		if err != nil {
 			return err
 		}
-		return db.UpdateValidatorWrapper(wrapper.Address, wrapper)
+		if err := db.UpdateValidatorWrapper(wrapper.Address, wrapper); err != nil {
+			return err
+		}
+		addStakingLog(db, ref, chain, staking.ValidatorEditedEventTopic, nil, wrapper.Address)
+		return nil
 	}
 }
 
@@ -172,6 +182,10 @@ func DelegateFn(ref *block.Header, chain ChainContext) vm.DelegateFunc {
 		}
 
 		db.SubBalance(delegate.DelegatorAddress, balanceToBeDeducted)
+		addStakingLog(
+			db, ref, chain, staking.DelegateEventTopic, delegate.Amount,
+			delegate.DelegatorAddress, delegate.ValidatorAddress,
+		)
 
 		if rosettaTracer != nil && balanceToBeDeducted != big.NewInt(0) {
 			//add rosetta log
@@ -268,7 +282,14 @@ func UndelegateFn(ref *block.Header, chain ChainContext) vm.UndelegateFunc {
 			)
 		}
 
-		return db.UpdateValidatorWrapperWithRevert(wrapper.Address, wrapper)
+		if err := db.UpdateValidatorWrapperWithRevert(wrapper.Address, wrapper); err != nil {
+			return err
+		}
+		addStakingLog(
+			db, ref, chain, staking.UndelegateEventTopic, undelegate.Amount,
+			undelegate.DelegatorAddress, undelegate.ValidatorAddress,
+		)
+		return nil
 	}
 }
 
@@ -301,6 +322,10 @@ func CollectRewardsFn(ref *block.Header, chain ChainContext) vm.CollectRewardsFu
 			Data:        totalRewards.Bytes(),
 			BlockNumber: ref.Number().Uint64(),
 		})
+		addStakingLog(
+			db, ref, chain, staking.CollectRewardsEventTopic, totalRewards,
+			collectRewards.DelegatorAddress,
+		)
 
 		//add rosetta log
 		if rosettaTracer != nil {
@@ -481,6 +506,50 @@ func GetVRFFn(ref *block.Header, chain ChainContext) func(n uint64) common.Hash
 	}
 }
 
+// GetCrossLinkFn returns a GetCrossLinkFunc which retrieves the last crosslink
+// of a shard committed in the ancestry of ref. Only the parent chain of ref is
+// read, up to params.ShardInfoCrossLinkWindow headers, so the result does not
+// depend on the crosslinks a node happens to have processed.
+func GetCrossLinkFn(ref *block.Header, chain ChainContext) func(shardID uint32) (uint64, common.Hash) {
+	var cache map[uint32]types.CrossLink
+
+	return func(shardID uint32) (uint64, common.Hash) {
+		if cache == nil {
+			cache = map[uint32]types.CrossLink{}
+			header := ref
+			for i := uint64(0); i < params.ShardInfoCrossLinkWindow && header.Number().Sign() > 0; i++ {
+				header = chain.GetHeader(header.ParentHash(), header.Number().Uint64()-1)
+				if header == nil {
+					break
+				}
+				if len(header.CrossLinks()) == 0 {
+					continue
+				}
+				crossLinks := types.CrossLinks{}
+				if err := rlp.DecodeBytes(header.CrossLinks(), &crossLinks); err != nil {
+					continue
+				}
+				// the nearest header wins, within a header the highest block number
+				latest := map[uint32]types.CrossLink{}
+				for _, crossLink := range crossLinks {
+					if prev, ok := latest[crossLink.ShardID()]; !ok || crossLink.BlockNum() > prev.BlockNum() {
+						latest[crossLink.ShardID()] = crossLink
+					}
+				}
+				for shard, crossLink := range latest {
+					if _, ok := cache[shard]; !ok {
+						cache[shard] = crossLink
+					}
+				}
+			}
+		}
+		if crossLink, ok := cache[shardID]; ok {
+			return crossLink.BlockNum(), crossLink.Hash()
+		}
+		return 0, common.Hash{}
+	}
+}
+
 // CanTransfer checks whether there are enough funds in the address' account to make a transfer.
 // This does not take the necessary gas in to account to make the transfer valid.
 func CanTransfer(db vm.StateDB, addr common.Address, amount *big.Int) bool {
@@ -501,3 +570,30 @@ func Transfer(db vm.StateDB, sender, recipient common.Address, amount *big.Int,
 		db.AddBalance(recipient, amount)
 	}
 }
+
+// addStakingLog adds the well-known log of a staking state transition, from the
+// staking precompile address, if the staking logs are enabled at the epoch of the
+// header. The addresses are the indexed topics following the event topic and the
+// amount, if any, is the data.
+func addStakingLog(
+	db vm.StateDB, ref *block.Header, chain ChainContext,
+	topic common.Hash, amount *big.Int, addrs ...common.Address,
+) {
+	if chain == nil || !chain.Config().IsStakingLogs(ref.Epoch()) {
+		return
+	}
+	topics := []common.Hash{topic}
+	for _, addr := range addrs {
+		topics = append(topics, common.BytesToHash(addr.Bytes()))
+	}
+	var data []byte
+	if amount != nil {
+		data = common.BigToHash(amount).Bytes()
+	}
+	db.AddLog(&types.Log{
+		Address:     staking.LogAddress,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: ref.Number().Uint64(),
+	})
+}
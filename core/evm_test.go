@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/harmony-one/harmony/block"
 	blockfactory "github.com/harmony-one/harmony/block/factory"
@@ -24,6 +26,7 @@ import (
 	chain2 "github.com/harmony-one/harmony/internal/chain"
 	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/numeric"
+	stakingLogs "github.com/harmony-one/harmony/staking"
 	staking "github.com/harmony-one/harmony/staking/types"
 )
 
@@ -129,6 +132,9 @@ func TestEVMStaking(t *testing.T) {
 	if err != nil {
 		t.Errorf("Got error %v in CollectRewards", err)
 	}
+	if amount := collectedRewards(db, collectRewards.DelegatorAddress); amount.Cmp(common.Big257) != 0 {
+		t.Errorf("Got collected rewards %v, expected %v", amount, common.Big257)
+	}
 
 	//// migration test - when from has no delegations
 	//toKey, _ := crypto.GenerateKey()
@@ -475,3 +481,140 @@ func TestWriteCapablePrecompilesIntegration(t *testing.T) {
 		t.Errorf(fmt.Sprintf("Got error %v in evm.Call", err))
 	}
 }
+
+func TestStakingLogs(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	chain, db, header, _ := getTestEnvironment(*key)
+	header.SetNumber(big.NewInt(3))
+	delegator, validator := common.Address{1}, common.Address{2}
+	txHash := common.Hash{3}
+	db.Prepare(txHash, common.Hash{}, 0)
+
+	addStakingLog(db, header, chain, stakingLogs.DelegateEventTopic, big.NewInt(100), delegator, validator)
+	addStakingLog(db, header, chain, stakingLogs.ValidatorEditedEventTopic, nil, validator)
+	logs := db.GetLogs(txHash)
+	if len(logs) != 2 {
+		t.Fatalf("logs count mismatch: have %d, want 2", len(logs))
+	}
+	delegateLog := logs[0]
+	if delegateLog.Address != stakingLogs.LogAddress || delegateLog.BlockNumber != 3 {
+		t.Errorf("log mismatch: %+v", delegateLog)
+	}
+	wantTopics := []common.Hash{
+		stakingLogs.DelegateEventTopic,
+		common.HexToHash("0x0000000000000000000000000100000000000000000000000000000000000000"),
+		common.HexToHash("0x0000000000000000000000000200000000000000000000000000000000000000"),
+	}
+	if !reflect.DeepEqual(delegateLog.Topics, wantTopics) {
+		t.Errorf("topics mismatch: have %x, want %x", delegateLog.Topics, wantTopics)
+	}
+	if new(big.Int).SetBytes(delegateLog.Data).Cmp(big.NewInt(100)) != 0 || len(delegateLog.Data) != 32 {
+		t.Errorf("data mismatch: have %x", delegateLog.Data)
+	}
+	if len(logs[1].Topics) != 2 || len(logs[1].Data) != 0 {
+		t.Errorf("validator edited log mismatch: %+v", logs[1])
+	}
+
+	// No log is emitted before the staking logs epoch
+	config := *chain.Config()
+	config.StakingLogsEpoch = big.NewInt(5)
+	chain.chainConfig = &config
+	addStakingLog(db, header, chain, stakingLogs.UndelegateEventTopic, big.NewInt(1), delegator, validator)
+	if len(db.GetLogs(txHash)) != 2 {
+		t.Errorf("log emitted before the staking logs epoch")
+	}
+}
+
+// headerChainContext serves the headers of a test chain by hash
+type headerChainContext struct {
+	*fakeChainContext
+	headers map[common.Hash]*block.Header
+}
+
+func (chain *headerChainContext) GetHeader(hash common.Hash, number uint64) *block.Header {
+	if header, ok := chain.headers[hash]; ok && header.Number().Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+// makeCrossLinkChain makes a chain of n headers, where the header at each
+// number in crossLinks commits the given crosslinks.
+func makeCrossLinkChain(t *testing.T, n int64, crossLinks map[int64]types.CrossLinks) (*headerChainContext, *block.Header) {
+	chain := &headerChainContext{
+		fakeChainContext: makeFakeChainContext(nil),
+		headers:          map[common.Hash]*block.Header{},
+	}
+	var parent *block.Header
+	for i := int64(0); i < n; i++ {
+		header := blockfactory.ForTest.NewHeader(big.NewInt(0)).With().Number(big.NewInt(i)).Header()
+		if parent != nil {
+			header.SetParentHash(parent.Hash())
+		}
+		if links, ok := crossLinks[i]; ok {
+			encoded, err := rlp.EncodeToBytes(links)
+			if err != nil {
+				t.Fatal(err)
+			}
+			header.SetCrossLinks(encoded)
+		}
+		chain.headers[header.Hash()] = header
+		parent = header
+	}
+	return chain, parent
+}
+
+func makeTestCrossLink(shardID uint32, blockNum int64) types.CrossLink {
+	return types.CrossLink{
+		HashF:        common.BigToHash(big.NewInt(blockNum)),
+		BlockNumberF: big.NewInt(blockNum),
+		ViewIDF:      big.NewInt(blockNum),
+		ShardIDF:     shardID,
+		EpochF:       big.NewInt(0),
+	}
+}
+
+func TestGetCrossLinkFn(t *testing.T) {
+	chain, ref := makeCrossLinkChain(t, 5, map[int64]types.CrossLinks{
+		1: {makeTestCrossLink(1, 10), makeTestCrossLink(1, 11), makeTestCrossLink(2, 5)},
+		2: {makeTestCrossLink(1, 12)},
+		4: {makeTestCrossLink(3, 7)},
+	})
+	getCrossLink := GetCrossLinkFn(ref, chain)
+
+	tests := []struct {
+		shardID  uint32
+		blockNum uint64
+	}{
+		{1, 12},
+		{2, 5},
+		// the crosslinks committed in ref itself are not visible to its transactions
+		{3, 0},
+	}
+	for i, test := range tests {
+		blockNum, hash := getCrossLink(test.shardID)
+		if blockNum != test.blockNum {
+			t.Errorf("test %d: expected block number %d, got %d", i, test.blockNum, blockNum)
+		}
+		if hash != common.BigToHash(new(big.Int).SetUint64(test.blockNum)) && test.blockNum != 0 {
+			t.Errorf("test %d: unexpected block hash %x", i, hash)
+		}
+	}
+}
+
+func TestGetCrossLinkFnWindow(t *testing.T) {
+	window := int64(params.ShardInfoCrossLinkWindow)
+	crossLinks := map[int64]types.CrossLinks{
+		1: {makeTestCrossLink(1, 10)},
+	}
+	// the parent headers down to number 1 are within the window
+	chain, ref := makeCrossLinkChain(t, window+2, crossLinks)
+	if blockNum, _ := GetCrossLinkFn(ref, chain)(1); blockNum != 10 {
+		t.Errorf("expected the crosslink within the window, got block number %d", blockNum)
+	}
+	// one more header pushes it out of the window
+	chain, ref = makeCrossLinkChain(t, window+3, crossLinks)
+	if blockNum, hash := GetCrossLinkFn(ref, chain)(1); blockNum != 0 || hash != (common.Hash{}) {
+		t.Errorf("expected no crosslink out of the window, got block number %d", blockNum)
+	}
+}
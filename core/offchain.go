@@ -262,6 +262,9 @@ func (bc *BlockChain) CommitOffChainData(
 				if err := bc.DeleteFromPendingSlashingCandidates(records); err != nil {
 					utils.Logger().Debug().Err(err).Msg("could not deleting pending slashes")
 				}
+				if err := bc.writeSlashHistory(batch, block, records, state); err != nil {
+					utils.Logger().Error().Err(err).Msg("could not write slash history")
+				}
 			}
 		} else {
 			if isNewEpoch && isPreStaking {
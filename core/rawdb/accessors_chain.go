@@ -298,6 +298,11 @@ func ReadReceipts(db DatabaseReader, hash common.Hash, number uint64) types.Rece
 	if len(data) == 0 {
 		return nil
 	}
+	data, err := decompressValue(data)
+	if err != nil {
+		utils.Logger().Error().Err(err).Str("hash", hash.Hex()).Msg("Invalid compressed receipts")
+		return nil
+	}
 	// Convert the receipts from their storage form to their internal representation
 	storageReceipts := []*types.ReceiptForStorage{}
 	if err := rlp.DecodeBytes(data, &storageReceipts); err != nil {
@@ -324,7 +329,7 @@ func WriteReceipts(db DatabaseWriter, hash common.Hash, number uint64, receipts
 		return err
 	}
 	// Store the flattened receipt slice
-	if err := db.Put(blockReceiptsKey(number, hash), bytes); err != nil {
+	if err := db.Put(blockReceiptsKey(number, hash), compressValue(bytes)); err != nil {
 		utils.Logger().Error().Msg("Failed to store block receipts")
 		return err
 	}
@@ -340,6 +345,48 @@ func DeleteReceipts(db DatabaseDeleter, hash common.Hash, number uint64) error {
 	return nil
 }
 
+// ReadStateDiffs retrieves the state changes made by the transactions of a block.
+func ReadStateDiffs(db DatabaseReader, hash common.Hash, number uint64) []*types.StateDiff {
+	data, _ := db.Get(stateDiffKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	data, err := decompressValue(data)
+	if err != nil {
+		utils.Logger().Error().Err(err).Str("hash", hash.Hex()).Msg("Invalid compressed state diffs")
+		return nil
+	}
+	diffs := []*types.StateDiff{}
+	if err := rlp.DecodeBytes(data, &diffs); err != nil {
+		utils.Logger().Error().Err(err).Str("hash", hash.Hex()).Msg("Invalid state diff array RLP")
+		return nil
+	}
+	return diffs
+}
+
+// WriteStateDiffs stores the state changes made by the transactions of a block.
+func WriteStateDiffs(db DatabaseWriter, hash common.Hash, number uint64, diffs []*types.StateDiff) error {
+	bytes, err := rlp.EncodeToBytes(diffs)
+	if err != nil {
+		utils.Logger().Error().Msg("Failed to encode block state diffs")
+		return err
+	}
+	if err := db.Put(stateDiffKey(number, hash), compressValue(bytes)); err != nil {
+		utils.Logger().Error().Msg("Failed to store block state diffs")
+		return err
+	}
+	return nil
+}
+
+// DeleteStateDiffs removes the state changes associated with a block hash.
+func DeleteStateDiffs(db DatabaseDeleter, hash common.Hash, number uint64) error {
+	if err := db.Delete(stateDiffKey(number, hash)); err != nil {
+		utils.Logger().Error().Msg("Failed to delete block state diffs")
+		return err
+	}
+	return nil
+}
+
 // ReadBlock retrieves an entire block corresponding to the hash, assembling it
 // back from the stored header and body. If either the header or body could not
 // be retrieved nil is returned.
@@ -334,3 +334,51 @@ func TestBlockReceiptStorage(t *testing.T) {
 		t.Fatalf("deleted receipts returned: %v", rs)
 	}
 }
+
+// Tests that the block state diffs are stored and retrieved.
+func TestStateDiffStorage(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	diffs := []*types.StateDiff{
+		{
+			TxHash: common.BytesToHash([]byte{0x11}),
+			Accounts: []*types.AccountDiff{{
+				Address:       common.BytesToAddress([]byte{0x01}),
+				Existed:       true,
+				Exists:        true,
+				BalanceBefore: big.NewInt(2),
+				BalanceAfter:  big.NewInt(1),
+				NonceBefore:   1,
+				NonceAfter:    2,
+				Storage: []*types.StorageDiff{
+					{Key: common.Hash{1}, Before: common.Hash{}, After: common.Hash{2}},
+				},
+			}},
+		},
+		{
+			Accounts: []*types.AccountDiff{{
+				Address:       common.BytesToAddress([]byte{0x02}),
+				Exists:        true,
+				BalanceBefore: big.NewInt(0),
+				BalanceAfter:  big.NewInt(3),
+			}},
+		},
+	}
+	hash := common.BytesToHash([]byte{0x03, 0x14})
+	if have := ReadStateDiffs(db, hash, 1); have != nil {
+		t.Fatalf("non existent state diffs returned: %v", have)
+	}
+	if err := WriteStateDiffs(db, hash, 1, diffs); err != nil {
+		t.Fatalf("write state diffs: %v", err)
+	}
+	have := ReadStateDiffs(db, hash, 1)
+	rlpHave, _ := rlp.EncodeToBytes(have)
+	rlpWant, _ := rlp.EncodeToBytes(diffs)
+	if len(have) != len(diffs) || !bytes.Equal(rlpHave, rlpWant) {
+		t.Fatalf("state diffs mismatch: have %v, want %v", have, diffs)
+	}
+	DeleteStateDiffs(db, hash, 1)
+	if have := ReadStateDiffs(db, hash, 1); have != nil {
+		t.Fatalf("deleted state diffs returned: %v", have)
+	}
+}
@@ -197,6 +197,22 @@ func WriteBloomBits(db DatabaseWriter, bit uint, section uint64, head common.Has
 	return nil
 }
 
+// ReadTraceBloomBits retrieves the compressed trace bloom bit vector belonging to
+// the given section and bit index.
+func ReadTraceBloomBits(db DatabaseReader, bit uint, section uint64, head common.Hash) ([]byte, error) {
+	return db.Get(traceBloomBitsKey(bit, section, head))
+}
+
+// WriteTraceBloomBits stores the compressed trace bloom bits vector belonging to
+// the given section and bit index.
+func WriteTraceBloomBits(db DatabaseWriter, bit uint, section uint64, head common.Hash, bits []byte) error {
+	if err := db.Put(traceBloomBitsKey(bit, section, head), bits); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store trace bloom bits")
+		return err
+	}
+	return nil
+}
+
 // ReadCxLookupEntry retrieves the positional metadata associated with a transaction hash
 // to allow retrieving cross shard receipt by hash in destination shard
 // not the original transaction in source shard
@@ -1,6 +1,7 @@
 package rawdb
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math/big"
@@ -87,6 +88,79 @@ func WritePendingSlashingCandidates(db DatabaseWriter, bytes []byte) error {
 	return db.Put(pendingSlashingKey, bytes)
 }
 
+// WriteSlashHistoryEntry stores the RLP encoded entry of a slash applied by the
+// given block of the given epoch into the slashing history.
+func WriteSlashHistoryEntry(
+	db DatabaseWriter, epoch, number uint64, record common.Hash, data []byte,
+) error {
+	return db.Put(slashHistoryKey(epoch, number, record), data)
+}
+
+// ReadSlashHistoryEntries retrieves the RLP encoded entries of the slashes applied
+// from epoch from to epoch to, inclusive, ordered by the block applying them.
+func ReadSlashHistoryEntries(db ethdb.Iteratee, from, to uint64) ([][]byte, error) {
+	it := db.NewIteratorWithStart(slashHistoryKey(from, 0, common.Hash{}))
+	defer it.Release()
+
+	entries := [][]byte{}
+	for it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, slashHistoryPrefix) {
+			break
+		}
+		if len(key) != len(slashHistoryPrefix)+16+common.HashLength {
+			continue
+		}
+		if binary.BigEndian.Uint64(key[len(slashHistoryPrefix):]) > to {
+			break
+		}
+		entries = append(entries, common.CopyBytes(it.Value()))
+	}
+	return entries, it.Error()
+}
+
+// ReadRewardHistoryEntry retrieves the RLP encoded entry of the rewards paid by the
+// validator to the delegator over the given epoch.
+func ReadRewardHistoryEntry(
+	db DatabaseReader, delegator common.Address, epoch uint64, validator common.Address,
+) ([]byte, error) {
+	return db.Get(rewardHistoryKey(delegator, epoch, validator))
+}
+
+// WriteRewardHistoryEntry stores the RLP encoded entry of the rewards paid by the
+// validator to the delegator over the given epoch.
+func WriteRewardHistoryEntry(
+	db DatabaseWriter, delegator common.Address, epoch uint64, validator common.Address, data []byte,
+) error {
+	return db.Put(rewardHistoryKey(delegator, epoch, validator), data)
+}
+
+// ReadRewardHistoryEntries retrieves the RLP encoded entries of the rewards paid to
+// the delegator from epoch from to epoch to, inclusive, ordered by epoch.
+func ReadRewardHistoryEntries(
+	db ethdb.Iteratee, delegator common.Address, from, to uint64,
+) ([][]byte, error) {
+	prefix := append(common.CopyBytes(rewardHistoryPrefix), delegator.Bytes()...)
+	it := db.NewIteratorWithStart(rewardHistoryKey(delegator, from, common.Address{}))
+	defer it.Release()
+
+	entries := [][]byte{}
+	for it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		if len(key) != len(prefix)+8+common.AddressLength {
+			continue
+		}
+		if binary.BigEndian.Uint64(key[len(prefix):]) > to {
+			break
+		}
+		entries = append(entries, common.CopyBytes(it.Value()))
+	}
+	return entries, it.Error()
+}
+
 // ReadCXReceipts retrieves all the transactions of receipts given destination shardID, number and blockHash
 func ReadCXReceipts(db DatabaseReader, shardID uint32, number uint64, hash common.Hash) (types.CXReceipts, error) {
 	data, err := db.Get(cxReceiptKey(shardID, number, hash))
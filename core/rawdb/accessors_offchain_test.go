@@ -0,0 +1,119 @@
+package rawdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// Tests that the slash history entries are read back by epoch range, in block order.
+func TestSlashHistoryStorage(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	entries := []struct {
+		epoch, number uint64
+		record        common.Hash
+	}{
+		{1, 20, common.Hash{2}},
+		{1, 20, common.Hash{1}},
+		{3, 70, common.Hash{3}},
+		{4, 80, common.Hash{4}},
+		{4, 75, common.Hash{5}},
+	}
+	for _, e := range entries {
+		if err := WriteSlashHistoryEntry(db, e.epoch, e.number, e.record, e.record.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Unrelated keys after the slash history must be ignored
+	if err := db.Put([]byte("slash-hist"), []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("slash-historz"), []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		from, to uint64
+		want     []common.Hash
+	}{
+		{0, 10, []common.Hash{{1}, {2}, {3}, {5}, {4}}},
+		{1, 1, []common.Hash{{1}, {2}}},
+		{2, 3, []common.Hash{{3}}},
+		{4, 4, []common.Hash{{5}, {4}}},
+		{5, 9, nil},
+	}
+	for i, test := range tests {
+		have, err := ReadSlashHistoryEntries(db, test.from, test.to)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if len(have) != len(test.want) {
+			t.Fatalf("test %d: entries count mismatch: have %d, want %d", i, len(have), len(test.want))
+		}
+		for j, data := range have {
+			if !bytes.Equal(data, test.want[j].Bytes()) {
+				t.Errorf("test %d: entry %d mismatch: have %x, want %x", i, j, data, test.want[j])
+			}
+		}
+	}
+}
+
+// Tests that the reward history entries of a delegator are read back by epoch range.
+func TestRewardHistoryStorage(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	delegator, other := common.Address{0x10}, common.Address{0x11}
+	entries := []struct {
+		delegator common.Address
+		epoch     uint64
+		validator common.Address
+	}{
+		{delegator, 1, common.Address{2}},
+		{delegator, 1, common.Address{1}},
+		{delegator, 3, common.Address{3}},
+		{other, 2, common.Address{4}},
+		{delegator, 4, common.Address{5}},
+	}
+	for _, e := range entries {
+		if err := WriteRewardHistoryEntry(db, e.delegator, e.epoch, e.validator, e.validator.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := ReadRewardHistoryEntry(db, delegator, 3, common.Address{3})
+	if err != nil || !bytes.Equal(data, common.Address{3}.Bytes()) {
+		t.Fatalf("entry mismatch: have %x, %v", data, err)
+	}
+	if _, err := ReadRewardHistoryEntry(db, other, 3, common.Address{3}); err == nil {
+		t.Fatal("missing entry found")
+	}
+
+	tests := []struct {
+		delegator common.Address
+		from, to  uint64
+		want      []common.Address
+	}{
+		{delegator, 0, 10, []common.Address{{1}, {2}, {3}, {5}}},
+		{delegator, 1, 1, []common.Address{{1}, {2}}},
+		{delegator, 2, 3, []common.Address{{3}}},
+		{delegator, 5, 9, nil},
+		{other, 0, 10, []common.Address{{4}}},
+		{common.Address{0x12}, 0, 10, nil},
+	}
+	for i, test := range tests {
+		have, err := ReadRewardHistoryEntries(db, test.delegator, test.from, test.to)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if len(have) != len(test.want) {
+			t.Fatalf("test %d: entries count mismatch: have %d, want %d", i, len(have), len(test.want))
+		}
+		for j, data := range have {
+			if !bytes.Equal(data, test.want[j].Bytes()) {
+				t.Errorf("test %d: entry %d mismatch: have %x, want %x", i, j, data, test.want[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// ReadSnapshotRoot retrieves the root of the state flattened in the snapshot.
+func ReadSnapshotRoot(db DatabaseReader) common.Hash {
+	data, _ := db.Get(snapshotRootKey)
+	if len(data) != common.HashLength {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteSnapshotRoot stores the root of the state flattened in the snapshot.
+func WriteSnapshotRoot(db DatabaseWriter, root common.Hash) error {
+	if err := db.Put(snapshotRootKey, root.Bytes()); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store snapshot root")
+		return err
+	}
+	return nil
+}
+
+// DeleteSnapshotRoot deletes the snapshot root, invalidating the snapshot.
+func DeleteSnapshotRoot(db DatabaseDeleter) error {
+	if err := db.Delete(snapshotRootKey); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to delete snapshot root")
+		return err
+	}
+	return nil
+}
+
+// ReadSnapshotGenerating reports whether the snapshot generation was interrupted.
+func ReadSnapshotGenerating(db DatabaseReader) bool {
+	ok, _ := db.Has(snapshotGeneratorKey)
+	return ok
+}
+
+// WriteSnapshotGenerating marks the snapshot as being generated.
+func WriteSnapshotGenerating(db DatabaseWriter) error {
+	if err := db.Put(snapshotGeneratorKey, []byte{1}); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store snapshot generator marker")
+		return err
+	}
+	return nil
+}
+
+// DeleteSnapshotGenerating marks the snapshot generation as done.
+func DeleteSnapshotGenerating(db DatabaseDeleter) error {
+	if err := db.Delete(snapshotGeneratorKey); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to delete snapshot generator marker")
+		return err
+	}
+	return nil
+}
+
+// ReadAccountSnapshot retrieves the snapshot entry of an account trie leaf.
+func ReadAccountSnapshot(db DatabaseReader, hash common.Hash) []byte {
+	data, _ := db.Get(snapshotAccountKey(hash))
+	return data
+}
+
+// WriteAccountSnapshot stores the snapshot entry of an account trie leaf.
+func WriteAccountSnapshot(db DatabaseWriter, hash common.Hash, entry []byte) error {
+	if err := db.Put(snapshotAccountKey(hash), entry); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store account snapshot")
+		return err
+	}
+	return nil
+}
+
+// DeleteAccountSnapshot removes the snapshot entry of an account trie leaf.
+func DeleteAccountSnapshot(db DatabaseDeleter, hash common.Hash) error {
+	if err := db.Delete(snapshotAccountKey(hash)); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to delete account snapshot")
+		return err
+	}
+	return nil
+}
+
+// ReadStorageSnapshot retrieves the snapshot entry of a storage trie leaf.
+func ReadStorageSnapshot(db DatabaseReader, accountHash, storageHash common.Hash) []byte {
+	data, _ := db.Get(snapshotStorageKey(accountHash, storageHash))
+	return data
+}
+
+// WriteStorageSnapshot stores the snapshot entry of a storage trie leaf.
+func WriteStorageSnapshot(db DatabaseWriter, accountHash, storageHash common.Hash, entry []byte) error {
+	if err := db.Put(snapshotStorageKey(accountHash, storageHash), entry); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to store storage snapshot")
+		return err
+	}
+	return nil
+}
+
+// DeleteStorageSnapshot removes the snapshot entry of a storage trie leaf.
+func DeleteStorageSnapshot(db DatabaseDeleter, accountHash, storageHash common.Hash) error {
+	if err := db.Delete(snapshotStorageKey(accountHash, storageHash)); err != nil {
+		utils.Logger().Error().Err(err).Msg("Failed to delete storage snapshot")
+		return err
+	}
+	return nil
+}
+
+// IterateStorageSnapshots returns an iterator over the snapshot storage entries
+// of the given account.
+func IterateStorageSnapshots(db DatabaseIterator, accountHash common.Hash) ethdb.Iterator {
+	return db.NewIteratorWithPrefix(snapshotStorageKeyPrefix(accountHash))
+}
+
+// WipeSnapshot deletes all the snapshot entries and the snapshot root.
+func WipeSnapshot(db ethdb.Database) error {
+	for _, prefix := range [][]byte{snapshotAccountPrefix, snapshotStoragePrefix} {
+		it := db.NewIteratorWithPrefix(prefix)
+		batch := db.NewBatch()
+		for it.Next() {
+			if err := batch.Delete(it.Key()); err != nil {
+				it.Release()
+				return err
+			}
+			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					it.Release()
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		it.Release()
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	return DeleteSnapshotRoot(db)
+}
@@ -0,0 +1,58 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// ReadStagedSyncProgress retrieves the block number reached by the given stage
+// of the staged sync, or zero if it has no checkpoint.
+func ReadStagedSyncProgress(db DatabaseReader, stage string) uint64 {
+	data, _ := db.Get(stagedSyncProgressKey(stage))
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// WriteStagedSyncProgress stores the block number reached by the given stage of
+// the staged sync.
+func WriteStagedSyncProgress(db DatabaseWriter, stage string, number uint64) error {
+	if err := db.Put(stagedSyncProgressKey(stage), encodeBlockNumber(number)); err != nil {
+		utils.Logger().Error().Err(err).Str("stage", stage).Msg("Failed to store staged sync progress")
+		return err
+	}
+	return nil
+}
+
+// ReadStagedSyncHash retrieves the canonical hash of the given block number
+// downloaded by the staged sync, or the empty hash if it is not stored.
+func ReadStagedSyncHash(db DatabaseReader, number uint64) common.Hash {
+	data, _ := db.Get(stagedSyncHashKey(number))
+	if len(data) != common.HashLength {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteStagedSyncHash stores the canonical hash of the given block number
+// downloaded by the staged sync.
+func WriteStagedSyncHash(db DatabaseWriter, number uint64, hash common.Hash) error {
+	if err := db.Put(stagedSyncHashKey(number), hash.Bytes()); err != nil {
+		utils.Logger().Error().Err(err).Uint64("number", number).Msg("Failed to store staged sync hash")
+		return err
+	}
+	return nil
+}
+
+// DeleteStagedSyncHash deletes the hash of the given block number downloaded by
+// the staged sync.
+func DeleteStagedSyncHash(db DatabaseDeleter, number uint64) error {
+	if err := db.Delete(stagedSyncHashKey(number)); err != nil {
+		utils.Logger().Error().Err(err).Uint64("number", number).Msg("Failed to delete staged sync hash")
+		return err
+	}
+	return nil
+}
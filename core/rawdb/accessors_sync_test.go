@@ -0,0 +1,37 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// Tests the staged sync checkpoints storage and retrieval operations.
+func TestStagedSyncStorage(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	if progress := ReadStagedSyncProgress(db, "hashes"); progress != 0 {
+		t.Fatalf("non existent progress returned: %v", progress)
+	}
+	WriteStagedSyncProgress(db, "hashes", 100)
+	WriteStagedSyncProgress(db, "execution", 20)
+	if progress := ReadStagedSyncProgress(db, "hashes"); progress != 100 {
+		t.Fatalf("hashes progress mismatch: have %v, want %v", progress, 100)
+	}
+	if progress := ReadStagedSyncProgress(db, "execution"); progress != 20 {
+		t.Fatalf("execution progress mismatch: have %v, want %v", progress, 20)
+	}
+
+	if hash := ReadStagedSyncHash(db, 21); hash != (common.Hash{}) {
+		t.Fatalf("non existent hash returned: %x", hash)
+	}
+	WriteStagedSyncHash(db, 21, common.Hash{21})
+	if hash := ReadStagedSyncHash(db, 21); hash != (common.Hash{21}) {
+		t.Fatalf("hash mismatch: have %x, want %x", hash, common.Hash{21})
+	}
+	DeleteStagedSyncHash(db, 21)
+	if hash := ReadStagedSyncHash(db, 21); hash != (common.Hash{}) {
+		t.Fatalf("deleted hash returned: %x", hash)
+	}
+}
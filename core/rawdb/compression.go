@@ -0,0 +1,162 @@
+package rawdb
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// Compression algorithms of the receipts and state diffs written to the database.
+// Traces are not stored but replayed on the state, and the vectors of the trace
+// bloom index are already compressed with bitutil, so neither is covered.
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+)
+
+// Compressed values start with the marker of their algorithm. Uncompressed values
+// are RLP lists, whose first byte is at least 0xc0, so that both are told apart
+// when reading and the algorithm can be changed on an existing database.
+const (
+	markerNone   byte = 0x00
+	markerSnappy byte = 0x01
+)
+
+var (
+	// compressionMarker is the marker of the algorithm used for new writes.
+	compressionMarker uint32
+
+	// ErrUnknownCompression is returned for an unsupported compression algorithm.
+	ErrUnknownCompression = errors.New("unknown compression algorithm")
+)
+
+// SetCompression sets the algorithm compressing the receipts and state diffs
+// written from now on. The values already stored are read whatever algorithm they
+// were written with.
+func SetCompression(algo string) error {
+	marker, err := compressionMarkerOf(algo)
+	if err != nil {
+		return err
+	}
+	atomic.StoreUint32(&compressionMarker, uint32(marker))
+	return nil
+}
+
+func compressionMarkerOf(algo string) (byte, error) {
+	switch algo {
+	case CompressionNone, "":
+		return markerNone, nil
+	case CompressionSnappy:
+		return markerSnappy, nil
+	default:
+		return 0, errors.Wrap(ErrUnknownCompression, algo)
+	}
+}
+
+// compressValue compresses the given RLP encoded value with the configured
+// algorithm.
+func compressValue(data []byte) []byte {
+	return compressWith(byte(atomic.LoadUint32(&compressionMarker)), data)
+}
+
+func compressWith(marker byte, data []byte) []byte {
+	switch marker {
+	case markerSnappy:
+		enc := make([]byte, 1+snappy.MaxEncodedLen(len(data)))
+		enc[0] = markerSnappy
+		return enc[:1+len(snappy.Encode(enc[1:], data))]
+	default:
+		return data
+	}
+}
+
+// decompressValue returns the RLP encoding of a value stored compressed or not.
+func decompressValue(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	switch storedMarker(data) {
+	case markerNone:
+		return data, nil
+	case markerSnappy:
+		return snappy.Decode(nil, data[1:])
+	default:
+		return nil, errors.Wrapf(ErrUnknownCompression, "marker %#x", data[0])
+	}
+}
+
+// storedMarker returns the marker of the algorithm a stored value is compressed with.
+func storedMarker(data []byte) byte {
+	if data[0] >= 0xc0 {
+		return markerNone
+	}
+	return data[0]
+}
+
+// RecompressStats are the statistics of a recompression.
+type RecompressStats struct {
+	Values  uint64             // number of rewritten values
+	Before  common.StorageSize // size of the rewritten values before
+	After   common.StorageSize // size of the rewritten values after
+	Elapsed time.Duration
+}
+
+// Recompress rewrites the block receipts and state diffs of the key-value store
+// with the given algorithm, so that the space of an existing database is reclaimed
+// once compacted. The receipts already moved to the freezer are left as they are.
+func Recompress(db ethdb.KeyValueStore, algo string, progress func(stats *RecompressStats)) (*RecompressStats, error) {
+	marker, err := compressionMarkerOf(algo)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	stats := &RecompressStats{}
+	keyLength := 8 + common.HashLength
+	for _, prefix := range [][]byte{blockReceiptsPrefix, stateDiffPrefix} {
+		it := db.NewIteratorWithPrefix(prefix)
+		batch := db.NewBatch()
+		for it.Next() {
+			key, value := it.Key(), it.Value()
+			if len(key) != len(prefix)+keyLength || len(value) == 0 || storedMarker(value) == marker {
+				continue
+			}
+			data, err := decompressValue(value)
+			if err != nil {
+				it.Release()
+				return nil, errors.Wrapf(err, "key %x", key)
+			}
+			enc := compressWith(marker, data)
+			if err := batch.Put(common.CopyBytes(key), enc); err != nil {
+				it.Release()
+				return nil, err
+			}
+			stats.Values++
+			stats.Before += common.StorageSize(len(value))
+			stats.After += common.StorageSize(len(enc))
+			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					it.Release()
+					return nil, err
+				}
+				batch.Reset()
+				if progress != nil {
+					progress(stats)
+				}
+			}
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return nil, err
+		}
+		if err := batch.Write(); err != nil {
+			return nil, err
+		}
+	}
+	stats.Elapsed = time.Since(start)
+	return stats, nil
+}
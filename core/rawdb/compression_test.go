@@ -0,0 +1,79 @@
+package rawdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// Tests that the receipts are read whatever compression they were written with,
+// and that an existing database is rewritten with another compression.
+func TestReceiptCompression(t *testing.T) {
+	defer SetCompression(CompressionNone)
+	db := rawdb.NewMemoryDatabase()
+
+	receipts := types.Receipts{}
+	for i := 0; i < 16; i++ {
+		receipts = append(receipts, &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(i),
+			Logs:              []*types.Log{{Address: common.BytesToAddress([]byte{0x11}), Data: make([]byte, 64)}},
+			TxHash:            common.BytesToHash([]byte{byte(i)}),
+			GasUsed:           21000,
+		})
+	}
+	want, _ := rlp.EncodeToBytes(receipts)
+
+	check := func(hash common.Hash, number uint64) {
+		t.Helper()
+		have, _ := rlp.EncodeToBytes(ReadReceipts(db, hash, number))
+		if !bytes.Equal(have, want) {
+			t.Fatalf("receipts %d mismatch", number)
+		}
+	}
+	plainHash, snappyHash := common.Hash{1}, common.Hash{2}
+	if err := WriteReceipts(db, plainHash, 1, receipts); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetCompression(CompressionSnappy); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteReceipts(db, snappyHash, 2, receipts); err != nil {
+		t.Fatal(err)
+	}
+	plain, _ := db.Get(blockReceiptsKey(1, plainHash))
+	compressed, _ := db.Get(blockReceiptsKey(2, snappyHash))
+	if compressed[0] != markerSnappy || len(compressed) >= len(plain) {
+		t.Fatalf("receipts not compressed: %d bytes, plain %d bytes", len(compressed), len(plain))
+	}
+	check(plainHash, 1)
+	check(snappyHash, 2)
+
+	// Rewrite the database compressed, then uncompressed
+	stats, err := Recompress(db, CompressionSnappy, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Values != 1 {
+		t.Fatalf("rewritten values: have %d, want 1", stats.Values)
+	}
+	check(plainHash, 1)
+	if stats, err = Recompress(db, CompressionNone, nil); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Values != 2 {
+		t.Fatalf("rewritten values: have %d, want 2", stats.Values)
+	}
+	if data, _ := db.Get(blockReceiptsKey(2, snappyHash)); !bytes.Equal(data, plain) {
+		t.Fatalf("receipts not decompressed")
+	}
+	check(snappyHash, 2)
+
+	if err := SetCompression("zip"); err == nil {
+		t.Fatalf("expected error on unknown compression")
+	}
+}
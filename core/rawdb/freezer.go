@@ -0,0 +1,147 @@
+package rawdb
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// freezerHashTable is the table of the canonical block hashes.
+	freezerHashTable = "hashes"
+	// freezerBodiesTable is the table of the block bodies.
+	freezerBodiesTable = "bodies"
+	// freezerReceiptTable is the table of the block receipts.
+	freezerReceiptTable = "receipts"
+	// freezerCXReceiptTable is the table of the outgoing cross shard receipts of the blocks.
+	freezerCXReceiptTable = "cxreceipts"
+
+	// freezerTableSize is the maximum size of a data file of a freezer table.
+	freezerTableSize = 2 * 1000 * 1000 * 1000
+)
+
+// freezerTables are the tables of the freezer, all holding an item per block.
+var freezerTables = []string{
+	freezerHashTable, freezerBodiesTable, freezerReceiptTable, freezerCXReceiptTable,
+}
+
+// freezer is the append-only cold storage of the data of the old canonical
+// blocks, from the genesis block on.
+type freezer struct {
+	frozen uint64 // number of frozen blocks, accessed atomically
+	tables map[string]*freezerTable
+}
+
+// newFreezer opens the freezer in the given directory, dropping the blocks which
+// were not appended to all the tables.
+func newFreezer(dir string) (*freezer, error) {
+	f := &freezer{tables: make(map[string]*freezerTable)}
+	for _, name := range freezerTables {
+		table, err := newFreezerTable(dir, name, freezerTableSize)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		f.tables[name] = table
+	}
+	f.frozen = f.tables[freezerHashTable].Items()
+	for _, table := range f.tables {
+		if items := table.Items(); items < f.frozen {
+			f.frozen = items
+		}
+	}
+	for _, table := range f.tables {
+		if err := table.Truncate(f.frozen); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// HasAncient reports whether the given block is frozen.
+func (f *freezer) HasAncient(kind string, number uint64) (bool, error) {
+	if _, ok := f.tables[kind]; !ok {
+		return false, errors.Errorf("unknown freezer table %s", kind)
+	}
+	return number < atomic.LoadUint64(&f.frozen), nil
+}
+
+// Ancient returns the data of the given kind of a frozen block.
+func (f *freezer) Ancient(kind string, number uint64) ([]byte, error) {
+	table, ok := f.tables[kind]
+	if !ok {
+		return nil, errors.Errorf("unknown freezer table %s", kind)
+	}
+	if number >= atomic.LoadUint64(&f.frozen) {
+		return nil, errOutOfBounds
+	}
+	return table.Retrieve(number)
+}
+
+// Ancients returns the number of frozen blocks.
+func (f *freezer) Ancients() (uint64, error) {
+	return atomic.LoadUint64(&f.frozen), nil
+}
+
+// AncientSize returns the size of the given kind of data of the frozen blocks.
+func (f *freezer) AncientSize(kind string) (uint64, error) {
+	table, ok := f.tables[kind]
+	if !ok {
+		return 0, errors.Errorf("unknown freezer table %s", kind)
+	}
+	return table.Size()
+}
+
+// appendAncient freezes the data of the given block, which must follow the last
+// frozen one. The block is visible once all its data is appended.
+func (f *freezer) appendAncient(number uint64, hash common.Hash, body, receipts, cxReceipts []byte) error {
+	if number != atomic.LoadUint64(&f.frozen) {
+		return errOutOrderInsertion
+	}
+	for name, data := range map[string][]byte{
+		freezerHashTable:      hash.Bytes(),
+		freezerBodiesTable:    body,
+		freezerReceiptTable:   receipts,
+		freezerCXReceiptTable: cxReceipts,
+	} {
+		if err := f.tables[name].Append(number, data); err != nil {
+			f.truncate(number)
+			return errors.Wrapf(err, "freezer table %s", name)
+		}
+	}
+	atomic.AddUint64(&f.frozen, 1)
+	return nil
+}
+
+// truncate drops the blocks from the given number on from all the tables.
+func (f *freezer) truncate(items uint64) {
+	for _, table := range f.tables {
+		table.Truncate(items)
+	}
+}
+
+// Sync flushes the frozen data to disk.
+func (f *freezer) Sync() error {
+	for _, table := range f.tables {
+		if err := table.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the freezer tables.
+func (f *freezer) Close() error {
+	var errs []error
+	for _, table := range f.tables {
+		if err := table.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("%v", errs)
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+const (
+	// freezerRecheckInterval is the interval between the checks for blocks to freeze.
+	freezerRecheckInterval = time.Minute
+	// freezerBatchLimit is the maximum number of blocks frozen at once.
+	freezerBatchLimit = 30000
+)
+
+// frozenCXReceipts are the outgoing cross shard receipts of a block to a shard,
+// as stored in the freezer.
+type frozenCXReceipts struct {
+	ShardID  uint32
+	Receipts []byte
+}
+
+// freezerdb is a key-value database whose bodies, receipts and outgoing cross
+// shard receipts of the old canonical blocks are moved into a freezer. The frozen
+// entries are still read through the key-value interface.
+type freezerdb struct {
+	ethdb.Database
+	freezer   *freezer
+	threshold uint64 // number of recent blocks kept in the key-value store
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewDatabaseWithFreezer wraps the given key-value database, moving the data of
+// the canonical blocks older than threshold blocks from the head block into the
+// freezer in the given directory.
+func NewDatabaseWithFreezer(db ethdb.Database, dir string, threshold uint64) (ethdb.Database, error) {
+	f, err := newFreezer(dir)
+	if err != nil {
+		return nil, err
+	}
+	frdb := &freezerdb{
+		Database:  db,
+		freezer:   f,
+		threshold: threshold,
+		quit:      make(chan struct{}),
+	}
+	frdb.wg.Add(1)
+	go frdb.freezeLoop()
+	return frdb, nil
+}
+
+// Get retrieves the given key, from the freezer if it is frozen data.
+func (db *freezerdb) Get(key []byte) ([]byte, error) {
+	data, err := db.Database.Get(key)
+	if err == nil {
+		return data, nil
+	}
+	if frozen := db.readFrozenKey(key); frozen != nil {
+		return frozen, nil
+	}
+	return nil, err
+}
+
+// Has reports whether the given key is in the key-value store or the freezer.
+func (db *freezerdb) Has(key []byte) (bool, error) {
+	if ok, err := db.Database.Has(key); ok || err != nil {
+		return ok, err
+	}
+	return db.readFrozenKey(key) != nil, nil
+}
+
+// HasAncient reports whether the given block is frozen.
+func (db *freezerdb) HasAncient(kind string, number uint64) (bool, error) {
+	return db.freezer.HasAncient(kind, number)
+}
+
+// Ancient returns the data of the given kind of a frozen block.
+func (db *freezerdb) Ancient(kind string, number uint64) ([]byte, error) {
+	return db.freezer.Ancient(kind, number)
+}
+
+// Ancients returns the number of frozen blocks.
+func (db *freezerdb) Ancients() (uint64, error) {
+	return db.freezer.Ancients()
+}
+
+// AncientSize returns the size of the given kind of data of the frozen blocks.
+func (db *freezerdb) AncientSize(kind string) (uint64, error) {
+	return db.freezer.AncientSize(kind)
+}
+
+// Close stops the freezing and closes the freezer and the key-value store.
+func (db *freezerdb) Close() error {
+	close(db.quit)
+	db.wg.Wait()
+	if err := db.freezer.Close(); err != nil {
+		db.Database.Close()
+		return err
+	}
+	return db.Database.Close()
+}
+
+// readFrozenKey returns the frozen data stored under the given key, or nil.
+func (db *freezerdb) readFrozenKey(key []byte) []byte {
+	switch {
+	case len(key) == len(blockBodyPrefix)+8+common.HashLength && bytes.HasPrefix(key, blockBodyPrefix):
+		number, hash := decodeBlockNumber(key[1:9]), common.BytesToHash(key[9:])
+		return db.readFrozen(freezerBodiesTable, number, hash)
+
+	case len(key) == len(blockReceiptsPrefix)+8+common.HashLength && bytes.HasPrefix(key, blockReceiptsPrefix):
+		number, hash := decodeBlockNumber(key[1:9]), common.BytesToHash(key[9:])
+		return db.readFrozen(freezerReceiptTable, number, hash)
+
+	case len(key) == len(cxReceiptPrefix)+4+8+common.HashLength && bytes.HasPrefix(key, cxReceiptPrefix):
+		key = key[len(cxReceiptPrefix):]
+		shardID := binary.BigEndian.Uint32(key[:4])
+		number, hash := decodeBlockNumber(key[4:12]), common.BytesToHash(key[12:])
+		data := db.readFrozen(freezerCXReceiptTable, number, hash)
+		if data == nil {
+			return nil
+		}
+		var frozen []frozenCXReceipts
+		if err := rlp.DecodeBytes(data, &frozen); err != nil {
+			utils.Logger().Error().Err(err).Uint64("number", number).Msg("[Freezer] invalid frozen cx receipts")
+			return nil
+		}
+		for _, receipts := range frozen {
+			if receipts.ShardID == shardID {
+				return receipts.Receipts
+			}
+		}
+	}
+	return nil
+}
+
+// readFrozen returns the given kind of data of the given frozen block, or nil if
+// the block is not frozen or has no such data.
+func (db *freezerdb) readFrozen(kind string, number uint64, hash common.Hash) []byte {
+	frozenHash, err := db.freezer.Ancient(freezerHashTable, number)
+	if err != nil || !bytes.Equal(frozenHash, hash.Bytes()) {
+		return nil
+	}
+	data, err := db.freezer.Ancient(kind, number)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return data
+}
+
+// freezeLoop freezes the old blocks as the chain progresses, until the database
+// is closed.
+func (db *freezerdb) freezeLoop() {
+	defer db.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-db.quit:
+			return
+		case <-timer.C:
+		}
+		frozen, err := db.freeze()
+		if err != nil {
+			utils.Logger().Error().Err(err).Msg("[Freezer] failed to freeze blocks")
+		}
+		// Keep going without waiting while catching up
+		if frozen == freezerBatchLimit {
+			timer.Reset(0)
+		} else {
+			timer.Reset(freezerRecheckInterval)
+		}
+	}
+}
+
+// freeze moves the data of a batch of old canonical blocks into the freezer,
+// returning the number of frozen blocks.
+func (db *freezerdb) freeze() (int, error) {
+	headHash := ReadHeadBlockHash(db.Database)
+	if headHash == (common.Hash{}) {
+		return 0, nil
+	}
+	head := ReadHeaderNumber(db.Database, headHash)
+	if head == nil || *head <= db.threshold {
+		return 0, nil
+	}
+	first, _ := db.freezer.Ancients()
+	limit := *head - db.threshold
+	if limit <= first {
+		return 0, nil
+	}
+	if limit-first > freezerBatchLimit {
+		limit = first + freezerBatchLimit
+	}
+	start := time.Now()
+	shards := db.cxReceiptShards()
+	hashes := make([]common.Hash, 0, limit-first)
+	for number := first; number < limit; number++ {
+		select {
+		case <-db.quit:
+			return len(hashes), db.freezer.Sync()
+		default:
+		}
+		hash := ReadCanonicalHash(db.Database, number)
+		if hash == (common.Hash{}) {
+			break
+		}
+		body, _ := db.Database.Get(blockBodyKey(number, hash))
+		receipts, _ := db.Database.Get(blockReceiptsKey(number, hash))
+		var cxReceipts []frozenCXReceipts
+		for _, shardID := range shards {
+			if data, _ := db.Database.Get(cxReceiptKey(shardID, number, hash)); len(data) > 0 {
+				cxReceipts = append(cxReceipts, frozenCXReceipts{ShardID: shardID, Receipts: data})
+			}
+		}
+		cxData, err := rlp.EncodeToBytes(cxReceipts)
+		if err != nil {
+			return len(hashes), err
+		}
+		if err := db.freezer.appendAncient(number, hash, body, receipts, cxData); err != nil {
+			return len(hashes), err
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := db.freezer.Sync(); err != nil {
+		return len(hashes), err
+	}
+	if err := db.wipeFrozen(first, hashes, shards); err != nil {
+		return len(hashes), err
+	}
+	utils.Logger().Info().
+		Uint64("from", first).
+		Uint64("to", first+uint64(len(hashes))).
+		Dur("elapsed", time.Since(start)).
+		Msg("[Freezer] froze blocks")
+	return len(hashes), nil
+}
+
+// wipeFrozen deletes the data of the frozen blocks from the key-value store,
+// including the bodies and receipts of the side chains at the same heights.
+func (db *freezerdb) wipeFrozen(first uint64, hashes []common.Hash, shards []uint32) error {
+	batch := db.Database.NewBatch()
+	flush := func(force bool) error {
+		if !force && batch.ValueSize() < ethdb.IdealBatchSize {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	}
+	limit := first + uint64(len(hashes))
+	for _, prefix := range [][]byte{blockBodyPrefix, blockReceiptsPrefix} {
+		end := append(append([]byte{}, prefix...), encodeBlockNumber(limit)...)
+		it := db.Database.NewIteratorWithStart(append(append([]byte{}, prefix...), encodeBlockNumber(first)...))
+		for it.Next() && bytes.Compare(it.Key(), end) < 0 {
+			if len(it.Key()) != len(prefix)+8+common.HashLength {
+				continue
+			}
+			if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+				it.Release()
+				return err
+			}
+			if err := flush(false); err != nil {
+				it.Release()
+				return err
+			}
+		}
+		it.Release()
+	}
+	for i, hash := range hashes {
+		for _, shardID := range shards {
+			if err := batch.Delete(cxReceiptKey(shardID, first+uint64(i), hash)); err != nil {
+				return err
+			}
+		}
+		if err := flush(false); err != nil {
+			return err
+		}
+	}
+	return flush(true)
+}
+
+// cxReceiptShards returns the destination shards of the stored cross shard
+// receipts, skipping from one shard to the next in the key-value store.
+func (db *freezerdb) cxReceiptShards() []uint32 {
+	var (
+		shards []uint32
+		start  = append([]byte{}, cxReceiptPrefix...)
+	)
+	for {
+		it := db.Database.NewIteratorWithStart(start)
+		if !it.Next() || !bytes.HasPrefix(it.Key(), cxReceiptPrefix) || len(it.Key()) < len(cxReceiptPrefix)+4 {
+			it.Release()
+			return shards
+		}
+		key := it.Key()
+		shardID := binary.BigEndian.Uint32(key[len(cxReceiptPrefix):])
+		if len(key) == len(cxReceiptPrefix)+4+8+common.HashLength {
+			shards = append(shards, shardID)
+		}
+		it.Release()
+		if shardID == ^uint32(0) {
+			return shards
+		}
+		start = make([]byte, len(cxReceiptPrefix)+4)
+		copy(start, cxReceiptPrefix)
+		binary.BigEndian.PutUint32(start[len(cxReceiptPrefix):], shardID+1)
+	}
+}
@@ -0,0 +1,328 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// indexEntrySize is the size of an entry of a freezer table index: the number
+// of the data file of the item and the offset of its end in the file.
+const indexEntrySize = 8
+
+var (
+	// errOutOfBounds is returned if the item requested is not in the table.
+	errOutOfBounds = errors.New("out of bounds")
+	// errOutOrderInsertion is returned if the item appended is not the next one.
+	errOutOrderInsertion = errors.New("the append operation is out-order")
+)
+
+// indexEntry is the position of the end of an item in the data files.
+type indexEntry struct {
+	file   uint32
+	offset uint32
+}
+
+func (e indexEntry) marshal() []byte {
+	b := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint32(b[:4], e.file)
+	binary.BigEndian.PutUint32(b[4:], e.offset)
+	return b
+}
+
+func (e *indexEntry) unmarshal(b []byte) {
+	e.file = binary.BigEndian.Uint32(b[:4])
+	e.offset = binary.BigEndian.Uint32(b[4:])
+}
+
+// freezerTable is an append-only table of items numbered from zero. The items
+// are concatenated in data files of bounded size, and the index file holds the
+// end position of each of them, after a leading entry marking the start of the
+// first file.
+type freezerTable struct {
+	name    string
+	dir     string
+	maxSize uint32 // maximum size of a data file
+
+	index     *os.File
+	files     map[uint32]*os.File // data files, opened for reading and appending
+	headID    uint32              // number of the data file being appended
+	headBytes uint32              // size of the data file being appended
+	items     uint64
+
+	lock sync.RWMutex
+}
+
+// newFreezerTable opens the table of the given name in dir, creating it if needed
+// and discarding the items partially written before a crash.
+func newFreezerTable(dir, name string, maxSize uint32) (*freezerTable, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	index, err := os.OpenFile(filepath.Join(dir, name+".ridx"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	t := &freezerTable{
+		name:    name,
+		dir:     dir,
+		maxSize: maxSize,
+		index:   index,
+		files:   make(map[uint32]*os.File),
+	}
+	if err := t.repair(); err != nil {
+		t.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// dataPath returns the path of the data file with the given number.
+func (t *freezerTable) dataPath(id uint32) string {
+	return filepath.Join(t.dir, fmt.Sprintf("%s.%04d.rdat", t.name, id))
+}
+
+// openFile returns the data file with the given number, opening it if needed.
+func (t *freezerTable) openFile(id uint32) (*os.File, error) {
+	if f, ok := t.files[id]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(t.dataPath(id), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	t.files[id] = f
+	return f, nil
+}
+
+// readEntry reads the index entry at the given position.
+func (t *freezerTable) readEntry(pos uint64) (indexEntry, error) {
+	var (
+		entry indexEntry
+		b     = make([]byte, indexEntrySize)
+	)
+	if _, err := t.index.ReadAt(b, int64(pos*indexEntrySize)); err != nil {
+		return entry, err
+	}
+	entry.unmarshal(b)
+	return entry, nil
+}
+
+// repair opens the data files and truncates the index and the data to the last
+// item fully written.
+func (t *freezerTable) repair() error {
+	stat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+	size := stat.Size()
+	if size == 0 {
+		if _, err := t.index.WriteAt(indexEntry{}.marshal(), 0); err != nil {
+			return err
+		}
+		size = indexEntrySize
+	}
+	size -= size % indexEntrySize
+	for {
+		last, err := t.readEntry(uint64(size/indexEntrySize - 1))
+		if err != nil {
+			return err
+		}
+		head, err := t.openFile(last.file)
+		if err != nil {
+			return err
+		}
+		stat, err := head.Stat()
+		if err != nil {
+			return err
+		}
+		// Drop the index entries of the items whose data was not written
+		if stat.Size() < int64(last.offset) {
+			if size == indexEntrySize {
+				return errors.Errorf("freezer table %s: data of the first file missing", t.name)
+			}
+			size -= indexEntrySize
+			continue
+		}
+		if err := head.Truncate(int64(last.offset)); err != nil {
+			return err
+		}
+		t.headID, t.headBytes = last.file, last.offset
+		break
+	}
+	if err := t.index.Truncate(size); err != nil {
+		return err
+	}
+	t.items = uint64(size/indexEntrySize - 1)
+
+	// Open the older data files and remove the ones past the head
+	for id := uint32(0); id < t.headID; id++ {
+		if _, err := t.openFile(id); err != nil {
+			return err
+		}
+	}
+	for id := t.headID + 1; ; id++ {
+		if _, err := os.Stat(t.dataPath(id)); os.IsNotExist(err) {
+			break
+		}
+		if err := os.Remove(t.dataPath(id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Items returns the number of items in the table.
+func (t *freezerTable) Items() uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.items
+}
+
+// Append appends the given item, which must be the next one of the table.
+func (t *freezerTable) Append(item uint64, data []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if item != t.items {
+		return errOutOrderInsertion
+	}
+	if uint64(len(data)) > uint64(t.maxSize) {
+		return errors.Errorf("freezer table %s: item of %d bytes too large", t.name, len(data))
+	}
+	if uint64(t.headBytes)+uint64(len(data)) > uint64(t.maxSize) {
+		if err := t.files[t.headID].Sync(); err != nil {
+			return err
+		}
+		head, err := os.OpenFile(t.dataPath(t.headID+1), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		t.headID++
+		t.files[t.headID] = head
+		t.headBytes = 0
+	}
+	if _, err := t.files[t.headID].WriteAt(data, int64(t.headBytes)); err != nil {
+		return err
+	}
+	t.headBytes += uint32(len(data))
+	entry := indexEntry{file: t.headID, offset: t.headBytes}
+	if _, err := t.index.WriteAt(entry.marshal(), int64((t.items+1)*indexEntrySize)); err != nil {
+		return err
+	}
+	t.items++
+	return nil
+}
+
+// Retrieve returns the given item.
+func (t *freezerTable) Retrieve(item uint64) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if item >= t.items {
+		return nil, errOutOfBounds
+	}
+	start, err := t.readEntry(item)
+	if err != nil {
+		return nil, err
+	}
+	end, err := t.readEntry(item + 1)
+	if err != nil {
+		return nil, err
+	}
+	// Items are never split, an item starting a new file begins at its start
+	if start.file != end.file {
+		start.offset = 0
+	}
+	data := make([]byte, end.offset-start.offset)
+	if _, err := t.files[end.file].ReadAt(data, int64(start.offset)); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Truncate drops the items from the given number on.
+func (t *freezerTable) Truncate(items uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if items >= t.items {
+		return nil
+	}
+	last, err := t.readEntry(items)
+	if err != nil {
+		return err
+	}
+	if err := t.index.Truncate(int64((items + 1) * indexEntrySize)); err != nil {
+		return err
+	}
+	for id := t.headID; id > last.file; id-- {
+		t.files[id].Close()
+		delete(t.files, id)
+		if err := os.Remove(t.dataPath(id)); err != nil {
+			return err
+		}
+	}
+	if err := t.files[last.file].Truncate(int64(last.offset)); err != nil {
+		return err
+	}
+	t.headID, t.headBytes, t.items = last.file, last.offset, items
+	return nil
+}
+
+// Size returns the total size of the index and data files.
+func (t *freezerTable) Size() (uint64, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	stat, err := t.index.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := uint64(stat.Size())
+	for _, f := range t.files {
+		stat, err := f.Stat()
+		if err != nil {
+			return 0, err
+		}
+		size += uint64(stat.Size())
+	}
+	return size, nil
+}
+
+// Sync flushes the index and the data being appended to disk.
+func (t *freezerTable) Sync() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.files[t.headID].Sync(); err != nil {
+		return err
+	}
+	return t.index.Sync()
+}
+
+// Close closes the index and data files.
+func (t *freezerTable) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var errs []error
+	for _, f := range t.files {
+		if err := f.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	t.files = make(map[uint32]*os.File)
+	if err := t.index.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("freezer table %s: %v", t.name, errs)
+	}
+	return nil
+}
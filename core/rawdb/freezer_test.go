@@ -0,0 +1,163 @@
+package rawdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestFreezerTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Items of 10 bytes in files of at most 25 bytes
+	table, err := newFreezerTable(dir, "test", 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := func(i uint64) []byte {
+		return bytes.Repeat([]byte{byte(i)}, 10)
+	}
+	for i := uint64(0); i < 7; i++ {
+		if err := table.Append(i, item(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.Append(10, item(10)); err != errOutOrderInsertion {
+		t.Errorf("expected out of order error, got %v", err)
+	}
+	check := func(items uint64) {
+		t.Helper()
+		if table.Items() != items {
+			t.Fatalf("have %d items, want %d", table.Items(), items)
+		}
+		for i := uint64(0); i < items; i++ {
+			if data, err := table.Retrieve(i); err != nil || !bytes.Equal(data, item(i)) {
+				t.Errorf("item %d: %x %v", i, data, err)
+			}
+		}
+		if _, err := table.Retrieve(items); err != errOutOfBounds {
+			t.Errorf("expected out of bounds error, got %v", err)
+		}
+	}
+	check(7)
+	if table.headID != 3 {
+		t.Errorf("unexpected head file %d", table.headID)
+	}
+
+	// Reopening keeps the items, but drops the ones whose data is missing
+	table.Close()
+	if table, err = newFreezerTable(dir, "test", 25); err != nil {
+		t.Fatal(err)
+	}
+	check(7)
+	table.Close()
+	if err := os.Truncate(table.dataPath(3), 5); err != nil {
+		t.Fatal(err)
+	}
+	if table, err = newFreezerTable(dir, "test", 25); err != nil {
+		t.Fatal(err)
+	}
+	check(6)
+
+	if err := table.Truncate(3); err != nil {
+		t.Fatal(err)
+	}
+	check(3)
+	if err := table.Append(3, item(3)); err != nil {
+		t.Fatal(err)
+	}
+	check(4)
+	table.Close()
+}
+
+func TestFreezerDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kvdb := rawdb.NewMemoryDatabase()
+	f, err := newFreezer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := &freezerdb{Database: kvdb, freezer: f, threshold: 4, quit: make(chan struct{})}
+
+	var hashes []common.Hash
+	for number := uint64(0); number < 10; number++ {
+		hash := crypto.Keccak256Hash(encodeBlockNumber(number))
+		hashes = append(hashes, hash)
+		WriteCanonicalHash(db, hash, number)
+		WriteBodyRLP(db, hash, number, []byte(fmt.Sprintf("body %d", number)))
+		db.Put(blockReceiptsKey(number, hash), []byte(fmt.Sprintf("receipts %d", number)))
+		if number%2 == 0 {
+			db.Put(cxReceiptKey(1, number, hash), []byte(fmt.Sprintf("cx receipts %d", number)))
+		}
+		db.Put(cxReceiptKey(3, number, hash), []byte(fmt.Sprintf("cx receipts %d", number)))
+	}
+	db.Put(headerNumberKey(hashes[9]), encodeBlockNumber(9))
+	WriteHeadBlockHash(db, hashes[9])
+	// Side chain body at a frozen height
+	sideHash := common.HexToHash("0x1")
+	WriteBodyRLP(db, sideHash, 2, []byte("side body"))
+
+	frozen, err := db.freeze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frozen != 5 {
+		t.Fatalf("froze %d blocks, want 5", frozen)
+	}
+	for number, hash := range hashes {
+		n := uint64(number)
+		for key, exp := range map[string]string{
+			string(blockBodyKey(n, hash)):     fmt.Sprintf("body %d", n),
+			string(blockReceiptsKey(n, hash)): fmt.Sprintf("receipts %d", n),
+			string(cxReceiptKey(3, n, hash)):  fmt.Sprintf("cx receipts %d", n),
+		} {
+			if data, err := db.Get([]byte(key)); err != nil || string(data) != exp {
+				t.Errorf("block %d: have %q %v, want %q", n, data, err, exp)
+			}
+			// Only the recent blocks remain in the key-value store
+			if ok, _ := kvdb.Has([]byte(key)); ok != (n >= 5) {
+				t.Errorf("block %d: key-value store has data %v", n, ok)
+			}
+		}
+		if ok, _ := db.Has(cxReceiptKey(1, n, hash)); ok != (n%2 == 0) {
+			t.Errorf("block %d: unexpected cx receipts to shard 1", n)
+		}
+	}
+	if ok, _ := kvdb.Has(blockBodyKey(2, sideHash)); ok {
+		t.Errorf("side chain body not wiped")
+	}
+	if ok, _ := db.Has(blockBodyKey(2, sideHash)); ok {
+		t.Errorf("side chain body frozen")
+	}
+	if data := ReadBodyRLP(db, hashes[1], 1); string(data) != "body 1" {
+		t.Errorf("unexpected frozen body %q", data)
+	}
+
+	// Frozen blocks survive a restart
+	db.freezer.Close()
+	if db.freezer, err = newFreezer(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer db.freezer.Close()
+	if frozen, _ := db.Ancients(); frozen != 5 {
+		t.Errorf("have %d frozen blocks after restart, want 5", frozen)
+	}
+	if data, _ := db.Get(blockReceiptsKey(4, hashes[4])); string(data) != "receipts 4" {
+		t.Errorf("unexpected frozen receipts %q", data)
+	}
+}
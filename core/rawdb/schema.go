@@ -69,9 +69,26 @@ var (
 	epochVdfBlockNumberPrefix = []byte("epoch-vdf-block-number")
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix        = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
+	TraceBloomBitsIndexPrefix   = []byte("iT") // TraceBloomBitsIndexPrefix is the data table of the trace bloom indexer to track its progress
 	preimageCounter             = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter          = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 	currentRewardGivenOutPrefix = []byte("blk-rwd-")
+
+	snapshotRootKey       = []byte("SnapshotRoot")      // root of the state flattened in the snapshot
+	snapshotGeneratorKey  = []byte("SnapshotGenerator") // set while the snapshot is being generated
+	snapshotAccountPrefix = []byte("snap-a")            // snapshotAccountPrefix + account hash -> account trie value
+	snapshotStoragePrefix = []byte("snap-o")            // snapshotStoragePrefix + account hash + storage hash -> storage trie value
+
+	stateDiffPrefix = []byte("state-diff-") // stateDiffPrefix + num (uint64 big endian) + hash -> block state diffs
+
+	traceBloomBitsPrefix = []byte("trace-bloom-") // traceBloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> trace bloom bits
+
+	slashHistoryPrefix = []byte("slash-history-") // slashHistoryPrefix + epoch (uint64 big endian) + num (uint64 big endian) + record hash -> slash history entry
+
+	rewardHistoryPrefix = []byte("reward-history-") // rewardHistoryPrefix + delegator + epoch (uint64 big endian) + validator -> reward history entry
+
+	stagedSyncProgressPrefix = []byte("staged-sync-progress-") // stagedSyncProgressPrefix + stage -> progress (uint64 big endian)
+	stagedSyncHashPrefix     = []byte("staged-sync-hash-")     // stagedSyncHashPrefix + num (uint64 big endian) -> block hash
 )
 
 // TxLookupEntry is a positional metadata to help looking up the data content of
@@ -124,6 +141,33 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// stateDiffKey = stateDiffPrefix + num (uint64 big endian) + hash
+func stateDiffKey(number uint64, hash common.Hash) []byte {
+	return append(append(stateDiffPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// slashHistoryKey = slashHistoryPrefix + epoch (uint64 big endian) + num (uint64 big endian) + record hash
+func slashHistoryKey(epoch, number uint64, hash common.Hash) []byte {
+	key := append(common.CopyBytes(slashHistoryPrefix), encodeBlockNumber(epoch)...)
+	return append(append(key, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// rewardHistoryKey = rewardHistoryPrefix + delegator + epoch (uint64 big endian) + validator
+func rewardHistoryKey(delegator common.Address, epoch uint64, validator common.Address) []byte {
+	key := append(common.CopyBytes(rewardHistoryPrefix), delegator.Bytes()...)
+	return append(append(key, encodeBlockNumber(epoch)...), validator.Bytes()...)
+}
+
+// stagedSyncProgressKey = stagedSyncProgressPrefix + stage
+func stagedSyncProgressKey(stage string) []byte {
+	return append(common.CopyBytes(stagedSyncProgressPrefix), []byte(stage)...)
+}
+
+// stagedSyncHashKey = stagedSyncHashPrefix + num (uint64 big endian)
+func stagedSyncHashKey(number uint64) []byte {
+	return append(common.CopyBytes(stagedSyncHashPrefix), encodeBlockNumber(number)...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -144,6 +188,16 @@ func bloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// traceBloomBitsKey = traceBloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash
+func traceBloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
+	key := append(append(common.CopyBytes(traceBloomBitsPrefix), make([]byte, 10)...), hash.Bytes()...)
+
+	binary.BigEndian.PutUint16(key[len(traceBloomBitsPrefix):], uint16(bit))
+	binary.BigEndian.PutUint64(key[len(traceBloomBitsPrefix)+2:], section)
+
+	return key
+}
+
 // preimageKey = preimagePrefix + hash
 func preimageKey(hash common.Hash) []byte {
 	return append(preimagePrefix, hash.Bytes()...)
@@ -227,3 +281,18 @@ func blockRewardAccumKey(number uint64) []byte {
 func blockCommitSigKey(number uint64) []byte {
 	return append(blockCommitSigPrefix, encodeBlockNumber(number)...)
 }
+
+// snapshotAccountKey = snapshotAccountPrefix + account hash
+func snapshotAccountKey(hash common.Hash) []byte {
+	return append(append([]byte{}, snapshotAccountPrefix...), hash.Bytes()...)
+}
+
+// snapshotStorageKey = snapshotStoragePrefix + account hash + storage hash
+func snapshotStorageKey(accountHash, storageHash common.Hash) []byte {
+	return append(snapshotStorageKeyPrefix(accountHash), storageHash.Bytes()...)
+}
+
+// snapshotStorageKeyPrefix = snapshotStoragePrefix + account hash
+func snapshotStorageKeyPrefix(accountHash common.Hash) []byte {
+	return append(append([]byte{}, snapshotStoragePrefix...), accountHash.Bytes()...)
+}
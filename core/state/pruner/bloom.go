@@ -0,0 +1,44 @@
+package pruner
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// stateBloom is a bloom filter marking the trie nodes and contract codes to keep.
+// The keys are hashes already, so slices of the key are used as the bit positions
+// instead of rehashing it.
+type stateBloom struct {
+	bits []uint64
+	size uint64 // number of bits
+}
+
+// newStateBloom creates a bloom filter of the given size in megabytes.
+func newStateBloom(sizeMB uint64) *stateBloom {
+	if sizeMB == 0 {
+		sizeMB = 1
+	}
+	size := sizeMB * 1024 * 1024 * 8
+	return &stateBloom{bits: make([]uint64, size/64), size: size}
+}
+
+// add marks the given hash.
+func (b *stateBloom) add(hash common.Hash) {
+	for i := 0; i < common.HashLength; i += 8 {
+		pos := binary.BigEndian.Uint64(hash[i:i+8]) % b.size
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// contains reports whether the given hash may have been marked. False positives
+// are possible, false negatives are not.
+func (b *stateBloom) contains(hash common.Hash) bool {
+	for i := 0; i < common.HashLength; i += 8 {
+		pos := binary.BigEndian.Uint64(hash[i:i+8]) % b.size
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
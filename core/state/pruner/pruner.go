@@ -0,0 +1,349 @@
+// Package pruner deletes the state trie nodes and contract codes which are not
+// reachable from a set of retained state roots.
+//
+// Pruning marks the nodes of the retained states in a bloom filter, then sweeps
+// the database and deletes the trie nodes and codes absent from the filter. Nodes
+// written to the database while the pruning runs are recorded through the
+// database returned by Pruner.Database and never deleted, so the chain can keep
+// committing new states while a pruning is in progress.
+package pruner
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+var (
+	emptyCodeHash = crypto.Keccak256(nil)
+
+	// ErrPruneRunning is returned when a pruning is requested while another one runs.
+	ErrPruneRunning = errors.New("state pruning already running")
+	// ErrNoRetainedState is returned when the newest retained state is not available.
+	ErrNoRetainedState = errors.New("newest retained state is not available")
+	// ErrPruneAborted is returned when the pruner is stopped during a pruning.
+	ErrPruneAborted = errors.New("state pruning aborted")
+)
+
+// Stats are the statistics of a pruning.
+type Stats struct {
+	Roots   int                // number of retained states found in the database
+	Nodes   uint64             // number of deleted trie nodes and codes
+	Size    common.StorageSize // size of the deleted trie nodes and codes
+	Elapsed time.Duration
+}
+
+// Pruner deletes the trie nodes and contract codes unreachable from a set of
+// retained state roots.
+type Pruner struct {
+	db        ethdb.Database
+	bloomSize uint64 // size of the bloom filter in megabytes
+	running   int32
+	runLock   sync.Mutex // held by the running pruning
+	quit      chan struct{}
+	stopOnce  sync.Once
+
+	lock  sync.Mutex
+	fresh map[common.Hash]struct{} // keys written while pruning, nil when idle
+}
+
+// NewPruner creates a pruner of the given database, marking the retained states
+// in a bloom filter of bloomSize megabytes.
+func NewPruner(db ethdb.Database, bloomSize uint64) *Pruner {
+	return &Pruner{db: db, bloomSize: bloomSize, quit: make(chan struct{})}
+}
+
+// Stop aborts the running pruning, if any, and waits for it to return. Pruning is
+// not possible anymore once the pruner is stopped.
+func (p *Pruner) Stop() {
+	p.stopOnce.Do(func() { close(p.quit) })
+	p.runLock.Lock()
+	p.runLock.Unlock()
+}
+
+// aborted reports whether the pruner was stopped.
+func (p *Pruner) aborted() bool {
+	select {
+	case <-p.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// Database returns the database through which the trie nodes must be written
+// for them to be protected from a concurrent pruning.
+func (p *Pruner) Database() ethdb.Database {
+	return &recordingDB{Database: p.db, pruner: p}
+}
+
+// Running reports whether a pruning is in progress.
+func (p *Pruner) Running() bool {
+	return atomic.LoadInt32(&p.running) == 1
+}
+
+// record protects the given key from the running pruning, if any.
+func (p *Pruner) record(key []byte) {
+	if len(key) != common.HashLength {
+		return
+	}
+	p.lock.Lock()
+	if p.fresh != nil {
+		p.fresh[common.BytesToHash(key)] = struct{}{}
+	}
+	p.lock.Unlock()
+}
+
+// Prune deletes the trie nodes and contract codes which are not reachable from
+// the given roots, ordered from the newest to the oldest. The roots are resolved
+// through the given trie database, so that the states not yet flushed to disk are
+// kept as well. The newest root must be available, the other missing ones are
+// skipped.
+func (p *Pruner) Prune(triedb *trie.Database, roots []common.Hash) (*Stats, error) {
+	if !atomic.CompareAndSwapInt32(&p.running, 0, 1) {
+		return nil, ErrPruneRunning
+	}
+	defer atomic.StoreInt32(&p.running, 0)
+	p.runLock.Lock()
+	defer p.runLock.Unlock()
+	if p.aborted() {
+		return nil, ErrPruneAborted
+	}
+
+	p.lock.Lock()
+	p.fresh = make(map[common.Hash]struct{})
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		p.fresh = nil
+		p.lock.Unlock()
+	}()
+
+	start := time.Now()
+	stats := &Stats{}
+	bloom := newStateBloom(p.bloomSize)
+	parent := common.Hash{}
+	for i, root := range roots {
+		if err := p.markState(bloom, triedb, root, parent); err != nil {
+			if err == ErrPruneAborted {
+				return nil, err
+			}
+			if i == 0 {
+				return nil, errors.Wrap(ErrNoRetainedState, err.Error())
+			}
+			utils.Logger().Debug().Err(err).
+				Str("root", root.Hex()).
+				Msg("[StatePruner] skipping unavailable retained state")
+			continue
+		}
+		parent = root
+		stats.Roots++
+	}
+	utils.Logger().Info().
+		Int("roots", stats.Roots).
+		Dur("elapsed", time.Since(start)).
+		Msg("[StatePruner] marked retained states")
+
+	if err := p.sweep(bloom, stats); err != nil {
+		return nil, err
+	}
+	stats.Elapsed = time.Since(start)
+	utils.Logger().Info().
+		Int("roots", stats.Roots).
+		Uint64("nodes", stats.Nodes).
+		Str("size", stats.Size.String()).
+		Dur("elapsed", stats.Elapsed).
+		Msg("[StatePruner] pruned state")
+	return stats, nil
+}
+
+// markState marks the trie nodes and codes of the state with the given root. If
+// parent is set, the state of the given parent root must be marked already and
+// only the nodes which are not part of it are walked.
+func (p *Pruner) markState(bloom *stateBloom, triedb *trie.Database, root, parent common.Hash) error {
+	tr, err := trie.New(root, triedb)
+	if err != nil {
+		return err
+	}
+	var parentTrie *trie.Trie
+	it := tr.NodeIterator(nil)
+	if parent != (common.Hash{}) {
+		if parentTrie, err = trie.New(parent, triedb); err != nil {
+			return err
+		}
+		it, _ = trie.NewDifferenceIterator(parentTrie.NodeIterator(nil), it)
+	}
+	for it.Next(true) {
+		if p.aborted() {
+			return ErrPruneAborted
+		}
+		if hash := it.Hash(); hash != (common.Hash{}) {
+			bloom.add(hash)
+		}
+		if !it.Leaf() {
+			continue
+		}
+		var account state.Account
+		if err := rlp.DecodeBytes(it.LeafBlob(), &account); err != nil {
+			return err
+		}
+		if !bytes.Equal(account.CodeHash, emptyCodeHash) {
+			bloom.add(common.BytesToHash(account.CodeHash))
+		}
+		if account.Root == types.EmptyRootHash {
+			continue
+		}
+		// Walk the storage changes against the same account in the parent state
+		parentStorage := common.Hash{}
+		if parentTrie != nil {
+			blob, err := parentTrie.TryGet(it.LeafKey())
+			if err != nil {
+				return err
+			}
+			if blob != nil {
+				var parentAccount state.Account
+				if err := rlp.DecodeBytes(blob, &parentAccount); err != nil {
+					return err
+				}
+				parentStorage = parentAccount.Root
+			}
+		}
+		if account.Root == parentStorage {
+			continue
+		}
+		if parentStorage == types.EmptyRootHash {
+			parentStorage = common.Hash{}
+		}
+		if err := p.markTrie(bloom, triedb, account.Root, parentStorage); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// markTrie marks the nodes of the storage trie with the given root which are not
+// part of the parent storage trie, if set.
+func (p *Pruner) markTrie(bloom *stateBloom, triedb *trie.Database, root, parent common.Hash) error {
+	tr, err := trie.New(root, triedb)
+	if err != nil {
+		return err
+	}
+	it := tr.NodeIterator(nil)
+	if parent != (common.Hash{}) {
+		parentTrie, err := trie.New(parent, triedb)
+		if err != nil {
+			return err
+		}
+		it, _ = trie.NewDifferenceIterator(parentTrie.NodeIterator(nil), it)
+	}
+	for it.Next(true) {
+		if p.aborted() {
+			return ErrPruneAborted
+		}
+		if hash := it.Hash(); hash != (common.Hash{}) {
+			bloom.add(hash)
+		}
+	}
+	return it.Error()
+}
+
+// sweep deletes the trie nodes and codes of the database which are not marked in
+// the bloom filter.
+func (p *Pruner) sweep(bloom *stateBloom, stats *Stats) error {
+	it := p.db.NewIterator()
+	defer it.Release()
+
+	var (
+		keys  [][]byte
+		sizes []int
+		size  int
+	)
+	for it.Next() {
+		if p.aborted() {
+			return ErrPruneAborted
+		}
+		key := it.Key()
+		if len(key) != common.HashLength {
+			continue
+		}
+		hash := common.BytesToHash(key)
+		if bloom.contains(hash) {
+			continue
+		}
+		// Trie nodes and codes are keyed by their hash, skip any other entry
+		value := it.Value()
+		if crypto.Keccak256Hash(value) != hash {
+			continue
+		}
+		keys = append(keys, common.CopyBytes(key))
+		sizes = append(sizes, len(key)+len(value))
+		if size += len(key) + len(value); size >= ethdb.IdealBatchSize {
+			if err := p.delete(keys, sizes, stats); err != nil {
+				return err
+			}
+			keys, sizes, size = keys[:0], sizes[:0], 0
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return p.delete(keys, sizes, stats)
+}
+
+// delete deletes the given keys, except the ones written since the pruning started.
+func (p *Pruner) delete(keys [][]byte, sizes []int, stats *Stats) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	batch := p.db.NewBatch()
+	for i, key := range keys {
+		if _, ok := p.fresh[common.BytesToHash(key)]; ok {
+			continue
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		stats.Nodes++
+		stats.Size += common.StorageSize(sizes[i])
+	}
+	return batch.Write()
+}
+
+// recordingDB is a database which protects the keys written through it from the
+// running pruning.
+type recordingDB struct {
+	ethdb.Database
+	pruner *Pruner
+}
+
+func (db *recordingDB) Put(key []byte, value []byte) error {
+	db.pruner.record(key)
+	return db.Database.Put(key, value)
+}
+
+func (db *recordingDB) NewBatch() ethdb.Batch {
+	return &recordingBatch{Batch: db.Database.NewBatch(), pruner: db.pruner}
+}
+
+// recordingBatch is a batch which protects the keys written through it from the
+// running pruning.
+type recordingBatch struct {
+	ethdb.Batch
+	pruner *Pruner
+}
+
+func (b *recordingBatch) Put(key []byte, value []byte) error {
+	b.pruner.record(key)
+	return b.Batch.Put(key, value)
+}
@@ -0,0 +1,140 @@
+package pruner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/state"
+)
+
+var (
+	testAddr1 = common.HexToAddress("0x1")
+	testAddr2 = common.HexToAddress("0x2")
+	testSlot  = common.HexToHash("0x1")
+)
+
+// commitState applies the given change to the state at root and flushes the
+// resulting state to disk.
+func commitState(t *testing.T, sdb state.Database, root common.Hash, change func(*state.DB)) common.Hash {
+	statedb, err := state.New(root, sdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change(statedb)
+	newRoot, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(newRoot, false); err != nil {
+		t.Fatal(err)
+	}
+	return newRoot
+}
+
+func TestPrune(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	p := NewPruner(db, 1)
+	sdb := state.NewDatabase(p.Database())
+
+	root1 := commitState(t, sdb, common.Hash{}, func(statedb *state.DB) {
+		statedb.SetBalance(testAddr1, big.NewInt(1))
+		statedb.SetCode(testAddr1, []byte{0x1})
+		statedb.SetState(testAddr1, testSlot, common.HexToHash("0x1"))
+	})
+	root2 := commitState(t, sdb, root1, func(statedb *state.DB) {
+		statedb.SetCode(testAddr1, []byte{0x2})
+		statedb.SetState(testAddr1, testSlot, common.HexToHash("0x2"))
+		statedb.SetBalance(testAddr2, big.NewInt(2))
+	})
+	root3 := commitState(t, sdb, root2, func(statedb *state.DB) {
+		statedb.SetState(testAddr1, testSlot, common.HexToHash("0x3"))
+	})
+
+	stats, err := p.Prune(state.NewDatabase(db).TrieDB(), []common.Hash{root3, root2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Roots != 2 || stats.Nodes == 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if ok, _ := db.Has(root1.Bytes()); ok {
+		t.Errorf("unretained root not pruned")
+	}
+	if ok, _ := db.Has(crypto.Keccak256([]byte{0x1})); ok {
+		t.Errorf("unretained code not pruned")
+	}
+
+	// The retained states must be complete
+	for i, test := range []struct {
+		root common.Hash
+		slot common.Hash
+		code []byte
+	}{
+		{root2, common.HexToHash("0x2"), []byte{0x2}},
+		{root3, common.HexToHash("0x3"), []byte{0x2}},
+	} {
+		statedb, err := state.New(test.root, state.NewDatabase(db))
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if slot := statedb.GetState(testAddr1, testSlot); slot != test.slot {
+			t.Errorf("Test %v: unexpected slot %v", i, slot.Hex())
+		}
+		if code := statedb.GetCode(testAddr1); len(code) != 1 || code[0] != test.code[0] {
+			t.Errorf("Test %v: unexpected code %x", i, code)
+		}
+		if balance := statedb.GetBalance(testAddr2); balance.Cmp(big.NewInt(2)) != 0 {
+			t.Errorf("Test %v: unexpected balance %v", i, balance)
+		}
+		if err := statedb.Error(); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+
+	// The newest retained state is mandatory
+	if _, err := p.Prune(state.NewDatabase(db).TrieDB(), []common.Hash{root1}); err == nil {
+		t.Errorf("expected error pruning with a missing newest state")
+	}
+}
+
+func TestPruneKeepsFreshNodes(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	p := NewPruner(db, 1)
+	value := []byte("fresh node")
+	key := crypto.Keccak256(value)
+
+	// Simulate a node written while the database is swept
+	p.fresh = make(map[common.Hash]struct{})
+	if err := p.Database().Put(key, value); err != nil {
+		t.Fatal(err)
+	}
+	batch := p.Database().NewBatch()
+	batchValue := []byte("fresh batch node")
+	batch.Put(crypto.Keccak256(batchValue), batchValue)
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	garbage := []byte("garbage node")
+	db.Put(crypto.Keccak256(garbage), garbage)
+	// Entries not keyed by the hash of their value are never swept
+	db.Put(common.HexToHash("0x1").Bytes(), []byte("not a node"))
+
+	stats := &Stats{}
+	if err := p.sweep(newStateBloom(1), stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Nodes != 1 {
+		t.Errorf("unexpected number of pruned nodes %v", stats.Nodes)
+	}
+	for _, k := range [][]byte{key, crypto.Keccak256(batchValue), common.HexToHash("0x1").Bytes()} {
+		if ok, _ := db.Has(k); !ok {
+			t.Errorf("entry %x pruned", k)
+		}
+	}
+	if ok, _ := db.Has(crypto.Keccak256(garbage)); ok {
+		t.Errorf("garbage node not pruned")
+	}
+}
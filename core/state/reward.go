@@ -0,0 +1,39 @@
+package state
+
+import (
+	"math/big"
+
+	stk "github.com/harmony-one/harmony/staking/types"
+)
+
+// RecordRewards makes the state database record the rewards paid to every
+// delegation, available through Rewards.
+func (db *DB) RecordRewards() {
+	db.rewards = []*stk.DelegationReward{}
+}
+
+// Rewards returns the rewards paid since RecordRewards was called and the state
+// was last reset, or nil if they are not recorded.
+func (db *DB) Rewards() []*stk.DelegationReward {
+	return db.rewards
+}
+
+// recordRewards records the rewards paid to the delegations of the validator, the
+// commission going to its self delegation.
+func (db *DB) recordRewards(snapshot *stk.ValidatorWrapper, commission *big.Int, paid []*big.Int) {
+	for i, amount := range paid {
+		reward := &stk.DelegationReward{
+			Validator:  snapshot.Address,
+			Delegator:  snapshot.Delegations[i].DelegatorAddress,
+			Reward:     amount,
+			Commission: new(big.Int),
+		}
+		if i == 0 {
+			reward.Commission = commission
+		}
+		if reward.Reward.Sign() == 0 && reward.Commission.Sign() == 0 {
+			continue
+		}
+		db.rewards = append(db.rewards, reward)
+	}
+}
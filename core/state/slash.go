@@ -0,0 +1,16 @@
+package state
+
+import (
+	stk "github.com/harmony-one/harmony/staking/types"
+)
+
+// AddSlashApplication records the application of a slash to the state, to be
+// written to the slashing history along with the block.
+func (db *DB) AddSlashApplication(app *stk.SlashApplication) {
+	db.slashApplications = append(db.slashApplications, app)
+}
+
+// SlashApplications returns the slashes applied to the state since it was reset.
+func (db *DB) SlashApplications() []*stk.SlashApplication {
+	return db.slashApplications
+}
@@ -0,0 +1,154 @@
+package snapshot
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// diffLayer holds the account and storage changes of a state on top of the
+// layer of its parent state. It is immutable, except for the parent which is
+// replaced when the layers below are merged.
+type diffLayer struct {
+	parent    snapshot
+	root      common.Hash
+	destructs map[common.Hash]struct{}               // accounts wiped before the changes are applied
+	accounts  map[common.Hash][]byte                 // account changes, nil for deletions
+	storage   map[common.Hash]map[common.Hash][]byte // storage changes, nil for deletions
+	memory    uint64                                 // approximate size of the changes
+	stale     bool
+	lock      sync.RWMutex
+}
+
+// newDiffLayer creates a diff layer of the given changes on top of parent.
+func newDiffLayer(
+	parent snapshot, root common.Hash, destructs map[common.Hash]struct{},
+	accounts map[common.Hash][]byte, storage map[common.Hash]map[common.Hash][]byte,
+) *diffLayer {
+	dl := &diffLayer{
+		parent:    parent,
+		root:      root,
+		destructs: destructs,
+		accounts:  accounts,
+		storage:   storage,
+	}
+	dl.memory = uint64(len(destructs) * common.HashLength)
+	for _, data := range accounts {
+		dl.memory += uint64(common.HashLength + len(data))
+	}
+	for _, slots := range storage {
+		for _, data := range slots {
+			dl.memory += uint64(common.HashLength + len(data))
+		}
+	}
+	return dl
+}
+
+// Root returns the root of the state of the layer.
+func (dl *diffLayer) Root() common.Hash {
+	return dl.root
+}
+
+// Parent returns the layer below.
+func (dl *diffLayer) Parent() snapshot {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	return dl.parent
+}
+
+// Stale reports whether the layer was merged into another one.
+func (dl *diffLayer) Stale() bool {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	return dl.stale
+}
+
+// Account returns the RLP encoded account with the given address hash, looking
+// up the layers below if the account did not change in this layer.
+func (dl *diffLayer) Account(hash common.Hash) ([]byte, error) {
+	dl.lock.RLock()
+	if dl.stale {
+		dl.lock.RUnlock()
+		return nil, ErrSnapshotStale
+	}
+	if data, ok := dl.accounts[hash]; ok {
+		dl.lock.RUnlock()
+		return data, nil
+	}
+	if _, ok := dl.destructs[hash]; ok {
+		dl.lock.RUnlock()
+		return nil, nil
+	}
+	parent := dl.parent
+	dl.lock.RUnlock()
+	return parent.Account(hash)
+}
+
+// Storage returns the RLP encoded value of the given storage slot, looking up the
+// layers below if the slot did not change in this layer.
+func (dl *diffLayer) Storage(accountHash, storageHash common.Hash) ([]byte, error) {
+	dl.lock.RLock()
+	if dl.stale {
+		dl.lock.RUnlock()
+		return nil, ErrSnapshotStale
+	}
+	if data, ok := dl.storage[accountHash][storageHash]; ok {
+		dl.lock.RUnlock()
+		return data, nil
+	}
+	if _, ok := dl.destructs[accountHash]; ok {
+		dl.lock.RUnlock()
+		return nil, nil
+	}
+	parent := dl.parent
+	dl.lock.RUnlock()
+	return parent.Storage(accountHash, storageHash)
+}
+
+// flatten merges the diff layers below into a single one on top of the disk
+// layer, and the layer itself into it. The merged layers are marked stale and the
+// returned layer replaces this one.
+func (dl *diffLayer) flatten() snapshot {
+	parent, ok := dl.parent.(*diffLayer)
+	if !ok {
+		return dl
+	}
+	parent = parent.flatten().(*diffLayer)
+
+	parent.lock.Lock()
+	defer parent.lock.Unlock()
+	parent.stale = true
+
+	for hash := range dl.destructs {
+		parent.destructs[hash] = struct{}{}
+		delete(parent.accounts, hash)
+		delete(parent.storage, hash)
+	}
+	for hash, data := range dl.accounts {
+		parent.accounts[hash] = data
+	}
+	for accountHash, slots := range dl.storage {
+		merged, ok := parent.storage[accountHash]
+		if !ok {
+			merged = make(map[common.Hash][]byte, len(slots))
+			parent.storage[accountHash] = merged
+		}
+		for storageHash, data := range slots {
+			merged[storageHash] = data
+		}
+	}
+	dl.lock.Lock()
+	dl.stale = true
+	dl.lock.Unlock()
+
+	return &diffLayer{
+		parent:    parent.parent,
+		root:      dl.root,
+		destructs: parent.destructs,
+		accounts:  parent.accounts,
+		storage:   parent.storage,
+		memory:    parent.memory + dl.memory,
+	}
+}
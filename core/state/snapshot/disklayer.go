@@ -0,0 +1,181 @@
+package snapshot
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/utils"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// diskLayer is the persisted snapshot of a state, possibly still being generated.
+type diskLayer struct {
+	diskdb ethdb.Database
+	triedb *trie.Database
+	cache  *lru.Cache // recently read entries, nil values included
+	root   common.Hash
+	stale  bool
+
+	genMarker  []byte             // hash of the last generated account, nil once generated
+	genErr     error              // error which stopped the generation
+	genPending chan struct{}      // closed when the generation stops
+	genAbort   chan chan struct{} // aborts the generation, closing the sent channel when done
+
+	lock sync.RWMutex
+}
+
+// Root returns the root of the state of the layer.
+func (dl *diskLayer) Root() common.Hash {
+	return dl.root
+}
+
+// Parent returns nil, there is no layer below the disk layer.
+func (dl *diskLayer) Parent() snapshot {
+	return nil
+}
+
+// Stale reports whether the layer was replaced by a newer disk layer.
+func (dl *diskLayer) Stale() bool {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	return dl.stale
+}
+
+// Account returns the RLP encoded account with the given address hash.
+func (dl *diskLayer) Account(hash common.Hash) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return nil, ErrSnapshotStale
+	}
+	if dl.genMarker != nil && bytes.Compare(hash[:], dl.genMarker) > 0 {
+		return nil, ErrNotCoveredYet
+	}
+	key := string(hash[:])
+	if data, ok := dl.cache.Get(key); ok {
+		return data.([]byte), nil
+	}
+	data := rawdb.ReadAccountSnapshot(dl.diskdb, hash)
+	dl.cache.Add(key, data)
+	return data, nil
+}
+
+// Storage returns the RLP encoded value of the given storage slot.
+func (dl *diskLayer) Storage(accountHash, storageHash common.Hash) ([]byte, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	if dl.stale {
+		return nil, ErrSnapshotStale
+	}
+	if dl.genMarker != nil && bytes.Compare(accountHash[:], dl.genMarker) > 0 {
+		return nil, ErrNotCoveredYet
+	}
+	key := string(append(accountHash[:], storageHash[:]...))
+	if data, ok := dl.cache.Get(key); ok {
+		return data.([]byte), nil
+	}
+	data := rawdb.ReadStorageSnapshot(dl.diskdb, accountHash, storageHash)
+	dl.cache.Add(key, data)
+	return data, nil
+}
+
+// generating reports whether the layer is being generated.
+func (dl *diskLayer) generating() bool {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	return dl.genMarker != nil
+}
+
+// generationError returns the error which stopped the generation, if any.
+func (dl *diskLayer) generationError() error {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+
+	return dl.genErr
+}
+
+// abortGeneration stops the generation, if running, and waits for it to return.
+func (dl *diskLayer) abortGeneration() {
+	if dl.genPending == nil {
+		return
+	}
+	abort := make(chan struct{})
+	select {
+	case dl.genAbort <- abort:
+		<-abort
+	case <-dl.genPending:
+	}
+}
+
+// diffToDisk writes the changes of the given diff layer, right above the disk
+// layer, into the database and returns the new disk layer. Both the diff layer
+// and the previous disk layer are marked stale.
+func diffToDisk(bottom *diffLayer) *diskLayer {
+	base := bottom.parent.(*diskLayer)
+	base.lock.Lock()
+	base.stale = true
+	base.lock.Unlock()
+
+	bottom.lock.Lock()
+	bottom.stale = true
+	bottom.lock.Unlock()
+
+	// Invalidate the persisted snapshot until all the changes are written
+	batch := base.diskdb.NewBatch()
+	rawdb.DeleteSnapshotRoot(batch)
+	flush := func() {
+		if batch.ValueSize() < ethdb.IdealBatchSize {
+			return
+		}
+		if err := batch.Write(); err != nil {
+			utils.Logger().Error().Err(err).Msg("[Snapshot] failed to write snapshot")
+		}
+		batch.Reset()
+	}
+	for hash := range bottom.destructs {
+		rawdb.DeleteAccountSnapshot(batch, hash)
+		base.cache.Remove(string(hash[:]))
+
+		it := rawdb.IterateStorageSnapshots(base.diskdb, hash)
+		for it.Next() {
+			key := it.Key()
+			batch.Delete(key)
+			base.cache.Remove(string(append(hash[:], key[len(key)-common.HashLength:]...)))
+			flush()
+		}
+		it.Release()
+	}
+	for hash, data := range bottom.accounts {
+		if len(data) > 0 {
+			rawdb.WriteAccountSnapshot(batch, hash, data)
+		} else {
+			rawdb.DeleteAccountSnapshot(batch, hash)
+		}
+		base.cache.Add(string(hash[:]), data)
+		flush()
+	}
+	for accountHash, slots := range bottom.storage {
+		for storageHash, data := range slots {
+			if len(data) > 0 {
+				rawdb.WriteStorageSnapshot(batch, accountHash, storageHash, data)
+			} else {
+				rawdb.DeleteStorageSnapshot(batch, accountHash, storageHash)
+			}
+			base.cache.Add(string(append(accountHash[:], storageHash[:]...)), data)
+			flush()
+		}
+	}
+	rawdb.WriteSnapshotRoot(batch, bottom.root)
+	if err := batch.Write(); err != nil {
+		utils.Logger().Error().Err(err).Msg("[Snapshot] failed to write snapshot")
+	}
+	return &diskLayer{diskdb: base.diskdb, triedb: base.triedb, cache: base.cache, root: bottom.root}
+}
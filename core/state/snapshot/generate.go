@@ -0,0 +1,146 @@
+package snapshot
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// generateLogInterval is the interval between the generation progress logs.
+const generateLogInterval = 8 * time.Second
+
+// account is the trie representation of an account, as in state.Account.
+type account struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+// generate wipes the persisted snapshot and writes the accounts and storage slots
+// of the state trie of the layer, advancing the generation marker as the entries
+// are written.
+func (dl *diskLayer) generate() {
+	defer close(dl.genPending)
+
+	start := time.Now()
+	if err := rawdb.WipeSnapshot(dl.diskdb); err != nil {
+		dl.failGeneration(err)
+		return
+	}
+	accTrie, err := trie.New(dl.root, dl.triedb)
+	if err != nil {
+		dl.failGeneration(err)
+		return
+	}
+	var (
+		batch    = dl.diskdb.NewBatch()
+		accounts uint64
+		slots    uint64
+		logged   = time.Now()
+	)
+	it := trie.NewIterator(accTrie.NodeIterator(nil))
+	for it.Next() {
+		accountHash := common.BytesToHash(it.Key)
+		rawdb.WriteAccountSnapshot(batch, accountHash, it.Value)
+
+		var acc account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			dl.failGeneration(err)
+			return
+		}
+		if acc.Root != types.EmptyRootHash {
+			storeTrie, err := trie.New(acc.Root, dl.triedb)
+			if err != nil {
+				dl.failGeneration(err)
+				return
+			}
+			storeIt := trie.NewIterator(storeTrie.NodeIterator(nil))
+			for storeIt.Next() {
+				rawdb.WriteStorageSnapshot(batch, accountHash, common.BytesToHash(storeIt.Key), storeIt.Value)
+				slots++
+				// The slots of the account are not read before the marker covers it
+				if batch.ValueSize() >= ethdb.IdealBatchSize {
+					if err := batch.Write(); err != nil {
+						dl.failGeneration(err)
+						return
+					}
+					batch.Reset()
+				}
+			}
+			if storeIt.Err != nil {
+				dl.failGeneration(storeIt.Err)
+				return
+			}
+		}
+		accounts++
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				dl.failGeneration(err)
+				return
+			}
+			batch.Reset()
+			dl.lock.Lock()
+			dl.genMarker = common.CopyBytes(it.Key)
+			dl.lock.Unlock()
+		}
+		select {
+		case abort := <-dl.genAbort:
+			utils.Logger().Info().
+				Uint64("accounts", accounts).
+				Uint64("slots", slots).
+				Msg("[Snapshot] aborted state snapshot generation")
+			close(abort)
+			return
+		default:
+		}
+		if time.Since(logged) > generateLogInterval {
+			utils.Logger().Info().
+				Uint64("accounts", accounts).
+				Uint64("slots", slots).
+				Dur("elapsed", time.Since(start)).
+				Msg("[Snapshot] generating state snapshot")
+			logged = time.Now()
+		}
+	}
+	if it.Err != nil {
+		dl.failGeneration(it.Err)
+		return
+	}
+	rawdb.WriteSnapshotRoot(batch, dl.root)
+	rawdb.DeleteSnapshotGenerating(batch)
+	if err := batch.Write(); err != nil {
+		dl.failGeneration(err)
+		return
+	}
+	dl.lock.Lock()
+	dl.genMarker = nil
+	dl.lock.Unlock()
+
+	utils.Logger().Info().
+		Str("root", dl.root.Hex()).
+		Uint64("accounts", accounts).
+		Uint64("slots", slots).
+		Dur("elapsed", time.Since(start)).
+		Msg("[Snapshot] generated state snapshot")
+}
+
+// failGeneration stops the generation with the given error. The entries which
+// are not generated keep being read from the state tries.
+func (dl *diskLayer) failGeneration(err error) {
+	dl.lock.Lock()
+	dl.genErr = err
+	dl.lock.Unlock()
+
+	utils.Logger().Warn().Err(err).
+		Str("root", dl.root.Hex()).
+		Msg("[Snapshot] state snapshot generation failed")
+}
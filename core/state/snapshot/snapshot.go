@@ -0,0 +1,296 @@
+// Package snapshot implements a flat, key-value representation of the state on
+// top of the state tries, so that accounts and storage slots are read with a
+// single database lookup instead of a trie traversal.
+//
+// The disk layer persists the accounts and storage slots of one state, keyed by
+// the hashes of their trie keys. The changes of every following block are kept
+// in memory as a diff layer on top of the layer of its parent state, and the
+// oldest diff layers are merged into the disk layer as the chain progresses.
+package snapshot
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/utils"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+)
+
+const (
+	// aggregatorMemoryLimit is the memory of the bottom diff layer, merging the
+	// oldest blocks, above which it is written into the disk layer.
+	aggregatorMemoryLimit = 4 * 1024 * 1024
+
+	// cacheEntrySize is the estimated size of an entry of the disk layer cache.
+	cacheEntrySize = 128
+)
+
+var (
+	// ErrSnapshotStale is returned from the data accessors of a layer which was
+	// merged into another one and cannot be read anymore.
+	ErrSnapshotStale = errors.New("snapshot stale")
+	// ErrNotCoveredYet is returned from the data accessors when the requested
+	// entry is not generated yet.
+	ErrNotCoveredYet = errors.New("not covered yet")
+)
+
+// Snapshot is the flat representation of a state.
+type Snapshot interface {
+	// Root returns the root of the state the snapshot represents.
+	Root() common.Hash
+
+	// Account returns the RLP encoded account with the given address hash, or nil
+	// if the account does not exist.
+	Account(hash common.Hash) ([]byte, error)
+
+	// Storage returns the RLP encoded value of the storage slot with the given key
+	// hash in the account with the given address hash, or nil if the slot is empty.
+	Storage(accountHash, storageHash common.Hash) ([]byte, error)
+}
+
+// snapshot is a layer of the snapshot tree.
+type snapshot interface {
+	Snapshot
+
+	// Parent returns the layer below, or nil for the disk layer.
+	Parent() snapshot
+
+	// Stale reports whether the layer was merged into another one.
+	Stale() bool
+}
+
+// Tree is the set of snapshot layers: a single disk layer and the diff layers of
+// the recent states above it, forming a tree as the chain may fork.
+type Tree struct {
+	diskdb ethdb.Database
+	triedb *trie.Database
+	cache  int // megabytes of the disk layer cache
+	layers map[common.Hash]snapshot
+	lock   sync.RWMutex
+}
+
+// New creates the snapshot tree of the state with the given root. The persisted
+// snapshot is reused if it matches the root, otherwise it is regenerated in the
+// background from the state trie, which must be available on disk.
+func New(diskdb ethdb.Database, triedb *trie.Database, cache int, root common.Hash) *Tree {
+	t := &Tree{
+		diskdb: diskdb,
+		triedb: triedb,
+		cache:  cache,
+		layers: make(map[common.Hash]snapshot),
+	}
+	if rawdb.ReadSnapshotRoot(diskdb) == root && !rawdb.ReadSnapshotGenerating(diskdb) {
+		t.layers[root] = &diskLayer{diskdb: diskdb, triedb: triedb, cache: t.newCache(), root: root}
+		utils.Logger().Info().Str("root", root.Hex()).Msg("[Snapshot] loaded state snapshot")
+		return t
+	}
+	t.layers[root] = t.generate(root)
+	return t
+}
+
+// newCache creates the cache of a disk layer.
+func (t *Tree) newCache() *lru.Cache {
+	size := t.cache * 1024 * 1024 / cacheEntrySize
+	if size < 1 {
+		size = 1
+	}
+	cache, _ := lru.New(size)
+	return cache
+}
+
+// generate wipes the persisted snapshot and starts generating the disk layer of
+// the state with the given root.
+func (t *Tree) generate(root common.Hash) *diskLayer {
+	rawdb.WriteSnapshotGenerating(t.diskdb)
+	base := &diskLayer{
+		diskdb:     t.diskdb,
+		triedb:     t.triedb,
+		cache:      t.newCache(),
+		root:       root,
+		genMarker:  []byte{},
+		genPending: make(chan struct{}),
+		genAbort:   make(chan chan struct{}),
+	}
+	go base.generate()
+	return base
+}
+
+// Snapshot returns the snapshot of the state with the given root, or nil if
+// there is none.
+func (t *Tree) Snapshot(root common.Hash) Snapshot {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	layer, ok := t.layers[root]
+	if !ok {
+		return nil
+	}
+	return layer
+}
+
+// Update adds the diff layer of the state with the given root on top of the
+// layer of its parent state. Destructed accounts have their storage wiped before
+// the account and storage changes are applied, nil values being deletions.
+func (t *Tree) Update(
+	root, parentRoot common.Hash, destructs map[common.Hash]struct{},
+	accounts map[common.Hash][]byte, storage map[common.Hash]map[common.Hash][]byte,
+) error {
+	if root == parentRoot {
+		return fmt.Errorf("snapshot cycle at %s", root.Hex())
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.layers[root]; ok {
+		return nil
+	}
+	parent, ok := t.layers[parentRoot]
+	if !ok {
+		return fmt.Errorf("parent snapshot %s missing", parentRoot.Hex())
+	}
+	t.layers[root] = newDiffLayer(parent, root, destructs, accounts, storage)
+	return nil
+}
+
+// Cap keeps at most the given number of diff layers below and including the layer
+// of the given root, merging the older ones. The merged layers are written into
+// the disk layer once large enough, or always if layers is zero, unless the disk
+// layer is still being generated. The layers of the forks below the kept ones are
+// dropped.
+func (t *Tree) Cap(root common.Hash, layers int) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	layer, ok := t.layers[root]
+	if !ok {
+		return fmt.Errorf("snapshot %s missing", root.Hex())
+	}
+	diff, ok := layer.(*diffLayer)
+	if !ok {
+		return nil
+	}
+	if err := diskLayerOf(diff).generationError(); err != nil {
+		t.disable()
+		return errors.Wrap(err, "snapshot generation failed")
+	}
+	t.cap(diff, layers)
+
+	for root, layer := range t.layers {
+		if isStale(layer) {
+			delete(t.layers, root)
+		}
+	}
+	return nil
+}
+
+// cap merges the layers below the given number of diff layers from diff. The tree
+// lock must be held.
+func (t *Tree) cap(diff *diffLayer, layers int) {
+	if layers == 0 {
+		bottom := diff.flatten().(*diffLayer)
+		t.layers[bottom.root] = bottom
+		if base := bottom.parent.(*diskLayer); !base.generating() {
+			t.layers[bottom.root] = diffToDisk(bottom)
+		}
+		return
+	}
+	for i := 0; i < layers-1; i++ {
+		parent, ok := diff.parent.(*diffLayer)
+		if !ok {
+			return
+		}
+		diff = parent
+	}
+	parent, ok := diff.parent.(*diffLayer)
+	if !ok {
+		return
+	}
+	bottom := parent.flatten().(*diffLayer)
+	t.layers[bottom.root] = bottom
+
+	diff.lock.Lock()
+	defer diff.lock.Unlock()
+	diff.parent = bottom
+	if bottom.memory < aggregatorMemoryLimit || bottom.parent.(*diskLayer).generating() {
+		return
+	}
+	base := diffToDisk(bottom)
+	t.layers[base.root] = base
+	diff.parent = base
+}
+
+// Stop aborts the snapshot generation if it is running, in which case the
+// snapshot is regenerated on restart. Otherwise the diff layers up to the given
+// root are written into the disk layer, for the snapshot to be reused on restart.
+func (t *Tree) Stop(root common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, layer := range t.layers {
+		if base, ok := layer.(*diskLayer); ok && base.generating() {
+			base.abortGeneration()
+			t.disable()
+			return
+		}
+	}
+	if diff, ok := t.layers[root].(*diffLayer); ok {
+		t.cap(diff, 0)
+	}
+}
+
+// Rebuild drops all the layers and regenerates the snapshot of the state with the
+// given root, which must be available on disk.
+func (t *Tree) Rebuild(root common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, layer := range t.layers {
+		if base, ok := layer.(*diskLayer); ok {
+			base.abortGeneration()
+		}
+	}
+	t.disable()
+	t.layers[root] = t.generate(root)
+}
+
+// disable marks all the layers stale and drops them. The tree lock must be held.
+func (t *Tree) disable() {
+	for _, layer := range t.layers {
+		switch layer := layer.(type) {
+		case *diskLayer:
+			layer.lock.Lock()
+			layer.stale = true
+			layer.lock.Unlock()
+		case *diffLayer:
+			layer.lock.Lock()
+			layer.stale = true
+			layer.lock.Unlock()
+		}
+	}
+	t.layers = make(map[common.Hash]snapshot)
+}
+
+// diskLayerOf returns the disk layer below the given layer.
+func diskLayerOf(layer snapshot) *diskLayer {
+	for {
+		if base, ok := layer.(*diskLayer); ok {
+			return base
+		}
+		layer = layer.Parent()
+	}
+}
+
+// isStale reports whether the given layer or any layer below is stale.
+func isStale(layer snapshot) bool {
+	for ; layer != nil; layer = layer.Parent() {
+		if layer.Stale() {
+			return true
+		}
+	}
+	return false
+}
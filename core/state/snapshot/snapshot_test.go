@@ -0,0 +1,203 @@
+package snapshot
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+var (
+	testAcc1  = common.HexToHash("0x01")
+	testAcc2  = common.HexToHash("0x02")
+	testSlot1 = common.HexToHash("0x11")
+	testSlot2 = common.HexToHash("0x12")
+)
+
+// newTestTree creates a tree with a generated disk layer holding the given accounts
+// and storage slots.
+func newTestTree(
+	root common.Hash, accounts map[common.Hash][]byte, storage map[common.Hash]map[common.Hash][]byte,
+) *Tree {
+	db := ethRawDB.NewMemoryDatabase()
+	for hash, data := range accounts {
+		rawdb.WriteAccountSnapshot(db, hash, data)
+	}
+	for accountHash, slots := range storage {
+		for storageHash, data := range slots {
+			rawdb.WriteStorageSnapshot(db, accountHash, storageHash, data)
+		}
+	}
+	rawdb.WriteSnapshotRoot(db, root)
+	return New(db, trie.NewDatabase(db), 1, root)
+}
+
+func checkAccount(t *testing.T, snap Snapshot, hash common.Hash, exp []byte) {
+	t.Helper()
+	data, err := snap.Account(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, exp) {
+		t.Errorf("account %x at %x: have %x, want %x", hash, snap.Root(), data, exp)
+	}
+}
+
+func checkStorage(t *testing.T, snap Snapshot, accountHash, storageHash common.Hash, exp []byte) {
+	t.Helper()
+	data, err := snap.Storage(accountHash, storageHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, exp) {
+		t.Errorf("slot %x of %x at %x: have %x, want %x", storageHash, accountHash, snap.Root(), data, exp)
+	}
+}
+
+func TestDiffLayers(t *testing.T) {
+	var (
+		root0 = common.HexToHash("0xa0")
+		root1 = common.HexToHash("0xa1")
+		root2 = common.HexToHash("0xa2")
+	)
+	tree := newTestTree(root0,
+		map[common.Hash][]byte{testAcc1: {0x1}, testAcc2: {0x2}},
+		map[common.Hash]map[common.Hash][]byte{testAcc1: {testSlot1: {0x1}, testSlot2: {0x2}}},
+	)
+	// Block 1 changes a slot and deletes an account
+	if err := tree.Update(root1, root0, map[common.Hash]struct{}{testAcc2: {}},
+		map[common.Hash][]byte{},
+		map[common.Hash]map[common.Hash][]byte{testAcc1: {testSlot1: {0x11}}},
+	); err != nil {
+		t.Fatal(err)
+	}
+	// Block 2 recreates the first account, wiping its storage
+	if err := tree.Update(root2, root1, map[common.Hash]struct{}{testAcc1: {}},
+		map[common.Hash][]byte{testAcc1: {0x21}},
+		map[common.Hash]map[common.Hash][]byte{testAcc1: {testSlot2: {0x22}}},
+	); err != nil {
+		t.Fatal(err)
+	}
+	check := func() {
+		snap := tree.Snapshot(root2)
+		checkAccount(t, snap, testAcc1, []byte{0x21})
+		checkAccount(t, snap, testAcc2, nil)
+		checkStorage(t, snap, testAcc1, testSlot1, nil)
+		checkStorage(t, snap, testAcc1, testSlot2, []byte{0x22})
+	}
+	checkStorage(t, tree.Snapshot(root1), testAcc1, testSlot1, []byte{0x11})
+	checkStorage(t, tree.Snapshot(root1), testAcc1, testSlot2, []byte{0x2})
+	checkAccount(t, tree.Snapshot(root1), testAcc2, nil)
+	check()
+
+	// Keeping the layers must not change the top state
+	if err := tree.Cap(root2, 1); err != nil {
+		t.Fatal(err)
+	}
+	check()
+
+	// Writing everything into the disk layer must not either
+	old := tree.Snapshot(root1)
+	if err := tree.Cap(root2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tree.Snapshot(root2).(*diskLayer); !ok {
+		t.Fatalf("expected disk layer, got %T", tree.Snapshot(root2))
+	}
+	check()
+	if _, err := old.Account(testAcc1); err != ErrSnapshotStale {
+		t.Errorf("expected merged layer to be stale, got %v", err)
+	}
+	if tree.Snapshot(root0) != nil || tree.Snapshot(root1) != nil {
+		t.Errorf("merged layers still in the tree")
+	}
+	if root := rawdb.ReadSnapshotRoot(tree.diskdb); root != root2 {
+		t.Errorf("unexpected persisted root %x", root)
+	}
+}
+
+func TestCapDropsForks(t *testing.T) {
+	var (
+		root0 = common.HexToHash("0xa0")
+		root1 = common.HexToHash("0xa1")
+		root2 = common.HexToHash("0xa2")
+		root3 = common.HexToHash("0xa3")
+		fork2 = common.HexToHash("0xb2")
+	)
+	tree := newTestTree(root0, map[common.Hash][]byte{testAcc1: {0x1}}, nil)
+	for _, update := range []struct{ root, parent common.Hash }{
+		{root1, root0}, {root2, root1}, {fork2, root1}, {root3, root2},
+	} {
+		if err := tree.Update(update.root, update.parent, map[common.Hash]struct{}{},
+			map[common.Hash][]byte{testAcc1: update.root[:1]}, map[common.Hash]map[common.Hash][]byte{},
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The bottom layers are merged into a single one below the kept layer
+	if err := tree.Cap(root3, 1); err != nil {
+		t.Fatal(err)
+	}
+	if tree.Snapshot(root1) != nil || tree.Snapshot(root2) == nil {
+		t.Errorf("bottom layers not merged")
+	}
+	if tree.Snapshot(fork2) != nil {
+		t.Errorf("fork below the kept layers not dropped")
+	}
+	checkAccount(t, tree.Snapshot(root3), testAcc1, root3[:1])
+	checkAccount(t, tree.Snapshot(root2), testAcc1, root2[:1])
+}
+
+func TestGenerate(t *testing.T) {
+	db := ethRawDB.NewMemoryDatabase()
+	triedb := trie.NewDatabase(db)
+
+	storageTrie, _ := trie.NewSecure(common.Hash{}, triedb)
+	slotValue, _ := rlp.EncodeToBytes([]byte{0x1})
+	storageTrie.Update(testSlot1[:], slotValue)
+	storageRoot, _ := storageTrie.Commit(nil)
+
+	addr := common.HexToAddress("0x1")
+	accountData, _ := rlp.EncodeToBytes(&account{
+		Balance: big.NewInt(1), Root: storageRoot, CodeHash: crypto.Keccak256(nil),
+	})
+	emptyData, _ := rlp.EncodeToBytes(&account{
+		Balance: big.NewInt(2), Root: types.EmptyRootHash, CodeHash: crypto.Keccak256(nil),
+	})
+	accTrie, _ := trie.NewSecure(common.Hash{}, triedb)
+	accTrie.Update(addr[:], accountData)
+	accTrie.Update(common.HexToAddress("0x2").Bytes(), emptyData)
+	root, _ := accTrie.Commit(nil)
+	if err := triedb.Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+
+	tree := New(db, triedb, 1, root)
+	base := tree.Snapshot(root).(*diskLayer)
+	<-base.genPending
+	if base.generating() || base.generationError() != nil {
+		t.Fatalf("generation not done: %v", base.generationError())
+	}
+	accountHash := crypto.Keccak256Hash(addr[:])
+	checkAccount(t, base, accountHash, accountData)
+	checkAccount(t, base, crypto.Keccak256Hash(common.HexToAddress("0x2").Bytes()), emptyData)
+	checkStorage(t, base, accountHash, crypto.Keccak256Hash(testSlot1[:]), slotValue)
+
+	// The generated snapshot is reused
+	if base := New(db, triedb, 1, root).Snapshot(root).(*diskLayer); base.genPending != nil {
+		t.Errorf("snapshot regenerated")
+	}
+	// Unless it does not match the state
+	if base := New(db, triedb, 1, storageRoot).Snapshot(storageRoot).(*diskLayer); base.genPending == nil {
+		t.Errorf("mismatching snapshot reused")
+	} else {
+		<-base.genPending
+	}
+}
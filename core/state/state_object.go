@@ -96,6 +96,7 @@ type Object struct {
 	dirtyCode bool // true if the code was updated
 	suicided  bool
 	deleted   bool
+	recreated bool // true if the object replaced an existing account since the last update
 }
 
 // empty returns whether the account is considered empty.
@@ -205,8 +206,21 @@ func (s *Object) GetCommittedState(db Database, key common.Hash) common.Hash {
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { s.db.StorageReads += time.Since(start) }(time.Now())
 	}
-	// Otherwise load the value from the database
-	enc, err := s.getTrie(db).TryGet(key[:])
+	// Otherwise load the value from the snapshot if available, from the trie otherwise
+	var (
+		enc []byte
+		err error
+	)
+	if s.db.snap != nil {
+		// The storage of a replaced account is wiped
+		if _, destructed := s.db.snapDestructs[s.addrHash]; destructed || s.recreated {
+			return common.Hash{}
+		}
+		enc, err = s.db.snap.Storage(s.addrHash, crypto.Keccak256Hash(key[:]))
+	}
+	if s.db.snap == nil || err != nil {
+		enc, err = s.getTrie(db).TryGet(key[:])
+	}
 	if err != nil {
 		s.setError(err)
 		return common.Hash{}
@@ -288,6 +302,7 @@ func (s *Object) updateTrie(db Database) Trie {
 	}
 	// Insert all the pending updates into the trie
 	tr := s.getTrie(db)
+	var storage map[common.Hash][]byte
 	for key, value := range s.pendingStorage {
 		// Skip noop changes, persist actual changes
 		if value == s.originStorage[key] {
@@ -295,13 +310,24 @@ func (s *Object) updateTrie(db Database) Trie {
 		}
 		s.originStorage[key] = value
 
+		var v []byte
 		if (value == common.Hash{}) {
 			s.setError(tr.TryDelete(key[:]))
-			continue
+		} else {
+			// Encoding []byte cannot fail, ok to ignore the error.
+			v, _ = rlp.EncodeToBytes(common.TrimLeftZeroes(value[:]))
+			s.setError(tr.TryUpdate(key[:], v))
+		}
+		// Keep track of the changed slots for the snapshot
+		if s.db.snap != nil {
+			if storage == nil {
+				if storage = s.db.snapStorage[s.addrHash]; storage == nil {
+					storage = make(map[common.Hash][]byte)
+					s.db.snapStorage[s.addrHash] = storage
+				}
+			}
+			storage[crypto.Keccak256Hash(key[:])] = v
 		}
-		// Encoding []byte cannot fail, ok to ignore the error.
-		v, _ := rlp.EncodeToBytes(common.TrimLeftZeroes(value[:]))
-		s.setError(tr.TryUpdate(key[:], v))
 	}
 	if len(s.pendingStorage) > 0 {
 		s.pendingStorage = make(Storage)
@@ -390,6 +416,7 @@ func (s *Object) deepCopy(db *DB) *Object {
 	stateObject.suicided = s.suicided
 	stateObject.dirtyCode = s.dirtyCode
 	stateObject.deleted = s.deleted
+	stateObject.recreated = s.recreated
 	return stateObject
 }
 
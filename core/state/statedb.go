@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 	common2 "github.com/harmony-one/harmony/internal/common"
 	"github.com/harmony-one/harmony/internal/utils"
@@ -74,6 +75,22 @@ type DB struct {
 	db   Database
 	trie Trie
 
+	// Flat snapshot of the state, and the changes to add on top of it on commit
+	snaps         *snapshot.Tree
+	snap          snapshot.Snapshot
+	snapDestructs map[common.Hash]struct{}
+	snapAccounts  map[common.Hash][]byte
+	snapStorage   map[common.Hash]map[common.Hash][]byte
+
+	// Changes made by every transaction, nil if they are not recorded
+	stateDiffs []*types.StateDiff
+
+	// Slashes applied while finalising the block
+	slashApplications []*stk.SlashApplication
+
+	// Rewards paid to the delegations, nil if they are not recorded
+	rewards []*stk.DelegationReward
+
 	// This map holds 'live' objects, which will get modified while processing a state transition.
 	stateObjects        map[common.Address]*Object
 	stateObjectsPending map[common.Address]struct{} // State objects finalized but not yet written to the trie
@@ -97,6 +114,12 @@ type DB struct {
 
 	preimages map[common.Hash][]byte
 
+	// Transient storage of the current transaction (EIP-1153)
+	transientStorage transientStorage
+
+	// Warm addresses and slots of the current transaction (EIP-2929)
+	accessList *accessList
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal        *journal
@@ -116,11 +139,17 @@ type DB struct {
 
 // New creates a new state from a given trie.
 func New(root common.Hash, db Database) (*DB, error) {
+	return NewWithSnapshots(root, db, nil)
+}
+
+// NewWithSnapshots creates a new state from a given trie, reading the accounts
+// and storage slots from the snapshot of the state in snaps when available.
+func NewWithSnapshots(root common.Hash, db Database, snaps *snapshot.Tree) (*DB, error) {
 	tr, err := db.OpenTrie(root)
 	if err != nil {
 		return nil, err
 	}
-	return &DB{
+	sdb := &DB{
 		db:                  db,
 		trie:                tr,
 		stateObjects:        make(map[common.Address]*Object),
@@ -129,8 +158,27 @@ func New(root common.Hash, db Database) (*DB, error) {
 		stateValidators:     make(map[common.Address]*stk.ValidatorWrapper),
 		logs:                make(map[common.Hash][]*types.Log),
 		preimages:           make(map[common.Hash][]byte),
+		transientStorage:    newTransientStorage(),
+		accessList:          newAccessList(),
 		journal:             newJournal(),
-	}, nil
+		snaps:               snaps,
+	}
+	sdb.resetSnapshot(root)
+	return sdb, nil
+}
+
+// resetSnapshot selects the snapshot of the state with the given root and clears
+// the changes to add on top of it.
+func (db *DB) resetSnapshot(root common.Hash) {
+	db.snap, db.snapDestructs, db.snapAccounts, db.snapStorage = nil, nil, nil, nil
+	if db.snaps == nil {
+		return
+	}
+	if db.snap = db.snaps.Snapshot(root); db.snap != nil {
+		db.snapDestructs = make(map[common.Hash]struct{})
+		db.snapAccounts = make(map[common.Hash][]byte)
+		db.snapStorage = make(map[common.Hash]map[common.Hash][]byte)
+	}
 }
 
 // setError remembers the first non-nil error it is called with.
@@ -162,6 +210,16 @@ func (db *DB) Reset(root common.Hash) error {
 	db.logs = make(map[common.Hash][]*types.Log)
 	db.logSize = 0
 	db.preimages = make(map[common.Hash][]byte)
+	db.transientStorage = newTransientStorage()
+	db.accessList = newAccessList()
+	db.resetSnapshot(root)
+	if db.stateDiffs != nil {
+		db.stateDiffs = []*types.StateDiff{}
+	}
+	db.slashApplications = nil
+	if db.rewards != nil {
+		db.rewards = []*stk.DelegationReward{}
+	}
 	db.clearJournalAndRefund()
 	return nil
 }
@@ -418,6 +476,40 @@ func (db *DB) SetState(addr common.Address, key, value common.Hash) {
 	}
 }
 
+// GetTransientState retrieves a value from the transient storage of the given account.
+func (db *DB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
+	return db.transientStorage.Get(addr, key)
+}
+
+// SetTransientState sets a value in the transient storage of the given account
+// and journals the change so it is reverted with the call frame.
+func (db *DB) SetTransientState(addr common.Address, key, value common.Hash) {
+	prev := db.GetTransientState(addr, key)
+	if prev == value {
+		return
+	}
+	db.journal.append(transientStorageChange{
+		account:  &addr,
+		key:      key,
+		prevalue: prev,
+	})
+	db.setTransientState(addr, key, value)
+}
+
+// setTransientState sets a transient storage value without journaling.
+func (db *DB) setTransientState(addr common.Address, key, value common.Hash) {
+	db.transientStorage.Set(addr, key, value)
+}
+
+// SetStorage replaces the entire storage for the specified account with given
+// storage. This function should only be used for debugging.
+func (db *DB) SetStorage(addr common.Address, storage map[common.Hash]common.Hash) {
+	stateObject := db.GetOrNewStateObject(addr)
+	if stateObject != nil {
+		stateObject.SetStorage(storage)
+	}
+}
+
 // Suicide marks the given account as suicided.
 // This clears the account balance.
 //
@@ -457,6 +549,10 @@ func (db *DB) updateStateObject(obj *Object) {
 		panic(fmt.Errorf("can't encode object at %x: %v", addr[:], err))
 	}
 	db.setError(db.trie.TryUpdate(addr[:], data))
+
+	if db.snap != nil {
+		db.snapAccounts[obj.addrHash] = data
+	}
 }
 
 // deleteStateObject removes the given object from the state trie.
@@ -468,6 +564,18 @@ func (db *DB) deleteStateObject(obj *Object) {
 	// Delete the account from the trie
 	addr := obj.Address()
 	db.setError(db.trie.TryDelete(addr[:]))
+
+	if db.snap != nil {
+		db.destructSnapshot(obj.addrHash)
+		delete(db.snapAccounts, obj.addrHash)
+	}
+}
+
+// destructSnapshot wipes the account storage from the snapshot, dropping the
+// storage changes collected so far.
+func (db *DB) destructSnapshot(addrHash common.Hash) {
+	db.snapDestructs[addrHash] = struct{}{}
+	delete(db.snapStorage, addrHash)
 }
 
 // getStateObject retrieves a state object given by the address, returning nil if
@@ -493,8 +601,17 @@ func (db *DB) getDeletedStateObject(addr common.Address) *Object {
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { db.AccountReads += time.Since(start) }(time.Now())
 	}
-	// Load the object from the database
-	enc, err := db.trie.TryGet(addr[:])
+	// Load the object from the snapshot if available, from the trie otherwise
+	var (
+		enc []byte
+		err error
+	)
+	if db.snap != nil {
+		enc, err = db.snap.Account(crypto.Keccak256Hash(addr[:]))
+	}
+	if db.snap == nil || err != nil {
+		enc, err = db.trie.TryGet(addr[:])
+	}
 	if len(enc) == 0 {
 		db.setError(err)
 		return nil
@@ -530,6 +647,7 @@ func (db *DB) createObject(addr common.Address) (newobj, prev *Object) {
 
 	newobj = newObject(db, addr, Account{})
 	newobj.setNonce(0) // sets the object to dirty
+	newobj.recreated = prev != nil
 	if prev == nil {
 		db.journal.append(createObjectChange{account: &addr})
 	} else {
@@ -601,7 +719,29 @@ func (db *DB) Copy() *DB {
 		logs:                make(map[common.Hash][]*types.Log, len(db.logs)),
 		logSize:             db.logSize,
 		preimages:           make(map[common.Hash][]byte),
+		transientStorage:    db.transientStorage.Copy(),
+		accessList:          db.accessList.Copy(),
 		journal:             newJournal(),
+		snaps:               db.snaps,
+		snap:                db.snap,
+	}
+	if db.snap != nil {
+		state.snapDestructs = make(map[common.Hash]struct{}, len(db.snapDestructs))
+		for hash := range db.snapDestructs {
+			state.snapDestructs[hash] = struct{}{}
+		}
+		state.snapAccounts = make(map[common.Hash][]byte, len(db.snapAccounts))
+		for hash, data := range db.snapAccounts {
+			state.snapAccounts[hash] = data
+		}
+		state.snapStorage = make(map[common.Hash]map[common.Hash][]byte, len(db.snapStorage))
+		for hash, slots := range db.snapStorage {
+			copied := make(map[common.Hash][]byte, len(slots))
+			for key, data := range slots {
+				copied[key] = data
+			}
+			state.snapStorage[hash] = copied
+		}
 	}
 	// Copy the dirty states, logs, and preimages
 	for addr := range db.journal.dirties {
@@ -709,6 +849,9 @@ func (db *DB) Finalise(deleteEmptyObjects bool) {
 		db.stateObjectsPending[addr] = struct{}{}
 		db.stateObjectsDirty[addr] = struct{}{}
 	}
+	if db.stateDiffs != nil {
+		db.recordStateDiff()
+	}
 	// Invalidate journal because reverting across transactions is not allowed.
 	db.clearJournalAndRefund()
 }
@@ -725,6 +868,11 @@ func (db *DB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 		if obj.deleted {
 			db.deleteStateObject(obj)
 		} else {
+			// The storage of a replaced account is wiped
+			if obj.recreated && db.snap != nil {
+				db.destructSnapshot(obj.addrHash)
+			}
+			obj.recreated = false
 			obj.updateRoot(db.db)
 			db.updateStateObject(obj)
 		}
@@ -740,11 +888,69 @@ func (db *DB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 }
 
 // Prepare sets the current transaction hash and index and block hash which is
-// used when the EVM emits new state logs.
+// used when the EVM emits new state logs. It also discards the transient storage
+// and the access list of the previous transaction.
 func (db *DB) Prepare(thash, bhash common.Hash, ti int) {
 	db.thash = thash
 	db.bhash = bhash
 	db.txIndex = ti
+	db.transientStorage = newTransientStorage()
+	db.accessList = newAccessList()
+}
+
+// PrepareAccessList warms up the sender, the recipient, the precompiles and
+// the EIP-2930 access list of a transaction (EIP-2929). It must be called
+// after Prepare, before the transaction is executed.
+func (db *DB) PrepareAccessList(sender common.Address, dst *common.Address, precompiles []common.Address, list types.AccessList) {
+	db.AddAddressToAccessList(sender)
+	if dst != nil {
+		db.AddAddressToAccessList(*dst)
+		// If it's a create-tx, the destination will be added inside evm.create
+	}
+	for _, addr := range precompiles {
+		db.AddAddressToAccessList(addr)
+	}
+	for _, el := range list {
+		db.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			db.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
+// AddAddressToAccessList adds the given address to the access list
+func (db *DB) AddAddressToAccessList(addr common.Address) {
+	if db.accessList.AddAddress(addr) {
+		db.journal.append(accessListAddAccountChange{&addr})
+	}
+}
+
+// AddSlotToAccessList adds the given (address, slot)-tuple to the access list
+func (db *DB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	addrMod, slotMod := db.accessList.AddSlot(addr, slot)
+	if addrMod {
+		// In practice, this should not happen, since there is no way to enter the
+		// scope of 'address' without having the 'address' become already added
+		// to the access list (via call-variant, create, etc).
+		// Better safe than sorry, though
+		db.journal.append(accessListAddAccountChange{&addr})
+	}
+	if slotMod {
+		db.journal.append(accessListAddSlotChange{
+			address: &addr,
+			slot:    &slot,
+		})
+	}
+}
+
+// AddressInAccessList returns true if the given address is in the access list.
+func (db *DB) AddressInAccessList(addr common.Address) bool {
+	return db.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList returns true if the given (address, slot)-tuple is in the access list.
+func (db *DB) SlotInAccessList(addr common.Address, slot common.Hash) (addressPresent bool, slotPresent bool) {
+	return db.accessList.Contains(addr, slot)
 }
 
 func (db *DB) clearJournalAndRefund() {
@@ -781,7 +987,7 @@ func (db *DB) Commit(deleteEmptyObjects bool) (root common.Hash, err error) {
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { db.AccountCommits += time.Since(start) }(time.Now())
 	}
-	return db.trie.Commit(func(leaf []byte, parent common.Hash) error {
+	root, err = db.trie.Commit(func(leaf []byte, parent common.Hash) error {
 		var account Account
 		if err := rlp.DecodeBytes(leaf, &account); err != nil {
 			return nil
@@ -795,6 +1001,25 @@ func (db *DB) Commit(deleteEmptyObjects bool) (root common.Hash, err error) {
 		}
 		return nil
 	})
+	// Add the changes on top of the snapshot, keeping as many diff layers as
+	// tries in memory
+	if err == nil && db.snap != nil {
+		if parent := db.snap.Root(); parent != root {
+			if err := db.snaps.Update(root, parent, db.snapDestructs, db.snapAccounts, db.snapStorage); err != nil {
+				utils.Logger().Warn().Err(err).
+					Str("root", root.Hex()).
+					Str("parent", parent.Hex()).
+					Msg("Failed to update snapshot")
+			}
+			if err := db.snaps.Cap(root, 128); err != nil {
+				utils.Logger().Warn().Err(err).
+					Str("root", root.Hex()).
+					Msg("Failed to cap snapshot tree")
+			}
+		}
+		db.snap, db.snapDestructs, db.snapAccounts, db.snapStorage = nil, nil, nil, nil
+	}
+	return root, err
 }
 
 var (
@@ -963,9 +1188,16 @@ func (db *DB) AddReward(snapshot *stk.ValidatorWrapper, reward *big.Int, shareLo
 
 	rewardPool := big.NewInt(0).Set(reward)
 	curValidator.BlockReward.Add(curValidator.BlockReward, reward)
+	// The rewards paid to each delegation, if they are recorded
+	var paid []*big.Int
+	if db.rewards != nil {
+		paid = make([]*big.Int, len(snapshot.Delegations))
+	}
+	commission := big.NewInt(0)
 	// Payout commission
 	if r := snapshot.Validator.CommissionRates.Rate; r.GT(zero) {
 		commissionInt := r.MulInt(reward).RoundInt()
+		commission = commissionInt
 		curValidator.Delegations[0].Reward.Add(
 			curValidator.Delegations[0].Reward,
 			commissionInt,
@@ -987,12 +1219,21 @@ func (db *DB) AddReward(snapshot *stk.ValidatorWrapper, reward *big.Int, shareLo
 		curDelegation := curValidator.Delegations[i]
 		curDelegation.Reward.Add(curDelegation.Reward, rewardInt)
 		rewardPool.Sub(rewardPool, rewardInt)
+		if paid != nil {
+			paid[i] = rewardInt
+		}
 	}
 
 	// The last remaining bit belongs to the validator (remember the validator's self delegation is
 	// always at index 0)
 	if rewardPool.Cmp(common.Big0) > 0 {
 		curValidator.Delegations[0].Reward.Add(curValidator.Delegations[0].Reward, rewardPool)
+		if paid != nil {
+			paid[0] = new(big.Int).Add(paid[0], rewardPool)
+		}
+	}
+	if paid != nil {
+		db.recordRewards(snapshot, commission, paid)
 	}
 
 	return nil
@@ -28,10 +28,12 @@ import (
 	"sync"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/rawdb"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/state/snapshot"
 	"github.com/harmony-one/harmony/core/types"
 
 	"github.com/harmony-one/harmony/crypto/bls"
@@ -41,6 +43,9 @@ import (
 	staketest "github.com/harmony-one/harmony/staking/types/test"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/common/denominations"
 )
 
@@ -920,6 +925,101 @@ func TestDeleteCreateRevert(t *testing.T) {
 	}
 }
 
+func TestGetProof(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+
+	addr := common.BytesToAddress([]byte("proof"))
+	key, value := common.HexToHash("0x01"), common.HexToHash("0x2a")
+	state.SetBalance(addr, big.NewInt(1))
+	state.SetState(addr, key, value)
+	root, _ := state.Commit(false)
+	state.Reset(root)
+
+	verify := func(root common.Hash, key []byte, proof [][]byte) []byte {
+		db := memorydb.New()
+		for _, node := range proof {
+			db.Put(crypto.Keccak256(node), node)
+		}
+		val, _, err := trie.VerifyProof(root, crypto.Keccak256(key), db)
+		if err != nil {
+			t.Fatalf("proof verification failed: %v", err)
+		}
+		return val
+	}
+
+	proof, err := state.GetProof(addr)
+	if err != nil {
+		t.Fatalf("account proof failed: %v", err)
+	}
+	if val := verify(root, addr.Bytes(), proof); len(val) == 0 {
+		t.Fatalf("account missing from proof")
+	}
+	storageProof, err := state.GetStorageProof(addr, key)
+	if err != nil {
+		t.Fatalf("storage proof failed: %v", err)
+	}
+	enc, _ := rlp.EncodeToBytes(common.TrimLeftZeroes(value[:]))
+	if val := verify(state.StorageTrie(addr).Hash(), key.Bytes(), storageProof); !bytes.Equal(val, enc) {
+		t.Fatalf("storage value mismatch: have %x, want %x", val, enc)
+	}
+}
+
+func TestTransientStorageRevert(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+
+	addr := common.BytesToAddress([]byte("so"))
+	key, value := common.HexToHash("0x01"), common.HexToHash("0x02")
+
+	state.SetTransientState(addr, key, value)
+	id := state.Snapshot()
+	state.SetTransientState(addr, key, common.HexToHash("0x03"))
+	state.RevertToSnapshot(id)
+	if got := state.GetTransientState(addr, key); got != value {
+		t.Fatalf("transient storage not reverted: have %x, want %x", got, value)
+	}
+
+	if got := state.Copy().GetTransientState(addr, key); got != value {
+		t.Fatalf("transient storage not copied: have %x, want %x", got, value)
+	}
+
+	// transient storage does not outlive the transaction
+	state.Prepare(common.Hash{}, common.Hash{}, 1)
+	if got := state.GetTransientState(addr, key); got != (common.Hash{}) {
+		t.Fatalf("transient storage not cleared: have %x", got)
+	}
+}
+
+func TestAccessListRevert(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+
+	addr := common.BytesToAddress([]byte("so"))
+	slot := common.HexToHash("0x01")
+
+	state.AddAddressToAccessList(addr)
+	id := state.Snapshot()
+	state.AddSlotToAccessList(addr, slot)
+	state.AddAddressToAccessList(common.BytesToAddress([]byte("aa")))
+	if _, slotOk := state.SlotInAccessList(addr, slot); !slotOk {
+		t.Fatalf("slot not added to access list")
+	}
+	state.RevertToSnapshot(id)
+	if addrOk, slotOk := state.SlotInAccessList(addr, slot); !addrOk || slotOk {
+		t.Fatalf("access list not reverted: address %v, slot %v", addrOk, slotOk)
+	}
+	if state.AddressInAccessList(common.BytesToAddress([]byte("aa"))) {
+		t.Fatalf("address not removed from access list")
+	}
+	if !state.Copy().AddressInAccessList(addr) {
+		t.Fatalf("access list not copied")
+	}
+
+	// the access list does not outlive the transaction
+	state.Prepare(common.Hash{}, common.Hash{}, 1)
+	if state.AddressInAccessList(addr) {
+		t.Fatalf("access list not cleared")
+	}
+}
+
 func makeValidValidatorWrapper(addr common.Address) stk.ValidatorWrapper {
 	cr := stk.CommissionRates{
 		Rate:          numeric.ZeroDec(),
@@ -1225,3 +1325,170 @@ func TestValidatorWrapperGetCode(t *testing.T) {
 		t.Fatalf("Loaded wrapper not equal to expected wrapper%v\n", err)
 	}
 }
+
+func TestSnapshotReads(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		sdb   = NewDatabase(db)
+		addr1 = common.HexToAddress("0x1")
+		addr2 = common.HexToAddress("0x2")
+		slot1 = common.HexToHash("0x1")
+		slot2 = common.HexToHash("0x2")
+	)
+	commit := func(statedb *DB) common.Hash {
+		root, err := statedb.Commit(true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return root
+	}
+	genesis, _ := New(common.Hash{}, sdb)
+	genesis.SetBalance(addr1, big.NewInt(1))
+	genesis.SetState(addr1, slot1, common.HexToHash("0x1"))
+	genesis.SetState(addr1, slot2, common.HexToHash("0x2"))
+	genesis.SetBalance(addr2, big.NewInt(2))
+	root0 := commit(genesis)
+	if err := sdb.TrieDB().Commit(root0, false); err != nil {
+		t.Fatal(err)
+	}
+	snaps := snapshot.New(db, sdb.TrieDB(), 1, root0)
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if _, err := snaps.Snapshot(root0).Account(crypto.Keccak256Hash(addr2[:])); err == nil {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("snapshot not generated")
+		}
+	}
+
+	// Block 1 changes a slot and destructs an account
+	statedb, _ := NewWithSnapshots(root0, sdb, snaps)
+	if value := statedb.GetState(addr1, slot1); value != common.HexToHash("0x1") {
+		t.Fatalf("unexpected slot value %x", value)
+	}
+	statedb.SetState(addr1, slot1, common.HexToHash("0x3"))
+	statedb.Suicide(addr2)
+	root1 := commit(statedb)
+
+	// Block 2 replaces an account, wiping its storage
+	statedb, _ = NewWithSnapshots(root1, sdb, snaps)
+	statedb.CreateAccount(addr1)
+	statedb.SetState(addr1, slot2, common.HexToHash("0x4"))
+	statedb.IntermediateRoot(true)
+	if value := statedb.GetState(addr1, slot1); value != (common.Hash{}) {
+		t.Fatalf("unexpected wiped slot value %x", value)
+	}
+	root2 := commit(statedb)
+
+	if snaps.Snapshot(root1) == nil || snaps.Snapshot(root2) == nil {
+		t.Fatal("snapshot layers not added")
+	}
+	for i, root := range []common.Hash{root0, root1, root2} {
+		fromTrie, _ := New(root, sdb)
+		fromSnap, _ := NewWithSnapshots(root, sdb, snaps)
+		for _, addr := range []common.Address{addr1, addr2} {
+			if have, want := fromSnap.Exist(addr), fromTrie.Exist(addr); have != want {
+				t.Errorf("Test %v: account %x existence %v, want %v", i, addr, have, want)
+			}
+			if have, want := fromSnap.GetBalance(addr), fromTrie.GetBalance(addr); have.Cmp(want) != 0 {
+				t.Errorf("Test %v: account %x balance %v, want %v", i, addr, have, want)
+			}
+			for _, slot := range []common.Hash{slot1, slot2} {
+				if have, want := fromSnap.GetState(addr, slot), fromTrie.GetState(addr, slot); have != want {
+					t.Errorf("Test %v: slot %x of %x is %x, want %x", i, slot, addr, have, want)
+				}
+			}
+		}
+	}
+	// The snapshot itself holds the state of the block
+	if data, err := snaps.Snapshot(root2).Storage(crypto.Keccak256Hash(addr1[:]), crypto.Keccak256Hash(slot1[:])); err != nil || data != nil {
+		t.Errorf("wiped slot in snapshot: %x %v", data, err)
+	}
+}
+
+func TestStateDiffs(t *testing.T) {
+	var (
+		sdb   = NewDatabase(rawdb.NewMemoryDatabase())
+		addr1 = common.HexToAddress("0x1")
+		addr2 = common.HexToAddress("0x2")
+		addr3 = common.HexToAddress("0x3")
+		slot  = common.HexToHash("0x1")
+		tx1   = common.HexToHash("0x11")
+		tx2   = common.HexToHash("0x22")
+	)
+	genesis, _ := New(common.Hash{}, sdb)
+	genesis.SetBalance(addr1, big.NewInt(10))
+	genesis.SetState(addr1, slot, common.HexToHash("0x1"))
+	genesis.SetBalance(addr2, big.NewInt(20))
+	root, _ := genesis.Commit(true)
+
+	statedb, _ := New(root, sdb)
+	statedb.RecordStateDiffs()
+
+	statedb.Prepare(tx1, common.Hash{}, 0)
+	statedb.SubBalance(addr1, big.NewInt(3))
+	statedb.SetNonce(addr1, 1)
+	statedb.SetState(addr1, slot, common.HexToHash("0x2"))
+	statedb.AddBalance(addr3, big.NewInt(3))
+	// Changes reverted within the transaction are not recorded
+	statedb.SetState(addr2, slot, common.HexToHash("0x3"))
+	statedb.SetState(addr2, slot, common.Hash{})
+	statedb.Finalise(true)
+
+	statedb.Prepare(tx2, common.Hash{}, 1)
+	statedb.Suicide(addr2)
+	statedb.Finalise(true)
+	// Changes made after the transactions
+	statedb.Prepare(common.Hash{}, common.Hash{}, 2)
+	statedb.AddBalance(addr1, big.NewInt(1))
+	statedb.IntermediateRoot(true)
+	statedb.AddBalance(addr1, big.NewInt(1))
+	statedb.IntermediateRoot(true)
+
+	want := []*types.StateDiff{
+		{TxHash: tx1, Accounts: []*types.AccountDiff{
+			{
+				Address: addr1, Existed: true, Exists: true,
+				BalanceBefore: big.NewInt(10), BalanceAfter: big.NewInt(7), NonceAfter: 1,
+				CodeHashBefore: emptyCode, CodeHashAfter: emptyCode,
+				Storage: []*types.StorageDiff{
+					{Key: slot, Before: common.HexToHash("0x1"), After: common.HexToHash("0x2")},
+				},
+			},
+			{
+				Address: addr3, Exists: true,
+				BalanceBefore: big.NewInt(0), BalanceAfter: big.NewInt(3), CodeHashAfter: emptyCode,
+			},
+		}},
+		{TxHash: tx2, Accounts: []*types.AccountDiff{
+			{
+				Address: addr2, Existed: true,
+				BalanceBefore: big.NewInt(20), BalanceAfter: big.NewInt(0), CodeHashBefore: emptyCode,
+			},
+		}},
+		{TxHash: common.Hash{}, Accounts: []*types.AccountDiff{
+			{
+				Address: addr1, Existed: true, Exists: true,
+				BalanceBefore: big.NewInt(7), BalanceAfter: big.NewInt(9), NonceBefore: 1, NonceAfter: 1,
+				CodeHashBefore: emptyCode, CodeHashAfter: emptyCode, Storage: []*types.StorageDiff{},
+			},
+		}},
+	}
+	have, _ := rlp.EncodeToBytes(statedb.StateDiffs())
+	exp, _ := rlp.EncodeToBytes(want)
+	if !bytes.Equal(have, exp) {
+		for _, diff := range statedb.StateDiffs() {
+			for _, account := range diff.Accounts {
+				t.Logf("%x: %+v", diff.TxHash, *account)
+			}
+		}
+		t.Fatalf("unexpected state diffs")
+	}
+
+	// The block changes merge into a single one
+	if merged := types.MergeStateDiffs(statedb.StateDiffs()); len(merged) != 3 ||
+		merged[0].BalanceBefore.Cmp(big.NewInt(10)) != 0 || merged[0].BalanceAfter.Cmp(big.NewInt(9)) != 0 ||
+		merged[1].Exists || merged[2].Existed {
+		t.Errorf("unexpected merged state diffs")
+	}
+}
@@ -0,0 +1,161 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// accountOrigin is the state of an account before the current transaction, as
+// recovered from the journal. Nil fields were not modified by the transaction.
+type accountOrigin struct {
+	existed  bool
+	balance  *big.Int
+	nonce    *uint64
+	codeHash *common.Hash
+	storage  map[common.Hash]common.Hash
+}
+
+// RecordStateDiffs makes the state database record the changes made by every
+// transaction, available through StateDiffs. The changes are recorded when the
+// state is finalised, under the transaction hash given to Prepare.
+func (db *DB) RecordStateDiffs() {
+	db.stateDiffs = []*types.StateDiff{}
+}
+
+// StateDiffs returns the changes recorded since RecordStateDiffs was called, or
+// nil if they are not recorded.
+func (db *DB) StateDiffs() []*types.StateDiff {
+	return db.stateDiffs
+}
+
+// recordStateDiff records the changes of the current transaction, described by
+// the journal. It must be called after the dirty objects are finalised, before
+// the journal is cleared.
+func (db *DB) recordStateDiff() {
+	origins := make(map[common.Address]*accountOrigin)
+	origin := func(addr common.Address) *accountOrigin {
+		if o, ok := origins[addr]; ok {
+			return o
+		}
+		o := &accountOrigin{existed: true, storage: make(map[common.Hash]common.Hash)}
+		origins[addr] = o
+		return o
+	}
+	// The first change of a value holds its value before the transaction
+	for _, entry := range db.journal.entries {
+		switch ch := entry.(type) {
+		case createObjectChange:
+			if _, ok := origins[*ch.account]; !ok {
+				o := origin(*ch.account)
+				o.existed = false
+				o.balance, o.nonce, o.codeHash = new(big.Int), new(uint64), &common.Hash{}
+			}
+		case resetObjectChange:
+			if _, ok := origins[ch.prev.address]; !ok {
+				o := origin(ch.prev.address)
+				if ch.prev.deleted {
+					o.existed = false
+					o.balance, o.nonce, o.codeHash = new(big.Int), new(uint64), &common.Hash{}
+				} else {
+					nonce, codeHash := ch.prev.Nonce(), common.BytesToHash(ch.prev.CodeHash())
+					o.balance, o.nonce, o.codeHash = new(big.Int).Set(ch.prev.Balance()), &nonce, &codeHash
+				}
+			}
+		case balanceChange:
+			if o := origin(*ch.account); o.balance == nil {
+				o.balance = new(big.Int).Set(ch.prev)
+			}
+		case suicideChange:
+			if o := origin(*ch.account); o.balance == nil {
+				o.balance = new(big.Int).Set(ch.prevbalance)
+			}
+		case nonceChange:
+			if o := origin(*ch.account); o.nonce == nil {
+				nonce := ch.prev
+				o.nonce = &nonce
+			}
+		case codeChange:
+			if o := origin(*ch.account); o.codeHash == nil {
+				codeHash := common.BytesToHash(ch.prevhash)
+				o.codeHash = &codeHash
+			}
+		case storageChange:
+			if o := origin(*ch.account); !containsKey(o.storage, ch.key) {
+				o.storage[ch.key] = ch.prevalue
+			}
+		case touchChange:
+			origin(*ch.account)
+		}
+	}
+	if len(origins) == 0 {
+		return
+	}
+
+	diff := &types.StateDiff{TxHash: db.thash}
+	for addr, o := range origins {
+		account := &types.AccountDiff{
+			Address: addr, Existed: o.existed, BalanceBefore: new(big.Int), BalanceAfter: new(big.Int),
+		}
+		// The values not modified by the transaction are the current ones
+		obj := db.stateObjects[addr]
+		if obj != nil {
+			account.BalanceBefore = new(big.Int).Set(obj.Balance())
+			account.NonceBefore = obj.Nonce()
+			account.CodeHashBefore = common.BytesToHash(obj.CodeHash())
+			if !obj.deleted {
+				account.Exists = true
+				account.BalanceAfter, account.NonceAfter, account.CodeHashAfter =
+					account.BalanceBefore, account.NonceBefore, account.CodeHashBefore
+			}
+		}
+		if o.balance != nil {
+			account.BalanceBefore = o.balance
+		}
+		if o.nonce != nil {
+			account.NonceBefore = *o.nonce
+		}
+		if o.codeHash != nil {
+			account.CodeHashBefore = *o.codeHash
+		}
+		for key, before := range o.storage {
+			slot := &types.StorageDiff{Key: key, Before: before}
+			if account.Exists {
+				slot.After = obj.pendingStorage[key]
+			}
+			if slot.Before != slot.After {
+				account.Storage = append(account.Storage, slot)
+			}
+		}
+		sort.Slice(account.Storage, func(i, j int) bool {
+			return bytes.Compare(account.Storage[i].Key[:], account.Storage[j].Key[:]) < 0
+		})
+		if account.Changed() {
+			diff.Accounts = append(diff.Accounts, account)
+		}
+	}
+	if len(diff.Accounts) == 0 {
+		return
+	}
+	sort.Slice(diff.Accounts, func(i, j int) bool {
+		return bytes.Compare(diff.Accounts[i].Address[:], diff.Accounts[j].Address[:]) < 0
+	})
+
+	// The state may be finalised several times per transaction
+	if last := len(db.stateDiffs) - 1; last >= 0 && db.stateDiffs[last].TxHash == db.thash {
+		db.stateDiffs[last].Accounts = types.MergeStateDiffs(
+			[]*types.StateDiff{db.stateDiffs[last], diff},
+		)
+		return
+	}
+	db.stateDiffs = append(db.stateDiffs, diff)
+}
+
+// containsKey reports whether the given storage slot is in the storage map.
+func containsKey(storage map[common.Hash]common.Hash, key common.Hash) bool {
+	_, ok := storage[key]
+	return ok
+}
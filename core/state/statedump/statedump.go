@@ -0,0 +1,311 @@
+// Package statedump exports the accounts and storage of a state trie to a stream
+// of records, and rebuilds the state trie from such a stream.
+//
+// A dump starts with a Header, followed by one record per account, each followed
+// by one record per storage slot of the account, and ends with a record holding
+// the number of accounts dumped. Keys are the hashes under which the accounts and
+// slots are stored in the tries, so that a dump is complete even if the preimages
+// of the keys are unknown.
+package statedump
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+// Version is the version of the dump format.
+const Version = 1
+
+const (
+	logInterval = 8 * time.Second
+	// flushSize is the size of the trie nodes kept in memory by an import before
+	// they are flushed to disk.
+	flushSize = 256 * 1024 * 1024
+)
+
+const (
+	accountRecord uint8 = iota + 1
+	storageRecord
+	endRecord
+)
+
+var (
+	emptyCodeHash = crypto.Keccak256(nil)
+
+	// ErrVersion is returned when importing a dump of an unsupported version.
+	ErrVersion = errors.New("unsupported state dump version")
+	// ErrTruncated is returned when a dump ends before its end record.
+	ErrTruncated = errors.New("truncated state dump")
+	// ErrRootMismatch is returned when the imported state differs from the dumped one.
+	ErrRootMismatch = errors.New("imported state root mismatch")
+)
+
+// Header describes the state of a dump.
+type Header struct {
+	Version uint64
+	ShardID uint32
+	Number  uint64      // number of the block of the state
+	Hash    common.Hash // hash of the block of the state
+	Root    common.Hash
+}
+
+// record is an account, a storage slot of the last account or the end of a dump.
+type record struct {
+	Kind  uint8
+	Key   common.Hash // hashed address or storage key
+	Value []byte      // trie value of the account or slot, account count of the end
+	Code  []byte      // code of a contract account
+}
+
+// account is the trie representation of an account, as in state.Account.
+type account struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+// Stats are the statistics of an export or an import.
+type Stats struct {
+	Accounts uint64
+	Slots    uint64
+	Elapsed  time.Duration
+}
+
+// Export writes the state with the root of the given header into w.
+func Export(w io.Writer, triedb *trie.Database, header Header) (*Stats, error) {
+	header.Version = Version
+	accTrie, err := trie.New(header.Root, triedb)
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(w)
+	if err := rlp.Encode(bw, &header); err != nil {
+		return nil, err
+	}
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		stats  = &Stats{}
+	)
+	it := trie.NewIterator(accTrie.NodeIterator(nil))
+	for it.Next() {
+		var acc account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return nil, err
+		}
+		rec := &record{Kind: accountRecord, Key: common.BytesToHash(it.Key), Value: it.Value}
+		if !bytes.Equal(acc.CodeHash, emptyCodeHash) {
+			if rec.Code, err = triedb.Node(common.BytesToHash(acc.CodeHash)); err != nil {
+				return nil, errors.Wrapf(err, "code of account %x", it.Key)
+			}
+		}
+		if err := rlp.Encode(bw, rec); err != nil {
+			return nil, err
+		}
+		stats.Accounts++
+
+		if acc.Root != types.EmptyRootHash {
+			storeTrie, err := trie.New(acc.Root, triedb)
+			if err != nil {
+				return nil, err
+			}
+			storeIt := trie.NewIterator(storeTrie.NodeIterator(nil))
+			for storeIt.Next() {
+				slot := &record{Kind: storageRecord, Key: common.BytesToHash(storeIt.Key), Value: storeIt.Value}
+				if err := rlp.Encode(bw, slot); err != nil {
+					return nil, err
+				}
+				stats.Slots++
+			}
+			if storeIt.Err != nil {
+				return nil, storeIt.Err
+			}
+		}
+		if time.Since(logged) > logInterval {
+			utils.Logger().Info().
+				Uint64("accounts", stats.Accounts).
+				Uint64("slots", stats.Slots).
+				Dur("elapsed", time.Since(start)).
+				Msg("[StateDump] exporting state")
+			logged = time.Now()
+		}
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	count, _ := rlp.EncodeToBytes(stats.Accounts)
+	if err := rlp.Encode(bw, &record{Kind: endRecord, Value: count}); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+	stats.Elapsed = time.Since(start)
+	return stats, nil
+}
+
+// importer rebuilds the tries of a dump, flushing their nodes to disk whenever
+// the nodes kept in memory grow over flushSize.
+type importer struct {
+	triedb *trie.Database
+}
+
+// commit commits the given trie and writes its nodes to disk.
+func (im *importer) commit(tr *trie.Trie) (common.Hash, error) {
+	root, err := tr.Commit(nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return root, im.triedb.Commit(root, false)
+}
+
+// maybeFlush writes the nodes of the given trie to disk if it uses too much memory.
+func (im *importer) maybeFlush(tr *trie.Trie) error {
+	if nodes, _ := im.triedb.Size(); nodes < flushSize {
+		return nil
+	}
+	_, err := im.commit(tr)
+	return err
+}
+
+// Import reads a state dump from r and writes its tries and codes into the given
+// trie database. The rebuilt state root is checked against the one of the dump.
+func Import(r io.Reader, triedb *trie.Database) (*Header, *Stats, error) {
+	stream := rlp.NewStream(bufio.NewReader(r), 0)
+	header := new(Header)
+	if err := stream.Decode(header); err != nil {
+		return nil, nil, err
+	}
+	if header.Version != Version {
+		return nil, nil, errors.Wrapf(ErrVersion, "version %d", header.Version)
+	}
+
+	var (
+		start    = time.Now()
+		logged   = time.Now()
+		stats    = &Stats{}
+		im       = &importer{triedb: triedb}
+		last     *record // last account read
+		lastAcc  account // decoded last account
+		storeTr  *trie.Trie
+		finished bool
+	)
+	accTrie, err := trie.New(common.Hash{}, triedb)
+	if err != nil {
+		return nil, nil, err
+	}
+	// finishAccount writes the storage trie of the last account and the account
+	finishAccount := func() error {
+		if last == nil {
+			return nil
+		}
+		root := types.EmptyRootHash
+		if storeTr != nil {
+			if root, err = im.commit(storeTr); err != nil {
+				return err
+			}
+		}
+		if root != lastAcc.Root {
+			return errors.Wrapf(ErrRootMismatch, "storage of account %x", last.Key)
+		}
+		if len(last.Code) > 0 {
+			codeHash := crypto.Keccak256Hash(last.Code)
+			triedb.InsertBlob(codeHash, last.Code)
+			if err := triedb.Commit(codeHash, false); err != nil {
+				return err
+			}
+		}
+		if err := accTrie.TryUpdate(last.Key[:], last.Value); err != nil {
+			return err
+		}
+		last, storeTr = nil, nil
+		return im.maybeFlush(accTrie)
+	}
+
+	for !finished {
+		rec := new(record)
+		if err := stream.Decode(rec); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, nil, ErrTruncated
+			}
+			return nil, nil, err
+		}
+		switch rec.Kind {
+		case accountRecord:
+			if err := finishAccount(); err != nil {
+				return nil, nil, err
+			}
+			if err := rlp.DecodeBytes(rec.Value, &lastAcc); err != nil {
+				return nil, nil, err
+			}
+			if len(rec.Code) > 0 && !bytes.Equal(crypto.Keccak256(rec.Code), lastAcc.CodeHash) {
+				return nil, nil, errors.Errorf("code hash mismatch of account %x", rec.Key)
+			}
+			last = rec
+			stats.Accounts++
+
+		case storageRecord:
+			if last == nil {
+				return nil, nil, errors.New("storage slot without account")
+			}
+			if storeTr == nil {
+				if storeTr, err = trie.New(common.Hash{}, triedb); err != nil {
+					return nil, nil, err
+				}
+			}
+			if err := storeTr.TryUpdate(rec.Key[:], rec.Value); err != nil {
+				return nil, nil, err
+			}
+			if err := im.maybeFlush(storeTr); err != nil {
+				return nil, nil, err
+			}
+			stats.Slots++
+
+		case endRecord:
+			if err := finishAccount(); err != nil {
+				return nil, nil, err
+			}
+			var count uint64
+			if err := rlp.DecodeBytes(rec.Value, &count); err != nil {
+				return nil, nil, err
+			}
+			if count != stats.Accounts {
+				return nil, nil, errors.Wrapf(ErrTruncated, "%d of %d accounts", stats.Accounts, count)
+			}
+			finished = true
+
+		default:
+			return nil, nil, errors.Errorf("unknown state dump record %d", rec.Kind)
+		}
+		if time.Since(logged) > logInterval {
+			utils.Logger().Info().
+				Uint64("accounts", stats.Accounts).
+				Uint64("slots", stats.Slots).
+				Dur("elapsed", time.Since(start)).
+				Msg("[StateDump] importing state")
+			logged = time.Now()
+		}
+	}
+	root, err := im.commit(accTrie)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root != header.Root {
+		return nil, nil, errors.Wrapf(ErrRootMismatch, "got %x, expect %x", root, header.Root)
+	}
+	stats.Elapsed = time.Since(start)
+	return header, stats, nil
+}
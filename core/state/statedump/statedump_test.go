@@ -0,0 +1,90 @@
+package statedump
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+)
+
+func TestExportImport(t *testing.T) {
+	srcDB := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, _ := state.New(common.Hash{}, srcDB)
+	for i := byte(1); i <= 100; i++ {
+		addr := common.BytesToAddress([]byte{i})
+		statedb.AddBalance(addr, big.NewInt(int64(i)))
+		statedb.SetNonce(addr, uint64(i))
+		if i%10 == 0 {
+			statedb.SetCode(addr, []byte{i, i})
+			for j := byte(1); j <= i; j++ {
+				statedb.SetState(addr, common.BytesToHash([]byte{j}), common.BytesToHash([]byte{i, j}))
+			}
+		}
+	}
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srcDB.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var dump bytes.Buffer
+	stats, err := Export(&dump, srcDB.TrieDB(), Header{ShardID: 1, Number: 42, Root: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Accounts != 100 || stats.Slots != 550 {
+		t.Fatalf("exported %d accounts and %d slots, expect 100 and 550", stats.Accounts, stats.Slots)
+	}
+
+	destDB := state.NewDatabase(rawdb.NewMemoryDatabase())
+	header, stats, err := Import(bytes.NewReader(dump.Bytes()), destDB.TrieDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.ShardID != 1 || header.Number != 42 || header.Root != root {
+		t.Errorf("unexpected header %+v", header)
+	}
+	if stats.Accounts != 100 || stats.Slots != 550 {
+		t.Errorf("imported %d accounts and %d slots, expect 100 and 550", stats.Accounts, stats.Slots)
+	}
+	imported, err := state.New(root, destDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := common.BytesToAddress([]byte{30})
+	if balance := imported.GetBalance(addr); balance.Cmp(big.NewInt(30)) != 0 {
+		t.Errorf("balance %v, expect 30", balance)
+	}
+	if code := imported.GetCode(addr); !bytes.Equal(code, []byte{30, 30}) {
+		t.Errorf("code %x, expect 1e1e", code)
+	}
+	slot := imported.GetState(addr, common.BytesToHash([]byte{7}))
+	if slot != common.BytesToHash([]byte{30, 7}) {
+		t.Errorf("slot %x, expect 1e07", slot)
+	}
+}
+
+func TestImportTruncated(t *testing.T) {
+	srcDB := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, _ := state.New(common.Hash{}, srcDB)
+	statedb.AddBalance(common.BytesToAddress([]byte{1}), big.NewInt(1))
+	statedb.AddBalance(common.BytesToAddress([]byte{2}), big.NewInt(2))
+	root, _ := statedb.Commit(false)
+	srcDB.TrieDB().Commit(root, false)
+
+	var dump bytes.Buffer
+	if _, err := Export(&dump, srcDB.TrieDB(), Header{Root: root}); err != nil {
+		t.Fatal(err)
+	}
+	// Drop the end record
+	truncated := dump.Bytes()[:dump.Len()-4]
+	destDB := state.NewDatabase(rawdb.NewMemoryDatabase())
+	if _, _, err := Import(bytes.NewReader(truncated), destDB.TrieDB()); err != ErrTruncated {
+		t.Fatalf("unexpected error %v, expect %v", err, ErrTruncated)
+	}
+}
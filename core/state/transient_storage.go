@@ -0,0 +1,39 @@
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// transientStorage is the EIP-1153 storage of the current transaction. It is
+// never written to the trie and is discarded at the start of every transaction.
+type transientStorage map[common.Address]Storage
+
+func newTransientStorage() transientStorage {
+	return make(transientStorage)
+}
+
+// Set sets the transient storage slot of an account.
+func (t transientStorage) Set(addr common.Address, key, value common.Hash) {
+	if _, ok := t[addr]; !ok {
+		t[addr] = make(Storage)
+	}
+	t[addr][key] = value
+}
+
+// Get returns the transient storage slot of an account.
+func (t transientStorage) Get(addr common.Address, key common.Hash) common.Hash {
+	val, ok := t[addr]
+	if !ok {
+		return common.Hash{}
+	}
+	return val[key]
+}
+
+// Copy returns a deep copy of the transient storage.
+func (t transientStorage) Copy() transientStorage {
+	storage := make(transientStorage, len(t))
+	for addr, slots := range t {
+		storage[addr] = slots.Copy()
+	}
+	return storage
+}
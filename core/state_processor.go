@@ -155,6 +155,9 @@ func (p *StateProcessor) Process(
 	}
 	utils.Logger().Debug().Int64("elapsed time", time.Now().Sub(startTime).Milliseconds()).Msg("Process Staking Txns")
 
+	// The changes made after the transactions are not attributed to the last one
+	statedb.Prepare(common.Hash{}, block.Hash(), len(receipts))
+
 	// incomingReceipts should always be processed
 	// after transactions (to be consistent with the block proposal)
 	for _, cx := range block.IncomingReceipts() {
@@ -243,6 +246,13 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 		)
 	}
 
+	if tx.Type() != types.LegacyTxType && !config.IsBerlin(header.Epoch()) {
+		return nil, nil, nil, 0, types.ErrTxTypeNotSupported
+	}
+	if tx.Type() == types.DynamicFeeTxType && !config.IsLondon(header.Epoch()) {
+		return nil, nil, nil, 0, types.ErrTxTypeNotSupported
+	}
+
 	var signer types.Signer
 	if tx.IsEthCompatible() {
 		if !config.IsEthCompatible(header.Epoch()) {
@@ -285,6 +295,7 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	receipt := types.NewReceipt(root, failedExe, *usedGas)
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
+	receipt.EffectiveGasPrice = tx.EffectiveGasPrice(header.BaseFee())
 	// if the transaction created a contract, store the creation address in the receipt.
 	if msg.To() == nil {
 		receipt.ContractAddress = crypto.CreateAddress(vmenv.Context.Origin, tx.Nonce())
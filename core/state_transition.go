@@ -21,10 +21,13 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/staking"
 	stakingTypes "github.com/harmony-one/harmony/staking/types"
 	"github.com/pkg/errors"
 )
@@ -90,6 +93,9 @@ type Message interface {
 	Data() []byte
 	Type() types.TransactionType
 	BlockNum() *big.Int
+	AccessList() types.AccessList
+	GasFeeCap() *big.Int
+	GasTipCap() *big.Int
 }
 
 // ExecutionResult is the return value from a transaction committed to the DB
@@ -202,6 +208,33 @@ func (st *StateTransition) preCheck() error {
 			return ErrNonceTooLow
 		}
 	}
+	// Make sure that the fee caps cover the base fee and derive the
+	// effective gas price paid by this message.
+	if st.evm.ChainConfig().IsLondon(st.evm.EpochNumber) && st.evm.BaseFee != nil {
+		feeCap, tipCap := st.msg.GasFeeCap(), st.msg.GasTipCap()
+		skip := st.evm.Config().NoBaseFee && feeCap.Sign() == 0 && tipCap.Sign() == 0
+		if !skip {
+			if feeCap.Cmp(tipCap) < 0 {
+				return errors.Wrapf(
+					ErrTipAboveFeeCap,
+					"address %v, maxPriorityFeePerGas: %s, maxFeePerGas: %s",
+					st.msg.From().Hex(), tipCap, feeCap,
+				)
+			}
+			if feeCap.Cmp(st.evm.BaseFee) < 0 {
+				return errors.Wrapf(
+					ErrFeeCapTooLow,
+					"address %v, maxFeePerGas: %s, baseFee: %s",
+					st.msg.From().Hex(), feeCap, st.evm.BaseFee,
+				)
+			}
+			st.gasPrice = new(big.Int).Add(tipCap, st.evm.BaseFee)
+			if st.gasPrice.Cmp(feeCap) > 0 {
+				st.gasPrice = new(big.Int).Set(feeCap)
+			}
+			st.evm.GasPrice = st.gasPrice
+		}
+	}
 	return st.buyGas()
 }
 
@@ -223,11 +256,25 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	if err != nil {
 		return ExecutionResult{}, err
 	}
+	rules := st.evm.ChainConfig().Rules(st.evm.EpochNumber)
+	if rules.IsBerlin {
+		accessListGas, err := vm.AccessListGas(msg.AccessList())
+		if err != nil {
+			return ExecutionResult{}, err
+		}
+		var overflow bool
+		if gas, overflow = math.SafeAdd(gas, accessListGas); overflow {
+			return ExecutionResult{}, vm.ErrOutOfGas
+		}
+	}
 	if err = st.useGas(gas); err != nil {
 		return ExecutionResult{}, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gas, gas)
 	}
 
 	evm := st.evm
+	if rules.IsBerlin {
+		st.state.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
+	}
 
 	var ret []byte
 	// All VM errors are valid except for insufficient balance, therefore returned separately
@@ -250,7 +297,11 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 			return ExecutionResult{}, vmErr
 		}
 	}
-	st.refundGas()
+	refundQuotient := params.RefundQuotient
+	if rules.IsRefundReduction {
+		refundQuotient = params.RefundQuotientEIP3529
+	}
+	st.evm.CaptureRefund(gas, st.refundGas(refundQuotient))
 
 	// Burn Txn Fees after staking epoch
 	if !st.evm.ChainConfig().IsStaking(st.evm.EpochNumber) {
@@ -265,9 +316,11 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	}, err
 }
 
-func (st *StateTransition) refundGas() {
-	// Apply refund counter, capped to half of the used gas.
-	refund := st.gasUsed() / 2
+// refundGas applies the refund counter, capped to a portion of the used gas
+// given by refundQuotient, and returns the amount of gas refunded.
+func (st *StateTransition) refundGas(refundQuotient uint64) uint64 {
+	// Apply refund counter, capped to a refundQuotient portion of the used gas.
+	refund := st.gasUsed() / refundQuotient
 	if refund > st.state.GetRefund() {
 		refund = st.state.GetRefund()
 	}
@@ -280,6 +333,7 @@ func (st *StateTransition) refundGas() {
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
 	st.gp.AddGas(st.gas)
+	return refund
 }
 
 // gasUsed returns the amount of gas used up by the state transition.
@@ -313,6 +367,13 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 	// Increment the nonce for the next transaction
 	st.state.SetNonce(msg.From(), st.state.GetNonce(sender.Address())+1)
 
+	// Value movement of the directive, reported to staking aware tracers
+	var (
+		directive            stakingTypes.Directive
+		validator, delegator common.Address
+		amount               = big.NewInt(0)
+	)
+
 	// from worker.go, we get here with shardID == BeaconChainShardID
 	// from node_handler.go, via blockchain.go => it is checked that block shard == node shard
 	// same via consensus
@@ -328,6 +389,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 		if msg.From() != stkMsg.ValidatorAddress {
 			return 0, errInvalidSigner
 		}
+		directive, validator, delegator, amount = stkMsg.Type(), stkMsg.ValidatorAddress, stkMsg.ValidatorAddress, stkMsg.Amount
 		err = st.evm.CreateValidator(st.evm.StateDB, nil, stkMsg)
 	case types.StakeEditVal:
 		stkMsg := &stakingTypes.EditValidator{}
@@ -339,6 +401,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 		if msg.From() != stkMsg.ValidatorAddress {
 			return 0, errInvalidSigner
 		}
+		directive, validator = stkMsg.Type(), stkMsg.ValidatorAddress
 		err = st.evm.EditValidator(st.evm.StateDB, nil, stkMsg)
 	case types.Delegate:
 		stkMsg := &stakingTypes.Delegate{}
@@ -349,6 +412,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 		if msg.From() != stkMsg.DelegatorAddress {
 			return 0, errInvalidSigner
 		}
+		directive, validator, delegator, amount = stkMsg.Type(), stkMsg.ValidatorAddress, stkMsg.DelegatorAddress, stkMsg.Amount
 		err = st.evm.Delegate(st.evm.StateDB, nil, stkMsg)
 	case types.Undelegate:
 		stkMsg := &stakingTypes.Undelegate{}
@@ -359,6 +423,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 		if msg.From() != stkMsg.DelegatorAddress {
 			return 0, errInvalidSigner
 		}
+		directive, validator, delegator, amount = stkMsg.Type(), stkMsg.ValidatorAddress, stkMsg.DelegatorAddress, stkMsg.Amount
 		err = st.evm.Undelegate(st.evm.StateDB, nil, stkMsg)
 	case types.CollectRewards:
 		stkMsg := &stakingTypes.CollectRewards{}
@@ -369,11 +434,18 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 		if msg.From() != stkMsg.DelegatorAddress {
 			return 0, errInvalidSigner
 		}
+		directive, delegator = stkMsg.Type(), stkMsg.DelegatorAddress
 		err = st.evm.CollectRewards(st.evm.StateDB, nil, stkMsg)
+		amount = collectedRewards(st.state, stkMsg.DelegatorAddress)
 	default:
 		return 0, stakingTypes.ErrInvalidStakingKind
 	}
-	st.refundGas()
+	refundQuotient := params.RefundQuotient
+	if st.evm.ChainConfig().IsRefundReduction(st.evm.EpochNumber) {
+		refundQuotient = params.RefundQuotientEIP3529
+	}
+	st.refundGas(refundQuotient)
+	st.evm.CaptureStaking(directive, validator, delegator, amount, st.gasUsed(), err)
 
 	// Burn Txn Fees
 	//txFee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice)
@@ -381,3 +453,16 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 
 	return st.gasUsed(), err
 }
+
+// collectedRewards returns the rewards paid out to the delegator by the current
+// transaction, as recorded in its reward collection log. The rewards come from
+// several validators, so the log is the only record of their total.
+func collectedRewards(db vm.StateDB, delegator common.Address) *big.Int {
+	receipt := &types.Receipt{Logs: db.GetLogs(db.TxHash())}
+	for _, log := range types.FindLogsWithTopic(receipt, staking.CollectRewardsTopic) {
+		if log.Address == delegator {
+			return new(big.Int).SetBytes(log.Data)
+		}
+	}
+	return big.NewInt(0)
+}
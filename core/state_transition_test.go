@@ -4,11 +4,15 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/internal/params"
+	stakingLogs "github.com/harmony-one/harmony/staking"
 	staking "github.com/harmony-one/harmony/staking/types"
 	"github.com/pkg/errors"
 )
@@ -81,3 +85,26 @@ func testApplyStakingMessage(test applyStakingMessageTest, t *testing.T) {
 		}
 	})
 }
+
+func TestCollectedRewards(t *testing.T) {
+	_, db, _, _ := getTestEnvironment(*key)
+	delegator := crypto.PubkeyToAddress(key.PublicKey)
+	other := common.BytesToAddress([]byte{0x11})
+
+	// logs of another transaction are not read
+	db.Prepare(common.Hash{0x01}, common.Hash{}, 0)
+	db.AddLog(&types.Log{Address: delegator, Topics: []common.Hash{stakingLogs.CollectRewardsTopic}, Data: big.NewInt(7).Bytes()})
+
+	db.Prepare(common.Hash{0x02}, common.Hash{}, 1)
+	if amount := collectedRewards(db, delegator); amount.Sign() != 0 {
+		t.Errorf("Got collected rewards %v without a reward log, expected 0", amount)
+	}
+	// balance changes, such as the gas payment, do not count
+	db.AddBalance(delegator, big.NewInt(1000))
+	db.AddLog(&types.Log{Address: other, Topics: []common.Hash{stakingLogs.CollectRewardsTopic}, Data: big.NewInt(5).Bytes()})
+	db.AddLog(&types.Log{Address: delegator, Topics: []common.Hash{stakingLogs.DelegateTopic}, Data: big.NewInt(3).Bytes()})
+	db.AddLog(&types.Log{Address: delegator, Topics: []common.Hash{stakingLogs.CollectRewardsTopic}, Data: big.NewInt(42).Bytes()})
+	if amount := collectedRewards(db, delegator); amount.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("Got collected rewards %v, expected 42", amount)
+	}
+}
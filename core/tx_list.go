@@ -287,14 +287,8 @@ func (l *txList) Overlaps(tx types.PoolTransaction) bool {
 func (l *txList) Add(tx types.PoolTransaction, priceBump uint64) (bool, types.PoolTransaction) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
-	if old != nil {
-		threshold := new(big.Int).Div(new(big.Int).Mul(old.GasPrice(), big.NewInt(100+int64(priceBump))), big.NewInt(100))
-		// Have to ensure that the new gas price is higher than the old gas
-		// price as well as checking the percentage threshold to ensure that
-		// this is accurate for low (Wei-level) gas price replacements
-		if old.GasPrice().Cmp(tx.GasPrice()) >= 0 || threshold.Cmp(tx.GasPrice()) > 0 {
-			return false, nil
-		}
+	if old != nil && tx.GasPrice().Cmp(replacementPrice(old.GasPrice(), priceBump)) < 0 {
+		return false, nil
 	}
 	// Otherwise overwrite the old transaction with the current one
 	cost, err := tx.Cost()
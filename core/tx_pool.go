@@ -246,8 +246,10 @@ type TxPool struct {
 	pendingState  *state.ManagedState // Pending state tracking virtual nonces
 	currentMaxGas uint64              // Current gas limit for transaction caps
 
-	locals  *accountSet // Set of local transaction to exempt from eviction rules
-	journal *txJournal  // Journal of local transaction to back up to disk
+	locals    *accountSet     // Set of local transaction to exempt from eviction rules
+	replaced  *txReplacements // Last attempts to replace a transaction of the same nonce
+	submitted *accountSet     // Set of accounts which submitted transactions to this node
+	journal   *txJournal      // Journal of local and submitted transaction to back up to disk
 
 	pending map[common.Address]*txList   // All currently processable transactions
 	queue   map[common.Address]*txList   // Queued but non-processable transactions
@@ -261,6 +263,8 @@ type TxPool struct {
 
 	homestead bool
 	istanbul  bool
+	berlin    bool
+	london    bool
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -289,6 +293,8 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig,
 		utils.Logger().Info().Interface("address", addr).Msg("Setting new local account")
 		pool.locals.add(addr)
 	}
+	pool.submitted = newAccountSet(chainconfig.ChainID)
+	pool.replaced = newTxReplacements(maxTxReplacements)
 	pool.priced = newTxPricedList(pool.all)
 	pool.reset(nil, chain.CurrentBlock().Header())
 
@@ -296,10 +302,10 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig,
 	if !config.NoLocals && config.Journal != "" {
 		pool.journal = newTxJournal(config.Journal)
 
-		if err := pool.journal.load(pool.AddLocals); err != nil {
+		if err := pool.journal.load(pool.addJournaled); err != nil {
 			utils.Logger().Warn().Err(err).Msg("Failed to load transaction journal")
 		}
-		if err := pool.journal.rotate(pool.local()); err != nil {
+		if err := pool.journal.rotate(pool.journaled()); err != nil {
 			utils.Logger().Warn().Err(err).Msg("Failed to rotate transaction journal")
 		}
 	}
@@ -347,6 +353,12 @@ func (pool *TxPool) loop() {
 				if pool.chainconfig.IsIstanbul(ev.Block.Epoch()) {
 					pool.istanbul = true
 				}
+				if pool.chainconfig.IsBerlin(ev.Block.Epoch()) {
+					pool.berlin = true
+				}
+				if pool.chainconfig.IsLondon(ev.Block.Epoch()) {
+					pool.london = true
+				}
 				pool.reset(head.Header(), ev.Block.Header())
 				head = ev.Block
 				pool.mu.Unlock()
@@ -397,7 +409,7 @@ func (pool *TxPool) loop() {
 		case <-journal.C:
 			if pool.journal != nil {
 				pool.mu.Lock()
-				if err := pool.journal.rotate(pool.local()); err != nil {
+				if err := pool.journal.rotate(pool.journaled()); err != nil {
 					utils.Logger().Warn().Err(err).Msg("Failed to rotate local tx journal")
 				}
 				pool.mu.Unlock()
@@ -656,6 +668,20 @@ func (pool *TxPool) Queued() (map[common.Address]types.PoolTransactions, error)
 	return queued, nil
 }
 
+// Replacements returns the last attempts to replace a pool transaction with one of
+// the same sender and nonce, from the oldest to the newest.
+func (pool *TxPool) Replacements() []TxReplacement {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.replaced.list()
+}
+
+// PriceBump returns the minimum price bump percentage of a replacement transaction
+func (pool *TxPool) PriceBump() uint64 {
+	return pool.config.PriceBump
+}
+
 // Locals retrieves the accounts currently considered local by the pool.
 func (pool *TxPool) Locals() []common.Address {
 	pool.mu.Lock()
@@ -664,17 +690,22 @@ func (pool *TxPool) Locals() []common.Address {
 	return pool.locals.flatten()
 }
 
-// local retrieves all currently known local transactions, grouped by origin
-// account and sorted by nonce. The returned transaction set is a copy and can be
-// freely modified by calling code.
-func (pool *TxPool) local() map[common.Address]types.PoolTransactions {
+// journaled retrieves all currently known local and submitted transactions,
+// grouped by origin account and sorted by nonce. The returned transaction set is
+// a copy and can be freely modified by calling code.
+func (pool *TxPool) journaled() map[common.Address]types.PoolTransactions {
 	txs := make(map[common.Address]types.PoolTransactions)
-	for addr := range pool.locals.accounts {
-		if pending := pool.pending[addr]; pending != nil {
-			txs[addr] = append(txs[addr], pending.Flatten()...)
-		}
-		if queued := pool.queue[addr]; queued != nil {
-			txs[addr] = append(txs[addr], queued.Flatten()...)
+	for _, set := range []*accountSet{pool.locals, pool.submitted} {
+		for addr := range set.accounts {
+			if _, ok := txs[addr]; ok {
+				continue
+			}
+			if pending := pool.pending[addr]; pending != nil {
+				txs[addr] = append(txs[addr], pending.Flatten()...)
+			}
+			if queued := pool.queue[addr]; queued != nil {
+				txs[addr] = append(txs[addr], queued.Flatten()...)
+			}
 		}
 	}
 	return txs
@@ -686,6 +717,20 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	if tx.ShardID() != pool.chain.CurrentBlock().ShardID() {
 		return errors.WithMessagef(ErrInvalidShard, "transaction shard is %d", tx.ShardID())
 	}
+	// Reject typed transactions until the access list fork.
+	plainTx, isPlainTx := tx.(*types.Transaction)
+	if isPlainTx && plainTx.Type() != types.LegacyTxType && !pool.berlin {
+		return errors.WithMessagef(types.ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+	}
+	// Reject dynamic fee transactions until EIP-1559 is activated.
+	if isPlainTx && plainTx.Type() == types.DynamicFeeTxType {
+		if !pool.london {
+			return errors.WithMessagef(types.ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+		}
+		if plainTx.GasFeeCap().Cmp(plainTx.GasTipCap()) < 0 {
+			return ErrTipAboveFeeCap
+		}
+	}
 	// For DOS prevention, reject excessively large transactions.
 	if tx.Size() >= types.MaxPoolTransactionDataSize {
 		return errors.WithMessagef(ErrOversizedData, "transaction size is %s", tx.Size().String())
@@ -762,6 +807,16 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	if err != nil {
 		return err
 	}
+	if isPlainTx && pool.berlin {
+		accessListGas, err := vm.AccessListGas(plainTx.AccessList())
+		if err != nil {
+			return err
+		}
+		if intrGas+accessListGas < intrGas {
+			return vm.ErrOutOfGas
+		}
+		intrGas += accessListGas
+	}
 	if tx.GasLimit() < intrGas {
 		return errors.WithMessagef(ErrIntrinsicGas, "transaction gas is %d", tx.GasLimit())
 	}
@@ -974,10 +1029,14 @@ func (pool *TxPool) add(tx types.PoolTransaction, local bool) (bool, error) {
 	from, _ := tx.SenderAddress() // already validated
 	if list := pool.pending[from]; list != nil && list.Overlaps(tx) {
 		// Nonce already pending, check if required price bump is met
+		existing := list.txs.Get(tx.Nonce())
 		inserted, old := list.Add(tx, pool.config.PriceBump)
+		pool.replaced.add(existing, tx, pool.config.PriceBump, inserted)
 		if !inserted {
 			pendingDiscardCounter.Inc(1)
-			return false, errors.WithMessage(ErrReplaceUnderpriced, "existing transaction price was not bumped enough")
+			return false, errors.WithMessagef(ErrReplaceUnderpriced,
+				"existing transaction price was not bumped enough, minimum gas price is %v",
+				replacementPrice(existing.GasPrice(), pool.config.PriceBump))
 		}
 		// New transaction is better, replace old one
 		if old != nil {
@@ -1045,11 +1104,20 @@ func (pool *TxPool) enqueueTx(tx types.PoolTransaction) (bool, error) {
 	if pool.queue[from] == nil {
 		pool.queue[from] = newTxList(false)
 	}
+	existing := pool.queue[from].txs.Get(tx.Nonce())
 	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump)
+	if existing != nil {
+		pool.replaced.add(existing, tx, pool.config.PriceBump, inserted)
+	}
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardCounter.Inc(1)
-		return false, ErrReplaceUnderpriced
+		if existing == nil {
+			return false, ErrReplaceUnderpriced
+		}
+		return false, errors.WithMessagef(ErrReplaceUnderpriced,
+			"existing transaction price was not bumped enough, minimum gas price is %v",
+			replacementPrice(existing.GasPrice(), pool.config.PriceBump))
 	}
 	// Discard any previous transaction and mark this
 	if old != nil {
@@ -1072,10 +1140,10 @@ func (pool *TxPool) enqueueTx(tx types.PoolTransaction) (bool, error) {
 }
 
 // journalTx adds the specified transaction to the local disk journal if it is
-// deemed to have been sent from a local account.
+// deemed to have been sent from a local or submitting account.
 func (pool *TxPool) journalTx(from common.Address, tx types.PoolTransaction) {
-	// Only journal if it's enabled and the transaction is local
-	if pool.journal == nil || !pool.locals.contains(from) {
+	// Only journal if it's enabled and the transaction is local or submitted
+	if pool.journal == nil || (!pool.locals.contains(from) && !pool.submitted.contains(from)) {
 		return
 	}
 	if err := pool.journal.insert(tx); err != nil {
@@ -1158,6 +1226,63 @@ func (pool *TxPool) AddRemotes(txs types.PoolTransactions) []error {
 	return pool.addTxs(txs, false)
 }
 
+// AddSubmitted enqueues a batch of transactions submitted to this node, e.g.
+// through RPC, if they are valid. Full pricing constraints apply as for remote
+// transactions, but the senders are tracked so that their transactions are
+// journaled and survive node restarts.
+func (pool *TxPool) AddSubmitted(txs types.PoolTransactions) []error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	// the transactions of the new senders are not journaled when added
+	fresh := make(map[common.Address]bool)
+	for _, tx := range txs {
+		if from, err := tx.SenderAddress(); err == nil && !pool.submitted.contains(from) {
+			fresh[from] = true
+		}
+	}
+	errs := pool.addTxsLocked(txs, false)
+	if pool.config.NoLocals {
+		return errs
+	}
+	for i, tx := range txs {
+		// a known transaction may have been received from the network first
+		if errs[i] != nil && errs[i] != ErrKnownTransaction {
+			continue
+		}
+		from, _ := tx.SenderAddress() // already validated
+		if fresh[from] {
+			pool.submitted.add(from)
+			pool.journalTx(from, tx)
+		}
+	}
+	return errs
+}
+
+// addJournaled enqueues the transactions loaded from the journal, the ones of
+// the configured local accounts as local, the others as submitted.
+func (pool *TxPool) addJournaled(txs types.PoolTransactions) []error {
+	var (
+		locals, submitted      types.PoolTransactions
+		localIdx, submittedIdx []int
+		errs                   = make([]error, len(txs))
+	)
+	for i, tx := range txs {
+		if pool.locals.containsTx(tx) {
+			locals, localIdx = append(locals, tx), append(localIdx, i)
+		} else {
+			submitted, submittedIdx = append(submitted, tx), append(submittedIdx, i)
+		}
+	}
+	for i, err := range pool.AddLocals(locals) {
+		errs[localIdx[i]] = err
+	}
+	for i, err := range pool.AddSubmitted(submitted) {
+		errs[submittedIdx[i]] = err
+	}
+	return errs
+}
+
 // addTx enqueues a single transaction into the pool if it is valid.
 func (pool *TxPool) addTx(tx types.PoolTransaction, local bool) error {
 	pool.mu.Lock()
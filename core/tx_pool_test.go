@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -1459,6 +1460,118 @@ func testTransactionJournaling(t *testing.T, nolocals bool) {
 	pool.Stop()
 }
 
+// TestTransactionJournalingSubmitted tests that the transactions submitted to the
+// node are journaled and replayed without making their senders local.
+func TestTransactionJournalingSubmitted(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.Journal = filepath.Join(dir, "transactions.rlp")
+	pool := NewTxPool(config, params.TestChainConfig, blockchain, dummyErrorSink)
+
+	submitter, _ := crypto.GenerateKey()
+	remote, _ := crypto.GenerateKey()
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(submitter.PublicKey), big.NewInt(9000000000000000000))
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(remote.PublicKey), big.NewInt(9000000000000000000))
+
+	// The sender of a known transaction is tracked once submitted
+	known := pricedTransaction(0, 0, 100000, big.NewInt(30000000000), submitter)
+	if err := pool.AddRemote(known); err != nil {
+		t.Fatalf("failed to add remote transaction: %v", err)
+	}
+	submitted := types.PoolTransactions{
+		known,
+		pricedTransaction(0, 1, 100000, big.NewInt(30000000000), submitter),
+		pricedTransaction(0, 2, 100000, big.NewInt(1), submitter), // underpriced
+	}
+	errs := pool.AddSubmitted(submitted)
+	if errs[0] != ErrKnownTransaction || errs[1] != nil || errs[2] != ErrUnderpriced {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if err := pool.AddRemote(pricedTransaction(0, 0, 100000, big.NewInt(30000000000), remote)); err != nil {
+		t.Fatalf("failed to add remote transaction: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 3 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 3)
+	}
+	pool.Stop()
+
+	// Restart the pool and ensure only the submitted transactions survive
+	blockchain = &testBlockChain{statedb, 1000000, new(event.Feed)}
+	pool = NewTxPool(config, params.TestChainConfig, blockchain, dummyErrorSink)
+	defer pool.Stop()
+
+	if pending, queued := pool.Stats(); pending != 2 || queued != 0 {
+		t.Fatalf("transactions mismatched: have %d/%d, want %d/%d", pending, queued, 2, 0)
+	}
+	for _, tx := range submitted[:2] {
+		if pool.Get(tx.Hash()) == nil {
+			t.Errorf("submitted transaction %x not replayed", tx.Hash())
+		}
+	}
+	if locals := pool.Locals(); len(locals) != 0 {
+		t.Errorf("unexpected local accounts: %v", locals)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// TestTransactionReplacements tests that the pool records the replacements of
+// pending and queued transactions and the underpriced replacement attempts.
+func TestTransactionReplacements(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+	pool.currentState.AddBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(9000000000000000000))
+
+	minPrice := big.NewInt(30900000000) // 3% above 30 Gwei
+	txs := []types.PoolTransaction{
+		pricedTransaction(0, 0, 100000, big.NewInt(30000000000), key),
+		pricedTransaction(0, 0, 100000, big.NewInt(30800000000), key), // underpriced
+		pricedTransaction(0, 0, 100000, minPrice, key),
+		pricedTransaction(0, 5, 100000, big.NewInt(30000000000), key), // queued
+		pricedTransaction(0, 5, 100000, big.NewInt(40000000000), key),
+	}
+	for i, tx := range txs {
+		err := pool.AddRemote(tx)
+		if expUnderpriced := i == 1; expUnderpriced != (err == ErrReplaceUnderpriced) {
+			t.Fatalf("transaction %d: unexpected error %v", i, err)
+		}
+	}
+	exp := []struct {
+		old, new int
+		replaced bool
+	}{
+		{0, 1, false},
+		{0, 2, true},
+		{3, 4, true},
+	}
+	replacements := pool.Replacements()
+	if len(replacements) != len(exp) {
+		t.Fatalf("replacements mismatched: have %d, want %d", len(replacements), len(exp))
+	}
+	for i, r := range replacements {
+		oldTx, newTx := txs[exp[i].old], txs[exp[i].new]
+		if r.Old != oldTx.Hash() || r.New != newTx.Hash() || r.Replaced != exp[i].replaced || r.Nonce != newTx.Nonce() {
+			t.Errorf("replacement %d mismatched: %+v", i, r)
+		}
+		if i < 2 && r.MinPrice.Cmp(minPrice) != 0 {
+			t.Errorf("replacement %d: min price %v, want %v", i, r.MinPrice, minPrice)
+		}
+	}
+}
+
 // TestTransactionStatusCheck tests that the pool can correctly retrieve the
 // pending status of individual transactions.
 func TestTransactionStatusCheck(t *testing.T) {
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// maxTxReplacements is the number of replacement attempts remembered by the pool
+const maxTxReplacements = 1024
+
+// TxReplacement is an attempt to replace a pool transaction with a transaction
+// of the same sender and nonce, either replacing it or rejected as underpriced.
+type TxReplacement struct {
+	From     common.Address
+	Nonce    uint64
+	Old      common.Hash // transaction in the pool
+	New      common.Hash // replacing transaction
+	OldPrice *big.Int
+	NewPrice *big.Int
+	MinPrice *big.Int // minimum gas price of a replacement
+	Replaced bool     // false if rejected as underpriced
+	Time     time.Time
+}
+
+// txReplacements remembers the last replacement attempts in a ring buffer
+type txReplacements struct {
+	entries []TxReplacement
+	next    int
+}
+
+func newTxReplacements(size int) *txReplacements {
+	return &txReplacements{entries: make([]TxReplacement, 0, size)}
+}
+
+// add records an attempt to replace the old transaction with the new one
+func (r *txReplacements) add(old, tx types.PoolTransaction, priceBump uint64, replaced bool) {
+	from, _ := tx.SenderAddress() // already validated
+	entry := TxReplacement{
+		From:     from,
+		Nonce:    tx.Nonce(),
+		Old:      old.Hash(),
+		New:      tx.Hash(),
+		OldPrice: old.GasPrice(),
+		NewPrice: tx.GasPrice(),
+		MinPrice: replacementPrice(old.GasPrice(), priceBump),
+		Replaced: replaced,
+		Time:     time.Now(),
+	}
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+}
+
+// list returns the recorded attempts from the oldest to the newest
+func (r *txReplacements) list() []TxReplacement {
+	res := make([]TxReplacement, 0, len(r.entries))
+	res = append(res, r.entries[r.next:]...)
+	return append(res, r.entries[:r.next]...)
+}
+
+// replacementPrice returns the minimum gas price of a transaction replacing one
+// priced at old: the price bump percentage above old, and at least one wei above
+// so that low (Wei-level) gas prices are also bumped.
+func replacementPrice(old *big.Int, priceBump uint64) *big.Int {
+	threshold := new(big.Int).Div(new(big.Int).Mul(old, big.NewInt(100+int64(priceBump))), big.NewInt(100))
+	if min := new(big.Int).Add(old, common.Big1); threshold.Cmp(min) < 0 {
+		return min
+	}
+	return threshold
+}
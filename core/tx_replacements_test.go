@@ -0,0 +1,43 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestReplacementPrice(t *testing.T) {
+	tests := []struct {
+		old, bump, exp int64
+	}{
+		{100, 10, 110},
+		{30000000000, 3, 30900000000},
+		{5, 10, 6}, // at least one wei above
+		{0, 10, 1},
+	}
+	for i, test := range tests {
+		if price := replacementPrice(big.NewInt(test.old), uint64(test.bump)); price.Int64() != test.exp {
+			t.Errorf("Test %v: unexpected price %v / %v", i, price, test.exp)
+		}
+	}
+}
+
+func TestTxReplacementsRing(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	r := newTxReplacements(3)
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		old := pricedTransaction(0, nonce, 100000, big.NewInt(1), key)
+		tx := pricedTransaction(0, nonce, 100000, big.NewInt(2), key)
+		r.add(old, tx, 10, true)
+	}
+	list := r.list()
+	if len(list) != 3 {
+		t.Fatalf("unexpected length %v / %v", len(list), 3)
+	}
+	for i, entry := range list {
+		if exp := uint64(i + 2); entry.Nonce != exp {
+			t.Errorf("entry %v: unexpected nonce %v / %v", i, entry.Nonce, exp)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Transaction envelope types, see EIP-2718. Legacy transactions are not enveloped.
+const (
+	LegacyTxType     = 0x00
+	AccessListTxType = 0x01
+	DynamicFeeTxType = 0x02
+)
+
+// Errors of typed transactions.
+var (
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+	errShortTypedTx       = errors.New("typed transaction too short")
+)
+
+// AccessList is an EIP-2930 access list.
+type AccessList []AccessTuple
+
+// AccessTuple is the element type of an access list.
+type AccessTuple struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []common.Hash  `json:"storageKeys"`
+}
+
+// StorageKeys returns the total number of storage keys in the access list.
+func (al AccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+	return sum
+}
+
+// Copy returns a deep copy of the access list.
+func (al AccessList) Copy() AccessList {
+	if al == nil {
+		return nil
+	}
+	cpy := make(AccessList, len(al))
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]common.Hash(nil), tuple.StorageKeys...),
+		}
+	}
+	return cpy
+}
+
+// accessListTxdata is the EIP-2930 payload of a harmony transaction.
+type accessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values, V is the y parity of the signature
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethAccessListTxdata is the EIP-2930 payload of an ethereum-compatible transaction.
+type ethAccessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values, V is the y parity of the signature
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// encodeTyped returns the EIP-2718 envelope of a typed transaction payload.
+func encodeTyped(txType uint8, payload interface{}) ([]byte, error) {
+	enc, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{txType}, enc...), nil
+}
+
+// prefixedRlpHash hashes the EIP-2718 envelope of a typed transaction payload.
+func prefixedRlpHash(txType uint8, payload interface{}) common.Hash {
+	enc, _ := encodeTyped(txType, payload)
+	return crypto.Keccak256Hash(enc)
+}
+
+// isLegacyTxBytes returns whether the binary encoding of a transaction is a
+// legacy RLP list rather than an EIP-2718 envelope, whose type is at most 0x7f.
+func isLegacyTxBytes(b []byte) bool {
+	return len(b) > 0 && b[0] > 0x7f
+}
@@ -38,6 +38,7 @@ import (
 	v1 "github.com/harmony-one/harmony/block/v1"
 	v2 "github.com/harmony-one/harmony/block/v2"
 	v3 "github.com/harmony-one/harmony/block/v3"
+	v4 "github.com/harmony-one/harmony/block/v4"
 	"github.com/harmony-one/harmony/crypto/hash"
 	"github.com/harmony-one/harmony/internal/utils"
 	staking "github.com/harmony-one/harmony/staking/types"
@@ -143,7 +144,7 @@ type Body struct {
 func NewBodyForMatchingHeader(h *block.Header) (*Body, error) {
 	var bi BodyInterface
 	switch h.Header.(type) {
-	case *v3.Header:
+	case *v4.Header, *v3.Header:
 		bi = new(BodyV2)
 	case *v2.Header, *v1.Header:
 		bi = new(BodyV1)
@@ -423,7 +424,7 @@ func (b *Block) EncodeRLP(w io.Writer) error {
 	var eb interface{}
 
 	switch h := b.header.Header.(type) {
-	case *v3.Header:
+	case *v4.Header, *v3.Header:
 		eb = extblockV2{b.header, b.transactions, b.stakingTransactions, b.uncles, b.incomingReceipts}
 	case *v2.Header, *v1.Header:
 		eb = extblockV1{b.header, b.transactions, b.uncles, b.incomingReceipts}
@@ -475,6 +476,9 @@ func (b *Block) GasLimit() uint64 { return b.header.GasLimit() }
 // GasUsed returns header gas used.
 func (b *Block) GasUsed() uint64 { return b.header.GasUsed() }
 
+// BaseFee returns the EIP-1559 base fee of the block, nil before the London fork.
+func (b *Block) BaseFee() *big.Int { return b.header.BaseFee() }
+
 // Time is header time.
 func (b *Block) Time() *big.Int { return b.header.Time() }
 
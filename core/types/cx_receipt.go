@@ -7,7 +7,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
 	"github.com/pkg/errors"
 )
@@ -117,6 +119,67 @@ func (cs CXReceipts) GetToShardReceipts(shardID uint32) CXReceipts {
 	return cxs
 }
 
+// cxProofList collects the trie nodes of a merkle proof, in path order
+type cxProofList [][]byte
+
+func (l *cxProofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *cxProofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// Prove returns the trie nodes proving the i'th receipt against the root of
+// the list, as computed by DeriveSha.
+func (cs CXReceipts) Prove(i int) ([][]byte, error) {
+	if i < 0 || i >= len(cs) {
+		return nil, errors.Errorf("receipt index %d out of range [0, %d)", i, len(cs))
+	}
+	keybuf := new(bytes.Buffer)
+	tr := new(trie.Trie)
+	for j := range cs {
+		keybuf.Reset()
+		rlp.Encode(keybuf, uint(j))
+		tr.Update(keybuf.Bytes(), cs.GetRlp(j))
+	}
+	keybuf.Reset()
+	rlp.Encode(keybuf, uint(i))
+	proof := cxProofList{}
+	if err := tr.Prove(keybuf.Bytes(), 0, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyCXReceiptProof checks the proof of the i'th receipt of a list against
+// the root of the list and returns the proven receipt.
+func VerifyCXReceiptProof(root common.Hash, i int, proof [][]byte) (*CXReceipt, error) {
+	proofDb := memorydb.New()
+	for _, node := range proof {
+		if err := proofDb.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	key, err := rlp.EncodeToBytes(uint(i))
+	if err != nil {
+		return nil, err
+	}
+	value, _, err := trie.VerifyProof(root, key, proofDb)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.Errorf("no receipt at index %d", i)
+	}
+	cx := &CXReceipt{}
+	if err := rlp.DecodeBytes(value, cx); err != nil {
+		return nil, err
+	}
+	return cx, nil
+}
+
 // CXMerkleProof represents the merkle proof of a collection of ordered cross shard transactions
 type CXMerkleProof struct {
 	BlockNum      *big.Int      // blockNumber of source shard
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCXReceipts_Prove(t *testing.T) {
+	cxs := CXReceipts{}
+	for i := 0; i < 20; i++ {
+		to := common.BigToAddress(big.NewInt(int64(i + 100)))
+		cxs = append(cxs, &CXReceipt{
+			TxHash:    common.BigToHash(big.NewInt(int64(i))),
+			From:      common.BigToAddress(big.NewInt(int64(i))),
+			To:        &to,
+			ShardID:   0,
+			ToShardID: 1,
+			Amount:    big.NewInt(int64(i * 1000)),
+		})
+	}
+	root := DeriveSha(cxs)
+	for i := range cxs {
+		proof, err := cxs.Prove(i)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		cx, err := VerifyCXReceiptProof(root, i, proof)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if cx.TxHash != cxs[i].TxHash || cx.Amount.Cmp(cxs[i].Amount) != 0 {
+			t.Errorf("Test %v: unexpected receipt %+v", i, cx)
+		}
+		if _, err := VerifyCXReceiptProof(common.Hash{1}, i, proof); err == nil {
+			t.Errorf("Test %v: proof verified against a wrong root", i)
+		}
+	}
+	if _, err := cxs.Prove(len(cxs)); err == nil {
+		t.Errorf("expected error for out of range index")
+	}
+}
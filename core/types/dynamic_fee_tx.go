@@ -0,0 +1,70 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// dynamicFeeTxdata is the EIP-1559 payload of a harmony transaction.
+type dynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap    *big.Int // a.k.a. maxFeePerGas
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values, V is the y parity of the signature
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethDynamicFeeTxdata is the EIP-1559 payload of an ethereum-compatible transaction.
+type ethDynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap    *big.Int // a.k.a. maxFeePerGas
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values, V is the y parity of the signature
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// effectiveGasTip returns the tip a transaction pays to the block proposer on
+// top of the base fee, which may be negative if the fee cap is below it.
+func effectiveGasTip(gasTipCap, gasFeeCap, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(gasTipCap)
+	}
+	tip := new(big.Int).Sub(gasFeeCap, baseFee)
+	if tip.Cmp(gasTipCap) > 0 {
+		tip.Set(gasTipCap)
+	}
+	return tip
+}
+
+// effectiveGasPrice returns the price per gas a transaction pays given the base fee.
+func effectiveGasPrice(gasTipCap, gasFeeCap, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(gasFeeCap)
+	}
+	price := new(big.Int).Add(gasTipCap, baseFee)
+	if price.Cmp(gasFeeCap) > 0 {
+		price.Set(gasFeeCap)
+	}
+	return price
+}
@@ -62,6 +62,13 @@ type ethTxdata struct {
 	R *big.Int `json:"r" gencodec:"required"`
 	S *big.Int `json:"s" gencodec:"required"`
 
+	// Typed transaction fields, they are not part of the legacy encoding.
+	Type       uint8      `json:"type,omitempty"       rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"    rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty" rlp:"-"`
+	// GasTipCap is the EIP-1559 priority fee, the price is the fee cap of those transactions.
+	GasTipCap *big.Int `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
 }
@@ -76,9 +83,46 @@ func (d *ethTxdata) CopyFrom(d2 *ethTxdata) {
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.AccessList = d2.AccessList.Copy()
+	d.GasTipCap = copyBig(d2.GasTipCap)
 	d.Hash = copyHash(d2.Hash)
 }
 
+// payload returns the EIP-2718 payload of a typed transaction.
+func (d *ethTxdata) payload() interface{} {
+	if d.Type == DynamicFeeTxType {
+		return &ethDynamicFeeTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			GasTipCap:    d.GasTipCap,
+			GasFeeCap:    d.Price,
+			GasLimit:     d.GasLimit,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		}
+	}
+	return &ethAccessListTxdata{
+		ChainID:      d.ChainID,
+		AccountNonce: d.AccountNonce,
+		Price:        d.Price,
+		GasLimit:     d.GasLimit,
+		Recipient:    d.Recipient,
+		Amount:       d.Amount,
+		Payload:      d.Payload,
+		AccessList:   d.AccessList,
+		V:            d.V,
+		R:            d.R,
+		S:            d.S,
+	}
+}
+
 type ethTxdataMarshaling struct {
 	AccountNonce hexutil.Uint64
 	Price        *hexutil.Big
@@ -88,6 +132,8 @@ type ethTxdataMarshaling struct {
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
 }
 
 // NewEthTransaction returns new ethereum-compatible transaction, which works as a intra-shard transaction
@@ -120,6 +166,25 @@ func newEthTransaction(nonce uint64, to *common.Address, amount *big.Int, gasLim
 	return &EthTransaction{data: d, time: time.Now()}
 }
 
+// NewEthAccessListTransaction returns a new EIP-2930 ethereum-compatible transaction.
+// A nil recipient means contract creation.
+func NewEthAccessListTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := newEthTransaction(nonce, to, amount, gasLimit, gasPrice, data)
+	tx.data.Type = AccessListTxType
+	tx.data.ChainID = new(big.Int).Set(chainID)
+	tx.data.AccessList = accessList.Copy()
+	return tx
+}
+
+// NewEthDynamicFeeTransaction returns a new EIP-1559 ethereum-compatible transaction.
+// A nil recipient means contract creation.
+func NewEthDynamicFeeTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := NewEthAccessListTransaction(chainID, nonce, to, amount, gasLimit, gasFeeCap, data, accessList)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.GasTipCap = new(big.Int).Set(gasTipCap)
+	return tx
+}
+
 // From returns the sender address of the transaction
 func (tx *EthTransaction) From() *atomic.Value {
 	return &tx.from
@@ -175,13 +240,54 @@ func (tx *EthTransaction) shardID() uint32 {
 	return uint32(tx.ChainID().Uint64()-ethChainIDBase.Uint64()) + nodeconfig.GetDefaultConfig().ShardID
 }
 
-// ChainID returns which chain id this transaction was signed for (if at all)
+// ChainID returns which chain id this transaction was signed for (if at all).
+// A typed transaction without chain id, which is not valid, returns zero.
 func (tx *EthTransaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		if tx.data.ChainID == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
+// Type returns the EIP-2718 type of the transaction, legacy transactions are 0.
+func (tx *EthTransaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// AccessList returns the EIP-2930 access list of the transaction.
+func (tx *EthTransaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
+// GasTipCap returns the EIP-1559 priority fee per gas of the transaction,
+// which is the gas price for other transaction types.
+func (tx *EthTransaction) GasTipCap() *big.Int {
+	if tx.data.Type == DynamicFeeTxType {
+		return new(big.Int).Set(tx.data.GasTipCap)
+	}
+	return new(big.Int).Set(tx.data.Price)
+}
+
+// GasFeeCap returns the EIP-1559 max fee per gas of the transaction,
+// which is the gas price for other transaction types.
+func (tx *EthTransaction) GasFeeCap() *big.Int {
+	return new(big.Int).Set(tx.data.Price)
+}
+
+// EffectiveGasPrice returns the price per gas paid by the transaction given the base fee.
+func (tx *EthTransaction) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	return effectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee)
+}
+
 // Protected returns whether the transaction is protected from replay protection.
+// Typed transactions always include the chain ID.
 func (tx *EthTransaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -208,6 +314,10 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.AccessList = d.AccessList.Copy()
+	d2.GasTipCap = copyBig(d.GasTipCap)
 
 	d2.ShardID = tx.ShardID()
 	d2.ToShardID = tx.ToShardID()
@@ -220,15 +330,33 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	return &tx2
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder, typed transactions are encoded as
+// an RLP string of their EIP-2718 envelope.
 func (tx *EthTransaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *EthTransaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind != rlp.List {
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		return tx.UnmarshalBinary(b)
+	}
+	err = s.Decode(&tx.data)
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 		tx.time = time.Now()
@@ -237,6 +365,79 @@ func (tx *EthTransaction) DecodeRLP(s *rlp.Stream) error {
 	return err
 }
 
+// MarshalBinary returns the canonical encoding of the transaction, the RLP
+// list of legacy transactions or the EIP-2718 envelope of typed transactions.
+// This is the encoding of raw transactions sent through the RPC.
+func (tx *EthTransaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	return encodeTyped(tx.data.Type, tx.data.payload())
+}
+
+// UnmarshalBinary decodes the canonical encoding of a transaction.
+func (tx *EthTransaction) UnmarshalBinary(b []byte) error {
+	if isLegacyTxBytes(b) {
+		var data ethTxdata
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+		*tx = EthTransaction{data: data, time: time.Now()}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	}
+	if len(b) <= 1 {
+		return errShortTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner ethAccessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*tx = EthTransaction{data: ethTxdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.Price,
+			GasLimit:     inner.GasLimit,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+			Type:         AccessListTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+		}, time: time.Now()}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	case DynamicFeeTxType:
+		var inner ethDynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*tx = EthTransaction{data: ethTxdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.GasFeeCap,
+			GasLimit:     inner.GasLimit,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+			Type:         DynamicFeeTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+			GasTipCap:    inner.GasTipCap,
+		}, time: time.Now()}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
 // MarshalJSON encodes the web3 RPC transaction format.
 func (tx *EthTransaction) MarshalJSON() ([]byte, error) {
 	hash := tx.Hash()
@@ -255,7 +456,9 @@ func (tx *EthTransaction) UnmarshalJSON(input []byte) error {
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -306,7 +509,12 @@ func (tx *EthTransaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		v = prefixedRlpHash(tx.data.Type, tx.data.payload())
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -317,6 +525,11 @@ func (tx *EthTransaction) Size() common.StorageSize {
 	if size := tx.size.Load(); size != nil {
 		return size.(common.StorageSize)
 	}
+	if tx.data.Type != LegacyTxType {
+		enc, _ := tx.MarshalBinary()
+		tx.size.Store(common.StorageSize(len(enc)))
+		return common.StorageSize(len(enc))
+	}
 	c := writeCounter(0)
 	rlp.Encode(&c, &tx.data)
 	tx.size.Store(common.StorageSize(c))
@@ -364,8 +577,13 @@ func (tx *EthTransaction) AsMessage(s Signer) (Message, error) {
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
+	if tx.data.Type == DynamicFeeTxType {
+		msg.gasFeeCap = new(big.Int).Set(tx.data.Price)
+		msg.gasTipCap = new(big.Int).Set(tx.data.GasTipCap)
+	}
 
 	var err error
 	msg.from, err = Sender(s, tx)
@@ -25,6 +25,11 @@ func (e ethTxdata) MarshalJSON() ([]byte, error) {
 		V            *hexutil.Big    `json:"v" gencodec:"required"`
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
+		Type         hexutil.Uint64  `json:"type,omitempty"       rlp:"-"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"    rlp:"-"`
+		AccessList   *AccessList     `json:"accessList,omitempty" rlp:"-"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
 	}
 	var enc ethTxdata
@@ -37,6 +42,15 @@ func (e ethTxdata) MarshalJSON() ([]byte, error) {
 	enc.V = (*hexutil.Big)(e.V)
 	enc.R = (*hexutil.Big)(e.R)
 	enc.S = (*hexutil.Big)(e.S)
+	enc.Type = hexutil.Uint64(e.Type)
+	enc.ChainID = (*hexutil.Big)(e.ChainID)
+	if e.Type != LegacyTxType {
+		enc.AccessList = &e.AccessList
+	}
+	if e.Type == DynamicFeeTxType {
+		enc.GasTipCap = (*hexutil.Big)(e.GasTipCap)
+		enc.GasFeeCap = (*hexutil.Big)(e.Price)
+	}
 	enc.Hash = e.Hash
 	return json.Marshal(&enc)
 }
@@ -53,6 +67,11 @@ func (e *ethTxdata) UnmarshalJSON(input []byte) error {
 		V            *hexutil.Big    `json:"v" gencodec:"required"`
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
+		Type         *hexutil.Uint64 `json:"type,omitempty"       rlp:"-"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"    rlp:"-"`
+		AccessList   *AccessList     `json:"accessList,omitempty" rlp:"-"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+		GasFeeCap    *hexutil.Big    `json:"maxFeePerGas,omitempty"`
 		Hash         *common.Hash    `json:"hash" rlp:"-"`
 	}
 	var dec ethTxdata
@@ -94,6 +113,27 @@ func (e *ethTxdata) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 's' for ethTxdata")
 	}
 	e.S = (*big.Int)(dec.S)
+	if dec.Type != nil {
+		e.Type = uint8(*dec.Type)
+	}
+	if dec.ChainID != nil {
+		e.ChainID = (*big.Int)(dec.ChainID)
+	}
+	if dec.AccessList != nil {
+		e.AccessList = *dec.AccessList
+	}
+	if dec.GasTipCap != nil {
+		e.GasTipCap = (*big.Int)(dec.GasTipCap)
+	}
+	if dec.GasFeeCap != nil {
+		e.Price = (*big.Int)(dec.GasFeeCap)
+	}
+	if e.Type == DynamicFeeTxType && e.GasTipCap == nil {
+		return errors.New("missing required field 'maxPriorityFeePerGas' for txdata")
+	}
+	if e.Type != LegacyTxType && e.ChainID == nil {
+		return errors.New("missing required field 'chainId' for ethTxdata")
+	}
 	if dec.Hash != nil {
 		e.Hash = dec.Hash
 	}
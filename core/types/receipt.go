@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math/big"
 	"unsafe"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -57,6 +58,11 @@ type Receipt struct {
 	TxHash          common.Hash    `json:"transactionHash" gencodec:"required"`
 	ContractAddress common.Address `json:"contractAddress"`
 	GasUsed         uint64         `json:"gasUsed" gencodec:"required"`
+
+	// EffectiveGasPrice is the price paid per unit of gas after EIP-1559.
+	// It is derived from the transaction and the block base fee and is
+	// therefore not part of the stored receipt.
+	EffectiveGasPrice *big.Int `json:"effectiveGasPrice"`
 }
 
 type receiptMarshaling struct {
@@ -64,6 +70,7 @@ type receiptMarshaling struct {
 	Status            hexutil.Uint64
 	CumulativeGasUsed hexutil.Uint64
 	GasUsed           hexutil.Uint64
+	EffectiveGasPrice *hexutil.Big
 }
 
 // receiptRLP is the consensus encoding of a receipt.
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StateDiff is the change of the state made by a transaction. The changes made
+// by a block after its transactions, such as the incoming cross-shard receipts
+// and the block rewards, have an empty TxHash.
+type StateDiff struct {
+	TxHash   common.Hash
+	Accounts []*AccountDiff // sorted by address
+}
+
+// AccountDiff is the change of an account. The values of an account which does
+// not exist are zero.
+type AccountDiff struct {
+	Address        common.Address
+	Existed        bool // whether the account existed before the change
+	Exists         bool // whether the account exists after the change
+	BalanceBefore  *big.Int
+	BalanceAfter   *big.Int
+	NonceBefore    uint64
+	NonceAfter     uint64
+	CodeHashBefore common.Hash
+	CodeHashAfter  common.Hash
+	Storage        []*StorageDiff // sorted by key
+}
+
+// StorageDiff is the change of a storage slot.
+type StorageDiff struct {
+	Key    common.Hash
+	Before common.Hash
+	After  common.Hash
+}
+
+// Changed reports whether the account differs before and after the change.
+func (d *AccountDiff) Changed() bool {
+	if d.Existed != d.Exists || d.NonceBefore != d.NonceAfter ||
+		d.CodeHashBefore != d.CodeHashAfter || d.BalanceBefore.Cmp(d.BalanceAfter) != 0 {
+		return true
+	}
+	for _, slot := range d.Storage {
+		if slot.Before != slot.After {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge returns the change of the account made by the receiver followed by the
+// given change of the same account.
+func (d *AccountDiff) Merge(next *AccountDiff) *AccountDiff {
+	merged := *d
+	merged.Exists = next.Exists
+	merged.BalanceAfter = next.BalanceAfter
+	merged.NonceAfter = next.NonceAfter
+	merged.CodeHashAfter = next.CodeHashAfter
+
+	slots := make(map[common.Hash]*StorageDiff, len(d.Storage)+len(next.Storage))
+	for _, slot := range d.Storage {
+		cpy := *slot
+		slots[slot.Key] = &cpy
+	}
+	for _, slot := range next.Storage {
+		if prev, ok := slots[slot.Key]; ok {
+			prev.After = slot.After
+		} else {
+			cpy := *slot
+			slots[slot.Key] = &cpy
+		}
+	}
+	merged.Storage = make([]*StorageDiff, 0, len(slots))
+	for _, slot := range slots {
+		merged.Storage = append(merged.Storage, slot)
+	}
+	sort.Slice(merged.Storage, func(i, j int) bool {
+		return bytes.Compare(merged.Storage[i].Key[:], merged.Storage[j].Key[:]) < 0
+	})
+	return &merged
+}
+
+// MergeStateDiffs returns the accounts changed by the given state diffs applied
+// in order, sorted by address.
+func MergeStateDiffs(diffs []*StateDiff) []*AccountDiff {
+	accounts := make(map[common.Address]*AccountDiff)
+	for _, diff := range diffs {
+		for _, account := range diff.Accounts {
+			if prev, ok := accounts[account.Address]; ok {
+				accounts[account.Address] = prev.Merge(account)
+			} else {
+				accounts[account.Address] = account
+			}
+		}
+	}
+	merged := make([]*AccountDiff, 0, len(accounts))
+	for _, account := range accounts {
+		if account.Changed() {
+			merged = append(merged, account)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return bytes.Compare(merged[i].Address[:], merged[j].Address[:]) < 0
+	})
+	return merged
+}
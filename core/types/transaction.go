@@ -143,6 +143,13 @@ type txdata struct {
 	R *big.Int `json:"r" gencodec:"required"`
 	S *big.Int `json:"s" gencodec:"required"`
 
+	// Typed transaction fields, they are not part of the legacy encoding.
+	Type       uint8      `json:"type,omitempty"       rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"    rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty" rlp:"-"`
+	// GasTipCap is the EIP-1559 priority fee, the price is the fee cap of those transactions.
+	GasTipCap *big.Int `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
 }
@@ -175,9 +182,57 @@ func (d *txdata) CopyFrom(d2 *txdata) {
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.AccessList = d2.AccessList.Copy()
+	d.GasTipCap = copyBig(d2.GasTipCap)
 	d.Hash = copyHash(d2.Hash)
 }
 
+func copyBig(b *big.Int) *big.Int {
+	if b == nil {
+		return nil
+	}
+	return new(big.Int).Set(b)
+}
+
+// payload returns the EIP-2718 payload of a typed transaction.
+func (d *txdata) payload() interface{} {
+	if d.Type == DynamicFeeTxType {
+		return &dynamicFeeTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			GasTipCap:    d.GasTipCap,
+			GasFeeCap:    d.Price,
+			GasLimit:     d.GasLimit,
+			ShardID:      d.ShardID,
+			ToShardID:    d.ToShardID,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		}
+	}
+	return &accessListTxdata{
+		ChainID:      d.ChainID,
+		AccountNonce: d.AccountNonce,
+		Price:        d.Price,
+		GasLimit:     d.GasLimit,
+		ShardID:      d.ShardID,
+		ToShardID:    d.ToShardID,
+		Recipient:    d.Recipient,
+		Amount:       d.Amount,
+		Payload:      d.Payload,
+		AccessList:   d.AccessList,
+		V:            d.V,
+		R:            d.R,
+		S:            d.S,
+	}
+}
+
 type txdataMarshaling struct {
 	AccountNonce hexutil.Uint64
 	Price        *hexutil.Big
@@ -187,6 +242,8 @@ type txdataMarshaling struct {
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
 }
 
 // NewTransaction returns new transaction, this method is to create same shard transaction
@@ -204,6 +261,23 @@ func NewContractCreation(nonce uint64, shardID uint32, amount *big.Int, gasLimit
 	return newTransaction(nonce, nil, shardID, amount, gasLimit, gasPrice, data)
 }
 
+// NewAccessListTransaction returns a new EIP-2930 transaction. A nil recipient means contract creation.
+func NewAccessListTransaction(chainID *big.Int, nonce uint64, to *common.Address, shardID uint32, toShardID uint32, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, accessList AccessList) *Transaction {
+	tx := newCrossShardTransaction(nonce, to, shardID, toShardID, amount, gasLimit, gasPrice, data)
+	tx.data.Type = AccessListTxType
+	tx.data.ChainID = new(big.Int).Set(chainID)
+	tx.data.AccessList = accessList.Copy()
+	return tx
+}
+
+// NewDynamicFeeTransaction returns a new EIP-1559 transaction. A nil recipient means contract creation.
+func NewDynamicFeeTransaction(chainID *big.Int, nonce uint64, to *common.Address, shardID uint32, toShardID uint32, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte, accessList AccessList) *Transaction {
+	tx := NewAccessListTransaction(chainID, nonce, to, shardID, toShardID, amount, gasLimit, gasFeeCap, data, accessList)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.GasTipCap = new(big.Int).Set(gasTipCap)
+	return tx
+}
+
 func newTransaction(nonce uint64, to *common.Address, shardID uint32, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte) *Transaction {
 	if len(data) > 0 {
 		data = common.CopyBytes(data)
@@ -298,11 +372,54 @@ func (tx *Transaction) Data() []byte {
 	return common.CopyBytes(tx.data.Payload)
 }
 
-// ChainID returns which chain id this transaction was signed for (if at all)
+// ChainID returns which chain id this transaction was signed for (if at all).
+// A typed transaction without chain id, which is not valid, returns zero.
 func (tx *Transaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		if tx.data.ChainID == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
+// Type returns the EIP-2718 type of the transaction, legacy transactions are 0.
+func (tx *Transaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// AccessList returns the EIP-2930 access list of the transaction.
+func (tx *Transaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
+// GasTipCap returns the EIP-1559 priority fee per gas of the transaction,
+// which is the gas price for other transaction types.
+func (tx *Transaction) GasTipCap() *big.Int {
+	if tx.data.Type == DynamicFeeTxType {
+		return new(big.Int).Set(tx.data.GasTipCap)
+	}
+	return new(big.Int).Set(tx.data.Price)
+}
+
+// GasFeeCap returns the EIP-1559 max fee per gas of the transaction,
+// which is the gas price for other transaction types.
+func (tx *Transaction) GasFeeCap() *big.Int {
+	return new(big.Int).Set(tx.data.Price)
+}
+
+// EffectiveGasTip returns the tip per gas paid to the block proposer given
+// the base fee, it is negative if the fee cap is below the base fee.
+func (tx *Transaction) EffectiveGasTip(baseFee *big.Int) *big.Int {
+	return effectiveGasTip(tx.GasTipCap(), tx.GasFeeCap(), baseFee)
+}
+
+// EffectiveGasPrice returns the price per gas paid by the transaction given the base fee.
+func (tx *Transaction) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	return effectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee)
+}
+
 // ShardID returns which shard id this transaction was signed for (if at all)
 func (tx *Transaction) ShardID() uint32 {
 	return tx.data.ShardID
@@ -319,7 +436,11 @@ func (tx *Transaction) Time() time.Time {
 }
 
 // Protected returns whether the transaction is protected from replay protection.
+// Typed transactions always include the chain ID.
 func (tx *Transaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -332,15 +453,33 @@ func isProtectedV(V *big.Int) bool {
 	return true
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder, typed transactions are encoded as
+// an RLP string of their EIP-2718 envelope.
 func (tx *Transaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind != rlp.List {
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		return tx.UnmarshalBinary(b)
+	}
+	err = s.Decode(&tx.data)
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 		tx.time = time.Now()
@@ -349,6 +488,82 @@ func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
 	return err
 }
 
+// MarshalBinary returns the canonical encoding of the transaction, the RLP
+// list of legacy transactions or the EIP-2718 envelope of typed transactions.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	return encodeTyped(tx.data.Type, tx.data.payload())
+}
+
+// UnmarshalBinary decodes the canonical encoding of a transaction.
+func (tx *Transaction) UnmarshalBinary(b []byte) error {
+	if isLegacyTxBytes(b) {
+		var data txdata
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+		*tx = Transaction{data: data, time: time.Now()}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	}
+	if len(b) <= 1 {
+		return errShortTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner accessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*tx = Transaction{data: txdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.Price,
+			GasLimit:     inner.GasLimit,
+			ShardID:      inner.ShardID,
+			ToShardID:    inner.ToShardID,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+			Type:         AccessListTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+		}, time: time.Now()}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	case DynamicFeeTxType:
+		var inner dynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*tx = Transaction{data: txdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.GasFeeCap,
+			GasLimit:     inner.GasLimit,
+			ShardID:      inner.ShardID,
+			ToShardID:    inner.ToShardID,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+			Type:         DynamicFeeTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+			GasTipCap:    inner.GasTipCap,
+		}, time: time.Now()}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
 // MarshalJSON encodes the web3 RPC transaction format.
 func (tx *Transaction) MarshalJSON() ([]byte, error) {
 	hash := tx.Hash()
@@ -367,7 +582,9 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -408,7 +625,12 @@ func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		v = prefixedRlpHash(tx.data.Type, tx.data.payload())
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -428,6 +650,11 @@ func (tx *Transaction) Size() common.StorageSize {
 	if size := tx.size.Load(); size != nil {
 		return size.(common.StorageSize)
 	}
+	if tx.data.Type != LegacyTxType {
+		enc, _ := tx.MarshalBinary()
+		tx.size.Store(common.StorageSize(len(enc)))
+		return common.StorageSize(len(enc))
+	}
 	c := writeCounter(0)
 	rlp.Encode(&c, &tx.data)
 	tx.size.Store(common.StorageSize(c))
@@ -454,6 +681,10 @@ func (tx *Transaction) ConvertToEth() *EthTransaction {
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.AccessList = d.AccessList.Copy()
+	d2.GasTipCap = copyBig(d.GasTipCap)
 
 	copy := tx2.Hash()
 	d2.Hash = &copy
@@ -476,8 +707,13 @@ func (tx *Transaction) AsMessage(s Signer) (Message, error) {
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
+	if tx.data.Type == DynamicFeeTxType {
+		msg.gasFeeCap = new(big.Int).Set(tx.data.Price)
+		msg.gasTipCap = new(big.Int).Set(tx.data.GasTipCap)
+	}
 
 	var err error
 	msg.from, err = Sender(s, tx)
@@ -563,24 +799,45 @@ func (s *TxByPrice) Pop() interface{} {
 	return x
 }
 
+// TxWithMinerFee wraps a transaction with its effective miner tip, the
+// part of the gas price paid to the block proposer on top of the base fee.
+type TxWithMinerFee struct {
+	tx       *Transaction
+	minerFee *big.Int
+}
+
+// NewTxWithMinerFee creates a wrapped transaction, calculating the effective
+// miner tip if a base fee is provided. Returns nil if the fee cap of the
+// transaction is below the base fee.
+func NewTxWithMinerFee(tx *Transaction, baseFee *big.Int) *TxWithMinerFee {
+	minerFee := tx.EffectiveGasTip(baseFee)
+	if minerFee.Sign() < 0 {
+		return nil
+	}
+	return &TxWithMinerFee{
+		tx:       tx,
+		minerFee: minerFee,
+	}
+}
+
 // TxByPriceAndTime implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
-type TxByPriceAndTime Transactions
+type TxByPriceAndTime []*TxWithMinerFee
 
 func (s TxByPriceAndTime) Len() int { return len(s) }
 func (s TxByPriceAndTime) Less(i, j int) bool {
 	// If the prices are equal, use the time the transaction was first seen for
 	// deterministic sorting
-	cmp := s[i].data.Price.Cmp(s[j].data.Price)
+	cmp := s[i].minerFee.Cmp(s[j].minerFee)
 	if cmp == 0 {
-		return s[i].time.Before(s[j].time)
+		return s[i].tx.time.Before(s[j].tx.time)
 	}
 	return cmp > 0
 }
 func (s TxByPriceAndTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 func (s *TxByPriceAndTime) Push(x interface{}) {
-	*s = append(*s, x.(*Transaction))
+	*s = append(*s, x.(*TxWithMinerFee))
 }
 
 func (s *TxByPriceAndTime) Pop() interface{} {
@@ -599,31 +856,37 @@ type TransactionsByPriceAndNonce struct {
 	heads     TxByPriceAndTime                // Next transaction for each unique account (price heap)
 	signer    Signer                          // Signer for the set of transactions
 	ethSigner Signer                          // Signer for the set of transactions
+	baseFee   *big.Int                        // Current base fee
 }
 
 // NewTransactionsByPriceAndNonce creates a transaction set that can retrieve
-// price sorted transactions in a nonce-honouring way.
+// price sorted transactions in a nonce-honouring way. Transactions are ordered
+// by their effective tip under the given base fee, which may be nil before
+// EIP-1559 is active.
 //
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
-func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[common.Address]Transactions) *TransactionsByPriceAndNonce {
+func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[common.Address]Transactions, baseFee *big.Int) *TransactionsByPriceAndNonce {
 	// Initialize a price based heap with the head transactions
 	heads := make(TxByPriceAndTime, 0, len(txs))
 	for from, accTxs := range txs {
 		if accTxs.Len() == 0 {
 			continue
 		}
-		heads = append(heads, accTxs[0])
 		// Ensure the sender address is from the signer
 		signer := hmySigner
 		if accTxs[0].IsEthCompatible() {
 			signer = ethSigner
 		}
 		acc, _ := Sender(signer, accTxs[0])
-		txs[acc] = accTxs[1:]
-		if from != acc {
+		wrapped := NewTxWithMinerFee(accTxs[0], baseFee)
+		// Remove transaction if sender doesn't match from, or if wrapping fails.
+		if acc != from || wrapped == nil {
 			delete(txs, from)
+			continue
 		}
+		heads = append(heads, wrapped)
+		txs[from] = accTxs[1:]
 	}
 	heap.Init(&heads)
 
@@ -633,6 +896,7 @@ func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[
 		heads:     heads,
 		signer:    hmySigner,
 		ethSigner: ethSigner,
+		baseFee:   baseFee,
 	}
 }
 
@@ -641,7 +905,7 @@ func (t *TransactionsByPriceAndNonce) Peek() *Transaction {
 	if len(t.heads) == 0 {
 		return nil
 	}
-	return t.heads[0]
+	return t.heads[0].tx
 }
 
 // Shift replaces the current best head with the next one from the same account.
@@ -650,16 +914,18 @@ func (t *TransactionsByPriceAndNonce) Shift() {
 		return
 	}
 	signer := t.signer
-	if t.heads[0].IsEthCompatible() {
+	if t.heads[0].tx.IsEthCompatible() {
 		signer = t.ethSigner
 	}
-	acc, _ := Sender(signer, t.heads[0])
+	acc, _ := Sender(signer, t.heads[0].tx)
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		t.heads[0], t.txs[acc] = txs[0], txs[1:]
-		heap.Fix(&t.heads, 0)
-	} else {
-		heap.Pop(&t.heads)
+		if wrapped := NewTxWithMinerFee(txs[0], t.baseFee); wrapped != nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
 	}
+	heap.Pop(&t.heads)
 }
 
 // Pop removes the best transaction, *not* replacing it with the next one from
@@ -678,7 +944,10 @@ type Message struct {
 	amount     *big.Int
 	gasLimit   uint64
 	gasPrice   *big.Int
+	gasFeeCap  *big.Int
+	gasTipCap  *big.Int
 	data       []byte
+	accessList AccessList
 	checkNonce bool
 	blockNum   *big.Int
 	txType     TransactionType
@@ -727,6 +996,29 @@ func (m Message) GasPrice() *big.Int {
 	return m.gasPrice
 }
 
+// GasFeeCap returns the EIP-1559 max fee per gas of the Message, which
+// is the gas price unless it was built from a dynamic fee transaction.
+func (m Message) GasFeeCap() *big.Int {
+	if m.gasFeeCap != nil {
+		return m.gasFeeCap
+	}
+	return m.gasPrice
+}
+
+// GasTipCap returns the EIP-1559 priority fee per gas of the Message, which
+// is the gas price unless it was built from a dynamic fee transaction.
+func (m Message) GasTipCap() *big.Int {
+	if m.gasTipCap != nil {
+		return m.gasTipCap
+	}
+	return m.gasPrice
+}
+
+// SetGasPrice sets the gas price actually paid by the Message.
+func (m *Message) SetGasPrice(gasPrice *big.Int) {
+	m.gasPrice = gasPrice
+}
+
 // Value returns the value amount from Message.
 func (m Message) Value() *big.Int {
 	return m.amount
@@ -752,6 +1044,16 @@ func (m Message) CheckNonce() bool {
 	return m.checkNonce
 }
 
+// AccessList returns the EIP-2930 access list of the Message.
+func (m Message) AccessList() AccessList {
+	return m.accessList
+}
+
+// SetAccessList sets the EIP-2930 access list of the Message.
+func (m *Message) SetAccessList(accessList AccessList) {
+	m.accessList = accessList
+}
+
 // Type returns the type of message
 func (m Message) Type() TransactionType {
 	return m.txType
@@ -114,6 +114,23 @@ type Signer interface {
 	Equal(Signer) bool
 }
 
+// typedTransaction is implemented by transactions which may carry an
+// EIP-2718 type, an EIP-2930 access list and EIP-1559 fees.
+type typedTransaction interface {
+	Type() uint8
+	AccessList() AccessList
+	GasTipCap() *big.Int
+	GasFeeCap() *big.Int
+}
+
+// txType returns the EIP-2718 type of the transaction.
+func txType(tx InternalTransaction) uint8 {
+	if typed, ok := tx.(typedTransaction); ok {
+		return typed.Type()
+	}
+	return LegacyTxType
+}
+
 // EIP155Signer implements Signer using the EIP155 rules.
 type EIP155Signer struct {
 	chainID, chainIDMul *big.Int
@@ -148,6 +165,15 @@ func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
 	if tx.ChainID().Cmp(ethChainID) != 0 && tx.ChainID().Cmp(s.chainID) != 0 {
 		return common.Address{}, ErrInvalidChainID
 	}
+	switch txType(tx) {
+	case LegacyTxType:
+	case AccessListTxType, DynamicFeeTxType:
+		// V of typed transactions is the y parity of the signature
+		V := new(big.Int).Add(tx.V(), big.NewInt(27))
+		return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
+	default:
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	V := new(big.Int).Sub(tx.V(), s.chainIDMul)
 	V.Sub(V, big8)
 	return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
@@ -160,6 +186,13 @@ func (s EIP155Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S,
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	switch txType(tx) {
+	case LegacyTxType:
+	case AccessListTxType, DynamicFeeTxType:
+		return R, S, big.NewInt(int64(sig[64])), nil
+	default:
+		return nil, nil, nil, ErrTxTypeNotSupported
+	}
 	if s.chainID.Sign() != 0 {
 		V = big.NewInt(int64(sig[64] + 35))
 		V.Add(V, s.chainIDMul)
@@ -170,6 +203,9 @@ func (s EIP155Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S,
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s EIP155Signer) Hash(tx InternalTransaction) common.Hash {
+	if txType(tx) != LegacyTxType {
+		return s.typedHash(tx)
+	}
 	if params.IsEthCompatible(s.chainID) {
 		// following the same logic as in go-eth implementation
 		return hash.FromRLP([]interface{}{
@@ -195,6 +231,70 @@ func (s EIP155Signer) Hash(tx InternalTransaction) common.Hash {
 	})
 }
 
+// typedHash returns the signing hash of a typed transaction, which
+// commits to the chain ID and the access list.
+func (s EIP155Signer) typedHash(tx InternalTransaction) common.Hash {
+	typed := tx.(typedTransaction)
+	if typed.Type() == DynamicFeeTxType {
+		return s.dynamicFeeHash(tx, typed)
+	}
+	if params.IsEthCompatible(s.chainID) {
+		return prefixedRlpHash(typed.Type(), []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			typed.AccessList(),
+		})
+	}
+	return prefixedRlpHash(typed.Type(), []interface{}{
+		s.chainID,
+		tx.Nonce(),
+		tx.GasPrice(),
+		tx.GasLimit(),
+		tx.ShardID(),
+		tx.ToShardID(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		typed.AccessList(),
+	})
+}
+
+// dynamicFeeHash returns the signing hash of an EIP-1559 transaction, which
+// commits to both fee caps instead of the gas price.
+func (s EIP155Signer) dynamicFeeHash(tx InternalTransaction, typed typedTransaction) common.Hash {
+	if params.IsEthCompatible(s.chainID) {
+		return prefixedRlpHash(DynamicFeeTxType, []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			typed.GasTipCap(),
+			typed.GasFeeCap(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			typed.AccessList(),
+		})
+	}
+	return prefixedRlpHash(DynamicFeeTxType, []interface{}{
+		s.chainID,
+		tx.Nonce(),
+		typed.GasTipCap(),
+		typed.GasFeeCap(),
+		tx.GasLimit(),
+		tx.ShardID(),
+		tx.ToShardID(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		typed.AccessList(),
+	})
+}
+
 // HomesteadSigner implements InternalTransaction using the
 // homestead rules.
 type HomesteadSigner struct{ FrontierSigner }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 func TestEIP155Signing(t *testing.T) {
@@ -96,3 +97,125 @@ func TestChainID(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestAccessListSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	accessList := AccessList{{Address: addr, StorageKeys: []common.Hash{{0x01}}}}
+
+	signer := NewEIP155Signer(big.NewInt(18))
+	tx, err := SignTx(NewAccessListTransaction(big.NewInt(18), 0, &addr, 0, 0, new(big.Int), 0, new(big.Int), nil, accessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc[0] != AccessListTxType {
+		t.Fatalf("expected typed envelope, got type %d", enc[0])
+	}
+	decoded := new(Transaction)
+	if err := decoded.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != tx.Hash() {
+		t.Errorf("hash mismatch after decoding: got %x want %x", decoded.Hash(), tx.Hash())
+	}
+	if decoded.AccessList().StorageKeys() != 1 {
+		t.Errorf("expected access list to be decoded, got %v", decoded.AccessList())
+	}
+
+	from, err := Sender(signer, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+}
+
+func TestDynamicFeeSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	signer := NewEIP155Signer(big.NewInt(18))
+	tx, err := SignTx(NewDynamicFeeTransaction(big.NewInt(18), 0, &addr, 0, 0, new(big.Int), 21000, big.NewInt(2), big.NewInt(10), nil, nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc[0] != DynamicFeeTxType {
+		t.Fatalf("expected typed envelope, got type %d", enc[0])
+	}
+	decoded := new(Transaction)
+	if err := decoded.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != tx.Hash() {
+		t.Errorf("hash mismatch after decoding: got %x want %x", decoded.Hash(), tx.Hash())
+	}
+	if decoded.GasTipCap().Cmp(big.NewInt(2)) != 0 || decoded.GasFeeCap().Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("fee caps not decoded, got tip %v cap %v", decoded.GasTipCap(), decoded.GasFeeCap())
+	}
+	if price := decoded.EffectiveGasPrice(big.NewInt(5)); price.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("effective gas price mismatch: got %v want 7", price)
+	}
+	if price := decoded.EffectiveGasPrice(big.NewInt(9)); price.Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("effective gas price not capped: got %v want 10", price)
+	}
+
+	from, err := Sender(signer, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+}
+
+func TestEthAccessListSigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	accessList := AccessList{{Address: addr, StorageKeys: []common.Hash{{0x01}}}}
+
+	signer := NewEIP155Signer(big.NewInt(18))
+	tx, err := SignEthTx(NewEthAccessListTransaction(big.NewInt(18), 0, &addr, new(big.Int), 0, new(big.Int), nil, accessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(EthTransaction)
+	if err := rlp.DecodeBytes(enc, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != tx.Hash() {
+		t.Errorf("hash mismatch after decoding: got %x want %x", decoded.Hash(), tx.Hash())
+	}
+
+	from, err := Sender(signer, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+	if decoded.ConvertToHmy().Type() != AccessListTxType {
+		t.Error("expected type to survive the conversion")
+	}
+}
+
+func TestUnsupportedTxType(t *testing.T) {
+	if err := new(Transaction).UnmarshalBinary([]byte{0x7f, 0xc0}); err != ErrTxTypeNotSupported {
+		t.Errorf("expected %v, got %v", ErrTxTypeNotSupported, err)
+	}
+}
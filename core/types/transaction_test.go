@@ -55,7 +55,7 @@ func TestTransactionPriceNonceSort(t *testing.T) {
 		}
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := NewTransactionsByPriceAndNonce(signer, signer, groups)
+	txset := NewTransactionsByPriceAndNonce(signer, signer, groups, nil)
 
 	txs := InternalTransactions{}
 	for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
@@ -136,6 +136,42 @@ func TestTransactionJSON(t *testing.T) {
 	}
 }
 
+// Tests that a typed transaction without chain id is rejected by the JSON decoding,
+// and has a zero chain id when made otherwise.
+func TestTypedTransactionMissingChainID(t *testing.T) {
+	tx := NewAccessListTransaction(common.Big1, 0, &common.Address{1}, 0, 0, common.Big0, 21000, common.Big2, nil, nil)
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	delete(fields, "chainId")
+	if data, err = json.Marshal(fields); err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var parsedTx Transaction
+	if err := json.Unmarshal(data, &parsedTx); err == nil {
+		t.Errorf("expected error decoding transaction without chain id")
+	}
+	var parsedEthTx EthTransaction
+	if err := json.Unmarshal(data, &parsedEthTx); err == nil {
+		t.Errorf("expected error decoding eth transaction without chain id")
+	}
+
+	tx.data.ChainID = nil
+	if tx.ChainID().Sign() != 0 {
+		t.Errorf("expected zero chain id, got %v", tx.ChainID())
+	}
+	ethTx := &EthTransaction{data: ethTxdata{Type: AccessListTxType}}
+	if ethTx.ChainID().Sign() != 0 {
+		t.Errorf("expected zero chain id, got %v", ethTx.ChainID())
+	}
+}
+
 // Tests that if multiple transactions have the same price, the ones seen earlier
 // are prioritized to avoid network spam attacks aiming for a specific ordering.
 func TestTransactionTimeSort(t *testing.T) {
@@ -162,6 +198,7 @@ func TestTransactionTimeSort(t *testing.T) {
 		NewEIP155Signer(config.ChainID),
 		NewEIP155Signer(config.EthCompatibleChainID),
 		groups,
+		nil,
 	)
 
 	txs := Transactions{}
@@ -0,0 +1,403 @@
+// Package verify checks the integrity of the recent blocks of a chain database:
+// the presence of their headers, bodies and receipts against the roots of the
+// headers, and every trie node and contract code of the newest state available.
+// The missing or corrupt trie nodes and codes can be fetched again from peers.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+	staking "github.com/harmony-one/harmony/staking/types"
+	"github.com/pkg/errors"
+)
+
+// Kinds of the problems found by a verification.
+const (
+	KindHeader       = "header"
+	KindBody         = "body"
+	KindReceipts     = "receipts"
+	KindState        = "state"
+	KindStorage      = "storage"
+	KindCode         = "code"
+	KindStateMissing = "stateMissing"
+)
+
+const (
+	logInterval = 8 * time.Second
+	// maxRepairRounds is the number of times the nodes found missing under the
+	// repaired ones are fetched in turn.
+	maxRepairRounds = 16
+)
+
+var emptyCodeHash = crypto.Keccak256Hash(nil)
+
+// NodeFetcher fetches trie nodes and contract codes by hash from peers. The data
+// is returned in the order of the hashes, with a nil entry for the ones which
+// could not be fetched.
+type NodeFetcher interface {
+	FetchNodes(ctx context.Context, hashes []common.Hash) ([][]byte, error)
+}
+
+// Problem is a missing or corrupt item of the database.
+type Problem struct {
+	Number   uint64      // number of the block of the item
+	Kind     string      // kind of the item
+	Hash     common.Hash // hash of the trie node or code, or of the block
+	Corrupt  bool        // whether the item is present but invalid
+	Repaired bool        // whether the item was fetched from peers
+	Detail   string
+}
+
+// Result is the report of a verification.
+type Result struct {
+	From       uint64      // first verified block
+	To         uint64      // last verified block
+	StateBlock uint64      // block whose state was walked
+	StateRoot  common.Hash // root of the walked state
+	Nodes      uint64      // number of trie nodes and codes walked
+	Problems   []*Problem
+	Elapsed    time.Duration
+}
+
+// Healthy reports whether the verification found no problem left unrepaired.
+func (r *Result) Healthy() bool {
+	for _, p := range r.Problems {
+		if !p.Repaired {
+			return false
+		}
+	}
+	return true
+}
+
+// Verifier verifies a chain database.
+type Verifier struct {
+	db      ethdb.Database
+	triedb  *trie.Database
+	fetcher NodeFetcher
+}
+
+// New creates a verifier of the given database, reading the tries through the
+// given trie database. Missing trie nodes and codes are fetched with the fetcher
+// on repair, which may be nil if repairing is not needed.
+func New(db ethdb.Database, triedb *trie.Database, fetcher NodeFetcher) *Verifier {
+	return &Verifier{db: db, triedb: triedb, fetcher: fetcher}
+}
+
+// Verify verifies the given number of canonical blocks up to head, and the state
+// of the newest of them whose state is available. If repair is set, the missing
+// or corrupt trie nodes and codes are fetched from peers.
+func (v *Verifier) Verify(ctx context.Context, head, blocks uint64, repair bool) (*Result, error) {
+	if repair && v.fetcher == nil {
+		return nil, errors.New("repairing requires peers to fetch the nodes from")
+	}
+	start := time.Now()
+	res := &Result{To: head}
+	if blocks == 0 || blocks > head+1 {
+		blocks = head + 1
+	}
+	res.From = head + 1 - blocks
+
+	var stateNumber *uint64
+	for number := head + 1; number > res.From; number-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		root, ok := v.verifyBlock(res, number-1)
+		if ok && stateNumber == nil {
+			if _, err := v.triedb.Node(root); err == nil {
+				n := number - 1
+				stateNumber = &n
+				res.StateBlock, res.StateRoot = n, root
+			}
+		}
+	}
+	if stateNumber == nil {
+		hash := rawdb.ReadCanonicalHash(v.db, head)
+		res.Problems = append(res.Problems, &Problem{
+			Number: head, Kind: KindStateMissing, Hash: hash,
+			Detail: fmt.Sprintf("no state of blocks %d to %d", res.From, head),
+		})
+		res.Elapsed = time.Since(start)
+		return res, nil
+	}
+
+	w := newWalker(ctx, v.triedb)
+	if err := w.walkTrie(res.StateRoot, true); err != nil {
+		return nil, err
+	}
+	if repair {
+		if err := v.repair(ctx, w); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range w.problems {
+		m.Number = res.StateBlock
+		res.Problems = append(res.Problems, m)
+	}
+	res.Nodes = w.nodes
+	res.Elapsed = time.Since(start)
+	return res, nil
+}
+
+// verifyBlock verifies the header, body and receipts of the canonical block of
+// the given number, and returns its state root.
+func (v *Verifier) verifyBlock(res *Result, number uint64) (common.Hash, bool) {
+	hash := rawdb.ReadCanonicalHash(v.db, number)
+	if hash == (common.Hash{}) {
+		res.Problems = append(res.Problems, &Problem{Number: number, Kind: KindHeader, Detail: "canonical hash missing"})
+		return common.Hash{}, false
+	}
+	header := rawdb.ReadHeader(v.db, hash, number)
+	if header == nil {
+		res.Problems = append(res.Problems, &Problem{Number: number, Kind: KindHeader, Hash: hash, Detail: "header missing"})
+		return common.Hash{}, false
+	}
+	if body := rawdb.ReadBody(v.db, hash, number); body == nil {
+		res.Problems = append(res.Problems, &Problem{Number: number, Kind: KindBody, Hash: hash, Detail: "body missing"})
+	} else if txRoot := types.DeriveSha(
+		types.Transactions(body.Transactions()),
+		staking.StakingTransactions(body.StakingTransactions()),
+	); txRoot != header.TxHash() {
+		res.Problems = append(res.Problems, &Problem{
+			Number: number, Kind: KindBody, Hash: hash, Corrupt: true,
+			Detail: fmt.Sprintf("transaction root %x, expect %x", txRoot, header.TxHash()),
+		})
+	}
+	receipts := rawdb.ReadReceipts(v.db, hash, number)
+	if receipts == nil && header.ReceiptHash() != types.EmptyRootHash {
+		res.Problems = append(res.Problems, &Problem{Number: number, Kind: KindReceipts, Hash: hash, Detail: "receipts missing"})
+	} else if receiptRoot := types.DeriveSha(receipts); receiptRoot != header.ReceiptHash() {
+		res.Problems = append(res.Problems, &Problem{
+			Number: number, Kind: KindReceipts, Hash: hash, Corrupt: true,
+			Detail: fmt.Sprintf("receipt root %x, expect %x", receiptRoot, header.ReceiptHash()),
+		})
+	}
+	return header.Root(), true
+}
+
+// repair fetches the missing and corrupt nodes found by the walker, and walks
+// the subtries of the fetched nodes in turn, until nothing more is missing.
+func (v *Verifier) repair(ctx context.Context, w *walker) error {
+	pending := w.problems
+	for round := 0; round < maxRepairRounds && len(pending) > 0; round++ {
+		hashes := make([]common.Hash, 0, len(pending))
+		for _, p := range pending {
+			hashes = append(hashes, p.Hash)
+		}
+		data, err := v.fetcher.FetchNodes(ctx, hashes)
+		if err != nil {
+			return err
+		}
+		batch := v.db.NewBatch()
+		var repaired []*Problem
+		for i, blob := range data {
+			if len(blob) == 0 || crypto.Keccak256Hash(blob) != hashes[i] {
+				continue
+			}
+			if err := batch.Put(hashes[i].Bytes(), blob); err != nil {
+				return err
+			}
+			pending[i].Repaired = true
+			repaired = append(repaired, pending[i])
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		utils.Logger().Info().
+			Int("requested", len(hashes)).
+			Int("repaired", len(repaired)).
+			Msg("[Verify] fetched missing trie nodes")
+		if len(repaired) == 0 {
+			return nil
+		}
+		// The subtries of the repaired nodes were never walked
+		found := len(w.problems)
+		for _, p := range repaired {
+			if p.Kind == KindCode {
+				continue
+			}
+			if err := w.walkTrie(p.Hash, p.Kind == KindState); err != nil {
+				return err
+			}
+		}
+		pending = w.problems[found:]
+	}
+	return nil
+}
+
+// account is the trie representation of an account, as in state.Account.
+type account struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+// walker walks the trie nodes of a state, recording the missing or corrupt ones.
+type walker struct {
+	ctx      context.Context
+	triedb   *trie.Database
+	seen     map[common.Hash]struct{} // storage roots and codes already walked
+	nodes    uint64
+	problems []*Problem
+	start    time.Time
+	logged   time.Time
+}
+
+func newWalker(ctx context.Context, triedb *trie.Database) *walker {
+	return &walker{
+		ctx:    ctx,
+		triedb: triedb,
+		seen:   make(map[common.Hash]struct{}),
+		start:  time.Now(),
+		logged: time.Now(),
+	}
+}
+
+// kind returns the problem kind of a node of an account or storage trie.
+func kind(accountTrie bool) string {
+	if accountTrie {
+		return KindState
+	}
+	return KindStorage
+}
+
+// resolve returns the blob of the given hash, or records it as a problem.
+func (w *walker) resolve(hash common.Hash, kind string) []byte {
+	w.nodes++
+	blob, err := w.triedb.Node(hash)
+	if err != nil || len(blob) == 0 {
+		w.problems = append(w.problems, &Problem{Kind: kind, Hash: hash, Detail: "missing"})
+		return nil
+	}
+	if crypto.Keccak256Hash(blob) != hash {
+		w.problems = append(w.problems, &Problem{Kind: kind, Hash: hash, Corrupt: true, Detail: "hash mismatch"})
+		return nil
+	}
+	return blob
+}
+
+// walkTrie walks the trie node of the given hash and all the nodes below it.
+func (w *walker) walkTrie(hash common.Hash, accountTrie bool) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	if time.Since(w.logged) > logInterval {
+		utils.Logger().Info().
+			Uint64("nodes", w.nodes).
+			Int("problems", len(w.problems)).
+			Dur("elapsed", time.Since(w.start)).
+			Msg("[Verify] walking state")
+		w.logged = time.Now()
+	}
+	blob := w.resolve(hash, kind(accountTrie))
+	if blob == nil {
+		return nil
+	}
+	if err := w.walkNode(blob, accountTrie); err != nil {
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			return err
+		}
+		w.problems = append(w.problems, &Problem{
+			Kind: kind(accountTrie), Hash: hash, Corrupt: true, Detail: err.Error(),
+		})
+	}
+	return nil
+}
+
+// walkNode walks the children of the given encoded trie node. A node is either a
+// short node of a compact encoded key and a value or child, or a full node of 16
+// children and a value. Children are either hashes or embedded nodes.
+func (w *walker) walkNode(blob []byte, accountTrie bool) error {
+	elems, _, err := rlp.SplitList(blob)
+	if err != nil {
+		return err
+	}
+	switch count, _ := rlp.CountValues(elems); count {
+	case 2:
+		key, rest, err := rlp.SplitString(elems)
+		if err != nil {
+			return err
+		}
+		if len(key) > 0 && key[0]>>4 >= 2 {
+			// Leaf node, whose value is an account in the account trie
+			if !accountTrie {
+				return nil
+			}
+			value, _, err := rlp.SplitString(rest)
+			if err != nil {
+				return err
+			}
+			return w.walkAccount(value)
+		}
+		return w.walkChild(rest, accountTrie)
+	case 17:
+		for i := 0; i < 16; i++ {
+			_, _, rest, err := rlp.Split(elems)
+			if err != nil {
+				return err
+			}
+			if err := w.walkChild(elems[:len(elems)-len(rest)], accountTrie); err != nil {
+				return err
+			}
+			elems = rest
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid trie node of %d elements", count)
+	}
+}
+
+// walkChild walks the given encoded child reference of a node.
+func (w *walker) walkChild(ref []byte, accountTrie bool) error {
+	kind, content, _, err := rlp.Split(ref)
+	if err != nil {
+		return err
+	}
+	switch {
+	case kind == rlp.List:
+		return w.walkNode(ref, accountTrie)
+	case len(content) == 0:
+		return nil
+	case len(content) == common.HashLength:
+		return w.walkTrie(common.BytesToHash(content), accountTrie)
+	default:
+		return fmt.Errorf("invalid trie node reference of %d bytes", len(content))
+	}
+}
+
+// walkAccount walks the storage trie and the code of the given account.
+func (w *walker) walkAccount(value []byte) error {
+	var acc account
+	if err := rlp.DecodeBytes(value, &acc); err != nil {
+		return err
+	}
+	if acc.Root != types.EmptyRootHash {
+		if _, ok := w.seen[acc.Root]; !ok {
+			w.seen[acc.Root] = struct{}{}
+			if err := w.walkTrie(acc.Root, false); err != nil {
+				return err
+			}
+		}
+	}
+	codeHash := common.BytesToHash(acc.CodeHash)
+	if !bytes.Equal(acc.CodeHash, emptyCodeHash[:]) {
+		if _, ok := w.seen[codeHash]; !ok {
+			w.seen[codeHash] = struct{}{}
+			w.resolve(codeHash, KindCode)
+		}
+	}
+	return nil
+}
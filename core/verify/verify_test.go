@@ -0,0 +1,113 @@
+package verify
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// dbFetcher fetches the nodes from another database.
+type dbFetcher struct {
+	db ethdb.Database
+}
+
+func (f *dbFetcher) FetchNodes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	data := make([][]byte, 0, len(hashes))
+	for _, hash := range hashes {
+		blob, _ := f.db.Get(hash.Bytes())
+		data = append(data, blob)
+	}
+	return data, nil
+}
+
+func TestVerify(t *testing.T) {
+	db := ethRawDB.NewMemoryDatabase()
+	sdb := state.NewDatabase(db)
+	statedb, _ := state.New(common.Hash{}, sdb)
+	contract := common.BytesToAddress([]byte{0x10})
+	for i := byte(1); i <= 50; i++ {
+		statedb.AddBalance(common.BytesToAddress([]byte{i}), big.NewInt(int64(i)))
+		statedb.SetState(contract, common.BytesToHash([]byte{i}), common.BytesToHash([]byte{i}))
+	}
+	code := []byte{0x60, 0x00}
+	statedb.SetCode(contract, code)
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	for number := uint64(0); number < 3; number++ {
+		header := blockfactory.NewTestHeader().With().
+			Number(new(big.Int).SetUint64(number)).
+			Root(root).
+			TxHash(types.EmptyRootHash).
+			ReceiptHash(types.EmptyRootHash).
+			Header()
+		rawdb.WriteHeader(db, header)
+		rawdb.WriteBody(db, header.Hash(), number, types.NewTestBody())
+		rawdb.WriteCanonicalHash(db, header.Hash(), number)
+	}
+	backup := ethRawDB.NewMemoryDatabase()
+	it := db.NewIterator()
+	for it.Next() {
+		backup.Put(common.CopyBytes(it.Key()), common.CopyBytes(it.Value()))
+	}
+	it.Release()
+
+	// Lose a body, the storage root and the code of the contract
+	hash1 := rawdb.ReadCanonicalHash(db, 1)
+	rawdb.DeleteBody(db, hash1, 1)
+	accTrie, _ := trie.NewSecure(root, trie.NewDatabase(db))
+	blob, _ := accTrie.TryGet(contract.Bytes())
+	var acc account
+	if err := rlp.DecodeBytes(blob, &acc); err != nil {
+		t.Fatal(err)
+	}
+	db.Delete(acc.Root.Bytes())
+	db.Delete(crypto.Keccak256(code))
+
+	res, err := New(db, trie.NewDatabase(db), nil).Verify(context.Background(), 2, 128, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.From != 0 || res.To != 2 || res.StateBlock != 2 || res.StateRoot != root {
+		t.Errorf("unexpected result %+v", res)
+	}
+	kinds := make(map[string]int)
+	for _, p := range res.Problems {
+		kinds[p.Kind]++
+	}
+	if len(res.Problems) != 3 || kinds[KindBody] != 1 || kinds[KindStorage] != 1 || kinds[KindCode] != 1 {
+		t.Fatalf("unexpected problems %v", kinds)
+	}
+
+	res, err = New(db, trie.NewDatabase(db), &dbFetcher{backup}).Verify(context.Background(), 2, 128, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range res.Problems {
+		if p.Repaired == (p.Kind == KindBody) {
+			t.Errorf("unexpected repair of problem %+v", p)
+		}
+	}
+	res, err = New(db, trie.NewDatabase(db), nil).Verify(context.Background(), 2, 128, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Problems) != 1 || res.Problems[0].Kind != KindBody || res.Problems[0].Number != 1 {
+		t.Errorf("unexpected problems after repair %+v", res.Problems)
+	}
+}
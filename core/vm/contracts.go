@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/crypto/blake2b"
 
@@ -29,6 +30,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/bn256"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/internal/params"
 	"golang.org/x/crypto/ripemd160"
 
@@ -137,20 +140,115 @@ var PrecompiledContractsStaking = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{255}): &vrf{},
 }
 
+// PrecompiledContractsBLS contains the BLS12-381 pre-compiled contracts,
+// which are added to the staking set after the BLSPrecompileEpoch
+var PrecompiledContractsBLS = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{10}): &bls12381G1Add{},
+	common.BytesToAddress([]byte{11}): &bls12381G1Mul{},
+	common.BytesToAddress([]byte{13}): &bls12381G2Add{},
+	common.BytesToAddress([]byte{14}): &bls12381G2Mul{},
+	common.BytesToAddress([]byte{16}): &bls12381Pairing{},
+
+	common.BytesToAddress([]byte{250}): &blsVerify{},
+}
+
+// PrecompiledContractsShardInfo contains the shard info pre-compiled contract,
+// which is added to the staking set after the ShardInfoPrecompileEpoch
+var PrecompiledContractsShardInfo = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{249}): &shardInfo{},
+}
+
 func init() {
 	// check that there is no overlap, and panic if there is
-	readOnlyContracts := PrecompiledContractsStaking
 	writeCapableContracts := WriteCapablePrecompiledContractsStaking
-	for address, readOnlyContract := range readOnlyContracts {
-		if readOnlyContract != nil && writeCapableContracts[address] != nil {
-			panic(fmt.Errorf("Address %v is included in both readOnlyContracts and writeCapableContracts", address))
+	for _, readOnlyContracts := range []map[common.Address]PrecompiledContract{
+		PrecompiledContractsStaking, PrecompiledContractsBLS, PrecompiledContractsShardInfo,
+	} {
+		for address, readOnlyContract := range readOnlyContracts {
+			if readOnlyContract != nil && writeCapableContracts[address] != nil {
+				panic(fmt.Errorf("Address %v is included in both readOnlyContracts and writeCapableContracts", address))
+			}
+		}
+		for address, writeCapableContract := range writeCapableContracts {
+			if writeCapableContract != nil && readOnlyContracts[address] != nil {
+				panic(fmt.Errorf("Address %v is included in both readOnlyContracts and writeCapableContracts", address))
+			}
+		}
+	}
+	// the sets added after the staking set must not replace any of its contracts
+	for _, additions := range []map[common.Address]PrecompiledContract{
+		PrecompiledContractsBLS, PrecompiledContractsShardInfo,
+	} {
+		for address := range additions {
+			if _, ok := PrecompiledContractsStaking[address]; ok {
+				panic(fmt.Errorf("Address %v is included in both the staking set and an added set", address))
+			}
+		}
+	}
+}
+
+// precompilesFor returns the read-only and write capable precompiles enabled
+// with the given rules. The precompiles enabled after the staking set are added
+// to it one epoch at a time.
+func precompilesFor(rules params.Rules) (map[common.Address]PrecompiledContract, map[common.Address]WriteCapablePrecompiledContract) {
+	precompiles := PrecompiledContractsHomestead
+	// assign empty write capable precompiles till they are available in the fork
+	writeCapablePrecompiles := make(map[common.Address]WriteCapablePrecompiledContract)
+	if rules.IsS3 {
+		precompiles = PrecompiledContractsByzantium
+	}
+	if rules.IsIstanbul {
+		precompiles = PrecompiledContractsIstanbul
+	}
+	if rules.IsVRF {
+		precompiles = PrecompiledContractsVRF
+	}
+	if rules.IsSHA3 {
+		precompiles = PrecompiledContractsSHA3FIPS
+	}
+	if rules.IsStakingPrecompile {
+		precompiles = PrecompiledContractsStaking
+		writeCapablePrecompiles = WriteCapablePrecompiledContractsStaking
+	}
+
+	var additions []map[common.Address]PrecompiledContract
+	if rules.IsBLSPrecompile {
+		additions = append(additions, PrecompiledContractsBLS)
+	}
+	if rules.IsShardInfoPrecompile {
+		additions = append(additions, PrecompiledContractsShardInfo)
+	}
+	if len(additions) > 0 {
+		base := precompiles
+		precompiles = make(map[common.Address]PrecompiledContract, len(base))
+		for address, contract := range base {
+			precompiles[address] = contract
+		}
+		for _, added := range additions {
+			for address, contract := range added {
+				precompiles[address] = contract
+			}
+		}
+	}
+	return precompiles, writeCapablePrecompiles
+}
+
+// ActivePrecompiles returns the addresses of the read-only and write capable
+// precompiles enabled with the given rules, they are warm under EIP-2929.
+func ActivePrecompiles(rules params.Rules) []common.Address {
+	precompiles, writeCapablePrecompiles := precompilesFor(rules)
+	addresses := make([]common.Address, 0, len(precompiles)+len(writeCapablePrecompiles))
+	for address, contract := range precompiles {
+		if contract != nil {
+			addresses = append(addresses, address)
 		}
 	}
-	for address, writeCapableContract := range writeCapableContracts {
-		if writeCapableContract != nil && readOnlyContracts[address] != nil {
-			panic(fmt.Errorf("Address %v is included in both readOnlyContracts and writeCapableContracts", address))
+	for address, contract := range writeCapablePrecompiles {
+		if contract != nil {
+			addresses = append(addresses, address)
 		}
 	}
+	return addresses
 }
 
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
@@ -596,6 +694,24 @@ func (c *epoch) Run(input []byte) ([]byte, error) {
 	return common.LeftPadBytes(input, 32), nil
 }
 
+// shardInfo returns the shard ID and epoch of the current block, along with the
+// last crosslink of the shard requested in the input, implemented as a native contract
+type shardInfo struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+//
+// This method does not require any overflow checking as the input size gas costs
+// required for anything significant is so high it's impossible to pay for.
+func (c *shardInfo) RequiredGas(input []byte) uint64 {
+	return GasQuickStep
+}
+
+func (c *shardInfo) Run(input []byte) ([]byte, error) {
+	// Note the input was overwritten with the shard info of the current block
+	// So just return
+	return input, nil
+}
+
 // VRF implemented as a native contract.
 type vrf struct{}
 
@@ -668,3 +784,315 @@ func (c *ecrecoverPublicKey) Run(input []byte) ([]byte, error) {
 
 	return pubKey, nil
 }
+
+// blsVerifyInputLength is the length of the (hash, public key, signature) input
+const blsVerifyInputLength = 32 + bls.PublicKeySizeInBytes + bls.BLSSignatureSizeInBytes
+
+var errBLSVerifyInvalidInputLength = errors.New("invalid input length")
+
+// blsVerify implements BLS12-381 signature verification as a native contract,
+// using the same curve and hashing as the consensus signatures. Aggregated
+// signatures are verified against the aggregated public key of the signers.
+//
+// The points are in the compressed encoding of the node's BLS library, the
+// curve arithmetic precompiles below take the uncompressed points of EIP-2537.
+type blsVerify struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *blsVerify) RequiredGas(input []byte) uint64 {
+	return params.BLSVerifyGas
+}
+
+// Run verifies the signature of a 32 byte message hash. The input is
+// (hash, serialized public key, serialized signature) and the output is
+// a 32 byte word set to 1 if the signature is valid and 0 otherwise.
+func (c *blsVerify) Run(input []byte) ([]byte, error) {
+	if len(input) != blsVerifyInputLength {
+		return nil, errBLSVerifyInvalidInputLength
+	}
+	var (
+		hash   = input[:32]
+		rawKey = input[32 : 32+bls.PublicKeySizeInBytes]
+		rawSig = input[32+bls.PublicKeySizeInBytes:]
+	)
+	pubKey := &bls_core.PublicKey{}
+	if err := pubKey.Deserialize(rawKey); err != nil {
+		return common.LeftPadBytes(nil, 32), nil
+	}
+	sig := &bls_core.Sign{}
+	if err := sig.Deserialize(rawSig); err != nil {
+		return common.LeftPadBytes(nil, 32), nil
+	}
+	// the pairing check holds for the identity key and signature with any hash
+	if bls.PublicKeyPoint(pubKey).IsZero() || bls.SignaturePoint(sig).IsZero() {
+		return common.LeftPadBytes(nil, 32), nil
+	}
+	if !sig.VerifyHash(pubKey, hash) {
+		return common.LeftPadBytes(nil, 32), nil
+	}
+	return common.LeftPadBytes([]byte{1}, 32), nil
+}
+
+// Encoded lengths of the BLS12-381 field elements, points and scalars of EIP-2537
+const (
+	bls12381FieldElementLength = 64
+	bls12381G1PointLength      = 2 * bls12381FieldElementLength
+	bls12381G2PointLength      = 4 * bls12381FieldElementLength
+	bls12381ScalarLength       = 32
+	bls12381PairLength         = bls12381G1PointLength + bls12381G2PointLength
+)
+
+var (
+	// bls12381FieldModulus is the modulus p of the base field of BLS12-381
+	bls12381FieldModulus, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+	// bls12381GroupOrder is the order r of the G1 and G2 subgroups of BLS12-381
+	bls12381GroupOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+)
+
+var (
+	errBLS12381InvalidInputLength          = errors.New("invalid input length")
+	errBLS12381InvalidFieldElementTopBytes = errors.New("invalid field element top bytes")
+	errBLS12381InvalidFieldElement         = errors.New("invalid field element")
+	errBLS12381InvalidPoint                = errors.New("invalid point")
+	errBLS12381PointNotInSubgroup          = errors.New("point is not in the subgroup")
+)
+
+// decodeBLS12381Point decodes the field elements of an EIP-2537 point into the
+// hex string form of the BLS library, the point at infinity is all zeros.
+func decodeBLS12381Point(in []byte) (string, error) {
+	coords := make([]string, 0, len(in)/bls12381FieldElementLength)
+	infinity := true
+	for i := 0; i < len(in); i += bls12381FieldElementLength {
+		element := in[i : i+bls12381FieldElementLength]
+		for _, b := range element[:bls12381FieldElementLength-48] {
+			if b != 0 {
+				return "", errBLS12381InvalidFieldElementTopBytes
+			}
+		}
+		x := new(big.Int).SetBytes(element)
+		if x.Cmp(bls12381FieldModulus) >= 0 {
+			return "", errBLS12381InvalidFieldElement
+		}
+		infinity = infinity && x.Sign() == 0
+		coords = append(coords, x.Text(16))
+	}
+	if infinity {
+		return "0", nil
+	}
+	return "1 " + strings.Join(coords, " "), nil
+}
+
+// encodeBLS12381Point encodes a point in the hex string form of the BLS library
+// into the field elements of EIP-2537.
+func encodeBLS12381Point(str string, length int) []byte {
+	out := make([]byte, length)
+	coords := strings.Fields(str)
+	if len(coords) == 0 || coords[0] == "0" {
+		return out
+	}
+	for i, coord := range coords[1:] {
+		x, _ := new(big.Int).SetString(coord, 16)
+		x.FillBytes(out[i*bls12381FieldElementLength : (i+1)*bls12381FieldElementLength])
+	}
+	return out
+}
+
+func decodeBLS12381G1(in []byte) (*bls_core.G1, error) {
+	str, err := decodeBLS12381Point(in)
+	if err != nil {
+		return nil, err
+	}
+	p := &bls_core.G1{}
+	if err := p.SetString(str, 16); err != nil {
+		return nil, errBLS12381InvalidPoint
+	}
+	return p, nil
+}
+
+func decodeBLS12381G2(in []byte) (*bls_core.G2, error) {
+	str, err := decodeBLS12381Point(in)
+	if err != nil {
+		return nil, err
+	}
+	p := &bls_core.G2{}
+	if err := p.SetString(str, 16); err != nil {
+		return nil, errBLS12381InvalidPoint
+	}
+	return p, nil
+}
+
+// decodeBLS12381Scalar decodes a 32 byte big endian scalar, reduced by the group
+// order, which is the same multiplier for the points of the subgroups.
+func decodeBLS12381Scalar(in []byte) *bls_core.Fr {
+	k := new(big.Int).Mod(new(big.Int).SetBytes(in), bls12381GroupOrder)
+	s := &bls_core.Fr{}
+	// a reduced scalar is always accepted
+	_ = s.SetString(k.Text(16), 16)
+	return s
+}
+
+// bls12381OrderMinusOne returns r - 1, the largest scalar the BLS library takes
+func bls12381OrderMinusOne() *bls_core.Fr {
+	return decodeBLS12381Scalar(new(big.Int).Sub(bls12381GroupOrder, big1).Bytes())
+}
+
+// isInBLS12381G1Subgroup checks that r * p is the point at infinity.
+func isInBLS12381G1Subgroup(p *bls_core.G1) bool {
+	q := &bls_core.G1{}
+	bls_core.G1Mul(q, p, bls12381OrderMinusOne())
+	bls_core.G1Add(q, q, p)
+	return q.IsZero()
+}
+
+// isInBLS12381G2Subgroup checks that r * p is the point at infinity.
+func isInBLS12381G2Subgroup(p *bls_core.G2) bool {
+	q := &bls_core.G2{}
+	bls_core.G2Mul(q, p, bls12381OrderMinusOne())
+	bls_core.G2Add(q, q, p)
+	return q.IsZero()
+}
+
+// bls12381G1Add implements the EIP-2537 G1 point addition as a native contract.
+type bls12381G1Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G1AddGas
+}
+
+// Run adds two G1 points, the input is (point, point) and the output the sum.
+func (c *bls12381G1Add) Run(input []byte) ([]byte, error) {
+	if len(input) != 2*bls12381G1PointLength {
+		return nil, errBLS12381InvalidInputLength
+	}
+	p0, err := decodeBLS12381G1(input[:bls12381G1PointLength])
+	if err != nil {
+		return nil, err
+	}
+	p1, err := decodeBLS12381G1(input[bls12381G1PointLength:])
+	if err != nil {
+		return nil, err
+	}
+	bls_core.G1Add(p0, p0, p1)
+	return encodeBLS12381Point(p0.GetString(16), bls12381G1PointLength), nil
+}
+
+// bls12381G1Mul implements the EIP-2537 G1 scalar multiplication as a native contract.
+type bls12381G1Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G1Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G1MulGas
+}
+
+// Run multiplies a G1 point of the subgroup by a scalar, the input is
+// (point, scalar) and the output the product.
+func (c *bls12381G1Mul) Run(input []byte) ([]byte, error) {
+	if len(input) != bls12381G1PointLength+bls12381ScalarLength {
+		return nil, errBLS12381InvalidInputLength
+	}
+	p, err := decodeBLS12381G1(input[:bls12381G1PointLength])
+	if err != nil {
+		return nil, err
+	}
+	if !isInBLS12381G1Subgroup(p) {
+		return nil, errBLS12381PointNotInSubgroup
+	}
+	bls_core.G1Mul(p, p, decodeBLS12381Scalar(input[bls12381G1PointLength:]))
+	return encodeBLS12381Point(p.GetString(16), bls12381G1PointLength), nil
+}
+
+// bls12381G2Add implements the EIP-2537 G2 point addition as a native contract.
+type bls12381G2Add struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Add) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G2AddGas
+}
+
+// Run adds two G2 points, the input is (point, point) and the output the sum.
+func (c *bls12381G2Add) Run(input []byte) ([]byte, error) {
+	if len(input) != 2*bls12381G2PointLength {
+		return nil, errBLS12381InvalidInputLength
+	}
+	p0, err := decodeBLS12381G2(input[:bls12381G2PointLength])
+	if err != nil {
+		return nil, err
+	}
+	p1, err := decodeBLS12381G2(input[bls12381G2PointLength:])
+	if err != nil {
+		return nil, err
+	}
+	bls_core.G2Add(p0, p0, p1)
+	return encodeBLS12381Point(p0.GetString(16), bls12381G2PointLength), nil
+}
+
+// bls12381G2Mul implements the EIP-2537 G2 scalar multiplication as a native contract.
+type bls12381G2Mul struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381G2Mul) RequiredGas(input []byte) uint64 {
+	return params.Bls12381G2MulGas
+}
+
+// Run multiplies a G2 point of the subgroup by a scalar, the input is
+// (point, scalar) and the output the product.
+func (c *bls12381G2Mul) Run(input []byte) ([]byte, error) {
+	if len(input) != bls12381G2PointLength+bls12381ScalarLength {
+		return nil, errBLS12381InvalidInputLength
+	}
+	p, err := decodeBLS12381G2(input[:bls12381G2PointLength])
+	if err != nil {
+		return nil, err
+	}
+	if !isInBLS12381G2Subgroup(p) {
+		return nil, errBLS12381PointNotInSubgroup
+	}
+	bls_core.G2Mul(p, p, decodeBLS12381Scalar(input[bls12381G2PointLength:]))
+	return encodeBLS12381Point(p.GetString(16), bls12381G2PointLength), nil
+}
+
+// bls12381Pairing implements the EIP-2537 pairing check as a native contract.
+type bls12381Pairing struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *bls12381Pairing) RequiredGas(input []byte) uint64 {
+	return params.Bls12381PairingBaseGas + uint64(len(input)/bls12381PairLength)*params.Bls12381PairingPerPairGas
+}
+
+// Run checks that the product of the pairings of (G1 point, G2 point) pairs of
+// the subgroups is one, the output is a 32 byte word set to 1 if it is and 0
+// otherwise.
+func (c *bls12381Pairing) Run(input []byte) ([]byte, error) {
+	if len(input) == 0 || len(input)%bls12381PairLength != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+	product := &bls_core.GT{}
+	product.SetInt64(1)
+	for i := 0; i < len(input); i += bls12381PairLength {
+		p, err := decodeBLS12381G1(input[i : i+bls12381G1PointLength])
+		if err != nil {
+			return nil, err
+		}
+		q, err := decodeBLS12381G2(input[i+bls12381G1PointLength : i+bls12381PairLength])
+		if err != nil {
+			return nil, err
+		}
+		if !isInBLS12381G1Subgroup(p) || !isInBLS12381G2Subgroup(q) {
+			return nil, errBLS12381PointNotInSubgroup
+		}
+		// a pair with the point at infinity pairs to one
+		if p.IsZero() || q.IsZero() {
+			continue
+		}
+		e := &bls_core.GT{}
+		bls_core.MillerLoop(e, p, q)
+		bls_core.GTMul(product, product, e)
+	}
+	result := &bls_core.GT{}
+	bls_core.FinalExp(result, product)
+	if !result.IsOne() {
+		return common.LeftPadBytes(nil, 32), nil
+	}
+	return common.LeftPadBytes([]byte{1}, 32), nil
+}
@@ -21,9 +21,14 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/params"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -717,3 +722,278 @@ func TestPrecompiledEcrecoverPublicKey(t *testing.T) {
 	}
 
 }
+
+var blsVerifyMalformedInputTests = []precompiledFailureTest{
+	{
+		input:         "",
+		expectedError: errBLSVerifyInvalidInputLength,
+		name:          "vector 0: empty input",
+	},
+	{
+		input:         strings.Repeat("00", blsVerifyInputLength-1),
+		expectedError: errBLSVerifyInvalidInputLength,
+		name:          "vector 1: less than 176 bytes input",
+	},
+	{
+		input:         strings.Repeat("00", blsVerifyInputLength+1),
+		expectedError: errBLSVerifyInvalidInputLength,
+		name:          "vector 2: more than 176 bytes input",
+	},
+}
+
+// BLS verify test vectors, a zero key and signature never verify
+var blsVerifyTests = []precompiledTest{
+	{
+		input:    strings.Repeat("00", blsVerifyInputLength),
+		expected: strings.Repeat("00", 32),
+		name:     "zero key and signature",
+	},
+}
+
+// testBLSPrecompiled runs a test of a precompile of the BLS set
+func testBLSPrecompiled(addr string, test precompiledTest, t *testing.T) {
+	p := PrecompiledContractsBLS[common.HexToAddress(addr)]
+	in := common.Hex2Bytes(test.input)
+	contract := NewContract(AccountRef(common.HexToAddress("1337")),
+		nil, new(big.Int), p.RequiredGas(in))
+	t.Run(fmt.Sprintf("%s-Gas=%d", test.name, contract.Gas), func(t *testing.T) {
+		if res, err := RunPrecompiledContract(p, in, contract); err != nil {
+			t.Error(err)
+		} else if common.Bytes2Hex(res) != test.expected {
+			t.Errorf("Expected %v, got %v", test.expected, common.Bytes2Hex(res))
+		}
+		// Verify that the precompile did not touch the input buffer
+		exp := common.Hex2Bytes(test.input)
+		if !bytes.Equal(in, exp) {
+			t.Errorf("Precompiled %v modified input data", addr)
+		}
+	})
+}
+
+func testBLSPrecompiledFailure(addr string, test precompiledFailureTest, t *testing.T) {
+	p := PrecompiledContractsBLS[common.HexToAddress(addr)]
+	in := common.Hex2Bytes(test.input)
+	contract := NewContract(AccountRef(common.HexToAddress("31337")),
+		nil, new(big.Int), p.RequiredGas(in))
+
+	t.Run(test.name, func(t *testing.T) {
+		_, err := RunPrecompiledContract(p, in, contract)
+		if !reflect.DeepEqual(err, test.expectedError) {
+			t.Errorf("Expected error [%v], got [%v]", test.expectedError, err)
+		}
+	})
+}
+
+// blsVerifyInput returns the precompile input of the signature of a hash
+func blsVerifyInput(hash []byte, pubKey *bls_core.PublicKey, sig *bls_core.Sign) string {
+	return common.Bytes2Hex(hash) + common.Bytes2Hex(pubKey.Serialize()) + common.Bytes2Hex(sig.Serialize())
+}
+
+func TestPrecompiledBLSVerify(t *testing.T) {
+	for _, test := range blsVerifyTests {
+		testBLSPrecompiled("fa", test, t)
+	}
+
+	var (
+		valid   = strings.Repeat("00", 31) + "01"
+		invalid = strings.Repeat("00", 32)
+		hash    = crypto.Keccak256([]byte("harmony"))
+		other   = crypto.Keccak256([]byte("one"))
+		key     = bls.RandPrivateKey()
+		key2    = bls.RandPrivateKey()
+		sig     = key.SignHash(hash)
+	)
+	// a validator signature and an aggregated signature of two validators,
+	// checked against their aggregated public key
+	aggPubKey := key.GetPublicKey()
+	aggPubKey.Add(key2.GetPublicKey())
+	aggSig := bls.AggregateSig([]*bls_core.Sign{sig, key2.SignHash(hash)})
+	// the identity key and signature, and a key canceled out by its negation
+	zeroKey, zeroSig := &bls_core.PublicKey{}, &bls_core.Sign{}
+	canceledKey := key.GetPublicKey()
+	canceledKey.Sub(key.GetPublicKey())
+
+	for _, test := range []precompiledTest{
+		{input: blsVerifyInput(hash, key.GetPublicKey(), sig), expected: valid, name: "valid signature"},
+		{input: blsVerifyInput(hash, aggPubKey, aggSig), expected: valid, name: "valid aggregated signature"},
+		{input: blsVerifyInput(other, key.GetPublicKey(), sig), expected: invalid, name: "signature of another hash"},
+		{input: blsVerifyInput(hash, key2.GetPublicKey(), sig), expected: invalid, name: "signature of another key"},
+		{input: blsVerifyInput(hash, key.GetPublicKey(), aggSig), expected: invalid, name: "aggregated signature of a single key"},
+		{input: blsVerifyInput(hash, zeroKey, zeroSig), expected: invalid, name: "identity key and signature"},
+		{input: blsVerifyInput(hash, zeroKey, sig), expected: invalid, name: "identity key"},
+		{input: blsVerifyInput(hash, key.GetPublicKey(), zeroSig), expected: invalid, name: "identity signature"},
+		{input: blsVerifyInput(hash, canceledKey, zeroSig), expected: invalid, name: "canceled key and identity signature"},
+	} {
+		testBLSPrecompiled("fa", test, t)
+	}
+}
+
+func TestPrecompiledBLSVerifyMalformedInput(t *testing.T) {
+	for _, test := range blsVerifyMalformedInputTests {
+		testBLSPrecompiledFailure("fa", test, t)
+	}
+}
+
+// BLS12-381 points in the EIP-2537 encoding
+const (
+	// the G1 generator
+	bls12381G1Generator = "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb" +
+		"0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1"
+	// twice the G1 generator
+	bls12381G1Double = "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e" +
+		"00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28"
+	// three times the G1 generator
+	bls12381G1Triple = "0000000000000000000000000000000009ece308f9d1f0131765212deca99697b112d61f9be9a5f1f3780a51335b3ff981747a0b2ca2179b96d2c0c9024e5224" +
+		"00000000000000000000000000000000032b80d3a6f5b09f8a84623389c5f80ca69a0cddabc3097f9d9c27310fd43be6e745256c634af45ca3473b0590ae30d1"
+	// the negated G1 generator
+	bls12381G1Negated = "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb" +
+		"00000000000000000000000000000000114d1d6855d545a8aa7d76c8cf2e21f267816aef1db507c96655b9d5caac42364e6f38ba0ecb751bad54dcd6b939c2ca"
+	// a point on the G1 curve outside of the subgroup
+	bls12381G1NotInSubgroup = "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004" +
+		"000000000000000000000000000000000a989badd40d6212b33cffc3f3763e9bc760f988c9926b26da9dd85e928483446346b8ed00e1de5d5ea93e354abe706c"
+	// the G1 generator with y + 1, not on the curve
+	bls12381G1NotOnCurve = "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb" +
+		"0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e2"
+	// the G2 generator
+	bls12381G2Generator = "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8" +
+		"0000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+		"000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801" +
+		"000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be"
+	// twice the G2 generator
+	bls12381G2Double = "000000000000000000000000000000001638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053" +
+		"000000000000000000000000000000000a4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c33577" +
+		"000000000000000000000000000000000468fb440d82b0630aeb8dca2b5256789a66da69bf91009cbfe6bd221e47aa8ae88dece9764bf3bd999d95d71e4c9899" +
+		"000000000000000000000000000000000f6d4552fa65dd2638b361543f887136a43253d9c66c411697003f7a13c308f5422e1aa0a59c8967acdefd8b6e36ccf3"
+	// three times the G2 generator
+	bls12381G2Triple = "00000000000000000000000000000000122915c824a0857e2ee414a3dccb23ae691ae54329781315a0c75df1c04d6d7a50a030fc866f09d516020ef82324afae" +
+		"0000000000000000000000000000000009380275bbc8e5dcea7dc4dd7e0550ff2ac480905396eda55062650f8d251c96eb480673937cc6d9d6a44aaa56ca66dc" +
+		"000000000000000000000000000000000b21da7955969e61010c7a1abc1a6f0136961d1e3b20b1a7326ac738fef5c721479dfd948b52fdf2455e44813ecfd892" +
+		"0000000000000000000000000000000008f239ba329b3967fe48d718a36cfe5f62a7e42e0bf1c1ed714150a166bfbd6bcf6b3b58b975b9edea56d53f23a0e849"
+	// the negated G2 generator
+	bls12381G2Negated = "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8" +
+		"0000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+		"000000000000000000000000000000000d1b3cc2c7027888be51d9ef691d77bcb679afda66c73f17f9ee3837a55024f78c71363275a75d75d86bab79f74782aa" +
+		"0000000000000000000000000000000013fa4d4a0ad8b1ce186ed5061789213d993923066dddaf1040bc3ff59f825c78df74f2d75467e25e0f55f8a00fa030ed"
+)
+
+var (
+	bls12381G1Infinity = strings.Repeat("00", bls12381G1PointLength)
+	bls12381G2Infinity = strings.Repeat("00", bls12381G2PointLength)
+	bls12381True       = strings.Repeat("00", 31) + "01"
+	bls12381False      = strings.Repeat("00", 32)
+)
+
+// bls12381Scalar returns the EIP-2537 encoding of a scalar
+func bls12381Scalar(k *big.Int) string {
+	return common.Bytes2Hex(common.LeftPadBytes(k.Bytes(), bls12381ScalarLength))
+}
+
+func TestPrecompiledBLS12381G1Add(t *testing.T) {
+	for _, test := range []precompiledTest{
+		{input: bls12381G1Generator + bls12381G1Generator, expected: bls12381G1Double, name: "g1 + g1"},
+		{input: bls12381G1Double + bls12381G1Generator, expected: bls12381G1Triple, name: "2g1 + g1"},
+		{input: bls12381G1Generator + bls12381G1Negated, expected: bls12381G1Infinity, name: "g1 - g1"},
+		{input: bls12381G1Generator + bls12381G1Infinity, expected: bls12381G1Generator, name: "g1 + infinity"},
+		{input: bls12381G1Infinity + bls12381G1Infinity, expected: bls12381G1Infinity, name: "infinity + infinity"},
+	} {
+		testBLSPrecompiled("0a", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G1Mul(t *testing.T) {
+	for _, test := range []precompiledTest{
+		{input: bls12381G1Generator + bls12381Scalar(big.NewInt(2)), expected: bls12381G1Double, name: "g1 * 2"},
+		{input: bls12381G1Generator + bls12381Scalar(big.NewInt(3)), expected: bls12381G1Triple, name: "g1 * 3"},
+		{input: bls12381G1Generator + bls12381Scalar(big.NewInt(0)), expected: bls12381G1Infinity, name: "g1 * 0"},
+		{input: bls12381G1Generator + bls12381Scalar(bls12381GroupOrder), expected: bls12381G1Infinity, name: "g1 * order"},
+		{input: bls12381G1Generator + bls12381Scalar(new(big.Int).Add(bls12381GroupOrder, big.NewInt(2))), expected: bls12381G1Double, name: "g1 * (order + 2)"},
+		{input: bls12381G1Infinity + bls12381Scalar(big.NewInt(2)), expected: bls12381G1Infinity, name: "infinity * 2"},
+	} {
+		testBLSPrecompiled("0b", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G2Add(t *testing.T) {
+	for _, test := range []precompiledTest{
+		{input: bls12381G2Generator + bls12381G2Generator, expected: bls12381G2Double, name: "g2 + g2"},
+		{input: bls12381G2Double + bls12381G2Generator, expected: bls12381G2Triple, name: "2g2 + g2"},
+		{input: bls12381G2Generator + bls12381G2Negated, expected: bls12381G2Infinity, name: "g2 - g2"},
+		{input: bls12381G2Generator + bls12381G2Infinity, expected: bls12381G2Generator, name: "g2 + infinity"},
+	} {
+		testBLSPrecompiled("0d", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G2Mul(t *testing.T) {
+	for _, test := range []precompiledTest{
+		{input: bls12381G2Generator + bls12381Scalar(big.NewInt(2)), expected: bls12381G2Double, name: "g2 * 2"},
+		{input: bls12381G2Generator + bls12381Scalar(big.NewInt(3)), expected: bls12381G2Triple, name: "g2 * 3"},
+		{input: bls12381G2Generator + bls12381Scalar(big.NewInt(0)), expected: bls12381G2Infinity, name: "g2 * 0"},
+		{input: bls12381G2Generator + bls12381Scalar(bls12381GroupOrder), expected: bls12381G2Infinity, name: "g2 * order"},
+	} {
+		testBLSPrecompiled("0e", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381Pairing(t *testing.T) {
+	for _, test := range []precompiledTest{
+		{input: bls12381G1Generator + bls12381G2Generator, expected: bls12381False, name: "e(g1, g2)"},
+		{input: bls12381G1Generator + bls12381G2Generator + bls12381G1Negated + bls12381G2Generator, expected: bls12381True, name: "e(g1, g2) * e(-g1, g2)"},
+		{input: bls12381G1Double + bls12381G2Generator + bls12381G1Negated + bls12381G2Double, expected: bls12381True, name: "e(2g1, g2) * e(-g1, 2g2)"},
+		{input: bls12381G1Triple + bls12381G2Generator + bls12381G1Negated + bls12381G2Double, expected: bls12381False, name: "e(3g1, g2) * e(-g1, 2g2)"},
+		{input: bls12381G1Infinity + bls12381G2Generator, expected: bls12381True, name: "e(infinity, g2)"},
+		{input: bls12381G1Generator + bls12381G2Infinity, expected: bls12381True, name: "e(g1, infinity)"},
+	} {
+		testBLSPrecompiled("10", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381MalformedInput(t *testing.T) {
+	var (
+		// a field element with a non zero top byte, and one equal to the modulus
+		topBytes = "01" + bls12381G1Generator[2:]
+		modulus  = common.Bytes2Hex(common.LeftPadBytes(bls12381FieldModulus.Bytes(), bls12381FieldElementLength)) +
+			bls12381G1Generator[2*bls12381FieldElementLength:]
+	)
+	for _, test := range []struct {
+		addr string
+		precompiledFailureTest
+	}{
+		{"0a", precompiledFailureTest{input: bls12381G1Generator, expectedError: errBLS12381InvalidInputLength, name: "g1 add of a single point"}},
+		{"0a", precompiledFailureTest{input: topBytes + bls12381G1Generator, expectedError: errBLS12381InvalidFieldElementTopBytes, name: "g1 add with top bytes set"}},
+		{"0a", precompiledFailureTest{input: modulus + bls12381G1Generator, expectedError: errBLS12381InvalidFieldElement, name: "g1 add of the field modulus"}},
+		{"0a", precompiledFailureTest{input: bls12381G1NotOnCurve + bls12381G1Generator, expectedError: errBLS12381InvalidPoint, name: "g1 add of a point not on the curve"}},
+		{"0b", precompiledFailureTest{input: bls12381G1Generator, expectedError: errBLS12381InvalidInputLength, name: "g1 mul without a scalar"}},
+		{"0d", precompiledFailureTest{input: bls12381G2Generator, expectedError: errBLS12381InvalidInputLength, name: "g2 add of a single point"}},
+		{"0d", precompiledFailureTest{input: bls12381G2Generator + bls12381G1Generator + bls12381G1Generator, expectedError: errBLS12381InvalidPoint, name: "g2 add of a point not on the curve"}},
+		{"0e", precompiledFailureTest{input: bls12381G2Generator, expectedError: errBLS12381InvalidInputLength, name: "g2 mul without a scalar"}},
+		{"10", precompiledFailureTest{input: "", expectedError: errBLS12381InvalidInputLength, name: "pairing of no pairs"}},
+		{"10", precompiledFailureTest{input: bls12381G1Generator + bls12381G2Generator + "00", expectedError: errBLS12381InvalidInputLength, name: "pairing of a partial pair"}},
+	} {
+		testBLSPrecompiledFailure(test.addr, test.precompiledFailureTest, t)
+	}
+}
+
+// Points outside of the subgroup are rejected by the multiplication and the
+// pairing, either on decoding or by the subgroup check.
+func TestPrecompiledBLS12381NotInSubgroup(t *testing.T) {
+	for _, test := range []struct {
+		addr, input string
+	}{
+		{"0b", bls12381G1NotInSubgroup + bls12381Scalar(big.NewInt(2))},
+		{"10", bls12381G1NotInSubgroup + bls12381G2Generator},
+	} {
+		p := PrecompiledContractsBLS[common.HexToAddress(test.addr)]
+		if _, err := p.Run(common.Hex2Bytes(test.input)); err == nil {
+			t.Errorf("precompile %v accepted a point outside of the subgroup", test.addr)
+		}
+	}
+}
+
+func TestPrecompiledBLS12381PairingGas(t *testing.T) {
+	p := PrecompiledContractsBLS[common.HexToAddress("10")]
+	input := common.Hex2Bytes(bls12381G1Generator + bls12381G2Generator + bls12381G1Negated + bls12381G2Generator)
+	if gas, want := p.RequiredGas(input), params.Bls12381PairingBaseGas+2*params.Bls12381PairingPerPairGas; gas != want {
+		t.Errorf("expected %d gas for two pairs, got %d", want, gas)
+	}
+}
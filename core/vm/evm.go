@@ -41,6 +41,19 @@ type RosettaTracer interface {
 	AddRosettaLog(op OpCode, from, to *RosettaLogAddressItem, val *big.Int)
 }
 
+// StakingTracer is implemented by tracers that record staking directives,
+// which are executed outside of the interpreter.
+type StakingTracer interface {
+	CaptureStaking(env *EVM, directive stakingTypes.Directive, validator, delegator common.Address, amount *big.Int, gasUsed uint64, err error) error
+}
+
+// RefundTracer is implemented by tracers that account for the intrinsic gas
+// and the gas refund of a transaction, which are charged before and applied
+// after the interpreter has run.
+type RefundTracer interface {
+	CaptureRefund(env *EVM, intrinsicGas, refund uint64) error
+}
+
 type (
 	// CanTransferFunc is the signature of a transfer guard function
 	CanTransferFunc func(StateDB, common.Address, *big.Int) bool
@@ -54,6 +67,9 @@ type (
 	// GetVRFFunc returns the nth block vrf in the blockchain
 	// and is used by the precompile VRF contract.
 	GetVRFFunc func(uint64) common.Hash
+	// GetCrossLinkFunc returns the block number and hash of the last crosslink
+	// of a shard and is used by the precompile shard info contract.
+	GetCrossLinkFunc func(uint32) (uint64, common.Hash)
 	// Below functions are used by staking precompile, and state transition
 	CreateValidatorFunc func(db StateDB, rosettaTracer RosettaTracer, stakeMsg *stakingTypes.CreateValidator) error
 	EditValidatorFunc   func(db StateDB, rosettaTracer RosettaTracer, stakeMsg *stakingTypes.EditValidator) error
@@ -68,26 +84,7 @@ type (
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := PrecompiledContractsHomestead
-		// assign empty write capable precompiles till they are available in the fork
-		writeCapablePrecompiles := make(map[common.Address]WriteCapablePrecompiledContract)
-		if evm.ChainConfig().IsS3(evm.EpochNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if evm.chainRules.IsVRF {
-			precompiles = PrecompiledContractsVRF
-		}
-		if evm.chainRules.IsSHA3 {
-			precompiles = PrecompiledContractsSHA3FIPS
-		}
-		if evm.chainRules.IsStakingPrecompile {
-			precompiles = PrecompiledContractsStaking
-			writeCapablePrecompiles = WriteCapablePrecompiledContractsStaking
-		}
-		if p := precompiles[*contract.CodeAddr]; p != nil {
+		if p := evm.precompiles[*contract.CodeAddr]; p != nil {
 			if _, ok := p.(*vrf); ok {
 				if evm.chainRules.IsPrevVRF {
 					requestedBlockNum := big.NewInt(0).SetBytes(input)
@@ -96,7 +93,10 @@ func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, err
 					if requestedBlockNum.Cmp(evm.BlockNumber) == 0 {
 						input = evm.Context.VRF.Bytes()
 					} else if requestedBlockNum.Cmp(minBlockNum) > 0 && requestedBlockNum.Cmp(evm.BlockNumber) < 0 {
-						// requested block number is in range
+						// requested block number is in range, the lookup is charged separately
+						if evm.chainRules.IsVRFLookupGas && !contract.UseGas(params.VRFLookupGas) {
+							return nil, ErrOutOfGas
+						}
 						input = evm.GetVRF(requestedBlockNum.Uint64()).Bytes()
 					} else {
 						// else default to the current block's VRF
@@ -108,10 +108,17 @@ func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, err
 				}
 			} else if _, ok := p.(*epoch); ok {
 				input = evm.EpochNumber.Bytes()
+			} else if _, ok := p.(*shardInfo); ok {
+				shardID, requested := shardInfoRequest(input)
+				// the crosslink lookup reads past headers and is charged separately
+				if requested && !contract.UseGas(params.ShardInfoCrossLinkGas) {
+					return nil, ErrOutOfGas
+				}
+				input = evm.shardInfo(shardID, requested)
 			}
 			return RunPrecompiledContract(p, input, contract)
 		}
-		if p := writeCapablePrecompiles[*contract.CodeAddr]; p != nil {
+		if p := evm.writeCapablePrecompiles[*contract.CodeAddr]; p != nil {
 			return RunWriteCapablePrecompiledContract(p, evm, contract, input, readOnly)
 		}
 	}
@@ -148,6 +155,8 @@ type Context struct {
 	GetHash GetHashFunc
 	// GetVRF returns the VRF corresponding to n
 	GetVRF GetVRFFunc
+	// GetCrossLink returns the last crosslink of a shard
+	GetCrossLink GetCrossLinkFunc
 
 	// IsValidator determines whether the address corresponds to a validator or a smart contract
 	// true: is a validator address; false: is smart contract address
@@ -164,6 +173,7 @@ type Context struct {
 	EpochNumber *big.Int       // Provides information for EPOCH
 	Time        *big.Int       // Provides information for TIME
 	VRF         common.Hash    // Provides information for VRF
+	BaseFee     *big.Int       // Provides the EIP-1559 base fee
 
 	TxType types.TransactionType
 
@@ -199,6 +209,12 @@ type EVM struct {
 	chainConfig *params.ChainConfig
 	// chain rules contains the chain rules for the current epoch
 	chainRules params.Rules
+	// gas table contains the gas prices for the current epoch
+	gasTable params.GasTable
+	// precompiles contains the read-only and write capable precompiles enabled
+	// by the chain rules
+	precompiles             map[common.Address]PrecompiledContract
+	writeCapablePrecompiles map[common.Address]WriteCapablePrecompiledContract
 	// virtual machine configuration options used to initialise the
 	// evm.
 	vmConfig Config
@@ -227,8 +243,10 @@ func NewEVM(ctx Context, statedb StateDB, chainConfig *params.ChainConfig, vmCon
 		vmConfig:     vmConfig,
 		chainConfig:  chainConfig,
 		chainRules:   chainConfig.Rules(ctx.EpochNumber),
+		gasTable:     chainConfig.GasTable(ctx.EpochNumber),
 		interpreters: make([]Interpreter, 0, 1),
 	}
+	evm.precompiles, evm.writeCapablePrecompiles = precompilesFor(evm.chainRules)
 
 	//if chainConfig.IsS3(ctx.EpochNumber) {
 	//	to be implemented by EVM-C and Wagon PRs.
@@ -265,11 +283,55 @@ func (evm *EVM) Cancelled() bool {
 	return atomic.LoadInt32(&evm.abort) == 1
 }
 
+// CaptureStaking reports an executed staking directive to the configured tracer,
+// if tracing is enabled and the tracer records staking directives.
+func (evm *EVM) CaptureStaking(directive stakingTypes.Directive, validator, delegator common.Address, amount *big.Int, gasUsed uint64, err error) {
+	if !evm.vmConfig.Debug {
+		return
+	}
+	if tracer, ok := evm.vmConfig.Tracer.(StakingTracer); ok {
+		tracer.CaptureStaking(evm, directive, validator, delegator, amount, gasUsed, err)
+	}
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() Interpreter {
 	return evm.interpreter
 }
 
+// shardInfoRequest parses the shard whose last crosslink is requested from the
+// first input word. An empty input or a word overflowing uint32 requests none.
+func shardInfoRequest(input []byte) (uint32, bool) {
+	if len(input) == 0 {
+		return 0, false
+	}
+	requested := new(big.Int).SetBytes(getData(input, 0, 32))
+	if requested.BitLen() > 32 {
+		return 0, false
+	}
+	return uint32(requested.Uint64()), true
+}
+
+// shardInfo returns the shard ID and epoch of the current block followed by the
+// block number and hash of the last crosslink of the requested shard, each as a
+// 32 byte word. The crosslink words are zero if no shard is requested or no
+// crosslink was committed in the recent ancestry, only the beacon chain commits
+// crosslinks.
+func (evm *EVM) shardInfo(shardID uint32, requested bool) []byte {
+	var (
+		blockNum  uint64
+		blockHash common.Hash
+	)
+	if requested && evm.GetCrossLink != nil {
+		blockNum, blockHash = evm.GetCrossLink(shardID)
+	}
+	output := make([]byte, 0, 128)
+	output = append(output, common.LeftPadBytes(new(big.Int).SetUint64(uint64(evm.ShardID)).Bytes(), 32)...)
+	output = append(output, common.LeftPadBytes(evm.EpochNumber.Bytes(), 32)...)
+	output = append(output, common.LeftPadBytes(new(big.Int).SetUint64(blockNum).Bytes(), 32)...)
+	return append(output, blockHash.Bytes()...)
+}
+
 // Call executes the contract associated with the addr with the given input as
 // parameters. It also handles any necessary value transfer required and takes
 // the necessary steps to create accounts and reverses the state in case of an
@@ -296,26 +358,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		snapshot = evm.StateDB.Snapshot()
 	)
 	if !evm.StateDB.Exist(addr) && txType != types.SubtractionOnly {
-		precompiles := PrecompiledContractsHomestead
-		writeCapablePrecompiles := make(map[common.Address]WriteCapablePrecompiledContract)
-		if evm.ChainConfig().IsS3(evm.EpochNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if evm.chainRules.IsVRF {
-			precompiles = PrecompiledContractsVRF
-		}
-		if evm.chainRules.IsSHA3 {
-			precompiles = PrecompiledContractsSHA3FIPS
-		}
-		if evm.chainRules.IsStakingPrecompile {
-			precompiles = PrecompiledContractsStaking
-			writeCapablePrecompiles = WriteCapablePrecompiledContractsStaking
-		}
-
-		if writeCapablePrecompiles[addr] == nil && precompiles[addr] == nil && evm.ChainConfig().IsS3(evm.EpochNumber) && value.Sign() == 0 {
+		if evm.writeCapablePrecompiles[addr] == nil && evm.precompiles[addr] == nil && evm.ChainConfig().IsS3(evm.EpochNumber) && value.Sign() == 0 {
 			// Calling a non existing account, don't do anything, but ping the tracer
 			if evm.vmConfig.Debug && evm.depth == 0 {
 				evm.vmConfig.Tracer.CaptureStart(evm, caller.Address(), addr, false, input, gas, value)
@@ -505,6 +548,11 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 	nonce := evm.StateDB.GetNonce(caller.Address())
 	evm.StateDB.SetNonce(caller.Address(), nonce+1)
+	// We add this to the access list _before_ taking a snapshot. Even if the creation fails,
+	// the access-list change should not be rolled back
+	if evm.chainRules.IsBerlin {
+		evm.StateDB.AddAddressToAccessList(address)
+	}
 
 	// Ensure there's no existing contract already at the designated address
 	contractHash := evm.StateDB.GetCodeHash(address)
@@ -587,5 +635,20 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 	return evm.create(caller, codeAndHash, gas, endowment, contractAddr)
 }
 
+// CaptureRefund reports the intrinsic gas and the gas refunded at the end of a
+// transaction to the configured tracer, if tracing is enabled and the tracer
+// accounts for refunds.
+func (evm *EVM) CaptureRefund(intrinsicGas, refund uint64) {
+	if !evm.vmConfig.Debug {
+		return
+	}
+	if tracer, ok := evm.vmConfig.Tracer.(RefundTracer); ok {
+		tracer.CaptureRefund(evm, intrinsicGas, refund)
+	}
+}
+
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
+
+// Config returns the environment's virtual machine configuration
+func (evm *EVM) Config() Config { return evm.vmConfig }
@@ -33,3 +33,154 @@ func TestEpochPrecompile(t *testing.T) {
 		t.Error("Epoch did not match")
 	}
 }
+
+func TestShardInfoPrecompile(t *testing.T) {
+	targetEpoch := big.NewInt(3)
+	linkHash := common.HexToHash("0x1234")
+	evm := NewEVM(Context{
+		EpochNumber: targetEpoch,
+		ShardID:     0,
+		GetCrossLink: func(shardID uint32) (uint64, common.Hash) {
+			if shardID == 1 {
+				return 42, linkHash
+			}
+			return 0, common.Hash{}
+		},
+	}, nil, params.TestChainConfig, Config{})
+	precompileAddr := common.BytesToAddress([]byte{249})
+
+	tests := []struct {
+		input     []byte
+		gas       uint64
+		blockNum  int64
+		blockHash common.Hash
+		err       error
+	}{
+		{[]byte{}, GasQuickStep, 0, common.Hash{}, nil},
+		{common.LeftPadBytes([]byte{1}, 32), GasQuickStep + params.ShardInfoCrossLinkGas, 42, linkHash, nil},
+		{common.LeftPadBytes([]byte{2}, 32), GasQuickStep + params.ShardInfoCrossLinkGas, 0, common.Hash{}, nil},
+		{common.LeftPadBytes([]byte{1}, 32), GasQuickStep, 0, common.Hash{}, ErrOutOfGas},
+		// shard IDs overflowing uint32 are never looked up
+		{common.LeftPadBytes([]byte{1, 0, 0, 0, 1}, 32), GasQuickStep, 0, common.Hash{}, nil},
+	}
+	for i, test := range tests {
+		contract := Contract{
+			CodeAddr: &precompileAddr,
+			Gas:      test.gas,
+		}
+		result, err := run(evm, &contract, test.input, true)
+		if err != test.err {
+			t.Fatalf("test %d: expected error %v, got %v", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if len(result) != 128 {
+			t.Fatalf("test %d: expected 128 bytes, got %d", i, len(result))
+		}
+		if new(big.Int).SetBytes(result[:32]).Sign() != 0 {
+			t.Errorf("test %d: shard ID did not match", i)
+		}
+		if new(big.Int).SetBytes(result[32:64]).Cmp(targetEpoch) != 0 {
+			t.Errorf("test %d: epoch did not match", i)
+		}
+		if new(big.Int).SetBytes(result[64:96]).Int64() != test.blockNum {
+			t.Errorf("test %d: crosslink block number did not match", i)
+		}
+		if common.BytesToHash(result[96:]) != test.blockHash {
+			t.Errorf("test %d: crosslink block hash did not match", i)
+		}
+		if contract.Gas != 0 {
+			t.Errorf("test %d: expected all gas used, %d left", i, contract.Gas)
+		}
+	}
+}
+
+func TestVRFPrecompileLookupGas(t *testing.T) {
+	blockNumber := big.NewInt(300)
+	currentVRF := common.HexToHash("0x01")
+	pastVRF := common.HexToHash("0x02")
+	config := *params.TestChainConfig
+	config.VRFLookupGasEpoch = big.NewInt(2)
+	precompileAddr := common.BytesToAddress([]byte{255})
+
+	tests := []struct {
+		epoch     int64
+		requested *big.Int
+		gas       uint64
+		expected  common.Hash
+		err       error
+	}{
+		{2, blockNumber, GasQuickStep, currentVRF, nil},
+		{2, big.NewInt(299), GasQuickStep + params.VRFLookupGas, pastVRF, nil},
+		{2, big.NewInt(299), GasQuickStep, common.Hash{}, ErrOutOfGas},
+		// out of the lookup window, defaults to the current block for free
+		{2, big.NewInt(10), GasQuickStep, currentVRF, nil},
+		// the lookup is free before the VRFLookupGasEpoch
+		{1, big.NewInt(299), GasQuickStep, pastVRF, nil},
+	}
+	for i, test := range tests {
+		evm := NewEVM(Context{
+			BlockNumber: blockNumber,
+			EpochNumber: big.NewInt(test.epoch),
+			VRF:         currentVRF,
+			GetVRF: func(n uint64) common.Hash {
+				return pastVRF
+			},
+		}, nil, &config, Config{})
+		contract := Contract{
+			CodeAddr: &precompileAddr,
+			Gas:      test.gas,
+		}
+		result, err := run(evm, &contract, test.requested.Bytes(), true)
+		if err != test.err {
+			t.Fatalf("test %d: expected error %v, got %v", i, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if common.BytesToHash(result) != test.expected {
+			t.Errorf("test %d: expected VRF %x, got %x", i, test.expected, result)
+		}
+		if contract.Gas != 0 {
+			t.Errorf("test %d: expected all gas used, %d left", i, contract.Gas)
+		}
+	}
+}
+
+func TestShardInfoPrecompileSet(t *testing.T) {
+	shardInfoAddr := common.BytesToAddress([]byte{249})
+	blsVerifyAddr := common.BytesToAddress([]byte{250})
+	epochAddr := common.BytesToAddress([]byte{251})
+	stakingSetSize := len(PrecompiledContractsStaking)
+
+	tests := []struct {
+		bls, shardInfo bool
+	}{
+		{false, false},
+		{true, false},
+		{false, true},
+		{true, true},
+	}
+	for i, test := range tests {
+		rules := params.TestRules
+		rules.IsBLSPrecompile, rules.IsShardInfoPrecompile = test.bls, test.shardInfo
+		active := make(map[common.Address]bool)
+		for _, address := range ActivePrecompiles(rules) {
+			active[address] = true
+		}
+		if active[blsVerifyAddr] != test.bls {
+			t.Errorf("test %d: have BLS precompile %v, want %v", i, active[blsVerifyAddr], test.bls)
+		}
+		if active[shardInfoAddr] != test.shardInfo {
+			t.Errorf("test %d: have shard info precompile %v, want %v", i, active[shardInfoAddr], test.shardInfo)
+		}
+		// the added precompiles extend the staking set
+		if !active[epochAddr] {
+			t.Errorf("test %d: missing the epoch precompile of the staking set", i)
+		}
+	}
+	if len(PrecompiledContractsStaking) != stakingSetSize {
+		t.Error("the staking set was modified")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"math"
 	"math/big"
 
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/internal/params"
 )
 
@@ -93,3 +94,18 @@ func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidato
 	}
 	return gas, nil
 }
+
+// AccessListGas computes the intrinsic gas of an EIP-2930 access list.
+func AccessListGas(accessList types.AccessList) (uint64, error) {
+	addresses, keys := uint64(len(accessList)), uint64(accessList.StorageKeys())
+	if addresses > math.MaxUint64/params.TxAccessListAddressGas ||
+		keys > math.MaxUint64/params.TxAccessListStorageKeyGas {
+		return 0, ErrOutOfGas
+	}
+	addressGas := addresses * params.TxAccessListAddressGas
+	keyGas := keys * params.TxAccessListStorageKeyGas
+	if addressGas > math.MaxUint64-keyGas {
+		return 0, ErrOutOfGas
+	}
+	return addressGas + keyGas, nil
+}
@@ -108,3 +108,72 @@ func TestEIP2200(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyGasTable(t *testing.T) {
+	tests := []struct {
+		jt JumpTable
+		gt params.GasTable
+	}{
+		{frontierInstructionSet, params.GasTableR3},
+		{constantinopleInstructionSet, params.GasTableS3},
+		{istanbulInstructionSet, params.GasTableIstanbul},
+	}
+	for i, tt := range tests {
+		jt := tt.jt
+		applyGasTable(&jt, tt.gt)
+		for op := 0; op < len(jt); op++ {
+			if jt[op].constantGas != tt.jt[op].constantGas {
+				t.Errorf("test %d: %v repriced from %d to %d", i, OpCode(op), tt.jt[op].constantGas, jt[op].constantGas)
+			}
+		}
+	}
+	// the berlin table matches the warm costs of EIP-2929
+	jt := istanbulInstructionSet
+	enable2929(&jt)
+	expected := jt
+	applyGasTable(&jt, params.GasTableBerlin)
+	for op := 0; op < len(jt); op++ {
+		if jt[op].constantGas != expected[op].constantGas {
+			t.Errorf("berlin: %v repriced from %d to %d", OpCode(op), expected[op].constantGas, jt[op].constantGas)
+		}
+	}
+}
+
+func TestEIP3529(t *testing.T) {
+	tests := []struct {
+		eips   []int
+		refund uint64
+	}{
+		{nil, params.SstoreClearsScheduleRefundEIP3529}, // reduced refund
+		{[]int{2929}, params.SstoreClearRefundEIP2200},  // EIP-2929 refund
+	}
+	for i, tt := range tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, hexutil.MustDecode("0x6000600055")) // 1 -> 0
+		statedb.SetState(address, common.Hash{}, common.BytesToHash([]byte{1}))
+		statedb.Finalise(true) // Push the state into the "original" slot
+		statedb.AddAddressToAccessList(address)
+
+		vmctx := Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int, types.TransactionType) {},
+			IsValidator: func(StateDB, common.Address) bool { return false },
+			EpochNumber: big.NewInt(0),
+		}
+		vmenv := NewEVM(vmctx, statedb, params.AllProtocolChanges, Config{ExtraEips: tt.eips})
+
+		_, gas, err := vmenv.Call(AccountRef(common.Address{}), address, nil, math.MaxUint64, new(big.Int))
+		if err != nil {
+			t.Fatalf("test %d: unexpected failure: %v", i, err)
+		}
+		if used := math.MaxUint64 - gas; used != 5006 {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, 5006)
+		}
+		if refund := vmenv.StateDB.GetRefund(); refund != tt.refund {
+			t.Errorf("test %d: gas refund mismatch: have %v, want %v", i, refund, tt.refund)
+		}
+	}
+}
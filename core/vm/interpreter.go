@@ -50,6 +50,25 @@ type Config struct {
 
 	// ExtraEips the additional EIPS that are to be enabled
 	ExtraEips []int
+
+	// NoBaseFee forces the EIP-1559 base fee check to be skipped for
+	// zero-priced messages, as used by eth_call and gas estimation
+	NoBaseFee bool
+}
+
+// AddTracer registers an additional tracer and enables debugging. If a tracer
+// is already configured, both are attached through a new MultiTracer so that a
+// single execution feeds all of them, leaving copies of the config untouched.
+func (c *Config) AddTracer(tracer Tracer) {
+	if tracer == nil {
+		return
+	}
+	c.Debug = true
+	if c.Tracer == nil {
+		c.Tracer = tracer
+		return
+	}
+	c.Tracer = NewMultiTracer(c.Tracer, tracer)
 }
 
 // Interpreter is used to run Ethereum based contracts and will utilise the
@@ -111,6 +130,20 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 		default:
 			jt = frontierInstructionSet
 		}
+		if evm.chainRules.IsTransientStorage {
+			enable1153(&jt)
+		}
+		if evm.chainRules.IsPush0 {
+			enable3855(&jt)
+		}
+		if evm.chainRules.IsBerlin {
+			enable2929(&jt)
+			// the reduced refunds build on the EIP-2929 gas functions
+			if evm.chainRules.IsRefundReduction {
+				enable3529(&jt)
+			}
+		}
+		applyGasTable(&jt, evm.gasTable)
 		for i, eip := range cfg.ExtraEips {
 			if err := EnableEIP(eip, &jt); err != nil {
 				// Disable it, so caller can check if it's activated or not
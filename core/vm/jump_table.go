@@ -66,6 +66,26 @@ var (
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]operation
 
+// applyGasTable prices the state reading opcodes of the given jump table
+// according to the gas table of the current epoch. Opcodes that are not
+// defined by the jump table are left untouched.
+func applyGasTable(jt *JumpTable, gt params.GasTable) {
+	jt[BALANCE].constantGas = gt.Balance
+	jt[EXTCODESIZE].constantGas = gt.ExtcodeSize
+	jt[EXTCODECOPY].constantGas = gt.ExtcodeCopy
+	jt[SLOAD].constantGas = gt.SLoad
+	jt[CALL].constantGas = gt.Calls
+	jt[CALLCODE].constantGas = gt.Calls
+	for _, op := range []OpCode{DELEGATECALL, STATICCALL} {
+		if jt[op].valid {
+			jt[op].constantGas = gt.Calls
+		}
+	}
+	if jt[EXTCODEHASH].valid {
+		jt[EXTCODEHASH].constantGas = gt.ExtcodeHash
+	}
+}
+
 // newIstanbulInstructionSet returns the frontier, homestead
 // byzantium, contantinople and petersburg instructions.
 func newIstanbulInstructionSet() JumpTable {
@@ -170,7 +190,6 @@ func newByzantiumInstructionSet() JumpTable {
 // EIP 158 a.k.a Spurious Dragon
 func newSpuriousDragonInstructionSet() JumpTable {
 	instructionSet := newTangerineWhistleInstructionSet()
-	instructionSet[EXP].dynamicGas = gasExpEIP158
 	return instructionSet
 
 }
@@ -282,7 +301,7 @@ func newFrontierInstructionSet() JumpTable {
 		},
 		EXP: {
 			execute:    opExp,
-			dynamicGas: gasExpFrontier,
+			dynamicGas: gasExp,
 			minStack:   minStack(2, 1),
 			maxStack:   maxStack(2, 1),
 			valid:      true,
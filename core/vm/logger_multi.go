@@ -0,0 +1,142 @@
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	stakingTypes "github.com/harmony-one/harmony/staking/types"
+)
+
+// MultiTracer multiplexes the tracing hooks of a single EVM execution to
+// several tracers, e.g. an explorer index and a debug request tracing the
+// same block. Hooks are invoked in registration order; the first error
+// returned by any tracer is reported, but every tracer is still called.
+type MultiTracer struct {
+	tracers []Tracer
+}
+
+// NewMultiTracer returns a tracer forwarding every hook to the given tracers.
+func NewMultiTracer(tracers ...Tracer) *MultiTracer {
+	t := &MultiTracer{}
+	for _, tracer := range tracers {
+		t.Add(tracer)
+	}
+	return t
+}
+
+// Add registers a tracer, nested multi tracers are flattened.
+func (t *MultiTracer) Add(tracer Tracer) {
+	switch tr := tracer.(type) {
+	case nil:
+	case *MultiTracer:
+		t.tracers = append(t.tracers, tr.tracers...)
+	default:
+		t.tracers = append(t.tracers, tr)
+	}
+}
+
+// Tracers returns the registered tracers.
+func (t *MultiTracer) Tracers() []Tracer {
+	return t.tracers
+}
+
+// CaptureStart implements the Tracer interface.
+func (t *MultiTracer) CaptureStart(env *EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	var firstErr error
+	for _, tracer := range t.tracers {
+		if terr := tracer.CaptureStart(env, from, to, create, input, gas, value); terr != nil && firstErr == nil {
+			firstErr = terr
+		}
+	}
+	return firstErr
+}
+
+// CaptureState implements the Tracer interface, the returned hook runs the
+// after-execution hooks of all tracers.
+func (t *MultiTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) (HookAfter, error) {
+	var (
+		hooks    []HookAfter
+		firstErr error
+	)
+	for _, tracer := range t.tracers {
+		hook, terr := tracer.CaptureState(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+		if terr != nil && firstErr == nil {
+			firstErr = terr
+		}
+		if hook != nil {
+			hooks = append(hooks, hook)
+		}
+	}
+	switch len(hooks) {
+	case 0:
+		return nil, firstErr
+	case 1:
+		return hooks[0], firstErr
+	}
+	return func(memory *Memory, stack *Stack) {
+		for _, hook := range hooks {
+			hook(memory, stack)
+		}
+	}, firstErr
+}
+
+// CaptureFault implements the Tracer interface.
+func (t *MultiTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	var firstErr error
+	for _, tracer := range t.tracers {
+		if terr := tracer.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err); terr != nil && firstErr == nil {
+			firstErr = terr
+		}
+	}
+	return firstErr
+}
+
+// CaptureEnd implements the Tracer interface.
+func (t *MultiTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	var firstErr error
+	for _, tracer := range t.tracers {
+		if terr := tracer.CaptureEnd(output, gasUsed, d, err); terr != nil && firstErr == nil {
+			firstErr = terr
+		}
+	}
+	return firstErr
+}
+
+// CaptureStaking implements the StakingTracer interface, forwarding the
+// directive to the tracers that record staking directives.
+func (t *MultiTracer) CaptureStaking(env *EVM, directive stakingTypes.Directive, validator, delegator common.Address, amount *big.Int, gasUsed uint64, err error) error {
+	var firstErr error
+	for _, tracer := range t.tracers {
+		if st, ok := tracer.(StakingTracer); ok {
+			if terr := st.CaptureStaking(env, directive, validator, delegator, amount, gasUsed, err); terr != nil && firstErr == nil {
+				firstErr = terr
+			}
+		}
+	}
+	return firstErr
+}
+
+// CaptureRefund implements the RefundTracer interface, forwarding the intrinsic
+// gas and the refund to the tracers that account for refunds.
+func (t *MultiTracer) CaptureRefund(env *EVM, intrinsicGas, refund uint64) error {
+	var firstErr error
+	for _, tracer := range t.tracers {
+		if rt, ok := tracer.(RefundTracer); ok {
+			if terr := rt.CaptureRefund(env, intrinsicGas, refund); terr != nil && firstErr == nil {
+				firstErr = terr
+			}
+		}
+	}
+	return firstErr
+}
+
+// AddRosettaLog implements the RosettaTracer interface, forwarding the log
+// to the tracers that record rosetta operations.
+func (t *MultiTracer) AddRosettaLog(op OpCode, from, to *RosettaLogAddressItem, val *big.Int) {
+	for _, tracer := range t.tracers {
+		if rt, ok := tracer.(RosettaTracer); ok {
+			rt.AddRosettaLog(op, from, to, val)
+		}
+	}
+}
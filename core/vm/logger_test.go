@@ -68,3 +68,40 @@ func TestStoreCapture(t *testing.T) {
 		t.Errorf("expected %x, got %x", exp, logger.changedValues[contract.Address()][index])
 	}
 }
+
+func TestMultiTracerCapture(t *testing.T) {
+	var (
+		env      = NewEVM(Context{}, &dummyStatedb{}, params.TestChainConfig, Config{})
+		first    = NewStructLogger(nil)
+		second   = NewStructLogger(&LogConfig{DisableStack: true})
+		mem      = NewMemory()
+		stack    = newstack()
+		contract = NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 0)
+	)
+	cfg := Config{}
+	cfg.AddTracer(first)
+	if cfg.Tracer != first || !cfg.Debug {
+		t.Fatalf("expected single tracer to be set directly, got %T", cfg.Tracer)
+	}
+	cfg.AddTracer(second)
+	multi, ok := cfg.Tracer.(*MultiTracer)
+	if !ok || len(multi.Tracers()) != 2 {
+		t.Fatalf("expected multi tracer with 2 tracers, got %T", cfg.Tracer)
+	}
+
+	stack.push(big.NewInt(1))
+	hook, err := cfg.Tracer.CaptureState(env, 0, POP, 0, 0, mem, stack, contract, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stack.pop()
+	hook(mem, stack)
+	for i, logger := range []*StructLogger{first, second} {
+		if len(logger.StructLogs()) != 1 {
+			t.Fatalf("tracer %d: expected 1 log, got %d", i, len(logger.StructLogs()))
+		}
+	}
+	if first.StructLogs()[0].AfterStack == nil {
+		t.Errorf("after hook of the first tracer not invoked")
+	}
+}
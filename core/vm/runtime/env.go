@@ -31,6 +31,8 @@ func NewEnv(cfg *Config) *vm.EVM {
 		GetHash:     func(uint64) common.Hash { return common.Hash{} },
 		GetVRF:      func(uint64) common.Hash { return common.Hash{} },
 
+		GetCrossLink: func(uint32) (uint64, common.Hash) { return 0, common.Hash{} },
+
 		Origin:      cfg.Origin,
 		Coinbase:    cfg.Coinbase,
 		BlockNumber: cfg.BlockNumber,
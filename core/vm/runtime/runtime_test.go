@@ -118,6 +118,60 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestTransientStorage(t *testing.T) {
+	code := []byte{
+		byte(vm.PUSH1), 7,
+		byte(vm.PUSH1), 0,
+		byte(vm.TSTORE),
+		byte(vm.PUSH1), 0,
+		byte(vm.TLOAD),
+		byte(vm.PUSH1), 0,
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH1), 0,
+		byte(vm.RETURN),
+	}
+
+	ret, _, err := Execute(code, nil, &Config{ChainConfig: params.TestChainConfig})
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	num := new(big.Int).SetBytes(ret)
+	if num.Cmp(big.NewInt(7)) != 0 {
+		t.Error("Expected 7, got", num)
+	}
+
+	// the opcodes are invalid before the transient storage epoch
+	if _, _, err := Execute(code, nil, nil); err == nil {
+		t.Error("expected invalid opcode error before the transient storage epoch")
+	}
+}
+
+func TestPush0(t *testing.T) {
+	code := []byte{
+		byte(vm.PUSH1), 1,
+		byte(vm.PUSH0),
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 32,
+		byte(vm.PUSH0),
+		byte(vm.RETURN),
+	}
+
+	ret, _, err := Execute(code, nil, &Config{ChainConfig: params.TestChainConfig})
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	num := new(big.Int).SetBytes(ret)
+	if num.Cmp(big.NewInt(1)) != 0 {
+		t.Error("Expected 1, got", num)
+	}
+
+	// the opcode is invalid before the PUSH0 epoch
+	if _, _, err := Execute(code, nil, nil); err == nil {
+		t.Error("expected invalid opcode error before the PUSH0 epoch")
+	}
+}
+
 func BenchmarkCall(b *testing.B) {
 	var definition = `[{"constant":true,"inputs":[],"name":"seller","outputs":[{"name":"","type":"address"}],"type":"function"},{"constant":false,"inputs":[],"name":"abort","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"value","outputs":[{"name":"","type":"uint256"}],"type":"function"},{"constant":false,"inputs":[],"name":"refund","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"buyer","outputs":[{"name":"","type":"address"}],"type":"function"},{"constant":false,"inputs":[],"name":"confirmReceived","outputs":[],"type":"function"},{"constant":true,"inputs":[],"name":"state","outputs":[{"name":"","type":"uint8"}],"type":"function"},{"constant":false,"inputs":[],"name":"confirmPurchase","outputs":[],"type":"function"},{"inputs":[],"type":"constructor"},{"anonymous":false,"inputs":[],"name":"Aborted","type":"event"},{"anonymous":false,"inputs":[],"name":"PurchaseConfirmed","type":"event"},{"anonymous":false,"inputs":[],"name":"ItemReceived","type":"event"},{"anonymous":false,"inputs":[],"name":"Refunded","type":"event"}]`
 
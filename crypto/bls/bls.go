@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"math/big"
+	"unsafe"
 
 	"github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/pkg/errors"
@@ -111,3 +112,16 @@ func SeparateSigAndMask(commitSigs []byte) ([]byte, []byte, error) {
 	//#### END Read payload data from committed msg
 	return aggSig, bitmap, nil
 }
+
+// PublicKeyPoint returns the G1 point of a bls public key. The library is built
+// with BLS_SWAP_G, so public keys are G1 points and signatures G2 points.
+func PublicKeyPoint(key *bls.PublicKey) *bls.G1 {
+	// #nosec
+	return (*bls.G1)(unsafe.Pointer(key))
+}
+
+// SignaturePoint returns the G2 point of a bls signature.
+func SignaturePoint(sig *bls.Sign) *bls.G2 {
+	// #nosec
+	return (*bls.G2)(unsafe.Pointer(sig))
+}
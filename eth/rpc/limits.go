@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// ServerLimits bounds the work clients can request from a server.
+type ServerLimits struct {
+	// BatchRequestLimit is the maximum number of requests in a batch, 0 for no limit
+	BatchRequestLimit int
+	// MethodRateLimits is the number of requests per second allowed for a method,
+	// keyed by full method name, e.g. trace_block. It is shared by all clients.
+	MethodRateLimits map[string]int
+}
+
+// requestLimiter enforces the ServerLimits of a server. A nil limiter allows everything.
+type requestLimiter struct {
+	batchLimit int
+
+	methods     map[string]*rate.Limiter
+	methodsLock sync.RWMutex
+}
+
+func newRequestLimiter(limits ServerLimits) *requestLimiter {
+	l := &requestLimiter{batchLimit: limits.BatchRequestLimit}
+	l.setMethodRateLimits(limits.MethodRateLimits)
+	return l
+}
+
+// setMethodRateLimits replaces the per method rate limits
+func (l *requestLimiter) setMethodRateLimits(rateLimits map[string]int) {
+	methods := make(map[string]*rate.Limiter, len(rateLimits))
+	for method, rps := range rateLimits {
+		if rps > 0 {
+			methods[method] = rate.NewLimiter(rate.Limit(rps), rps)
+		}
+	}
+	l.methodsLock.Lock()
+	l.methods = methods
+	l.methodsLock.Unlock()
+}
+
+// checkBatch returns an error if a batch of the given size exceeds the batch limit.
+func (l *requestLimiter) checkBatch(size int) error {
+	if l == nil || l.batchLimit <= 0 || size <= l.batchLimit {
+		return nil
+	}
+	doMetricRejectedBatch()
+	return &invalidRequestError{
+		fmt.Sprintf("batch of %d requests exceeds the limit of %d", size, l.batchLimit),
+	}
+}
+
+// allow takes a token from the rate limiter of the given method, if any, and
+// reports whether the call may proceed.
+func (l *requestLimiter) allow(method string) bool {
+	if l == nil {
+		return true
+	}
+	l.methodsLock.RLock()
+	limiter, ok := l.methods[method]
+	l.methodsLock.RUnlock()
+	if !ok || limiter.Allow() {
+		return true
+	}
+	doMetricRateLimitedRequest(method)
+	return false
+}
+
+// SetLimits sets the batch and per method rate limits of the server. It must be
+// called before the server starts serving requests.
+func (s *Server) SetLimits(limits ServerLimits) {
+	s.services.limiter = newRequestLimiter(limits)
+}
+
+// SetMethodRateLimits replaces the per method rate limits of the server. Unlike
+// SetLimits, it may be called while the server is serving requests. It has no
+// effect if the limits of the server were never set.
+func (s *Server) SetMethodRateLimits(rateLimits map[string]int) {
+	if s.services.limiter != nil {
+		s.services.limiter.setMethodRateLimits(rateLimits)
+	}
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postJSON(t *testing.T, url, body string) string {
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(respBody)
+}
+
+func TestServerBatchLimit(t *testing.T) {
+	server := newTestServer()
+	server.SetLimits(ServerLimits{BatchRequestLimit: 2})
+	defer server.Stop()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	call := `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+	if resp := postJSON(t, ts.URL, "["+call+","+call+"]"); strings.Contains(resp, "error") {
+		t.Errorf("batch within the limit failed: %s", resp)
+	}
+	resp := postJSON(t, ts.URL, "["+call+","+call+","+call+"]")
+	if !strings.Contains(resp, "exceeds the limit of 2") {
+		t.Errorf("batch over the limit not rejected: %s", resp)
+	}
+}
+
+func TestServerMethodRateLimit(t *testing.T) {
+	server := newTestServer()
+	server.SetLimits(ServerLimits{MethodRateLimits: map[string]int{"test_echo": 1}})
+	defer server.Stop()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	limited := `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+	if resp := postJSON(t, ts.URL, limited); strings.Contains(resp, "error") {
+		t.Errorf("first call failed: %s", resp)
+	}
+	if resp := postJSON(t, ts.URL, limited); !strings.Contains(resp, "rate limit exceeded for method test_echo") {
+		t.Errorf("second call not rate limited: %s", resp)
+	}
+	other := `{"jsonrpc":"2.0","id":1,"method":"test_rets","params":[]}`
+	if resp := postJSON(t, ts.URL, other); strings.Contains(resp, "error") {
+		t.Errorf("call of a method without limit failed: %s", resp)
+	}
+}
+
+func TestServerSetMethodRateLimits(t *testing.T) {
+	server := newTestServer()
+	server.SetLimits(ServerLimits{MethodRateLimits: map[string]int{"test_echo": 1}})
+	defer server.Stop()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	call := `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+	postJSON(t, ts.URL, call)
+	if resp := postJSON(t, ts.URL, call); !strings.Contains(resp, "rate limit exceeded") {
+		t.Errorf("second call not rate limited: %s", resp)
+	}
+	server.SetMethodRateLimits(nil)
+	if resp := postJSON(t, ts.URL, call); strings.Contains(resp, "error") {
+		t.Errorf("call failed after the limit is removed: %s", resp)
+	}
+	server.SetMethodRateLimits(map[string]int{"test_rets": 1})
+	other := `{"jsonrpc":"2.0","id":1,"method":"test_rets","params":[]}`
+	postJSON(t, ts.URL, other)
+	if resp := postJSON(t, ts.URL, other); !strings.Contains(resp, "rate limit exceeded") {
+		t.Errorf("call not rate limited after the limit is set: %s", resp)
+	}
+}
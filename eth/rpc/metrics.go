@@ -10,6 +10,9 @@ func init() {
 		requestCounterVec,
 		requestErroredCounterVec,
 		requestDurationHistVec,
+		requestInFlightGaugeVec,
+		rateLimitedCounterVec,
+		rejectedBatchCounter,
 	)
 }
 
@@ -34,14 +37,44 @@ var (
 		[]string{"method"},
 	)
 
+	rateLimitedCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "rpc2",
+			Name:      "rate_limited_count",
+			Help:      "counters of RPC calls rejected by the rate limit of their method",
+		},
+		[]string{"method"},
+	)
+
+	rejectedBatchCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "rpc2",
+			Name:      "rejected_batch_count",
+			Help:      "number of RPC batches rejected for exceeding the batch limit",
+		},
+	)
+
 	requestDurationHistVec = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "hmy",
 			Subsystem: "rpc2",
 			Name:      "delay_histogram",
 			Help:      "delays histogram in seconds",
-			// buckets: 50ms, 100ms, 200ms, 400ms, 800ms, 1600ms, 3200ms, +INF
-			Buckets: prometheus.ExponentialBuckets(0.05, 2, 8),
+			// buckets: 50ms, 100ms, 200ms, 400ms, 800ms, 1600ms, 3200ms, 6.4s, 12.8s,
+			// 25.6s, 51.2s, 102.4s, +INF. The long buckets are for the trace methods.
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{"method"},
+	)
+
+	requestInFlightGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "hmy",
+			Subsystem: "rpc2",
+			Name:      "in_flight",
+			Help:      "number of RPC requests being executed for each method",
 		},
 		[]string{"method"},
 	)
@@ -52,7 +85,11 @@ func doMetricRequest(method string) *prometheus.Timer {
 		"method": method,
 	}
 	requestCounterVec.With(pLabel).Inc()
-	timer := prometheus.NewTimer(requestDurationHistVec.With(pLabel))
+	requestInFlightGaugeVec.With(pLabel).Inc()
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
+		requestInFlightGaugeVec.With(pLabel).Dec()
+		requestDurationHistVec.With(pLabel).Observe(v)
+	}))
 	return timer
 }
 
@@ -63,6 +100,17 @@ func doMetricErroredRequest(method string) {
 	requestErroredCounterVec.With(pLabel).Inc()
 }
 
+func doMetricRateLimitedRequest(method string) {
+	pLabel := prometheus.Labels{
+		"method": method,
+	}
+	rateLimitedCounterVec.With(pLabel).Inc()
+}
+
+func doMetricRejectedBatch() {
+	rejectedBatchCounter.Inc()
+}
+
 func doMetricDelayHist(timer *prometheus.Timer) {
 	timer.ObserveDuration()
 }
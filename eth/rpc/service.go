@@ -39,6 +39,7 @@ var (
 type serviceRegistry struct {
 	mu       sync.Mutex
 	services map[string]service
+	limiter  *requestLimiter // limits enforced on incoming calls, nil for none
 }
 
 // service represents a registered object.
@@ -122,3 +122,34 @@ func TestBlockNumberOrHash_UnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestDecimalOrHexUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		mustFail bool
+		expected DecimalOrHex
+	}{
+		0: {`4`, false, DecimalOrHex(4)},
+		1: {`"4"`, false, DecimalOrHex(4)},
+		2: {`"0x10"`, false, DecimalOrHex(16)},
+		3: {`"-1"`, true, DecimalOrHex(0)},
+		4: {`"0x"`, true, DecimalOrHex(0)},
+		5: {`"latest"`, true, DecimalOrHex(0)},
+	}
+
+	for i, test := range tests {
+		var num DecimalOrHex
+		err := json.Unmarshal([]byte(test.input), &num)
+		if test.mustFail && err == nil {
+			t.Errorf("Test %d should fail", i)
+			continue
+		}
+		if !test.mustFail && err != nil {
+			t.Errorf("Test %d should pass but got err: %v", i, err)
+			continue
+		}
+		if num != test.expected {
+			t.Errorf("Test %d got unexpected value, want %d, got %d", i, test.expected, num)
+		}
+	}
+}
@@ -0,0 +1,1389 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/rpc/filters"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
+	"github.com/harmony-one/harmony/shard"
+	staking "github.com/harmony-one/harmony/staking/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxBlocksPerQuery is the maximum number of blocks returned by a single
+	// blocks query, since every block may fan out to its transactions and traces
+	maxBlocksPerQuery = 128
+	// traceReexec is the number of blocks re-executed to regenerate the state
+	// a traced transaction runs on
+	traceReexec = uint64(128)
+)
+
+var (
+	parityTracer = "ParityBlockTracer"
+
+	errBlockInvariant = errors.New("block objects must be instantiated with at least one of number or hash")
+)
+
+// Account represents an account at a specific block.
+type Account struct {
+	hmy           *hmy.Harmony
+	address       common.Address
+	blockNrOrHash rpc.BlockNumberOrHash
+}
+
+// getState fetches the state of the block the account is bound to.
+func (a *Account) getState(ctx context.Context) (*state.DB, error) {
+	stateDb, _, err := a.hmy.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if stateDb == nil {
+		return nil, errors.New("block not found")
+	}
+	return stateDb, nil
+}
+
+// Address ..
+func (a *Account) Address(ctx context.Context) (common.Address, error) {
+	return a.address, nil
+}
+
+// Balance ..
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	stateDb, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*stateDb.GetBalance(a.address)), nil
+}
+
+// TransactionCount ..
+func (a *Account) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	stateDb, err := a.getState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(stateDb.GetNonce(a.address)), nil
+}
+
+// Code ..
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	stateDb, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(stateDb.GetCode(a.address)), nil
+}
+
+// Storage ..
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	stateDb, err := a.getState(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return stateDb.GetState(a.address, args.Slot), nil
+}
+
+// BlockNumberArgs are the arguments of the fields binding an account to a block.
+type BlockNumberArgs struct {
+	Block *hexutil.Uint64
+}
+
+// NumberOr returns the requested block, or the given default if none was requested.
+func (a BlockNumberArgs) NumberOr(current rpc.BlockNumberOrHash) rpc.BlockNumberOrHash {
+	if a.Block != nil {
+		return rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(*a.Block))
+	}
+	return current
+}
+
+// Log represents an individual log message.
+type Log struct {
+	hmy         *hmy.Harmony
+	transaction *Transaction
+	log         *types.Log
+}
+
+// Transaction ..
+func (l *Log) Transaction(ctx context.Context) *Transaction {
+	return l.transaction
+}
+
+// Account ..
+func (l *Log) Account(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{
+		hmy:           l.hmy,
+		address:       l.log.Address,
+		blockNrOrHash: args.NumberOr(rpc.BlockNumberOrHashWithHash(l.log.BlockHash, false)),
+	}
+}
+
+// Index ..
+func (l *Log) Index(ctx context.Context) int32 {
+	return int32(l.log.Index)
+}
+
+// Topics ..
+func (l *Log) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+// Data ..
+func (l *Log) Data(ctx context.Context) hexutil.Bytes {
+	return l.log.Data
+}
+
+// parityFrame is a call frame in the output format of the parity style block tracer.
+type parityFrame struct {
+	Type         string  `json:"type"`
+	TraceAddress []int32 `json:"traceAddress"`
+	Subtraces    int32   `json:"subtraces"`
+	Error        *string `json:"error"`
+	Action       struct {
+		CallType      string          `json:"callType"`
+		From          *common.Address `json:"from"`
+		To            *common.Address `json:"to"`
+		Value         *hexutil.Big    `json:"value"`
+		Gas           *hexutil.Uint64 `json:"gas"`
+		Input         *hexutil.Bytes  `json:"input"`
+		Init          *hexutil.Bytes  `json:"init"`
+		Address       *common.Address `json:"address"`
+		RefundAddress *common.Address `json:"refundAddress"`
+		Balance       *hexutil.Big    `json:"balance"`
+	} `json:"action"`
+	Result *struct {
+		GasUsed *hexutil.Uint64 `json:"gasUsed"`
+		Output  *hexutil.Bytes  `json:"output"`
+		Address *common.Address `json:"address"`
+		Code    *hexutil.Bytes  `json:"code"`
+	} `json:"result"`
+}
+
+// Trace represents a single call frame of a transaction.
+type Trace struct {
+	frame parityFrame
+}
+
+// Type ..
+func (t *Trace) Type(ctx context.Context) string {
+	return t.frame.Type
+}
+
+// CallType ..
+func (t *Trace) CallType(ctx context.Context) *string {
+	if t.frame.Action.CallType == "" {
+		return nil
+	}
+	return &t.frame.Action.CallType
+}
+
+// TraceAddress ..
+func (t *Trace) TraceAddress(ctx context.Context) []int32 {
+	if t.frame.TraceAddress == nil {
+		return []int32{}
+	}
+	return t.frame.TraceAddress
+}
+
+// Subtraces ..
+func (t *Trace) Subtraces(ctx context.Context) int32 {
+	return t.frame.Subtraces
+}
+
+// From ..
+func (t *Trace) From(ctx context.Context) *common.Address {
+	if t.frame.Type == "suicide" {
+		return t.frame.Action.Address
+	}
+	return t.frame.Action.From
+}
+
+// To ..
+func (t *Trace) To(ctx context.Context) *common.Address {
+	switch {
+	case t.frame.Type == "suicide":
+		return t.frame.Action.RefundAddress
+	case t.frame.Type == "create" && t.frame.Result != nil:
+		return t.frame.Result.Address
+	}
+	return t.frame.Action.To
+}
+
+// Value ..
+func (t *Trace) Value(ctx context.Context) *hexutil.Big {
+	if t.frame.Type == "suicide" {
+		return t.frame.Action.Balance
+	}
+	return t.frame.Action.Value
+}
+
+// Gas ..
+func (t *Trace) Gas(ctx context.Context) *hexutil.Uint64 {
+	return t.frame.Action.Gas
+}
+
+// GasUsed ..
+func (t *Trace) GasUsed(ctx context.Context) *hexutil.Uint64 {
+	if t.frame.Result == nil {
+		return nil
+	}
+	return t.frame.Result.GasUsed
+}
+
+// Input ..
+func (t *Trace) Input(ctx context.Context) *hexutil.Bytes {
+	if t.frame.Action.Init != nil {
+		return t.frame.Action.Init
+	}
+	return t.frame.Action.Input
+}
+
+// Output ..
+func (t *Trace) Output(ctx context.Context) *hexutil.Bytes {
+	if t.frame.Result == nil {
+		return nil
+	}
+	if t.frame.Result.Code != nil {
+		return t.frame.Result.Code
+	}
+	return t.frame.Result.Output
+}
+
+// Error ..
+func (t *Trace) Error(ctx context.Context) *string {
+	return t.frame.Error
+}
+
+// CrossShardReceipt represents the receipt of a cross-shard transfer.
+type CrossShardReceipt struct {
+	cx *types.CXReceipt
+}
+
+// TransactionHash ..
+func (c *CrossShardReceipt) TransactionHash(ctx context.Context) common.Hash {
+	return c.cx.TxHash
+}
+
+// From ..
+func (c *CrossShardReceipt) From(ctx context.Context) common.Address {
+	return c.cx.From
+}
+
+// To ..
+func (c *CrossShardReceipt) To(ctx context.Context) *common.Address {
+	return c.cx.To
+}
+
+// ShardID ..
+func (c *CrossShardReceipt) ShardID(ctx context.Context) int32 {
+	return int32(c.cx.ShardID)
+}
+
+// ToShardID ..
+func (c *CrossShardReceipt) ToShardID(ctx context.Context) int32 {
+	return int32(c.cx.ToShardID)
+}
+
+// Amount ..
+func (c *CrossShardReceipt) Amount(ctx context.Context) hexutil.Big {
+	if c.cx.Amount == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*c.cx.Amount)
+}
+
+// Transaction represents a plain transaction.
+type Transaction struct {
+	hmy   *hmy.Harmony
+	hash  common.Hash
+	tx    *types.Transaction
+	block *Block
+	index uint64
+}
+
+// resolve returns the internal transaction object, fetching it if needed.
+func (t *Transaction) resolve(ctx context.Context) (*types.Transaction, error) {
+	if t.tx != nil {
+		return t.tx, nil
+	}
+	tx, blockHash, _, index := rawdb.ReadTransaction(t.hmy.ChainDb(), t.hash)
+	if tx != nil {
+		t.tx = tx
+		blockNrOrHash := rpc.BlockNumberOrHashWithHash(blockHash, false)
+		t.block = &Block{
+			hmy:          t.hmy,
+			numberOrHash: &blockNrOrHash,
+		}
+		t.index = index
+		return t.tx, nil
+	}
+	if tx, ok := t.hmy.GetPoolTransaction(t.hash).(*types.Transaction); ok {
+		t.tx = tx
+	}
+	return t.tx, nil
+}
+
+// blockNrOrHash returns the block the transaction was included in, or the
+// latest block for pending transactions.
+func (t *Transaction) blockNrOrHash() rpc.BlockNumberOrHash {
+	if t.block != nil {
+		return *t.block.numberOrHash
+	}
+	return rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+}
+
+// getReceipt returns the receipt of the transaction, nil if it is pending.
+func (t *Transaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	receipts, err := t.block.resolveReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t.index >= uint64(len(receipts)) {
+		return nil, nil
+	}
+	return receipts[t.index], nil
+}
+
+// Hash ..
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+// Nonce ..
+func (t *Transaction) Nonce(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce()), nil
+}
+
+// Index ..
+func (t *Transaction) Index(ctx context.Context) (*int32, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	index := int32(t.index)
+	return &index, nil
+}
+
+// From ..
+func (t *Transaction) From(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	from, err := tx.SenderAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		hmy:           t.hmy,
+		address:       from,
+		blockNrOrHash: args.NumberOr(t.blockNrOrHash()),
+	}, nil
+}
+
+// To ..
+func (t *Transaction) To(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.To() == nil {
+		return nil, err
+	}
+	return &Account{
+		hmy:           t.hmy,
+		address:       *tx.To(),
+		blockNrOrHash: args.NumberOr(t.blockNrOrHash()),
+	}, nil
+}
+
+// Value ..
+func (t *Transaction) Value(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tx.Value()), nil
+}
+
+// GasPrice ..
+func (t *Transaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tx.GasPrice()), nil
+}
+
+// Gas ..
+func (t *Transaction) Gas(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.GasLimit()), nil
+}
+
+// InputData ..
+func (t *Transaction) InputData(ctx context.Context) (hexutil.Bytes, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return tx.Data(), nil
+}
+
+// Block ..
+func (t *Transaction) Block(ctx context.Context) (*Block, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+// ShardID ..
+func (t *Transaction) ShardID(ctx context.Context) (int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return int32(tx.ShardID()), nil
+}
+
+// ToShardID ..
+func (t *Transaction) ToShardID(ctx context.Context) (int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return int32(tx.ToShardID()), nil
+}
+
+// Status ..
+func (t *Transaction) Status(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	status := hexutil.Uint64(receipt.Status)
+	return &status, nil
+}
+
+// GasUsed ..
+func (t *Transaction) GasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	gasUsed := hexutil.Uint64(receipt.GasUsed)
+	return &gasUsed, nil
+}
+
+// CumulativeGasUsed ..
+func (t *Transaction) CumulativeGasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	gasUsed := hexutil.Uint64(receipt.CumulativeGasUsed)
+	return &gasUsed, nil
+}
+
+// CreatedContract ..
+func (t *Transaction) CreatedContract(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil || receipt.ContractAddress == (common.Address{}) {
+		return nil, err
+	}
+	return &Account{
+		hmy:           t.hmy,
+		address:       receipt.ContractAddress,
+		blockNrOrHash: args.NumberOr(t.blockNrOrHash()),
+	}, nil
+}
+
+// Logs ..
+func (t *Transaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{
+			hmy:         t.hmy,
+			transaction: t,
+			log:         log,
+		})
+	}
+	return &ret, nil
+}
+
+// Traces re-executes the transaction with the parity style tracer.
+func (t *Transaction) Traces(ctx context.Context) (*[]*Trace, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	block, err := t.block.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ctx, release, err := t.hmy.StartTrace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	msg, vmctx, statedb, err := t.hmy.ComputeTxEnv(block, int(t.index), traceReexec)
+	if err != nil {
+		return nil, err
+	}
+	result, err := t.hmy.TraceTx(ctx, msg, vmctx, statedb, &hmy.TraceConfig{Tracer: &parityTracer})
+	if err != nil {
+		return nil, err
+	}
+	frames, ok := result.([]json.RawMessage)
+	if !ok {
+		return nil, errors.New("tracer bug: expected []json.RawMessage")
+	}
+	traces := make([]*Trace, 0, len(frames))
+	for _, frame := range frames {
+		trace := &Trace{}
+		if err := json.Unmarshal(frame, &trace.frame); err != nil {
+			return nil, errors.Wrap(err, "malformed trace")
+		}
+		traces = append(traces, trace)
+	}
+	return &traces, nil
+}
+
+// CrossShardReceipt ..
+func (t *Transaction) CrossShardReceipt(ctx context.Context) (*CrossShardReceipt, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || tx.ShardID() == tx.ToShardID() {
+		return nil, err
+	}
+	cx, _, _, _ := rawdb.ReadCXReceipt(t.hmy.ChainDb(), t.hash)
+	if cx == nil {
+		return nil, nil
+	}
+	return &CrossShardReceipt{cx}, nil
+}
+
+// StakingTransaction represents a staking transaction.
+type StakingTransaction struct {
+	hmy   *hmy.Harmony
+	hash  common.Hash
+	tx    *staking.StakingTransaction
+	block *Block
+	index uint64
+}
+
+// resolve returns the internal staking transaction object, fetching it if needed.
+func (t *StakingTransaction) resolve(ctx context.Context) (*staking.StakingTransaction, error) {
+	if t.tx != nil {
+		return t.tx, nil
+	}
+	tx, blockHash, _, index := rawdb.ReadStakingTransaction(t.hmy.ChainDb(), t.hash)
+	if tx != nil {
+		t.tx = tx
+		blockNrOrHash := rpc.BlockNumberOrHashWithHash(blockHash, false)
+		t.block = &Block{
+			hmy:          t.hmy,
+			numberOrHash: &blockNrOrHash,
+		}
+		t.index = index
+	}
+	return t.tx, nil
+}
+
+// getReceipt returns the receipt of the staking transaction, which follows the
+// receipts of the plain transactions of the block.
+func (t *StakingTransaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	if _, err := t.resolve(ctx); err != nil || t.block == nil {
+		return nil, err
+	}
+	block, err := t.block.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	receipts, err := t.block.resolveReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := uint64(len(block.Transactions())) + t.index
+	if index >= uint64(len(receipts)) {
+		return nil, nil
+	}
+	return receipts[index], nil
+}
+
+// Hash ..
+func (t *StakingTransaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+// Nonce ..
+func (t *StakingTransaction) Nonce(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce()), nil
+}
+
+// Index ..
+func (t *StakingTransaction) Index(ctx context.Context) (*int32, error) {
+	if _, err := t.resolve(ctx); err != nil || t.block == nil {
+		return nil, err
+	}
+	index := int32(t.index)
+	return &index, nil
+}
+
+// From ..
+func (t *StakingTransaction) From(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	from, err := tx.SenderAddress()
+	if err != nil {
+		return nil, err
+	}
+	current := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if t.block != nil {
+		current = *t.block.numberOrHash
+	}
+	return &Account{
+		hmy:           t.hmy,
+		address:       from,
+		blockNrOrHash: args.NumberOr(current),
+	}, nil
+}
+
+// Type ..
+func (t *StakingTransaction) Type(ctx context.Context) (string, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return "", err
+	}
+	return tx.StakingType().String(), nil
+}
+
+// Msg returns the directive in the JSON format of the hmyv2 RPC namespace.
+func (t *StakingTransaction) Msg(ctx context.Context) (string, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return "", err
+	}
+	rpcTx, err := v2.NewStakingTransaction(tx, common.Hash{}, 0, 0, 0, true)
+	if err != nil {
+		return "", err
+	}
+	msg, err := json.Marshal(rpcTx.Msg)
+	if err != nil {
+		return "", err
+	}
+	return string(msg), nil
+}
+
+// GasPrice ..
+func (t *StakingTransaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tx.GasPrice()), nil
+}
+
+// Gas ..
+func (t *StakingTransaction) Gas(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.GasLimit()), nil
+}
+
+// Block ..
+func (t *StakingTransaction) Block(ctx context.Context) (*Block, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+// Status ..
+func (t *StakingTransaction) Status(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	status := hexutil.Uint64(receipt.Status)
+	return &status, nil
+}
+
+// GasUsed ..
+func (t *StakingTransaction) GasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	gasUsed := hexutil.Uint64(receipt.GasUsed)
+	return &gasUsed, nil
+}
+
+// Logs ..
+func (t *StakingTransaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{
+			hmy:         t.hmy,
+			transaction: &Transaction{hmy: t.hmy, hash: log.TxHash},
+			log:         log,
+		})
+	}
+	return &ret, nil
+}
+
+// Delegation represents a delegation to a validator.
+type Delegation struct {
+	delegation staking.Delegation
+}
+
+// Delegator ..
+func (d *Delegation) Delegator(ctx context.Context) common.Address {
+	return d.delegation.DelegatorAddress
+}
+
+// Amount ..
+func (d *Delegation) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*d.delegation.Amount)
+}
+
+// Reward ..
+func (d *Delegation) Reward(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*d.delegation.Reward)
+}
+
+// Validator represents a validator of the beacon chain state.
+type Validator struct {
+	info *staking.ValidatorRPCEnhanced
+}
+
+// Address ..
+func (v *Validator) Address(ctx context.Context) common.Address {
+	return v.info.Wrapper.Address
+}
+
+// Name ..
+func (v *Validator) Name(ctx context.Context) string {
+	return v.info.Wrapper.Name
+}
+
+// TotalDelegation ..
+func (v *Validator) TotalDelegation(ctx context.Context) hexutil.Big {
+	if v.info.TotalDelegated == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*v.info.TotalDelegated)
+}
+
+// CurrentlyInCommittee ..
+func (v *Validator) CurrentlyInCommittee(ctx context.Context) bool {
+	return v.info.CurrentlyInCommittee
+}
+
+// EposStatus ..
+func (v *Validator) EposStatus(ctx context.Context) string {
+	return v.info.EPoSStatus
+}
+
+// ActiveStatus ..
+func (v *Validator) ActiveStatus(ctx context.Context) string {
+	return v.info.ActiveStatus
+}
+
+// Delegations ..
+func (v *Validator) Delegations(ctx context.Context) []*Delegation {
+	ret := make([]*Delegation, 0, len(v.info.Wrapper.Delegations))
+	for _, delegation := range v.info.Wrapper.Delegations {
+		ret = append(ret, &Delegation{delegation})
+	}
+	return ret
+}
+
+// Block represents a block of the shard. It is resolved lazily from its number
+// or hash, so that nested queries only load what they use.
+type Block struct {
+	hmy          *hmy.Harmony
+	numberOrHash *rpc.BlockNumberOrHash
+	block        *types.Block
+	receipts     types.Receipts
+}
+
+// resolve returns the internal block object, fetching it if needed.
+func (b *Block) resolve(ctx context.Context) (*types.Block, error) {
+	if b.block != nil {
+		return b.block, nil
+	}
+	if b.numberOrHash == nil {
+		return nil, errBlockInvariant
+	}
+	block, err := b.hmy.BlockByNumberOrHash(ctx, *b.numberOrHash)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	b.block = block
+	// Bind the block to its hash, so that accounts and children resolve
+	// against this very block even if the canonical chain moves on.
+	blockNrOrHash := rpc.BlockNumberOrHashWithHash(block.Hash(), false)
+	b.numberOrHash = &blockNrOrHash
+	return b.block, nil
+}
+
+// resolveReceipts returns the receipts of the block, fetching them if needed.
+func (b *Block) resolveReceipts(ctx context.Context) (types.Receipts, error) {
+	if b.receipts != nil {
+		return b.receipts, nil
+	}
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	receipts, err := b.hmy.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	b.receipts = receipts
+	return receipts, nil
+}
+
+// Number ..
+func (b *Block) Number(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.NumberU64()), nil
+}
+
+// Hash ..
+func (b *Block) Hash(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Hash(), nil
+}
+
+// Parent ..
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil || block.NumberU64() == 0 {
+		return nil, err
+	}
+	blockNrOrHash := rpc.BlockNumberOrHashWithHash(block.ParentHash(), false)
+	return &Block{
+		hmy:          b.hmy,
+		numberOrHash: &blockNrOrHash,
+	}, nil
+}
+
+// ShardID ..
+func (b *Block) ShardID(ctx context.Context) (int32, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return int32(block.ShardID()), nil
+}
+
+// Epoch ..
+func (b *Block) Epoch(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Epoch().Uint64()), nil
+}
+
+// ViewID ..
+func (b *Block) ViewID(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Header().ViewID().Uint64()), nil
+}
+
+// TransactionsRoot ..
+func (b *Block) TransactionsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Header().TxHash(), nil
+}
+
+// TransactionCount ..
+func (b *Block) TransactionCount(ctx context.Context) (*int32, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	count := int32(len(block.Transactions()))
+	return &count, nil
+}
+
+// StateRoot ..
+func (b *Block) StateRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Root(), nil
+}
+
+// ReceiptsRoot ..
+func (b *Block) ReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Header().ReceiptHash(), nil
+}
+
+// OutgoingReceiptsRoot ..
+func (b *Block) OutgoingReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Header().OutgoingReceiptHash(), nil
+}
+
+// IncomingReceiptsRoot ..
+func (b *Block) IncomingReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Header().IncomingReceiptHash(), nil
+}
+
+// Leader ..
+func (b *Block) Leader(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return &Account{
+		hmy:           b.hmy,
+		address:       block.Header().Coinbase(),
+		blockNrOrHash: args.NumberOr(*b.numberOrHash),
+	}, nil
+}
+
+// ExtraData ..
+func (b *Block) ExtraData(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(block.Header().Extra()), nil
+}
+
+// GasLimit ..
+func (b *Block) GasLimit(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.GasLimit()), nil
+}
+
+// GasUsed ..
+func (b *Block) GasUsed(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.GasUsed()), nil
+}
+
+// BaseFeePerGas ..
+func (b *Block) BaseFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil || block.Header().BaseFee() == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(block.Header().BaseFee()), nil
+}
+
+// Timestamp ..
+func (b *Block) Timestamp(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Time().Uint64()), nil
+}
+
+// LogsBloom ..
+func (b *Block) LogsBloom(ctx context.Context) (hexutil.Bytes, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(block.Bloom().Bytes()), nil
+}
+
+// Transactions ..
+func (b *Block) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		ret = append(ret, &Transaction{
+			hmy:   b.hmy,
+			hash:  tx.Hash(),
+			tx:    tx,
+			block: b,
+			index: uint64(i),
+		})
+	}
+	return &ret, nil
+}
+
+// TransactionAt ..
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index int32 }) (*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if args.Index < 0 || int(args.Index) >= len(txs) {
+		return nil, nil
+	}
+	tx := txs[args.Index]
+	return &Transaction{
+		hmy:   b.hmy,
+		hash:  tx.Hash(),
+		tx:    tx,
+		block: b,
+		index: uint64(args.Index),
+	}, nil
+}
+
+// StakingTransactionCount ..
+func (b *Block) StakingTransactionCount(ctx context.Context) (*int32, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	count := int32(len(block.StakingTransactions()))
+	return &count, nil
+}
+
+// StakingTransactions ..
+func (b *Block) StakingTransactions(ctx context.Context) (*[]*StakingTransaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ret := make([]*StakingTransaction, 0, len(block.StakingTransactions()))
+	for i, tx := range block.StakingTransactions() {
+		ret = append(ret, &StakingTransaction{
+			hmy:   b.hmy,
+			hash:  tx.Hash(),
+			tx:    tx,
+			block: b,
+			index: uint64(i),
+		})
+	}
+	return &ret, nil
+}
+
+// IncomingReceipts ..
+func (b *Block) IncomingReceipts(ctx context.Context) ([]*CrossShardReceipt, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ret := []*CrossShardReceipt{}
+	for _, proof := range block.IncomingReceipts() {
+		for _, cx := range proof.Receipts {
+			ret = append(ret, &CrossShardReceipt{cx})
+		}
+	}
+	return ret, nil
+}
+
+// OutgoingReceipts ..
+func (b *Block) OutgoingReceipts(ctx context.Context) ([]*CrossShardReceipt, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ret := []*CrossShardReceipt{}
+	numShards := shard.Schedule.InstanceForEpoch(block.Epoch()).NumShards()
+	for toShard := uint32(0); toShard < numShards; toShard++ {
+		if toShard == block.ShardID() {
+			continue
+		}
+		cxs, err := b.hmy.BlockChain.ReadCXReceipts(toShard, block.NumberU64(), block.Hash())
+		if err != nil {
+			return nil, err
+		}
+		for _, cx := range cxs {
+			ret = append(ret, &CrossShardReceipt{cx})
+		}
+	}
+	return ret, nil
+}
+
+// BlockFilterCriteria ..
+type BlockFilterCriteria struct {
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+	Topics    *[][]common.Hash  // restricts matches to particular event topics
+}
+
+// runFilter runs a filter and wraps the matching logs.
+func runFilter(ctx context.Context, hmy *hmy.Harmony, filter *filters.Filter) ([]*Log, error) {
+	logs, err := filter.Logs(ctx)
+	if err != nil || logs == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(logs))
+	for _, log := range logs {
+		ret = append(ret, &Log{
+			hmy:         hmy,
+			transaction: &Transaction{hmy: hmy, hash: log.TxHash},
+			log:         log,
+		})
+	}
+	return ret, nil
+}
+
+// filterArgs unpacks the optional address and topic filters.
+func filterArgs(addressesArg *[]common.Address, topicsArg *[][]common.Hash) ([]common.Address, [][]common.Hash) {
+	var addresses []common.Address
+	if addressesArg != nil {
+		addresses = *addressesArg
+	}
+	var topics [][]common.Hash
+	if topicsArg != nil {
+		topics = *topicsArg
+	}
+	return addresses, topics
+}
+
+// Logs ..
+func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	addresses, topics := filterArgs(args.Filter.Addresses, args.Filter.Topics)
+	filter := filters.NewBlockFilter(b.hmy, block.Hash(), addresses, topics, false)
+	return runFilter(ctx, b.hmy, filter)
+}
+
+// Account ..
+func (b *Block) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
+	if _, err := b.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return &Account{
+		hmy:           b.hmy,
+		address:       args.Address,
+		blockNrOrHash: *b.numberOrHash,
+	}, nil
+}
+
+// Resolver is the root resolver of the GraphQL schema.
+type Resolver struct {
+	hmy *hmy.Harmony
+}
+
+// Block ..
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*Block, error) {
+	var blockNrOrHash rpc.BlockNumberOrHash
+	switch {
+	case args.Number != nil:
+		blockNrOrHash = rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(*args.Number))
+	case args.Hash != nil:
+		blockNrOrHash = rpc.BlockNumberOrHashWithHash(*args.Hash, false)
+	default:
+		blockNrOrHash = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	}
+	block := &Block{
+		hmy:          r.hmy,
+		numberOrHash: &blockNrOrHash,
+	}
+	// Resolve the block, return nil if it doesn't exist
+	if resolved, err := block.resolve(ctx); err != nil || resolved == nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// Blocks ..
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*Block, error) {
+	from := uint64(args.From)
+	to := r.hmy.CurrentBlock().NumberU64()
+	if args.To != nil && uint64(*args.To) < to {
+		to = uint64(*args.To)
+	}
+	if to < from {
+		return []*Block{}, nil
+	}
+	if to-from >= maxBlocksPerQuery {
+		return nil, fmt.Errorf("block range too large, at most %d blocks per query", maxBlocksPerQuery)
+	}
+	ret := make([]*Block, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(i))
+		ret = append(ret, &Block{
+			hmy:          r.hmy,
+			numberOrHash: &blockNrOrHash,
+		})
+	}
+	return ret, nil
+}
+
+// Transaction ..
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx := &Transaction{
+		hmy:  r.hmy,
+		hash: args.Hash,
+	}
+	// Resolve the transaction; if it doesn't exist, return nil.
+	if resolved, err := tx.resolve(ctx); err != nil || resolved == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// StakingTransaction ..
+func (r *Resolver) StakingTransaction(ctx context.Context, args struct{ Hash common.Hash }) (*StakingTransaction, error) {
+	tx := &StakingTransaction{
+		hmy:  r.hmy,
+		hash: args.Hash,
+	}
+	if resolved, err := tx.resolve(ctx); err != nil || resolved == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// CrossShardReceipt ..
+func (r *Resolver) CrossShardReceipt(ctx context.Context, args struct{ Hash common.Hash }) (*CrossShardReceipt, error) {
+	cx, _, _, _ := rawdb.ReadCXReceipt(r.hmy.ChainDb(), args.Hash)
+	if cx == nil {
+		return nil, nil
+	}
+	return &CrossShardReceipt{cx}, nil
+}
+
+// Validator ..
+func (r *Resolver) Validator(ctx context.Context, args struct {
+	Address common.Address
+	Block   *hexutil.Uint64
+}) (*Validator, error) {
+	if r.hmy.ShardID != shard.BeaconChainShardID {
+		return nil, errors.New("validator information is only served by the beacon shard")
+	}
+	blockNum := rpc.LatestBlockNumber
+	if args.Block != nil {
+		blockNum = rpc.BlockNumber(*args.Block)
+	}
+	block, err := r.hmy.BlockByNumber(ctx, blockNum)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	info, err := r.hmy.GetValidatorInformation(args.Address, block)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{info}, nil
+}
+
+// FilterCriteria ..
+type FilterCriteria struct {
+	FromBlock *hexutil.Uint64   // beginning of the queried range, nil means latest block
+	ToBlock   *hexutil.Uint64   // end of the range, nil means latest block
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+	Topics    *[][]common.Hash  // restricts matches to particular event topics
+}
+
+// Logs ..
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	begin := rpc.LatestBlockNumber.Int64()
+	if args.Filter.FromBlock != nil {
+		begin = int64(*args.Filter.FromBlock)
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if args.Filter.ToBlock != nil {
+		end = int64(*args.Filter.ToBlock)
+	}
+	addresses, topics := filterArgs(args.Filter.Addresses, args.Filter.Topics)
+	filter := filters.NewRangeFilter(r.hmy, begin, end, addresses, topics, false)
+	return runFilter(ctx, r.hmy, filter)
+}
+
+// GasPrice ..
+func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	price, err := r.hmy.SuggestPrice(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*price), nil
+}
+
+// ChainID ..
+func (r *Resolver) ChainID(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(r.hmy.ChainID))
+}
+
+// ShardID ..
+func (r *Resolver) ShardID(ctx context.Context) int32 {
+	return int32(r.hmy.ShardID)
+}
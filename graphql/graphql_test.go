@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBuildSchema(t *testing.T) {
+	// Make sure the schema can be parsed and matched up to the resolvers
+	if _, err := newHandler(nil); err != nil {
+		t.Fatalf("could not create new handler: %v", err)
+	}
+}
+
+func TestTraceFrames(t *testing.T) {
+	tests := []struct {
+		frame   string
+		expType string
+		expFrom common.Address
+		expTo   common.Address
+		expGas  uint64
+	}{
+		{
+			frame:   `{"blockNumber":1,"subtraces":1,"traceAddress":[],"type":"call","action":{"callType":"call","value":"0x0","to":"0x0000000000000000000000000000000000000002","gas":"0x5208","from":"0x0000000000000000000000000000000000000001","input":"0x"},"result":{"output":"0x","gasUsed":"0x5208"}}`,
+			expType: "call",
+			expFrom: common.HexToAddress("0x01"),
+			expTo:   common.HexToAddress("0x02"),
+			expGas:  0x5208,
+		},
+		{
+			frame:   `{"blockNumber":1,"subtraces":0,"traceAddress":[0],"type":"create","action":{"from":"0x0000000000000000000000000000000000000001","gas":"0x10","init":"0x6000","value":"0x1"},"result":{"address":"0x0000000000000000000000000000000000000003","code":"0x","gasUsed":"0x8"}}`,
+			expType: "create",
+			expFrom: common.HexToAddress("0x01"),
+			expTo:   common.HexToAddress("0x03"),
+			expGas:  0x8,
+		},
+		{
+			frame:   `{"blockNumber":1,"subtraces":0,"traceAddress":[0,1],"type":"suicide","action":{"refundAddress":"0x0000000000000000000000000000000000000004","balance":"0x2","address":"0x0000000000000000000000000000000000000003"},"result":null}`,
+			expType: "suicide",
+			expFrom: common.HexToAddress("0x03"),
+			expTo:   common.HexToAddress("0x04"),
+		},
+	}
+	ctx := context.Background()
+	for i, test := range tests {
+		trace := &Trace{}
+		if err := json.Unmarshal([]byte(test.frame), &trace.frame); err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if trace.Type(ctx) != test.expType {
+			t.Errorf("Test %v: unexpected type %v", i, trace.Type(ctx))
+		}
+		if from := trace.From(ctx); from == nil || *from != test.expFrom {
+			t.Errorf("Test %v: unexpected from %v", i, from)
+		}
+		if to := trace.To(ctx); to == nil || *to != test.expTo {
+			t.Errorf("Test %v: unexpected to %v", i, to)
+		}
+		gasUsed := trace.GasUsed(ctx)
+		if test.expGas == 0 && gasUsed != nil {
+			t.Errorf("Test %v: unexpected gas used %v", i, gasUsed)
+		}
+		if test.expGas != 0 && (gasUsed == nil || uint64(*gasUsed) != test.expGas) {
+			t.Errorf("Test %v: unexpected gas used %v", i, gasUsed)
+		}
+	}
+}
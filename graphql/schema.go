@@ -0,0 +1,314 @@
+package graphql
+
+// schema is the Ethereum GraphQL schema (EIP-1767) restricted to what a Harmony
+// shard can serve, extended with the shard, staking and cross-shard data.
+const schema string = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    # An empty byte string is represented as '0x'.
+    scalar Bytes
+    # BigInt is a large integer. Input is accepted as either a JSON number or as a string.
+    # Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
+    # 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer.
+    scalar Long
+
+    schema {
+        query: Query
+    }
+
+    # Account is an account at a particular block.
+    type Account {
+        # Address is the address owning the account.
+        address: Address!
+        # Balance is the balance of the account, in wei.
+        balance: BigInt!
+        # TransactionCount is the nonce of the account.
+        transactionCount: Long!
+        # Code contains the smart contract code of the account, if any.
+        code: Bytes!
+        # Storage provides access to the storage of a contract account, indexed
+        # by its 32 byte slot identifier.
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is an event log.
+    type Log {
+        # Index is the index of this log in the block.
+        index: Int!
+        # Account is the contract account which generated this log.
+        account(block: Long): Account!
+        # Topics is a list of 0-4 indexed topics for the log.
+        topics: [Bytes32!]!
+        # Data is unindexed data for this log.
+        data: Bytes!
+        # Transaction is the transaction that generated this log entry.
+        transaction: Transaction!
+    }
+
+    # Trace is a single call frame of a transaction execution, in the format of
+    # the trace_ RPC namespace. Frames are listed depth first.
+    type Trace {
+        # Type is the frame type: call, create, suicide or cxTransfer.
+        type: String!
+        # CallType is the call opcode of call frames.
+        callType: String
+        # TraceAddress is the path of the frame in the call tree.
+        traceAddress: [Int!]!
+        # Subtraces is the number of direct child frames.
+        subtraces: Int!
+        # From is the account executing the frame.
+        from: Address
+        # To is the callee, or the created contract of create frames.
+        to: Address
+        # Value is the value, in wei, transferred by the frame.
+        value: BigInt
+        # Gas is the gas made available to the frame.
+        gas: Long
+        # GasUsed is the gas consumed by the frame.
+        gasUsed: Long
+        # Input is the call data or the init code of the frame.
+        input: Bytes
+        # Output is the return data or the deployed code of the frame.
+        output: Bytes
+        # Error is set if the frame reverted or failed.
+        error: String
+    }
+
+    # CrossShardReceipt is the receipt of a transfer between shards, emitted by
+    # the source shard and credited by the destination shard.
+    type CrossShardReceipt {
+        # TransactionHash is the hash of the transaction in the source shard.
+        transactionHash: Bytes32!
+        # From is the sender in the source shard.
+        from: Address!
+        # To is the recipient in the destination shard.
+        to: Address
+        # ShardID is the source shard.
+        shardID: Int!
+        # ToShardID is the destination shard.
+        toShardID: Int!
+        # Amount is the value transferred, in wei.
+        amount: BigInt!
+    }
+
+    # Transaction is a plain, possibly cross-shard, transaction.
+    type Transaction {
+        # Hash is the hash of this transaction.
+        hash: Bytes32!
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Index is the index of this transaction in the parent block, null if pending.
+        index: Int
+        # From is the account that sent this transaction.
+        from(block: Long): Account!
+        # To is the account the transaction was sent to, null for contract creations.
+        to(block: Long): Account
+        # Value is the value, in wei, sent along with this transaction.
+        value: BigInt!
+        # GasPrice is the price offered for gas, in wei per unit.
+        gasPrice: BigInt!
+        # Gas is the maximum amount of gas this transaction can consume.
+        gas: Long!
+        # InputData is the data supplied to the target of the transaction.
+        inputData: Bytes!
+        # Block is the block this transaction was included in, null if pending.
+        block: Block
+        # ShardID is the shard the transaction was sent from.
+        shardID: Int!
+        # ToShardID is the shard of the recipient, different from ShardID for
+        # cross-shard transactions.
+        toShardID: Int!
+        # Status is 1 if the transaction succeeded and 0 if it failed, null if pending.
+        status: Long
+        # GasUsed is the amount of gas used by this transaction, null if pending.
+        gasUsed: Long
+        # CumulativeGasUsed is the total gas used in the block up to and including
+        # this transaction, null if pending.
+        cumulativeGasUsed: Long
+        # CreatedContract is the contract created by the transaction, if any.
+        createdContract(block: Long): Account
+        # Logs is the list of log entries emitted by this transaction, null if pending.
+        logs: [Log!]
+        # Traces re-executes the transaction and returns its call frames, null
+        # if pending. This requires the state of the parent block, or recent
+        # enough blocks to regenerate it.
+        traces: [Trace!]
+        # CrossShardReceipt is the outgoing receipt of a cross-shard transaction.
+        crossShardReceipt: CrossShardReceipt
+    }
+
+    # StakingTransaction is a staking directive.
+    type StakingTransaction {
+        # Hash is the hash of this transaction.
+        hash: Bytes32!
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Index is the index of this transaction among the staking transactions
+        # of the parent block.
+        index: Int
+        # From is the account that sent this transaction.
+        from(block: Long): Account!
+        # Type is the staking directive, e.g. Delegate.
+        type: String!
+        # Msg is the JSON encoded directive.
+        msg: String!
+        # GasPrice is the price offered for gas, in wei per unit.
+        gasPrice: BigInt!
+        # Gas is the maximum amount of gas this transaction can consume.
+        gas: Long!
+        # Block is the block this transaction was included in.
+        block: Block
+        # Status is 1 if the transaction succeeded and 0 if it failed.
+        status: Long
+        # GasUsed is the amount of gas used by this transaction.
+        gasUsed: Long
+        # Logs is the list of log entries emitted by this transaction.
+        logs: [Log!]
+    }
+
+    # Delegation is a stake delegated to a validator.
+    type Delegation {
+        # Delegator is the delegating account.
+        delegator: Address!
+        # Amount is the delegated stake, in wei.
+        amount: BigInt!
+        # Reward is the unclaimed reward, in wei.
+        reward: BigInt!
+    }
+
+    # Validator is a validator as stored in the beacon chain state.
+    type Validator {
+        # Address is the validator account.
+        address: Address!
+        # Name is the display name of the validator.
+        name: String!
+        # TotalDelegation is the sum of all delegations, in wei.
+        totalDelegation: BigInt!
+        # CurrentlyInCommittee is whether the validator signs blocks in the current epoch.
+        currentlyInCommittee: Boolean!
+        # EPoSStatus is the election status of the validator.
+        eposStatus: String!
+        # ActiveStatus is whether the validator is active or inactive.
+        activeStatus: String!
+        # Delegations is the list of delegations, including the self delegation.
+        delegations: [Delegation!]!
+    }
+
+    # BlockFilterCriteria encapsulates log filter criteria for a filter applied
+    # to a single block.
+    input BlockFilterCriteria {
+        # Addresses is list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics, see the
+        # filter criteria of eth_getLogs.
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is a block of the shard.
+    type Block {
+        # Number is the number of this block, starting at 0 for the genesis block.
+        number: Long!
+        # Hash is the block hash of this block.
+        hash: Bytes32!
+        # Parent is the parent block of this block.
+        parent: Block
+        # ShardID is the shard of this block.
+        shardID: Int!
+        # Epoch is the epoch of this block.
+        epoch: Long!
+        # ViewID is the consensus view of this block.
+        viewID: Long!
+        # TransactionsRoot is the root of the trie of transactions in this block.
+        transactionsRoot: Bytes32!
+        # TransactionCount is the number of plain transactions in this block.
+        transactionCount: Int
+        # StateRoot is the root of the state trie after this block was processed.
+        stateRoot: Bytes32!
+        # ReceiptsRoot is the root of the trie of transaction receipts in this block.
+        receiptsRoot: Bytes32!
+        # OutgoingReceiptsRoot is the root of the cross-shard receipts emitted by this block.
+        outgoingReceiptsRoot: Bytes32!
+        # IncomingReceiptsRoot is the hash of the cross-shard receipts credited by this block.
+        incomingReceiptsRoot: Bytes32!
+        # Leader is the account of the leader that proposed this block.
+        leader(block: Long): Account!
+        # ExtraData is an arbitrary data field supplied by the leader.
+        extraData: Bytes!
+        # GasLimit is the maximum amount of gas available to transactions in this block.
+        gasLimit: Long!
+        # GasUsed is the amount of gas used executing transactions in this block.
+        gasUsed: Long!
+        # BaseFee is the base fee per gas of this block, null before London.
+        baseFeePerGas: BigInt
+        # Timestamp is the unix timestamp of this block.
+        timestamp: Long!
+        # LogsBloom is a bloom filter of the log entries of this block.
+        logsBloom: Bytes!
+        # Transactions is the list of plain transactions of this block.
+        transactions: [Transaction!]
+        # TransactionAt returns the plain transaction at the specified index.
+        transactionAt(index: Int!): Transaction
+        # StakingTransactionCount is the number of staking transactions in this block.
+        stakingTransactionCount: Int
+        # StakingTransactions is the list of staking transactions of this block.
+        stakingTransactions: [StakingTransaction!]
+        # IncomingReceipts is the list of cross-shard receipts credited by this block.
+        incomingReceipts: [CrossShardReceipt!]!
+        # OutgoingReceipts is the list of cross-shard receipts emitted by this block.
+        outgoingReceipts: [CrossShardReceipt!]!
+        # Logs returns a filtered set of logs from this block.
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        # Account fetches an account at the state of this block.
+        account(address: Address!): Account!
+    }
+
+    # FilterCriteria encapsulates log filter criteria for searching log entries.
+    input FilterCriteria {
+        # FromBlock is the block at which to start searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        fromBlock: Long
+        # ToBlock is the block at which to stop searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        toBlock: Long
+        # Addresses is a list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics, see the
+        # filter criteria of eth_getLogs.
+        topics: [[Bytes32!]!]
+    }
+
+    type Query {
+        # Block fetches a block by number or by hash. If neither is supplied,
+        # the most recent known block is returned.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns the blocks between two numbers, inclusive, at most
+        # maxBlocksPerQuery of them. If to is not supplied, it defaults to the
+        # most recent known block.
+        blocks(from: Long!, to: Long): [Block!]!
+        # Transaction returns a plain transaction specified by its hash.
+        transaction(hash: Bytes32!): Transaction
+        # StakingTransaction returns a staking transaction specified by its hash.
+        stakingTransaction(hash: Bytes32!): StakingTransaction
+        # CrossShardReceipt returns the cross-shard receipt emitted by the
+        # transaction of the given hash.
+        crossShardReceipt(hash: Bytes32!): CrossShardReceipt
+        # Validator returns the validator of the given address at the given
+        # block, or the most recent one. Only served by the beacon shard.
+        validator(address: Address!, block: Long): Validator
+        # Logs returns log entries matching the provided filter.
+        logs(filter: FilterCriteria!): [Log!]!
+        # GasPrice returns the suggested gas price.
+        gasPrice: BigInt!
+        # ChainID returns the chain ID used for transaction signing.
+        chainID: BigInt!
+        # ShardID returns the shard served by this node.
+        shardID: Int!
+    }
+`
@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+var (
+	listener     net.Listener
+	endpoint     = ""
+	httpOrigins  = []string{"*"}
+	virtualHosts = []string{"*"}
+)
+
+// StartServers starts the GraphQL http server, serving queries on /graphql
+func StartServers(hmy *hmy.Harmony, config nodeconfig.GraphQLServerConfig) error {
+	if !config.HTTPEnabled {
+		utils.Logger().Info().Msg("GraphQL http server disabled...")
+		return nil
+	}
+
+	handler, err := newHandler(hmy)
+	if err != nil {
+		return err
+	}
+	endpoint = fmt.Sprintf("%s:%d", config.HTTPIp, config.HTTPPort)
+	if listener, err = net.Listen("tcp", endpoint); err != nil {
+		return err
+	}
+	go rpc.NewHTTPServer(httpOrigins, virtualHosts, rpc.DefaultHTTPTimeouts, handler).Serve(listener)
+
+	utils.Logger().Info().
+		Str("url", fmt.Sprintf("http://%s/graphql", endpoint)).
+		Msg("GraphQL endpoint opened")
+	fmt.Printf("Started GraphQL server at: %v\n", endpoint)
+	return nil
+}
+
+// StopServers stops the GraphQL http server
+func StopServers() error {
+	if listener == nil {
+		return nil
+	}
+	if err := listener.Close(); err != nil {
+		return err
+	}
+	listener = nil
+	utils.Logger().Info().
+		Str("url", fmt.Sprintf("http://%s/graphql", endpoint)).
+		Msg("GraphQL endpoint closed")
+	return nil
+}
+
+// newHandler returns the http handler answering GraphQL queries.
+func newHandler(hmy *hmy.Harmony) (http.Handler, error) {
+	s, err := graphql.ParseSchema(schema, &Resolver{hmy})
+	if err != nil {
+		return nil, err
+	}
+	h := &relay.Handler{Schema: s}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", h)
+	mux.Handle("/graphql/", h)
+	return mux, nil
+}
@@ -0,0 +1,77 @@
+package hmy
+
+import (
+	"context"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy/tracers"
+	"github.com/pkg/errors"
+)
+
+// CreateAccessList simulates the message at the given block and returns the
+// EIP-2930 access list of the accounts and storage slots it touches, along
+// with the gas used when executed with that list. Since adding an access list
+// may change the execution path, the message is re-executed with the last
+// generated list until the list is stable.
+func (hmy *Harmony) CreateAccessList(
+	ctx context.Context, msg types.Message, blockNrOrHash rpc.BlockNumberOrHash,
+) (acl types.AccessList, gasUsed uint64, vmErr error, err error) {
+	state, header, err := hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if state == nil || header == nil {
+		return nil, 0, nil, errors.New("block not found")
+	}
+	rules := hmy.ChainConfig().Rules(header.Epoch())
+	if !rules.IsBerlin {
+		return nil, 0, nil, errors.New("access lists are not enabled at the requested block")
+	}
+
+	// The recipient of a contract creation is excluded by its derived address.
+	var to common.Address
+	if msg.To() != nil {
+		to = *msg.To()
+	} else {
+		to = crypto.CreateAddress(msg.From(), state.GetNonce(msg.From()))
+	}
+	precompiles := vm.ActivePrecompiles(rules)
+
+	prevTracer := tracers.NewAccessListTracer(msg.AccessList(), msg.From(), to, precompiles)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, nil, err
+		}
+		// Retrieve a fresh state for every run, execution modifies it
+		state, header, err := hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+		if state == nil || err != nil {
+			return nil, 0, nil, err
+		}
+		acl = prevTracer.AccessList()
+		msg.SetAccessList(acl)
+
+		tracer := tracers.NewAccessListTracer(acl, msg.From(), to, precompiles)
+		vmCtx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
+		vmCfg := *hmy.BlockChain.GetVMConfig()
+		vmCfg.NoBaseFee = true
+		vmCfg.AddTracer(tracer)
+		state.SetBalance(msg.From(), ethmath.MaxBig256)
+		evm := vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), vmCfg)
+
+		result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+		if err != nil {
+			return nil, 0, nil, errors.Wrap(err, "failed to apply transaction")
+		}
+		if tracer.Equal(prevTracer) {
+			return acl, result.UsedGas, result.VMErr, nil
+		}
+		prevTracer = tracer
+	}
+}
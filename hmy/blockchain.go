@@ -13,6 +13,7 @@ import (
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/verify"
 	"github.com/harmony-one/harmony/crypto/bls"
 	internal_bls "github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/eth/rpc"
@@ -231,6 +232,24 @@ func (hmy *Harmony) BlockByNumber(ctx context.Context, blockNum rpc.BlockNumber)
 	return hmy.BlockChain.GetBlockByNumber(uint64(blockNum)), nil
 }
 
+// BlockByNumberOrHash ...
+func (hmy *Harmony) BlockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if blockNum, ok := blockNrOrHash.Number(); ok {
+		return hmy.BlockByNumber(ctx, blockNum)
+	}
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block := hmy.BlockChain.GetBlockByHash(hash)
+		if block == nil {
+			return nil, errors.New("block for hash not found")
+		}
+		if blockNrOrHash.RequireCanonical && rawdb.ReadCanonicalHash(hmy.chainDb, block.NumberU64()) != hash {
+			return nil, errors.New("hash is not currently canonical")
+		}
+		return block, nil
+	}
+	return nil, errors.New("invalid arguments; neither block nor hash specified")
+}
+
 // HeaderByNumber ...
 func (hmy *Harmony) HeaderByNumber(ctx context.Context, blockNum rpc.BlockNumber) (*block.Header, error) {
 	// Pending block is only known by the miner
@@ -268,6 +287,27 @@ func (hmy *Harmony) StateAndHeaderByNumber(ctx context.Context, blockNum rpc.Blo
 	return stateDb, header, err
 }
 
+// StateAndHeaderByNumberOrHash returns the state and header of the block
+// identified by number or hash. Historical states are only available on
+// archival nodes or within the in-memory trie cache of full nodes.
+func (hmy *Harmony) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.DB, *block.Header, error) {
+	if blockNr, ok := blockNrOrHash.Number(); ok {
+		return hmy.StateAndHeaderByNumber(ctx, blockNr)
+	}
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header, err := hmy.HeaderByHash(ctx, hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if blockNrOrHash.RequireCanonical && rawdb.ReadCanonicalHash(hmy.chainDb, header.Number().Uint64()) != hash {
+			return nil, nil, errors.New("hash is not currently canonical")
+		}
+		stateDb, err := hmy.BlockChain.StateAt(header.Root())
+		return stateDb, header, err
+	}
+	return nil, nil, errors.New("invalid arguments; neither block nor hash specified")
+}
+
 // GetLeaderAddress returns the one address of the leader, given the coinbaseAddr.
 // Note that the coinbaseAddr is overloaded with the BLS pub key hash in staking era.
 func (hmy *Harmony) GetLeaderAddress(coinbaseAddr common.Address, epoch *big.Int) string {
@@ -367,3 +407,18 @@ func (hmy *Harmony) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) e
 func (hmy *Harmony) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return hmy.BlockChain.SubscribeLogsEvent(ch)
 }
+
+// VerifyChain checks the headers, bodies and receipts of the given number of most
+// recent blocks and walks the state of the newest one, reporting the missing and
+// corrupt items. If repair is set, the missing trie nodes and codes are fetched
+// from the peers of the stream downloader.
+func (hmy *Harmony) VerifyChain(ctx context.Context, blocks uint64, repair bool) (*verify.Result, error) {
+	var fetcher verify.NodeFetcher
+	if repair {
+		if fetcher = hmy.NodeAPI.NodeDataFetcher(); fetcher == nil {
+			return nil, errors.New("repair needs the stream downloader to be running")
+		}
+	}
+	head := hmy.BlockChain.CurrentBlock().NumberU64()
+	return verify.New(hmy.BlockChain.ChainDb(), hmy.BlockChain.TrieDB(), fetcher).Verify(ctx, head, blocks, repair)
+}
@@ -17,10 +17,14 @@ type syncProtocol interface {
 	GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error)
 	GetBlockHashes(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]common.Hash, sttypes.StreamID, error)
 	GetBlocksByHashes(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error)
+	GetNodeData(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error)
+	GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, sttypes.StreamID, error)
+	GetStorageRange(ctx context.Context, root, account, storageRoot, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, sttypes.StreamID, error)
 
 	RemoveStream(stID sttypes.StreamID) // If a stream delivers invalid data, remove the stream
 	SubscribeAddStreamEvent(ch chan<- streammanager.EvtStreamAdded) event.Subscription
 	NumStreams() int
+	StreamIDs() []sttypes.StreamID
 }
 
 type blockChain interface {
@@ -30,3 +34,13 @@ type blockChain interface {
 	InsertChain(chain types.Blocks, verifyHeaders bool) (int, error)
 	WriteCommitSig(blockNum uint64, lastCommits []byte) error
 }
+
+// snapBlockChain is the blockchain able to store the blocks without executing them
+// during a snap sync.
+type snapBlockChain interface {
+	blockChain
+
+	CurrentFastBlock() *types.Block
+	InsertSnapSyncChain(blocks types.Blocks) (int, error)
+	SnapSyncCommitHead(hash common.Hash) error
+}
@@ -2,6 +2,7 @@ package downloader
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -263,6 +264,25 @@ func (sp *testSyncProtocol) GetBlocksByHashes(ctx context.Context, hs []common.H
 	return res, sp.nextStreamID(), nil
 }
 
+func (sp *testSyncProtocol) GetNodeData(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+
+	res := make([][]byte, 0, len(hs))
+	for _, h := range hs {
+		res = append(res, h.Bytes())
+	}
+	return res, sp.nextStreamID(), nil
+}
+
+func (sp *testSyncProtocol) GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, sttypes.StreamID, error) {
+	return nil, nil, "", errors.New("state not found")
+}
+
+func (sp *testSyncProtocol) GetStorageRange(ctx context.Context, root, account, storageRoot, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, sttypes.StreamID, error) {
+	return nil, nil, "", errors.New("state not found")
+}
+
 func (sp *testSyncProtocol) RemoveStream(target sttypes.StreamID) {
 	sp.lock.Lock()
 	defer sp.lock.Unlock()
@@ -290,6 +310,13 @@ func (sp *testSyncProtocol) NumStreams() int {
 	return len(sp.streamIDs)
 }
 
+func (sp *testSyncProtocol) StreamIDs() []sttypes.StreamID {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+
+	return append([]sttypes.StreamID{}, sp.streamIDs...)
+}
+
 func (sp *testSyncProtocol) SubscribeAddStreamEvent(ch chan<- streammanager.EvtStreamAdded) event.Subscription {
 	var evtFeed event.Feed
 	go func() {
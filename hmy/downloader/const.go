@@ -15,6 +15,43 @@ const (
 	numBlocksByHashesUpperCap int = 10 // number of get blocks by hashes upper cap
 	numBlocksByHashesLowerCap int = 3  // number of get blocks by hashes lower cap
 
+	numBlockHashesPerStagedRequest int = 50 // number of get block hashes for each staged sync request
+
+	// stagedHashesAhead is the number of blocks the hashes stage of the staged sync
+	// can verify ahead of the blockchain.
+	stagedHashesAhead uint64 = 8192
+
+	// stagedMaxInsertFailures is the number of consecutive insert failures after which
+	// the staged sync drops its checkpoint and restarts.
+	stagedMaxInsertFailures int = 3
+
+	// snapPivotDistance is the number of blocks between the remote head and the pivot
+	// of the snap sync, so that the peers still hold the state of the pivot.
+	snapPivotDistance uint64 = 64
+
+	// snapMinBlocks is the minimum height of the remote chain to do a snap sync. A
+	// shorter chain is simply executed.
+	snapMinBlocks uint64 = 1024
+
+	// snapAccountChunks is the number of chunks the account hash space is split into
+	// to be downloaded concurrently.
+	snapAccountChunks int = 16
+
+	// snapRangeAttempts is the number of requests made for an account or storage range
+	// before leaving it to the trie healing.
+	snapRangeAttempts int = 3
+
+	// snapTrieCommitLeaves is the number of leaves inserted into a rebuilt trie before
+	// it is flushed to the database, which bounds the memory used by large tries.
+	snapTrieCommitLeaves int = 100000
+
+	// snapHealBatch is the number of trie nodes requested at once by the trie healing.
+	snapHealBatch int = 1024
+
+	// snapHealAttempts is the number of trie healing passes made before giving up on
+	// the nodes unknown to the peers.
+	snapHealAttempts int = 3
+
 	lastMileThres int = 10
 
 	// soft cap of size in resultQueue. When the queue size is larger than this limit,
@@ -38,9 +75,11 @@ type (
 
 		// parameters
 		Network     nodeconfig.NetworkType
-		Concurrency int // Number of concurrent sync requests
-		MinStreams  int // Minimum number of streams to do sync
-		InitStreams int // Number of streams requirement for initial bootstrap
+		Concurrency int  // Number of concurrent sync requests
+		MinStreams  int  // Minimum number of streams to do sync
+		InitStreams int  // Number of streams requirement for initial bootstrap
+		Staged      bool // Whether to do the initial sync as a staged sync
+		SnapSync    bool // Whether to bootstrap a new node with a snap sync of the state
 
 		// stream manager config
 		SmSoftLowCap int
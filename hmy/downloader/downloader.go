@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -18,6 +19,7 @@ import (
 	"github.com/harmony-one/harmony/p2p"
 	"github.com/harmony-one/harmony/p2p/stream/common/streammanager"
 	"github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
 )
 
 type (
@@ -26,6 +28,7 @@ type (
 		bc           blockChain
 		syncProtocol syncProtocol
 		bh           *beaconHelper
+		db           ethdb.KeyValueStore // checkpoints of the staged sync
 
 		downloadC chan struct{}
 		closeC    chan struct{}
@@ -51,6 +54,7 @@ func NewDownloader(host p2p.Host, bc *core.BlockChain, config Config) *Downloade
 		Discovery: host.GetDiscovery(),
 		ShardID:   nodeconfig.ShardID(bc.ShardID()),
 		Network:   config.Network,
+		Reporter:  host,
 
 		SmSoftLowCap: config.SmSoftLowCap,
 		SmHardLowCap: config.SmHardLowCap,
@@ -70,6 +74,7 @@ func NewDownloader(host p2p.Host, bc *core.BlockChain, config Config) *Downloade
 		bc:           bc,
 		syncProtocol: sp,
 		bh:           bh,
+		db:           bc.ChainDb(),
 
 		downloadC: make(chan struct{}),
 		closeC:    make(chan struct{}),
@@ -116,6 +121,11 @@ func (d *Downloader) NumPeers() int {
 	return d.syncProtocol.NumStreams()
 }
 
+// StreamIDs returns the IDs of the sync streams of the shard.
+func (d *Downloader) StreamIDs() []sttypes.StreamID {
+	return d.syncProtocol.StreamIDs()
+}
+
 // IsSyncing return the current sync status
 func (d *Downloader) SyncStatus() (bool, uint64, uint64) {
 	syncing, target := d.status.get()
@@ -229,7 +239,17 @@ func (d *Downloader) loop() {
 }
 
 func (d *Downloader) doDownload(initSync bool) (n int, err error) {
-	if initSync {
+	if initSync && d.config.SnapSync {
+		d.logger.Info().Uint64("current number", d.bc.CurrentBlock().NumberU64()).
+			Uint32("shard ID", d.bc.ShardID()).Msg("start snap sync")
+
+		n, err = d.doSnapSync()
+	} else if initSync && d.config.Staged {
+		d.logger.Info().Uint64("current number", d.bc.CurrentBlock().NumberU64()).
+			Uint32("shard ID", d.bc.ShardID()).Msg("start staged sync")
+
+		n, err = d.doStagedSync()
+	} else if initSync {
 		d.logger.Info().Uint64("current number", d.bc.CurrentBlock().NumberU64()).
 			Uint32("shard ID", d.bc.ShardID()).Msg("start long range sync")
 
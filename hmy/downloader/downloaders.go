@@ -4,6 +4,7 @@ import (
 	"github.com/harmony-one/abool"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/p2p"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
 )
 
 // Downloaders is the set of downloaders
@@ -81,6 +82,16 @@ func (ds *Downloaders) NumPeers() map[uint32]int {
 	return res
 }
 
+// StreamIDs returns the IDs of the sync streams for each shard
+func (ds *Downloaders) StreamIDs() map[uint32][]sttypes.StreamID {
+	res := make(map[uint32][]sttypes.StreamID)
+
+	for sid, d := range ds.ds {
+		res[sid] = d.StreamIDs()
+	}
+	return res
+}
+
 // SyncStatus returns whether the given shard is doing syncing task and the target block
 // number.
 func (ds *Downloaders) SyncStatus(shardID uint32) (bool, uint64, uint64) {
@@ -149,7 +149,7 @@ func (lsi *lrSyncIter) fetchAndInsertBlocks(targetBN uint64) error {
 	}
 
 	// insert the blocks to chain. Return when the target block number is reached.
-	lsi.insertChainLoop(targetBN)
+	lsi.insertChainLoop(targetBN, lsi.processBlocks)
 
 	select {
 	case <-lsi.ctx.Done():
@@ -159,7 +159,9 @@ func (lsi *lrSyncIter) fetchAndInsertBlocks(targetBN uint64) error {
 	return nil
 }
 
-func (lsi *lrSyncIter) insertChainLoop(targetBN uint64) {
+// insertChainLoop pulls the continuous downloaded blocks and hands them to process
+// until the target block number is reached.
+func (lsi *lrSyncIter) insertChainLoop(targetBN uint64, process func([]*blockResult, uint64)) {
 	var (
 		gbm     = lsi.gbm
 		t       = time.NewTicker(100 * time.Millisecond)
@@ -190,7 +192,7 @@ func (lsi *lrSyncIter) insertChainLoop(targetBN uint64) {
 		case <-resultC:
 			blockResults := gbm.PullContinuousBlocks(blocksPerInsert)
 			if len(blockResults) > 0 {
-				lsi.processBlocks(blockResults, targetBN)
+				process(blockResults, targetBN)
 				// more blocks is expected being able to be pulled from queue
 				trigger()
 			}
@@ -333,6 +335,16 @@ func (gbm *getBlocksManager) GetNextBatch() []uint64 {
 	return bns
 }
 
+// SetTargetBN raises the block number up to which blocks are requested
+func (gbm *getBlocksManager) SetTargetBN(bn uint64) {
+	gbm.lock.Lock()
+	defer gbm.lock.Unlock()
+
+	if bn > gbm.targetBN {
+		gbm.targetBN = bn
+	}
+}
+
 // HandleRequestError handles the error result
 func (gbm *getBlocksManager) HandleRequestError(bns []uint64, err error, stid sttypes.StreamID) {
 	gbm.lock.Lock()
@@ -0,0 +1,78 @@
+package downloader
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+)
+
+// fetchNodeAttempts is the number of requests made for a batch of node data,
+// each one asking for the nodes still unknown.
+const fetchNodeAttempts = 5
+
+// FetchNodes fetches the trie nodes and contract codes of the given hashes from
+// the sync streams, in batches of sync.GetNodeDataAmountCap. The nodes unknown to
+// the peers are nil.
+func (d *Downloader) FetchNodes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	data := make([][]byte, 0, len(hashes))
+	for start := 0; start < len(hashes); start += sync.GetNodeDataAmountCap {
+		end := start + sync.GetNodeDataAmountCap
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		batch, err := d.fetchNodeBatch(ctx, hashes[start:end])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, batch...)
+	}
+	return data, nil
+}
+
+// fetchNodeBatch fetches the given nodes, asking the ones still missing to
+// another stream after each request.
+func (d *Downloader) fetchNodeBatch(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	var (
+		data     = make([][]byte, len(hashes))
+		answered bool
+		lastErr  error
+	)
+	for attempt := 0; attempt < fetchNodeAttempts; attempt++ {
+		var (
+			indexes []int
+			missing []common.Hash
+		)
+		for i, blob := range data {
+			if blob == nil {
+				indexes = append(indexes, i)
+				missing = append(missing, hashes[i])
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+		resp, stid, err := d.syncProtocol.GetNodeData(ctx, missing)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			d.logger.Warn().Err(err).Str("stream", string(stid)).
+				Msg("failed to fetch node data")
+			lastErr = err
+			continue
+		}
+		answered = true
+		for i, blob := range resp {
+			if len(blob) != 0 {
+				data[indexes[i]] = blob
+			}
+		}
+	}
+	if !answered && lastErr != nil {
+		return nil, errors.Wrap(lastErr, "fetch node data")
+	}
+	return data, nil
+}
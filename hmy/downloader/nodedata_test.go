@@ -0,0 +1,32 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+)
+
+func TestDownloader_FetchNodes(t *testing.T) {
+	d := &Downloader{syncProtocol: newTestSyncProtocol(100, 4, nil)}
+
+	hashes := make([]common.Hash, 0, 2*sync.GetNodeDataAmountCap+1)
+	for i := 0; i < cap(hashes); i++ {
+		hashes = append(hashes, makeTestBlockHash(uint64(i)))
+	}
+	data, err := d.FetchNodes(context.Background(), hashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != len(hashes) {
+		t.Fatalf("fetched %v nodes, expect %v", len(data), len(hashes))
+	}
+	for i, hash := range hashes {
+		if !bytes.Equal(data[i], hash.Bytes()) {
+			t.Errorf("node %v: unexpected data %x", i, data[i])
+		}
+	}
+}
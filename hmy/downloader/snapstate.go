@@ -0,0 +1,494 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+)
+
+var (
+	maxHash      = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	emptyCodeKey = crypto.Keccak256Hash(nil)
+
+	// errBadRange is the error of a range response not matching its request
+	errBadRange = errors.New("invalid range response")
+)
+
+// stateSyncer downloads the state of a root. The account and storage leaves are
+// downloaded by ranges from the sync streams and the tries are rebuilt from them.
+// The tries are then healed from the root, downloading the trie nodes missed or
+// wrongly rebuilt, along with the contract codes.
+//
+// The ranges are requested without range proofs, by design: the sync protocol
+// carries no proofs and the trie package has no range proof verification. A range
+// is only checked to be ordered, within the requested bounds and decodable, and
+// the stream serving it is removed otherwise. A range with forged leaves can only
+// make the rebuilt trie nodes differ from the ones of the root, and such nodes are
+// never referenced from the root: the tries are stored by node hash and the
+// healing downloads every node missing under the root, checking each node against
+// its hash. The storage tries and codes to heal are taken from the healed account
+// trie, never from the downloaded ranges.
+type stateSyncer struct {
+	d    *Downloader
+	p    syncProtocol
+	db   ethdb.KeyValueStore
+	root common.Hash
+
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+// accountLeaves is a range of accounts downloaded
+type accountLeaves struct {
+	hashes   []common.Hash
+	accounts [][]byte
+	roots    []common.Hash // storage roots of the accounts
+}
+
+// storageTask is the storage trie of an account to download
+type storageTask struct {
+	account common.Hash
+	root    common.Hash
+}
+
+func newStateSyncer(ctx context.Context, d *Downloader, root common.Hash, logger zerolog.Logger) *stateSyncer {
+	return &stateSyncer{
+		d:      d,
+		p:      d.syncProtocol,
+		db:     d.db,
+		root:   root,
+		ctx:    ctx,
+		logger: logger.With().Str("root", root.Hex()).Logger(),
+	}
+}
+
+// run downloads the state and heals it
+func (ss *stateSyncer) run() error {
+	start := time.Now()
+	rebuilt, err := ss.syncRanges()
+	if err != nil {
+		return err
+	}
+	ss.logger.Info().Bool("complete", rebuilt == ss.root).
+		Str("elapsed", time.Since(start).String()).Msg("state ranges downloaded")
+
+	return ss.heal()
+}
+
+// syncRanges downloads the accounts by chunks of the hash space and the storage of
+// the accounts concurrently. Returns the root of the rebuilt account trie.
+func (ss *stateSyncer) syncRanges() (common.Hash, error) {
+	var (
+		chunkC   = make(chan [2]common.Hash, snapAccountChunks)
+		leavesC  = make(chan accountLeaves, ss.d.config.Concurrency)
+		storageC = make(chan storageTask, 1024)
+
+		accountWg sync.WaitGroup
+		storageWg sync.WaitGroup
+	)
+	for _, chunk := range splitHashSpace(snapAccountChunks) {
+		chunkC <- chunk
+	}
+	close(chunkC)
+
+	for i := 0; i != ss.d.config.Concurrency; i++ {
+		accountWg.Add(1)
+		go func() {
+			defer accountWg.Done()
+			for chunk := range chunkC {
+				ss.syncAccountChunk(chunk[0], chunk[1], leavesC)
+			}
+		}()
+		storageWg.Add(1)
+		go func() {
+			defer storageWg.Done()
+			for task := range storageC {
+				ss.syncStorage(task)
+			}
+		}()
+	}
+	go func() {
+		accountWg.Wait()
+		close(leavesC)
+	}()
+
+	tb := newTrieBuilder(ss.db)
+	var buildErr error
+	for leaves := range leavesC {
+		if buildErr != nil {
+			continue
+		}
+		for i, hash := range leaves.hashes {
+			if err := tb.add(hash, leaves.accounts[i]); err != nil {
+				buildErr = err
+				break
+			}
+			if root := leaves.roots[i]; root != types.EmptyRootHash {
+				storageC <- storageTask{account: hash, root: root}
+			}
+		}
+	}
+	close(storageC)
+	storageWg.Wait()
+
+	if buildErr != nil {
+		return common.Hash{}, buildErr
+	}
+	if err := ss.ctx.Err(); err != nil {
+		return common.Hash{}, err
+	}
+	return tb.commit()
+}
+
+// syncAccountChunk downloads the accounts in the hash range [origin, limit]. The
+// rest of the chunk is left to the trie healing if no stream could deliver it.
+func (ss *stateSyncer) syncAccountChunk(origin, limit common.Hash, leavesC chan<- accountLeaves) {
+	for {
+		var (
+			hashes   []common.Hash
+			accounts [][]byte
+			roots    []common.Hash
+		)
+		_, err := ss.fetchRange(func(ctx context.Context) (sttypes.StreamID, error) {
+			var (
+				stid sttypes.StreamID
+				err  error
+			)
+			hashes, accounts, stid, err = ss.p.GetAccountRange(ctx, ss.root, origin, limit, syncproto.GetRangeBytesCap)
+			if err != nil {
+				return stid, err
+			}
+			if err := checkRange(hashes, accounts, origin, limit); err != nil {
+				return stid, err
+			}
+			roots = make([]common.Hash, 0, len(accounts))
+			for i, blob := range accounts {
+				var acc state.Account
+				if err := rlp.DecodeBytes(blob, &acc); err != nil {
+					return stid, errors.Wrapf(errBadRange, "account %x: %v", hashes[i], err)
+				}
+				roots = append(roots, acc.Root)
+			}
+			return stid, nil
+		})
+		if err != nil {
+			ss.logger.Warn().Err(err).Str("origin", origin.Hex()).Str("limit", limit.Hex()).
+				Msg("account range left to trie healing")
+			return
+		}
+		if len(hashes) == 0 {
+			return
+		}
+		select {
+		case leavesC <- accountLeaves{hashes, accounts, roots}:
+		case <-ss.ctx.Done():
+			return
+		}
+		last := hashes[len(hashes)-1]
+		if last == limit {
+			return
+		}
+		origin = incHash(last)
+	}
+}
+
+// syncStorage downloads and rebuilds the storage trie of the account. The storage
+// trie is left to the trie healing if it could not be rebuilt.
+func (ss *stateSyncer) syncStorage(task storageTask) {
+	if known, _ := ss.db.Has(task.root[:]); known {
+		return
+	}
+	var (
+		tb      = newTrieBuilder(ss.db)
+		origin  common.Hash
+		streams = make(map[sttypes.StreamID]struct{})
+	)
+	for {
+		var (
+			hashes []common.Hash
+			slots  [][]byte
+		)
+		stid, err := ss.fetchRange(func(ctx context.Context) (sttypes.StreamID, error) {
+			var (
+				stid sttypes.StreamID
+				err  error
+			)
+			hashes, slots, stid, err = ss.p.GetStorageRange(ctx, ss.root, task.account, task.root, origin, maxHash, syncproto.GetRangeBytesCap)
+			if err != nil {
+				return stid, err
+			}
+			return stid, checkRange(hashes, slots, origin, maxHash)
+		})
+		if err != nil {
+			ss.logger.Debug().Err(err).Str("account", task.account.Hex()).
+				Msg("storage left to trie healing")
+			return
+		}
+		streams[stid] = struct{}{}
+		for i, hash := range hashes {
+			if err := tb.add(hash, slots[i]); err != nil {
+				return
+			}
+		}
+		if len(hashes) == 0 || hashes[len(hashes)-1] == maxHash {
+			break
+		}
+		origin = incHash(hashes[len(hashes)-1])
+	}
+	root, err := tb.commit()
+	if err != nil || root == task.root {
+		return
+	}
+	// The storage root comes from an unproven account range, so a mismatch is only
+	// blamed on the stream when it served the whole storage trie.
+	if len(streams) == 1 {
+		for stid := range streams {
+			ss.logger.Warn().Str("stream", string(stid)).Str("account", task.account.Hex()).
+				Msg("storage range not matching its root")
+			ss.p.RemoveStream(stid)
+		}
+	}
+}
+
+// fetchRange does the range request, retrying on another stream on failure. The
+// stream of an invalid range response is removed. Returns the stream serving the
+// range.
+func (ss *stateSyncer) fetchRange(request func(ctx context.Context) (sttypes.StreamID, error)) (sttypes.StreamID, error) {
+	var (
+		stid sttypes.StreamID
+		err  error
+	)
+	for attempt := 0; attempt != snapRangeAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(ss.ctx, 10*time.Second)
+		stid, err = request(ctx)
+		cancel()
+		if err == nil {
+			return stid, nil
+		}
+		if ss.ctx.Err() != nil {
+			return "", ss.ctx.Err()
+		}
+		if errors.Cause(err) == errBadRange {
+			ss.logger.Warn().Err(err).Str("stream", string(stid)).Msg("invalid range response")
+			ss.p.RemoveStream(stid)
+			continue
+		}
+		ss.logger.Debug().Err(err).Str("stream", string(stid)).Msg("range request failed")
+	}
+	return "", err
+}
+
+// checkRange checks the leaves of a range response are ordered and within the
+// requested range [origin, limit].
+func checkRange(hashes []common.Hash, values [][]byte, origin, limit common.Hash) error {
+	if len(hashes) != len(values) {
+		return errors.Wrapf(errBadRange, "%v hashes for %v values", len(hashes), len(values))
+	}
+	for i, hash := range hashes {
+		if bytes.Compare(hash[:], origin[:]) < 0 || bytes.Compare(hash[:], limit[:]) > 0 {
+			return errors.Wrapf(errBadRange, "leaf %x out of range", hash)
+		}
+		if i != 0 && bytes.Compare(hashes[i-1][:], hash[:]) >= 0 {
+			return errors.Wrapf(errBadRange, "leaf %x not ordered", hash)
+		}
+		if len(values[i]) == 0 {
+			return errors.Wrapf(errBadRange, "leaf %x empty", hash)
+		}
+	}
+	return nil
+}
+
+// heal downloads the trie nodes and codes missing from the state. A node known to
+// the database is the root of a complete sub trie, since the tries are rebuilt
+// and healed bottom up. The account trie is healed first, then the storage tries
+// and codes its accounts refer to.
+func (ss *stateSyncer) heal() error {
+	bloom := trie.NewSyncBloom(1, ss.db)
+	defer bloom.Close()
+
+	var missing int
+	for attempt := 0; attempt != snapHealAttempts; attempt++ {
+		sched := state.NewStateSync(ss.root, ss.db, bloom)
+		complete, err := ss.db.Has(ss.root[:])
+		if err != nil {
+			return err
+		}
+		if complete {
+			if err := ss.scheduleAccountData(sched); err != nil {
+				return err
+			}
+		}
+		healed, err := ss.healPass(sched)
+		if err != nil {
+			return err
+		}
+		missing = sched.Pending()
+		ss.logger.Info().Int("healed", healed).Int("missing", missing).Msg("state heal pass finished")
+		if complete && missing == 0 {
+			return nil
+		}
+	}
+	if missing == 0 {
+		return errors.New("state heal not finished")
+	}
+	return fmt.Errorf("%v state nodes unknown to the peers", missing)
+}
+
+// scheduleAccountData schedules the storage tries and codes missing from the
+// accounts of the complete account trie.
+func (ss *stateSyncer) scheduleAccountData(sched *trie.Sync) error {
+	tr, err := trie.New(ss.root, trie.NewDatabase(ss.db))
+	if err != nil {
+		return err
+	}
+	it := trie.NewIterator(tr.NodeIterator(nil))
+	for it.Next() {
+		var acc state.Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return errors.Wrapf(err, "account %x", it.Key)
+		}
+		if acc.Root != types.EmptyRootHash {
+			if known, _ := ss.db.Has(acc.Root[:]); !known {
+				sched.AddSubTrie(acc.Root, 64, common.Hash{}, nil)
+			}
+		}
+		if codeHash := common.BytesToHash(acc.CodeHash); codeHash != emptyCodeKey {
+			if known, _ := ss.db.Has(codeHash[:]); !known {
+				sched.AddRawEntry(codeHash, 64, common.Hash{})
+			}
+		}
+	}
+	return it.Err
+}
+
+// healPass downloads the nodes scheduled until no more nodes can be requested. The
+// nodes unknown to the peers are left pending in the scheduler. Returns the number
+// of nodes downloaded.
+func (ss *stateSyncer) healPass(sched *trie.Sync) (int, error) {
+	var healed int
+	for {
+		hashes := sched.Missing(snapHealBatch)
+		if len(hashes) == 0 {
+			return healed, nil
+		}
+		data, err := ss.d.FetchNodes(ss.ctx, hashes)
+		if err != nil {
+			return healed, err
+		}
+		var (
+			results = make([]trie.SyncResult, 0, len(hashes))
+			unknown int
+		)
+		for i, blob := range data {
+			if blob == nil {
+				unknown++
+				continue
+			}
+			results = append(results, trie.SyncResult{Hash: hashes[i], Data: blob})
+		}
+		if _, index, err := sched.Process(results); err != nil {
+			return healed, errors.Wrapf(err, "process node %x", results[index].Hash)
+		}
+		batch := ss.db.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			return healed, err
+		}
+		if err := batch.Write(); err != nil {
+			return healed, err
+		}
+		healed += len(results)
+		if unknown != 0 {
+			ss.logger.Debug().Int("unknown", unknown).Msg("state nodes unknown to the peers")
+		}
+	}
+}
+
+// trieBuilder rebuilds a trie from its leaves, flushing it to the database
+// periodically.
+type trieBuilder struct {
+	triedb *trie.Database
+	tr     *trie.Trie
+	leaves int
+}
+
+func newTrieBuilder(db ethdb.KeyValueStore) *trieBuilder {
+	triedb := trie.NewDatabase(db)
+	tr, _ := trie.New(common.Hash{}, triedb)
+	return &trieBuilder{
+		triedb: triedb,
+		tr:     tr,
+	}
+}
+
+func (tb *trieBuilder) add(key common.Hash, value []byte) error {
+	if err := tb.tr.TryUpdate(key[:], value); err != nil {
+		return err
+	}
+	tb.leaves++
+	if tb.leaves%snapTrieCommitLeaves == 0 {
+		if _, err := tb.commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commit flushes the trie to the database and returns its root
+func (tb *trieBuilder) commit() (common.Hash, error) {
+	root, err := tb.tr.Commit(nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := tb.triedb.Commit(root, false); err != nil {
+		return common.Hash{}, err
+	}
+	if tb.tr, err = trie.New(root, tb.triedb); err != nil {
+		return common.Hash{}, err
+	}
+	return root, nil
+}
+
+// splitHashSpace splits the hash space into the given number of contiguous ranges
+func splitHashSpace(n int) [][2]common.Hash {
+	var (
+		ranges = make([][2]common.Hash, 0, n)
+		step   = new(big.Int).Div(new(big.Int).Add(maxHash.Big(), common.Big1), big.NewInt(int64(n)))
+		origin = new(big.Int)
+	)
+	for i := 0; i != n; i++ {
+		limit := maxHash
+		if i != n-1 {
+			limit = common.BigToHash(new(big.Int).Sub(new(big.Int).Add(origin, step), common.Big1))
+		}
+		ranges = append(ranges, [2]common.Hash{common.BigToHash(origin), limit})
+		origin = new(big.Int).Add(origin, step)
+	}
+	return ranges
+}
+
+// incHash returns the hash following the given one
+func incHash(h common.Hash) common.Hash {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i]++
+		if h[i] != 0 {
+			break
+		}
+	}
+	return h
+}
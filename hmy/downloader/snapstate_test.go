@@ -0,0 +1,304 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/rs/zerolog"
+
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+)
+
+func TestStateSyncer_run(t *testing.T) {
+	tests := []struct {
+		failAccount  func(origin common.Hash) bool
+		failStorage  func(account common.Hash) bool
+		badAccount   func(origin common.Hash) bool
+		badStorage   func(account common.Hash) bool
+		unknownNodes bool
+		expErr       error
+		expRemoved   bool
+	}{
+		{},
+		{
+			// ranges missed are healed
+			failAccount: func(origin common.Hash) bool { return origin[0]>>4 == 3 },
+			failStorage: func(account common.Hash) bool { return account[0]%2 == 0 },
+		},
+		{
+			failAccount:  func(origin common.Hash) bool { return origin[0]>>4 == 3 },
+			unknownNodes: true,
+			expErr:       errors.New("state nodes unknown to the peers"),
+		},
+		{
+			// invalid ranges are healed and their stream removed
+			badAccount: func(origin common.Hash) bool { return origin[0]>>4 == 5 },
+			expRemoved: true,
+		},
+		{
+			badStorage: func(account common.Hash) bool { return account[0]%2 == 1 },
+			expRemoved: true,
+		},
+	}
+	for i, test := range tests {
+		srcDB, root := makeTestState(t, 200)
+		sp := &testSnapProtocol{
+			testSyncProtocol: newTestSyncProtocol(100, 32, nil),
+			db:               srcDB,
+			failAccount:      test.failAccount,
+			failStorage:      test.failStorage,
+			badAccount:       test.badAccount,
+			badStorage:       test.badStorage,
+			unknownNodes:     test.unknownNodes,
+		}
+		db := rawdb.NewMemoryDatabase()
+		d := &Downloader{
+			syncProtocol: sp,
+			db:           db,
+			config:       Config{Concurrency: 4},
+			logger:       zerolog.Nop(),
+		}
+		ss := newStateSyncer(context.Background(), d, root, d.logger)
+		err := ss.run()
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if removed := sp.removed(); removed != test.expRemoved {
+			t.Errorf("Test %v: unexpected bad stream removed %v / %v", i, removed, test.expRemoved)
+		}
+		if err := checkTestState(db, root, 200); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+func TestSplitHashSpace(t *testing.T) {
+	ranges := splitHashSpace(16)
+	if len(ranges) != 16 {
+		t.Fatalf("unexpected number of ranges %v", len(ranges))
+	}
+	if ranges[0][0] != (common.Hash{}) || ranges[15][1] != maxHash {
+		t.Errorf("ranges not covering the hash space")
+	}
+	for i := 1; i != len(ranges); i++ {
+		if incHash(ranges[i-1][1]) != ranges[i][0] {
+			t.Errorf("range %v not contiguous with range %v", i, i-1)
+		}
+	}
+	if ranges[3][0][0] != 0x30 {
+		t.Errorf("unexpected range origin %x", ranges[3][0])
+	}
+}
+
+// makeTestState makes a state with the given number of accounts, some of them with
+// storage and code.
+func makeTestState(t *testing.T, accounts int) (ethdb.Database, common.Hash) {
+	db := rawdb.NewMemoryDatabase()
+	sdb := state.NewDatabase(db)
+	st, err := state.New(common.Hash{}, sdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i != accounts; i++ {
+		addr := testStateAddress(i)
+		st.SetBalance(addr, big.NewInt(int64(i+1)))
+		if i%3 == 0 {
+			for j := 0; j != 20; j++ {
+				st.SetState(addr, common.BigToHash(big.NewInt(int64(j))), common.BigToHash(big.NewInt(int64(i*100+j+1))))
+			}
+		}
+		if i%5 == 0 {
+			st.SetCode(addr, []byte(fmt.Sprintf("code %v", i)))
+		}
+	}
+	root, err := st.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return db, root
+}
+
+// checkTestState checks the state made by makeTestState is complete in the database
+func checkTestState(db ethdb.Database, root common.Hash, accounts int) error {
+	sdb := state.NewDatabase(db)
+	tr, err := trie.New(root, sdb.TrieDB())
+	if err != nil {
+		return err
+	}
+	var leaves int
+	it := trie.NewIterator(tr.NodeIterator(nil))
+	for it.Next() {
+		leaves++
+		var acc state.Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return err
+		}
+		if acc.Root == types.EmptyRootHash {
+			continue
+		}
+		st, err := trie.New(acc.Root, sdb.TrieDB())
+		if err != nil {
+			return err
+		}
+		sit := trie.NewIterator(st.NodeIterator(nil))
+		for sit.Next() {
+		}
+		if sit.Err != nil {
+			return sit.Err
+		}
+	}
+	if it.Err != nil {
+		return it.Err
+	}
+	if leaves != accounts {
+		return fmt.Errorf("unexpected number of accounts %v / %v", leaves, accounts)
+	}
+
+	st, err := state.New(root, sdb)
+	if err != nil {
+		return err
+	}
+	for i := 0; i != accounts; i++ {
+		addr := testStateAddress(i)
+		if st.GetBalance(addr).Cmp(big.NewInt(int64(i+1))) != 0 {
+			return fmt.Errorf("unexpected balance of account %v", i)
+		}
+		if i%3 == 0 {
+			value := st.GetState(addr, common.BigToHash(big.NewInt(19)))
+			if value != common.BigToHash(big.NewInt(int64(i*100+20))) {
+				return fmt.Errorf("unexpected storage of account %v", i)
+			}
+		}
+		if i%5 == 0 && !bytes.Equal(st.GetCode(addr), []byte(fmt.Sprintf("code %v", i))) {
+			return fmt.Errorf("unexpected code of account %v", i)
+		}
+	}
+	return nil
+}
+
+func testStateAddress(i int) common.Address {
+	return common.BigToAddress(big.NewInt(int64(i + 1)))
+}
+
+// testSnapProtocol serves the ranges and nodes of a state, with few leaves per range.
+// The bad ranges are served by testBadStream.
+type testSnapProtocol struct {
+	*testSyncProtocol
+
+	db           ethdb.Database
+	failAccount  func(origin common.Hash) bool
+	failStorage  func(account common.Hash) bool
+	badAccount   func(origin common.Hash) bool
+	badStorage   func(account common.Hash) bool
+	unknownNodes bool
+
+	badRemoved bool
+	lock       sync.Mutex
+}
+
+const (
+	testSnapRangeLeaves = 16
+
+	testBadStream sttypes.StreamID = "bad stream"
+)
+
+func (sp *testSnapProtocol) RemoveStream(target sttypes.StreamID) {
+	if target == testBadStream {
+		sp.lock.Lock()
+		sp.badRemoved = true
+		sp.lock.Unlock()
+		return
+	}
+	sp.testSyncProtocol.RemoveStream(target)
+}
+
+func (sp *testSnapProtocol) removed() bool {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+
+	return sp.badRemoved
+}
+
+func (sp *testSnapProtocol) GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, sttypes.StreamID, error) {
+	if sp.failAccount != nil && sp.failAccount(origin) {
+		return nil, nil, "", errors.New("state not found")
+	}
+	tr, err := trie.New(root, trie.NewDatabase(sp.db))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	hashes, values := readTestRange(tr, origin, limit)
+	if sp.badAccount != nil && sp.badAccount(origin) && len(hashes) > 1 {
+		// unordered leaves
+		hashes[0], hashes[1] = hashes[1], hashes[0]
+		return hashes, values, testBadStream, nil
+	}
+	return hashes, values, "", nil
+}
+
+func (sp *testSnapProtocol) GetStorageRange(ctx context.Context, root, account, storageRoot, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, sttypes.StreamID, error) {
+	if sp.failStorage != nil && sp.failStorage(account) {
+		return nil, nil, "", errors.New("state not found")
+	}
+	tr, err := trie.New(storageRoot, trie.NewDatabase(sp.db))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	hashes, values := readTestRange(tr, origin, limit)
+	if sp.badStorage != nil && sp.badStorage(account) {
+		// forged slot value
+		if origin == (common.Hash{}) && len(values) != 0 {
+			values[0] = append(common.CopyBytes(values[0]), 0x1)
+		}
+		return hashes, values, testBadStream, nil
+	}
+	return hashes, values, "", nil
+}
+
+func (sp *testSnapProtocol) GetNodeData(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error) {
+	data := make([][]byte, 0, len(hs))
+	for _, h := range hs {
+		var blob []byte
+		if !sp.unknownNodes {
+			blob, _ = sp.db.Get(h[:])
+		}
+		data = append(data, blob)
+	}
+	return data, "", nil
+}
+
+func readTestRange(tr *trie.Trie, origin, limit common.Hash) ([]common.Hash, [][]byte) {
+	var (
+		hashes []common.Hash
+		values [][]byte
+	)
+	it := trie.NewIterator(tr.NodeIterator(origin[:]))
+	for len(hashes) < testSnapRangeLeaves && it.Next() {
+		if bytes.Compare(it.Key, limit[:]) > 0 {
+			break
+		}
+		hashes = append(hashes, common.BytesToHash(it.Key))
+		values = append(values, common.CopyBytes(it.Value))
+	}
+	return hashes, values
+}
@@ -0,0 +1,101 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/shard"
+)
+
+// doSnapSync bootstraps a new node with a snap sync: the blocks up to a recent pivot
+// are verified and stored without being executed, the state of the pivot is
+// downloaded by ranges and healed, and the pivot becomes the head of the chain. The
+// remaining blocks are then synced with a long range sync.
+//
+// Snap sync is only done on an empty shard chain. The beacon chain keeps the staking
+// history derived from the states of the past blocks, so it is always executed.
+func (d *Downloader) doSnapSync() (int, error) {
+	sbc, ok := d.bc.(snapBlockChain)
+	if !ok || d.bc.ShardID() == shard.BeaconChainShardID || d.bc.CurrentBlock().NumberU64() != 0 {
+		return d.doLongRangeSync()
+	}
+	n, err := d.snapSyncToPivot(sbc)
+	if err != nil {
+		return n, err
+	}
+	m, err := d.doLongRangeSync()
+	return n + m, err
+}
+
+// snapSyncToPivot stores the blocks up to the pivot and downloads the state of the
+// pivot. Returns the number of blocks stored.
+func (d *Downloader) snapSyncToPivot(sbc snapBlockChain) (int, error) {
+	d.startSyncing()
+	defer d.finishSyncing()
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer cancel()
+
+	logger := d.logger.With().Str("mode", "snap").Logger()
+	iter := &lrSyncIter{
+		bc:     &snapChain{sbc},
+		p:      d.syncProtocol,
+		d:      d,
+		ctx:    ctx,
+		config: d.config,
+		logger: logger,
+	}
+	if err := iter.checkPrerequisites(); err != nil {
+		return 0, err
+	}
+	bn, err := iter.estimateCurrentNumber()
+	if err != nil {
+		return 0, err
+	}
+	if bn < snapMinBlocks {
+		logger.Info().Uint64("target number", bn).Msg("remote chain too short for snap sync")
+		return 0, nil
+	}
+	pivot := bn - snapPivotDistance
+	logger.Info().Uint64("target number", bn).Uint64("pivot", pivot).Msg("start snap sync")
+	d.status.setTargetBN(bn)
+
+	if err := iter.fetchAndInsertBlocks(pivot); err != nil {
+		return iter.inserted, err
+	}
+	pivotBlock := sbc.CurrentFastBlock()
+	if pivotBlock.NumberU64() < pivot {
+		return iter.inserted, fmt.Errorf("snap sync stopped at block %v before pivot %v",
+			pivotBlock.NumberU64(), pivot)
+	}
+
+	ss := newStateSyncer(ctx, d, pivotBlock.Root(), logger)
+	if err := ss.run(); err != nil {
+		return iter.inserted, errors.Wrap(err, "state sync")
+	}
+	if err := sbc.SnapSyncCommitHead(pivotBlock.Hash()); err != nil {
+		return iter.inserted, errors.Wrap(err, "commit pivot")
+	}
+	logger.Info().Uint64("pivot", pivotBlock.NumberU64()).Str("root", pivotBlock.Root().Hex()).
+		Msg("snap sync finished")
+	return iter.inserted, nil
+}
+
+// snapChain stores the blocks without executing them, so that the long range sync
+// pipeline downloads and verifies the blocks up to the pivot of the snap sync.
+type snapChain struct {
+	snapBlockChain
+}
+
+// CurrentBlock returns the last block stored by the snap sync
+func (sc *snapChain) CurrentBlock() *types.Block {
+	return sc.CurrentFastBlock()
+}
+
+// InsertChain stores the blocks without executing them
+func (sc *snapChain) InsertChain(chain types.Blocks, verifyHeaders bool) (int, error) {
+	return sc.InsertSnapSyncChain(chain)
+}
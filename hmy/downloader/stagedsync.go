@@ -0,0 +1,467 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/pkg/errors"
+)
+
+// Stages of the staged sync which keep a progress checkpoint in database.
+const (
+	stageHashes    = "hashes"
+	stageExecution = "execution"
+)
+
+// doStagedSync does the long range sync as a pipeline of stages running concurrently:
+//  1. Hashes: the canonical block hashes are fetched ahead of the chain and each batch
+//     is cross checked with a second stream. The verified hashes are checkpointed in
+//     database so that an interrupted sync resumes from them.
+//  2. Bodies: the blocks of the verified hashes are fetched from multiple streams.
+//  3. Execution: the blocks are verified and inserted to the blockchain, which also
+//     produces the receipts and state of the blocks.
+func (d *Downloader) doStagedSync() (int, error) {
+	var totalInserted int
+
+	d.startSyncing()
+	defer d.finishSyncing()
+
+	for {
+		ctx, cancel := context.WithCancel(d.ctx)
+
+		iter := &stagedSyncIter{
+			lrSyncIter: &lrSyncIter{
+				bc:     d.bc,
+				p:      d.syncProtocol,
+				d:      d,
+				ctx:    ctx,
+				config: d.config,
+				logger: d.logger.With().Str("mode", "staged").Logger(),
+			},
+			db:     d.db,
+			hashes: make(map[uint64]common.Hash),
+			cancel: cancel,
+		}
+		if err := iter.doStagedSync(); err != nil {
+			cancel()
+			return totalInserted + iter.inserted, err
+		}
+		cancel()
+
+		totalInserted += iter.inserted
+
+		if iter.inserted < lastMileThres {
+			return totalInserted, nil
+		}
+	}
+}
+
+// stagedSyncIter run a single iteration of a staged sync.
+type stagedSyncIter struct {
+	*lrSyncIter
+
+	db ethdb.KeyValueStore
+
+	hashes   map[uint64]common.Hash // verified hashes of the blocks not inserted yet
+	hashesBN uint64                 // block number up to which the hashes are verified
+	hashLock sync.Mutex
+
+	insertFailures int
+	err            error
+	cancel         func()
+}
+
+func (ssi *stagedSyncIter) doStagedSync() error {
+	if err := ssi.checkPrerequisites(); err != nil {
+		return err
+	}
+	bn, err := ssi.estimateCurrentNumber()
+	if err != nil {
+		return err
+	}
+	ssi.logger.Info().Uint64("target number", bn).Msg("estimated remote current number")
+	ssi.d.status.setTargetBN(bn)
+
+	ssi.loadCheckpoint(ssi.bc.CurrentBlock().NumberU64())
+	if err := ssi.verifyCheckpoint(); err != nil {
+		ssi.logger.Warn().Err(err).Uint64("hashes number", ssi.hashesBN).
+			Msg("staged sync checkpoint dropped")
+		ssi.clearCheckpoint()
+	}
+
+	return ssi.fetchAndInsertBlocks(bn)
+}
+
+// loadCheckpoint loads the verified hashes stored by a previous staged sync above
+// the current block number.
+func (ssi *stagedSyncIter) loadCheckpoint(curBN uint64) {
+	ssi.hashesBN = curBN
+
+	progress := rawdb.ReadStagedSyncProgress(ssi.db, stageHashes)
+	if progress <= curBN {
+		return
+	}
+	// Hashes are pruned in insert order, so the ones left below the current block
+	// number by a shutdown are continuous.
+	executed := rawdb.ReadStagedSyncProgress(ssi.db, stageExecution)
+	for bn := curBN; bn > executed; bn-- {
+		if rawdb.ReadStagedSyncHash(ssi.db, bn) == (common.Hash{}) {
+			break
+		}
+		rawdb.DeleteStagedSyncHash(ssi.db, bn)
+	}
+	for bn := curBN + 1; bn <= progress; bn++ {
+		hash := rawdb.ReadStagedSyncHash(ssi.db, bn)
+		if hash == (common.Hash{}) {
+			break
+		}
+		ssi.hashes[bn] = hash
+		ssi.hashesBN = bn
+	}
+	if ssi.hashesBN > curBN {
+		ssi.logger.Info().Uint64("current number", curBN).Uint64("hashes number", ssi.hashesBN).
+			Msg("resuming staged sync from checkpoint")
+	}
+}
+
+// verifyCheckpoint checks the last loaded hash is still canonical on the remote chain.
+// The block hash commits to the parent hash, so the hashes below it are canonical as well.
+func (ssi *stagedSyncIter) verifyCheckpoint() error {
+	if len(ssi.hashes) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ssi.ctx, 10*time.Second)
+	defer cancel()
+
+	hs, _, err := ssi.p.GetBlockHashes(ctx, []uint64{ssi.hashesBN})
+	if err != nil {
+		return err
+	}
+	if len(hs) != 1 || hs[0] != ssi.hashes[ssi.hashesBN] {
+		return errors.New("checkpoint hash not canonical on remote chain")
+	}
+	return nil
+}
+
+// clearCheckpoint removes the verified hashes from memory and database.
+func (ssi *stagedSyncIter) clearCheckpoint() {
+	ssi.hashLock.Lock()
+	defer ssi.hashLock.Unlock()
+
+	for bn := range ssi.hashes {
+		rawdb.DeleteStagedSyncHash(ssi.db, bn)
+	}
+	rawdb.WriteStagedSyncProgress(ssi.db, stageHashes, 0)
+	ssi.hashes = make(map[uint64]common.Hash)
+	ssi.hashesBN = ssi.bc.CurrentBlock().NumberU64()
+}
+
+func (ssi *stagedSyncIter) fetchAndInsertBlocks(targetBN uint64) error {
+	gbm := newGetBlocksManager(ssi.bc, ssi.hashesBN, ssi.logger)
+	ssi.gbm = gbm
+
+	go ssi.hashesLoop(targetBN)
+
+	for i := 0; i != ssi.config.Concurrency; i++ {
+		worker := &getBodiesWorker{ssi: ssi}
+		go worker.workLoop()
+	}
+
+	ssi.insertChainLoop(targetBN, ssi.processBlocks)
+
+	if ssi.err != nil {
+		return ssi.err
+	}
+	select {
+	case <-ssi.ctx.Done():
+		return ssi.ctx.Err()
+	default:
+	}
+	return nil
+}
+
+// hashesLoop fetches the verified block hashes up to the target block number, no
+// more than stagedHashesAhead blocks ahead of the blockchain.
+func (ssi *stagedSyncIter) hashesLoop(targetBN uint64) {
+	for {
+		select {
+		case <-ssi.ctx.Done():
+			return
+		default:
+		}
+
+		batches := ssi.nextHashBatches(targetBN)
+		committed := 0
+		if len(batches) != 0 {
+			results := make([][]common.Hash, len(batches))
+
+			var wg sync.WaitGroup
+			wg.Add(len(batches))
+			for i, bns := range batches {
+				go func(i int, bns []uint64) {
+					defer wg.Done()
+
+					hs, err := ssi.doHashesBatch(bns)
+					if err != nil {
+						ssi.logger.Warn().Err(err).Uint64("from", bns[0]).Msg("get block hashes failed")
+						return
+					}
+					results[i] = hs
+				}(i, bns)
+			}
+			wg.Wait()
+
+			committed = ssi.commitHashes(batches, results)
+		}
+		if committed == 0 {
+			if ssi.getHashesBN() >= targetBN {
+				return
+			}
+			select {
+			case <-ssi.ctx.Done():
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+	}
+}
+
+// nextHashBatches get the next block number batches of the hashes stage
+func (ssi *stagedSyncIter) nextHashBatches(targetBN uint64) [][]uint64 {
+	end := ssi.bc.CurrentBlock().NumberU64() + stagedHashesAhead
+	if end > targetBN {
+		end = targetBN
+	}
+	var batches [][]uint64
+	for bn := ssi.getHashesBN() + 1; bn <= end && len(batches) < ssi.config.Concurrency; {
+		var bns []uint64
+		for ; bn <= end && len(bns) < numBlockHashesPerStagedRequest; bn++ {
+			bns = append(bns, bn)
+		}
+		batches = append(batches, bns)
+	}
+	return batches
+}
+
+// doHashesBatch gets the block hashes of the batch from a stream, and cross checks
+// them with another stream.
+func (ssi *stagedSyncIter) doHashesBatch(bns []uint64) ([]common.Hash, error) {
+	ctx, cancel := context.WithTimeout(ssi.ctx, 10*time.Second)
+	defer cancel()
+
+	hs, stid, err := ssi.p.GetBlockHashes(ctx, bns)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			ssi.p.RemoveStream(stid)
+		}
+		return nil, err
+	}
+	if len(hs) != len(bns) {
+		ssi.p.RemoveStream(stid)
+		return nil, fmt.Errorf("unexpected number of block hashes delivered: %v / %v", len(hs), len(bns))
+	}
+	for i, h := range hs {
+		if h == emptyHash {
+			return nil, fmt.Errorf("block hash not delivered for %v", bns[i])
+		}
+	}
+
+	crossHs, crossStid, err := ssi.p.GetBlockHashes(ctx, bns,
+		syncproto.WithBlacklist([]sttypes.StreamID{stid}))
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			ssi.p.RemoveStream(crossStid)
+		}
+		return nil, err
+	}
+	if len(crossHs) != len(hs) {
+		ssi.p.RemoveStream(crossStid)
+		return nil, fmt.Errorf("unexpected number of block hashes delivered: %v / %v", len(crossHs), len(bns))
+	}
+	for i := range hs {
+		if hs[i] != crossHs[i] {
+			return nil, fmt.Errorf("block hash of %v mismatch between streams %v and %v",
+				bns[i], stid, crossStid)
+		}
+	}
+	return hs, nil
+}
+
+// commitHashes stores the verified hashes of the batches continuous with the hashes
+// already verified, and makes their blocks available for download. Returns the
+// number of hashes committed.
+func (ssi *stagedSyncIter) commitHashes(batches [][]uint64, results [][]common.Hash) int {
+	var (
+		batch     = ssi.db.NewBatch()
+		committed = make(map[uint64]common.Hash)
+		last      uint64
+	)
+	for i, bns := range batches {
+		if results[i] == nil {
+			break
+		}
+		for j, bn := range bns {
+			rawdb.WriteStagedSyncHash(batch, bn, results[i][j])
+			committed[bn] = results[i][j]
+			last = bn
+		}
+	}
+	if len(committed) == 0 {
+		return 0
+	}
+	rawdb.WriteStagedSyncProgress(batch, stageHashes, last)
+	if err := batch.Write(); err != nil {
+		ssi.logger.Warn().Err(err).Msg("failed to write staged sync hashes")
+		return 0
+	}
+
+	ssi.hashLock.Lock()
+	for bn, hash := range committed {
+		ssi.hashes[bn] = hash
+	}
+	ssi.hashesBN = last
+	ssi.hashLock.Unlock()
+
+	ssi.gbm.SetTargetBN(last)
+	return len(committed)
+}
+
+func (ssi *stagedSyncIter) getHashesBN() uint64 {
+	ssi.hashLock.Lock()
+	defer ssi.hashLock.Unlock()
+
+	return ssi.hashesBN
+}
+
+func (ssi *stagedSyncIter) getHashes(bns []uint64) ([]common.Hash, error) {
+	ssi.hashLock.Lock()
+	defer ssi.hashLock.Unlock()
+
+	hs := make([]common.Hash, 0, len(bns))
+	for _, bn := range bns {
+		hash, ok := ssi.hashes[bn]
+		if !ok {
+			return nil, fmt.Errorf("block hash of %v not verified", bn)
+		}
+		hs = append(hs, hash)
+	}
+	return hs, nil
+}
+
+// processBlocks inserts the blocks to the chain and prunes their hashes. After
+// consecutive insert failures, the iteration is aborted and the checkpoint dropped.
+func (ssi *stagedSyncIter) processBlocks(results []*blockResult, targetBN uint64) {
+	blocks := blockResultsToBlocks(results)
+
+	for i, block := range blocks {
+		if err := verifyAndInsertBlock(ssi.bc, block); err != nil {
+			ssi.logger.Warn().Err(err).Uint64("target block", targetBN).
+				Uint64("block number", block.NumberU64()).
+				Msg("insert blocks failed in staged sync")
+			pl := ssi.d.promLabels()
+			pl["error"] = err.Error()
+			longRangeFailInsertedBlockCounterVec.With(pl).Inc()
+
+			ssi.p.RemoveStream(results[i].stid)
+			ssi.gbm.HandleInsertError(results, i)
+			ssi.pruneHashes(blocks[:i])
+
+			ssi.insertFailures++
+			if ssi.insertFailures >= stagedMaxInsertFailures {
+				ssi.clearCheckpoint()
+				ssi.err = errors.Wrap(err, "staged sync insert failed")
+				ssi.cancel()
+			}
+			return
+		}
+
+		ssi.inserted++
+		ssi.insertFailures = 0
+		longRangeSyncedBlockCounterVec.With(ssi.d.promLabels()).Inc()
+	}
+	ssi.gbm.HandleInsertResult(results)
+	ssi.pruneHashes(blocks)
+}
+
+// pruneHashes removes the hashes of the inserted blocks and updates the checkpoint
+// of the execution stage.
+func (ssi *stagedSyncIter) pruneHashes(blocks types.Blocks) {
+	if len(blocks) == 0 {
+		return
+	}
+	batch := ssi.db.NewBatch()
+	rawdb.WriteStagedSyncProgress(batch, stageExecution, blocks[len(blocks)-1].NumberU64())
+
+	ssi.hashLock.Lock()
+	for _, block := range blocks {
+		delete(ssi.hashes, block.NumberU64())
+		rawdb.DeleteStagedSyncHash(batch, block.NumberU64())
+	}
+	ssi.hashLock.Unlock()
+
+	if err := batch.Write(); err != nil {
+		ssi.logger.Warn().Err(err).Msg("failed to prune staged sync hashes")
+	}
+}
+
+// getBodiesWorker does the request job of the bodies stage
+type getBodiesWorker struct {
+	ssi *stagedSyncIter
+}
+
+func (w *getBodiesWorker) workLoop() {
+	gbm := w.ssi.gbm
+	for {
+		select {
+		case <-w.ssi.ctx.Done():
+			return
+		default:
+		}
+		batch := gbm.GetNextBatch()
+		if len(batch) == 0 {
+			select {
+			case <-w.ssi.ctx.Done():
+				return
+			case <-time.After(100 * time.Millisecond):
+				continue
+			}
+		}
+
+		blocks, stid, err := w.doBatch(batch)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) && stid != "" {
+				w.ssi.p.RemoveStream(stid)
+			}
+			err = errors.Wrap(err, "request error")
+			gbm.HandleRequestError(batch, err, stid)
+		} else {
+			gbm.HandleRequestResult(batch, blocks, stid)
+		}
+	}
+}
+
+func (w *getBodiesWorker) doBatch(bns []uint64) ([]*types.Block, sttypes.StreamID, error) {
+	hs, err := w.ssi.getHashes(bns)
+	if err != nil {
+		return nil, "", err
+	}
+	ctx, cancel := context.WithTimeout(w.ssi.ctx, 10*time.Second)
+	defer cancel()
+
+	blocks, stid, err := w.ssi.p.GetBlocksByHashes(ctx, hs)
+	if err != nil {
+		return nil, stid, err
+	}
+	if err := checkGetBlockByHashesResult(blocks, hs); err != nil {
+		return nil, stid, err
+	}
+	return blocks, stid, nil
+}
@@ -0,0 +1,157 @@
+package downloader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethRawdb "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/rawdb"
+)
+
+func TestDownloader_doStagedSync(t *testing.T) {
+	targetBN := uint64(1000)
+	bc := newTestBlockChain(1, nil)
+
+	d := &Downloader{
+		bc:           bc,
+		syncProtocol: newTestSyncProtocol(targetBN, 32, nil),
+		db:           ethRawdb.NewMemoryDatabase(),
+		config: Config{
+			Concurrency: 16,
+			MinStreams:  16,
+		},
+		ctx: context.Background(),
+	}
+	synced, err := d.doStagedSync()
+	if err != nil {
+		t.Error(err)
+	}
+	if synced == 0 {
+		t.Errorf("synced false")
+	}
+	if curNum := d.bc.CurrentBlock().NumberU64(); curNum != targetBN {
+		t.Errorf("block number not expected: %v / %v", curNum, targetBN)
+	}
+	if progress := rawdb.ReadStagedSyncProgress(d.db, stageHashes); progress != targetBN {
+		t.Errorf("hashes progress not expected: %v / %v", progress, targetBN)
+	}
+	if progress := rawdb.ReadStagedSyncProgress(d.db, stageExecution); progress != targetBN {
+		t.Errorf("execution progress not expected: %v / %v", progress, targetBN)
+	}
+	for bn := uint64(2); bn <= targetBN; bn++ {
+		if hash := rawdb.ReadStagedSyncHash(d.db, bn); hash != (common.Hash{}) {
+			t.Fatalf("hash of %v not pruned", bn)
+		}
+	}
+}
+
+func TestStagedSyncIter_loadCheckpoint(t *testing.T) {
+	tests := []struct {
+		curBN       uint64
+		progress    uint64
+		executed    uint64
+		stored      []uint64
+		expHashesBN uint64
+		expStored   []uint64
+	}{
+		{
+			// no checkpoint
+			curBN:       10,
+			expHashesBN: 10,
+		},
+		{
+			curBN:       10,
+			progress:    20,
+			executed:    10,
+			stored:      []uint64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+			expHashesBN: 20,
+			expStored:   []uint64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+		},
+		{
+			// stale hashes below the current block number
+			curBN:       12,
+			progress:    15,
+			executed:    8,
+			stored:      []uint64{9, 10, 11, 12, 13, 14, 15},
+			expHashesBN: 15,
+			expStored:   []uint64{13, 14, 15},
+		},
+		{
+			// hashes loaded until the first missing
+			curBN:       10,
+			progress:    15,
+			executed:    10,
+			stored:      []uint64{11, 12, 14, 15},
+			expHashesBN: 12,
+			expStored:   []uint64{11, 12, 14, 15},
+		},
+	}
+	for i, test := range tests {
+		db := ethRawdb.NewMemoryDatabase()
+		rawdb.WriteStagedSyncProgress(db, stageHashes, test.progress)
+		rawdb.WriteStagedSyncProgress(db, stageExecution, test.executed)
+		for _, bn := range test.stored {
+			rawdb.WriteStagedSyncHash(db, bn, makeTestBlockHash(bn))
+		}
+
+		ssi := &stagedSyncIter{
+			lrSyncIter: &lrSyncIter{},
+			db:         db,
+			hashes:     make(map[uint64]common.Hash),
+		}
+		ssi.loadCheckpoint(test.curBN)
+
+		if ssi.hashesBN != test.expHashesBN {
+			t.Errorf("Test %v: unexpected hashes number: %v / %v", i, ssi.hashesBN, test.expHashesBN)
+		}
+		if len(ssi.hashes) != int(test.expHashesBN-test.curBN) {
+			t.Errorf("Test %v: unexpected loaded hashes: %v / %v", i, len(ssi.hashes), test.expHashesBN-test.curBN)
+		}
+		expStored := make(map[uint64]struct{})
+		for _, bn := range test.expStored {
+			expStored[bn] = struct{}{}
+		}
+		for _, bn := range test.stored {
+			_, exp := expStored[bn]
+			if stored := rawdb.ReadStagedSyncHash(db, bn) != (common.Hash{}); stored != exp {
+				t.Errorf("Test %v: unexpected hash of %v stored: %v / %v", i, bn, stored, exp)
+			}
+		}
+	}
+}
+
+func TestDownloader_doStagedSync_resume(t *testing.T) {
+	var (
+		targetBN = uint64(1000)
+		bc       = newTestBlockChain(100, nil)
+		db       = ethRawdb.NewMemoryDatabase()
+	)
+	// checkpoint of an interrupted sync, the last hash no longer canonical
+	rawdb.WriteStagedSyncProgress(db, stageHashes, 300)
+	rawdb.WriteStagedSyncProgress(db, stageExecution, 100)
+	for bn := uint64(101); bn < 300; bn++ {
+		rawdb.WriteStagedSyncHash(db, bn, makeTestBlockHash(bn))
+	}
+	rawdb.WriteStagedSyncHash(db, 300, common.Hash{1})
+
+	d := &Downloader{
+		bc:           bc,
+		syncProtocol: newTestSyncProtocol(targetBN, 32, nil),
+		db:           db,
+		config: Config{
+			Concurrency: 16,
+			MinStreams:  16,
+		},
+		ctx: context.Background(),
+	}
+	if _, err := d.doStagedSync(); err != nil {
+		t.Error(err)
+	}
+	if curNum := d.bc.CurrentBlock().NumberU64(); curNum != targetBN {
+		t.Errorf("block number not expected: %v / %v", curNum, targetBN)
+	}
+	if hash := rawdb.ReadStagedSyncHash(db, 300); hash != (common.Hash{}) {
+		t.Errorf("non canonical hash not dropped")
+	}
+}
@@ -0,0 +1,149 @@
+package hmy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxFeeHistory is the maximum number of blocks that can be retrieved
+	// in a single fee history request.
+	maxFeeHistory = 1024
+)
+
+var (
+	errInvalidBlockCount = errors.New("block count must be positive")
+	errInvalidPercentile = errors.New("invalid reward percentile")
+	errRequestBeyondHead = errors.New("request beyond head block")
+)
+
+// FeeHistory returns the base fee, gas used ratio and the requested tip
+// percentiles of up to maxFeeHistory blocks ending at lastBlock. The base
+// fee slice has one extra entry holding the base fee of the block following
+// lastBlock. Tip percentiles are weighted by the gas used of each transaction.
+// The pending block is not produced by RPC nodes, so it is the latest block.
+func (hmy *Harmony) FeeHistory(
+	ctx context.Context, blocks uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64,
+) (oldest *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, err error) {
+	if blocks == 0 {
+		return nil, nil, nil, nil, errInvalidBlockCount
+	}
+	if blocks > maxFeeHistory {
+		blocks = maxFeeHistory
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, nil, nil, nil, errors.Wrapf(errInvalidPercentile, "%f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, nil, nil, nil, errors.Wrapf(
+				errInvalidPercentile, "#%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p,
+			)
+		}
+	}
+
+	head := hmy.CurrentBlock().NumberU64()
+	last := head
+	if lastBlock != rpc.LatestBlockNumber && lastBlock != rpc.PendingBlockNumber {
+		if lastBlock < 0 || uint64(lastBlock) > head {
+			return nil, nil, nil, nil, errors.Wrapf(
+				errRequestBeyondHead, "requested %d, head %d", lastBlock, head,
+			)
+		}
+		last = uint64(lastBlock)
+	}
+	if blocks > last+1 {
+		blocks = last + 1
+	}
+	first := last + 1 - blocks
+
+	reward = make([][]*big.Int, 0, blocks)
+	baseFee = make([]*big.Int, 0, blocks+1)
+	gasUsedRatio = make([]float64, 0, blocks)
+	var header *block.Header
+	for number := first; number <= last; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		blk := hmy.BlockChain.GetBlockByNumber(number)
+		if blk == nil {
+			return nil, nil, nil, nil, fmt.Errorf("block %d not found", number)
+		}
+		header = blk.Header()
+		fee := header.BaseFee()
+		if fee == nil {
+			fee = new(big.Int)
+		}
+		baseFee = append(baseFee, fee)
+		if header.GasLimit() > 0 {
+			gasUsedRatio = append(gasUsedRatio, float64(header.GasUsed())/float64(header.GasLimit()))
+		} else {
+			gasUsedRatio = append(gasUsedRatio, 0)
+		}
+		if len(rewardPercentiles) > 0 {
+			receipts := hmy.BlockChain.GetReceiptsByHash(blk.Hash())
+			reward = append(reward, blockRewardPercentiles(blk, receipts, fee, rewardPercentiles))
+		}
+	}
+	next := new(big.Int)
+	if hmy.ChainConfig().IsLondon(header.Epoch()) {
+		next = core.CalcBaseFee(hmy.ChainConfig(), header)
+	}
+	baseFee = append(baseFee, next)
+	if len(rewardPercentiles) == 0 {
+		reward = nil
+	}
+	return new(big.Int).SetUint64(first), reward, baseFee, gasUsedRatio, nil
+}
+
+// blockRewardPercentiles computes the effective tips paid at the given
+// percentiles of the gas used by the plain transactions of a block.
+func blockRewardPercentiles(
+	blk *types.Block, receipts types.Receipts, baseFee *big.Int, percentiles []float64,
+) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	txs := blk.Transactions()
+	if len(txs) == 0 || len(receipts) < len(txs) {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+	type txGasAndReward struct {
+		gasUsed uint64
+		reward  *big.Int
+	}
+	sorter := make([]txGasAndReward, len(txs))
+	var totalGas uint64
+	for i, tx := range txs {
+		tip := tx.EffectiveGasTip(baseFee)
+		if tip.Sign() < 0 {
+			tip = new(big.Int)
+		}
+		sorter[i] = txGasAndReward{gasUsed: receipts[i].GasUsed, reward: tip}
+		totalGas += receipts[i].GasUsed
+	}
+	sort.SliceStable(sorter, func(i, j int) bool {
+		return sorter[i].reward.Cmp(sorter[j].reward) < 0
+	})
+
+	var txIndex int
+	sumGasUsed := sorter[0].gasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(totalGas) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorter)-1 {
+			txIndex++
+			sumGasUsed += sorter[txIndex].gasUsed
+		}
+		rewards[i] = sorter[txIndex].reward
+	}
+	return rewards
+}
@@ -0,0 +1,135 @@
+package hmy
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/pkg/errors"
+)
+
+func TestBlockRewardPercentiles(t *testing.T) {
+	baseFee := big.NewInt(1e9)
+	to := common.HexToAddress("0x2000")
+	// the tips are 4, 0 and 2 gwei, the second transaction pays less than the
+	// base fee, and they are weighted by the gas used of their receipt
+	txs := []*types.Transaction{
+		types.NewTransaction(0, to, 0, new(big.Int), params.TxGas, big.NewInt(5e9), nil),
+		types.NewTransaction(1, to, 0, new(big.Int), params.TxGas, big.NewInt(5e8), nil),
+		types.NewTransaction(2, to, 0, new(big.Int), params.TxGas, big.NewInt(3e9), nil),
+	}
+	receipts := types.Receipts{{GasUsed: 21000}, {GasUsed: 42000}, {GasUsed: 21000}}
+	blk := types.NewBlock(blockfactory.NewTestHeader(), txs, receipts, nil, nil, nil)
+
+	percentiles := []float64{0, 50, 60, 75, 76, 100}
+	want := []int64{0, 0, 2e9, 2e9, 4e9, 4e9}
+	rewards := blockRewardPercentiles(blk, receipts, baseFee, percentiles)
+	if len(rewards) != len(want) {
+		t.Fatalf("have %d rewards, want %d", len(rewards), len(want))
+	}
+	for i, reward := range rewards {
+		if reward.Cmp(big.NewInt(want[i])) != 0 {
+			t.Errorf("percentile %v: have reward %v, want %d", percentiles[i], reward, want[i])
+		}
+	}
+
+	// the rewards of a block without transactions, or whose receipts are
+	// missing, are zero
+	empty := types.NewBlock(blockfactory.NewTestHeader(), nil, nil, nil, nil, nil)
+	for _, test := range []struct {
+		blk      *types.Block
+		receipts types.Receipts
+	}{
+		{empty, nil},
+		{blk, receipts[:1]},
+	} {
+		rewards := blockRewardPercentiles(test.blk, test.receipts, baseFee, percentiles)
+		if len(rewards) != len(percentiles) {
+			t.Fatalf("have %d rewards, want %d", len(rewards), len(percentiles))
+		}
+		for i, reward := range rewards {
+			if reward.Sign() != 0 {
+				t.Errorf("percentile %v: have reward %v, want 0", percentiles[i], reward)
+			}
+		}
+	}
+}
+
+func TestFeeHistory(t *testing.T) {
+	hmy := newTestHarmony(t, nil)
+	to := common.HexToAddress("0x2000")
+	addTestBlock(t, hmy, func(nonce uint64) []*types.Transaction {
+		return []*types.Transaction{
+			types.NewTransaction(nonce, to, 0, big.NewInt(1), params.TxGas, big.NewInt(2e9), nil),
+			types.NewTransaction(nonce+1, to, 0, big.NewInt(1), params.TxGas, big.NewInt(4e9), nil),
+		}
+	})
+	addTestBlock(t, hmy, func(uint64) []*types.Transaction { return nil })
+	ctx := context.Background()
+
+	oldest, reward, baseFee, gasUsedRatio, err := hmy.FeeHistory(ctx, 2, rpc.LatestBlockNumber, []float64{0, 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldest.Uint64() != 1 {
+		t.Errorf("have oldest block %v, want 1", oldest)
+	}
+	if len(reward) != 2 || len(baseFee) != 3 || len(gasUsedRatio) != 2 {
+		t.Fatalf("have %d rewards, %d base fees and %d gas used ratios, want 2, 3 and 2", len(reward), len(baseFee), len(gasUsedRatio))
+	}
+	// the first block pays tips in increasing order of the percentiles, the
+	// second block is empty
+	fee := baseFee[0]
+	for i, want := range []*big.Int{
+		new(big.Int).Sub(big.NewInt(2e9), fee), new(big.Int).Sub(big.NewInt(4e9), fee), new(big.Int), new(big.Int),
+	} {
+		if have := reward[i/2][i%2]; have.Cmp(want) != 0 {
+			t.Errorf("block %d percentile %d: have reward %v, want %v", i/2+1, i%2, have, want)
+		}
+	}
+	if gasUsedRatio[0] <= 0 || gasUsedRatio[1] != 0 {
+		t.Errorf("have gas used ratios %v, want a positive one then 0", gasUsedRatio)
+	}
+
+	// the pending block is the latest block
+	pendingOldest, pendingReward, pendingBaseFee, _, err := hmy.FeeHistory(ctx, 2, rpc.PendingBlockNumber, []float64{0, 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pendingOldest.Cmp(oldest) != 0 || len(pendingReward) != len(reward) || len(pendingBaseFee) != len(baseFee) {
+		t.Errorf("have pending history from %v of %d blocks, want the latest one", pendingOldest, len(pendingReward))
+	}
+
+	// the block count is clamped to the blocks up to the last one
+	for _, blocks := range []uint64{3, maxFeeHistory + 1, math.MaxUint64} {
+		oldest, reward, baseFee, gasUsedRatio, err := hmy.FeeHistory(ctx, blocks, 1, nil)
+		if err != nil {
+			t.Fatalf("%d blocks: %v", blocks, err)
+		}
+		if oldest.Sign() != 0 || reward != nil || len(baseFee) != 3 || len(gasUsedRatio) != 2 {
+			t.Errorf("%d blocks: have history from %v with %d base fees, want it from 0 with 3", blocks, oldest, len(baseFee))
+		}
+	}
+
+	for _, test := range []struct {
+		blocks      uint64
+		lastBlock   rpc.BlockNumber
+		percentiles []float64
+		err         error
+	}{
+		{0, rpc.LatestBlockNumber, nil, errInvalidBlockCount},
+		{1, 3, nil, errRequestBeyondHead},
+		{1, rpc.LatestBlockNumber, []float64{50, 10}, errInvalidPercentile},
+		{1, rpc.LatestBlockNumber, []float64{101}, errInvalidPercentile},
+	} {
+		if _, _, _, _, err := hmy.FeeHistory(ctx, test.blocks, test.lastBlock, test.percentiles); errors.Cause(err) != test.err {
+			t.Errorf("%d blocks up to %d at %v: have error %v, want %v", test.blocks, test.lastBlock, test.percentiles, err, test.err)
+		}
+	}
+}
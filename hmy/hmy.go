@@ -3,6 +3,8 @@ package hmy
 import (
 	"context"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -15,8 +17,12 @@ import (
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/verify"
 	"github.com/harmony-one/harmony/core/vm"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/p2p/security"
 	commonRPC "github.com/harmony-one/harmony/rpc/common"
 	"github.com/harmony-one/harmony/shard"
 	staking "github.com/harmony-one/harmony/staking/types"
@@ -51,15 +57,14 @@ type Harmony struct {
 	TxPool        *core.TxPool
 	CxPool        *core.CxPool // CxPool is used to store the blockHashes of blocks containing cx receipts to be sent
 	// DB interfaces
-	BloomIndexer *core.ChainIndexer // Bloom indexer operating during block imports
-	NodeAPI      NodeAPI
+	BloomIndexer      *core.ChainIndexer // Bloom indexer operating during block imports
+	TraceBloomIndexer *core.ChainIndexer // Trace bloom indexer, nil if the trace index is disabled
+	NodeAPI           NodeAPI
 	// ChainID is used to identify which network we are using
 	ChainID uint64
 	// EthCompatibleChainID is used to identify the Ethereum compatible chain ID
 	EthChainID uint64
-	// RPCGasCap is the global gas cap for eth-call variants.
-	RPCGasCap *big.Int `toml:",omitempty"`
-	ShardID   uint32
+	ShardID    uint32
 
 	// Gas price suggestion oracle
 	gpo *Oracle
@@ -77,6 +82,18 @@ type Harmony struct {
 	preStakingBlockRewardsCache *lru.Cache
 	// totalStakeCache to save on recomputation for `totalStakeCacheDuration` blocks.
 	totalStakeCache *totalStakeCache
+	// limitsLock guards the limits below, which may be changed at runtime.
+	limitsLock sync.RWMutex
+	// rpcGasCap is the global gas cap for eth-call variants.
+	rpcGasCap *big.Int
+	// traceTimeout is the execution timeout of a single trace request, zero means no timeout.
+	traceTimeout time.Duration
+	// traceSlots limits the number of trace requests executing concurrently.
+	traceSlots chan struct{}
+	// traceMaxReexec bounds the number of blocks re-executed by a trace, zero means no bound.
+	traceMaxReexec uint64
+	// traceCache keeps the results of recently traced blocks.
+	traceCache *traceCache
 }
 
 // NodeAPI is the list of functions from node used to call rpc apis.
@@ -101,6 +118,15 @@ type NodeAPI interface {
 	ListPeer(topic string) []peer.ID
 	ListTopic() []string
 	ListBlockedPeer() []peer.ID
+	PeerScores() []security.PeerScore
+	SetPeerScore(id peer.ID, score float64)
+	NodeInfo() p2p.NodeInfo
+	PeerInfos() []p2p.PeerInfo
+	SyncStreamPeers() map[uint32][]peer.ID
+	ConnectPeer(ctx context.Context, ai peer.AddrInfo) error
+	DisconnectPeer(id peer.ID) error
+	RuntimeConfig() harmonyconfig.RuntimeConfig
+	SetRuntimeConfig(cfg harmonyconfig.RuntimeConfig) error
 
 	GetConsensusInternal() commonRPC.ConsensusInternal
 	IsBackup() bool
@@ -114,6 +140,7 @@ type NodeAPI interface {
 	GetConfig() commonRPC.Config
 	ShutDown()
 	GetLastSigningPower() (float64, error)
+	NodeDataFetcher() verify.NodeFetcher
 }
 
 // New creates a new Harmony object (including the
@@ -161,6 +188,19 @@ func New(
 	return backend
 }
 
+// NewOffline creates a Harmony object serving the tracer APIs of the given chain
+// without a running node, for the offline tools. The APIs depending on the node,
+// the pools or the indexers are not available.
+func NewOffline(bc *core.BlockChain) *Harmony {
+	return &Harmony{
+		BlockChain: bc,
+		chainDb:    bc.ChainDb(),
+		ChainID:    bc.Config().ChainID.Uint64(),
+		EthChainID: bc.Config().EthCompatibleChainID.Uint64(),
+		ShardID:    bc.ShardID(),
+	}
+}
+
 // SingleFlightRequest ..
 func (hmy *Harmony) SingleFlightRequest(
 	key string,
@@ -235,7 +275,31 @@ func (hmy *Harmony) GetNodeMetadata() commonRPC.NodeMetadata {
 func (hmy *Harmony) GetEVM(ctx context.Context, msg core.Message, state *state.DB, header *block.Header) (*vm.EVM, error) {
 	state.SetBalance(msg.From(), math.MaxBig256)
 	vmCtx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
-	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), *hmy.BlockChain.GetVMConfig()), nil
+	vmCfg := *hmy.BlockChain.GetVMConfig()
+	vmCfg.NoBaseFee = true
+	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), vmCfg), nil
+}
+
+// SetRPCGasCap sets the global gas cap of eth_call and eth_estimateGas. Zero
+// leaves the gas of calls uncapped.
+func (hmy *Harmony) SetRPCGasCap(gasCap uint64) {
+	hmy.limitsLock.Lock()
+	defer hmy.limitsLock.Unlock()
+
+	if gasCap > 0 {
+		hmy.rpcGasCap = new(big.Int).SetUint64(gasCap)
+	} else {
+		hmy.rpcGasCap = nil
+	}
+}
+
+// RPCGasCap returns the global gas cap of eth_call and eth_estimateGas, nil if
+// the gas of calls is uncapped.
+func (hmy *Harmony) RPCGasCap() *big.Int {
+	hmy.limitsLock.RLock()
+	defer hmy.limitsLock.RUnlock()
+
+	return hmy.rpcGasCap
 }
 
 // ChainDb ..
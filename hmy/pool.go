@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/types"
 )
 
@@ -48,6 +49,18 @@ func (hmy *Harmony) GetPoolTransactions() (types.PoolTransactions, error) {
 	return txs, nil
 }
 
+// GetPoolContent returns the pending and queued pool transactions, both plain
+// and staking, grouped by sender and sorted by nonce.
+func (hmy *Harmony) GetPoolContent() (pending, queued map[common.Address]types.PoolTransactions) {
+	return hmy.TxPool.Content()
+}
+
+// GetPoolReplacements returns the last attempts to replace a pool transaction,
+// from the oldest to the newest, and the minimum price bump in percent.
+func (hmy *Harmony) GetPoolReplacements() ([]core.TxReplacement, uint64) {
+	return hmy.TxPool.Replacements(), hmy.TxPool.PriceBump()
+}
+
 func (hmy *Harmony) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return hmy.gpo.SuggestPrice(ctx)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/harmony-one/harmony/block"
@@ -20,6 +21,7 @@ import (
 	"github.com/harmony-one/harmony/shard/committee"
 	"github.com/harmony-one/harmony/staking/availability"
 	"github.com/harmony-one/harmony/staking/effective"
+	"github.com/harmony-one/harmony/staking/slash"
 	staking "github.com/harmony-one/harmony/staking/types"
 	"github.com/pkg/errors"
 )
@@ -429,6 +431,96 @@ func (hmy *Harmony) GetValidatorInformation(
 	return defaultReply, nil
 }
 
+// GetValidatorPerformance returns the performance of the validator over each epoch
+// from fromEpoch to toEpoch, inclusive, computed from its snapshots at the epoch
+// boundaries. The current epoch is measured up to the current block.
+func (hmy *Harmony) GetValidatorPerformance(
+	addr common.Address, fromEpoch, toEpoch uint64,
+) ([]*staking.EpochPerformance, error) {
+	bc := hmy.BlockChain
+	current := bc.CurrentBlock().Header()
+	if toEpoch > current.Epoch().Uint64() {
+		return nil, errors.Errorf(
+			"epoch %d is beyond the current epoch %d", toEpoch, current.Epoch().Uint64(),
+		)
+	}
+	if fromEpoch < bc.Config().StakingEpoch.Uint64() {
+		return nil, errors.Errorf(
+			"epoch %d is before the staking epoch %d", fromEpoch, bc.Config().StakingEpoch.Uint64(),
+		)
+	}
+
+	perfs := make([]*staking.EpochPerformance, 0, toEpoch-fromEpoch+1)
+	for number := fromEpoch; number <= toEpoch; number++ {
+		epoch := new(big.Int).SetUint64(number)
+		begin, err := bc.ReadValidatorSnapshotAtEpoch(epoch, addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "no snapshot of the validator at epoch %d", number)
+		}
+		// The state at the end of an epoch is the snapshot of the next one
+		var end *staking.ValidatorWrapper
+		endHeader := current
+		if number == current.Epoch().Uint64() {
+			end, err = bc.ReadValidatorInformationAtRoot(addr, current.Root())
+		} else {
+			var snapshot *staking.ValidatorSnapshot
+			snapshot, err = bc.ReadValidatorSnapshotAtEpoch(new(big.Int).SetUint64(number+1), addr)
+			if snapshot != nil {
+				end = snapshot.Validator
+			}
+			endHeader = bc.GetHeaderByNumber(shard.Schedule.EpochLastBlock(number))
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "no state of the validator at the end of epoch %d", number)
+		}
+		shardState, err := bc.ReadShardState(epoch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "no shard state at epoch %d", number)
+		}
+		duration := int64(0)
+		beginHeader := bc.GetHeaderByNumber(shard.Schedule.EpochLastBlock(number - 1))
+		if beginHeader != nil && endHeader != nil {
+			duration = new(big.Int).Sub(endHeader.Time(), beginHeader.Time()).Int64()
+		}
+		perfs = append(perfs, availability.ComputeEpochPerformance(
+			epoch, begin.Validator, end, shardState, duration,
+		))
+	}
+	return perfs, nil
+}
+
+// GetSlashingHistory returns the slashes applied from epoch fromEpoch to toEpoch,
+// inclusive, to the given validator, or to any validator if it is nil.
+func (hmy *Harmony) GetSlashingHistory(
+	validator *common.Address, fromEpoch, toEpoch uint64,
+) ([]*slash.HistoryEntry, error) {
+	history, err := hmy.BlockChain.ReadSlashHistory(fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	if validator == nil {
+		return history, nil
+	}
+	filtered := []*slash.HistoryEntry{}
+	for _, entry := range history {
+		if entry.Record.Evidence.Offender == *validator {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+// GetRewardHistory returns the rewards paid to the delegator from epoch fromEpoch
+// to toEpoch, inclusive, by epoch and validator.
+func (hmy *Harmony) GetRewardHistory(
+	delegator common.Address, fromEpoch, toEpoch uint64,
+) ([]*staking.RewardHistoryEntry, error) {
+	if !hmy.BlockChain.IsRewardHistoryIndexEnabled() {
+		return nil, errors.New("reward history index is not enabled")
+	}
+	return hmy.BlockChain.ReadRewardHistory(delegator, fromEpoch, toEpoch)
+}
+
 // GetMedianRawStakeSnapshot ..
 func (hmy *Harmony) GetMedianRawStakeSnapshot() (
 	*committee.CompletedEPoSRound, error,
@@ -520,6 +612,88 @@ func (hmy *Harmony) GetDelegationsByDelegatorByBlock(
 	return addresses, delegations
 }
 
+const (
+	// blockTimeSampleSize is the number of recent blocks the average block time
+	// is measured over to estimate when an undelegation unlocks
+	blockTimeSampleSize = 1000
+	// defaultBlockTime is the block time assumed when it cannot be measured
+	defaultBlockTime = 2 * time.Second
+)
+
+// PendingUndelegation is an undelegation waiting for its funds to unlock, with the
+// epoch, block and estimated unix time at which they are paid out.
+type PendingUndelegation struct {
+	Validator   common.Address
+	Amount      *big.Int
+	Epoch       *big.Int
+	UnlockEpoch *big.Int
+	UnlockBlock uint64
+	UnlockTime  int64
+}
+
+// GetPendingUndelegations returns the pending undelegations of the delegator
+// across all validators. The unlock time is estimated from the average block time
+// of the recent blocks.
+func (hmy *Harmony) GetPendingUndelegations(delegator common.Address) ([]*PendingUndelegation, error) {
+	bc := hmy.BlockChain
+	header := bc.CurrentHeader()
+	validators, delegations := hmy.GetDelegationsByDelegatorByBlock(delegator, bc.CurrentBlock())
+	if validators == nil {
+		return nil, errors.Errorf("could not read the delegations of %s", delegator.Hex())
+	}
+
+	lockPeriod := hmy.GetDelegationLockingPeriodInEpoch(header.Epoch())
+	noEarlyUnlock := hmy.IsNoEarlyUnlockEpoch(header.Epoch())
+	blockTime := hmy.averageBlockTime(header)
+	pending := []*PendingUndelegation{}
+	for i, delegation := range delegations {
+		if delegation == nil || len(delegation.Undelegations) == 0 {
+			continue
+		}
+		wrapper, err := bc.ReadValidatorInformationAtRoot(validators[i], header.Root())
+		if err != nil {
+			return nil, err
+		}
+		for _, undelegation := range delegation.Undelegations {
+			unlockEpoch := undelegation.UnlockEpoch(
+				header.Epoch(), wrapper.LastEpochInCommittee, lockPeriod, noEarlyUnlock,
+			)
+			unlockBlock := shard.Schedule.EpochLastBlock(unlockEpoch.Uint64())
+			unlockTime := header.Time().Int64()
+			if unlockBlock > header.Number().Uint64() {
+				unlockTime += int64(float64(unlockBlock-header.Number().Uint64()) * blockTime)
+			}
+			pending = append(pending, &PendingUndelegation{
+				Validator:   validators[i],
+				Amount:      undelegation.Amount,
+				Epoch:       undelegation.Epoch,
+				UnlockEpoch: unlockEpoch,
+				UnlockBlock: unlockBlock,
+				UnlockTime:  unlockTime,
+			})
+		}
+	}
+	return pending, nil
+}
+
+// averageBlockTime returns the average time in seconds between the recent blocks
+// up to the given header.
+func (hmy *Harmony) averageBlockTime(header *block.Header) float64 {
+	number := header.Number().Uint64()
+	sample := uint64(blockTimeSampleSize)
+	if number < sample {
+		sample = number
+	}
+	if sample > 0 {
+		if past := hmy.BlockChain.GetHeaderByNumber(number - sample); past != nil {
+			if elapsed := new(big.Int).Sub(header.Time(), past.Time()); elapsed.Sign() > 0 {
+				return float64(elapsed.Int64()) / float64(sample)
+			}
+		}
+	}
+	return defaultBlockTime.Seconds()
+}
+
 // UndelegationPayouts ..
 type UndelegationPayouts struct {
 	Data map[common.Address]map[common.Address]*big.Int
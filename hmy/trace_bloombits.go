@@ -0,0 +1,309 @@
+package hmy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/bitutil"
+	"github.com/ethereum/go-ethereum/core/bloombits"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+const (
+	// TraceBloomBitsBlocks is the number of blocks a single trace bloom bit
+	// section vector contains.
+	TraceBloomBitsBlocks = params.BloomBitsBlocks
+
+	// traceBloomConfirms is the number of confirmation blocks before a trace
+	// bloom section is considered final.
+	traceBloomConfirms = params.BloomConfirms
+)
+
+var parityBlockTracer = "ParityBlockTracer"
+
+// parityTrace holds the addresses of a trace produced by the ParityBlockTracer.
+type parityTrace struct {
+	Type   string `json:"type"`
+	Action struct {
+		From          *common.Address `json:"from"`
+		To            *common.Address `json:"to"`
+		Address       *common.Address `json:"address"`
+		RefundAddress *common.Address `json:"refundAddress"`
+		Validator     *common.Address `json:"validator"`
+		Delegator     *common.Address `json:"delegator"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+}
+
+// ParityTraceEndpoints returns the sender and the recipient of a trace produced by
+// the ParityBlockTracer. The recipient of a call is its callee, the one of a create
+// is the created contract, the one of a suicide is the refund address and the one
+// of a staking action is the validator, whose delegator is the sender. A missing
+// endpoint, such as the contract of a failed create, is the zero address.
+func ParityTraceEndpoints(raw json.RawMessage) (from, to common.Address, err error) {
+	var trace parityTrace
+	if err := json.Unmarshal(raw, &trace); err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	addr := func(a *common.Address) common.Address {
+		if a == nil {
+			return common.Address{}
+		}
+		return *a
+	}
+	switch trace.Type {
+	case "call", "cxTransfer":
+		return addr(trace.Action.From), addr(trace.Action.To), nil
+	case "create":
+		if trace.Result != nil {
+			to = addr(trace.Result.Address)
+		}
+		return addr(trace.Action.From), to, nil
+	case "suicide":
+		return addr(trace.Action.Address), addr(trace.Action.RefundAddress), nil
+	case "staking":
+		return addr(trace.Action.Delegator), addr(trace.Action.Validator), nil
+	default:
+		return common.Address{}, common.Address{}, fmt.Errorf("unknown trace type %q", trace.Type)
+	}
+}
+
+// TraceBloomIndexer implements a core.ChainIndexer, building up a rotated bloom
+// bits index over the senders and recipients of the actions traced in every block
+// by the ParityBlockTracer, internal calls included. Tracing the blocks needs
+// their historical states, so the index can only be built by archival nodes.
+type TraceBloomIndexer struct {
+	hmy     *Harmony
+	size    uint64               // section size to generate bloombits for
+	gen     *bloombits.Generator // generator to rotate the bloom bits crating the bloom index
+	section uint64               // Section is the section number being processed currently
+	head    common.Hash          // Head is the hash of the last header processed
+}
+
+// NewTraceBloomIndexer returns a chain indexer that generates trace bloom bits
+// data for the canonical chain, to skip the blocks which cannot match a trace
+// filter.
+func NewTraceBloomIndexer(hmy *Harmony, size, confirms uint64) *core.ChainIndexer {
+	backend := &TraceBloomIndexer{
+		hmy:  hmy,
+		size: size,
+	}
+	table := ethRawDB.NewTable(hmy.chainDb, string(rawdb.TraceBloomBitsIndexPrefix))
+
+	return core.NewChainIndexer(hmy.chainDb, table, backend, size, confirms, bloomThrottling, "tracebloombits")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new trace bloombits index
+// section.
+func (b *TraceBloomIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	gen, err := bloombits.NewGenerator(uint(b.size))
+	b.gen, b.section, b.head = gen, section, common.Hash{}
+	return err
+}
+
+// Process implements core.ChainIndexerBackend, tracing the block of the header and
+// adding the bloom of its trace addresses into the index.
+func (b *TraceBloomIndexer) Process(ctx context.Context, header *block.Header) error {
+	number := header.Number().Uint64()
+	bin := new(big.Int)
+	if number > 0 {
+		blk := b.hmy.BlockChain.GetBlock(header.Hash(), number)
+		if blk == nil {
+			return fmt.Errorf("block %d %s not found", number, header.Hash().Hex())
+		}
+		config := &TraceConfig{Tracer: &parityBlockTracer}
+		results, err := b.hmy.traceBlockNoThread(ctx, blk, config)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			traces, ok := result.Result.([]json.RawMessage)
+			if !ok {
+				return fmt.Errorf("unexpected trace result of block %d", number)
+			}
+			for _, trace := range traces {
+				from, to, err := ParityTraceEndpoints(trace)
+				if err != nil {
+					return err
+				}
+				for _, addr := range []common.Address{from, to} {
+					if addr != (common.Address{}) {
+						bin.Or(bin, types.Bloom9(addr.Bytes()))
+					}
+				}
+			}
+		}
+	}
+	b.gen.AddBloom(uint(number-b.section*b.size), types.BytesToBloom(bin.Bytes()))
+	b.head = header.Hash()
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, finalizing the trace bloom section
+// and writing it out into the database.
+func (b *TraceBloomIndexer) Commit() error {
+	batch := b.hmy.chainDb.NewBatch()
+	for i := 0; i < types.BloomBitLength; i++ {
+		bits, err := b.gen.Bitset(uint(i))
+		if err != nil {
+			return err
+		}
+		err = rawdb.WriteTraceBloomBits(batch, uint(i), b.section, b.head, bitutil.CompressBytes(bits))
+		if err != nil {
+			return err
+		}
+	}
+	utils.Logger().Debug().
+		Uint64("section", b.section).
+		Msg("[TraceBloomIndexer] committed section")
+	return batch.Write()
+}
+
+// StartTraceBloomIndexer starts indexing the trace addresses of the canonical
+// blocks, which is only possible on archival nodes.
+func (hmy *Harmony) StartTraceBloomIndexer() {
+	hmy.TraceBloomIndexer = NewTraceBloomIndexer(hmy, TraceBloomBitsBlocks, traceBloomConfirms)
+	hmy.TraceBloomIndexer.Start(hmy.BlockChain)
+	hmy.registerTraceIndexMetrics()
+}
+
+// TraceIndexLag returns the number of canonical blocks not yet covered by the
+// trace bloom index, false if the index is disabled. The last sections are only
+// indexed once confirmed, so the lag stays below the section size plus the
+// confirmations when the index is keeping up with the chain.
+func (hmy *Harmony) TraceIndexLag() (uint64, bool) {
+	if hmy.TraceBloomIndexer == nil {
+		return 0, false
+	}
+	sections, _, _ := hmy.TraceBloomIndexer.Sections()
+	head := hmy.BlockChain.CurrentBlock().NumberU64() + 1
+	if indexed := sections * TraceBloomBitsBlocks; indexed < head {
+		return head - indexed, true
+	}
+	return 0, true
+}
+
+// TraceBloomCandidates returns the numbers of the blocks between begin and end,
+// inclusive, which may contain a traced action sent by one of the from addresses to
+// one of the to addresses according to the trace bloom index. An empty address
+// list matches any address. The blocks not covered by the index are all returned.
+func (hmy *Harmony) TraceBloomCandidates(
+	ctx context.Context, begin, end uint64, from, to []common.Address,
+) ([]uint64, error) {
+	var indexed uint64
+	if hmy.TraceBloomIndexer != nil && (len(from) > 0 || len(to) > 0) {
+		sections, _, _ := hmy.TraceBloomIndexer.Sections()
+		indexed = sections * TraceBloomBitsBlocks
+	}
+	var blocks []uint64
+	for number := begin; number <= end; {
+		if number >= indexed {
+			blocks = append(blocks, number)
+			number++
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		section := number / TraceBloomBitsBlocks
+		matches, err := hmy.traceBloomSection(section, from, to)
+		if err != nil {
+			return nil, err
+		}
+		last := (section+1)*TraceBloomBitsBlocks - 1
+		if last > end {
+			last = end
+		}
+		for ; number <= last; number++ {
+			i := number - section*TraceBloomBitsBlocks
+			if matches[i/8]&(1<<(7-i%8)) != 0 {
+				blocks = append(blocks, number)
+			}
+		}
+	}
+	return blocks, nil
+}
+
+// traceBloomSection returns the bit vector of the blocks of the given indexed
+// section whose trace bloom may contain one of the from addresses and one of the
+// to addresses.
+func (hmy *Harmony) traceBloomSection(section uint64, from, to []common.Address) ([]byte, error) {
+	head := rawdb.ReadCanonicalHash(hmy.chainDb, (section+1)*TraceBloomBitsBlocks-1)
+	vectors := make(map[uint][]byte)
+	vector := func(bit uint) ([]byte, error) {
+		if v, ok := vectors[bit]; ok {
+			return v, nil
+		}
+		comp, err := rawdb.ReadTraceBloomBits(hmy.chainDb, bit, section, head)
+		if err != nil {
+			return nil, err
+		}
+		v, err := bitutil.DecompressBytes(comp, int(TraceBloomBitsBlocks/8))
+		if err != nil {
+			return nil, err
+		}
+		vectors[bit] = v
+		return v, nil
+	}
+	// match returns the blocks which may contain any of the addresses, or nil if
+	// the addresses match all the blocks
+	match := func(addrs []common.Address) ([]byte, error) {
+		if len(addrs) == 0 {
+			return nil, nil
+		}
+		res := make([]byte, TraceBloomBitsBlocks/8)
+		for _, addr := range addrs {
+			all := make([]byte, TraceBloomBitsBlocks/8)
+			for i := range all {
+				all[i] = 0xff
+			}
+			for _, bit := range traceBloomBits(addr) {
+				v, err := vector(bit)
+				if err != nil {
+					return nil, err
+				}
+				bitutil.ANDBytes(all, all, v)
+			}
+			bitutil.ORBytes(res, res, all)
+		}
+		return res, nil
+	}
+	fromMatches, err := match(from)
+	if err != nil {
+		return nil, err
+	}
+	toMatches, err := match(to)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case fromMatches == nil:
+		return toMatches, nil
+	case toMatches == nil:
+		return fromMatches, nil
+	}
+	bitutil.ANDBytes(fromMatches, fromMatches, toMatches)
+	return fromMatches, nil
+}
+
+// traceBloomBits returns the indexes of the three bloom bits set by an address.
+func traceBloomBits(addr common.Address) [3]uint {
+	hash := crypto.Keccak256(addr.Bytes())
+	var bits [3]uint
+	for i := 0; i < len(bits); i++ {
+		bits[i] = (uint(hash[2*i])<<8 | uint(hash[2*i+1])) & 2047
+	}
+	return bits
+}
@@ -0,0 +1,149 @@
+package hmy
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+func TestParityTraceEndpoints(t *testing.T) {
+	var (
+		a = common.HexToAddress("0x0a")
+		b = common.HexToAddress("0x0b")
+	)
+	tests := []struct {
+		trace    string
+		from, to common.Address
+		fails    bool
+	}{
+		{`{"type":"call","action":{"from":"` + a.Hex() + `","to":"` + b.Hex() + `"}}`, a, b, false},
+		{`{"type":"cxTransfer","action":{"from":"` + a.Hex() + `","to":"` + b.Hex() + `"}}`, a, b, false},
+		{`{"type":"create","action":{"from":"` + a.Hex() + `"},"result":{"address":"` + b.Hex() + `"}}`, a, b, false},
+		// the contract of a failed create is unknown
+		{`{"type":"create","action":{"from":"` + a.Hex() + `"},"error":"Reverted"}`, a, common.Address{}, false},
+		{`{"type":"suicide","action":{"address":"` + a.Hex() + `","refundAddress":"` + b.Hex() + `"}}`, a, b, false},
+		{`{"type":"staking","action":{"delegator":"` + a.Hex() + `","validator":"` + b.Hex() + `"}}`, a, b, false},
+		{`{"type":"reward","action":{}}`, common.Address{}, common.Address{}, true},
+		{`[]`, common.Address{}, common.Address{}, true},
+	}
+	for i, test := range tests {
+		from, to, err := ParityTraceEndpoints(json.RawMessage(test.trace))
+		if (err != nil) != test.fails {
+			t.Errorf("test %d: have error %v, want failure %v", i, err, test.fails)
+		}
+		if from != test.from || to != test.to {
+			t.Errorf("test %d: have %x to %x, want %x to %x", i, from, to, test.from, test.to)
+		}
+	}
+}
+
+func TestTraceBloomIndex(t *testing.T) {
+	var (
+		forwarder = common.HexToAddress("0x1000")
+		recipient = common.HexToAddress("0x3000")
+		other     = common.HexToAddress("0x4000")
+	)
+	// the forwarder sends the value it receives to the recipient
+	forwarderCode := []byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.CALLVALUE), byte(vm.PUSH20),
+	}
+	forwarderCode = append(forwarderCode, recipient.Bytes()...)
+	forwarderCode = append(forwarderCode, byte(vm.GAS), byte(vm.CALL), byte(vm.POP), byte(vm.STOP))
+	hmy := newTestHarmony(t, core.GenesisAlloc{forwarder: {Balance: new(big.Int), Code: forwarderCode}})
+	addTestBlock(t, hmy, func(nonce uint64) []*types.Transaction {
+		return []*types.Transaction{
+			types.NewTransaction(nonce, forwarder, 0, big.NewInt(1), 100000, big.NewInt(2e9), nil),
+		}
+	})
+	addTestBlock(t, hmy, func(nonce uint64) []*types.Transaction {
+		return []*types.Transaction{
+			types.NewTransaction(nonce, other, 0, big.NewInt(1), params.TxGas, big.NewInt(2e9), nil),
+		}
+	})
+	ctx := context.Background()
+
+	// nothing is skipped without the index
+	if lag, ok := hmy.TraceIndexLag(); ok {
+		t.Errorf("have lag %d of a disabled index", lag)
+	}
+	blocks, err := hmy.TraceBloomCandidates(ctx, 0, 4, []common.Address{recipient}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint64{0, 1, 2, 3, 4}; !reflect.DeepEqual(blocks, want) {
+		t.Errorf("have candidates %v without the index, want %v", blocks, want)
+	}
+
+	// build the first section of the index, the blocks after the head of the
+	// chain are empty, as if the chain were one section long
+	backend := &TraceBloomIndexer{hmy: hmy, size: TraceBloomBitsBlocks}
+	if err := backend.Reset(ctx, 0, common.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	for number := uint64(0); number <= 2; number++ {
+		if err := backend.Process(ctx, hmy.BlockChain.GetHeaderByNumber(number)); err != nil {
+			t.Fatalf("block %d: %v", number, err)
+		}
+	}
+	for number := uint64(3); number < TraceBloomBitsBlocks; number++ {
+		if err := backend.gen.AddBloom(uint(number), types.BytesToBloom(nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	head := common.HexToHash("0x01")
+	if err := rawdb.WriteCanonicalHash(hmy.chainDb, head, TraceBloomBitsBlocks-1); err != nil {
+		t.Fatal(err)
+	}
+	backend.head = head
+	if err := backend.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	hmy.TraceBloomIndexer = NewTraceBloomIndexer(hmy, TraceBloomBitsBlocks, traceBloomConfirms)
+	hmy.TraceBloomIndexer.AddCheckpoint(0, head)
+	if lag, ok := hmy.TraceIndexLag(); !ok || lag != 0 {
+		t.Errorf("have lag %d %v, want 0", lag, ok)
+	}
+
+	tests := []struct {
+		begin, end uint64
+		from, to   []common.Address
+		want       []uint64
+	}{
+		// the internal call of the forwarder is indexed
+		{0, 10, nil, []common.Address{recipient}, []uint64{1}},
+		{0, 10, []common.Address{forwarder}, []common.Address{recipient}, []uint64{1}},
+		{0, 10, []common.Address{testAddress}, nil, []uint64{1, 2}},
+		{0, 10, nil, []common.Address{recipient, other}, []uint64{1, 2}},
+		{0, 10, []common.Address{forwarder}, []common.Address{other}, nil},
+		{2, 2, []common.Address{testAddress}, nil, []uint64{2}},
+		// the blocks after the index are all candidates
+		{TraceBloomBitsBlocks - 2, TraceBloomBitsBlocks + 1, []common.Address{testAddress}, nil, []uint64{TraceBloomBitsBlocks, TraceBloomBitsBlocks + 1}},
+		// so are all the blocks without an address to match
+		{0, 3, nil, nil, []uint64{0, 1, 2, 3}},
+	}
+	for i, test := range tests {
+		blocks, err := hmy.TraceBloomCandidates(ctx, test.begin, test.end, test.from, test.to)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(blocks, test.want) {
+			t.Errorf("test %d: have candidates %v, want %v", i, blocks, test.want)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := hmy.TraceBloomCandidates(canceled, 0, 10, []common.Address{testAddress}, nil); err != context.Canceled {
+		t.Errorf("have error %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,167 @@
+package hmy
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	prom "github.com/harmony-one/harmony/api/service/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	traceCacheCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "trace",
+			Name:      "cache",
+			Help:      "number of block trace cache lookups",
+		},
+		[]string{
+			"result",
+		},
+	)
+	traceCacheSizeGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "hmy",
+			Subsystem: "trace",
+			Name:      "cache_bytes",
+			Help:      "estimated size of the cached block trace results",
+		},
+	)
+
+	onceTraceCacheMetrics sync.Once
+)
+
+// traceCacheEntry is the trace result of a block for a single tracer config.
+// The results are held in JSON form and copied out on every hit, so that no
+// caller shares them with another.
+type traceCacheEntry struct {
+	key     string
+	results []*TxTraceResult
+	size    uint64
+}
+
+// traceCache is a LRU cache of block trace results bounded by the estimated
+// memory size of the results.
+type traceCache struct {
+	sync.Mutex
+	budget  uint64
+	size    uint64
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+// newTraceCache creates a trace result cache with a memory budget in bytes.
+func newTraceCache(budget uint64) *traceCache {
+	onceTraceCacheMetrics.Do(func() {
+		prom.PromRegistry().MustRegister(traceCacheCounterVec, traceCacheSizeGauge)
+	})
+	return &traceCache{
+		budget:  budget,
+		entries: list.New(),
+		index:   make(map[string]*list.Element),
+	}
+}
+
+// traceCacheKey returns the cache key of a block traced with the given config.
+func traceCacheKey(blockHash common.Hash, config *TraceConfig) (string, error) {
+	conf, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return blockHash.Hex() + string(conf), nil
+}
+
+// copyTraceResult returns a copy of the result of a tracer in JSON form. The
+// frames of the ParityBlockTracer are kept apart, the trace_ endpoints read
+// them one by one.
+func copyTraceResult(result interface{}) (interface{}, error) {
+	switch result := result.(type) {
+	case nil:
+		return nil, nil
+	case []json.RawMessage:
+		frames := make([]json.RawMessage, len(result))
+		for i, frame := range result {
+			frames[i] = append(json.RawMessage(nil), frame...)
+		}
+		return frames, nil
+	case json.RawMessage:
+		return append(json.RawMessage(nil), result...), nil
+	default:
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(encoded), nil
+	}
+}
+
+// copyTraceResults returns a copy of the block trace results in JSON form.
+func copyTraceResults(results []*TxTraceResult) ([]*TxTraceResult, error) {
+	copied := make([]*TxTraceResult, len(results))
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		res, err := copyTraceResult(result.Result)
+		if err != nil {
+			return nil, err
+		}
+		copied[i] = &TxTraceResult{Result: res, Error: result.Error}
+	}
+	return copied, nil
+}
+
+// get returns a copy of the cached results of the key.
+func (c *traceCache) get(key string) ([]*TxTraceResult, bool) {
+	c.Lock()
+	defer c.Unlock()
+	elem, ok := c.index[key]
+	if !ok {
+		traceCacheCounterVec.With(prometheus.Labels{"result": "miss"}).Inc()
+		return nil, false
+	}
+	traceCacheCounterVec.With(prometheus.Labels{"result": "hit"}).Inc()
+	c.entries.MoveToFront(elem)
+	// the cached results are in JSON form, copying them cannot fail
+	results, _ := copyTraceResults(elem.Value.(*traceCacheEntry).results)
+	return results, true
+}
+
+// add caches the results, evicting the least recently used entries to stay
+// within the budget. Results larger than the whole budget are not cached.
+func (c *traceCache) add(key string, results []*TxTraceResult) {
+	results, err := copyTraceResults(results)
+	if err != nil {
+		return
+	}
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		return
+	}
+	size := uint64(len(key) + len(encoded))
+	if size > c.budget {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if elem, ok := c.index[key]; ok {
+		c.remove(elem)
+	}
+	for c.size+size > c.budget {
+		c.remove(c.entries.Back())
+	}
+	c.index[key] = c.entries.PushFront(&traceCacheEntry{key: key, results: results, size: size})
+	c.size += size
+	traceCacheSizeGauge.Set(float64(c.size))
+}
+
+func (c *traceCache) remove(elem *list.Element) {
+	entry := c.entries.Remove(elem).(*traceCacheEntry)
+	delete(c.index, entry.key)
+	c.size -= entry.size
+	traceCacheSizeGauge.Set(float64(c.size))
+}
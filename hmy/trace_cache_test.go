@@ -0,0 +1,142 @@
+package hmy
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+func parityResults(frames ...string) []*TxTraceResult {
+	raw := make([]json.RawMessage, len(frames))
+	for i, frame := range frames {
+		raw[i] = json.RawMessage(frame)
+	}
+	return []*TxTraceResult{{Result: raw}}
+}
+
+func mustEncode(t *testing.T, v interface{}) string {
+	t.Helper()
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(encoded)
+}
+
+func TestTraceCacheHitMiss(t *testing.T) {
+	cache := newTraceCache(1024 * 1024)
+	if _, ok := cache.get("a"); ok {
+		t.Fatal("hit in an empty cache")
+	}
+	results := []*TxTraceResult{
+		{Result: []json.RawMessage{json.RawMessage(`{"type":"call"}`)}},
+		{Result: &ExecutionResult{Gas: 21000, StructLogs: []StructLogRes{}}},
+		{Error: "execution reverted"},
+	}
+	cache.add("a", results)
+	cached, ok := cache.get("a")
+	if !ok {
+		t.Fatal("miss of a cached key")
+	}
+	if have, want := mustEncode(t, cached), mustEncode(t, results); have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	// the frames of the ParityBlockTracer are read one by one
+	if _, ok := cached[0].Result.([]json.RawMessage); !ok {
+		t.Errorf("have result of type %T, want []json.RawMessage", cached[0].Result)
+	}
+	if _, ok := cache.get("b"); ok {
+		t.Error("hit of a key never cached")
+	}
+}
+
+func TestTraceCacheEviction(t *testing.T) {
+	results := parityResults(`{"type":"call"}`)
+	size := uint64(len("a") + len(mustEncode(t, results)))
+	cache := newTraceCache(2 * size)
+
+	cache.add("a", results)
+	cache.add("b", results)
+	// a is the most recently used, b is evicted for c
+	if _, ok := cache.get("a"); !ok {
+		t.Fatal("a is not cached")
+	}
+	cache.add("c", results)
+	if _, ok := cache.get("b"); ok {
+		t.Error("b is not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("%s is evicted", key)
+		}
+	}
+	if cache.size != 2*size {
+		t.Errorf("have size %d, want %d", cache.size, 2*size)
+	}
+
+	// results larger than the whole budget are not cached
+	cache.add("d", parityResults(`{"type":"call"}`, `{"type":"call"}`, `{"type":"call"}`))
+	if _, ok := cache.get("d"); ok {
+		t.Error("cached results larger than the budget")
+	}
+	if _, ok := cache.get("a"); !ok {
+		t.Error("a is evicted by results never cached")
+	}
+}
+
+func TestTraceCacheAliasing(t *testing.T) {
+	cache := newTraceCache(1024 * 1024)
+	results := parityResults(`{"type":"call"}`, `{"type":"create"}`)
+	want := mustEncode(t, results)
+	cache.add("a", results)
+
+	// changing the added results does not change the cached ones
+	results[0].Result.([]json.RawMessage)[0][2] = 'X'
+	results[0].Error = "changed"
+
+	first, _ := cache.get("a")
+	if have := mustEncode(t, first); have != want {
+		t.Fatalf("have %s, want %s", have, want)
+	}
+	// nor does changing the results of a hit
+	frames := first[0].Result.([]json.RawMessage)
+	frames[0][2] = 'X'
+	frames[1] = json.RawMessage(`{"type":"suicide"}`)
+	first[0] = &TxTraceResult{Error: "changed"}
+
+	second, _ := cache.get("a")
+	if have := mustEncode(t, second); have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+}
+
+func TestTraceBlockCache(t *testing.T) {
+	hmy := newTestHarmony(t, nil)
+	hmy.SetTraceCache(1)
+	block := addTestBlock(t, hmy, func(nonce uint64) []*types.Transaction {
+		return []*types.Transaction{
+			types.NewTransaction(nonce, common.HexToAddress("0x2000"), 0, big.NewInt(1), params.TxGas, big.NewInt(2e9), nil),
+		}
+	})
+	config := tracerConfig("ParityBlockTracer")
+
+	traced, err := hmy.TraceBlock(context.Background(), block, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mustEncode(t, traced)
+	traced[0].Result.([]json.RawMessage)[0] = nil
+
+	cached, err := hmy.TraceBlock(context.Background(), block, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := mustEncode(t, cached); have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+}
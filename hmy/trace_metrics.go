@@ -0,0 +1,105 @@
+package hmy
+
+import (
+	"sync"
+	"time"
+
+	prom "github.com/harmony-one/harmony/api/service/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	prom.PromRegistry().MustRegister(
+		traceActiveGauge,
+		traceLimitedCounter,
+		traceTxDurationVec,
+		traceReexecDurationHist,
+		traceReexecBlocksCounter,
+	)
+}
+
+var (
+	traceActiveGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "hmy",
+			Subsystem: "trace",
+			Name:      "active",
+			Help:      "number of trace requests executing",
+		},
+	)
+	traceLimitedCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "trace",
+			Name:      "limited",
+			Help:      "number of trace requests rejected by the concurrent trace limit",
+		},
+	)
+	traceTxDurationVec = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "hmy",
+			Subsystem: "trace",
+			Name:      "tx_duration_seconds",
+			Help:      "duration in seconds of the transaction traces",
+			// buckets: 1ms, 4ms, 16ms, 64ms, 256ms, 1s, 4s, 16s, +INF
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 8),
+		},
+		[]string{"tracer"},
+	)
+	traceReexecDurationHist = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "hmy",
+			Subsystem: "trace",
+			Name:      "reexec_duration_seconds",
+			Help:      "duration in seconds of the re-execution of blocks to regenerate a historical state",
+			// buckets: 100ms, 400ms, 1.6s, 6.4s, 25.6s, 102.4s, 409.6s, +INF
+			Buckets: prometheus.ExponentialBuckets(0.1, 4, 7),
+		},
+	)
+	traceReexecBlocksCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "trace",
+			Name:      "reexec_blocks",
+			Help:      "number of blocks re-executed to regenerate historical states",
+		},
+	)
+
+	onceTraceIndexMetrics sync.Once
+)
+
+// registerTraceIndexMetrics exposes the number of canonical blocks not yet covered
+// by the trace bloom index.
+func (hmy *Harmony) registerTraceIndexMetrics() {
+	onceTraceIndexMetrics.Do(func() {
+		prom.PromRegistry().MustRegister(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: "hmy",
+				Subsystem: "trace",
+				Name:      "index_lag",
+				Help:      "number of blocks not covered by the trace index",
+			},
+			func() float64 {
+				lag, _ := hmy.TraceIndexLag()
+				return float64(lag)
+			},
+		))
+	})
+}
+
+// traceMetricName returns the name of the tracer of the config used as metric
+// label. Custom JavaScript tracers share a single label.
+func traceMetricName(config *TraceConfig) string {
+	switch {
+	case config == nil || config.Tracer == nil:
+		return "structLogger"
+	case IsKnownTracer(*config.Tracer):
+		return *config.Tracer
+	}
+	return "custom"
+}
+
+func observeTraceTx(config *TraceConfig, start time.Time) {
+	traceTxDurationVec.With(prometheus.Labels{"tracer": traceMetricName(config)}).
+		Observe(time.Since(start).Seconds())
+}
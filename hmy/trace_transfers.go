@@ -0,0 +1,126 @@
+package hmy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// InternalTransfer is a transfer of ONE made by a contract within a transaction,
+// by a call, a create or a self-destruct, as traced by the ParityBlockTracer.
+type InternalTransfer struct {
+	TxHash  common.Hash
+	TxIndex uint64
+	From    common.Address
+	To      common.Address
+	Value   *big.Int
+}
+
+// parityTransfer holds the value transferred by a trace of the ParityBlockTracer
+type parityTransfer struct {
+	TransactionHash     common.Hash `json:"transactionHash"`
+	TransactionPosition uint64      `json:"transactionPosition"`
+	TraceAddress        []int       `json:"traceAddress"`
+	Type                string      `json:"type"`
+	Action              struct {
+		Value   *hexutil.Big `json:"value"`
+		Balance *hexutil.Big `json:"balance"`
+	} `json:"action"`
+	Error string `json:"error"`
+}
+
+// InternalTransfers returns the internal transfers of the traces produced by the
+// ParityBlockTracer for a transaction. The top level action is the transaction
+// itself and is not an internal transfer, neither is a reverted action nor any
+// action nested in it.
+func InternalTransfers(traces []json.RawMessage) ([]InternalTransfer, error) {
+	var (
+		transfers []InternalTransfer
+		reverted  = make(map[string]struct{})
+	)
+	for _, raw := range traces {
+		var trace parityTransfer
+		if err := json.Unmarshal(raw, &trace); err != nil {
+			return nil, err
+		}
+		// the traces are listed depth first, so a reverted action comes before the
+		// actions it contains
+		if trace.Error != "" {
+			reverted[fmt.Sprint(trace.TraceAddress)] = struct{}{}
+			continue
+		}
+		if len(trace.TraceAddress) == 0 || isTraceReverted(trace.TraceAddress, reverted) {
+			continue
+		}
+		value := trace.Action.Value
+		if trace.Type == "suicide" {
+			value = trace.Action.Balance
+		}
+		if value == nil || value.ToInt().Sign() == 0 {
+			continue
+		}
+		from, to, err := ParityTraceEndpoints(raw)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, InternalTransfer{
+			TxHash:  trace.TransactionHash,
+			TxIndex: trace.TransactionPosition,
+			From:    from,
+			To:      to,
+			Value:   value.ToInt(),
+		})
+	}
+	return transfers, nil
+}
+
+// isTraceReverted returns whether an action enclosing the one of the trace address
+// has been reverted
+func isTraceReverted(traceAddress []int, reverted map[string]struct{}) bool {
+	for i := len(traceAddress) - 1; i >= 0; i-- {
+		if _, ok := reverted[fmt.Sprint(traceAddress[:i])]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TraceInternalTransfers traces the block with the ParityBlockTracer and returns
+// the internal transfers of its plain transactions, in the order of execution,
+// identified by the hashes of the transactions according to their type.
+// Tracing the block needs the state of its parent, or of a recent ancestor to
+// regenerate it from.
+func (hmy *Harmony) TraceInternalTransfers(ctx context.Context, block *types.Block) ([]InternalTransfer, error) {
+	if block.NumberU64() == 0 || len(block.Transactions()) == 0 {
+		return nil, nil
+	}
+	config := &TraceConfig{Tracer: &parityBlockTracer}
+	results, err := hmy.traceBlockNoThread(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	var transfers []InternalTransfer
+	for i, tx := range block.Transactions() {
+		traces, ok := results[i].Result.([]json.RawMessage)
+		if !ok {
+			return nil, fmt.Errorf("unexpected trace result of block %d", block.NumberU64())
+		}
+		txTransfers, err := InternalTransfers(traces)
+		if err != nil {
+			return nil, err
+		}
+		// the traces carry the Ethereum hash of the transactions, use the one
+		// of their type as the transaction history does
+		for j := range txTransfers {
+			txTransfers[j].TxHash = tx.HashByType()
+			txTransfers[j].TxIndex = uint64(i)
+		}
+		transfers = append(transfers, txTransfers...)
+	}
+	return transfers, nil
+}
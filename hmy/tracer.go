@@ -18,8 +18,10 @@ package hmy
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -53,14 +55,75 @@ const (
 	defaultTraceReexec = uint64(128)
 
 	err
+
+	// traceTimeoutErrorCode is the JSON-RPC error code reported for traces
+	// aborted by their execution deadline.
+	traceTimeoutErrorCode = -32002
+)
+
+var (
+	// ErrTraceLimitReached is returned when no trace slot became available before
+	// the request was canceled.
+	ErrTraceLimitReached = errors.New("too many concurrent trace requests")
 )
 
+// TraceTimeoutError is returned when a trace is aborted because it exceeded its
+// execution deadline.
+type TraceTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TraceTimeoutError) Error() string {
+	if e.Timeout == 0 {
+		return "trace timeout: execution aborted"
+	}
+	return fmt.Sprintf("trace timeout: execution aborted (timeout = %v)", e.Timeout)
+}
+
+// ErrorCode returns the JSON-RPC error code of a trace timeout.
+func (e *TraceTimeoutError) ErrorCode() int {
+	return traceTimeoutErrorCode
+}
+
+// traceCtxErr converts the error of a finished trace context into the error
+// reported to the caller.
+func traceCtxErr(ctx context.Context, timeout time.Duration) error {
+	switch err := ctx.Err(); err {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return &TraceTimeoutError{Timeout: timeout}
+	default:
+		return err
+	}
+}
+
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
 	Tracer  *string
 	Timeout *string
-	Reexec  *uint64
+	// Reexec is the number of blocks re-executed to regenerate a missing historical
+	// state, bounded by the node
+	Reexec *uint64
+	// TracerConfig is passed to the setup function of a JavaScript tracer, such as
+	// {"onlyTopCall": true} for the callTracer
+	TracerConfig json.RawMessage
+	// MaxInputBytes and MaxOutputBytes truncate the call data and return data
+	// captured by the ParityBlockTracer, the original length is reported instead
+	MaxInputBytes  *int
+	MaxOutputBytes *int
+}
+
+// IsKnownTracer reports whether the tracer of the given name is served by TraceTx,
+// either natively or as a builtin JavaScript tracer. Other names are compiled as
+// the code of a custom JavaScript tracer.
+func IsKnownTracer(name string) bool {
+	switch name {
+	case "ParityBlockTracer", "RosettaBlockTracer", "opProfiler":
+		return true
+	}
+	return tracers.IsBuiltin(name)
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -100,6 +163,100 @@ type txTraceTask struct {
 	index   int       // Transaction offset in the block
 }
 
+// SetTraceLimits sets the execution timeout of a single trace request and the
+// number of trace requests allowed to execute at the same time. Zero values
+// leave the corresponding limit disabled.
+// The limits can be changed while traces are executing, the traces already
+// started keep the slot they reserved.
+func (hmy *Harmony) SetTraceLimits(timeout time.Duration, maxConcurrent int) {
+	hmy.limitsLock.Lock()
+	defer hmy.limitsLock.Unlock()
+
+	hmy.traceTimeout = timeout
+	if maxConcurrent > 0 {
+		hmy.traceSlots = make(chan struct{}, maxConcurrent)
+	} else {
+		hmy.traceSlots = nil
+	}
+}
+
+// TraceTimeout returns the execution timeout of a single trace request, zero if
+// traces are not bounded in time.
+func (hmy *Harmony) TraceTimeout() time.Duration {
+	hmy.limitsLock.RLock()
+	defer hmy.limitsLock.RUnlock()
+
+	return hmy.traceTimeout
+}
+
+// SetTraceMaxReexec bounds the number of blocks a trace request may re-execute
+// to regenerate a missing historical state. Zero leaves it unbounded.
+func (hmy *Harmony) SetTraceMaxReexec(max uint64) {
+	hmy.limitsLock.Lock()
+	defer hmy.limitsLock.Unlock()
+
+	hmy.traceMaxReexec = max
+}
+
+// LimitTraceReexec returns the number of blocks to re-execute for a trace
+// requesting the given reexec, bounded by the maximum set on the node.
+func (hmy *Harmony) LimitTraceReexec(reexec uint64) uint64 {
+	hmy.limitsLock.RLock()
+	defer hmy.limitsLock.RUnlock()
+
+	if hmy.traceMaxReexec != 0 && reexec > hmy.traceMaxReexec {
+		return hmy.traceMaxReexec
+	}
+	return reexec
+}
+
+// SetTraceCache sets the memory budget in megabytes of the block trace result
+// cache. Zero disables the cache.
+func (hmy *Harmony) SetTraceCache(sizeMB int) {
+	if sizeMB > 0 {
+		hmy.traceCache = newTraceCache(uint64(sizeMB) * 1024 * 1024)
+	} else {
+		hmy.traceCache = nil
+	}
+}
+
+// StartTrace reserves a slot in the global trace limiter and bounds the given
+// context by the configured trace timeout. The wait for a slot is bounded by
+// the trace timeout as well. The returned function must be called once the
+// trace has finished to release the slot.
+func (hmy *Harmony) StartTrace(ctx context.Context) (context.Context, func(), error) {
+	hmy.limitsLock.RLock()
+	slots, timeout := hmy.traceSlots, hmy.traceTimeout
+	hmy.limitsLock.RUnlock()
+
+	if slots != nil {
+		waitCtx := ctx
+		if timeout > 0 {
+			var cancelWait context.CancelFunc
+			waitCtx, cancelWait = context.WithTimeout(ctx, timeout)
+			defer cancelWait()
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-waitCtx.Done():
+			traceLimitedCounter.Inc()
+			return nil, nil, ErrTraceLimitReached
+		}
+	}
+	traceActiveGauge.Inc()
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {
+		cancel()
+		traceActiveGauge.Dec()
+		if slots != nil {
+			<-slots
+		}
+	}, nil
+}
+
 // TraceChain configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requested tracer.
@@ -129,6 +286,7 @@ func (hmy *Harmony) TraceChain(ctx context.Context, start, end *types.Block, con
 		if config != nil && config.Reexec != nil {
 			reexec = *config.Reexec
 		}
+		reexec = hmy.LimitTraceReexec(reexec)
 		// Find the most recent block that has state available
 		for i := uint64(0); i < reexec; i++ {
 			start = hmy.BlockChain.GetBlock(start.ParentHash(), start.NumberU64()-1)
@@ -358,16 +516,18 @@ func (hmy *Harmony) traceBlockNoThread(ctx context.Context, block *types.Block,
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
+	reexec = hmy.LimitTraceReexec(reexec)
 	statedb, err := hmy.ComputeStateDB(parent, reexec)
 	if err != nil {
 		return nil, err
 	}
 	// Execute all the transaction contained within the block concurrently
 	var (
-		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-		ethSigner = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
-		txs       = block.Transactions()
-		results   = make([]*TxTraceResult, len(txs))
+		hmySigner  = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
+		ethSigner  = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+		txs        = block.Transactions()
+		stakingTxs = block.StakingTransactions()
+		results    = make([]*TxTraceResult, len(txs)+len(stakingTxs))
 	)
 
 	blockHash := block.Hash()
@@ -396,32 +556,124 @@ traceLoop:
 		statedb.Finalise(true)
 		select {
 		case <-ctx.Done():
-			failed = errors.New("trace task was canceled!")
+			failed = traceCtxErr(ctx, hmy.TraceTimeout())
 			break traceLoop
 		default:
 		}
 	}
 
+	// Staking transactions are executed after the plain ones, same as in block processing
+	if failed == nil {
+	stakingLoop:
+		for i, tx := range stakingTxs {
+			msg, err := core.StakingToMessage(tx, block.Number())
+			if err != nil {
+				failed = err
+				break
+			}
+			statedb.Prepare(tx.Hash(), blockHash, i+len(txs))
+			vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
+			res, err := hmy.TraceStakingTx(ctx, msg, vmctx, statedb)
+			if err != nil {
+				results[i+len(txs)] = &TxTraceResult{Error: err.Error()}
+				failed = err
+				break
+			}
+			results[i+len(txs)] = &TxTraceResult{Result: res}
+			statedb.Finalise(true)
+			select {
+			case <-ctx.Done():
+				failed = traceCtxErr(ctx, hmy.TraceTimeout())
+				break stakingLoop
+			default:
+			}
+		}
+	}
+
 	// If execution failed in between, abort
 	if failed != nil {
 		return nil, failed
 	}
+	// Incoming cross-shard receipts are credited last, they are not executed so
+	// only their synthetic transfer is reported
+	position := uint64(len(results))
+	for _, cxp := range block.IncomingReceipts() {
+		if cxp == nil {
+			continue
+		}
+		for _, cx := range cxp.Receipts {
+			if cx == nil {
+				continue
+			}
+			tracer := &tracers.ParityBlockTracer{}
+			tracer.CaptureCXTransfer(block.NumberU64(), blockHash, position, cx)
+			res, err := tracer.GetResult()
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, &TxTraceResult{Result: res})
+			position++
+		}
+	}
 	return results, nil
 }
 
+// TraceStakingTx executes the given staking message in the provided environment
+// and returns its synthetic staking action, as produced by the ParityBlockTracer.
+func (hmy *Harmony) TraceStakingTx(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.DB) (interface{}, error) {
+	select {
+	case <-ctx.Done():
+		return nil, traceCtxErr(ctx, hmy.TraceTimeout())
+	default:
+	}
+	tracer := &tracers.ParityBlockTracer{}
+	vmenv := vm.NewEVM(vmctx, statedb, hmy.BlockChain.Config(), vm.Config{Debug: true, Tracer: tracer})
+	if _, err := core.ApplyStakingMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()), hmy.BlockChain); err != nil {
+		return nil, fmt.Errorf("tracing failed: %v", err)
+	}
+	return tracer.GetResult()
+}
+
 // TraceBlock configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requested tracer.
+// Results are served from the trace cache when the block was already traced
+// with the same configuration.
 func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*TxTraceResult, error) {
 	select {
 	case <-ctx.Done():
-		return nil, errors.New("canceled!")
+		return nil, traceCtxErr(ctx, hmy.TraceTimeout())
 	default:
 	}
 
-	if *config.Tracer == "ParityBlockTracer" {
-		return hmy.traceBlockNoThread(ctx, block, config)
+	var cacheKey string
+	if hmy.traceCache != nil {
+		if key, err := traceCacheKey(block.Hash(), config); err == nil {
+			if results, ok := hmy.traceCache.get(key); ok {
+				return results, nil
+			}
+			cacheKey = key
+		}
+	}
+
+	var (
+		results []*TxTraceResult
+		err     error
+	)
+	if config != nil && config.Tracer != nil && *config.Tracer == "ParityBlockTracer" {
+		results, err = hmy.traceBlockNoThread(ctx, block, config)
+	} else {
+		results, err = hmy.traceBlock(ctx, block, config)
 	}
+	if err == nil && cacheKey != "" {
+		hmy.traceCache.add(cacheKey, results)
+	}
+	return results, err
+}
+
+// traceBlock executes all the transactions of the block concurrently with the
+// configured tracer.
+func (hmy *Harmony) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*TxTraceResult, error) {
 	// Create the parent state database
 	if err := hmy.BlockChain.Engine().VerifyHeader(hmy.BlockChain, block.Header(), true); err != nil {
 		return nil, err
@@ -434,6 +686,7 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
+	reexec = hmy.LimitTraceReexec(reexec)
 	statedb, err := hmy.ComputeStateDB(parent, reexec)
 	if err != nil {
 		return nil, err
@@ -480,7 +733,15 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 	}
 	// Feed the transactions into the tracers and return
 	var failed error
+feedLoop:
 	for i, tx := range txs {
+		// Stop feeding the tracers if the request was canceled or timed out
+		select {
+		case <-ctx.Done():
+			failed = traceCtxErr(ctx, hmy.TraceTimeout())
+			break feedLoop
+		default:
+		}
 		// Send the trace task over for execution
 		jobs <- &txTraceTask{statedb: statedb.Copy(), index: i}
 
@@ -508,13 +769,77 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 	if failed != nil {
 		return nil, failed
 	}
+	if err := traceCtxErr(ctx, hmy.TraceTimeout()); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
-// standardTraceBlockToFile configures a new tracer which uses standard JSON output,
-// and traces either a full block or an individual transaction. The return value will
-// be one filename per transaction traced.
-func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.Block, config *StdTraceConfig) ([]string, error) {
+// IntermediateRoots re-executes the plain and staking transactions of the block
+// and returns the intermediate state root after each of them, in block order.
+func (hmy *Harmony) IntermediateRoots(ctx context.Context, block *types.Block, config *TraceConfig) ([]common.Hash, error) {
+	parent := hmy.BlockChain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	reexec = hmy.LimitTraceReexec(reexec)
+	statedb, err := hmy.ComputeStateDB(parent, reexec)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		chainConfig = hmy.BlockChain.Config()
+		hmySigner   = types.MakeSigner(chainConfig, block.Number())
+		ethSigner   = types.NewEIP155Signer(chainConfig.EthCompatibleChainID)
+		deleteEmpty = chainConfig.IsS3(block.Epoch())
+		blockHash   = block.Hash()
+		txs         = block.Transactions()
+		roots       = make([]common.Hash, 0, len(txs)+len(block.StakingTransactions()))
+	)
+	for i, tx := range txs {
+		if err := traceCtxErr(ctx, hmy.TraceTimeout()); err != nil {
+			return nil, err
+		}
+		signer := hmySigner
+		if tx.IsEthCompatible() {
+			signer = ethSigner
+		}
+		msg, _ := tx.AsMessage(signer)
+		statedb.Prepare(tx.ConvertToEth().Hash(), blockHash, i)
+		vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
+		vmenv := vm.NewEVM(vmctx, statedb, chainConfig, vm.Config{})
+		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		roots = append(roots, statedb.IntermediateRoot(deleteEmpty))
+	}
+	for i, tx := range block.StakingTransactions() {
+		if err := traceCtxErr(ctx, hmy.TraceTimeout()); err != nil {
+			return nil, err
+		}
+		msg, err := core.StakingToMessage(tx, block.Number())
+		if err != nil {
+			return nil, err
+		}
+		statedb.Prepare(tx.Hash(), blockHash, i+len(txs))
+		vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
+		vmenv := vm.NewEVM(vmctx, statedb, chainConfig, vm.Config{})
+		if _, err := core.ApplyStakingMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas()), hmy.BlockChain); err != nil {
+			return nil, fmt.Errorf("staking transaction %#x failed: %v", tx.Hash(), err)
+		}
+		roots = append(roots, statedb.IntermediateRoot(deleteEmpty))
+	}
+	return roots, nil
+}
+
+// StandardTraceBlockToFile configures a new tracer which uses standard JSON output,
+// and traces either a full block or an individual transaction. The traces are
+// gzip compressed and the return value will be one filename per transaction traced.
+func (hmy *Harmony) StandardTraceBlockToFile(ctx context.Context, block *types.Block, config *StdTraceConfig) ([]string, error) {
 	// If we're tracing a single transaction, make sure it's present
 	if config != nil && config.TxHash != (common.Hash{}) {
 		if !containsTx(block, config.TxHash) {
@@ -533,6 +858,7 @@ func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.B
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
+	reexec = hmy.LimitTraceReexec(reexec)
 	statedb, err := hmy.ComputeStateDB(parent, reexec)
 	if err != nil {
 		return nil, err
@@ -568,22 +894,29 @@ func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.B
 
 			vmConf vm.Config
 			dump   *os.File
+			zipper *gzip.Writer
 			writer *bufio.Writer
 			err    error
 		)
+		select {
+		case <-ctx.Done():
+			return dumps, traceCtxErr(ctx, hmy.TraceTimeout())
+		default:
+		}
 		// If the transaction needs tracing, swap out the configs
 		if tx.Hash() == txHash || txHash == (common.Hash{}) {
 			// Generate a unique temporary file to dump it into
 			prefix := fmt.Sprintf("block_%#x-%d-%#x-", block.Hash().Bytes()[:4], i, tx.Hash().Bytes()[:4])
 
-			dump, err = ioutil.TempFile(os.TempDir(), prefix)
+			dump, err = ioutil.TempFile(os.TempDir(), prefix+"*.jsonl.gz")
 			if err != nil {
 				return nil, err
 			}
 			dumps = append(dumps, dump.Name())
 
 			// Swap out the noop logger to the standard tracer
-			writer = bufio.NewWriter(dump)
+			zipper = gzip.NewWriter(dump)
+			writer = bufio.NewWriter(zipper)
 			vmConf = vm.Config{
 				Debug:                   true,
 				Tracer:                  vm.NewJSONLogger(&logConfig, writer),
@@ -595,6 +928,7 @@ func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.B
 		_, err = core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas()))
 		if writer != nil {
 			writer.Flush()
+			zipper.Close()
 		}
 		if dump != nil {
 			dump.Close()
@@ -661,6 +995,7 @@ func (hmy *Harmony) ComputeStateDB(block *types.Block, reexec uint64) (*state.DB
 		logged time.Time
 		proot  common.Hash
 	)
+	defer func() { traceReexecDurationHist.Observe(time.Since(start).Seconds()) }()
 	for block.NumberU64() < origin {
 		// Print progress logs if long enough time elapsed
 		if time.Since(logged) > 8*time.Second {
@@ -680,6 +1015,7 @@ func (hmy *Harmony) ComputeStateDB(block *types.Block, reexec uint64) (*state.DB
 		if err != nil {
 			return nil, fmt.Errorf("processing block %d failed: %v", block.NumberU64(), err)
 		}
+		traceReexecBlocksCounter.Inc()
 		// Finalize the state so any modifications are written to the trie
 		root, err := statedb.Commit(true)
 		if err != nil {
@@ -704,6 +1040,28 @@ func (hmy *Harmony) ComputeStateDB(block *types.Block, reexec uint64) (*state.DB
 	return statedb, nil
 }
 
+// StateDiffs returns the state changes made by the transactions of the given
+// block. They are read from the state diff index if the block is indexed, or
+// recorded by executing the block again otherwise.
+func (hmy *Harmony) StateDiffs(block *types.Block) ([]*types.StateDiff, error) {
+	if diffs := hmy.BlockChain.GetStateDiffs(block.Hash(), block.NumberU64()); diffs != nil {
+		return diffs, nil
+	}
+	parent := hmy.BlockChain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, err := hmy.ComputeStateDB(parent, defaultTraceReexec)
+	if err != nil {
+		return nil, err
+	}
+	statedb.RecordStateDiffs()
+	if _, _, _, _, _, _, _, err := hmy.BlockChain.Processor().Process(block, statedb, vm.Config{}, false); err != nil {
+		return nil, fmt.Errorf("processing block %d failed: %v", block.NumberU64(), err)
+	}
+	return statedb.StateDiffs(), nil
+}
+
 // TraceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
@@ -711,35 +1069,47 @@ func (hmy *Harmony) ComputeStateDB(block *types.Block, reexec uint64) (*state.DB
 func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.DB, config *TraceConfig) (interface{}, error) {
 	// Assemble the structured logger or the JavaScript tracer
 	var (
-		tracer vm.Tracer
-		err    error
+		tracer  vm.Tracer
+		err     error
+		timeout = hmy.TraceTimeout()
 	)
+	// Every tracer runs under a context that aborts the EVM once the request
+	// is canceled or its deadline expires.
+	txCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer observeTraceTx(config, time.Now())
+
 	switch {
 	case config != nil && config.Tracer != nil:
 		if *config.Tracer == "ParityBlockTracer" {
-			tracer = &tracers.ParityBlockTracer{}
+			parityTracer := &tracers.ParityBlockTracer{}
+			if config.MaxInputBytes != nil {
+				parityTracer.MaxInputBytes = *config.MaxInputBytes
+			}
+			if config.MaxOutputBytes != nil {
+				parityTracer.MaxOutputBytes = *config.MaxOutputBytes
+			}
+			tracer = parityTracer
 			break
 		} else if *config.Tracer == "RosettaBlockTracer" {
 			tracer = &tracers.RosettaBlockTracer{ParityBlockTracer: &tracers.ParityBlockTracer{}}
 			break
+		} else if *config.Tracer == "opProfiler" {
+			tracer = &tracers.OpProfiler{}
+			break
 		}
 		// Define a meaningful timeout of a single transaction trace
-		timeout := defaultTraceTimeout
+		timeout = defaultTraceTimeout
 		if config.Timeout != nil {
 			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
 				return nil, err
 			}
 		}
 		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
+		if tracer, err = tracers.New(*config.Tracer, config.TracerConfig); err != nil {
 			return nil, err
 		}
-		// Handle timeouts and RPC cancellations
-		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
-		go func() {
-			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
-		}()
+		txCtx, cancel = context.WithTimeout(txCtx, timeout)
 		defer cancel()
 
 	case config == nil:
@@ -751,7 +1121,32 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 	// Run the transaction with tracing enabled.
 	vmenv := vm.NewEVM(vmctx, statedb, hmy.BlockChain.Config(), vm.Config{Debug: true, Tracer: tracer})
 
+	// Handle timeouts and RPC cancellations, the EVM checks for the cancellation
+	// before each opcode so every tracer is aborted at the next step.
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-txCtx.Done():
+			select {
+			case <-finished:
+				return
+			default:
+			}
+			vmenv.Cancel()
+			if jst, ok := tracer.(*tracers.Tracer); ok {
+				jst.Stop(errors.New("execution timeout"))
+			}
+		case <-finished:
+		}
+	}()
+
 	result, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
+	if vmenv.Cancelled() {
+		if err := traceCtxErr(txCtx, timeout); err != nil {
+			return nil, err
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("tracing failed: %v", err)
 	}
@@ -771,12 +1166,48 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 		return tracer.GetResult()
 	case *tracers.RosettaBlockTracer:
 		return tracer.GetResult()
+	case *tracers.OpProfiler:
+		return tracer.GetResult()
 
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
 	}
 }
 
+// TraceParityCall traces a message with the parity style block tracer on top of the given
+// state and returns the call frames along with the result of the execution. The changes of
+// the message are finalized in the state, so that dependent messages can be traced after it.
+func (hmy *Harmony) TraceParityCall(
+	ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.DB,
+) ([]json.RawMessage, *core.ExecutionResult, error) {
+	txCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tracer := &tracers.ParityBlockTracer{}
+	vmenv := vm.NewEVM(vmctx, statedb, hmy.BlockChain.Config(), vm.Config{Debug: true, Tracer: tracer})
+	go func() {
+		<-txCtx.Done()
+		vmenv.Cancel()
+	}()
+
+	result, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
+	if vmenv.Cancelled() {
+		if err := traceCtxErr(txCtx, hmy.TraceTimeout()); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing failed: %v", err)
+	}
+	statedb.Finalise(true)
+
+	frames, err := tracer.GetResult()
+	if err != nil {
+		return nil, nil, err
+	}
+	return frames, &result, nil
+}
+
 // ComputeTxEnv returns the execution environment of a certain transaction.
 func (hmy *Harmony) ComputeTxEnv(block *types.Block, txIndex int, reexec uint64) (core.Message, vm.Context, *state.DB, error) {
 	// Create the parent state database
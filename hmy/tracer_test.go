@@ -0,0 +1,351 @@
+package hmy
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	chain2 "github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/node/worker"
+	"github.com/harmony-one/harmony/shard"
+)
+
+var (
+	testKey, _  = crypto.GenerateKey()
+	testAddress = crypto.PubkeyToAddress(testKey.PublicKey)
+	testFunds   = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+	// loopAddress holds a contract jumping back to its first instruction forever
+	loopAddress = common.HexToAddress("0x1000")
+	loopCode    = []byte{byte(vm.JUMPDEST), byte(vm.PUSH1), 0x00, byte(vm.JUMP)}
+)
+
+// testEngine skips the verification of the commit signatures, which the test
+// blocks lack
+type testEngine struct {
+	engine.Engine
+}
+
+func (e testEngine) VerifyHeader(chain engine.ChainReader, header *block.Header, seal bool) error {
+	return e.Engine.VerifyHeader(chain, header, false)
+}
+
+// newTestHarmony returns a Harmony backed by an in-memory chain holding only
+// the genesis block, which funds the test account and deploys the given code.
+func newTestHarmony(t *testing.T, alloc core.GenesisAlloc) *Harmony {
+	t.Helper()
+	genesisAlloc := core.GenesisAlloc{
+		testAddress: {Balance: testFunds},
+		loopAddress: {Balance: new(big.Int), Code: loopCode},
+	}
+	for addr, account := range alloc {
+		genesisAlloc[addr] = account
+	}
+	var (
+		database = rawdb.NewMemoryDatabase()
+		gspec    = core.Genesis{
+			Config:   params.TestChainConfig,
+			Factory:  blockfactory.ForTest,
+			Alloc:    genesisAlloc,
+			GasLimit: params.TestGenesisGasLimit,
+			ShardID:  0,
+			// the test account proposes the blocks
+			ShardState: shard.State{
+				Epoch: big.NewInt(0),
+				Shards: []shard.Committee{{
+					ShardID: 0,
+					Slots:   shard.SlotList{{EcdsaAddress: testAddress}},
+				}},
+			},
+		}
+	)
+	gspec.MustCommit(database)
+	chain, err := core.NewBlockChain(database, nil, gspec.Config, testEngine{chain2.NewEngine()}, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(chain.Stop)
+	return NewOffline(chain)
+}
+
+// traceTestTx traces a call from the test account with the given tracer config
+// on top of the current state of the chain.
+func traceTestTx(ctx context.Context, t *testing.T, hmy *Harmony, to common.Address, data []byte, config *TraceConfig) (interface{}, error) {
+	t.Helper()
+	statedb, err := hmy.BlockChain.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := types.NewMessage(testAddress, &to, 0, new(big.Int), 50000000, big.NewInt(2e9), data, false)
+	vmctx := core.NewEVMContext(msg, hmy.BlockChain.CurrentHeader(), hmy.BlockChain, nil)
+	return hmy.TraceTx(ctx, msg, vmctx, statedb, config)
+}
+
+func tracerConfig(name string) *TraceConfig {
+	return &TraceConfig{Tracer: &name}
+}
+
+func TestStartTraceLimit(t *testing.T) {
+	hmy := &Harmony{}
+	hmy.SetTraceLimits(0, 1)
+
+	_, release, err := hmy.StartTrace(context.Background())
+	if err != nil {
+		t.Fatalf("first trace: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := hmy.StartTrace(ctx); err != ErrTraceLimitReached {
+		t.Fatalf("second trace: have %v, want %v", err, ErrTraceLimitReached)
+	}
+	release()
+	_, release, err = hmy.StartTrace(context.Background())
+	if err != nil {
+		t.Fatalf("trace after release: %v", err)
+	}
+	release()
+}
+
+func TestStartTraceWaitBoundedByTimeout(t *testing.T) {
+	hmy := &Harmony{}
+	hmy.SetTraceLimits(20*time.Millisecond, 1)
+
+	ctx, release, err := hmy.StartTrace(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("trace context has no deadline")
+	}
+	// the second request has no deadline of its own
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := hmy.StartTrace(context.Background())
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrTraceLimitReached {
+			t.Errorf("have %v, want %v", err, ErrTraceLimitReached)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait for a trace slot is not bounded by the trace timeout")
+	}
+}
+
+func TestTraceTxCancel(t *testing.T) {
+	hmy := newTestHarmony(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := traceTestTx(ctx, t, hmy, loopAddress, nil, tracerConfig("ParityBlockTracer")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("have %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestTraceTxTimeout(t *testing.T) {
+	hmy := newTestHarmony(t, nil)
+	hmy.SetTraceLimits(20*time.Millisecond, 0)
+
+	ctx, release, err := hmy.StartTrace(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	_, err = traceTestTx(ctx, t, hmy, loopAddress, nil, tracerConfig("ParityBlockTracer"))
+	if terr, ok := err.(*TraceTimeoutError); !ok || terr.Timeout != 20*time.Millisecond {
+		t.Fatalf("have %v, want a trace timeout of 20ms", err)
+	}
+
+	// the timeout of a JavaScript tracer is set by its config
+	config, timeout := tracerConfig("callTracer"), "20ms"
+	config.Timeout = &timeout
+	_, err = traceTestTx(context.Background(), t, hmy, loopAddress, nil, config)
+	if terr, ok := err.(*TraceTimeoutError); !ok || terr.Timeout != 20*time.Millisecond {
+		t.Fatalf("have %v, want a trace timeout of 20ms", err)
+	}
+}
+
+func TestTraceTxCompletes(t *testing.T) {
+	hmy := newTestHarmony(t, nil)
+
+	// a finished trace is not stopped when its context ends afterwards
+	ctx, cancel := context.WithCancel(context.Background())
+	res, err := traceTestTx(ctx, t, hmy, common.HexToAddress("0x2000"), nil, tracerConfig("callTracer"))
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("no result")
+	}
+}
+
+// addTestBlock inserts a block holding the given transactions of the test
+// account on top of the chain.
+func addTestBlock(t *testing.T, hmy *Harmony, gen func(nonce uint64) []*types.Transaction) *types.Block {
+	t.Helper()
+	chain := hmy.BlockChain
+	w := worker.New(chain.Config(), chain, chain.Engine())
+	signer := types.MakeSigner(chain.Config(), new(big.Int).Add(chain.CurrentBlock().Number(), common.Big1))
+	var txs types.Transactions
+	for _, tx := range gen(w.GetCurrentState().GetNonce(testAddress)) {
+		signed, err := types.SignTx(tx, signer, testKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, signed)
+	}
+	if err := w.CommitTransactions(map[common.Address]types.Transactions{testAddress: txs}, nil, testAddress); err != nil {
+		t.Fatal(err)
+	}
+	commitSigs := make(chan []byte, 1)
+	commitSigs <- make([]byte, 96+1)
+	block, err := w.FinalizeNewBlock(commitSigs, func() uint64 { return 0 }, testAddress, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.InsertChain(types.Blocks{block}, false); err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestStandardTraceBlockToFile(t *testing.T) {
+	hmy := newTestHarmony(t, nil)
+	block := addTestBlock(t, hmy, func(nonce uint64) []*types.Transaction {
+		return []*types.Transaction{
+			types.NewTransaction(nonce, common.HexToAddress("0x2000"), 0, big.NewInt(1), params.TxGas, big.NewInt(2e9), nil),
+			types.NewTransaction(nonce+1, loopAddress, 0, big.NewInt(0), 30000, big.NewInt(2e9), nil),
+		}
+	})
+
+	// readDump returns the lines of a gzip compressed trace
+	readDump := func(name string) []map[string]interface{} {
+		t.Cleanup(func() { os.Remove(name) })
+		if !strings.HasSuffix(name, ".jsonl.gz") {
+			t.Errorf("trace file %s is not a gzip compressed JSON lines file", name)
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+		reader, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var lines []map[string]interface{}
+		for decoder := json.NewDecoder(reader); decoder.More(); {
+			var line map[string]interface{}
+			if err := decoder.Decode(&line); err != nil {
+				t.Fatalf("invalid trace line in %s: %v", name, err)
+			}
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	dumps, err := hmy.StandardTraceBlockToFile(context.Background(), block, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dumps) != 2 {
+		t.Fatalf("have %d trace files, want 2", len(dumps))
+	}
+	// a transfer runs no opcode, the loop runs until it is out of gas
+	if lines := readDump(dumps[0]); len(lines) != 1 || lines[0]["gasUsed"] == nil {
+		t.Errorf("transfer: have trace %v, want only the summary", lines)
+	}
+	lines := readDump(dumps[1])
+	if len(lines) < 2 || lines[0]["opName"] != "JUMPDEST" {
+		t.Fatalf("loop: have %d lines, want the opcodes from JUMPDEST", len(lines))
+	}
+	if summary := lines[len(lines)-1]; summary["error"] == nil {
+		t.Errorf("loop: have summary %v, want an out of gas error", summary)
+	}
+
+	// a single transaction is traced on request
+	txHash := block.Transactions()[1].Hash()
+	dumps, err = hmy.StandardTraceBlockToFile(context.Background(), block, &StdTraceConfig{TxHash: txHash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dumps) != 1 || !strings.Contains(dumps[0], fmt.Sprintf("-1-%#x-", txHash.Bytes()[:4])) {
+		t.Fatalf("have trace files %v, want one of transaction 1", dumps)
+	}
+	readDump(dumps[0])
+
+	if _, err := hmy.StandardTraceBlockToFile(context.Background(), block, &StdTraceConfig{TxHash: common.HexToHash("0x01")}); err == nil {
+		t.Error("traced a transaction missing from the block")
+	}
+}
+
+func TestIntermediateRoots(t *testing.T) {
+	hmy := newTestHarmony(t, nil)
+	block := addTestBlock(t, hmy, func(nonce uint64) []*types.Transaction {
+		return []*types.Transaction{
+			types.NewTransaction(nonce, common.HexToAddress("0x2000"), 0, big.NewInt(1), params.TxGas, big.NewInt(2e9), nil),
+			types.NewTransaction(nonce+1, loopAddress, 0, big.NewInt(0), 30000, big.NewInt(2e9), nil),
+		}
+	})
+
+	roots, err := hmy.IntermediateRoots(context.Background(), block, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the roots are the ones of the block processing, one per transaction
+	chain := hmy.BlockChain
+	parent := chain.GetBlockByHash(block.ParentHash())
+	statedb, err := chain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		gp      = new(core.GasPool).AddGas(block.GasLimit())
+		usedGas uint64
+		want    []common.Hash
+	)
+	for i, tx := range block.Transactions() {
+		statedb.Prepare(tx.Hash(), block.Hash(), i)
+		if _, _, _, _, err := core.ApplyTransaction(chain.Config(), chain, nil, gp, statedb, block.Header(), tx, &usedGas, vm.Config{}); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, statedb.IntermediateRoot(chain.Config().IsS3(block.Epoch())))
+	}
+	if len(roots) != len(want) {
+		t.Fatalf("have %d roots, want %d", len(roots), len(want))
+	}
+	for i := range want {
+		if roots[i] != want[i] {
+			t.Errorf("root %d: have %x, want %x", i, roots[i], want[i])
+		}
+	}
+	if roots[0] == roots[1] {
+		t.Error("both transactions have the same root")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := hmy.IntermediateRoots(ctx, block, nil); err != context.Canceled {
+		t.Errorf("have %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,150 @@
+package tracers
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// accessList is an accumulator for the set of accounts and storage slots an EVM
+// contract execution touches.
+type accessList map[common.Address]map[common.Hash]struct{}
+
+// addAddress adds an address to the access list.
+func (al accessList) addAddress(address common.Address) {
+	if _, ok := al[address]; !ok {
+		al[address] = make(map[common.Hash]struct{})
+	}
+}
+
+// addSlot adds a storage slot of an address to the access list.
+func (al accessList) addSlot(address common.Address, slot common.Hash) {
+	al.addAddress(address)
+	al[address][slot] = struct{}{}
+}
+
+// equal checks if the content of the current access list is the same as the
+// content of the other one.
+func (al accessList) equal(other accessList) bool {
+	if len(al) != len(other) {
+		return false
+	}
+	for addr, slots := range al {
+		otherSlots, ok := other[addr]
+		if !ok || len(slots) != len(otherSlots) {
+			return false
+		}
+		for slot := range slots {
+			if _, ok := otherSlots[slot]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// accessList converts the accumulated accounts and slots into a sorted
+// EIP-2930 access list.
+func (al accessList) accessList() types.AccessList {
+	acl := make(types.AccessList, 0, len(al))
+	for addr, slots := range al {
+		tuple := types.AccessTuple{Address: addr, StorageKeys: make([]common.Hash, 0, len(slots))}
+		for slot := range slots {
+			tuple.StorageKeys = append(tuple.StorageKeys, slot)
+		}
+		sort.Slice(tuple.StorageKeys, func(i, j int) bool {
+			return bytes.Compare(tuple.StorageKeys[i][:], tuple.StorageKeys[j][:]) < 0
+		})
+		acl = append(acl, tuple)
+	}
+	sort.Slice(acl, func(i, j int) bool {
+		return bytes.Compare(acl[i].Address[:], acl[j].Address[:]) < 0
+	})
+	return acl
+}
+
+// AccessListTracer is a tracer accumulating the accounts and storage slots
+// touched by an execution into an EIP-2930 access list. The sender, the
+// recipient and the precompiles are excluded since they are warm regardless.
+type AccessListTracer struct {
+	excl map[common.Address]struct{} // addresses excluded from the list
+	list accessList                  // accounts and slots accessed so far
+}
+
+// NewAccessListTracer creates a tracer seeded with the given access list,
+// which excludes the sender, the recipient and the precompiles.
+func NewAccessListTracer(acl types.AccessList, from common.Address, to common.Address, precompiles []common.Address) *AccessListTracer {
+	excl := map[common.Address]struct{}{
+		from: {}, to: {},
+	}
+	for _, addr := range precompiles {
+		excl[addr] = struct{}{}
+	}
+	list := make(accessList)
+	for _, tuple := range acl {
+		if _, ok := excl[tuple.Address]; ok {
+			continue
+		}
+		list.addAddress(tuple.Address)
+		for _, slot := range tuple.StorageKeys {
+			list.addSlot(tuple.Address, slot)
+		}
+	}
+	return &AccessListTracer{
+		excl: excl,
+		list: list,
+	}
+}
+
+// CaptureStart implements the Tracer interface.
+func (a *AccessListTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	return nil
+}
+
+// CaptureState implements the Tracer interface to record the accounts and
+// slots accessed by the opcode.
+func (a *AccessListTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	stackLen := len(stack.Data())
+	switch {
+	case (op == vm.SLOAD || op == vm.SSTORE) && stackLen >= 1:
+		a.list.addSlot(contract.Address(), common.BigToHash(stack.Back(0)))
+	case (op == vm.EXTCODECOPY || op == vm.EXTCODEHASH || op == vm.EXTCODESIZE || op == vm.BALANCE || op == vm.SELFDESTRUCT) && stackLen >= 1:
+		a.addAddress(common.BigToAddress(stack.Back(0)))
+	case (op == vm.DELEGATECALL || op == vm.CALL || op == vm.STATICCALL || op == vm.CALLCODE) && stackLen >= 5:
+		a.addAddress(common.BigToAddress(stack.Back(1)))
+	}
+	return nil, nil
+}
+
+// addAddress records an account unless it is excluded.
+func (a *AccessListTracer) addAddress(addr common.Address) {
+	if _, ok := a.excl[addr]; !ok {
+		a.list.addAddress(addr)
+	}
+}
+
+// CaptureFault implements the Tracer interface.
+func (a *AccessListTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the Tracer interface.
+func (a *AccessListTracer) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	return nil
+}
+
+// AccessList returns the current access list.
+func (a *AccessListTracer) AccessList() types.AccessList {
+	return a.list.accessList()
+}
+
+// Equal returns whether the access lists of both tracers hold the same
+// accounts and slots.
+func (a *AccessListTracer) Equal(other *AccessListTracer) bool {
+	return a.list.equal(other.list)
+}
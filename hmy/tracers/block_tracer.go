@@ -25,7 +25,9 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	staking "github.com/harmony-one/harmony/staking/types"
 )
 
 type action struct {
@@ -44,6 +46,45 @@ type action struct {
 	err      error
 	revert   []byte
 	subCalls []*action
+	staking  *stakingAction
+	cx       *cxTransferAction
+
+	// original sizes of the truncated input, output and revert, zero if not truncated
+	inputSize  int
+	outputSize int
+	revertSize int
+}
+
+// stakingAction is the synthetic action of a staking transaction, which moves
+// value outside of the EVM.
+type stakingAction struct {
+	directive staking.Directive
+	validator common.Address
+	delegator common.Address
+}
+
+// cxTransferAction is the synthetic action of an incoming cross-shard receipt,
+// which credits the recipient outside of transaction execution.
+type cxTransferAction struct {
+	fromShard uint32
+	toShard   uint32
+}
+
+// truncate caps data to limit bytes, returning the original size if it was cut.
+// A non positive limit keeps the data in full.
+func truncate(data []byte, limit int) ([]byte, int) {
+	if limit <= 0 || len(data) <= limit {
+		return data, 0
+	}
+	return append([]byte(nil), data[:limit]...), len(data)
+}
+
+// withSize annotates a JSON object with the original size of a truncated field.
+func withSize(obj string, field string, size int) string {
+	if size == 0 {
+		return obj
+	}
+	return fmt.Sprintf(`%s,"%sLength":%d}`, strings.TrimSuffix(obj, "}"), field, size)
 }
 
 func (c *action) push(ac *action) {
@@ -51,16 +92,33 @@ func (c *action) push(ac *action) {
 }
 
 func (c action) toJsonStr() (string, *string, *string) {
+	if c.staking != nil {
+		action := fmt.Sprintf(
+			`{"directive":"%s","validator":"0x%x","delegator":"0x%x","amount":"0x%s"}`,
+			c.staking.directive, c.staking.validator, c.staking.delegator, c.value.Text(16),
+		)
+		output := fmt.Sprintf(`{"gasUsed":"0x%x"}`, c.gasUsed)
+		return "staking", &action, &output
+	}
+	if c.cx != nil {
+		action := fmt.Sprintf(
+			`{"fromShard":%d,"toShard":%d,"from":"0x%x","to":"0x%x","value":"0x%s"}`,
+			c.cx.fromShard, c.cx.toShard, c.from, c.to, c.value.Text(16),
+		)
+		return "cxTransfer", &action, nil
+	}
 	callType := strings.ToLower(c.op.String())
 	if c.op == vm.CREATE || c.op == vm.CREATE2 {
 		action := fmt.Sprintf(
 			`{"from":"0x%x","gas":"0x%x","init":"0x%x","value":"0x%s"}`,
 			c.from, c.gas, c.input, c.value.Text(16),
 		)
+		action = withSize(action, "init", c.inputSize)
 		output := fmt.Sprintf(
 			`{"address":"0x%x","code":"0x%x","gasUsed":"0x%x"}`,
 			c.to, c.output, c.gasUsed,
 		)
+		output = withSize(output, "code", c.outputSize)
 		return "create", &action, &output
 	}
 	if c.op == vm.CALL || c.op == vm.CALLCODE || c.op == vm.DELEGATECALL || c.op == vm.STATICCALL {
@@ -72,11 +130,13 @@ func (c action) toJsonStr() (string, *string, *string) {
 			`{"callType":"%s","value":"0x%s","to":"0x%x","gas":"0x%x","from":"0x%x","input":"0x%x"}`,
 			callType, c.value.Text(16), c.to, c.gas, c.from, c.input,
 		)
+		action = withSize(action, "input", c.inputSize)
 
 		output := fmt.Sprintf(
 			`{"output":"0x%x","gasUsed":"0x%x"}`,
 			c.output, c.gasUsed,
 		)
+		output = withSize(output, "output", c.outputSize)
 		return "call", &action, &output
 	}
 	if c.op == vm.SELFDESTRUCT {
@@ -90,6 +150,11 @@ func (c action) toJsonStr() (string, *string, *string) {
 }
 
 type ParityBlockTracer struct {
+	// MaxInputBytes and MaxOutputBytes cap the captured call data and return
+	// data of every frame, zero means unlimited
+	MaxInputBytes  int
+	MaxOutputBytes int
+
 	blockNumber         uint64
 	blockHash           common.Hash
 	transactionPosition uint64
@@ -97,6 +162,12 @@ type ParityBlockTracer struct {
 	descended           bool
 	calls               []*action
 	action
+
+	// gas accounting of the transaction, set if the refund is captured
+	gasAccounted     bool
+	intrinsicGas     uint64
+	gasRefunded      uint64
+	effectiveGasUsed uint64
 }
 
 func (jst *ParityBlockTracer) push(ac *action) {
@@ -126,7 +197,7 @@ func (jst *ParityBlockTracer) CaptureStart(env *vm.EVM, from common.Address, to
 	}
 	jst.from = from
 	jst.to = to
-	jst.input = input
+	jst.input, jst.inputSize = truncate(input, jst.MaxInputBytes)
 	jst.gas = gas
 	jst.value = (&big.Int{}).Set(value)
 	jst.blockHash = env.StateDB.BlockHash()
@@ -144,49 +215,36 @@ func (jst *ParityBlockTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode,
 		return nil, jst.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err)
 	}
 
-	var retErr error
-	stackPeek := func(n int) *big.Int {
-		if n >= len(stack.Data()) {
-			retErr = errors.New("tracer bug:stack overflow")
-			return big.NewInt(0)
-		}
-		return stack.Back(n)
-	}
-	memoryCopy := func(off, size int64) []byte {
-		if off+size >= int64(memory.Len()) {
-			retErr = errors.New("tracer bug:memory leak")
-			return nil
-		}
-		return memory.GetCopy(off, size)
-	}
+	frame := frameReader{stack: stack, memory: memory}
 
 	switch op {
 	case vm.CREATE, vm.CREATE2:
-		inOff := stackPeek(1).Int64()
-		inSize := stackPeek(2).Int64()
-		jst.push(&action{
+		inOff := frame.peekInt64(1)
+		inSize := frame.peekInt64(2)
+		createObj := &action{
 			op:      op,
 			from:    contract.Address(),
-			input:   memoryCopy(inOff, inSize),
 			gasIn:   gas,
 			gasCost: cost,
-			value:   (&big.Int{}).Set(stackPeek(0)),
-		})
+			value:   (&big.Int{}).Set(frame.peek(0)),
+		}
+		createObj.input, createObj.inputSize = truncate(frame.slice(inOff, inSize), jst.MaxInputBytes)
+		jst.push(createObj)
 		jst.descended = true
-		return nil, retErr
+		return nil, nil
 	case vm.SELFDESTRUCT:
 		ac := jst.last()
 		ac.push(&action{
 			op:      op,
 			from:    contract.Address(),
-			to:      common.BigToAddress(stackPeek(0)),
+			to:      common.BigToAddress(frame.peek(0)),
 			gasIn:   gas,
 			gasCost: cost,
 			value:   env.StateDB.GetBalance(contract.Address()),
 		})
-		return nil, retErr
+		return nil, nil
 	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
-		to := common.BigToAddress(stackPeek(1))
+		to := common.BigToAddress(frame.peek(1))
 		precompiles := vm.PrecompiledContractsVRF
 		if _, exist := precompiles[to]; exist {
 			return nil, nil
@@ -195,25 +253,25 @@ func (jst *ParityBlockTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode,
 		if op == vm.DELEGATECALL || op == vm.STATICCALL {
 			off = 0
 		}
-		inOff := stackPeek(2 + off).Int64()
-		inSize := stackPeek(3 + off).Int64()
+		inOff := frame.peekInt64(2 + off)
+		inSize := frame.peekInt64(3 + off)
 		callObj := &action{
 			op:      op,
 			from:    contract.Address(),
 			to:      to,
-			input:   memoryCopy(inOff, inSize),
 			gasIn:   gas,
 			gasCost: cost,
-			outOff:  stackPeek(4 + off).Int64(),
-			outLen:  stackPeek(5 + off).Int64(),
+			outOff:  frame.peekInt64(4 + off),
+			outLen:  frame.peekInt64(5 + off),
 		}
+		callObj.input, callObj.inputSize = truncate(frame.slice(inOff, inSize), jst.MaxInputBytes)
 		if op != vm.DELEGATECALL && op != vm.STATICCALL {
-			callObj.value = (&big.Int{}).Set(stackPeek(2))
+			callObj.value = (&big.Int{}).Set(frame.peek(2))
 		}
 		jst.push(callObj)
 		jst.descended = true
 
-		return nil, retErr
+		return nil, nil
 	}
 
 	if jst.descended {
@@ -225,20 +283,20 @@ func (jst *ParityBlockTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode,
 	if op == vm.REVERT {
 		last := jst.last()
 		last.err = errors.New("execution reverted")
-		revertOff := stackPeek(0).Int64()
-		revertLen := stackPeek(1).Int64()
-		last.revert = memoryCopy(revertOff, revertLen)
-		return nil, retErr
+		revertOff := frame.peekInt64(0)
+		revertLen := frame.peekInt64(1)
+		last.revert, last.revertSize = truncate(frame.slice(revertOff, revertLen), jst.MaxOutputBytes)
+		return nil, nil
 	}
 	if depth == jst.len()-1 { // depth == len - 1
 		call := jst.pop()
 		if call.op == vm.CREATE || call.op == vm.CREATE2 {
 			call.gasUsed = call.gasIn - call.gasCost - gas
 
-			ret := stackPeek(0)
+			ret := frame.peek(0)
 			if ret.Sign() != 0 {
 				call.to = common.BigToAddress(ret)
-				call.output = env.StateDB.GetCode(call.to)
+				call.output, call.outputSize = truncate(env.StateDB.GetCode(call.to), jst.MaxOutputBytes)
 			} else if call.err == nil {
 				call.err = errors.New("internal failure")
 			}
@@ -246,16 +304,16 @@ func (jst *ParityBlockTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode,
 			if call.gas != 0 {
 				call.gasUsed = call.gasIn - call.gasCost + call.gas - gas
 			}
-			ret := stackPeek(0)
+			ret := frame.peek(0)
 			if ret.Sign() != 0 {
-				call.output = memoryCopy(call.outOff, call.outLen)
+				call.output, call.outputSize = truncate(frame.slice(call.outOff, call.outLen), jst.MaxOutputBytes)
 			} else if call.err == nil {
 				call.err = errors.New("internal failure")
 			}
 		}
 		jst.last().push(call)
 	}
-	return nil, retErr
+	return nil, nil
 }
 
 // CaptureFault implements the ParityBlockTracer interface to trace an execution fault
@@ -281,9 +339,53 @@ func (jst *ParityBlockTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode,
 	return nil
 }
 
+// CaptureStaking implements the StakingTracer interface to record a staking
+// transaction as a single synthetic staking action.
+func (jst *ParityBlockTracer) CaptureStaking(env *vm.EVM, directive staking.Directive, validator, delegator common.Address, amount *big.Int, gasUsed uint64, err error) error {
+	jst.staking = &stakingAction{
+		directive: directive,
+		validator: validator,
+		delegator: delegator,
+	}
+	jst.from = delegator
+	jst.to = validator
+	jst.value = new(big.Int)
+	if amount != nil {
+		jst.value.Set(amount)
+	}
+	jst.gasUsed = gasUsed
+	jst.err = err
+	jst.blockHash = env.StateDB.BlockHash()
+	jst.transactionPosition = uint64(env.StateDB.TxIndex())
+	jst.transactionHash = env.StateDB.TxHash()
+	jst.blockNumber = env.BlockNumber.Uint64()
+	return nil
+}
+
+// CaptureCXTransfer records an incoming cross-shard receipt applied at the given
+// position of the block as a single synthetic cxTransfer action.
+func (jst *ParityBlockTracer) CaptureCXTransfer(blockNumber uint64, blockHash common.Hash, position uint64, cx *types.CXReceipt) {
+	jst.cx = &cxTransferAction{
+		fromShard: cx.ShardID,
+		toShard:   cx.ToShardID,
+	}
+	jst.from = cx.From
+	if cx.To != nil {
+		jst.to = *cx.To
+	}
+	jst.value = new(big.Int)
+	if cx.Amount != nil {
+		jst.value.Set(cx.Amount)
+	}
+	jst.blockHash = blockHash
+	jst.transactionPosition = position
+	jst.transactionHash = cx.TxHash
+	jst.blockNumber = blockNumber
+}
+
 // CaptureEnd is called after the call finishes to finalize the tracing.
 func (jst *ParityBlockTracer) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
-	jst.output = output
+	jst.output, jst.outputSize = truncate(output, jst.MaxOutputBytes)
 	jst.gasUsed = gasUsed
 	if err != nil {
 		jst.err = err
@@ -291,6 +393,17 @@ func (jst *ParityBlockTracer) CaptureEnd(output []byte, gasUsed uint64, t time.D
 	return nil
 }
 
+// CaptureRefund is called after the transaction refund is applied, so that
+// the gasUsed of the top level call reflects the gas actually paid for. The
+// intrinsic gas and the refund are reported on the top level trace.
+func (jst *ParityBlockTracer) CaptureRefund(env *vm.EVM, intrinsicGas, refund uint64) error {
+	jst.gasUsed, jst.effectiveGasUsed = applyRefund(intrinsicGas, jst.gasUsed, refund)
+	jst.gasAccounted = true
+	jst.intrinsicGas = intrinsicGas
+	jst.gasRefunded = refund
+	return nil
+}
+
 // GetResult calls the Javascript 'result' function and returns its value, or any accumulated error
 func (jst *ParityBlockTracer) GetResult() ([]json.RawMessage, error) {
 	root := &jst.action
@@ -314,8 +427,14 @@ func (jst *ParityBlockTracer) GetResult() ([]json.RawMessage, error) {
 			len(ac.subCalls), string(traceStr), typStr, *acStr,
 		)
 		var resultPiece string
-		if ac.err != nil {
+		if ac.err != nil && ac.staking != nil {
+			errStr, _ := json.Marshal(ac.err.Error())
+			resultPiece = fmt.Sprintf(`,"error":%s`, errStr)
+		} else if ac.err != nil {
 			resultPiece = fmt.Sprintf(`,"error":"Reverted","revert":"0x%x"`, ac.revert)
+			if ac.revertSize != 0 {
+				resultPiece += fmt.Sprintf(`,"revertLength":%d`, ac.revertSize)
+			}
 
 		} else if outStr != nil {
 			resultPiece = fmt.Sprintf(`,"result":%s`, *outStr)
@@ -323,7 +442,16 @@ func (jst *ParityBlockTracer) GetResult() ([]json.RawMessage, error) {
 			resultPiece = `,"result":null`
 		}
 
-		jstr := "{" + headPiece + bodyPiece + resultPiece + "}"
+		// the gas used by the transaction as in its receipt is the intrinsic gas
+		// and the gas used by the top level call, net of the refund
+		var gasPiece string
+		if ac == root && jst.gasAccounted {
+			gasPiece = fmt.Sprintf(
+				`,"intrinsicGas":"0x%x","gasRefunded":"0x%x","effectiveGasUsed":"0x%x"`,
+				jst.intrinsicGas, jst.gasRefunded, jst.effectiveGasUsed,
+			)
+		}
+		jstr := "{" + headPiece + bodyPiece + resultPiece + gasPiece + "}"
 		results = append(results, json.RawMessage(jstr))
 		for i, subAc := range ac.subCalls {
 			finalize(subAc, append(traceAddress[:], i))
@@ -0,0 +1,159 @@
+package tracers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func TestParityBlockTracerCXTransfer(t *testing.T) {
+	var (
+		from      = common.HexToAddress("0x1000")
+		to        = common.HexToAddress("0x2000")
+		txHash    = common.HexToHash("0x01")
+		blockHash = common.HexToHash("0x02")
+	)
+	tracer := &ParityBlockTracer{}
+	tracer.CaptureCXTransfer(12, blockHash, 3, &types.CXReceipt{
+		TxHash:    txHash,
+		From:      from,
+		To:        &to,
+		ShardID:   1,
+		ToShardID: 0,
+		Amount:    big.NewInt(1000),
+	})
+	results, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("have %d traces, want 1", len(results))
+	}
+	var trace struct {
+		BlockNumber         uint64      `json:"blockNumber"`
+		BlockHash           common.Hash `json:"blockHash"`
+		TransactionHash     common.Hash `json:"transactionHash"`
+		TransactionPosition uint64      `json:"transactionPosition"`
+		Type                string      `json:"type"`
+		Action              struct {
+			FromShard uint32         `json:"fromShard"`
+			ToShard   uint32         `json:"toShard"`
+			From      common.Address `json:"from"`
+			To        common.Address `json:"to"`
+			Value     *hexutil.Big   `json:"value"`
+		} `json:"action"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(results[0], &trace); err != nil {
+		t.Fatalf("invalid trace %s: %v", results[0], err)
+	}
+	if trace.Type != "cxTransfer" {
+		t.Errorf("have type %q, want cxTransfer", trace.Type)
+	}
+	if trace.BlockNumber != 12 || trace.BlockHash != blockHash || trace.TransactionHash != txHash || trace.TransactionPosition != 3 {
+		t.Errorf("have block %d %x, tx %x at %d, want block 12 %x, tx %x at 3",
+			trace.BlockNumber, trace.BlockHash, trace.TransactionHash, trace.TransactionPosition, blockHash, txHash)
+	}
+	action := trace.Action
+	if action.FromShard != 1 || action.ToShard != 0 || action.From != from || action.To != to {
+		t.Errorf("have transfer %x@%d to %x@%d, want %x@1 to %x@0", action.From, action.FromShard, action.To, action.ToShard, from, to)
+	}
+	if action.Value == nil || action.Value.ToInt().Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("have value %v, want 1000", action.Value)
+	}
+	if string(trace.Result) != "null" {
+		t.Errorf("have result %s, want null", trace.Result)
+	}
+}
+
+func TestParityBlockTracerTruncate(t *testing.T) {
+	var (
+		from   = common.HexToAddress("0x1000")
+		caller = common.HexToAddress("0x2000")
+		callee = common.HexToAddress("0x3000")
+	)
+	// the caller passes its call data padded to 64 bytes to the callee, which
+	// returns it, and returns the result of the call
+	callerCode := []byte{
+		byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.CALLDATACOPY),
+		byte(vm.PUSH1), 64, byte(vm.PUSH1), 0, byte(vm.PUSH1), 64, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.PUSH20),
+	}
+	callerCode = append(callerCode, callee.Bytes()...)
+	callerCode = append(callerCode,
+		byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+		byte(vm.PUSH1), 64, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	)
+	calleeCode := []byte{
+		byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.CALLDATACOPY),
+		byte(vm.PUSH1), 64, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}
+	input := make([]byte, 40)
+	for i := range input {
+		input[i] = byte(i + 1)
+	}
+	alloc := map[common.Address]account{
+		from:   {Balance: math.NewHexOrDecimal256(1e18)},
+		caller: {Code: callerCode},
+		callee: {Code: calleeCode},
+	}
+
+	type payloadTrace struct {
+		Action struct {
+			Input       hexutil.Bytes `json:"input"`
+			InputLength int           `json:"inputLength"`
+		} `json:"action"`
+		Result struct {
+			Output       hexutil.Bytes `json:"output"`
+			OutputLength int           `json:"outputLength"`
+		} `json:"result"`
+	}
+	run := func(maxInput, maxOutput int) []payloadTrace {
+		msg := types.NewMessage(from, &caller, 0, new(big.Int), 100000, big.NewInt(1), input, false)
+		tracer := &ParityBlockTracer{MaxInputBytes: maxInput, MaxOutputBytes: maxOutput}
+		runTracer(t, makePreState(t, alloc), fixtureConfig(), &callContext{GasLimit: 1000000}, msg, tracer)
+		results, err := tracer.GetResult()
+		if err != nil {
+			t.Fatal(err)
+		}
+		traces := make([]payloadTrace, len(results))
+		for i, result := range results {
+			if err := json.Unmarshal(result, &traces[i]); err != nil {
+				t.Fatalf("invalid trace %s: %v", result, err)
+			}
+		}
+		if len(traces) != 2 {
+			t.Fatalf("have %d traces, want 2", len(traces))
+		}
+		return traces
+	}
+
+	// nothing is truncated without limits
+	traces := run(0, 0)
+	for i, trace := range traces {
+		if trace.Action.InputLength != 0 || trace.Result.OutputLength != 0 {
+			t.Errorf("trace %d: have input length %d and output length %d, want none", i, trace.Action.InputLength, trace.Result.OutputLength)
+		}
+	}
+	if !bytes.Equal(traces[0].Action.Input, input) || len(traces[1].Action.Input) != 64 || len(traces[0].Result.Output) != 64 {
+		t.Errorf("have input %x, call input %x and output %x, want them in full", traces[0].Action.Input, traces[1].Action.Input, traces[0].Result.Output)
+	}
+
+	traces = run(4, 8)
+	for i, want := range []struct{ inputLength, outputLength int }{{40, 64}, {64, 64}} {
+		trace := traces[i]
+		if !bytes.Equal(trace.Action.Input, input[:4]) || trace.Action.InputLength != want.inputLength {
+			t.Errorf("trace %d: have input %x of length %d, want %x of length %d", i, trace.Action.Input, trace.Action.InputLength, input[:4], want.inputLength)
+		}
+		if !bytes.Equal(trace.Result.Output, input[:8]) || trace.Result.OutputLength != want.outputLength {
+			t.Errorf("trace %d: have output %x of length %d, want %x of length %d", i, trace.Result.Output, trace.Result.OutputLength, input[:8], want.outputLength)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tracers
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// maxMemoryExpansion caps how far past the current memory size a tracer reads.
+// Expanding the memory by that much costs more gas than a block can hold, so a
+// farther read is for an operation bound to fail.
+const maxMemoryExpansion = 16 * 1024 * 1024
+
+// stackBack returns the nth element from the top of the stack, or zero and
+// false if the stack is not that deep.
+func stackBack(stack *vm.Stack, n int) (*big.Int, bool) {
+	if n < 0 || n >= len(stack.Data()) {
+		return new(big.Int), false
+	}
+	return stack.Back(n), true
+}
+
+// memorySlice returns a copy of size bytes of the memory at the offset. The
+// memory may not be expanded yet for the operation being traced, so the bytes
+// past its current size read as zero, as the expansion fills them. It returns
+// false for a negative range or one past the expansion cap.
+func memorySlice(memory *vm.Memory, off, size int64) ([]byte, bool) {
+	if size == 0 {
+		return nil, true
+	}
+	if off < 0 || size < 0 || size > maxMemoryExpansion || off > int64(memory.Len())+maxMemoryExpansion-size {
+		return nil, false
+	}
+	data := make([]byte, size)
+	if off < int64(memory.Len()) {
+		copy(data, memory.Data()[off:])
+	}
+	return data, true
+}
+
+// frameReader reads the stack and the memory of the operation being traced,
+// reading zero values out of range rather than failing the trace.
+type frameReader struct {
+	stack  *vm.Stack
+	memory *vm.Memory
+}
+
+// peek returns the nth element from the top of the stack
+func (f frameReader) peek(n int) *big.Int {
+	v, _ := stackBack(f.stack, n)
+	return v
+}
+
+// peekInt64 returns the nth element from the top of the stack as an offset or
+// a size, saturated to the largest int64.
+func (f frameReader) peekInt64(n int) int64 {
+	v := f.peek(n)
+	if !v.IsInt64() {
+		return math.MaxInt64
+	}
+	return v.Int64()
+}
+
+// slice returns a copy of size bytes of the memory at the offset, nil if the
+// range cannot be read.
+func (f frameReader) slice(off, size int64) []byte {
+	data, _ := memorySlice(f.memory, off, size)
+	return data
+}
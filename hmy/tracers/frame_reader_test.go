@@ -0,0 +1,52 @@
+package tracers
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func TestMemorySlice(t *testing.T) {
+	memory := vm.NewMemory()
+	memory.Resize(64)
+	memory.Set(0, 4, []byte{1, 2, 3, 4})
+	memory.Set(60, 4, []byte{5, 6, 7, 8})
+
+	tests := []struct {
+		off, size int64
+		want      []byte
+		ok        bool
+	}{
+		{0, 4, []byte{1, 2, 3, 4}, true},
+		{60, 4, []byte{5, 6, 7, 8}, true},
+		// the memory is not expanded yet
+		{62, 4, []byte{7, 8, 0, 0}, true},
+		{100, 2, []byte{0, 0}, true},
+		// no memory is read for an empty range
+		{math.MaxInt64, 0, nil, true},
+		{-1, 4, nil, false},
+		{0, math.MaxInt64, nil, false},
+		{math.MaxInt64, 1, nil, false},
+	}
+	for i, test := range tests {
+		data, ok := memorySlice(memory, test.off, test.size)
+		if ok != test.ok || !bytes.Equal(data, test.want) {
+			t.Errorf("test %d: have %x %v, want %x %v", i, data, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestFrameReaderOutOfRange(t *testing.T) {
+	frame := frameReader{stack: &vm.Stack{}, memory: vm.NewMemory()}
+	if v := frame.peek(0); v.Sign() != 0 {
+		t.Errorf("peek of an empty stack: have %v, want 0", v)
+	}
+	if v := frame.peekInt64(3); v != 0 {
+		t.Errorf("peekInt64 of an empty stack: have %v, want 0", v)
+	}
+	if data := frame.slice(0, 32); !bytes.Equal(data, make([]byte, 32)) {
+		t.Errorf("slice of an empty memory: have %x, want 32 zero bytes", data)
+	}
+}
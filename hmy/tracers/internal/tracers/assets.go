@@ -137,7 +137,7 @@ func block_tracerJs() (*asset, error) {
 	return a, nil
 }
 
-var _call_tracerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x5a\xdf\x6f\x1b\x37\xf2\x7f\x96\xfe\x8a\x89\x1f\x6a\x09\x51\x24\x39\xe9\xb7\x5f\xc0\xae\x7a\x50\x1d\x25\x35\xe0\xc6\x81\xad\x34\x08\x82\x3c\x50\xbb\xb3\x12\x6b\x8a\xdc\x92\x5c\xc9\xba\xd6\xff\xfb\x61\x86\xdc\xd5\xae\x24\x3b\xbe\x5e\x71\xe8\xbd\x69\x97\x33\xc3\xe1\xcc\x67\x7e\x71\x35\x18\xc0\xb9\xc9\x37\x56\xce\x17\x1e\x5e\x0e\x4f\xfe\x1f\xa6\x0b\x84\xb9\x79\x81\x7e\x81\x16\x8b\x25\x8c\x0b\xbf\x30\xd6\xb5\x07\x03\x98\x2e\xa4\x83\x4c\x2a\x04\xe9\x20\x17\xd6\x83\xc9\xc0\xef\xd0\x2b\x39\xb3\xc2\x6e\xfa\xed\xc1\x20\xf0\x1c\x5c\x26\x09\x99\x45\x04\x67\x32\xbf\x16\x16\x4f\x61\x63\x0a\x48\x84\x06\x8b\xa9\x74\xde\xca\x59\xe1\x11\xa4\x07\xa1\xd3\x81\xb1\xb0\x34\xa9\xcc\x36\x24\x52\x7a\x28\x74\x8a\x96\xb7\xf6\x68\x97\xae\xd4\xe3\xed\xbb\x0f\x70\x89\xce\xa1\x85\xb7\xa8\xd1\x0a\x05\xef\x8b\x99\x92\x09\x5c\xca\x04\xb5\x43\x10\x0e\x72\x7a\xe3\x16\x98\xc2\x8c\xc5\x11\xe3\x1b\x52\xe5\x26\xaa\x02\x6f\x4c\xa1\x53\xe1\xa5\xd1\x3d\x40\x49\x9a\xc3\x0a\xad\x93\x46\xc3\xab\x72\xab\x28\xb0\x07\xc6\x92\x90\x8e\xf0\x74\x00\x0b\x26\x27\xbe\x2e\x08\xbd\x01\x25\xfc\x96\xf5\x09\x06\xd9\x9e\x3b\x05\xa9\x79\x9b\x85\xc9\x11\xfc\x42\x78\x3a\xf5\x5a\x2a\x05\x33\x84\xc2\x61\x56\xa8\x1e\x49\x9b\x15\x1e\x3e\x5e\x4c\x7f\xba\xfa\x30\x85\xf1\xbb\x4f\xf0\x71\x7c\x7d\x3d\x7e\x37\xfd\x74\x06\x6b\xe9\x17\xa6\xf0\x80\x2b\x0c\xa2\xe4\x32\x57\x12\x53\x58\x0b\x6b\x85\xf6\x1b\x30\x19\x49\xf8\x79\x72\x7d\xfe\xd3\xf8\xdd\x74\xfc\xe3\xc5\xe5\xc5\xf4\x13\x18\x0b\x6f\x2e\xa6\xef\x26\x37\x37\xf0\xe6\xea\x1a\xc6\xf0\x7e\x7c\x3d\xbd\x38\xff\x70\x39\xbe\x86\xf7\x1f\xae\xdf\x5f\xdd\x4c\xfa\x70\x83\xa4\x15\x12\xff\xd7\x6d\x9e\xb1\xf7\x2c\x42\x8a\x5e\x48\xe5\x4a\x4b\x7c\x32\x05\xb8\x85\x29\x54\x0a\x0b\xb1\x42\xb0\x98\xa0\x5c\x61\x0a\x02\x12\x93\x6f\x9e\xec\x54\x92\x25\x94\xd1\x73\x3e\xf3\x83\x80\x84\x8b\x0c\xb4\xf1\x3d\x70\x88\xf0\xfd\xc2\xfb\xfc\x74\x30\x58\xaf\xd7\xfd\xb9\x2e\xfa\xc6\xce\x07\x2a\x88\x73\x83\x1f\xfa\x6d\x92\x99\x08\xa5\xa6\x56\x24\x68\xc9\x39\x02\xb2\x82\xcc\xaf\xcc\x5a\x83\xb7\x42\x3b\x91\x90\xab\xe9\x77\xc2\x60\x14\x1e\xf0\x8e\x9e\xbc\x23\xd0\x82\xc5\xdc\x58\xfa\xad\x54\x89\x33\xa9\x3d\x5a\x2d\x14\xcb\x76\xb0\x14\x29\xc2\x6c\x03\xa2\x2e\xb0\x57\x3f\x0c\xc1\x28\xb8\x1b\xa4\xce\x8c\x5d\x32\x2c\xfb\xed\xdf\xdb\xad\xa8\xa1\xf3\x22\xb9\x25\x05\x49\x7e\x52\x58\x8b\xda\x93\x29\x0b\xeb\xe4\x0a\x99\x04\x02\x4d\xb4\xe7\xe4\x97\x9f\x01\xef\x30\x29\x82\xa4\x56\x25\xe4\x14\x3e\xff\x7e\xff\xa5\xd7\x66\xd1\x29\xba\x04\x75\x8a\x29\x9f\xef\xd6\xc1\x7a\xc1\x16\x85\x35\x1e\xaf\x10\x7e\x2d\x9c\xaf\xd1\x64\xd6\x2c\x41\x68\x30\x05\x21\xbe\x6e\x1d\xa9\xbd\x61\x81\x82\x7e\x6b\xb4\xac\x51\xbf\xdd\xaa\x98\x4f\x21\x13\xca\x61\xdc\xd7\x79\xcc\xe9\x34\x52\xaf\xcc\x2d\x49\x36\x96\x20\x6c\x37\x60\xf2\xc4\xa4\x31\x18\xe8\x1c\xd5\x31\xd0\xf5\xdb\x2d\xe2\x3b\x85\xac\xd0\xbc\x6d\x47\x99\x79\x0f\xd2\x59\x17\x7e\x6f\xb7\x48\xec\xb9\xc8\x7d\x61\x91\xed\x89\xd6\x1a\xeb\x40\x2e\x97\x98\x4a\xe1\x51\x6d\xda\xad\xd6\x4a\xd8\xb0\x00\x23\x50\x66\xde\x9f\xa3\x9f\xd0\x63\xa7\x7b\xd6\x6e\xb5\x64\x06\x9d\xb0\xfa\x6c\x34\xe2\xec\x93\x49\x8d\x69\x10\xdf\xf2\x0b\xe9\xfa\x99\x28\x94\xaf\xf6\x25\xa6\x96\x45\x5f\x58\x4d\x3f\xef\x83\x16\x1f\x11\x8c\x56\x1b\x48\x28\xcb\x88\x19\x85\xa7\xdb\x38\x8f\xcb\x78\x38\xd7\x83\x4c\x38\x32\xa1\xcc\x60\x8d\x90\x5b\x7c\x91\x2c\x90\x7c\xa7\x13\x8c\x5a\xba\x8d\x63\xa7\x8e\x80\x76\xeb\x9b\xbc\xef\xcd\xbb\x62\x39\x43\xdb\xe9\xc2\x37\x30\xbc\xcb\x86\x5d\x18\x8d\xf8\x47\xa9\x7b\xe4\x89\xfa\x92\x14\x93\xc7\x83\x32\xff\x8d\xb7\x52\xcf\xc3\x59\xa3\xae\x17\x19\x08\xd0\xb8\x86\xc4\x68\x06\x35\x79\x65\x86\x52\xcf\x21\xb1\x28\x3c\xa6\x3d\x10\x69\x0a\xde\x04\xe4\x55\x38\x6b\x6e\x09\xdf\x7c\x03\x1d\xda\x6c\x04\xc7\xe7\xd7\x93\xf1\x74\x72\x0c\x7f\xfc\x01\xe1\xcd\x51\x78\xf3\xf2\xa8\x5b\xd3\x4c\xea\xab\x2c\x8b\xca\xb1\xc0\x7e\x8e\x78\xdb\x39\xe9\xf6\x57\x42\x15\x78\x95\x05\x35\x23\xed\x44\xa7\x30\x8a\x3c\xcf\x77\x79\x5e\x36\x78\x88\x69\x30\x80\xb1\x73\xb8\x9c\x29\xdc\x0f\xc8\x18\xb1\x1c\xbc\xce\x53\xc6\x22\xf4\x25\x66\x99\x2b\x24\x54\x95\xbb\x46\xf3\xb3\xc6\x2d\xbf\xc9\xf1\x14\x00\xc0\xe4\x3d\x7e\x41\xb1\xc0\x2f\xbc\xf9\x09\xef\xd8\x47\xa5\x09\x09\x55\xe3\x34\xb5\xe8\x5c\xa7\xdb\x0d\xe4\x52\xe7\x85\x3f\x6d\x90\x2f\x71\x69\xec\xa6\xef\x28\x21\x75\xf8\x68\xbd\x70\xd2\x92\x67\x2e\xdc\x85\x26\x9e\x88\xd4\xb7\xc2\x75\xb6\x4b\xe7\xc6\xf9\xd3\x72\x89\x1e\xca\x35\xb6\x05\xb1\x1d\x0f\xef\x8e\xf7\xad\x35\xec\x6e\x91\x70\xf2\x5d\x97\x58\xee\xcf\x2a\x7c\x57\x69\xa2\x9f\x17\x6e\xd1\x61\x38\x6d\x57\xb7\xa9\x60\x04\xde\x16\x78\x10\xfe\x0c\xa9\x7d\x38\x39\x54\x19\xe5\x12\x6f\x8b\x84\x61\x35\x17\x9c\x69\x38\xd2\x05\x65\x5e\x57\xcc\xd8\xe6\xde\x98\x7d\x74\x45\x70\xdd\x4c\x2e\xdf\xbc\x9e\xdc\x4c\xaf\x3f\x9c\x4f\x8f\x6b\x70\x52\x98\x79\x52\xaa\x79\x06\x85\x7a\xee\x17\xac\x3f\x89\x6b\xae\x7e\x26\x9e\x17\x27\x5f\xc2\x1b\x18\x1d\x08\xf9\xd6\xe3\x1c\xf0\xf9\x0b\xcb\xbe\xdf\x37\x5f\x93\x34\x18\xf3\xaf\x41\x92\x37\x4c\x5c\x92\x7b\x53\x12\x3c\xee\xe7\xbf\x18\x54\xe9\x8c\x28\x7e\x14\x4a\xe8\x04\x1f\xd1\x79\x1f\x6b\xf5\xa4\x79\x20\x0f\x2d\xd1\x2f\x4c\xca\x85\x21\x11\xa1\xb6\x94\x08\x4a\x8d\xc6\x7f\x3f\x1b\x8d\x2f\x2f\x6b\xb9\x88\x9f\xcf\xaf\x5e\xd7\xf3\xd3\xf1\xeb\xc9\xe5\xe4\xed\x78\x3a\xd9\xa5\xbd\x99\x8e\xa7\x17\xe7\xfc\xb6\x4c\x5d\x83\x01\xdc\xdc\xca\x9c\x2b\x0c\xe7\x6d\xb3\xcc\xb9\x55\xae\xf4\x75\x3d\xf0\x0b\x43\x4d\xa8\x8d\x05\x34\x13\x3a\x29\x0b\x9b\x2b\x01\xeb\x0d\xc1\xf5\x21\xe7\x9d\xec\x38\xaf\x82\xb0\x74\xef\x2d\xc6\x4d\xd3\x8e\x37\xa5\x5e\x5b\x83\x06\x34\x72\xf2\xe7\x04\xdb\x79\xfa\x21\xe1\x1f\x30\x84\x53\x38\x89\x59\xf4\x91\x34\xfd\x12\x9e\x93\xf8\x3f\x91\xac\x5f\x1d\xe0\xfc\x7b\xa6\xec\xbd\x40\xfb\xef\xa7\x72\x53\xf8\xab\x2c\x3b\x85\x5d\x23\x7e\xbb\x67\xc4\x8a\xfe\x12\xf5\x3e\xfd\xff\xed\xd1\x6f\xd3\x3e\xa1\xca\xe4\xf0\x6c\x0f\x22\x21\xe9\x3e\xdb\x89\x83\x68\x5c\x6e\xef\x58\x1a\x8c\x1e\x28\x34\x2f\x9b\x18\x7e\x28\x53\xfe\x47\x85\xe6\x60\x9b\x4a\xcd\x68\xb3\x11\xed\x81\x45\x6f\x25\xae\x68\xd4\x3c\x76\x2c\x92\x1a\x76\xb3\xa6\xf4\xd5\x87\x8f\x18\x24\x6a\x44\x4e\x2e\xb1\xc1\xa7\xfe\x8c\x7b\x5e\x6a\xd2\xe3\xa8\xc6\x10\x13\xdc\x87\x5b\x84\xa5\xd8\xd0\xa8\x96\x15\xfa\x76\x03\x73\xe1\x20\xdd\x68\xb1\x94\x89\x0b\xf2\xb8\xb9\xb7\x38\x17\x96\xc5\x5a\xfc\xad\x40\x47\x73\x1f\x01\x59\x24\xbe\x10\x4a\x6d\x60\x2e\x69\x78\x23\xee\xce\xcb\x57\xc3\x21\x38\x2f\x73\xd4\x69\x0f\xbe\x7b\x35\xf8\xee\x5b\xb0\x85\xc2\x6e\xbf\x5d\x2b\x61\xd5\x51\xa3\x37\x68\x21\xa2\xe7\x35\xe6\x7e\xd1\xe9\xc2\x0f\x0f\xd4\xc2\x07\x0a\xdb\x41\x5a\x78\x01\x27\x5f\xfa\xa4\xd7\xa8\x81\xdb\xe0\x49\x40\xe5\x30\x4a\xa3\x81\xf7\xea\xf5\x55\xe7\x56\x58\xa1\xc4\x0c\xbb\xa7\x3c\x00\xb3\xad\xd6\x22\x4e\x40\xe4\x14\xc8\x95\x90\x1a\x44\x92\x98\x42\x7b\x32\x7c\x39\xcc\xa8\x0d\xe5\xf7\x63\x5f\xca\xe3\x59\x51\x24\x09\x3a\x57\xa6\x7b\xf6\x1a\xa9\x23\x96\xc4\x0d\x52\x3b\x99\x62\xcd\x2b\x94\x1d\x0c\xa7\xe6\x48\x41\xa3\x74\x29\x70\x69\x1c\x6d\x32\x43\x58\x5b\x1a\xbc\x9c\xd4\x09\xdf\x3c\xa4\x48\xd6\x76\x60\x34\x08\x50\x86\xaf\x3b\x38\xc6\x41\xd8\xb9\xeb\x87\x7c\x4f\xdb\x52\xce\xd1\x66\xdd\x6f\x02\xb9\x0e\x55\x1e\x71\x76\x5a\x21\x0d\x78\x27\x9d\xe7\x8e\x9a\xb4\x94\x0e\x02\x92\xa5\x9e\xf7\x20\x37\x39\xe7\xe9\xaf\x95\xb3\x98\xac\xaf\x27\xbf\x4c\xae\xab\xc6\xe7\xe9\x4e\x2c\x67\x9e\xa3\x6a\x24\x04\x4b\xf3\x96\xc7\xf4\xe8\xc0\x10\x73\x00\x50\xa3\x07\x00\x45\xf2\xb7\xb5\xf1\x7d\xed\x38\x4a\x38\xbf\x75\xcc\x1c\xc3\x3c\x57\x57\xc0\x15\xca\xbb\x9d\xdc\xbd\x9b\x1c\x4c\x5e\x56\x08\x52\x8a\xd3\x0e\x25\xf6\xdd\x49\xa3\xb1\xb0\x1d\x38\xb6\xf8\xbc\xa8\xd9\x78\xcd\xed\x66\x20\xaa\xa5\x06\x5e\x2f\xfb\x56\x11\xaa\x01\xeb\x6e\x0a\x4f\x70\xa0\xfa\xbd\x4d\x7e\x73\xe1\x3e\x38\xf6\x7a\x4c\x7f\x33\x39\xbf\xd0\xbe\x53\x2e\x5e\x68\x78\x01\xe5\x03\x25\x75\x78\xd1\x88\xa2\x03\xd9\xb1\x95\xa2\x42\x8f\xb0\x15\x71\x06\x3b\xaf\x48\x50\x30\x07\x1b\xcd\xa2\xdf\x2f\xce\xc3\x28\x8d\x0c\xf6\xcc\xa2\xef\xe3\x6f\x85\x50\xae\x33\xac\x9a\x85\x70\x02\x6f\xb8\xbc\x8d\xf6\x3a\x49\xe2\x69\xf6\x8e\x67\x35\xb6\x68\x8d\x92\x2d\x74\x82\xe7\x26\xc5\x47\x25\x44\x11\x31\x6d\x54\xbe\x8c\xc0\x3c\xd4\x7b\xb7\xea\x04\x70\x54\x35\x04\x99\x90\xaa\xb0\x78\x74\x06\x07\xd2\x8e\x2b\x6c\x26\x12\xf6\xa5\x43\xe0\x69\xdd\x81\x33\x4b\x5c\x98\x75\x50\xe0\x50\xf2\xda\x07\x47\x85\x83\x9d\xf2\xc1\xd7\x4e\xc2\x41\xe1\xc4\x1c\x6b\xe0\xa8\x0c\x5e\x3a\xea\xe0\x15\xc2\x9f\x86\xce\xf3\xea\xf1\x09\x28\xba\xff\x6b\xe0\xb1\xe3\xe7\xbd\x3e\xa7\x24\xe2\x6e\xa7\xf6\x50\x2a\x1b\x9a\x91\xbf\x97\xe3\x9f\x1c\x61\xbb\xb4\xe1\x68\x4d\xe2\x70\xc0\x6d\x5f\xf3\x75\xf7\x57\xab\x0f\x79\xfe\xa1\x96\x89\x30\xaa\x7f\xc5\xc4\x6f\x71\xca\x5d\x0e\x3d\xe5\x16\x57\xd2\x14\x54\xc0\xf0\x7f\x69\x1c\xae\x5a\xbe\xfb\x76\xeb\x3e\xde\x0b\xb2\xdf\xea\x17\x83\xeb\x45\xbc\xd7\x0e\xdd\x52\xad\x7c\x18\xae\xad\xf1\xba\x30\x0b\x37\xce\x2d\xe6\x7f\xe4\x82\x30\x06\xba\x37\x39\xb5\x03\xb1\x3a\x29\x8b\x22\xdd\x54\x05\xb1\x17\x1a\x11\x58\x08\x9d\xc6\x61\x44\xa4\xa9\x24\x79\x0c\x42\xd2\x50\xcc\x85\xd4\xed\x83\x66\xfc\x6a\x15\x3e\x84\x8c\xbd\xde\xb6\x5e\x48\xe3\x10\x49\x13\x1f\x6b\xdc\x7e\x42\xc1\xdc\x09\xa2\xdd\xbb\xce\x78\x5d\x6a\xb4\x2b\x96\xdc\x09\x83\x58\x09\xa9\x04\x4d\x5f\xdc\x61\xe9\x14\x12\x85\x42\x87\x2f\x1c\x98\x79\xb3\x42\xeb\xda\x4f\x00\xf9\x9f\xc1\xf8\x4e\x56\x2c\x1f\xa3\x39\x9e\x1e\xb3\x4f\x8d\xd8\x70\xfc\x37\x4a\x78\x1f\xe1\x55\x33\x6f\x88\x2c\xe9\xf9\xe3\x17\x6a\xdf\x7e\x5a\x48\x71\xcf\x44\x34\x3f\xc0\xb0\xd6\x97\xff\x5d\x82\x6c\x1f\x62\x97\x55\x7f\x16\x0f\xef\x8d\xe9\x81\x42\xc1\x53\x52\xf9\x69\xaa\xec\x47\x1f\x1b\xda\xca\xe8\x0d\x1d\xdd\x5e\xf8\xf2\x9d\xde\x02\xcb\x1b\x90\xd0\xda\xcf\x10\x35\x48\x8f\x56\xd0\x3c\x44\xe8\x8a\x5f\x53\x48\x4b\xc7\xe2\xd8\x2f\x92\x82\x2e\x0a\x8e\x9f\x36\xa8\x30\x4b\x3d\xef\xb7\x5b\xe1\x7d\x2d\xde\x13\x7f\xb7\x8d\xf7\x50\x01\x99\x33\xde\x09\x54\x57\x02\x89\xbf\xe3\x6e\x91\xc7\xe6\x9d\x7b\x01\x5a\xa3\x57\x61\xa6\xde\xb9\x05\x60\xc6\x78\x13\xb0\x7b\x27\x46\x6b\xfc\xae\x01\x70\x26\x9d\x0b\x17\xc4\xec\x84\x84\xbf\xdb\x8f\x88\x92\x81\x82\xe1\xf4\x30\x03\x2d\x1d\x60\xda\xb9\x99\x20\x62\x7e\x15\x56\x43\x3d\x3f\xad\xaf\x86\x57\xf1\xa0\x72\x59\xb3\x8d\x5c\xb2\x6d\xee\xcf\x0e\x27\xb9\x61\x89\xc7\xc3\xc9\x8c\x6c\x5e\x01\xf6\x01\xd6\xfa\xac\xb1\x4f\xf2\x58\xaa\x64\xe9\x65\x66\x7b\x80\x95\xa5\xd7\x5a\x0e\x7f\xf7\x74\x91\x15\x71\x5d\xc5\x06\x4d\x43\x08\xdf\x36\xee\x2d\x1f\x9a\xb4\x68\x50\x89\x84\x65\x73\x35\x1a\x1d\x0d\xef\xaa\x0f\x23\x31\x57\x35\x68\x4a\x25\x42\x64\x84\xf3\x72\x54\xc8\x7f\x62\xdc\xb6\x1e\x83\xe5\x12\x58\x0c\x1f\x70\xb8\x9b\xa5\x10\x34\x33\x6e\x20\x0a\x47\xa3\xe8\x36\xb6\x52\x74\xd2\x62\x0a\x99\x44\x95\x82\x49\xd1\xf2\xa0\xfb\xab\x33\x3a\x7c\xaa\x43\x2b\x49\x62\xf8\x24\x19\xfe\x1d\xc0\x1f\x4a\xb5\x4c\xd0\x6f\x20\x43\xc1\xdf\xdc\xbc\x81\x5c\x38\x07\x4b\x14\x34\xda\x66\x85\x52\x1b\x30\x36\x45\x12\x5e\xcd\x7a\x14\xd6\x06\x0a\x87\xd6\xc1\x7a\x61\x62\xa9\xe5\x16\x2f\xa7\x6e\x55\xfa\x5e\xbc\xce\x91\x2e\x57\x62\x03\xd2\x53\x59\x8f\x87\xaa\x47\x7a\xf5\xa1\x8b\xbf\x96\x19\x32\xf0\x7e\x98\x97\x53\x61\x33\xce\xf9\x35\x3d\x35\x23\x3c\x0e\x45\xcd\xd8\xde\x5e\x74\x35\x03\xb9\x2c\x3d\xcd\x68\xad\x17\xb2\x66\x48\xf2\x0a\x3f\x35\x83\xb1\xd6\x6a\xf3\x02\x23\xa8\x62\xe0\xa7\x9d\xf0\x64\x2d\x63\x7c\x86\xcf\xba\x15\x39\x3f\xf5\x22\x60\xc8\x8b\x1d\x32\xce\x2d\x6e\x28\x9b\x07\x1b\xd5\x4a\x53\x78\xf1\xf9\x16\x37\x5f\x0e\x57\xa2\x08\xc7\x1a\x5d\x55\x7a\xca\xb0\x08\x6b\x8f\x24\x83\x4a\x0b\x39\x1a\x9e\x81\xfc\xbe\xce\x50\x56\x4f\x90\xcf\x9f\x97\x7b\xd6\xd7\x3f\xcb\x2f\x65\x84\x57\x88\xdf\x59\xef\x36\x34\x8a\x31\x12\x68\x28\x28\xda\xf7\xed\x7f\x05\x00\x00\xff\xff\xfb\x65\x93\x4f\xfc\x22\x00\x00")
+var _call_tracerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x5a\x51\x73\xdb\x36\x12\x7e\xb6\x7f\x05\xe2\x87\x5a\x9a\x28\xb2\x93\xf4\x7a\x33\x76\xdd\x1b\xd5\x51\x52\xcf\xb8\x71\xc6\x76\x9a\xc9\x64\xf2\x00\x91\xa0\xc4\x9a\x22\x58\x02\xb4\xac\x6b\xfd\xdf\xef\xdb\x05\x40\x91\x94\xe4\xb8\xb9\xce\x4d\x2f\x2f\x31\x81\xdd\xc5\x62\x77\xf1\xed\x2e\xa0\x83\x03\x71\xaa\x8b\x65\x99\x4e\x67\x56\xbc\x38\x7c\xfe\x4f\x71\x3d\x53\x62\xaa\x9f\x29\x3b\x53\xa5\xaa\xe6\x62\x54\xd9\x99\x2e\xcd\xee\xc1\x01\xa6\x52\x23\x92\x34\x53\x02\xff\x17\xb2\xb4\x42\x27\xc2\x76\xe8\xb3\x74\x52\xca\x72\x39\x04\x83\xe3\xd9\x38\x4d\x12\x92\x52\x29\x61\x74\x62\x17\xb2\x54\x47\x62\xa9\x2b\x11\xc9\x5c\x94\x2a\x4e\x8d\x2d\xd3\x49\x65\xb1\x90\x15\x32\x8f\x0f\x74\x29\xe6\x3a\x4e\x93\x25\x89\xc4\x58\x95\xc7\xaa\xe4\xa5\xad\x2a\xe7\x26\xe8\xf1\xe6\xed\x7b\x71\xae\x8c\xc1\xdc\x1b\x95\xab\x52\x66\xe2\x5d\x35\xc9\xd2\x48\x9c\xa7\x91\xca\x8d\x12\x12\x8a\xd3\x88\x99\xa9\x58\x4c\x58\x1c\x31\xbe\x26\x55\xae\xbc\x2a\xe2\xb5\x86\x7c\x69\x53\x9d\x0f\x84\x4a\x49\x73\x71\xab\x4a\x83\x6f\xf1\x32\x2c\xe5\x05\x0e\x84\x2e\x49\x48\x4f\x5a\xda\x40\x29\x74\x41\x7c\x7d\x68\xbd\x14\x99\xb4\x2b\xd6\x47\x18\x64\xb5\xef\x58\xa4\x39\x2f\x33\xd3\x05\xf6\x38\x83\x74\xec\x7a\x91\x66\x99\x98\x28\x51\x19\x95\x54\xd9\x80\xa4\x81\x58\x7c\x38\xbb\xfe\xe9\xe2\xfd\xb5\x18\xbd\xfd\x28\x3e\x8c\x2e\x2f\x47\x6f\xaf\x3f\x1e\x83\x18\x7e\xc3\xac\xba\x55\x4e\x54\x3a\x2f\xb2\x14\x92\xb1\xc5\x52\xe6\x76\x89\x9d\x90\x84\x9f\xc7\x97\xa7\x3f\x81\x65\xf4\xe3\xd9\xf9\xd9\xf5\x47\xec\x47\xbc\x3e\xbb\x7e\x3b\xbe\xba\x12\xaf\x2f\x2e\xc5\x48\xbc\x1b\x5d\x5e\x9f\x9d\xbe\x3f\x1f\x5d\x8a\x77\xef\x2f\xdf\x5d\x5c\x8d\x87\xe2\x4a\x91\x56\x8a\xf8\xbf\x6c\xf3\x84\xbd\x07\xbb\xc6\xca\xca\x34\x33\xc1\x12\x1f\xe1\x70\x03\x1d\xb3\x58\xcc\xe4\xad\x82\xe3\x23\x95\xde\x42\x43\x29\x22\xc4\xe4\xa3\x9d\x4a\xb2\x64\xa6\xf3\x29\xef\x79\x6b\x40\x8a\xb3\x44\xe4\xda\x0e\x84\x81\xf2\xdf\xcf\xac\x2d\x8e\x0e\x0e\x16\x8b\xc5\x70\x9a\x57\x43\x5d\x4e\x0f\x32\x27\xce\x1c\xfc\x30\xdc\x25\x99\x91\xcc\xb2\xeb\x52\x46\x58\x18\xce\x91\x02\x36\x87\xf9\x33\xbd\x80\x3d\x61\x41\x23\x23\x72\x35\xfd\x1d\x71\x30\xc2\x49\xea\x8e\xbe\xac\xa1\xa0\xc5\x7e\x0a\x5d\xd2\xdf\x59\x16\xe2\x2c\xcd\x11\x11\x39\x76\x40\xb2\x8d\x98\xcb\x58\x21\x0a\x21\xbb\x21\x70\xd0\xdc\x0c\x85\x91\x73\x37\x78\x61\xc8\x39\x87\xe5\x70\xf7\xf7\xdd\x1d\xaf\xa1\xb1\x32\xba\x21\x05\x49\x7e\x54\x95\xa5\xca\x2d\x99\xb2\x42\xd4\xc1\xa8\x44\x22\x1c\x8d\xb7\xe7\xf8\x97\x9f\xa1\x27\x08\x9c\xa4\x9d\x5a\xc8\x91\xf8\xf4\xfb\xfd\xe7\xc1\x2e\x8b\x8e\x95\x81\x35\x62\x78\x83\x76\x74\x63\xc4\x62\xc6\x16\x15\x0b\xb5\x0f\xb1\xbf\x56\xc6\x36\x68\x92\x52\xcf\xa1\xab\x40\xc0\x91\x29\x1a\xd6\xc1\x8e\x35\x0b\x94\xf4\x37\xdc\xc7\x1a\x61\xd9\x9a\xf9\x48\x24\x32\xc3\x49\x72\xeb\xea\x3c\x5b\x5e\xeb\xe2\x94\xd5\xbe\x49\x0b\xe3\xed\x16\x38\xcd\xc0\xdb\x35\x85\x89\x18\x00\x1a\x8b\xf1\x66\x49\x02\xe4\x37\x04\xb5\x57\x30\xca\x56\x05\x19\x2c\xcd\x6f\xf5\x0d\x1d\x88\x10\x35\xde\x93\x91\xce\x93\x74\x8a\x83\x96\x50\xd0\xd2\x44\x6d\x2e\xb2\x24\x5c\x0a\xf1\x2c\x05\x82\xab\x9c\x97\xee\x39\xa6\xbe\x80\x63\x76\x2c\x70\x72\xd8\xdc\xc8\x89\x97\xd9\x1e\x3c\x39\xc1\x8a\x95\x3a\xde\xdd\xb9\x0f\xaa\x59\xd5\xd2\x8c\x4e\x0e\xce\x2f\xd0\x41\x17\x91\x8e\x3d\x12\x90\x46\xb5\x0f\x15\x2b\x03\xbe\x86\x2e\x99\x9e\x0e\x44\x3c\x71\xca\x78\xc8\xb1\xba\x70\xe6\x81\x78\x67\xc0\xe0\xb6\x35\x23\x6a\x04\xe9\x9d\x05\x6b\x9a\x88\x5e\x77\x2f\x4e\xe8\x4e\x89\xed\x97\x39\x54\xdf\xb9\x77\x6b\x9c\xca\x02\x23\x8a\x03\x56\x95\x25\x32\x06\x00\x67\x0e\x28\x07\x06\x66\x4b\xd0\xdc\xca\xd2\x4d\xc0\x1a\x50\x70\x38\x55\x76\x4c\x9f\xbd\xfe\xb1\x5f\xca\xcd\x3e\x81\x59\x08\xde\x93\x34\x57\xb1\x5f\x8d\x95\x48\x64\x95\xd9\x7a\x6f\xc7\x1b\xb5\xf8\xa0\xd8\xfd\xd8\x29\xa9\x32\x21\xfc\x33\x4b\x58\x67\xee\x0d\x88\xe8\x49\xa4\xa1\x18\xc5\x82\x0b\x25\x8a\x52\x3d\x8b\x66\x8a\x0e\x47\x1e\x29\xaf\x25\x38\x22\xe7\x35\x5a\x6d\xa8\x8b\xa1\xd5\x6f\xab\xf9\x44\x41\x57\xf1\x8d\x38\xbc\x4b\x0e\xfb\xf0\x1e\xff\x11\x74\xf7\x3c\x5e\x5f\x92\x02\x7b\xbb\x8d\x32\xff\x15\x80\x3d\x9f\xba\xbd\x7a\x5d\x01\x47\x52\xe4\x6a\xc1\x06\x27\xd4\x20\xd7\x4c\x14\xc5\x75\x54\x2a\x98\x2d\x06\x12\xc4\x38\x7f\xda\x1d\xed\xfa\x20\xb7\x97\x14\xdf\x7c\x23\x7a\xb4\xd8\x89\xd8\x3f\xbd\x1c\x8f\xae\xc7\xfb\xe2\x8f\x3f\x84\x1b\xd9\x73\x23\x2f\xf6\xfa\x0d\xcd\xd2\xfc\x22\x49\xbc\x72\x2c\x70\x58\x28\x75\xd3\x7b\xde\x1f\xde\xca\xac\x52\x17\x89\x53\xd3\xd3\x8e\x81\x64\x27\x9e\xe7\x69\x97\xe7\x45\x8b\x87\x98\xb0\xb1\x11\xb0\x7a\x3e\xc9\xd4\x3a\xe2\xf9\xc8\x63\x74\x34\x96\x4e\x17\x45\x78\xa4\x91\x99\x14\xc5\x5e\x58\xd5\x9b\x9f\x35\xde\xb1\xcb\x02\xd5\x01\xfe\xe9\x62\xc0\x03\x14\xb5\x3c\x60\xf5\x4f\xea\x8e\x7d\x14\x4c\x48\x51\x35\x8a\xe3\x12\xe9\xa2\xd7\xef\x3b\xf2\x34\x2f\x2a\x7b\xd4\x22\x9f\x2b\xe4\xa3\xe5\xd0\x10\xe2\xf7\x78\x6b\x03\xb7\xd3\xc0\x33\x95\xe6\x2c\x27\x1e\x1f\xa9\x6f\x24\xe4\xd5\x53\xa7\xda\x40\xa0\x9f\xa2\x8f\x30\xc7\xb6\x20\xb6\xfd\xc3\xbb\xfd\x75\x6b\x1d\xf6\x57\x91\xf0\xfc\xbb\x3e\xb1\xdc\x1f\xd7\xf1\x5d\xe3\xf0\xb0\xa8\xcc\xac\xc7\xe1\xb4\x9a\x5d\x61\xad\x43\x8d\x8d\xe1\xcf\x21\xb5\x1e\x4e\x46\x65\x09\x81\x35\xf8\x22\x0e\xab\xa9\x64\x28\x67\x34\x91\x94\xda\x4c\x35\x61\x9b\x5b\xad\xd7\xa3\xcb\x07\xd7\xd5\xf8\xfc\xf5\xab\xf1\xd5\xf5\xe5\xfb\xd3\xeb\xfd\x46\x38\x65\x2a\xb1\xa4\x54\x7b\x0f\x99\xca\xa7\x76\xc6\xfa\xd7\x30\x52\xcf\x7e\x22\x9e\x67\xcf\x3f\xbb\x11\x46\xc2\xee\x91\xdf\x79\x98\x43\x7c\xfa\xcc\xb2\xef\x77\xbf\x40\xea\x8c\xf9\xd7\x44\x92\xd5\x4c\x1c\xc8\xad\x0e\x04\x0f\xfb\xf9\x2f\x0e\xaa\x78\x42\x14\x3f\xca\x4c\x02\xb2\x1e\xd0\x79\x3d\xd6\x9a\xa0\xb9\x01\x87\xe6\x48\xf0\x3a\xe6\xe4\x13\x49\x97\xbc\x43\x04\xc5\x3a\x57\x7f\x1e\x8d\x46\xe7\xe7\x0d\x2c\xe2\xef\xd3\x8b\x57\x4d\x7c\xda\x7f\x35\x3e\x1f\xbf\x01\x42\x75\x69\xaf\xae\x47\x28\x3a\x79\x34\x40\x17\x54\xbd\x42\x41\xc0\x19\x86\x71\x1b\xb0\xc1\xbd\x48\xad\x2f\xd0\x1d\x3b\xa0\x2a\xbf\xf4\x15\x4a\x02\x1b\x85\xe4\x69\x42\xc0\x62\x0b\x08\xd7\x6d\xce\x7b\xde\x71\x5e\x1d\xc2\xa9\x79\x87\xb2\xca\x2d\x1a\xc3\xf9\x41\xaf\x95\x41\x5d\x34\x32\xf8\x33\xc0\xf6\x1e\xbf\x49\xf1\x2f\x71\x28\x8e\xc4\x73\x8f\xa2\x0f\xc0\xf4\x0b\x84\x00\xc4\x7f\x05\x58\xbf\xdc\xc0\xf9\xf7\x84\xec\xb5\x83\xf6\xbf\x87\x72\x94\x0e\x90\x75\x24\xba\x46\xfc\x76\xcd\x88\x35\xfd\xb9\xca\xd7\xe9\xff\xb1\x46\xbf\x82\x7d\x8a\x2a\x84\xc2\x93\xb5\x10\x71\xa0\xfb\xa4\x73\x0e\xbc\x71\xb9\x7e\x66\x69\xb0\xf7\xe6\x44\xf3\xa2\x1d\xc3\xdb\x90\xf2\xbf\x4a\x34\x1b\xfb\x00\xaa\xf6\xdb\x95\x3e\x95\xeb\x50\x04\x55\x2c\x3a\xd8\x7d\xc3\x22\xa9\x23\xd2\x0b\x82\xaf\x21\x2a\x36\x27\x31\x57\x8a\xc1\xc5\x77\x50\x54\x9f\x71\x75\x4a\xc5\xb9\xef\x85\x39\xc4\x24\x37\x04\x08\xc3\xb9\x5c\x52\x2f\x8c\xa2\xf7\x66\x89\x84\x86\xee\x79\x99\xcb\x79\x1a\x19\x27\x8f\x8b\xfa\x52\x4d\x65\xc9\x62\x4b\xf5\x5b\x85\x04\x48\xcd\x25\x02\x19\x0b\x54\x10\x06\xbe\x94\xba\x63\xe2\xee\xbd\x78\x79\x78\x88\x08\x4f\x0b\xec\x64\x20\xbe\x7b\x79\xf0\xdd\xb7\xa2\xac\x32\xd5\x1f\x36\x2b\xe1\x7a\xab\xde\x1b\x34\xe1\xa3\xe7\x95\x2a\xec\x0c\x15\xe2\x0f\x5b\x72\xe1\x96\xc4\xb6\x91\x56\x3c\x13\x48\x60\xa4\xd7\x49\x2b\x6e\x9d\x27\x85\x42\x37\xe3\xa5\x51\x79\x7f\xf1\xea\xa2\x77\x23\xd1\x18\xcb\x89\xea\x1f\x71\xb9\xcf\xb6\x5a\x48\xdf\x62\x92\x53\x44\x91\x49\x18\x52\x46\x91\xae\x72\x4b\x86\x0f\xdd\x22\xec\x00\x7c\xdf\xb7\x41\x1e\x37\xe3\xa0\xc3\x89\x0c\x70\xcf\x5e\x23\x75\xe4\x9c\xb8\xe1\x5f\x93\xc6\xaa\xe1\x15\x42\x07\xcd\xd0\xec\x29\xe8\xae\x22\x08\x9c\xe3\x5c\x65\xec\xad\x45\x49\x9d\xad\x49\xe1\x7a\xba\xd0\x88\x15\x59\xdb\xa0\xf8\x86\x7e\x99\xe6\xfb\x24\x3e\xe3\x40\xf0\xa9\x19\x3a\xbc\xa7\x65\x09\x73\x72\xbd\x18\xb6\x03\xb9\x19\xaa\xdc\xe1\x75\x4a\xa1\x1c\xd1\x94\x1a\xee\x14\x57\xad\x0f\x45\x32\x46\x06\xa2\xc0\x11\x23\x9c\xfe\x52\x3a\xf3\x60\x7d\x39\xfe\x65\x7c\x59\x17\x3e\x8f\x77\x62\xe8\x79\xf6\x56\x4d\x64\x49\x3d\x1d\x62\x71\x6f\x43\x13\xb3\x21\xa0\x4e\xb6\x04\x14\xc9\x5f\xe5\xc6\x77\x8d\xed\x64\xe8\x71\x56\x8e\x81\xa8\x4e\x17\x0b\xb0\x45\x2f\x65\x3a\xd8\xdd\x05\x07\x5d\x84\x0c\x41\x4a\x31\xec\x10\xb0\x77\x3b\x8d\xd6\xc4\xaa\xe1\x58\xc5\xe7\x59\xc3\xc6\x0b\x2e\x37\x1d\x51\x03\x1a\x78\x3e\xd4\xad\xd2\x65\x03\xd6\x1d\xb0\x4a\xe1\x40\xf9\x7b\x05\x7e\x88\x88\xf7\x86\xbd\xee\xe1\x6f\x92\x4e\xcf\x72\xdb\x0b\x93\x67\x39\x4c\x13\x3e\x08\xd4\xf1\xd9\x3c\x45\x1b\xd0\x71\x27\x56\xc8\x67\x4a\xac\x44\x1c\x8b\xce\x10\x09\x72\xe6\x60\xa3\x41\xf7\xf5\xe4\x7c\xe8\xa5\x91\xc1\x9e\x80\x62\x08\xd8\x41\x60\x62\x3c\xd8\xc3\xed\x00\xc7\x8a\xfe\x9d\xac\x55\x92\xc4\xd3\xae\x1d\x8f\x1b\x6c\xde\x1a\x81\xcd\x55\x82\xa7\xb0\xcd\x83\x12\xbc\x08\x0f\x1b\xb5\x2f\x7d\x60\x6e\xaa\xbd\x77\x9a\x04\x62\xaf\x2e\x08\x12\x99\x66\x68\xf2\xf7\x8e\xc5\x06\xd8\x31\x55\x99\xc8\x88\x7d\x49\x97\x7e\xd4\xad\x1b\x80\xc2\x5c\xcd\xf4\xc2\x29\xb0\x09\xbc\xd6\x83\xa3\x8e\x83\x4e\xfa\xe0\x7b\x3d\x50\x54\x46\x4e\x55\x23\x38\x6a\x83\x07\x47\x6d\xbc\x42\xf8\xea\xd0\x79\x5a\x7f\x3e\x22\x8a\xee\xff\x9a\xf0\xe8\xf8\x79\xad\xce\x09\x44\x5c\xed\x34\x3e\x82\xb2\xae\x18\xf9\x7b\x39\xfe\xd1\x27\xac\x4b\xeb\xb6\xd6\x26\x76\x1b\x5c\xd5\x35\x5f\x76\x7f\x3d\xbb\xcd\xf3\xdb\x4a\x26\x8a\xd1\xfc\x57\x15\xd9\x55\x9c\x72\x95\x43\x5f\x68\x43\x6e\x53\x5d\x51\x02\x53\xff\x4f\xed\x70\x5d\xf2\xdd\xaf\xee\x1e\xd9\x6f\xad\x6b\xd1\x99\x7f\x38\x70\xd5\x52\x23\x7d\x68\xce\xad\xfe\x4a\x32\x71\x57\xfa\x3b\xcc\xbf\xed\x12\xf2\xcf\xdc\x24\x7a\x44\xb0\xba\xa0\xba\xc1\xa7\xb1\xac\x54\x32\x5e\xd6\x99\x73\xe0\x2a\x16\x94\x2a\x79\xec\xbb\x16\x64\x8d\x94\x16\xe6\x68\xa5\xad\xc8\x29\xea\x9d\xdd\x8d\xf6\xfe\x62\xba\xde\x14\x42\x6b\x8a\x36\x33\xae\xef\x36\xa9\x35\x64\x8d\x77\x1f\x91\x59\x3b\xa7\xad\x7b\x29\xea\xef\x55\xd1\xd6\x56\x73\x2e\x99\x85\xbc\xc5\x02\x92\xda\x34\x2e\xc5\x80\x80\x51\xa6\xe0\x09\x7e\x6b\x82\x97\x35\x3d\x35\xed\x3e\xe2\x34\x7c\xcd\x61\xe8\xc0\x67\xf8\xf4\xe6\x78\xfc\xe1\x7e\xec\xd1\x76\xdb\x7f\x9d\x49\x6b\x7d\x1c\x36\xcc\xeb\x8e\x60\x6a\xf9\x19\x12\x25\xec\xee\xe3\xce\x1e\x17\x57\x44\xf3\x83\x38\x6c\x14\xf0\x7f\x97\xd3\xb8\x1e\x62\xe7\x75\x21\xe7\x37\x6f\xb5\x1e\x60\x9b\x92\xdb\xa9\xf0\x48\x18\x0a\xd7\x87\xba\xbb\x70\xcc\x5d\xe9\xb7\x76\xce\xf9\xf2\x0f\xa2\xfc\x55\x89\xeb\x01\x26\x0a\x33\x29\x52\x00\x5d\x46\x0b\x8a\x2e\xff\xae\x45\x5a\x1a\x16\xc7\x7e\x49\xe9\xd0\x79\xc1\xfe\x91\x89\x32\x38\xa2\x07\xb8\xe0\xc6\x9b\x2f\x25\xf6\x6e\x05\x0c\x2e\x55\x32\xa7\xbf\x3c\xa8\xef\x0e\x40\xc7\x65\x25\xf7\xd7\x9d\x0b\x04\x9a\xa3\x21\xd7\x7c\x77\xae\x0b\x98\xd1\x5f\x19\x74\x2f\xcf\x68\x8e\xc7\x5a\x01\xce\xa4\x88\x51\x27\xa6\x73\x24\xc0\xb1\x76\x22\x02\x03\x1d\x86\xa3\xcd\x0c\x34\xb5\x81\xa9\x73\x85\x41\xc4\x3c\xe4\x66\x5d\xe2\x3f\x6a\xce\xba\x21\xbf\xd1\x74\xde\xb0\x0d\x3e\x68\x94\xef\x12\xfc\x2b\x0f\x8e\x45\x49\xbd\x59\x54\x23\x04\x39\xa3\x54\x30\x7e\xcc\xb7\x62\x37\x39\x3d\x5f\xd6\xd0\xbe\x98\xe9\xac\xf5\xfa\xe3\x44\xd1\x6b\x22\xbd\x85\xc5\x03\xd7\x38\x24\x09\xf2\x1f\xbd\x26\xba\xfa\x0b\xc1\x30\x97\x36\x42\xba\x77\x31\x93\xab\xe0\x76\x7e\xc2\x2d\xc2\xdb\x91\xdb\x9c\xd7\xe8\xcd\x16\x2c\x72\xde\x6f\xd3\xb9\xda\x78\xdd\xac\x4d\xa2\x0d\x10\xe5\x45\x41\xc9\x4b\xde\x31\xf4\xdc\x2a\xaa\x41\xb4\x5d\x52\xbd\xf1\x37\xdb\xca\x46\x48\xea\x12\xad\x8b\xbb\xdf\x9c\x85\x0e\x03\x60\x3c\x60\x96\x80\x28\x5b\x58\x1f\x96\xfe\x50\x2e\x73\xfb\xf3\xa9\x67\x0b\x2b\x4b\x6f\x14\x8f\xb4\xd9\xc7\x8a\xac\x89\x9b\x2a\xb6\x68\x5a\x42\xf8\xde\x78\x6d\x7a\x53\xcf\x4c\x2d\xa7\x27\x0c\x65\xf2\xc9\xc9\xde\xe1\x5d\xfd\xc4\xe5\x93\x49\x8b\x26\x28\xe1\xa0\xcb\xed\x97\x61\x2b\xfd\xb7\xf2\xcb\x36\x41\x32\x4c\x51\x3c\xf3\x53\x1c\xf7\x25\xfc\xb8\x3c\xe1\x52\xb0\x32\xe1\xf9\xd9\x81\x1f\x20\x33\x2d\xe9\x41\x35\x55\x19\x90\x92\x7e\x9c\x42\x57\x16\xbf\x1a\x3a\x51\xfc\xe6\x5c\xa6\x24\xd1\xbd\xde\xbb\x1f\xd2\xf0\x6f\x0a\x72\x94\xf3\x76\x29\x12\x2c\x42\xaf\xa7\x48\x6a\x85\x44\xeb\x3b\x47\x5a\xc7\x0a\xf4\x8b\x83\xa5\xd0\x25\xe4\xa9\x78\xd5\xb5\x13\xee\x6a\x3a\x87\x25\x3d\xcb\x6b\x5f\x0b\x71\xb1\x5e\x50\xdf\x91\xda\x81\xbf\x98\x4b\x4d\x91\xc9\x25\x06\xa8\x40\xf3\x9b\x6a\x42\x71\x5d\x86\xf1\xbb\xa7\x7b\x14\x5e\xc3\xe1\xd0\xdf\xb7\x81\x98\x87\xe9\xab\x0d\xc1\xbe\xbd\x6d\x83\xef\xea\xca\xb2\x8d\xb4\xa1\x36\x68\xc3\x69\xb3\xd2\xf0\x98\xb9\x3a\xf6\x7e\xba\x39\x14\xb8\xc3\x79\x5e\x49\x08\x23\x4c\xd1\x3d\xa7\x9e\xac\x3b\xdc\x46\x69\xbf\x18\xbe\xda\xf8\xdc\x68\xd3\x9c\x70\x8a\xd9\x9a\x81\xbf\x3a\x88\xcd\x76\xf1\x90\xed\x7e\x73\x51\x93\xbb\xdf\x35\xf8\x10\xa5\xb8\xe9\x91\x3b\x6e\xd4\x92\x12\xbc\xf3\x4a\xa3\x5a\x71\x03\x9f\x30\xfd\x79\x73\x71\xe2\x0f\x40\x83\xae\xae\x46\xc2\x41\x74\x73\x0f\xc0\x4f\xad\x45\x7a\x72\x78\x2c\xd2\xef\x9b\x0c\xa1\xa0\x12\xe9\xd3\xa7\x61\xcd\xe6\xfc\xa7\xf4\x73\xc0\x94\xfa\x8c\x75\xe6\xfb\x2d\x8d\xfc\xa9\x74\x34\x74\x0c\x77\xef\x77\xff\x03\x8e\x33\xba\xae\x99\x26\x00\x00")
 
 func call_tracerJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -24,8 +24,20 @@
 	// an inner call.
 	descended: false,
 
+	// onlyTopCall skips the inner calls, reporting the transaction call only.
+	onlyTopCall: false,
+
+	// setup is invoked with the tracer config before the execution starts.
+	setup: function(config) {
+		this.onlyTopCall = config.onlyTopCall === true;
+	},
+
 	// step is invoked for every opcode that the VM executes.
 	step: function(log, db) {
+		// The top call is reported from the transaction context
+		if (this.onlyTopCall) {
+			return;
+		}
 		// Capture any errors immediately
 		var error = log.getError();
 		if (error !== undefined) {
@@ -163,6 +175,9 @@
 
 	// fault is invoked when the actual execution of an opcode fails.
 	fault: function(log, db) {
+		if (this.onlyTopCall) {
+			return;
+		}
 		// If the topmost call already reverted, don't handle the additional fault again
 		if (this.callstack[this.callstack.length - 1].error !== undefined) {
 			return;
@@ -206,6 +221,13 @@
 			output:  toHex(ctx.output),
 			time:    ctx.time,
 		};
+		// The intrinsic gas and the refund are known when the whole transaction
+		// is traced, the effective gas used matches the one of the receipt
+		if (ctx.intrinsicGas !== undefined) {
+			result.intrinsicGas     = '0x' + bigInt(ctx.intrinsicGas).toString(16);
+			result.gasRefunded      = '0x' + bigInt(ctx.gasRefunded).toString(16);
+			result.effectiveGasUsed = '0x' + bigInt(ctx.effectiveGasUsed).toString(16);
+		}
 		if (this.callstack[0].calls !== undefined) {
 			result.calls = this.callstack[0].calls;
 		}
@@ -231,6 +253,9 @@
 			value:   call.value,
 			gas:     call.gas,
 			gasUsed: call.gasUsed,
+			intrinsicGas: call.intrinsicGas,
+			gasRefunded: call.gasRefunded,
+			effectiveGasUsed: call.effectiveGasUsed,
 			input:   call.input,
 			output:  call.output,
 			error:   call.error,
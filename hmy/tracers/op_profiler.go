@@ -0,0 +1,145 @@
+package tracers
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// OpStats is the aggregated execution profile of a single opcode.
+type OpStats struct {
+	Count uint64 `json:"count"`
+	Gas   uint64 `json:"gas"`
+}
+
+// OpProfileFrame is the execution profile of a single call frame. The gas of a
+// call or create opcode includes the gas consumed by the callee.
+type OpProfileFrame struct {
+	Type          string              `json:"type"`
+	Address       common.Address      `json:"address"`
+	GasUsed       uint64              `json:"gasUsed"`
+	MaxMemory     uint64              `json:"maxMemory"`
+	MaxStackDepth uint64              `json:"maxStackDepth"`
+	Ops           map[string]*OpStats `json:"ops"`
+	Calls         []*OpProfileFrame   `json:"calls,omitempty"`
+
+	startGas   uint64
+	pending    bool
+	pendingOp  vm.OpCode
+	pendingGas uint64
+	pendingFee uint64
+}
+
+func newOpProfileFrame(typ string, address common.Address) *OpProfileFrame {
+	return &OpProfileFrame{
+		Type:    typ,
+		Address: address,
+		Ops:     make(map[string]*OpStats),
+	}
+}
+
+// charge adds the gas of the last executed opcode to the frame profile.
+func (f *OpProfileFrame) charge(gas uint64) {
+	if !f.pending {
+		return
+	}
+	stats, ok := f.Ops[f.pendingOp.String()]
+	if !ok {
+		stats = &OpStats{}
+		f.Ops[f.pendingOp.String()] = stats
+	}
+	stats.Count++
+	stats.Gas += gas
+	f.pending = false
+}
+
+// close charges the last opcode of a finished frame with its static cost.
+func (f *OpProfileFrame) close() {
+	if !f.pending {
+		return
+	}
+	left := f.pendingGas - f.pendingFee
+	f.charge(f.pendingFee)
+	f.GasUsed = f.startGas - left
+}
+
+// OpProfiler is a tracer aggregating per-opcode execution counts, gas and the
+// maximum memory size and stack depth of every call frame. It is selected with
+// the "opProfiler" tracer name.
+type OpProfiler struct {
+	root   *OpProfileFrame
+	frames []*OpProfileFrame
+}
+
+// CaptureStart implements the Tracer interface to initialize the root frame.
+func (p *OpProfiler) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := vm.CALL.String()
+	if create {
+		typ = vm.CREATE.String()
+	}
+	p.root = newOpProfileFrame(typ, to)
+	p.root.startGas = gas
+	p.frames = []*OpProfileFrame{p.root}
+	return nil
+}
+
+// CaptureState implements the Tracer interface to profile a single opcode.
+func (p *OpProfiler) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if err != nil || p.root == nil {
+		return nil, nil
+	}
+	switch {
+	case depth > len(p.frames):
+		// entered a new frame through the pending call of the parent
+		parent := p.frames[len(p.frames)-1]
+		frame := newOpProfileFrame(parent.pendingOp.String(), contract.Address())
+		frame.startGas = gas
+		parent.Calls = append(parent.Calls, frame)
+		p.frames = append(p.frames, frame)
+	case depth < len(p.frames):
+		// returned from one or more frames, the caller is charged what the callee consumed
+		for len(p.frames) > depth {
+			p.frames[len(p.frames)-1].close()
+			p.frames = p.frames[:len(p.frames)-1]
+		}
+		fallthrough
+	default:
+		frame := p.frames[len(p.frames)-1]
+		frame.charge(frame.pendingGas - gas)
+	}
+
+	frame := p.frames[len(p.frames)-1]
+	frame.pending, frame.pendingOp, frame.pendingGas, frame.pendingFee = true, op, gas, cost
+	if size := uint64(memory.Len()); size > frame.MaxMemory {
+		frame.MaxMemory = size
+	}
+	if size := uint64(len(stack.Data())); size > frame.MaxStackDepth {
+		frame.MaxStackDepth = size
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the Tracer interface, faults end the frame through
+// the depth tracking of the following opcodes.
+func (p *OpProfiler) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the Tracer interface to close the remaining frames.
+func (p *OpProfiler) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error {
+	for i := len(p.frames) - 1; i >= 0; i-- {
+		p.frames[i].close()
+	}
+	p.frames = nil
+	if p.root != nil {
+		p.root.GasUsed = gasUsed
+	}
+	return nil
+}
+
+// GetResult returns the profile of the root call frame.
+func (p *OpProfiler) GetResult() (*OpProfileFrame, error) {
+	return p.root, nil
+}
@@ -0,0 +1,81 @@
+package tracers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func TestOpProfiler(t *testing.T) {
+	var (
+		from   = common.HexToAddress("0x1000")
+		caller = common.HexToAddress("0x2000")
+		callee = common.HexToAddress("0x3000")
+	)
+	// the caller calls the callee with all its gas, which stores a word in memory
+	callerCode := []byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.PUSH20),
+	}
+	callerCode = append(callerCode, callee.Bytes()...)
+	callerCode = append(callerCode, byte(vm.GAS), byte(vm.CALL), byte(vm.POP), byte(vm.STOP))
+	calleeCode := []byte{byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.STOP)}
+
+	statedb := makePreState(t, map[common.Address]account{
+		from:   {Balance: math.NewHexOrDecimal256(1e18)},
+		caller: {Code: callerCode},
+		callee: {Code: calleeCode},
+	})
+	msg := types.NewMessage(from, &caller, 0, new(big.Int), 100000, big.NewInt(1), nil, false)
+	profiler := &OpProfiler{}
+	runTracer(t, statedb, fixtureConfig(), &callContext{GasLimit: 1000000}, msg, profiler)
+
+	root, err := profiler.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Type != "CALL" || root.Address != caller {
+		t.Errorf("root frame: have %s %x, want CALL %x", root.Type, root.Address, caller)
+	}
+	for op, count := range map[string]uint64{"PUSH1": 5, "PUSH20": 1, "GAS": 1, "CALL": 1, "POP": 1, "STOP": 1} {
+		if stats := root.Ops[op]; stats == nil || stats.Count != count {
+			t.Errorf("root frame: have %s %+v, want count %d", op, stats, count)
+		}
+	}
+	if root.MaxStackDepth != 7 {
+		t.Errorf("root frame: have max stack depth %d, want 7", root.MaxStackDepth)
+	}
+	// the gas of the opcodes adds up to the gas of the frame, the call included
+	var gas uint64
+	for _, stats := range root.Ops {
+		gas += stats.Gas
+	}
+	if gas != root.GasUsed {
+		t.Errorf("root frame: opcodes use %d gas, want %d", gas, root.GasUsed)
+	}
+
+	if len(root.Calls) != 1 {
+		t.Fatalf("root frame: have %d calls, want 1", len(root.Calls))
+	}
+	call := root.Calls[0]
+	if call.Type != "CALL" || call.Address != callee {
+		t.Errorf("call frame: have %s %x, want CALL %x", call.Type, call.Address, callee)
+	}
+	// two pushes and a store expanding the memory to a word
+	if call.GasUsed != 3+3+6 {
+		t.Errorf("call frame: have gas used %d, want 12", call.GasUsed)
+	}
+	if stats := call.Ops["MSTORE"]; stats == nil || stats.Count != 1 || stats.Gas != 6 {
+		t.Errorf("call frame: have MSTORE %+v, want count 1 and gas 6", stats)
+	}
+	if call.MaxMemory != 32 {
+		t.Errorf("call frame: have max memory %d, want 32", call.MaxMemory)
+	}
+	if root.Ops["CALL"].Gas < call.GasUsed {
+		t.Errorf("CALL uses %d gas, less than the %d gas of the callee", root.Ops["CALL"].Gas, call.GasUsed)
+	}
+}
@@ -91,35 +91,31 @@ type memoryWrapper struct {
 	memory *vm.Memory
 }
 
-// slice returns the requested range of memory as a byte slice.
+// slice returns the requested range of memory as a byte slice, the bytes past
+// the memory not expanded yet reading as zero.
 func (mw *memoryWrapper) slice(begin, end int64) []byte {
 	if end == begin {
 		return []byte{}
 	}
-	if end < begin || begin < 0 {
+	blob, ok := memorySlice(mw.memory, begin, end-begin)
+	if !ok {
 		// TODO(karalabe): We can't js-throw from Go inside duktape inside Go. The Go
 		// runtime goes belly up https://github.com/golang/go/issues/15639.
-		log.Warn("Tracer accessed out of bound memory", "offset", begin, "end", end)
-		return nil
-	}
-	if mw.memory.Len() < int(end) {
-		// TODO(karalabe): We can't js-throw from Go inside duktape inside Go. The Go
-		// runtime goes belly up https://github.com/golang/go/issues/15639.
-		log.Warn("Tracer accessed out of bound memory", "available", mw.memory.Len(), "offset", begin, "size", end-begin)
-		return nil
+		log.Warn("Tracer accessed out of bound memory", "available", mw.memory.Len(), "offset", begin, "end", end)
 	}
-	return mw.memory.GetCopy(begin, end-begin)
+	return blob
 }
 
-// getUint returns the 32 bytes at the specified address interpreted as a uint.
+// getUint returns the 32 bytes at the specified address interpreted as a uint,
+// the bytes past the memory not expanded yet reading as zero.
 func (mw *memoryWrapper) getUint(addr int64) *big.Int {
-	if mw.memory.Len() < int(addr)+32 || addr < 0 {
+	blob, ok := memorySlice(mw.memory, addr, 32)
+	if !ok {
 		// TODO(karalabe): We can't js-throw from Go inside duktape inside Go. The Go
 		// runtime goes belly up https://github.com/golang/go/issues/15639.
 		log.Warn("Tracer accessed out of bound memory", "available", mw.memory.Len(), "offset", addr, "size", 32)
-		return new(big.Int)
 	}
-	return new(big.Int).SetBytes(mw.memory.GetPtr(addr, 32))
+	return new(big.Int).SetBytes(blob)
 }
 
 // pushObject assembles a JSVM object wrapping a swappable memory and pushes it
@@ -156,13 +152,13 @@ type stackWrapper struct {
 
 // peek returns the nth-from-the-top element of the stack.
 func (sw *stackWrapper) peek(idx int) *big.Int {
-	if len(sw.stack.Data()) <= idx || idx < 0 {
+	v, ok := stackBack(sw.stack, idx)
+	if !ok {
 		// TODO(karalabe): We can't js-throw from Go inside duktape inside Go. The Go
 		// runtime goes belly up https://github.com/golang/go/issues/15639.
 		log.Warn("Tracer accessed out of bound stack", "size", len(sw.stack.Data()), "index", idx)
-		return new(big.Int)
 	}
-	return sw.stack.Data()[len(sw.stack.Data())-idx-1]
+	return v
 }
 
 // pushObject assembles a JSVM object wrapping a swappable stack and pushes it
@@ -315,8 +311,9 @@ type Tracer struct {
 
 // New instantiates a new tracer instance. code specifies a Javascript snippet,
 // which must evaluate to an expression returning an object with 'step', 'fault'
-// and 'result' functions.
-func New(code string) (*Tracer, error) {
+// and 'result' functions. If the object has a 'setup' function, it is called
+// with the decoded cfg, or an empty object if cfg is empty, before tracing.
+func New(code string, cfg json.RawMessage) (*Tracer, error) {
 	// Resolve any tracers by name and assemble the tracer object
 	if tracer, ok := tracer(code); ok {
 		code = tracer
@@ -446,6 +443,27 @@ func New(code string) (*Tracer, error) {
 	tracer.vm.EvalString(bigIntegerJS)
 	tracer.vm.PutGlobalString("bigInt")
 
+	// Pass the tracer config to the setup function, if any
+	hasSetup := tracer.vm.GetPropString(tracer.tracerObject, "setup")
+	tracer.vm.Pop()
+	if hasSetup {
+		if len(cfg) == 0 {
+			cfg = json.RawMessage("{}")
+		} else if !json.Valid(cfg) {
+			return nil, errors.New("invalid tracer config")
+		}
+		tracer.vm.PushString("setup")
+		tracer.vm.PushString(string(cfg))
+		tracer.vm.JsonDecode(-1)
+		code := tracer.vm.PcallProp(tracer.tracerObject, 1)
+		if code != 0 {
+			err := tracer.vm.SafeToString(-1)
+			tracer.vm.Pop()
+			return nil, wrapError("setup", errors.New(err))
+		}
+		tracer.vm.Pop()
+	}
+
 	// Push the global environment state as object #1 into the JSVM stack
 	tracer.stateObject = tracer.vm.PushObject()
 
@@ -608,6 +626,42 @@ func (jst *Tracer) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, er
 	return nil
 }
 
+// CaptureRefund is called after the transaction refund is applied, so that
+// the reported gasUsed reflects the gas actually paid for. The intrinsic gas,
+// the refund and the gas used by the transaction as in its receipt are added
+// to the context.
+func (jst *Tracer) CaptureRefund(env *vm.EVM, intrinsicGas, refund uint64) error {
+	gasUsed, ok := jst.ctx["gasUsed"].(uint64)
+	if !ok {
+		return nil
+	}
+	gasUsed, effectiveGasUsed := applyRefund(intrinsicGas, gasUsed, refund)
+	jst.ctx["gasUsed"] = gasUsed
+	jst.ctx["intrinsicGas"] = intrinsicGas
+	jst.ctx["gasRefunded"] = refund
+	jst.ctx["effectiveGasUsed"] = effectiveGasUsed
+	return nil
+}
+
+// applyRefund returns the gas used by the execution and by the transaction,
+// both net of the refund. The refund is capped by a share of the gas used
+// including the intrinsic gas, so it may exceed the gas used by the execution,
+// which is then reported as zero.
+func applyRefund(intrinsicGas, gasUsed, refund uint64) (uint64, uint64) {
+	effectiveGasUsed := intrinsicGas + gasUsed
+	if effectiveGasUsed > refund {
+		effectiveGasUsed -= refund
+	} else {
+		effectiveGasUsed = 0
+	}
+	if gasUsed > refund {
+		gasUsed -= refund
+	} else {
+		gasUsed = 0
+	}
+	return gasUsed, effectiveGasUsed
+}
+
 // GetResult calls the Javascript 'result' function and returns its value, or any accumulated error
 func (jst *Tracer) GetResult() (json.RawMessage, error) {
 	// Transform the context into a JavaScript object and inject into the state
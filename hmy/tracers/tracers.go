@@ -51,3 +51,9 @@ func tracer(name string) (string, bool) {
 	}
 	return "", false
 }
+
+// IsBuiltin returns whether the name is the one of a built-in JavaScript tracer.
+func IsBuiltin(name string) bool {
+	_, ok := all[name]
+	return ok
+}
@@ -0,0 +1,665 @@
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+// account is an account of the prestate of a test case
+type account struct {
+	Balance *math.HexOrDecimal256       `json:"balance"`
+	Nonce   math.HexOrDecimal64         `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// callContext is the block context of a test case
+type callContext struct {
+	Number   math.HexOrDecimal64 `json:"number"`
+	Time     math.HexOrDecimal64 `json:"timestamp"`
+	GasLimit math.HexOrDecimal64 `json:"gasLimit"`
+	Miner    common.Address      `json:"miner"`
+}
+
+// callTrace is the result of a callTracer run.
+type callTrace struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      common.Address  `json:"to"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  hexutil.Bytes   `json:"output"`
+	Gas     *hexutil.Uint64 `json:"gas,omitempty"`
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []callTrace     `json:"calls,omitempty"`
+}
+
+// callTracerTest is a transaction captured on an Ethereum network, together
+// with the prestate it ran on and its trace by the Geth callTracer.
+type callTracerTest struct {
+	Genesis struct {
+		Alloc map[common.Address]account `json:"alloc"`
+	} `json:"genesis"`
+	Context *callContext `json:"context"`
+	Input   string       `json:"input"`
+	Result  *callTrace   `json:"result"`
+}
+
+// fixtureConfig returns the chain config running the test cases with the rules
+// of the S3 epoch, which match the ones of Byzantium. The test cases of older
+// blocks use no Byzantium opcode but depend on EIP-150, which the rules before
+// S3 lack. The Istanbul and later rules, which change gas costs, are left out.
+func fixtureConfig() *params.ChainConfig {
+	config := *params.TestChainConfig
+	for _, epoch := range []**big.Int{
+		&config.IstanbulEpoch, &config.BerlinEpoch, &config.LondonEpoch,
+		&config.RefundReductionEpoch, &config.TransientStorageEpoch, &config.Push0Epoch,
+		&config.SHA3Epoch, &config.VRFLookupGasEpoch,
+	} {
+		*epoch = params.EpochTBD
+	}
+	return &config
+}
+
+// makePreState creates a state holding the accounts
+func makePreState(t *testing.T, alloc map[common.Address]account) *state.DB {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatalf("failed to create state: %v", err)
+	}
+	for addr, acc := range alloc {
+		statedb.SetCode(addr, acc.Code)
+		statedb.SetNonce(addr, uint64(acc.Nonce))
+		if acc.Balance != nil {
+			statedb.SetBalance(addr, (*big.Int)(acc.Balance))
+		}
+		for key, value := range acc.Storage {
+			statedb.SetState(addr, key, value)
+		}
+	}
+	statedb.Finalise(false)
+	return statedb
+}
+
+// runTracer applies the message on the state with the tracer attached
+func runTracer(t *testing.T, statedb *state.DB, config *params.ChainConfig, ctx *callContext, msg types.Message, tracer vm.Tracer) core.ExecutionResult {
+	context := vm.Context{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		IsValidator: core.IsValidator,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		GetVRF:      func(uint64) common.Hash { return common.Hash{} },
+		Origin:      msg.From(),
+		Coinbase:    ctx.Miner,
+		BlockNumber: new(big.Int).SetUint64(uint64(ctx.Number)),
+		EpochNumber: big.NewInt(0),
+		Time:        new(big.Int).SetUint64(uint64(ctx.Time)),
+		GasLimit:    uint64(ctx.GasLimit),
+		GasPrice:    msg.GasPrice(),
+	}
+	evm := vm.NewEVM(context, statedb, config, vm.Config{Debug: true, Tracer: tracer})
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas()))
+	if err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	return result
+}
+
+// gethTracer hides the refund from the tracer, as Geth reported the gas used by
+// the transaction before the refund when the test cases were captured
+type gethTracer struct {
+	vm.Tracer
+}
+
+// loadFixtures reads the test cases of the testdata directory
+func loadFixtures(t *testing.T) map[string]*callTracerTest {
+	files, err := ioutil.ReadDir("testdata")
+	if err != nil {
+		t.Fatalf("failed to retrieve tracer test suite: %v", err)
+	}
+	tests := make(map[string]*callTracerTest)
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), "call_tracer_") {
+			continue
+		}
+		blob, err := ioutil.ReadFile(filepath.Join("testdata", file.Name()))
+		if err != nil {
+			t.Fatalf("failed to read testcase: %v", err)
+		}
+		test := new(callTracerTest)
+		if err := json.Unmarshal(blob, test); err != nil {
+			t.Fatalf("failed to parse testcase %s: %v", file.Name(), err)
+		}
+		tests[camel(strings.TrimSuffix(strings.TrimPrefix(file.Name(), "call_tracer_"), ".json"))] = test
+	}
+	return tests
+}
+
+// fixtureMessage decodes the transaction of the test case into a message
+func fixtureMessage(t *testing.T, test *callTracerTest) types.Message {
+	tx := new(ethtypes.Transaction)
+	if err := rlp.DecodeBytes(common.FromHex(test.Input), tx); err != nil {
+		t.Fatalf("failed to parse testcase input: %v", err)
+	}
+	var signer ethtypes.Signer = ethtypes.HomesteadSigner{}
+	if tx.Protected() {
+		signer = ethtypes.NewEIP155Signer(tx.ChainId())
+	}
+	from, err := ethtypes.Sender(signer, tx)
+	if err != nil {
+		t.Fatalf("failed to recover sender: %v", err)
+	}
+	return types.NewMessage(from, tx.To(), tx.Nonce(), tx.Value(), tx.Gas(), tx.GasPrice(), tx.Data(), true)
+}
+
+// TestCallTracer checks the callTracer against the traces of Geth.
+func TestCallTracer(t *testing.T) {
+	for name, test := range loadFixtures(t) {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer, err := New(all["callTracer"], nil)
+			if err != nil {
+				t.Fatalf("failed to create call tracer: %v", err)
+			}
+			runTracer(t, makePreState(t, test.Genesis.Alloc), fixtureConfig(), test.Context, fixtureMessage(t, test), gethTracer{tracer})
+
+			res, err := tracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve trace result: %v", err)
+			}
+			ret := new(callTrace)
+			if err := json.Unmarshal(res, ret); err != nil {
+				t.Fatalf("failed to unmarshal trace result: %v", err)
+			}
+			if diff := diffCallTraces(ret, test.Result, "result"); diff != "" {
+				t.Fatalf("trace mismatch: %s", diff)
+			}
+		})
+	}
+}
+
+// TestParityBlockTracer checks the ParityBlockTracer against the traces of
+// Geth, flattened to the form of OpenEthereum. The gas is not compared, as
+// the ParityBlockTracer reports the gas of the failed calls differently.
+func TestParityBlockTracer(t *testing.T) {
+	for name, test := range loadFixtures(t) {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer := &ParityBlockTracer{}
+			runTracer(t, makePreState(t, test.Genesis.Alloc), fixtureConfig(), test.Context, fixtureMessage(t, test), tracer)
+
+			have, err := flattenParityTraces(tracer)
+			if err != nil {
+				t.Fatalf("failed to retrieve trace result: %v", err)
+			}
+			if diff := diffFlatTraces(have, flattenCallTrace(test.Result, nil, nil)); diff != "" {
+				t.Fatalf("trace mismatch: %s", diff)
+			}
+		})
+	}
+}
+
+// gasAccounting is the gas accounting reported on the top level trace
+type gasAccounting struct {
+	GasUsed          hexutil.Uint64 `json:"gasUsed"`
+	IntrinsicGas     hexutil.Uint64 `json:"intrinsicGas"`
+	GasRefunded      hexutil.Uint64 `json:"gasRefunded"`
+	EffectiveGasUsed hexutil.Uint64 `json:"effectiveGasUsed"`
+}
+
+// TestTraceGasAccounting checks that the effective gas used reported by the
+// tracers matches the gas used by the transaction.
+func TestTraceGasAccounting(t *testing.T) {
+	for name, test := range loadFixtures(t) {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			check := func(tracerName string, gas gasAccounting, result core.ExecutionResult) {
+				if uint64(gas.EffectiveGasUsed) != result.UsedGas {
+					t.Errorf("%s: effective gas used %d, want %d", tracerName, gas.EffectiveGasUsed, result.UsedGas)
+				}
+				if gas.IntrinsicGas+gas.GasUsed != gas.EffectiveGasUsed {
+					t.Errorf("%s: intrinsic gas %d and gas used %d do not add up to %d", tracerName, gas.IntrinsicGas, gas.GasUsed, gas.EffectiveGasUsed)
+				}
+			}
+
+			callTracer, err := New(all["callTracer"], nil)
+			if err != nil {
+				t.Fatalf("failed to create call tracer: %v", err)
+			}
+			result := runTracer(t, makePreState(t, test.Genesis.Alloc), fixtureConfig(), test.Context, fixtureMessage(t, test), callTracer)
+			res, err := callTracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve call trace: %v", err)
+			}
+			var gas gasAccounting
+			if err := json.Unmarshal(res, &gas); err != nil {
+				t.Fatalf("failed to unmarshal call trace: %v", err)
+			}
+			check("callTracer", gas, result)
+
+			parityTracer := &ParityBlockTracer{}
+			result = runTracer(t, makePreState(t, test.Genesis.Alloc), fixtureConfig(), test.Context, fixtureMessage(t, test), parityTracer)
+			traces, err := parityTracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve parity trace: %v", err)
+			}
+			var root struct {
+				gasAccounting
+				Result *gasAccounting `json:"result"`
+			}
+			if err := json.Unmarshal(traces[0], &root); err != nil {
+				t.Fatalf("failed to unmarshal parity trace: %v", err)
+			}
+			// the gas used is only in the result of a successful transaction
+			root.GasUsed = root.EffectiveGasUsed - root.IntrinsicGas
+			if root.Result != nil {
+				root.GasUsed = root.Result.GasUsed
+			}
+			check("ParityBlockTracer", root.gasAccounting, result)
+		})
+	}
+}
+
+// TestTraceGasAccountingRefund checks the gas accounting of a transaction
+// clearing a storage slot, whose refund exceeds the gas used by its execution.
+func TestTraceGasAccountingRefund(t *testing.T) {
+	var (
+		from     = common.HexToAddress("0x1000")
+		contract = common.HexToAddress("0x2000")
+	)
+	alloc := map[common.Address]account{
+		from: {Balance: math.NewHexOrDecimal256(1e18)},
+		contract: {
+			Code:    []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)},
+			Storage: map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(1))},
+		},
+	}
+	msg := types.NewMessage(from, &contract, 0, new(big.Int), 100000, big.NewInt(1), nil, false)
+	// the execution clears the slot for 5006 gas, the refund of 15000 gas is
+	// capped to half of the 26006 gas used with the intrinsic gas
+	want := gasAccounting{GasUsed: 0, IntrinsicGas: 21000, GasRefunded: 13003, EffectiveGasUsed: 13003}
+
+	callTracer, err := New(all["callTracer"], nil)
+	if err != nil {
+		t.Fatalf("failed to create call tracer: %v", err)
+	}
+	result := runTracer(t, makePreState(t, alloc), fixtureConfig(), &callContext{GasLimit: 1000000}, msg, callTracer)
+	if result.UsedGas != uint64(want.EffectiveGasUsed) {
+		t.Fatalf("transaction used %d gas, want %d", result.UsedGas, want.EffectiveGasUsed)
+	}
+	res, err := callTracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve call trace: %v", err)
+	}
+	var gas gasAccounting
+	if err := json.Unmarshal(res, &gas); err != nil {
+		t.Fatalf("failed to unmarshal call trace: %v", err)
+	}
+	if gas != want {
+		t.Errorf("callTracer: have %+v, want %+v", gas, want)
+	}
+
+	parityTracer := &ParityBlockTracer{}
+	runTracer(t, makePreState(t, alloc), fixtureConfig(), &callContext{GasLimit: 1000000}, msg, parityTracer)
+	traces, err := parityTracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve parity trace: %v", err)
+	}
+	var root struct {
+		gasAccounting
+		Result gasAccounting `json:"result"`
+	}
+	if err := json.Unmarshal(traces[0], &root); err != nil {
+		t.Fatalf("failed to unmarshal parity trace: %v", err)
+	}
+	root.GasUsed = root.Result.GasUsed
+	if root.gasAccounting != want {
+		t.Errorf("ParityBlockTracer: have %+v, want %+v", root.gasAccounting, want)
+	}
+}
+
+// flatTrace is the part of a trace in the form of OpenEthereum which both
+// tracers agree on.
+type flatTrace struct {
+	TraceAddress []int
+	Subtraces    int
+	Type         string
+	CallType     string
+	From         common.Address
+	To           common.Address
+	Value        string
+	Input        string
+	Output       string
+	Failed       bool
+}
+
+// parityTrace is a trace of the ParityBlockTracer
+type parityTrace struct {
+	TraceAddress []int  `json:"traceAddress"`
+	Subtraces    int    `json:"subtraces"`
+	Type         string `json:"type"`
+	Action       struct {
+		CallType      string         `json:"callType"`
+		From          common.Address `json:"from"`
+		To            common.Address `json:"to"`
+		Value         *hexutil.Big   `json:"value"`
+		Input         hexutil.Bytes  `json:"input"`
+		Init          hexutil.Bytes  `json:"init"`
+		Address       common.Address `json:"address"`
+		RefundAddress common.Address `json:"refundAddress"`
+		Balance       *hexutil.Big   `json:"balance"`
+	} `json:"action"`
+	Result *struct {
+		Output  hexutil.Bytes  `json:"output"`
+		Code    hexutil.Bytes  `json:"code"`
+		Address common.Address `json:"address"`
+	} `json:"result"`
+	Error string `json:"error"`
+}
+
+// flattenParityTraces returns the traces of the ParityBlockTracer, without
+// the gas and the fields the callTracer lacks
+func flattenParityTraces(tracer *ParityBlockTracer) ([]flatTrace, error) {
+	results, err := tracer.GetResult()
+	if err != nil {
+		return nil, err
+	}
+	var traces []flatTrace
+	for _, raw := range results {
+		var trace parityTrace
+		if err := json.Unmarshal(raw, &trace); err != nil {
+			return nil, err
+		}
+		flat := flatTrace{
+			TraceAddress: trace.TraceAddress,
+			Subtraces:    trace.Subtraces,
+			Type:         trace.Type,
+			CallType:     trace.Action.CallType,
+			From:         trace.Action.From,
+			To:           trace.Action.To,
+			Value:        hexValue(trace.Action.Value),
+			Input:        trace.Action.Input.String(),
+			Failed:       trace.Error != "",
+		}
+		switch trace.Type {
+		case "create":
+			flat.Input = trace.Action.Init.String()
+			if trace.Result != nil {
+				flat.To = trace.Result.Address
+				flat.Output = trace.Result.Code.String()
+			}
+		case "suicide":
+			flat.From = trace.Action.Address
+			flat.To = trace.Action.RefundAddress
+			flat.Value = hexValue(trace.Action.Balance)
+			flat.Input = hexutil.Bytes(nil).String()
+		default:
+			if trace.Result != nil {
+				flat.Output = trace.Result.Output.String()
+			}
+		}
+		if flat.Failed {
+			flat.Output = ""
+			if trace.Type == "create" {
+				flat.To = common.Address{}
+			}
+		}
+		traces = append(traces, flat)
+	}
+	return traces, nil
+}
+
+// flattenCallTrace flattens a callTracer trace depth first, as the traces of
+// OpenEthereum are listed
+func flattenCallTrace(call *callTrace, traceAddress []int, traces []flatTrace) []flatTrace {
+	flat := flatTrace{
+		TraceAddress: append([]int{}, traceAddress...),
+		Subtraces:    len(call.Calls),
+		From:         call.From,
+		To:           call.To,
+		Value:        hexValue(call.Value),
+		Input:        call.Input.String(),
+		Output:       call.Output.String(),
+		Failed:       call.Error != "",
+	}
+	switch call.Type {
+	case "CREATE", "CREATE2":
+		flat.Type = "create"
+	case "SELFDESTRUCT":
+		flat.Type = "suicide"
+		flat.Output = ""
+	default:
+		flat.Type = "call"
+		flat.CallType = strings.ToLower(call.Type)
+	}
+	if flat.Failed {
+		flat.Output = ""
+		if flat.Type == "create" {
+			flat.To = common.Address{}
+		}
+	}
+	traces = append(traces, flat)
+	for i := range call.Calls {
+		traces = flattenCallTrace(&call.Calls[i], append(traceAddress, i), traces)
+	}
+	return traces
+}
+
+// diffCallTraces returns the first difference between the traces, if any
+func diffCallTraces(have, want *callTrace, path string) string {
+	haveCalls, wantCalls := have.Calls, want.Calls
+	haveFrame, wantFrame := *have, *want
+	haveFrame.Calls, wantFrame.Calls = nil, nil
+	// unlike Geth, the empty output of a call to an account without code is
+	// reported, and the one of a failed call omitted
+	if len(haveFrame.Output) == 0 && len(wantFrame.Output) == 0 {
+		haveFrame.Output, wantFrame.Output = nil, nil
+	}
+	if !reflect.DeepEqual(haveFrame, wantFrame) {
+		return fmt.Sprintf("%s:\nhave %s\nwant %s", path, dumpJSON(haveFrame), dumpJSON(wantFrame))
+	}
+	if len(haveCalls) != len(wantCalls) {
+		return fmt.Sprintf("%s: have %d calls, want %d", path, len(haveCalls), len(wantCalls))
+	}
+	for i := range haveCalls {
+		if diff := diffCallTraces(&haveCalls[i], &wantCalls[i], fmt.Sprintf("%s.calls[%d]", path, i)); diff != "" {
+			return diff
+		}
+	}
+	return ""
+}
+
+// diffFlatTraces returns the first difference between the traces, if any
+func diffFlatTraces(have, want []flatTrace) string {
+	for i := 0; i < len(have) && i < len(want); i++ {
+		if !reflect.DeepEqual(have[i], want[i]) {
+			return fmt.Sprintf("trace %d:\nhave %s\nwant %s", i, dumpJSON(have[i]), dumpJSON(want[i]))
+		}
+	}
+	if len(have) != len(want) {
+		return fmt.Sprintf("have %d traces, want %d", len(have), len(want))
+	}
+	return ""
+}
+
+func hexValue(value *hexutil.Big) string {
+	if value == nil {
+		return "0x0"
+	}
+	return value.String()
+}
+
+func dumpJSON(v interface{}) string {
+	b, _ := json.MarshalIndent(v, "", "  ")
+	return string(b)
+}
+
+// fuzzRuns is the number of random transactions of TestTracersFuzz
+const fuzzRuns = 200
+
+// TestTracersFuzz runs transactions calling random contracts, calling and
+// creating each other, through both the callTracer and the ParityBlockTracer,
+// and checks that they report the same calls.
+func TestTracersFuzz(t *testing.T) {
+	runs := fuzzRuns
+	if testing.Short() {
+		runs = 20
+	}
+	for seed := int64(0); seed < int64(runs); seed++ {
+		fuzzTracers(t, seed)
+	}
+}
+
+// fuzzContracts is the number of random contracts of a fuzz run
+const fuzzContracts = 4
+
+func fuzzAddress(i int) common.Address {
+	return common.BigToAddress(big.NewInt(0x1000 + int64(i)))
+}
+
+func fuzzTracers(t *testing.T, seed int64) {
+	rnd := rand.New(rand.NewSource(seed))
+	alloc := make(map[common.Address]account)
+	for i := 0; i < fuzzContracts; i++ {
+		alloc[fuzzAddress(i)] = account{
+			Balance: (*math.HexOrDecimal256)(big.NewInt(int64(rnd.Intn(4)))),
+			Code:    randomContract(rnd),
+		}
+	}
+	from := common.HexToAddress("0xf00d")
+	alloc[from] = account{Balance: (*math.HexOrDecimal256)(big.NewInt(1e18))}
+	to := fuzzAddress(0)
+	input := make([]byte, rnd.Intn(64))
+	rnd.Read(input)
+	msg := types.NewMessage(from, &to, 0, big.NewInt(int64(rnd.Intn(4))), 1000000, big.NewInt(1), input, false)
+	ctx := &callContext{Number: 1, Time: 1, GasLimit: 10000000}
+
+	callTracer, err := New(all["callTracer"], nil)
+	if err != nil {
+		t.Fatalf("failed to create call tracer: %v", err)
+	}
+	runTracer(t, makePreState(t, alloc), params.TestChainConfig, ctx, msg, callTracer)
+	res, err := callTracer.GetResult()
+	if err != nil {
+		t.Fatalf("seed %d: failed to retrieve call trace: %v", seed, err)
+	}
+	call := new(callTrace)
+	if err := json.Unmarshal(res, call); err != nil {
+		t.Fatalf("seed %d: failed to unmarshal call trace: %v", seed, err)
+	}
+
+	parityTracer := &ParityBlockTracer{}
+	runTracer(t, makePreState(t, alloc), params.TestChainConfig, ctx, msg, parityTracer)
+	have, err := flattenParityTraces(parityTracer)
+	if err != nil {
+		t.Fatalf("seed %d: failed to retrieve parity trace: %v", seed, err)
+	}
+	if diff := diffFlatTraces(have, flattenCallTrace(call, nil, nil)); diff != "" {
+		t.Fatalf("seed %d: trace mismatch: %s", seed, diff)
+	}
+}
+
+// randomContract returns the code of a contract doing a few random calls,
+// creations and storage writes before returning, reverting, failing or
+// self-destructing
+func randomContract(rnd *rand.Rand) []byte {
+	var code []byte
+	push := func(v uint64, size int) {
+		code = append(code, byte(vm.PUSH1)+byte(size-1))
+		for i := size - 1; i >= 0; i-- {
+			code = append(code, byte(v>>(8*uint(i))))
+		}
+	}
+	// fill some memory to pass as input and return data
+	push(rnd.Uint64(), 8)
+	push(uint64(rnd.Intn(64)), 1)
+	code = append(code, byte(vm.MSTORE))
+
+	for n := rnd.Intn(4); n > 0; n-- {
+		switch rnd.Intn(4) {
+		case 0, 1:
+			ops := []vm.OpCode{vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL}
+			op := ops[rnd.Intn(len(ops))]
+			push(uint64(rnd.Intn(64)), 1) // return size
+			push(uint64(rnd.Intn(64)), 1) // return offset
+			push(uint64(rnd.Intn(64)), 1) // input size
+			push(uint64(rnd.Intn(64)), 1) // input offset
+			if op == vm.CALL || op == vm.CALLCODE {
+				push(uint64(rnd.Intn(3)), 1)
+			}
+			target := fuzzAddress(rnd.Intn(fuzzContracts + 1)) // or an empty account
+			code = append(code, byte(vm.PUSH20))
+			code = append(code, target.Bytes()...)
+			push(uint64(rnd.Intn(60000)), 2)
+			code = append(code, byte(op), byte(vm.POP))
+		case 2:
+			var init []byte
+			switch rnd.Intn(3) {
+			case 0: // deploy one byte of code
+				init = []byte{byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0, byte(vm.MSTORE8), byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.RETURN)}
+			case 1:
+				init = []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT)}
+			default:
+				init = []byte{0xfe}
+			}
+			code = append(code, byte(vm.PUSH1)+byte(len(init)-1))
+			code = append(code, init...)
+			push(0, 1)
+			code = append(code, byte(vm.MSTORE))
+			push(uint64(len(init)), 1)
+			push(uint64(32-len(init)), 1)
+			push(uint64(rnd.Intn(2)), 1)
+			code = append(code, byte(vm.CREATE), byte(vm.POP))
+		default:
+			push(uint64(rnd.Intn(256)), 1)
+			push(uint64(rnd.Intn(4)), 1)
+			code = append(code, byte(vm.SSTORE))
+		}
+	}
+
+	switch rnd.Intn(5) {
+	case 0:
+		code = append(code, byte(vm.STOP))
+	case 1:
+		push(uint64(rnd.Intn(64)), 1)
+		push(uint64(rnd.Intn(64)), 1)
+		code = append(code, byte(vm.RETURN))
+	case 2:
+		push(uint64(rnd.Intn(64)), 1)
+		push(uint64(rnd.Intn(64)), 1)
+		code = append(code, byte(vm.REVERT))
+	case 3:
+		code = append(code, 0xfe)
+	default:
+		code = append(code, byte(vm.PUSH20))
+		code = append(code, fuzzAddress(rnd.Intn(fuzzContracts)).Bytes()...)
+		code = append(code, byte(vm.SELFDESTRUCT))
+	}
+	return code
+}
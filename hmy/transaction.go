@@ -4,10 +4,12 @@ import (
 	"context"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/pkg/errors"
 )
 
 // SendTx ...
@@ -49,6 +51,61 @@ func (hmy *Harmony) ResendCx(ctx context.Context, txID common.Hash) (uint64, boo
 	return blockNum, success
 }
 
+// CXReceiptProof is the inclusion proof of a cross-shard receipt in its source block.
+// The receipt proof links the receipt to the root of the receipts sent by the source
+// block to the destination shard, which the merkle proof links to the outgoing receipt
+// root of the source block header, itself committed to the beacon chain by the crosslink.
+type CXReceiptProof struct {
+	ReceiptIndex hexutil.Uint64       `json:"receiptIndex"`
+	ReceiptProof []hexutil.Bytes      `json:"receiptProof"`
+	MerkleProof  *types.CXMerkleProof `json:"merkleProof"`
+	CrossLink    *types.CrossLink     `json:"crossLink"` // nil for beacon blocks and until the source block is crosslinked
+}
+
+// GetCXReceiptProof returns the inclusion proof of the cross-shard receipt of the given
+// transaction, as credited by this shard. It returns nil if the receipt is not found.
+func (hmy *Harmony) GetCXReceiptProof(ctx context.Context, txID common.Hash) (*CXReceiptProof, error) {
+	blockHash, _, cxIndex := rawdb.ReadCxLookupEntry(hmy.chainDb, txID)
+	if blockHash == (common.Hash{}) {
+		return nil, nil
+	}
+	blk := hmy.BlockChain.GetBlockByHash(blockHash)
+	if blk == nil {
+		return nil, nil
+	}
+
+	// Locate the incoming receipts proof which carries the receipt
+	previousSum := uint64(0)
+	for _, cxp := range blk.IncomingReceipts() {
+		if cxIndex >= previousSum+uint64(len(cxp.Receipts)) {
+			previousSum += uint64(len(cxp.Receipts))
+			continue
+		}
+		index := cxIndex - previousSum
+		if cxp.Receipts[index].TxHash != txID {
+			return nil, errors.Errorf("cx lookup entry of %v points to another receipt", txID.Hex())
+		}
+		nodes, err := cxp.Receipts.Prove(int(index))
+		if err != nil {
+			return nil, err
+		}
+		proof := &CXReceiptProof{
+			ReceiptIndex: hexutil.Uint64(index),
+			ReceiptProof: make([]hexutil.Bytes, 0, len(nodes)),
+			MerkleProof:  cxp.MerkleProof,
+		}
+		for _, node := range nodes {
+			proof.ReceiptProof = append(proof.ReceiptProof, node)
+		}
+		srcShardID, srcBlockNum := cxp.MerkleProof.ShardID, cxp.MerkleProof.BlockNum.Uint64()
+		if link, err := hmy.BeaconChain.ReadCrossLink(srcShardID, srcBlockNum); err == nil {
+			proof.CrossLink = link
+		}
+		return proof, nil
+	}
+	return nil, errors.Errorf("cx lookup entry of %v points past the incoming receipts", txID.Hex())
+}
+
 // GetReceipts ...
 func (hmy *Harmony) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
 	return hmy.BlockChain.GetReceiptsByHash(hash), nil
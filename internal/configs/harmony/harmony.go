@@ -1,34 +1,43 @@
 package harmony
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
+	"time"
 )
 
 // HarmonyConfig contains all the configs user can set for running harmony binary. Served as the bridge
 // from user set flags to internal node configs. Also user can persist this structure to a toml file
 // to avoid inputting all arguments.
 type HarmonyConfig struct {
-	Version    string
-	General    GeneralConfig
-	Network    NetworkConfig
-	P2P        P2pConfig
-	HTTP       HttpConfig
-	WS         WsConfig
-	RPCOpt     RpcOptConfig
-	BLSKeys    BlsConfig
-	TxPool     TxPoolConfig
-	Pprof      PprofConfig
-	Log        LogConfig
-	Sync       SyncConfig
-	Sys        *SysConfig        `toml:",omitempty"`
-	Consensus  *ConsensusConfig  `toml:",omitempty"`
-	Devnet     *DevnetConfig     `toml:",omitempty"`
-	Revert     *RevertConfig     `toml:",omitempty"`
-	Legacy     *LegacyConfig     `toml:",omitempty"`
-	Prometheus *PrometheusConfig `toml:",omitempty"`
-	DNSSync    DnsSync
-	ShardData  ShardDataConfig
+	Version       string
+	General       GeneralConfig
+	Network       NetworkConfig
+	P2P           P2pConfig
+	HTTP          HttpConfig
+	WS            WsConfig
+	RPCOpt        RpcOptConfig
+	BLSKeys       BlsConfig
+	TxPool        TxPoolConfig
+	Pprof         PprofConfig
+	Log           LogConfig
+	Sync          SyncConfig
+	Sys           *SysConfig        `toml:",omitempty"`
+	Consensus     *ConsensusConfig  `toml:",omitempty"`
+	Devnet        *DevnetConfig     `toml:",omitempty"`
+	Revert        *RevertConfig     `toml:",omitempty"`
+	Legacy        *LegacyConfig     `toml:",omitempty"`
+	Prometheus    *PrometheusConfig `toml:",omitempty"`
+	Health        *HealthConfig     `toml:",omitempty"`
+	DNSSync       DnsSync
+	ShardData     ShardDataConfig
+	StatePrune    StatePruneConfig
+	Snapshot      SnapshotConfig
+	Freezer       FreezerConfig
+	StateDiff     StateDiffConfig
+	RewardHistory RewardHistoryConfig
+	DB            DBConfig
 }
 
 type DnsSync struct {
@@ -41,8 +50,9 @@ type DnsSync struct {
 }
 
 type NetworkConfig struct {
-	NetworkType string
-	BootNodes   []string
+	NetworkType  string
+	BootNodes    []string
+	DNSNodeLists []string `toml:",omitempty"` // EIP-1459 DNS node lists of the network
 }
 
 type P2pConfig struct {
@@ -52,6 +62,7 @@ type P2pConfig struct {
 	DHTDataStore    *string `toml:",omitempty"`
 	DiscConcurrency int     // Discovery Concurrency value
 	MaxConnsPerIP   int
+	PeerScoreFile   string // file persisting the peer scores and bans, not persisted if empty
 }
 
 type GeneralConfig struct {
@@ -74,9 +85,40 @@ type ShardDataConfig struct {
 	CacheSize       int
 }
 
+type StatePruneConfig struct {
+	Enabled   bool   // prune the historical states in the background
+	Retain    uint64 // number of recent block states kept
+	BloomSize uint64 // memory (MB) of the bloom filter marking the kept states
+}
+
+type SnapshotConfig struct {
+	Enabled bool // read the state from a flat snapshot layer
+	Cache   int  // memory (MB) of the snapshot cache
+}
+
+type FreezerConfig struct {
+	Enabled   bool   // move the old block bodies and receipts into flat freezer files
+	Threshold uint64 // number of recent blocks kept in the database
+}
+
+type StateDiffConfig struct {
+	Enabled bool // index the state changes of every imported block
+}
+
+type RewardHistoryConfig struct {
+	Enabled bool // index the rewards paid to every delegation by the imported blocks
+}
+
+type DBConfig struct {
+	Engine      string // key-value engine of the chain databases, only leveldb
+	Compression string // compression of the stored receipts and state diffs, none or snappy
+}
+
 type ConsensusConfig struct {
 	MinPeers     int
 	AggregateSig bool
+	// DeactivateOnDoubleSign stops signing with the keys seen double signing
+	DeactivateOnDoubleSign bool
 }
 
 type BlsConfig struct {
@@ -98,6 +140,9 @@ type TxPoolConfig struct {
 	BlacklistFile  string
 	RosettaFixFile string
 	AccountSlots   uint64
+	Journal        string // Journal of the submitted transactions, relative to the data dir, empty to disable
+	Rejournal      string // Interval to regenerate the journal, e.g. "1h"
+	PriceBump      uint64 // Minimum gas price bump in percent to replace a transaction of the same nonce, 0 for the network default
 }
 
 type PprofConfig struct {
@@ -152,8 +197,12 @@ type HttpConfig struct {
 	IP             string
 	Port           int
 	AuthPort       int
+	AdminEnabled   bool // Serves the admin APIs changing the node on a localhost only endpoint
+	AdminPort      int
 	RosettaEnabled bool
 	RosettaPort    int
+	GraphQLEnabled bool
+	GraphQLPort    int
 }
 
 type WsConfig struct {
@@ -167,6 +216,19 @@ type RpcOptConfig struct {
 	DebugEnabled      bool // Enables PrivateDebugService APIs, including the EVM tracer
 	RateLimterEnabled bool // Enable Rate limiter for RPC
 	RequestsPerSecond int  // for RPC rate limiter
+
+	TraceTimeout        string // Execution timeout of a single trace request, e.g. "30s"
+	MaxConcurrentTraces int    // Maximum number of trace requests executed at the same time
+	TraceCacheSize      int    // Memory budget of the block trace result cache in MB, 0 disables it
+	TraceMaxReexec      uint64 // Maximum number of blocks re-executed to regenerate a missing state, 0 for no limit
+	TraceIndex          bool   // Index the trace addresses of every block to speed up trace_filter, archival only
+
+	GasCap            uint64         // Global gas cap of eth_call and eth_estimateGas, 0 for no cap
+	BatchRequestLimit int            // Maximum number of requests in a JSON-RPC batch, 0 for no limit
+	MethodRateLimits  map[string]int `toml:",omitempty"` // Requests per second allowed for a method, keyed by full method name
+
+	LogsBlockRange  int // Maximum number of blocks spanned by a log query, 0 for no limit
+	LogsResultLimit int // Maximum number of logs returned by a log query, 0 for no limit
 }
 
 type DevnetConfig struct {
@@ -195,6 +257,26 @@ type PrometheusConfig struct {
 	Gateway    string
 }
 
+// HealthConfig is the config of the health endpoint. Each check maps its value to
+// degraded and unhealthy with a threshold pair, a zero threshold is disabled.
+type HealthConfig struct {
+	Enabled                bool
+	IP                     string
+	Port                   int
+	DegradedSyncLag        uint64 // blocks behind the sync target
+	UnhealthySyncLag       uint64
+	DegradedBlockAge       string // time since the head block, e.g. 30s
+	UnhealthyBlockAge      string
+	DegradedPeers          int // minimum connected peers
+	UnhealthyPeers         int
+	DegradedTxPoolSize     int // pending and queued transactions
+	UnhealthyTxPoolSize    int
+	DegradedTraceIndexLag  uint64 // blocks not covered by the trace index
+	UnhealthyTraceIndexLag uint64
+	DegradedDiskFreeMB     uint64 // minimum available space on the data disk
+	UnhealthyDiskFreeMB    uint64
+}
+
 type SyncConfig struct {
 	// TODO: Remove this bool after stream sync is fully up.
 	Enabled        bool // enable the stream sync protocol
@@ -206,4 +288,59 @@ type SyncConfig struct {
 	DiscHardLowCap int  // when removing stream, num is below this value, spin discovery immediately
 	DiscHighCap    int  // upper limit of streams in one sync protocol
 	DiscBatch      int  // size of each discovery
+	Staged         bool // do the initial sync in pipelined stages with resumable checkpoints
+	SnapSync       bool // bootstrap a new node by downloading the state of a recent pivot block
+}
+
+// RuntimeConfig is the part of the config that can be changed while the node is
+// running, through the admin_setConfig RPC of the localhost admin endpoint or by
+// reloading the config file.
+type RuntimeConfig struct {
+	LogVerbosity        int            `json:"logVerbosity"`
+	RequestsPerSecond   int            `json:"requestsPerSecond"`
+	MethodRateLimits    map[string]int `json:"methodRateLimits"`
+	TraceTimeout        string         `json:"traceTimeout"`
+	MaxConcurrentTraces int            `json:"maxConcurrentTraces"`
+	GasCap              uint64         `json:"gasCap"`
+	MaxConnsPerIP       int            `json:"maxConnsPerIP"`
+}
+
+// RuntimeConfig returns the settings of the config that can be changed at runtime
+func (hc HarmonyConfig) RuntimeConfig() RuntimeConfig {
+	return RuntimeConfig{
+		LogVerbosity:        hc.Log.Verbosity,
+		RequestsPerSecond:   hc.RPCOpt.RequestsPerSecond,
+		MethodRateLimits:    hc.RPCOpt.MethodRateLimits,
+		TraceTimeout:        hc.RPCOpt.TraceTimeout,
+		MaxConcurrentTraces: hc.RPCOpt.MaxConcurrentTraces,
+		GasCap:              hc.RPCOpt.GasCap,
+		MaxConnsPerIP:       hc.P2P.MaxConnsPerIP,
+	}
+}
+
+// Validate checks the runtime settings before they are applied
+func (rc RuntimeConfig) Validate() error {
+	if rc.LogVerbosity < 0 || rc.LogVerbosity > 5 {
+		return fmt.Errorf("invalid log verbosity %v, expect 0-5", rc.LogVerbosity)
+	}
+	if rc.RequestsPerSecond < 0 {
+		return fmt.Errorf("invalid requests per second %v", rc.RequestsPerSecond)
+	}
+	for method, rps := range rc.MethodRateLimits {
+		if rps < 0 {
+			return fmt.Errorf("invalid rate limit %v of method %v", rps, method)
+		}
+	}
+	if timeout, err := time.ParseDuration(rc.TraceTimeout); err != nil {
+		return fmt.Errorf("invalid trace timeout: %v", err)
+	} else if timeout < 0 {
+		return fmt.Errorf("invalid trace timeout %v", rc.TraceTimeout)
+	}
+	if rc.MaxConcurrentTraces < 0 {
+		return fmt.Errorf("invalid max concurrent traces %v", rc.MaxConcurrentTraces)
+	}
+	if rc.MaxConnsPerIP < 0 {
+		return fmt.Errorf("invalid max connections per IP %v", rc.MaxConnsPerIP)
+	}
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"strings"
 	"sync"
+	"time"
 
 	bls_core "github.com/harmony-one/bls/ffi/go/bls"
 	"github.com/harmony-one/harmony/crypto/bls"
@@ -79,6 +80,7 @@ type ConfigType struct {
 	IP              string              // IP of the node.
 	RPCServer       RPCServerConfig     // RPC server port and ip
 	RosettaServer   RosettaServerConfig // rosetta server port and ip
+	GraphQLServer   GraphQLServerConfig // graphql server port and ip
 	IsOffline       bool
 	Downloader      bool // Whether stream downloader is running; TODO: remove this after sync up
 	NtpServer       string
@@ -103,6 +105,9 @@ type RPCServerConfig struct {
 	HTTPPort     int
 	HTTPAuthPort int
 
+	AdminEnabled bool
+	AdminPort    int
+
 	WSEnabled  bool
 	WSIp       string
 	WSPort     int
@@ -112,6 +117,19 @@ type RPCServerConfig struct {
 
 	RateLimiterEnabled bool
 	RequestsPerSecond  int
+
+	TraceTimeout        time.Duration
+	MaxConcurrentTraces int
+	TraceCacheSize      int
+	TraceMaxReexec      uint64
+	TraceIndex          bool
+
+	GasCap            uint64
+	BatchRequestLimit int
+	MethodRateLimits  map[string]int
+
+	LogsBlockRange  int
+	LogsResultLimit int
 }
 
 // RosettaServerConfig is the config for the rosetta server
@@ -121,6 +139,13 @@ type RosettaServerConfig struct {
 	HTTPPort    int
 }
 
+// GraphQLServerConfig is the config for the graphql server
+type GraphQLServerConfig struct {
+	HTTPEnabled bool
+	HTTPIp      string
+	HTTPPort    int
+}
+
 // configs is a list of node configuration.
 // It has at least one configuration.
 // The first one is the default, global node configuration
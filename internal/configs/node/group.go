@@ -25,6 +25,7 @@ const (
 	GroupIDBeaconClient      GroupID = "%s/0.0.1/client/beacon"
 	GroupIDShardPrefix       GroupID = "%s/0.0.1/node/shard/%s"
 	GroupIDShardClientPrefix GroupID = "%s/0.0.1/client/shard/%s"
+	GroupIDShardCXPrefix     GroupID = "%s/0.0.1/node/cx/%s"
 	GroupIDGlobal            GroupID = "%s/0.0.1/node/global"
 	GroupIDGlobalClient      GroupID = "%s/0.0.1/node/global"
 	GroupIDUnknown           GroupID = "%s/B1acKh0lE"
@@ -71,6 +72,12 @@ func NewClientGroupIDByShardID(shardID ShardID) GroupID {
 	return GroupID(fmt.Sprintf(GroupIDShardClientPrefix.String(), getNetworkPrefix(shardID), strconv.Itoa(int(shardID))))
 }
 
+// NewCXGroupIDByShardID returns a new groupID for the cross-shard receipts sent
+// to a shard
+func NewCXGroupIDByShardID(shardID ShardID) GroupID {
+	return GroupID(fmt.Sprintf(GroupIDShardCXPrefix.String(), getNetworkPrefix(shardID), strconv.Itoa(int(shardID))))
+}
+
 // ActionType lists action on group
 type ActionType uint
 
@@ -49,14 +49,20 @@ const (
 	DefaultRPCPort = 9500
 	// DefaultAuthRPCPort is the default rpc auth port. The actual port used is 9000+501
 	DefaultAuthRPCPort = 9501
+	// DefaultAdminRPCPort is the default port of the localhost admin rpc. The actual port used is 9000+502
+	DefaultAdminRPCPort = 9502
 	// DefaultRosettaPort is the default rosetta port. The actual port used is 9000+700
 	DefaultRosettaPort = 9700
+	// DefaultGraphQLPort is the default graphql port. The actual port used is 9000+600
+	DefaultGraphQLPort = 9600
 	// DefaultWSPort is the default port for web socket endpoint. The actual port used is
 	DefaultWSPort = 9800
 	// DefaultAuthWSPort is the default port for web socket auth endpoint. The actual port used is
 	DefaultAuthWSPort = 9801
 	// DefaultPrometheusPort is the default prometheus port. The actual port used is 9000+900
 	DefaultPrometheusPort = 9900
+	// DefaultHealthPort is the default port of the health endpoint
+	DefaultHealthPort = 9950
 	// DefaultP2PConcurrency is the default P2P concurrency, 0 means is set the default value of P2P Discovery, the actual value is 10
 	DefaultP2PConcurrency = 0
 	DefaultMaxConnPerIP   = 10
@@ -65,6 +71,22 @@ const (
 const (
 	// DefaultRateLimit for RPC, the number of requests per second
 	DefaultRPCRateLimit = 1000
+	// DefaultTraceTimeout is the default execution timeout of a single trace request
+	DefaultTraceTimeout = "30s"
+	// DefaultMaxConcurrentTraces is the default number of trace requests allowed to run at the same time
+	DefaultMaxConcurrentTraces = 4
+	// DefaultTraceCacheSize is the default memory budget in MB of the block trace result cache
+	DefaultTraceCacheSize = 128
+	// DefaultTraceMaxReexec is the default maximum number of blocks re-executed by a trace request
+	DefaultTraceMaxReexec = 1024
+	// DefaultRPCGasCap is the default global gas cap of eth_call and eth_estimateGas
+	DefaultRPCGasCap = 50000000
+	// DefaultRPCBatchRequestLimit is the default maximum number of requests in a JSON-RPC batch
+	DefaultRPCBatchRequestLimit = 1000
+	// DefaultLogsBlockRange is the default maximum number of blocks spanned by a log query
+	DefaultLogsBlockRange = 1024
+	// DefaultLogsResultLimit is the default maximum number of logs returned by a log query
+	DefaultLogsResultLimit = 10000
 )
 
 const (
@@ -74,9 +96,15 @@ const (
 	// rpcHTTPAuthPortOffset is the port offset for RPC Auth HTTP requests
 	rpcHTTPAuthPortOffset = 501
 
+	// rpcHTTPAdminPortOffset is the port offset for the localhost RPC admin HTTP requests
+	rpcHTTPAdminPortOffset = 502
+
 	// rpcHTTPPortOffset is the port offset for rosetta HTTP requests
 	rosettaHTTPPortOffset = 700
 
+	// graphQLHTTPPortOffset is the port offset for graphql HTTP requests
+	graphQLHTTPPortOffset = 600
+
 	// rpcWSPortOffSet is the port offset for RPC websocket requests
 	rpcWSPortOffSet = 800
 
@@ -138,11 +166,21 @@ func GetRPCAuthHTTPPortFromBase(basePort int) int {
 	return basePort + rpcHTTPAuthPortOffset
 }
 
+// GetRPCAdminHTTPPortFromBase return the rpc admin HTTP port from base port
+func GetRPCAdminHTTPPortFromBase(basePort int) int {
+	return basePort + rpcHTTPAdminPortOffset
+}
+
 // GetRosettaHTTPPortFromBase return the rosetta HTTP port from base port
 func GetRosettaHTTPPortFromBase(basePort int) int {
 	return basePort + rosettaHTTPPortOffset
 }
 
+// GetGraphQLHTTPPortFromBase return the graphql HTTP port from base port
+func GetGraphQLHTTPPortFromBase(basePort int) int {
+	return basePort + graphQLHTTPPortOffset
+}
+
 // GetWSPortFromBase return the Websocket port from the base port
 func GetWSPortFromBase(basePort int) int {
 	return basePort + rpcWSPortOffSet
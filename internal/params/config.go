@@ -65,6 +65,16 @@ var (
 		SHA3Epoch:                  big.NewInt(725), // Around Mon Oct 11 2021, 19:00 UTC
 		HIP6And8Epoch:              big.NewInt(725), // Around Mon Oct 11 2021, 19:00 UTC
 		StakingPrecompileEpoch:     big.NewInt(871), // Around Tue Feb 11 2022
+		BLSPrecompileEpoch:         EpochTBD,
+		TransientStorageEpoch:      EpochTBD,
+		Push0Epoch:                 EpochTBD,
+		ShardInfoPrecompileEpoch:   EpochTBD,
+		VRFLookupGasEpoch:          EpochTBD,
+		BerlinEpoch:                EpochTBD,
+		LondonEpoch:                EpochTBD,
+		RefundReductionEpoch:       EpochTBD,
+		StakingLogsEpoch:           EpochTBD,
+		CXTopicEpoch:               EpochTBD,
 	}
 
 	// TestnetChainConfig contains the chain parameters to run a node on the harmony test network.
@@ -98,6 +108,16 @@ var (
 		SHA3Epoch:                  big.NewInt(74570),
 		HIP6And8Epoch:              big.NewInt(74570),
 		StakingPrecompileEpoch:     big.NewInt(75175),
+		BLSPrecompileEpoch:         EpochTBD,
+		TransientStorageEpoch:      EpochTBD,
+		Push0Epoch:                 EpochTBD,
+		ShardInfoPrecompileEpoch:   EpochTBD,
+		VRFLookupGasEpoch:          EpochTBD,
+		BerlinEpoch:                EpochTBD,
+		LondonEpoch:                EpochTBD,
+		RefundReductionEpoch:       EpochTBD,
+		StakingLogsEpoch:           EpochTBD,
+		CXTopicEpoch:               EpochTBD,
 	}
 
 	// PangaeaChainConfig contains the chain parameters for the Pangaea network.
@@ -132,6 +152,16 @@ var (
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              big.NewInt(0),
 		StakingPrecompileEpoch:     big.NewInt(2), // same as staking
+		BLSPrecompileEpoch:         big.NewInt(2), // same as staking precompile
+		TransientStorageEpoch:      big.NewInt(2),
+		Push0Epoch:                 big.NewInt(2),
+		ShardInfoPrecompileEpoch:   big.NewInt(2),
+		VRFLookupGasEpoch:          big.NewInt(2),
+		BerlinEpoch:                big.NewInt(2),
+		LondonEpoch:                big.NewInt(2),
+		RefundReductionEpoch:       big.NewInt(2),
+		StakingLogsEpoch:           big.NewInt(2),
+		CXTopicEpoch:               big.NewInt(2),
 	}
 
 	// PartnerChainConfig contains the chain parameters for the Partner network.
@@ -166,6 +196,16 @@ var (
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              big.NewInt(0),
 		StakingPrecompileEpoch:     big.NewInt(2),
+		BLSPrecompileEpoch:         big.NewInt(2), // same as staking precompile
+		TransientStorageEpoch:      big.NewInt(2),
+		Push0Epoch:                 big.NewInt(2),
+		ShardInfoPrecompileEpoch:   big.NewInt(2),
+		VRFLookupGasEpoch:          big.NewInt(2),
+		BerlinEpoch:                big.NewInt(2),
+		LondonEpoch:                big.NewInt(2),
+		RefundReductionEpoch:       big.NewInt(2),
+		StakingLogsEpoch:           big.NewInt(2),
+		CXTopicEpoch:               big.NewInt(2),
 	}
 
 	// StressnetChainConfig contains the chain parameters for the Stress test network.
@@ -200,6 +240,16 @@ var (
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              big.NewInt(0),
 		StakingPrecompileEpoch:     big.NewInt(2),
+		BLSPrecompileEpoch:         big.NewInt(2), // same as staking precompile
+		TransientStorageEpoch:      big.NewInt(2),
+		Push0Epoch:                 big.NewInt(2),
+		ShardInfoPrecompileEpoch:   big.NewInt(2),
+		VRFLookupGasEpoch:          big.NewInt(2),
+		BerlinEpoch:                big.NewInt(2),
+		LondonEpoch:                big.NewInt(2),
+		RefundReductionEpoch:       big.NewInt(2),
+		StakingLogsEpoch:           big.NewInt(2),
+		CXTopicEpoch:               big.NewInt(2),
 	}
 
 	// LocalnetChainConfig contains the chain parameters to run for local development.
@@ -233,6 +283,16 @@ var (
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              EpochTBD, // Never enable it for localnet as localnet has no external validator setup
 		StakingPrecompileEpoch:     big.NewInt(2),
+		BLSPrecompileEpoch:         big.NewInt(2), // same as staking precompile
+		TransientStorageEpoch:      big.NewInt(2),
+		Push0Epoch:                 big.NewInt(2),
+		ShardInfoPrecompileEpoch:   big.NewInt(2),
+		VRFLookupGasEpoch:          big.NewInt(2),
+		BerlinEpoch:                big.NewInt(2),
+		LondonEpoch:                big.NewInt(2),
+		RefundReductionEpoch:       big.NewInt(2),
+		StakingLogsEpoch:           big.NewInt(2),
+		CXTopicEpoch:               big.NewInt(2),
 	}
 
 	// AllProtocolChanges ...
@@ -268,6 +328,16 @@ var (
 		big.NewInt(0),                      // SHA3Epoch
 		big.NewInt(0),                      // HIP6And8Epoch
 		big.NewInt(0),                      // StakingPrecompileEpoch
+		big.NewInt(0),                      // BLSPrecompileEpoch
+		big.NewInt(0),                      // TransientStorageEpoch
+		big.NewInt(0),                      // Push0Epoch
+		big.NewInt(0),                      // ShardInfoPrecompileEpoch
+		big.NewInt(0),                      // VRFLookupGasEpoch
+		big.NewInt(0),                      // BerlinEpoch
+		big.NewInt(0),                      // LondonEpoch
+		big.NewInt(0),                      // RefundReductionEpoch
+		big.NewInt(0),                      // StakingLogsEpoch
+		big.NewInt(0),                      // CXTopicEpoch
 	}
 
 	// TestChainConfig ...
@@ -303,6 +373,16 @@ var (
 		big.NewInt(0),        // SHA3Epoch
 		big.NewInt(0),        // HIP6And8Epoch
 		big.NewInt(0),        // StakingPrecompileEpoch
+		big.NewInt(0),        // BLSPrecompileEpoch
+		big.NewInt(0),        // TransientStorageEpoch
+		big.NewInt(0),        // Push0Epoch
+		big.NewInt(0),        // ShardInfoPrecompileEpoch
+		big.NewInt(0),        // VRFLookupGasEpoch
+		big.NewInt(0),        // BerlinEpoch
+		big.NewInt(0),        // LondonEpoch
+		big.NewInt(0),        // RefundReductionEpoch
+		big.NewInt(0),        // StakingLogsEpoch
+		big.NewInt(0),        // CXTopicEpoch
 	}
 
 	// TestRules ...
@@ -420,6 +500,43 @@ type ChainConfig struct {
 
 	// StakingPrecompileEpoch is the first epoch to support the staking precompiles
 	StakingPrecompileEpoch *big.Int `json:"staking-precompile-epoch,omitempty"`
+
+	// BLSPrecompileEpoch is the first epoch to support the BLS signature verification precompile
+	BLSPrecompileEpoch *big.Int `json:"bls-precompile-epoch,omitempty"`
+
+	// TransientStorageEpoch is the first epoch to support the EIP-1153 transient
+	// storage opcodes TLOAD and TSTORE
+	TransientStorageEpoch *big.Int `json:"transient-storage-epoch,omitempty"`
+
+	// Push0Epoch is the first epoch to support the EIP-3855 PUSH0 opcode
+	Push0Epoch *big.Int `json:"push0-epoch,omitempty"`
+
+	// ShardInfoPrecompileEpoch is the first epoch to support the shard info precompile
+	ShardInfoPrecompileEpoch *big.Int `json:"shard-info-precompile-epoch,omitempty"`
+
+	// VRFLookupGasEpoch is the first epoch to charge the VRF precompile per
+	// historical block lookup
+	VRFLookupGasEpoch *big.Int `json:"vrf-lookup-gas-epoch,omitempty"`
+
+	// BerlinEpoch is the first epoch to support the EIP-2929 gas cost increases and
+	// EIP-2930 access list transactions
+	BerlinEpoch *big.Int `json:"berlin-epoch,omitempty"`
+
+	// LondonEpoch is the first epoch to support EIP-1559 dynamic fee transactions and
+	// the v4 block header which carries the base fee
+	LondonEpoch *big.Int `json:"london-epoch,omitempty"`
+
+	// RefundReductionEpoch is the first epoch to apply the EIP-3529 reduced gas refunds,
+	// which removes the selfdestruct refund and caps refunds to a fifth of the gas used
+	RefundReductionEpoch *big.Int `json:"refund-reduction-epoch,omitempty"`
+
+	// StakingLogsEpoch is the first epoch to emit the well-known logs of the
+	// delegations, undelegations, reward collections and validator edits
+	StakingLogsEpoch *big.Int `json:"staking-logs-epoch,omitempty"`
+
+	// CXTopicEpoch is the first epoch whose cross-shard receipts are sent only on
+	// the dedicated CX topic of the destination shard, and no longer on its shard topic
+	CXTopicEpoch *big.Int `json:"cx-topic-epoch,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -580,6 +697,65 @@ func (c *ChainConfig) IsStakingPrecompile(epoch *big.Int) bool {
 	return isForked(c.StakingPrecompileEpoch, epoch)
 }
 
+// IsBLSPrecompile determines whether the BLS signature
+// verification precompile is available in the EVM
+func (c *ChainConfig) IsBLSPrecompile(epoch *big.Int) bool {
+	return isForked(c.BLSPrecompileEpoch, epoch)
+}
+
+// IsTransientStorage determines whether the transient storage opcodes
+// TLOAD and TSTORE (EIP-1153) are available at the given epoch
+func (c *ChainConfig) IsTransientStorage(epoch *big.Int) bool {
+	return isForked(c.TransientStorageEpoch, epoch)
+}
+
+// IsPush0 determines whether the PUSH0 opcode (EIP-3855) is
+// available at the given epoch
+func (c *ChainConfig) IsPush0(epoch *big.Int) bool {
+	return isForked(c.Push0Epoch, epoch)
+}
+
+// IsShardInfoPrecompile determines whether the shard info
+// precompile is available at the given epoch
+func (c *ChainConfig) IsShardInfoPrecompile(epoch *big.Int) bool {
+	return isForked(c.ShardInfoPrecompileEpoch, epoch)
+}
+
+// IsVRFLookupGas determines whether the VRF precompile
+// charges gas per historical block lookup at the given epoch
+func (c *ChainConfig) IsVRFLookupGas(epoch *big.Int) bool {
+	return isForked(c.VRFLookupGasEpoch, epoch)
+}
+
+// IsBerlin determines whether it is the epoch to support
+// the EIP-2929 and EIP-2930 access list changes
+func (c *ChainConfig) IsBerlin(epoch *big.Int) bool {
+	return isForked(c.BerlinEpoch, epoch)
+}
+
+// IsLondon determines whether it is the epoch to support
+// EIP-1559 dynamic fee transactions and the base fee
+func (c *ChainConfig) IsLondon(epoch *big.Int) bool {
+	return isForked(c.LondonEpoch, epoch)
+}
+
+// IsRefundReduction determines whether it is the epoch to apply the EIP-3529 reduced gas refunds
+func (c *ChainConfig) IsRefundReduction(epoch *big.Int) bool {
+	return isForked(c.RefundReductionEpoch, epoch)
+}
+
+// IsStakingLogs determines whether the staking transactions emit the well-known
+// logs of their state transitions
+func (c *ChainConfig) IsStakingLogs(epoch *big.Int) bool {
+	return isForked(c.StakingLogsEpoch, epoch)
+}
+
+// IsCXTopic determines whether the cross-shard receipts of the epoch are sent
+// only on the CX topic of the destination shard
+func (c *ChainConfig) IsCXTopic(epoch *big.Int) bool {
+	return isForked(c.CXTopicEpoch, epoch)
+}
+
 // UpdateEthChainIDByShard update the ethChainID based on shard ID.
 func UpdateEthChainIDByShard(shardID uint32) {
 	once.Do(func() {
@@ -599,7 +775,7 @@ func IsEthCompatible(chainID *big.Int) bool {
 	return chainID.Cmp(EthMainnetShard0ChainID) >= 0
 }
 
-// GasTable returns the gas table corresponding to the current phase (homestead or homestead reprice).
+// GasTable returns the gas table corresponding to the current phase (r3, s3, istanbul or berlin).
 //
 // The returned GasTable's fields shouldn't, under any circumstances, be changed.
 func (c *ChainConfig) GasTable(epoch *big.Int) GasTable {
@@ -607,6 +783,10 @@ func (c *ChainConfig) GasTable(epoch *big.Int) GasTable {
 		return GasTableR3
 	}
 	switch {
+	case c.IsBerlin(epoch):
+		return GasTableBerlin
+	case c.IsIstanbul(epoch):
+		return GasTableIstanbul
 	case c.IsS3(epoch):
 		return GasTableS3
 	default:
@@ -628,9 +808,10 @@ func isForked(s, epoch *big.Int) bool {
 // Rules is a one time interface meaning that it shouldn't be used in between transition
 // phases.
 type Rules struct {
-	ChainID                                                                                              *big.Int
-	EthChainID                                                                                           *big.Int
-	IsCrossLink, IsEIP155, IsS3, IsReceiptLog, IsIstanbul, IsVRF, IsPrevVRF, IsSHA3, IsStakingPrecompile bool
+	ChainID                                                                                                                    *big.Int
+	EthChainID                                                                                                                 *big.Int
+	IsCrossLink, IsEIP155, IsS3, IsReceiptLog, IsIstanbul, IsVRF, IsPrevVRF, IsSHA3, IsStakingPrecompile                       bool
+	IsBLSPrecompile, IsTransientStorage, IsPush0, IsShardInfoPrecompile, IsVRFLookupGas, IsBerlin, IsLondon, IsRefundReduction bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -649,16 +830,24 @@ func (c *ChainConfig) Rules(epoch *big.Int) Rules {
 		ethChainID = new(big.Int)
 	}
 	return Rules{
-		ChainID:             new(big.Int).Set(chainID),
-		EthChainID:          new(big.Int).Set(ethChainID),
-		IsCrossLink:         c.IsCrossLink(epoch),
-		IsEIP155:            c.IsEIP155(epoch),
-		IsS3:                c.IsS3(epoch),
-		IsReceiptLog:        c.IsReceiptLog(epoch),
-		IsIstanbul:          c.IsIstanbul(epoch),
-		IsVRF:               c.IsVRF(epoch),
-		IsPrevVRF:           c.IsPrevVRF(epoch),
-		IsSHA3:              c.IsSHA3(epoch),
-		IsStakingPrecompile: c.IsStakingPrecompile(epoch),
+		ChainID:               new(big.Int).Set(chainID),
+		EthChainID:            new(big.Int).Set(ethChainID),
+		IsCrossLink:           c.IsCrossLink(epoch),
+		IsEIP155:              c.IsEIP155(epoch),
+		IsS3:                  c.IsS3(epoch),
+		IsReceiptLog:          c.IsReceiptLog(epoch),
+		IsIstanbul:            c.IsIstanbul(epoch),
+		IsVRF:                 c.IsVRF(epoch),
+		IsPrevVRF:             c.IsPrevVRF(epoch),
+		IsSHA3:                c.IsSHA3(epoch),
+		IsStakingPrecompile:   c.IsStakingPrecompile(epoch),
+		IsBLSPrecompile:       c.IsBLSPrecompile(epoch),
+		IsTransientStorage:    c.IsTransientStorage(epoch),
+		IsPush0:               c.IsPush0(epoch),
+		IsShardInfoPrecompile: c.IsShardInfoPrecompile(epoch),
+		IsVRFLookupGas:        c.IsVRFLookupGas(epoch),
+		IsBerlin:              c.IsBerlin(epoch),
+		IsLondon:              c.IsLondon(epoch),
+		IsRefundReduction:     c.IsRefundReduction(epoch),
 	}
 }
@@ -1,6 +1,8 @@
 package params
 
-// GasTable organizes gas prices for different harmony phases.
+// GasTable organizes gas prices for different harmony phases. The EVM selects
+// the table of the current epoch through ChainConfig.GasTable and prices the
+// state reading opcodes from it, so a repricing fork only needs a new table.
 type GasTable struct {
 	ExtcodeSize uint64
 	ExtcodeCopy uint64
@@ -45,6 +47,36 @@ var (
 		Suicide:     5000,
 		ExpByte:     50,
 
+		CreateBySuicide: 25000,
+	}
+	// GasTableIstanbul contain the gas re-prices for
+	// the istanbul phase (EIP-1884).
+	GasTableIstanbul = GasTable{
+		ExtcodeSize: 700,
+		ExtcodeCopy: 700,
+		ExtcodeHash: 700,
+		Balance:     700,
+		SLoad:       800,
+		Calls:       700,
+		Suicide:     5000,
+		ExpByte:     50,
+
+		CreateBySuicide: 25000,
+	}
+	// GasTableBerlin contain the gas re-prices for
+	// the berlin phase (EIP-2929). The listed prices are
+	// the warm access costs, cold accesses are charged
+	// on top of them.
+	GasTableBerlin = GasTable{
+		ExtcodeSize: 100,
+		ExtcodeCopy: 100,
+		ExtcodeHash: 100,
+		Balance:     100,
+		SLoad:       100,
+		Calls:       100,
+		Suicide:     5000,
+		ExpByte:     50,
+
 		CreateBySuicide: 25000,
 	}
 )
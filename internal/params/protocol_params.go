@@ -12,6 +12,12 @@ const (
 	GenesisGasLimit uint64 = 4712388 // Gas limit of the Genesis block.
 	// TestGenesisGasLimit ..
 	TestGenesisGasLimit uint64 = 80000000 // A Gas limit in testing of the Genesis block (set same as current mainnet)
+	// BaseFeeChangeDenominator ...
+	BaseFeeChangeDenominator uint64 = 8 // Bounds the amount the base fee can change between blocks (EIP 1559)
+	// ElasticityMultiplier ...
+	ElasticityMultiplier uint64 = 2 // Bounds the maximum gas limit an EIP-1559 block may have
+	// InitialBaseFee ...
+	InitialBaseFee uint64 = 1000000000 // Initial base fee for EIP-1559 blocks
 	// MaximumExtraDataSize ...
 	MaximumExtraDataSize uint64 = 32 // Maximum size extra data may be after Genesis.
 	// ExpByteGas ...
@@ -83,6 +89,8 @@ const (
 	SstoreCleanRefundEIP2200 uint64 = 4200 // Once per SSTORE operation for resetting to the original non-zero value
 	// SstoreClearRefundEIP2200 ...
 	SstoreClearRefundEIP2200 uint64 = 15000 // Once per SSTORE operation for clearing an originally existing storage slot
+	// SstoreClearsScheduleRefundEIP3529 ...
+	SstoreClearsScheduleRefundEIP3529 uint64 = SstoreCleanGasEIP2200 - ColdSloadCostEIP2929 + TxAccessListStorageKeyGas // Once per SSTORE operation for clearing an originally existing storage slot after EIP 3529
 
 	// JumpdestGas ...
 	JumpdestGas uint64 = 1 // Refunded gas, once per SSTORE operation if the zeroness changes to zero.
@@ -112,12 +120,20 @@ const (
 	Create2Gas uint64 = 32000 // Once per CREATE2 operation
 	// SelfdestructRefundGas ...
 	SelfdestructRefundGas uint64 = 24000 // Refunded following a selfdestruct operation.
+	// RefundQuotient ...
+	RefundQuotient uint64 = 2 // Maximum refund quotient; max gas refund is gasUsed / RefundQuotient
+	// RefundQuotientEIP3529 ...
+	RefundQuotientEIP3529 uint64 = 5 // Maximum refund quotient after EIP 3529
 	// MemoryGas ...
 	MemoryGas uint64 = 3 // Times the address of the (highest referenced byte in memory + 1). NOTE: referencing happens on read, write and in instructions such as RETURN and CALL.
 	// TxDataNonZeroGas ...
 	TxDataNonZeroGasFrontier uint64 = 68 // Per byte of data attached to a transaction that is not equal to zero. NOTE: Not payable on data of calls between transactions.
 	// TxDataNonZeroGasEIP2028 ...
 	TxDataNonZeroGasEIP2028 uint64 = 16 // Per byte of non zero data attached to a transaction after EIP 2028 (part in Istanbul)
+	// TxAccessListAddressGas ...
+	TxAccessListAddressGas uint64 = 2400 // Per address specified in an EIP 2930 access list
+	// TxAccessListStorageKeyGas ...
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in an EIP 2930 access list
 
 	// These have been changed during the course of the chain
 	CallGasFrontier              uint64 = 40  // Once per CALL operation & message call transaction.
@@ -130,6 +146,11 @@ const (
 	SloadGasFrontier             uint64 = 50
 	SloadGasEIP150               uint64 = 200
 	SloadGasEIP1884              uint64 = 800  // Cost of SLOAD after EIP 1884 (part of Istanbul)
+	TloadGasEIP1153              uint64 = 100  // Cost of TLOAD (EIP 1153)
+	TstoreGasEIP1153             uint64 = 100  // Cost of TSTORE (EIP 1153)
+	ColdAccountAccessCostEIP2929 uint64 = 2600 // Cost of a cold account access (EIP 2929)
+	ColdSloadCostEIP2929         uint64 = 2100 // Cost of a cold SLOAD (EIP 2929)
+	WarmStorageReadCostEIP2929   uint64 = 100  // Cost of a warm account or storage read (EIP 2929)
 	ExtcodeHashGasConstantinople uint64 = 400  // Cost of EXTCODEHASH (introduced in Constantinople)
 	ExtcodeHashGasEIP1884        uint64 = 700  // Cost of EXTCODEHASH after EIP 1884 (part in Istanbul)
 	SelfdestructGasEIP150        uint64 = 5000 // Cost of SELFDESTRUCT post EIP 150 (Tangerine)
@@ -175,6 +196,25 @@ const (
 	Sha3FipsGas     uint64 = 30 // Once per SHA3-256 operation.
 	Sha3FipsWordGas uint64 = 6  // Once per word of the SHA3-256 operation's data.
 
+	// BLSVerifyGas is priced as a two point pairing check, which dominates the cost
+	BLSVerifyGas uint64 = 113000 // Gas needed for a BLS12-381 signature verification
+
+	// The BLS12-381 curve arithmetic precompiles of EIP-2537
+	Bls12381G1AddGas          uint64 = 600    // Price for BLS12-381 elliptic curve G1 point addition
+	Bls12381G1MulGas          uint64 = 12000  // Price for BLS12-381 elliptic curve G1 point scalar multiplication
+	Bls12381G2AddGas          uint64 = 4500   // Price for BLS12-381 elliptic curve G2 point addition
+	Bls12381G2MulGas          uint64 = 55000  // Price for BLS12-381 elliptic curve G2 point scalar multiplication
+	Bls12381PairingBaseGas    uint64 = 115000 // Base gas price for BLS12-381 elliptic curve pairing check
+	Bls12381PairingPerPairGas uint64 = 23000  // Per-point pair gas price for BLS12-381 elliptic curve pairing check
+
+	// VRFLookupGas is charged on top of the VRF precompile gas when the VRF of a past block is requested
+	VRFLookupGas uint64 = 800 // Gas needed for a historical VRF lookup
+
+	// ShardInfoCrossLinkGas is charged on top of the shard info precompile gas when the
+	// last crosslink of a shard is requested, which reads up to ShardInfoCrossLinkWindow headers
+	ShardInfoCrossLinkGas uint64 = 3200 // Gas needed for a crosslink lookup
+	// ShardInfoCrossLinkWindow is the number of ancestor blocks searched for the last crosslink of a shard
+	ShardInfoCrossLinkWindow uint64 = 32
 )
 
 // nolint
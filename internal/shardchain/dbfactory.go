@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"time"
 
+	harmonyRawDB "github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/internal/shardchain/leveldb_shard"
 	"github.com/harmony-one/harmony/internal/shardchain/local_cache"
 
@@ -17,8 +18,12 @@ import (
 const (
 	LDBDirPrefix      = "harmony_db"
 	LDBShardDirPrefix = "harmony_sharddb"
+	FreezerDirName    = "ancient"
 )
 
+// EngineLevelDB is the key-value engine of the chain databases.
+const EngineLevelDB = "leveldb"
+
 // DBFactory is a blockchain database factory.
 type DBFactory interface {
 	// NewChainDB returns a new database for the blockchain for
@@ -28,13 +33,32 @@ type DBFactory interface {
 
 // LDBFactory is a LDB-backed blockchain database factory.
 type LDBFactory struct {
-	RootDir string // directory in which to put shard databases in.
+	RootDir          string // directory in which to put shard databases in.
+	FreezerThreshold uint64 // number of recent blocks kept out of the freezer, 0 to disable it
 }
 
 // NewChainDB returns a new LDB for the blockchain for given shard.
 func (f *LDBFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
 	dir := path.Join(f.RootDir, fmt.Sprintf("%s_%d", LDBDirPrefix, shardID))
-	return rawdb.NewLevelDBDatabase(dir, 256, 1024, "")
+	db, err := rawdb.NewLevelDBDatabase(dir, 256, 1024, "")
+	if err != nil {
+		return nil, err
+	}
+	return withFreezer(db, dir, f.FreezerThreshold)
+}
+
+// withFreezer moves the old chain data of the given database into a freezer in
+// its directory, if enabled.
+func withFreezer(db ethdb.Database, dir string, threshold uint64) (ethdb.Database, error) {
+	if threshold == 0 {
+		return db, nil
+	}
+	frdb, err := harmonyRawDB.NewDatabaseWithFreezer(db, filepath.Join(dir, FreezerDirName), threshold)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return frdb, nil
 }
 
 // MemDBFactory is a memory-backed blockchain database factory.
@@ -52,6 +76,8 @@ type LDBShardFactory struct {
 	ShardCount int
 	CacheTime  int
 	CacheSize  int
+
+	FreezerThreshold uint64 // number of recent blocks kept out of the freezer, 0 to disable it
 }
 
 // NewChainDB returns a new memDB for the blockchain for given shard.
@@ -62,8 +88,9 @@ func (f *LDBShardFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
 		return nil, err
 	}
 
-	return rawdb.NewDatabase(local_cache.NewLocalCacheDatabase(shard, local_cache.CacheConfig{
+	db := rawdb.NewDatabase(local_cache.NewLocalCacheDatabase(shard, local_cache.CacheConfig{
 		CacheTime: time.Duration(f.CacheTime) * time.Minute,
 		CacheSize: f.CacheSize,
-	})), nil
+	}))
+	return withFreezer(db, dir, f.FreezerThreshold)
 }
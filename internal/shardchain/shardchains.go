@@ -39,9 +39,19 @@ type CollectionImpl struct {
 	mtx          sync.Mutex
 	pool         map[uint32]*core.BlockChain
 	disableCache map[uint32]bool
+	statePrune   map[uint32]statePruneConfig
+	snapshot     map[uint32]int
+	stateDiff    map[uint32]bool
+	rewards      map[uint32]bool
 	chainConfig  *params.ChainConfig
 }
 
+// statePruneConfig is the background state pruning configuration of a shard chain.
+type statePruneConfig struct {
+	retain    uint64
+	bloomSize uint64
+}
+
 // NewCollection creates and returns a new shard chain collection.
 //
 // dbFactory is the shard chain database factory to use.
@@ -58,6 +68,9 @@ func NewCollection(
 		engine:       engine,
 		pool:         make(map[uint32]*core.BlockChain),
 		disableCache: make(map[uint32]bool),
+		statePrune:   make(map[uint32]statePruneConfig),
+		snapshot:     make(map[uint32]int),
+		stateDiff:    make(map[uint32]bool),
 		chainConfig:  chainConfig,
 	}
 }
@@ -97,6 +110,24 @@ func (sc *CollectionImpl) ShardChain(shardID uint32) (*core.BlockChain, error) {
 		utils.Logger().Info().
 			Uint32("shardID", shardID).
 			Msg("disable cache, running in archival mode")
+	} else if prune, ok := sc.statePrune[shardID]; ok {
+		cacheConfig = core.DefaultCacheConfig()
+		cacheConfig.PruneRetain = prune.retain
+		cacheConfig.PruneBloomSize = prune.bloomSize
+		utils.Logger().Info().
+			Uint32("shardID", shardID).
+			Uint64("retain", prune.retain).
+			Msg("enable background state pruning")
+	}
+	if cache, ok := sc.snapshot[shardID]; ok {
+		if cacheConfig == nil {
+			cacheConfig = core.DefaultCacheConfig()
+		}
+		cacheConfig.SnapshotLimit = cache
+		utils.Logger().Info().
+			Uint32("shardID", shardID).
+			Int("cache", cache).
+			Msg("enable state snapshot")
 	}
 
 	chainConfig := *sc.chainConfig
@@ -111,6 +142,18 @@ func (sc *CollectionImpl) ShardChain(shardID uint32) (*core.BlockChain, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot create blockchain")
 	}
+	if sc.stateDiff[shardID] {
+		bc.EnableStateDiffIndex()
+		utils.Logger().Info().
+			Uint32("shardID", shardID).
+			Msg("enable state diff index")
+	}
+	if sc.rewards[shardID] {
+		bc.EnableRewardHistoryIndex()
+		utils.Logger().Info().
+			Uint32("shardID", shardID).
+			Msg("enable reward history index")
+	}
 	db = nil // don't close
 	sc.pool[shardID] = bc
 	return bc, nil
@@ -126,6 +169,43 @@ func (sc *CollectionImpl) DisableCache(shardID uint32) {
 	sc.disableCache[shardID] = true
 }
 
+// EnableStatePruning enables the background pruning of the historical states for
+// newly opened chains, keeping the given number of recent states. It has no effect
+// on chains with caching disabled.
+func (sc *CollectionImpl) EnableStatePruning(shardID uint32, retain, bloomSize uint64) {
+	if sc.statePrune == nil {
+		sc.statePrune = make(map[uint32]statePruneConfig)
+	}
+	sc.statePrune[shardID] = statePruneConfig{retain: retain, bloomSize: bloomSize}
+}
+
+// EnableSnapshot enables the flat state snapshot for newly opened chains, with a
+// cache of the given size in megabytes.
+func (sc *CollectionImpl) EnableSnapshot(shardID uint32, cache int) {
+	if sc.snapshot == nil {
+		sc.snapshot = make(map[uint32]int)
+	}
+	sc.snapshot[shardID] = cache
+}
+
+// EnableStateDiffIndex enables the index of the state changes of every inserted
+// block for newly opened chains.
+func (sc *CollectionImpl) EnableStateDiffIndex(shardID uint32) {
+	if sc.stateDiff == nil {
+		sc.stateDiff = make(map[uint32]bool)
+	}
+	sc.stateDiff[shardID] = true
+}
+
+// EnableRewardHistoryIndex enables the index of the rewards paid to every
+// delegation for newly opened chains.
+func (sc *CollectionImpl) EnableRewardHistoryIndex(shardID uint32) {
+	if sc.rewards == nil {
+		sc.rewards = make(map[uint32]bool)
+	}
+	sc.rewards[shardID] = true
+}
+
 // CloseShardChain closes the given shard chain.
 func (sc *CollectionImpl) CloseShardChain(shardID uint32) error {
 	sc.mtx.Lock()
@@ -1,10 +1,15 @@
 package node
 
 import (
+	"context"
+
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/graphql"
 	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/p2p/security"
 	"github.com/harmony-one/harmony/rosetta"
 	hmy_rpc "github.com/harmony-one/harmony/rpc"
 	rpc_common "github.com/harmony-one/harmony/rpc/common"
@@ -32,11 +37,41 @@ func (node *Node) ListTopic() []string {
 	return node.host.ListTopic()
 }
 
+// NodeInfo returns the p2p identity of the node
+func (node *Node) NodeInfo() p2p.NodeInfo {
+	return node.host.NodeInfo()
+}
+
+// PeerInfos returns the information of the connected peers
+func (node *Node) PeerInfos() []p2p.PeerInfo {
+	return node.host.PeerInfos()
+}
+
+// ConnectPeer connects to the given peer
+func (node *Node) ConnectPeer(ctx context.Context, ai peer.AddrInfo) error {
+	return node.host.ConnectPeer(ctx, ai)
+}
+
+// DisconnectPeer disconnects the given peer
+func (node *Node) DisconnectPeer(id peer.ID) error {
+	return node.host.DisconnectPeer(id)
+}
+
 // ListBlockedPeer return list of blocked peers
 func (node *Node) ListBlockedPeer() []peer.ID {
 	return node.host.ListBlockedPeer()
 }
 
+// PeerScores return the scores of the known peers
+func (node *Node) PeerScores() []security.PeerScore {
+	return node.host.PeerScores()
+}
+
+// SetPeerScore overrides the score of the peer
+func (node *Node) SetPeerScore(id peer.ID, score float64) {
+	node.host.SetPeerScore(id, score)
+}
+
 // PendingCXReceipts returns node.pendingCXReceiptsProof
 func (node *Node) PendingCXReceipts() []*types.CXReceiptsProof {
 	cxReceipts := make([]*types.CXReceiptsProof, len(node.pendingCXReceipts))
@@ -66,6 +101,15 @@ func (node *Node) ReportPlainErrorSink() types.TransactionErrorReports {
 // StartRPC start RPC service
 func (node *Node) StartRPC() error {
 	harmony := hmy.New(node, node.TxPool, node.CxPool, node.Consensus.ShardID)
+	harmony.SetTraceLimits(node.NodeConfig.RPCServer.TraceTimeout, node.NodeConfig.RPCServer.MaxConcurrentTraces)
+	harmony.SetTraceCache(node.NodeConfig.RPCServer.TraceCacheSize)
+	harmony.SetTraceMaxReexec(node.NodeConfig.RPCServer.TraceMaxReexec)
+	if node.NodeConfig.RPCServer.TraceIndex {
+		harmony.StartTraceBloomIndexer()
+		node.traceIndexed.Store(harmony)
+	}
+	harmony.SetRPCGasCap(node.NodeConfig.RPCServer.GasCap)
+	node.registerRuntimeConfig(harmony, true, true)
 
 	// Gather all the possible APIs to surface
 	apis := node.APIs(harmony)
@@ -81,6 +125,8 @@ func (node *Node) StopRPC() error {
 // StartRosetta start rosetta service
 func (node *Node) StartRosetta() error {
 	harmony := hmy.New(node, node.TxPool, node.CxPool, node.Consensus.ShardID)
+	harmony.SetRPCGasCap(node.NodeConfig.RPCServer.GasCap)
+	node.registerRuntimeConfig(harmony, true, false)
 	return rosetta.StartServers(harmony, node.NodeConfig.RosettaServer, node.NodeConfig.RPCServer.RateLimiterEnabled, node.NodeConfig.RPCServer.RequestsPerSecond)
 }
 
@@ -89,17 +135,35 @@ func (node *Node) StopRosetta() error {
 	return rosetta.StopServers()
 }
 
+// StartGraphQL start graphql service
+func (node *Node) StartGraphQL() error {
+	harmony := hmy.New(node, node.TxPool, node.CxPool, node.Consensus.ShardID)
+	harmony.SetTraceLimits(node.NodeConfig.RPCServer.TraceTimeout, node.NodeConfig.RPCServer.MaxConcurrentTraces)
+	harmony.SetTraceMaxReexec(node.NodeConfig.RPCServer.TraceMaxReexec)
+	node.registerRuntimeConfig(harmony, false, true)
+	return graphql.StartServers(harmony, node.NodeConfig.GraphQLServer)
+}
+
+// StopGraphQL stops graphql service
+func (node *Node) StopGraphQL() error {
+	return graphql.StopServers()
+}
+
 // APIs return the collection of local RPC services.
 // NOTE, some of these services probably need to be moved to somewhere else.
 func (node *Node) APIs(harmony *hmy.Harmony) []rpc.API {
+	logsLimits := filters.LogsLimits{
+		BlockRange:  node.NodeConfig.RPCServer.LogsBlockRange,
+		ResultLimit: node.NodeConfig.RPCServer.LogsResultLimit,
+	}
 	// Append all the local APIs and return
 	return []rpc.API{
 		hmy_rpc.NewPublicNetAPI(node.host, harmony.ChainID, hmy_rpc.V1),
 		hmy_rpc.NewPublicNetAPI(node.host, harmony.ChainID, hmy_rpc.V2),
 		hmy_rpc.NewPublicNetAPI(node.host, harmony.ChainID, hmy_rpc.Eth),
 		hmy_rpc.NewPublicWeb3API(),
-		filters.NewPublicFilterAPI(harmony, false, "hmy"),
-		filters.NewPublicFilterAPI(harmony, false, "eth"),
+		filters.NewPublicFilterAPI(harmony, false, "hmy", logsLimits),
+		filters.NewPublicFilterAPI(harmony, false, "eth", logsLimits),
 	}
 }
 
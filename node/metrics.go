@@ -70,6 +70,19 @@ var (
 		},
 	)
 
+	// nodeCXBroadcastCounterVec is used to keep track of the cross-shard receipts proofs sent
+	nodeCXBroadcastCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "p2p",
+			Name:      "cx_broadcast",
+			Help:      "number of cross-shard receipts proofs sent",
+		},
+		[]string{
+			"type",
+		},
+	)
+
 	onceMetrics sync.Once
 )
 
@@ -81,6 +94,7 @@ func initMetrics() {
 			nodeConsensusMessageCounterVec,
 			nodeNodeMessageCounterVec,
 			nodeCrossLinkMessageCounterVec,
+			nodeCXBroadcastCounterVec,
 		)
 	})
 }
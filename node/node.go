@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -43,6 +45,7 @@ import (
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/node/worker"
 	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/p2p/security"
 	"github.com/harmony-one/harmony/shard"
 	"github.com/harmony-one/harmony/shard/committee"
 	"github.com/harmony-one/harmony/staking/reward"
@@ -100,6 +103,8 @@ type Node struct {
 	SyncingPeerProvider    SyncingPeerProvider
 	// The p2p host used to send/receive p2p messages
 	host p2p.Host
+	// cxBroadcaster sends the cross-shard receipts to the destination shards
+	cxBroadcaster *cxBroadcaster
 	// Service manager.
 	serviceManager               *service.Manager
 	ContractDeployerCurrentNonce uint64 // The nonce of the deployer contract at current block
@@ -107,6 +112,10 @@ type Node struct {
 	// Channel to notify consensus service to really start consensus
 	startConsensus chan struct{}
 	HarmonyConfig  *harmonyconfig.HarmonyConfig
+	// runtimeConfig holds the settings which can be changed while running
+	runtimeConfig runtimeConfig
+	// traceIndexed holds the *hmy.Harmony of the RPC service building the trace index
+	traceIndexed atomic.Value
 	// node configuration, including group ID, shard ID, etc
 	NodeConfig *nodeconfig.ConfigType
 	// Chain configuration.
@@ -201,8 +210,9 @@ func (node *Node) tryBroadcastStaking(stakingTx *staking.StakingTransaction) {
 	}
 }
 
-// Add new transactions to the pending transaction list.
-func (node *Node) addPendingTransactions(newTxs types.Transactions) []error {
+// Add new transactions to the pending transaction list. The transactions submitted
+// to this node are journaled to survive restarts.
+func (node *Node) addPendingTransactions(newTxs types.Transactions, submitted bool) []error {
 	if inSync, _, _ := node.SyncStatus(node.Blockchain().ShardID()); !inSync && node.NodeConfig.GetNetworkType() == nodeconfig.Mainnet {
 		utils.Logger().Debug().
 			Int("length of newTxs", len(newTxs)).
@@ -223,7 +233,11 @@ func (node *Node) addPendingTransactions(newTxs types.Transactions) []error {
 		}
 		poolTxs = append(poolTxs, tx)
 	}
-	errs = append(errs, node.TxPool.AddRemotes(poolTxs)...)
+	if submitted {
+		errs = append(errs, node.TxPool.AddSubmitted(poolTxs)...)
+	} else {
+		errs = append(errs, node.TxPool.AddRemotes(poolTxs)...)
+	}
 
 	pendingCount, queueCount := node.TxPool.Stats()
 	utils.Logger().Debug().
@@ -235,15 +249,21 @@ func (node *Node) addPendingTransactions(newTxs types.Transactions) []error {
 	return errs
 }
 
-// Add new staking transactions to the pending staking transaction list.
-func (node *Node) addPendingStakingTransactions(newStakingTxs staking.StakingTransactions) []error {
+// Add new staking transactions to the pending staking transaction list. The
+// transactions submitted to this node are journaled to survive restarts.
+func (node *Node) addPendingStakingTransactions(newStakingTxs staking.StakingTransactions, submitted bool) []error {
 	if node.IsRunningBeaconChain() {
 		if node.Blockchain().Config().IsPreStaking(node.Blockchain().CurrentHeader().Epoch()) {
 			poolTxs := types.PoolTransactions{}
 			for _, tx := range newStakingTxs {
 				poolTxs = append(poolTxs, tx)
 			}
-			errs := node.TxPool.AddRemotes(poolTxs)
+			var errs []error
+			if submitted {
+				errs = node.TxPool.AddSubmitted(poolTxs)
+			} else {
+				errs = node.TxPool.AddRemotes(poolTxs)
+			}
 			pendingCount, queueCount := node.TxPool.Stats()
 			utils.Logger().Info().
 				Int("length of newStakingTxs", len(poolTxs)).
@@ -266,7 +286,7 @@ func (node *Node) AddPendingStakingTransaction(
 	newStakingTx *staking.StakingTransaction,
 ) error {
 	if node.IsRunningBeaconChain() {
-		errs := node.addPendingStakingTransactions(staking.StakingTransactions{newStakingTx})
+		errs := node.addPendingStakingTransactions(staking.StakingTransactions{newStakingTx}, true)
 		var err error
 		for i := range errs {
 			if errs[i] != nil {
@@ -292,7 +312,7 @@ func (node *Node) AddPendingStakingTransaction(
 // This is only called from SDK.
 func (node *Node) AddPendingTransaction(newTx *types.Transaction) error {
 	if newTx.ShardID() == node.NodeConfig.ShardID {
-		errs := node.addPendingTransactions(types.Transactions{newTx})
+		errs := node.addPendingTransactions(types.Transactions{newTx}, true)
 		var err error
 		for i := range errs {
 			if errs[i] != nil {
@@ -390,6 +410,9 @@ var (
 	errIgnoreBeaconMsg   = errors.New("ignore beacon sync block")
 	errInvalidEpoch      = errors.New("invalid epoch for transaction")
 	errInvalidShard      = errors.New("invalid shard")
+
+	errInvalidBlockMsg           = errors.New("invalid block message")
+	errBeaconBlockOutOfTolerance = errors.New("beacon block height out of tolerance")
 )
 
 const beaconBlockHeightTolerance = 2
@@ -425,7 +448,7 @@ func (node *Node) validateNodeMessage(ctx context.Context, payload []byte) (
 			blocksPayload := payload[p2pNodeMsgPrefixSize+1:]
 			var blocks []*types.Block
 			if err := rlp.DecodeBytes(blocksPayload, &blocks); err != nil {
-				return nil, 0, errors.Wrap(err, "block decode error")
+				return nil, 0, errors.Wrapf(errInvalidBlockMsg, "block decode error: %v", err)
 			}
 			curBeaconHeight := node.Beaconchain().CurrentBlock().NumberU64()
 			for _, block := range blocks {
@@ -433,11 +456,11 @@ func (node *Node) validateNodeMessage(ctx context.Context, payload []byte) (
 				if block.NumberU64()+beaconBlockHeightTolerance <= curBeaconHeight {
 					utils.Logger().Debug().Uint64("receivedNum", block.NumberU64()).
 						Uint64("currentNum", curBeaconHeight).Msg("beacon block sync message rejected")
-					return nil, 0, errors.New("beacon block height smaller than current height beyond tolerance")
+					return nil, 0, errors.Wrap(errBeaconBlockOutOfTolerance, "smaller than current height")
 				} else if block.NumberU64()-beaconBlockHeightTolerance > curBeaconHeight {
 					utils.Logger().Debug().Uint64("receivedNum", block.NumberU64()).
 						Uint64("currentNum", curBeaconHeight).Msg("beacon block sync message rejected")
-					return nil, 0, errors.New("beacon block height too much higher than current height beyond tolerance")
+					return nil, 0, errors.Wrap(errBeaconBlockOutOfTolerance, "higher than current height")
 				} else if block.NumberU64() <= curBeaconHeight {
 					utils.Logger().Debug().Uint64("receivedNum", block.NumberU64()).
 						Uint64("currentNum", curBeaconHeight).Msg("beacon block sync message ignored")
@@ -477,6 +500,27 @@ func (node *Node) validateNodeMessage(ctx context.Context, payload []byte) (
 	return payload[p2pNodeMsgPrefixSize:], msgType, nil
 }
 
+// nodeMessageEvent returns the misbehaviour of a peer relaying a node message
+// failing the validation with the given error
+func nodeMessageEvent(err error) security.PeerEvent {
+	switch {
+	case errors.Is(err, errInvalidBlockMsg):
+		return security.InvalidBlock
+	case errors.Is(err, errBeaconBlockOutOfTolerance):
+		return security.UselessGossip
+	}
+	return security.ProtocolViolation
+}
+
+// consensusMessageEvent returns the misbehaviour of a peer relaying a consensus
+// message failing the validation with the given error
+func consensusMessageEvent(err error) security.PeerEvent {
+	if errors.Is(err, errViewIDTooOld) {
+		return security.UselessGossip
+	}
+	return security.ProtocolViolation
+}
+
 // validateShardBoundMessage validate consensus message
 // validate shardID
 // validate public key size
@@ -611,6 +655,7 @@ var (
 // StartPubSub kicks off the node message handling
 func (node *Node) StartPubSub() error {
 	node.psCtx, node.psCancel = context.WithCancel(context.Background())
+	go node.cxBroadcaster.run(node.psCtx)
 
 	// groupID and whether this topic is used for consensus
 	type t struct {
@@ -628,10 +673,15 @@ func (node *Node) StartPubSub() error {
 			groups[t.tp] = t.isCon
 		}
 	}
+	// the cross-shard receipts are received on a topic of their own, so that they
+	// are handled apart from the transaction gossip
+	cxGroupID := nodeconfig.NewCXGroupIDByShardID(nodeconfig.ShardID(node.NodeConfig.ShardID))
+	groups[cxGroupID] = false
 
 	type u struct {
 		p2p.NamedTopic
 		consensusBound bool
+		cxBound        bool
 	}
 
 	var allTopics []u
@@ -651,6 +701,7 @@ func (node *Node) StartPubSub() error {
 				allTopics, u{
 					NamedTopic:     p2p.NamedTopic{string(key), topicHandle},
 					consensusBound: isCon,
+					cxBound:        key == cxGroupID,
 				},
 			)
 		}
@@ -695,6 +746,7 @@ func (node *Node) StartPubSub() error {
 
 		topicNamed := allTopics[i].Name
 		isConsensusBound := allTopics[i].consensusBound
+		isCXBound := allTopics[i].cxBound
 
 		utils.Logger().Info().
 			Str("topic", topicNamed).
@@ -710,7 +762,7 @@ func (node *Node) StartPubSub() error {
 
 				// first to validate the size of the p2p message
 				if len(hmyMsg) < p2pMsgPrefixSize {
-					// TODO (lc): block peers sending empty messages
+					node.host.ReportPeer(peer, security.ProtocolViolation)
 					nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "invalid_size"}).Inc()
 					return libp2p_pubsub.ValidationReject
 				}
@@ -722,6 +774,7 @@ func (node *Node) StartPubSub() error {
 				case proto.Consensus:
 					// received consensus message in non-consensus bound topic
 					if !isConsensusBound {
+						node.host.ReportPeer(peer, security.ProtocolViolation)
 						nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "invalid_bound"}).Inc()
 						errChan <- withError{
 							errors.WithStack(errConsensusMessageOnUnexpectedTopic), msg,
@@ -736,6 +789,7 @@ func (node *Node) StartPubSub() error {
 					)
 
 					if err != nil {
+						node.host.ReportPeer(peer, consensusMessageEvent(err))
 						errChan <- withError{err, msg.GetFrom()}
 						return libp2p_pubsub.ValidationReject
 					}
@@ -756,9 +810,16 @@ func (node *Node) StartPubSub() error {
 				case proto.Node:
 					// node message is almost empty
 					if len(openBox) <= p2pNodeMsgPrefixSize {
+						node.host.ReportPeer(peer, security.ProtocolViolation)
 						nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "invalid_size"}).Inc()
 						return libp2p_pubsub.ValidationReject
 					}
+					// only the cross-shard receipts are handled on the CX topic, other
+					// node messages are dropped without penalizing the sender
+					if isCXBound && !isCXReceiptsMessage(openBox) {
+						nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "ignored_cx_bound"}).Inc()
+						return libp2p_pubsub.ValidationIgnore
+					}
 					nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "node_total"}).Inc()
 					validMsg, actionType, err := node.validateNodeMessage(
 						context.TODO(), openBox,
@@ -770,7 +831,7 @@ func (node *Node) StartPubSub() error {
 							// but propogate the messages to other nodes
 							return libp2p_pubsub.ValidationAccept
 						default:
-							// TODO (lc): block peers sending error messages
+							node.host.ReportPeer(peer, nodeMessageEvent(err))
 							errChan <- withError{err, msg.GetFrom()}
 							return libp2p_pubsub.ValidationReject
 						}
@@ -784,6 +845,7 @@ func (node *Node) StartPubSub() error {
 					return libp2p_pubsub.ValidationAccept
 				default:
 					// ignore garbled messages
+					node.host.ReportPeer(peer, security.ProtocolViolation)
 					nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "ignored"}).Inc()
 					return libp2p_pubsub.ValidationReject
 				}
@@ -979,6 +1041,9 @@ func New(
 		node.NodeConfig = nodeconfig.GetDefaultConfig()
 	}
 	node.HarmonyConfig = harmonyconfig
+	if harmonyconfig != nil {
+		node.runtimeConfig.config = harmonyconfig.RuntimeConfig()
+	}
 
 	copy(node.syncID[:], GenerateRandomString(SyncIDLength))
 	if host != nil {
@@ -999,6 +1064,19 @@ func New(
 	for shardID, archival := range isArchival {
 		if archival {
 			collection.DisableCache(shardID)
+		} else if harmonyconfig != nil && harmonyconfig.StatePrune.Enabled {
+			collection.EnableStatePruning(
+				shardID, harmonyconfig.StatePrune.Retain, harmonyconfig.StatePrune.BloomSize,
+			)
+		}
+		if harmonyconfig != nil && harmonyconfig.Snapshot.Enabled {
+			collection.EnableSnapshot(shardID, harmonyconfig.Snapshot.Cache)
+		}
+		if harmonyconfig != nil && harmonyconfig.StateDiff.Enabled {
+			collection.EnableStateDiffIndex(shardID)
+		}
+		if harmonyconfig != nil && harmonyconfig.RewardHistory.Enabled {
+			collection.EnableRewardHistoryIndex(shardID)
 		}
 	}
 	node.shardChains = collection
@@ -1036,12 +1114,23 @@ func New(
 		}
 		if harmonyconfig != nil {
 			txPoolConfig.AccountSlots = harmonyconfig.TxPool.AccountSlots
+			txPoolConfig.Journal = harmonyconfig.TxPool.Journal
+			// the rejournal interval is checked by validateHarmonyConfig
+			if rejournal, err := time.ParseDuration(harmonyconfig.TxPool.Rejournal); err == nil {
+				txPoolConfig.Rejournal = rejournal
+			}
+			if harmonyconfig.TxPool.PriceBump != 0 {
+				txPoolConfig.PriceBump = harmonyconfig.TxPool.PriceBump
+			}
 		}
 
 		txPoolConfig.Blacklist = blacklist
-		txPoolConfig.Journal = fmt.Sprintf("%v/%v", node.NodeConfig.DBDir, txPoolConfig.Journal)
+		if txPoolConfig.Journal != "" && !filepath.IsAbs(txPoolConfig.Journal) {
+			txPoolConfig.Journal = filepath.Join(node.NodeConfig.DBDir, txPoolConfig.Journal)
+		}
 		node.TxPool = core.NewTxPool(txPoolConfig, node.Blockchain().Config(), blockchain, node.TransactionErrorSink)
 		node.CxPool = core.NewCxPool(core.CxPoolSize)
+		node.cxBroadcaster = newCXBroadcaster(host.SendMessageToGroups)
 		node.Worker = worker.New(node.Blockchain().Config(), blockchain, engine)
 
 		node.deciderCache, _ = lru.New(16)
@@ -1101,6 +1190,20 @@ func New(
 		}()
 	}
 
+	// Notify the double signs of the keys of this node detected by consensus
+	if node.Consensus != nil {
+		go func() {
+			for alert := range node.Consensus.DoubleSignAlertChan {
+				if hooks := node.NodeConfig.WebHooks.Hooks; hooks != nil {
+					if s := hooks.Slashing; s != nil && s.OnOwnKeyDoubleSign != "" {
+						url, alert := s.OnOwnKeyDoubleSign, alert
+						go func() { webhooks.DoPost(url, &alert) }()
+					}
+				}
+			}
+		}()
+	}
+
 	// update reward values now that node is ready
 	node.updateInitialRewardValues()
 
@@ -1249,6 +1352,11 @@ func (node *Node) ShutDown() {
 		utils.Logger().Error().Err(err).Msg("failed to stop rosetta")
 	}
 
+	utils.Logger().Info().Msg("stopping graphql")
+	if err := node.StopGraphQL(); err != nil {
+		utils.Logger().Error().Err(err).Msg("failed to stop graphql")
+	}
+
 	utils.Logger().Info().Msg("stopping services")
 	if err := node.StopServices(); err != nil {
 		utils.Logger().Error().Err(err).Msg("failed to stop services")
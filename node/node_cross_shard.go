@@ -3,10 +3,10 @@ package node
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/api/proto"
 	proto_node "github.com/harmony-one/harmony/api/proto/node"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/types"
-	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/p2p"
 	"github.com/harmony-one/harmony/shard"
@@ -77,17 +77,22 @@ func (node *Node) BroadcastCXReceiptsWithShardID(block *types.Block, commitSig [
 		CommitBitmap: commitBitmap,
 	}
 
-	groupID := nodeconfig.NewGroupIDByShardID(nodeconfig.ShardID(toShardID))
 	utils.Logger().Info().Uint32("ToShardID", toShardID).
-		Str("GroupID", string(groupID)).
 		Interface("cxp", cxReceiptsProof).
 		Msg("[BroadcastCXReceiptsWithShardID] ReadCXReceipts and MerkleProof ready. Sending CX receipts...")
-	// TODO ek – limit concurrency
-	go node.host.SendMessageToGroups([]nodeconfig.GroupID{groupID},
+	node.cxBroadcaster.add(block.NumberU64(), block.Hash(), toShardID,
 		p2p.ConstructMessage(proto_node.ConstructCXReceiptsProof(cxReceiptsProof)),
+		node.Blockchain().Config().IsCXTopic(block.Epoch()),
 	)
 }
 
+// isCXReceiptsMessage returns whether the node message carries a cross-shard
+// receipts proof
+func isCXReceiptsMessage(payload []byte) bool {
+	return proto_node.MessageType(payload[proto.MessageCategoryBytes]) == proto_node.Block &&
+		proto_node.BlockMessageType(payload[p2pNodeMsgPrefixSize]) == proto_node.Receipt
+}
+
 // BroadcastMissingCXReceipts broadcasts missing cross shard receipts per request
 func (node *Node) BroadcastMissingCXReceipts() {
 	sendNextTime := []core.CxEntry{}
@@ -0,0 +1,205 @@
+package node
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// cxQueueSize is the maximum number of cross-shard receipts proofs tracked for
+	// sending and retransmission
+	cxQueueSize = 1024
+	// cxSendAttempts is the number of times a cross-shard receipts proof is sent
+	cxSendAttempts = 3
+	// cxRetransmitInterval is the interval between two sends of a proof
+	cxRetransmitInterval = 10 * time.Second
+)
+
+// cxSendFunc sends the message to the given groups
+type cxSendFunc func(groups []nodeconfig.GroupID, msg []byte) error
+
+// cxBroadcaster sends the cross-shard receipts proofs on the dedicated CX topics of
+// the destination shards. The proofs are sent by a worker of their own, the proofs
+// of the oldest blocks first, so that they are not delayed by the transaction gossip.
+// Each proof is retransmitted a few times, since a lost proof otherwise stays
+// pending until the destination shard requests the missing receipts.
+type cxBroadcaster struct {
+	send     cxSendFunc
+	interval time.Duration
+
+	queue   cxSendQueue
+	tracked map[cxSendKey]*cxSend
+	notifyC chan struct{}
+	lock    sync.Mutex
+}
+
+type cxSendKey struct {
+	blockHash common.Hash
+	toShardID uint32
+}
+
+// cxSend is a cross-shard receipts proof to be sent to a shard
+type cxSend struct {
+	key      cxSendKey
+	blockNum uint64
+	msg      []byte
+	cxOnly   bool // whether the shard topic is no longer used
+	attempts int
+	queued   bool
+}
+
+func newCXBroadcaster(send cxSendFunc) *cxBroadcaster {
+	return &cxBroadcaster{
+		send:     send,
+		interval: cxRetransmitInterval,
+		tracked:  make(map[cxSendKey]*cxSend),
+		notifyC:  make(chan struct{}, 1),
+	}
+}
+
+// add queues the proof of the block for the shard. A proof already tracked gets its
+// retransmission attempts reset. Unless cxOnly is set, the proof is also sent on the
+// shard topic, for the nodes not subscribed to the CX topic yet.
+func (b *cxBroadcaster) add(blockNum uint64, blockHash common.Hash, toShardID uint32, msg []byte, cxOnly bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := cxSendKey{blockHash, toShardID}
+	if s, ok := b.tracked[key]; ok {
+		s.attempts = 0
+		nodeCXBroadcastCounterVec.With(prometheus.Labels{"type": "renewed"}).Inc()
+		return
+	}
+	if len(b.tracked) >= cxQueueSize {
+		nodeCXBroadcastCounterVec.With(prometheus.Labels{"type": "dropped"}).Inc()
+		utils.Logger().Warn().Uint64("blockNum", blockNum).Uint32("toShardID", toShardID).
+			Msg("[cxBroadcaster] queue full, cross-shard receipts dropped")
+		return
+	}
+	s := &cxSend{
+		key:      key,
+		blockNum: blockNum,
+		msg:      msg,
+		cxOnly:   cxOnly,
+	}
+	b.tracked[key] = s
+	b.push(s)
+}
+
+// run sends the queued proofs until the context is done
+func (b *cxBroadcaster) run(ctx context.Context) {
+	for {
+		if s := b.pop(); s != nil {
+			b.sendOne(s)
+			continue
+		}
+		select {
+		case <-b.notifyC:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *cxBroadcaster) sendOne(s *cxSend) {
+	b.lock.Lock()
+	s.attempts++
+	retransmit := s.attempts > 1
+	done := s.attempts >= cxSendAttempts
+	if done {
+		delete(b.tracked, s.key)
+	}
+	b.lock.Unlock()
+
+	toShardID := nodeconfig.ShardID(s.key.toShardID)
+	groups := []nodeconfig.GroupID{nodeconfig.NewCXGroupIDByShardID(toShardID)}
+	if !s.cxOnly {
+		groups = append(groups, nodeconfig.NewGroupIDByShardID(toShardID))
+	}
+	if err := b.send(groups, s.msg); err != nil {
+		nodeCXBroadcastCounterVec.With(prometheus.Labels{"type": "failed"}).Inc()
+		utils.Logger().Warn().Err(err).Uint64("blockNum", s.blockNum).
+			Uint32("toShardID", s.key.toShardID).Msg("[cxBroadcaster] failed to send cross-shard receipts")
+	} else if retransmit {
+		nodeCXBroadcastCounterVec.With(prometheus.Labels{"type": "retransmitted"}).Inc()
+	} else {
+		nodeCXBroadcastCounterVec.With(prometheus.Labels{"type": "sent"}).Inc()
+	}
+	if !done {
+		time.AfterFunc(b.interval, func() { b.requeue(s) })
+	}
+}
+
+// requeue queues the proof for retransmission if it is still tracked
+func (b *cxBroadcaster) requeue(s *cxSend) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.tracked[s.key] != s || s.queued {
+		return
+	}
+	b.push(s)
+}
+
+func (b *cxBroadcaster) push(s *cxSend) {
+	s.queued = true
+	heap.Push(&b.queue, s)
+	select {
+	case b.notifyC <- struct{}{}:
+	default:
+	}
+}
+
+func (b *cxBroadcaster) pop() *cxSend {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.queue.Len() == 0 {
+		return nil
+	}
+	s := heap.Pop(&b.queue).(*cxSend)
+	s.queued = false
+	return s
+}
+
+// numTracked returns the number of proofs pending to be sent or retransmitted
+func (b *cxBroadcaster) numTracked() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return len(b.tracked)
+}
+
+// cxSendQueue is a priority queue of the proofs by ascending block number
+type cxSendQueue []*cxSend
+
+func (q cxSendQueue) Len() int { return len(q) }
+
+func (q cxSendQueue) Less(i, j int) bool {
+	if q[i].blockNum != q[j].blockNum {
+		return q[i].blockNum < q[j].blockNum
+	}
+	return q[i].key.toShardID < q[j].key.toShardID
+}
+
+func (q cxSendQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *cxSendQueue) Push(x interface{}) {
+	*q = append(*q, x.(*cxSend))
+}
+
+func (q *cxSendQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	s := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return s
+}
@@ -0,0 +1,80 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+)
+
+type cxSent struct {
+	groups []nodeconfig.GroupID
+	msg    string
+}
+
+func TestCXBroadcaster(t *testing.T) {
+	sentC := make(chan cxSent, 100)
+	b := newCXBroadcaster(func(groups []nodeconfig.GroupID, msg []byte) error {
+		sentC <- cxSent{groups, string(msg)}
+		return nil
+	})
+	b.interval = 50 * time.Millisecond
+
+	// queued before the worker starts, sent by ascending block number
+	b.add(12, common.Hash{12}, 1, []byte("12"), true)
+	b.add(10, common.Hash{10}, 2, []byte("10-2"), false)
+	b.add(10, common.Hash{10}, 1, []byte("10-1"), false)
+	b.add(10, common.Hash{10}, 1, []byte("10-1"), false)
+	// the proofs of the blocks before the CX topic epoch also go to the shard topic
+	expGroups := map[string]int{"10-1": 2, "10-2": 2, "12": 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.run(ctx)
+
+	expFirst := []string{"10-1", "10-2", "12"}
+	for i, exp := range expFirst {
+		sent := waitCXSent(t, sentC)
+		if sent.msg != exp {
+			t.Fatalf("send %v: unexpected message %v / %v", i, sent.msg, exp)
+		}
+		if len(sent.groups) != expGroups[sent.msg] {
+			t.Errorf("send %v: unexpected groups %v", i, sent.groups)
+		}
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < len(expFirst)*(cxSendAttempts-1); i++ {
+		sent := waitCXSent(t, sentC)
+		if len(sent.groups) != expGroups[sent.msg] {
+			t.Errorf("retransmit %v: unexpected groups %v", i, sent.groups)
+		}
+		counts[sent.msg]++
+	}
+	for _, msg := range expFirst {
+		if counts[msg] != cxSendAttempts-1 {
+			t.Errorf("message %v retransmitted %v times, expect %v", msg, counts[msg], cxSendAttempts-1)
+		}
+	}
+
+	select {
+	case sent := <-sentC:
+		t.Errorf("unexpected send after the last attempt: %v", sent.msg)
+	case <-time.After(3 * b.interval):
+	}
+	if n := b.numTracked(); n != 0 {
+		t.Errorf("unexpected tracked proofs after the last attempt: %v", n)
+	}
+}
+
+func waitCXSent(t *testing.T, sentC chan cxSent) cxSent {
+	select {
+	case sent := <-sentC:
+		return sent
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for cross-shard receipts to be sent")
+	}
+	return cxSent{}
+}
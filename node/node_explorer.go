@@ -171,17 +171,27 @@ func (node *Node) commitBlockForExplorer(block *types.Block) {
 	}
 }
 
-// GetTransactionsHistory returns list of transactions hashes of address.
+// GetTransactionsHistory returns list of transactions hashes of address. The INTERNAL
+// type lists the transactions having transferred ONE to or from the address in an
+// internal call.
 func (node *Node) GetTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
 	exp, err := node.getExplorerService()
 	if err != nil {
 		return nil, err
 	}
-	allTxs, tts, err := exp.GetNormalTxHashesByAccount(address)
-	if err != nil {
-		return nil, err
+	var txs []common.Hash
+	if txType == internalTxType {
+		txs, _, err = exp.GetInternalTxHashesByAccount(address)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		allTxs, tts, err := exp.GetNormalTxHashesByAccount(address)
+		if err != nil {
+			return nil, err
+		}
+		txs = getTargetTxHashes(allTxs, tts, txType)
 	}
-	txs := getTargetTxHashes(allTxs, tts, txType)
 
 	if order == "DESC" {
 		reverseTxs(txs)
@@ -213,6 +223,13 @@ func (node *Node) GetTransactionsCount(address, txType string) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if txType == internalTxType {
+		txs, _, err := exp.GetInternalTxHashesByAccount(address)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(len(txs)), nil
+	}
 	_, tts, err := exp.GetNormalTxHashesByAccount(address)
 	if err != nil {
 		return 0, err
@@ -253,6 +270,10 @@ func (node *Node) getExplorerService() (*explorer.Service, error) {
 	return rawService.(*explorer.Service), nil
 }
 
+// internalTxType is the transaction history type of the internal transactions, indexed
+// apart from the normal transactions
+const internalTxType = "INTERNAL"
+
 func isTargetTxType(tt explorer.TxType, target string) bool {
 	return target == "" || target == "ALL" || target == tt.String()
 }
@@ -112,7 +112,7 @@ func (node *Node) transactionMessageHandler(msgPayload []byte) {
 				Msg("Failed to deserialize transaction list")
 			return
 		}
-		node.addPendingTransactions(txs)
+		node.addPendingTransactions(txs, false)
 	}
 }
 
@@ -129,7 +129,7 @@ func (node *Node) stakingMessageHandler(msgPayload []byte) {
 				Msg("Failed to deserialize staking transaction list")
 			return
 		}
-		node.addPendingStakingTransactions(txs)
+		node.addPendingStakingTransactions(txs, false)
 	}
 }
 
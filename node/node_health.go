@@ -0,0 +1,48 @@
+package node
+
+import (
+	"time"
+
+	"github.com/harmony-one/harmony/api/service/health"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+// HealthStatus returns the status of the node reported by the health service
+func (node *Node) HealthStatus() health.Status {
+	chains := []*core.BlockChain{node.Blockchain()}
+	if !node.IsRunningBeaconChain() {
+		chains = append(chains, node.Beaconchain())
+	}
+
+	var status health.Status
+	for _, bc := range chains {
+		inSync, target, lag := node.SyncStatus(bc.ShardID())
+		head := bc.CurrentHeader()
+		status.Shards = append(status.Shards, health.ShardStatus{
+			ShardID:   bc.ShardID(),
+			InSync:    inSync,
+			Target:    target,
+			Lag:       lag,
+			Head:      head.Number().Uint64(),
+			BlockTime: time.Unix(head.Time().Int64(), 0),
+		})
+	}
+	if node.host != nil {
+		status.Peers = node.host.GetPeerCount()
+	}
+	status.TxPoolPending, status.TxPoolQueued = node.TxPool.Stats()
+	if harmony, ok := node.traceIndexed.Load().(*hmy.Harmony); ok {
+		if lag, ok := harmony.TraceIndexLag(); ok {
+			status.TraceIndexLag = &lag
+		}
+	}
+	free, total, err := health.DiskUsage(node.NodeConfig.DBDir)
+	if err != nil {
+		utils.Logger().Debug().Err(err).Str("dir", node.NodeConfig.DBDir).Msg("cannot read disk usage")
+	} else {
+		status.DiskFree, status.DiskTotal = free, total
+	}
+	return status
+}
@@ -0,0 +1,76 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/harmony-one/harmony/hmy"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	"github.com/harmony-one/harmony/internal/utils"
+	hmy_rpc "github.com/harmony-one/harmony/rpc"
+)
+
+// runtimeConfig holds the settings which can be changed while the node is running,
+// and the harmony instances of the RPC services applying them.
+type runtimeConfig struct {
+	config harmonyconfig.RuntimeConfig
+	// gasCapped are the instances applying the RPC gas cap
+	gasCapped []*hmy.Harmony
+	// traceLimited are the instances applying the trace timeout and concurrency
+	traceLimited []*hmy.Harmony
+	lock         sync.Mutex
+}
+
+// RuntimeConfig returns the current runtime settings of the node
+func (node *Node) RuntimeConfig() harmonyconfig.RuntimeConfig {
+	node.runtimeConfig.lock.Lock()
+	defer node.runtimeConfig.lock.Unlock()
+
+	return node.runtimeConfig.config
+}
+
+// SetRuntimeConfig applies the runtime settings to the running services. The node
+// keeps running, consensus participation is not interrupted.
+func (node *Node) SetRuntimeConfig(cfg harmonyconfig.RuntimeConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	rc := &node.runtimeConfig
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	// the trace timeout is already checked by Validate
+	traceTimeout, _ := time.ParseDuration(cfg.TraceTimeout)
+	utils.SetLogVerbosity(log.Lvl(cfg.LogVerbosity))
+	for _, harmony := range rc.gasCapped {
+		harmony.SetRPCGasCap(cfg.GasCap)
+	}
+	for _, harmony := range rc.traceLimited {
+		harmony.SetTraceLimits(traceTimeout, cfg.MaxConcurrentTraces)
+	}
+	hmy_rpc.SetRequestsPerSecond(cfg.RequestsPerSecond)
+	hmy_rpc.SetMethodRateLimits(cfg.MethodRateLimits)
+	if node.host != nil {
+		node.host.SetMaxConnPerIP(cfg.MaxConnsPerIP)
+	}
+	rc.config = cfg
+
+	utils.Logger().Info().Interface("config", cfg).Msg("runtime config applied")
+	return nil
+}
+
+// registerRuntimeConfig registers the harmony instance of an RPC service to
+// receive the changes of the runtime settings
+func (node *Node) registerRuntimeConfig(harmony *hmy.Harmony, gasCap, traceLimits bool) {
+	rc := &node.runtimeConfig
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	if gasCap {
+		rc.gasCapped = append(rc.gasCapped, harmony)
+	}
+	if traceLimits {
+		rc.traceLimited = append(rc.traceLimited, harmony)
+	}
+}
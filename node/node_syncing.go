@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 
 	"github.com/harmony-one/harmony/api/service"
@@ -23,6 +24,7 @@ import (
 	"github.com/harmony-one/harmony/api/service/synchronize"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/verify"
 	"github.com/harmony-one/harmony/hmy/downloader"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
@@ -843,6 +845,40 @@ func (node *Node) SyncPeers() map[string]int {
 	return res
 }
 
+// SyncStreamPeers returns the peers serving the sync streams of each shard
+func (node *Node) SyncStreamPeers() map[uint32][]peer.ID {
+	ds := node.getDownloaders()
+	if ds == nil {
+		return nil
+	}
+	res := make(map[uint32][]peer.ID)
+	for sid, stIDs := range ds.StreamIDs() {
+		for _, stID := range stIDs {
+			// stream IDs are the string form of the remote peer ID
+			id, err := peer.Decode(string(stID))
+			if err != nil {
+				continue
+			}
+			res[sid] = append(res[sid], id)
+		}
+	}
+	return res
+}
+
+// NodeDataFetcher returns the fetcher of trie nodes from the sync streams of the
+// shard, or nil if the stream downloaders are not running.
+func (node *Node) NodeDataFetcher() verify.NodeFetcher {
+	ds := node.getDownloaders()
+	if ds == nil || !ds.IsActive() {
+		return nil
+	}
+	d := ds.GetShardDownloader(node.Blockchain().ShardID())
+	if d == nil {
+		return nil
+	}
+	return d
+}
+
 func (node *Node) getDownloaders() *downloader.Downloaders {
 	syncService := node.serviceManager.GetService(service.Synchronize)
 	if syncService == nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/harmony-one/harmony/api/service/blockproposal"
 	"github.com/harmony-one/harmony/api/service/consensus"
 	"github.com/harmony-one/harmony/api/service/explorer"
+	"github.com/harmony-one/harmony/hmy"
 )
 
 // RegisterValidatorServices register the validator services.
@@ -25,9 +26,15 @@ func (node *Node) RegisterValidatorServices() {
 
 // RegisterExplorerServices register the explorer services
 func (node *Node) RegisterExplorerServices() {
+	// The internal transactions are traced from the historical states, so they are
+	// only indexed by archival nodes.
+	var tracer explorer.InternalTransferTracer
+	if node.NodeConfig.ArchiveModes()[node.NodeConfig.ShardID] {
+		tracer = hmy.New(node, node.TxPool, node.CxPool, node.Consensus.ShardID)
+	}
 	// Register explorer service.
 	node.serviceManager.Register(
-		service.SupportExplorer, explorer.New(&node.SelfPeer, node.Blockchain(), node),
+		service.SupportExplorer, explorer.New(&node.SelfPeer, node.Blockchain(), node, tracer),
 	)
 }
 
@@ -150,7 +150,7 @@ func (w *Worker) CommitTransactions(
 	}
 
 	// HARMONY TXNS
-	normalTxns := types.NewTransactionsByPriceAndNonce(w.current.signer, w.current.ethSigner, pendingNormal)
+	normalTxns := types.NewTransactionsByPriceAndNonce(w.current.signer, w.current.ethSigner, pendingNormal, w.current.header.BaseFee())
 
 	w.CommitSortedTransactions(normalTxns, coinbase)
 
@@ -306,6 +306,9 @@ func (w *Worker) UpdateCurrent() error {
 		Time(big.NewInt(timestamp)).
 		ShardID(w.chain.ShardID()).
 		Header()
+	if w.config.IsLondon(epoch) {
+		header.SetBaseFee(core.CalcBaseFee(w.config, parent.Header()))
+	}
 	return w.makeCurrent(parent, header)
 }
 
@@ -589,6 +592,9 @@ func New(
 		Time(big.NewInt(timestamp)).
 		ShardID(worker.chain.ShardID()).
 		Header()
+	if config.IsLondon(epoch) {
+		header.SetBaseFee(core.CalcBaseFee(config, parent.Header()))
+	}
 	worker.makeCurrent(parent, header)
 
 	return worker
@@ -78,7 +78,8 @@ func TestCommitTransactions(t *testing.T) {
 	// Generate a test tx
 	baseNonce := worker.GetCurrentState().GetNonce(crypto.PubkeyToAddress(testBankKey.PublicKey))
 	randAmount := rand.Float32()
-	tx, _ := types.SignTx(types.NewTransaction(baseNonce, testBankAddress, uint32(0), big.NewInt(int64(denominations.One*randAmount)), params.TxGas, nil, nil), types.HomesteadSigner{}, testBankKey)
+	gasPrice := new(big.Int).SetUint64(params.InitialBaseFee) // covers the EIP-1559 base fee
+	tx, _ := types.SignTx(types.NewTransaction(baseNonce, testBankAddress, uint32(0), big.NewInt(int64(denominations.One*randAmount)), params.TxGas, gasPrice, nil), types.HomesteadSigner{}, testBankKey)
 
 	// Commit the tx to the worker
 	txs := make(map[common.Address]types.Transactions)
@@ -29,6 +29,7 @@ type dhtDiscovery struct {
 	dht  *libp2p_dht.IpfsDHT
 	disc discovery.Discovery
 	host libp2p_host.Host
+	dns  *DNSResolver
 
 	opt    DHTConfig
 	logger zerolog.Logger
@@ -49,11 +50,20 @@ func NewDHTDiscovery(host libp2p_host.Host, opt DHTConfig) (Discovery, error) {
 	}
 	d := libp2p_dis.NewRoutingDiscovery(dht)
 
+	var dns *DNSResolver
+	if len(opt.DNSNodeLists) != 0 {
+		if dns, err = NewDNSResolver(opt.DNSNodeLists, nil); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
 	logger := utils.Logger().With().Str("module", "discovery").Logger()
 	return &dhtDiscovery{
 		dht:    dht,
 		disc:   d,
 		host:   host,
+		dns:    dns,
 		opt:    opt,
 		logger: logger,
 		ctx:    ctx,
@@ -61,8 +71,12 @@ func NewDHTDiscovery(host libp2p_host.Host, opt DHTConfig) (Discovery, error) {
 	}, nil
 }
 
-// Start bootstrap the dht discovery service.
+// Start bootstrap the dht discovery service. The peers of the DNS node lists are
+// dialed as additional bootstrap peers.
 func (d *dhtDiscovery) Start() error {
+	if d.dns != nil {
+		go dnsLoop(d.ctx, d.host, d.dns, d.logger)
+	}
 	return d.dht.Bootstrap(d.ctx)
 }
 
@@ -0,0 +1,160 @@
+package discovery
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	libp2p_host "github.com/libp2p/go-libp2p-core/host"
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// dnsRefreshInterval is the interval at which the DNS node lists are checked
+	dnsRefreshInterval = 30 * time.Minute
+	// dnsLowPeers is the number of connected peers below which the peers of the DNS
+	// node lists are dialed again
+	dnsLowPeers = 8
+	// dnsConnectTimeout is the timeout to connect to a peer of the DNS node lists
+	dnsConnectTimeout = 10 * time.Second
+)
+
+// Multiaddrs is the entry of a node record holding the libp2p multiaddrs of a node.
+// The multiaddrs must end with the /p2p/ component of the peer ID.
+type Multiaddrs []string
+
+// ENRKey implements enr.Entry
+func (Multiaddrs) ENRKey() string { return "libp2p" }
+
+// DNSResolver resolves the peers listed in EIP-1459 DNS node lists. A node list is
+// a tree of node records published as DNS TXT records, with its root signed by the
+// key in the URL of the list (enrtree://<key>@<domain>). The tree is verified
+// against the signature and the hashes of its entries when resolved.
+type DNSResolver struct {
+	client *dnsdisc.Client
+	urls   []string
+}
+
+// NewDNSResolver creates a resolver of the given node list URLs. The system DNS is
+// used if resolver is nil.
+func NewDNSResolver(urls []string, resolver dnsdisc.Resolver) (*DNSResolver, error) {
+	for _, url := range urls {
+		if _, _, err := dnsdisc.ParseURL(url); err != nil {
+			return nil, errors.Wrapf(err, "invalid DNS node list %v", url)
+		}
+	}
+	client, err := dnsdisc.NewClient(dnsdisc.Config{Resolver: resolver})
+	if err != nil {
+		return nil, err
+	}
+	return &DNSResolver{
+		client: client,
+		urls:   urls,
+	}, nil
+}
+
+// Resolve returns the peers of all node lists. The lists failing to be resolved are
+// skipped, and an error is returned only if none of them is resolved.
+func (r *DNSResolver) Resolve() ([]libp2p_peer.AddrInfo, error) {
+	var (
+		addrs   []ma.Multiaddr
+		lastErr error
+		synced  int
+	)
+	for _, url := range r.urls {
+		tree, err := r.client.SyncTree(url)
+		if err != nil {
+			lastErr = errors.Wrapf(err, "resolve DNS node list %v", url)
+			continue
+		}
+		synced++
+		for _, node := range tree.Nodes() {
+			var entry Multiaddrs
+			if err := node.Load(&entry); err != nil {
+				continue
+			}
+			for _, s := range entry {
+				addr, err := ma.NewMultiaddr(s)
+				if err != nil {
+					continue
+				}
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	if synced == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	var ais []libp2p_peer.AddrInfo
+	for _, addr := range addrs {
+		// addresses without a valid peer ID are skipped
+		ai, err := libp2p_peer.AddrInfosFromP2pAddrs(addr)
+		if err != nil {
+			continue
+		}
+		ais = append(ais, ai...)
+	}
+	return mergeAddrInfos(ais), nil
+}
+
+// mergeAddrInfos merges the addresses of the same peer
+func mergeAddrInfos(ais []libp2p_peer.AddrInfo) []libp2p_peer.AddrInfo {
+	var (
+		res   []libp2p_peer.AddrInfo
+		index = make(map[libp2p_peer.ID]int)
+	)
+	for _, ai := range ais {
+		if i, ok := index[ai.ID]; ok {
+			res[i].Addrs = append(res[i].Addrs, ai.Addrs...)
+			continue
+		}
+		index[ai.ID] = len(res)
+		res = append(res, ai)
+	}
+	return res
+}
+
+// dnsLoop dials the peers of the DNS node lists at start, and again whenever the
+// host runs low on connected peers.
+func dnsLoop(ctx context.Context, host libp2p_host.Host, r *DNSResolver, logger zerolog.Logger) {
+	t := time.NewTicker(dnsRefreshInterval)
+	defer t.Stop()
+
+	for {
+		if len(host.Network().Peers()) < dnsLowPeers {
+			connectDNSPeers(ctx, host, r, logger)
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func connectDNSPeers(ctx context.Context, host libp2p_host.Host, r *DNSResolver, logger zerolog.Logger) {
+	ais, err := r.Resolve()
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to resolve DNS node lists")
+		return
+	}
+	var connected int
+	for _, ai := range ais {
+		if ai.ID == host.ID() {
+			continue
+		}
+		cctx, cancel := context.WithTimeout(ctx, dnsConnectTimeout)
+		err := host.Connect(cctx, ai)
+		cancel()
+		if err != nil {
+			logger.Debug().Err(err).Str("peer", ai.ID.String()).Msg("failed to connect DNS node list peer")
+			continue
+		}
+		connected++
+	}
+	logger.Info().Int("resolved", len(ais)).Int("connected", connected).
+		Msg("connected to the peers of DNS node lists")
+}
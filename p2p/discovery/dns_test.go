@@ -0,0 +1,120 @@
+package discovery
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+)
+
+const (
+	testPeer1 = "Qmdfjtk6hPoyrH1zVD9PEH4zfWLo38dP2mDvvKXfh3tnEv"
+	testPeer2 = "QmZJJx6AdaoEkGLrYG4JeLCKeCKDjnFz2wfHNHxAqFSGA9"
+)
+
+func TestDNSResolver_Resolve(t *testing.T) {
+	listKey, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+
+	nodes := []*enode.Node{
+		makeTestDNSNode(t, Multiaddrs{"/ip4/1.2.3.4/tcp/9000/p2p/" + testPeer1}),
+		makeTestDNSNode(t, Multiaddrs{"/ip4/1.2.3.5/tcp/9000/p2p/" + testPeer2, "not an address"}),
+		makeTestDNSNode(t, Multiaddrs{"/ip4/1.2.3.6/tcp/9000/p2p/" + testPeer1}),
+		makeTestDNSNode(t, nil),
+	}
+	tree, err := dnsdisc.MakeTree(1, nodes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url, err := tree.Sign(listKey, "nodes.example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := testDNSResolver(tree.ToTXT("nodes.example.org"))
+
+	tests := []struct {
+		urls     []string
+		expAddrs map[string]int
+		expErr   bool
+	}{
+		{
+			urls:     []string{url},
+			expAddrs: map[string]int{testPeer1: 2, testPeer2: 1},
+		},
+		{
+			// the signature of the tree is not from the key of the URL
+			urls:   []string{otherURL(otherKey, "nodes.example.org")},
+			expErr: true,
+		},
+		{
+			// unresolved lists are skipped
+			urls:     []string{otherURL(listKey, "missing.example.org"), url},
+			expAddrs: map[string]int{testPeer1: 2, testPeer2: 1},
+		},
+	}
+	for i, test := range tests {
+		r, err := NewDNSResolver(test.urls, resolver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ais, err := r.Resolve()
+		if (err != nil) != test.expErr {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(ais) != len(test.expAddrs) {
+			t.Errorf("Test %v: unexpected peer number %v / %v", i, len(ais), len(test.expAddrs))
+		}
+		for _, ai := range ais {
+			if exp := test.expAddrs[ai.ID.Pretty()]; len(ai.Addrs) != exp {
+				t.Errorf("Test %v: unexpected addrs of peer %v: %v / %v", i, ai.ID, len(ai.Addrs), exp)
+			}
+		}
+	}
+}
+
+func TestNewDNSResolver(t *testing.T) {
+	if _, err := NewDNSResolver([]string{"/dnsaddr/bootstrap.t.hmny.io"}, nil); err == nil {
+		t.Errorf("expect error for invalid DNS node list URL")
+	}
+}
+
+func makeTestDNSNode(t *testing.T, addrs Multiaddrs) *enode.Node {
+	key, _ := crypto.GenerateKey()
+	var r enr.Record
+	if addrs != nil {
+		r.Set(addrs)
+	}
+	if err := enode.SignV4(&r, key); err != nil {
+		t.Fatal(err)
+	}
+	n, err := enode.New(enode.ValidSchemes, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func otherURL(key *ecdsa.PrivateKey, domain string) string {
+	tree, _ := dnsdisc.MakeTree(1, nil, nil)
+	url, _ := tree.Sign(key, domain)
+	return url
+}
+
+// testDNSResolver is a DNS resolver serving the TXT records from a map
+type testDNSResolver map[string]string
+
+func (r testDNSResolver) LookupTXT(ctx context.Context, domain string) ([]string, error) {
+	if txt, ok := r[domain]; ok {
+		return []string{txt}, nil
+	}
+	return nil, fmt.Errorf("no TXT record for %v", domain)
+}
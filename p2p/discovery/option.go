@@ -14,6 +14,7 @@ type DHTConfig struct {
 	BootNodes       []string
 	DataStoreFile   *string // File path to store DHT data. Shall be only used for bootstrap nodes.
 	DiscConcurrency int
+	DNSNodeLists    []string // URLs of the EIP-1459 DNS node lists (enrtree://<key>@<domain>)
 }
 
 // getLibp2pRawOptions get the raw libp2p options as a slice.
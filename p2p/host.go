@@ -56,6 +56,16 @@ type Host interface {
 	ListPeer(topic string) []libp2p_peer.ID
 	ListTopic() []string
 	ListBlockedPeer() []libp2p_peer.ID
+	// ReportPeer lowers the score of the peer for the given misbehaviour
+	ReportPeer(id libp2p_peer.ID, event security.PeerEvent)
+	PeerScores() []security.PeerScore
+	SetPeerScore(id libp2p_peer.ID, score float64)
+	// SetMaxConnPerIP sets the maximum number of peers connected from the same IP
+	SetMaxConnPerIP(maxConnPerIP int)
+	NodeInfo() NodeInfo
+	PeerInfos() []PeerInfo
+	ConnectPeer(ctx context.Context, ai libp2p_peer.AddrInfo) error
+	DisconnectPeer(id libp2p_peer.ID) error
 }
 
 // Peer is the object for a p2p peer (node)
@@ -83,9 +93,11 @@ type HostConfig struct {
 	Self            *Peer
 	BLSKey          libp2p_crypto.PrivKey
 	BootNodes       []string
+	DNSNodeLists    []string
 	DataStoreFile   *string
 	DiscConcurrency int
 	MaxConnPerIP    int
+	PeerScoreFile   string
 }
 
 // NewHost ..
@@ -116,11 +128,17 @@ func NewHost(cfg HostConfig) (Host, error) {
 		BootNodes:       cfg.BootNodes,
 		DataStoreFile:   cfg.DataStoreFile,
 		DiscConcurrency: cfg.DiscConcurrency,
+		DNSNodeLists:    cfg.DNSNodeLists,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot create DHT discovery")
 	}
 
+	scorer := security.NewScorer(cfg.PeerScoreFile)
+	scorer.SetBanHook(func(id libp2p_peer.ID) {
+		p2pHost.Network().ClosePeer(id)
+	})
+
 	options := []libp2p_pubsub.Option{
 		// WithValidateQueueSize sets the buffer of validate queue. Defaults to 32. When queue is full, validation is throttled and new messages are dropped.
 		libp2p_pubsub.WithValidateQueueSize(512),
@@ -132,6 +150,8 @@ func NewHost(cfg HostConfig) (Host, error) {
 		libp2p_pubsub.WithValidateThrottle(MaxMessageHandlers),
 		libp2p_pubsub.WithMaxMessageSize(MaxMessageSize),
 		libp2p_pubsub.WithDiscovery(disc.GetRawDiscovery()),
+		// WithBlacklist ignores the gossip of the peers banned for misbehaviour.
+		libp2p_pubsub.WithBlacklist(scorer),
 	}
 
 	traceFile := os.Getenv("P2P_TRACEFILE")
@@ -185,6 +205,7 @@ func NewHost(cfg HostConfig) (Host, error) {
 		priKey:        key,
 		discovery:     disc,
 		security:      security,
+		scorer:        scorer,
 		onConnections: []ConnectCallback{},
 		onDisconnects: []DisconnectCallback{},
 		logger:        &subLogger,
@@ -211,6 +232,7 @@ type HostV2 struct {
 	lock          sync.Mutex
 	discovery     discovery.Discovery
 	security      security.Security
+	scorer        *security.Scorer
 	logger        *zerolog.Logger
 	blocklist     libp2p_pubsub.Blacklist
 	onConnections []ConnectCallback
@@ -230,6 +252,8 @@ func (host *HostV2) Start() error {
 	host.h.Network().Notify(host)
 	host.SetConnectCallback(host.security.OnConnectCheck)
 	host.SetDisconnectCallback(host.security.OnDisconnectCheck)
+	host.SetConnectCallback(host.scorer.OnConnectCheck)
+	go host.scorer.SaveLoop(host.ctx.Done())
 	for _, proto := range host.streamProtos {
 		proto.Start()
 	}
@@ -243,6 +267,9 @@ func (host *HostV2) Close() error {
 	}
 	host.discovery.Close()
 	host.cancel()
+	if err := host.scorer.Save(); err != nil {
+		host.logger.Warn().Err(err).Msg("failed to save peer scores")
+	}
 	return host.h.Close()
 }
 
@@ -382,9 +409,28 @@ func (host *HostV2) ListPeer(topic string) []libp2p_peer.ID {
 
 // ListBlockedPeer returns list of blocked peer
 func (host *HostV2) ListBlockedPeer() []libp2p_peer.ID {
-	// TODO: this is a place holder for now
-	peers := make([]libp2p_peer.ID, 0)
-	return peers
+	return host.scorer.BannedPeers()
+}
+
+// ReportPeer lowers the score of the peer for the given misbehaviour, banning it
+// temporarily when the score gets too low
+func (host *HostV2) ReportPeer(id libp2p_peer.ID, event security.PeerEvent) {
+	host.scorer.Report(id, event)
+}
+
+// PeerScores returns the scores of the known peers
+func (host *HostV2) PeerScores() []security.PeerScore {
+	return host.scorer.Scores()
+}
+
+// SetPeerScore overrides the score of the peer
+func (host *HostV2) SetPeerScore(id libp2p_peer.ID, score float64) {
+	host.scorer.SetScore(id, score)
+}
+
+// SetMaxConnPerIP sets the maximum number of peers connected from the same IP
+func (host *HostV2) SetMaxConnPerIP(maxConnPerIP int) {
+	host.security.SetMaxConnPerIP(maxConnPerIP)
 }
 
 // GetPeerCount ...
@@ -0,0 +1,130 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	libp2p_network "github.com/libp2p/go-libp2p-core/network"
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	libp2p_peerstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+)
+
+// agentVersionKey is the peerstore key of the agent version learnt by identify
+const agentVersionKey = "AgentVersion"
+
+// NodeInfo is the p2p identity of the host
+type NodeInfo struct {
+	ID          libp2p_peer.ID
+	ListenAddrs []ma.Multiaddr
+	Protocols   []string
+	Topics      []string
+}
+
+// PeerInfo is the information of a connected peer
+type PeerInfo struct {
+	ID        libp2p_peer.ID
+	Agent     string
+	Protocols []string
+	Topics    []string
+	Conns     []ConnInfo
+}
+
+// ConnInfo is the information of a connection to a peer
+type ConnInfo struct {
+	LocalAddr  ma.Multiaddr
+	RemoteAddr ma.Multiaddr
+	Inbound    bool
+	Opened     time.Time
+}
+
+// NodeInfo returns the p2p identity of the host
+func (host *HostV2) NodeInfo() NodeInfo {
+	topics := host.ListTopic()
+	sort.Strings(topics)
+	return NodeInfo{
+		ID:          host.h.ID(),
+		ListenAddrs: host.h.Addrs(),
+		Protocols:   host.h.Mux().Protocols(),
+		Topics:      topics,
+	}
+}
+
+// PeerInfos returns the information of the connected peers
+func (host *HostV2) PeerInfos() []PeerInfo {
+	topics := host.peerTopics()
+	ps := host.h.Peerstore()
+
+	var infos []PeerInfo
+	for _, id := range host.h.Network().Peers() {
+		info := PeerInfo{
+			ID:     id,
+			Topics: topics[id],
+		}
+		if agent, err := ps.Get(id, agentVersionKey); err == nil {
+			info.Agent, _ = agent.(string)
+		}
+		if protos, err := ps.GetProtocols(id); err == nil {
+			sort.Strings(protos)
+			info.Protocols = protos
+		}
+		for _, conn := range host.h.Network().ConnsToPeer(id) {
+			stat := conn.Stat()
+			info.Conns = append(info.Conns, ConnInfo{
+				LocalAddr:  conn.LocalMultiaddr(),
+				RemoteAddr: conn.RemoteMultiaddr(),
+				Inbound:    stat.Direction == libp2p_network.DirInbound,
+				Opened:     stat.Opened,
+			})
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// peerTopics returns the joined topics of each peer
+func (host *HostV2) peerTopics() map[libp2p_peer.ID][]string {
+	host.lock.Lock()
+	defer host.lock.Unlock()
+
+	res := make(map[libp2p_peer.ID][]string)
+	for name, topic := range host.joined {
+		for _, id := range topic.ListPeers() {
+			res[id] = append(res[id], name)
+		}
+	}
+	for _, topics := range res {
+		sort.Strings(topics)
+	}
+	return res
+}
+
+// ConnectPeer adds the addresses of the peer to the peerstore and connects to it
+func (host *HostV2) ConnectPeer(ctx context.Context, ai libp2p_peer.AddrInfo) error {
+	if ai.ID == host.h.ID() {
+		return errors.New("cannot connect to self")
+	}
+	host.h.Peerstore().AddAddrs(ai.ID, ai.Addrs, libp2p_peerstore.PermanentAddrTTL)
+	if err := host.h.Connect(ctx, ai); err != nil {
+		return errors.Wrapf(err, "connect to peer %v", ai.ID)
+	}
+	host.logger.Info().Str("peer", ai.ID.String()).Msg("connected to peer")
+	return nil
+}
+
+// DisconnectPeer closes the connections to the peer and forgets its addresses.
+// The peer may still connect again, or be found again by discovery.
+func (host *HostV2) DisconnectPeer(id libp2p_peer.ID) error {
+	if host.h.Network().Connectedness(id) != libp2p_network.Connected {
+		return errors.Errorf("peer %v not connected", id)
+	}
+	host.h.Peerstore().ClearAddrs(id)
+	if err := host.h.Network().ClosePeer(id); err != nil {
+		return errors.Wrapf(err, "disconnect peer %v", id)
+	}
+	host.logger.Info().Str("peer", id.String()).Msg("disconnected peer")
+	return nil
+}
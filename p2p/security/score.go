@@ -0,0 +1,334 @@
+package security
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/harmony-one/harmony/internal/utils"
+	libp2p_network "github.com/libp2p/go-libp2p-core/network"
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+)
+
+// PeerEvent is a misbehaviour of a peer lowering its score
+type PeerEvent int
+
+// PeerReporter reports the misbehaviours of the peers
+type PeerReporter interface {
+	ReportPeer(id libp2p_peer.ID, event PeerEvent)
+}
+
+const (
+	// ProtocolViolation is a malformed or unexpected message or response
+	ProtocolViolation PeerEvent = iota
+	// InvalidBlock is a block failing the verification
+	InvalidBlock
+	// SlowResponse is a request not answered in time
+	SlowResponse
+	// UselessGossip is a stale or duplicate gossip message
+	UselessGossip
+)
+
+// String returns the name of the event
+func (e PeerEvent) String() string {
+	switch e {
+	case ProtocolViolation:
+		return "protocol violation"
+	case InvalidBlock:
+		return "invalid block"
+	case SlowResponse:
+		return "slow response"
+	case UselessGossip:
+		return "useless gossip"
+	}
+	return "unknown"
+}
+
+// eventPenalties is the score lost by a peer for each event
+var eventPenalties = map[PeerEvent]float64{
+	ProtocolViolation: 20,
+	InvalidBlock:      50,
+	SlowResponse:      5,
+	UselessGossip:     1,
+}
+
+const (
+	// BanThreshold is the score at or below which a peer is temporarily banned
+	BanThreshold = -100
+	// BanDuration is how long a peer stays banned
+	BanDuration = 30 * time.Minute
+
+	// scoreHalfLife is the time for a score to decay half way back to zero
+	scoreHalfLife = 10 * time.Minute
+	// saveInterval is the interval at which the scores are persisted
+	saveInterval = 5 * time.Minute
+)
+
+// PeerScore is the score of a peer and its ban status
+type PeerScore struct {
+	ID          libp2p_peer.ID `json:"peer"`
+	Score       float64        `json:"score"`
+	Updated     time.Time      `json:"updated"`
+	BannedUntil time.Time      `json:"bannedUntil"`
+}
+
+// Banned returns whether the peer is banned at the given time
+func (ps *PeerScore) Banned(now time.Time) bool {
+	return now.Before(ps.BannedUntil)
+}
+
+// decay brings the score closer to zero according to the time elapsed since it
+// was last updated.
+func (ps *PeerScore) decay(now time.Time) {
+	if elapsed := now.Sub(ps.Updated); elapsed > 0 {
+		ps.Score *= math.Pow(0.5, float64(elapsed)/float64(scoreHalfLife))
+	}
+	ps.Updated = now
+}
+
+// Scorer keeps the score of the peers and bans the misbehaving ones. It implements
+// the pubsub blacklist, so that the banned peers are ignored by the gossip.
+type Scorer struct {
+	file   string
+	scores map[libp2p_peer.ID]*PeerScore
+	onBan  func(libp2p_peer.ID)
+	now    func() time.Time
+	lock   sync.Mutex
+}
+
+// NewScorer creates a new scorer persisting the scores to the given file. The
+// scores are not persisted if the file is empty.
+func NewScorer(file string) *Scorer {
+	s := &Scorer{
+		file:   file,
+		scores: make(map[libp2p_peer.ID]*PeerScore),
+		now:    time.Now,
+	}
+	if err := s.load(); err != nil {
+		utils.Logger().Warn().Err(err).Str("file", file).Msg("failed to load peer scores")
+	}
+	return s
+}
+
+// SetBanHook sets the function called when a peer gets banned
+func (s *Scorer) SetBanHook(hook func(libp2p_peer.ID)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.onBan = hook
+}
+
+// Report lowers the score of the peer for the given event, and bans it if the
+// score reaches the ban threshold.
+func (s *Scorer) Report(id libp2p_peer.ID, event PeerEvent) {
+	if id == "" {
+		return
+	}
+	s.lock.Lock()
+	ps := s.getScore(id)
+	ps.Score -= eventPenalties[event]
+	score := ps.Score
+	banned := s.checkBan(ps)
+	hook := s.onBan
+	s.lock.Unlock()
+
+	if banned {
+		utils.Logger().Warn().Str("peer", id.String()).Str("event", event.String()).
+			Float64("score", score).Msg("peer banned")
+		if hook != nil {
+			hook(id)
+		}
+	}
+}
+
+// Score returns the current score of the peer
+func (s *Scorer) Score(id libp2p_peer.ID) float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if ps, ok := s.scores[id]; ok {
+		ps.decay(s.now())
+		return ps.Score
+	}
+	return 0
+}
+
+// SetScore overrides the score of the peer. The peer is banned if the score is at
+// or below the ban threshold, and unbanned otherwise.
+func (s *Scorer) SetScore(id libp2p_peer.ID, score float64) {
+	s.lock.Lock()
+	ps := s.getScore(id)
+	ps.Score = score
+	ps.BannedUntil = time.Time{}
+	banned := s.checkBan(ps)
+	hook := s.onBan
+	s.lock.Unlock()
+
+	if banned && hook != nil {
+		hook(id)
+	}
+}
+
+// Scores returns the scores of the known peers sorted by ascending score
+func (s *Scorer) Scores() []PeerScore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	scores := make([]PeerScore, 0, len(s.scores))
+	for _, ps := range s.scores {
+		ps.decay(now)
+		scores = append(scores, *ps)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+		return scores[i].ID < scores[j].ID
+	})
+	return scores
+}
+
+// BannedPeers returns the peers currently banned
+func (s *Scorer) BannedPeers() []libp2p_peer.ID {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	peers := make([]libp2p_peer.ID, 0)
+	for id, ps := range s.scores {
+		if ps.Banned(now) {
+			peers = append(peers, id)
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	return peers
+}
+
+// Add bans the peer, implementing the pubsub blacklist
+func (s *Scorer) Add(id libp2p_peer.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ps := s.getScore(id)
+	if ps.Banned(s.now()) {
+		return false
+	}
+	ps.BannedUntil = s.now().Add(BanDuration)
+	return true
+}
+
+// Contains returns whether the peer is banned, implementing the pubsub blacklist
+func (s *Scorer) Contains(id libp2p_peer.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ps, ok := s.scores[id]
+	return ok && ps.Banned(s.now())
+}
+
+// OnConnectCheck closes the connections of the banned peers
+func (s *Scorer) OnConnectCheck(net libp2p_network.Network, conn libp2p_network.Conn) error {
+	if !s.Contains(conn.RemotePeer()) {
+		return nil
+	}
+	utils.Logger().Debug().Str("peer", conn.RemotePeer().String()).Msg("closing connection of banned peer")
+	return net.ClosePeer(conn.RemotePeer())
+}
+
+// SaveLoop persists the scores periodically until the stop channel is closed
+func (s *Scorer) SaveLoop(stopC <-chan struct{}) {
+	t := time.NewTicker(saveInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			if err := s.Save(); err != nil {
+				utils.Logger().Warn().Err(err).Msg("failed to save peer scores")
+			}
+		case <-stopC:
+			return
+		}
+	}
+}
+
+// Save persists the scores of the peers which are banned or not fully recovered.
+func (s *Scorer) Save() error {
+	if s.file == "" {
+		return nil
+	}
+	s.lock.Lock()
+	now := s.now()
+	scores := make([]*PeerScore, 0, len(s.scores))
+	for id, ps := range s.scores {
+		ps.decay(now)
+		if !ps.Banned(now) && math.Abs(ps.Score) < 1 {
+			delete(s.scores, id)
+			continue
+		}
+		scores = append(scores, ps)
+	}
+	b, err := json.Marshal(scores)
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0700); err != nil {
+		return err
+	}
+	tmp := s.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.file)
+}
+
+func (s *Scorer) load() error {
+	if s.file == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(s.file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var scores []*PeerScore
+	if err := json.Unmarshal(b, &scores); err != nil {
+		return errors.Wrap(err, "malformed peer scores")
+	}
+	for _, ps := range scores {
+		s.scores[ps.ID] = ps
+	}
+	return nil
+}
+
+// getScore returns the decayed score of the peer, creating it if unknown
+func (s *Scorer) getScore(id libp2p_peer.ID) *PeerScore {
+	ps, ok := s.scores[id]
+	if !ok {
+		ps = &PeerScore{ID: id}
+		s.scores[id] = ps
+	}
+	ps.decay(s.now())
+	return ps
+}
+
+// checkBan bans the peer if its score reached the ban threshold and it is not
+// banned yet. Returns whether the peer was banned.
+func (s *Scorer) checkBan(ps *PeerScore) bool {
+	now := s.now()
+	if ps.Score > BanThreshold || ps.Banned(now) {
+		return false
+	}
+	ps.BannedUntil = now.Add(BanDuration)
+	return true
+}
@@ -0,0 +1,99 @@
+package security
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScorer(file string) (*Scorer, *time.Time) {
+	now := time.Unix(1600000000, 0)
+	s := NewScorer(file)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestScorer_Ban(t *testing.T) {
+	s, now := newTestScorer("")
+	id := peer.ID("peer1")
+
+	var banned []peer.ID
+	s.SetBanHook(func(id peer.ID) { banned = append(banned, id) })
+
+	s.Report(id, InvalidBlock)
+	assert.Equal(t, float64(-50), s.Score(id))
+	assert.False(t, s.Contains(id))
+
+	s.Report(id, InvalidBlock)
+	assert.True(t, s.Contains(id))
+	assert.Equal(t, []peer.ID{id}, banned)
+	assert.Equal(t, []peer.ID{id}, s.BannedPeers())
+
+	// no second ban while banned
+	s.Report(id, ProtocolViolation)
+	assert.Equal(t, 1, len(banned))
+
+	*now = now.Add(BanDuration)
+	assert.False(t, s.Contains(id))
+	assert.Empty(t, s.BannedPeers())
+}
+
+func TestScorer_Decay(t *testing.T) {
+	s, now := newTestScorer("")
+	id := peer.ID("peer1")
+
+	s.Report(id, ProtocolViolation)
+	*now = now.Add(scoreHalfLife)
+	assert.InDelta(t, -10, s.Score(id), 1e-9)
+	*now = now.Add(scoreHalfLife)
+	assert.InDelta(t, -5, s.Score(id), 1e-9)
+}
+
+func TestScorer_SetScore(t *testing.T) {
+	s, _ := newTestScorer("")
+	id := peer.ID("peer1")
+
+	s.SetScore(id, BanThreshold)
+	assert.True(t, s.Contains(id))
+
+	s.SetScore(id, 10)
+	assert.False(t, s.Contains(id))
+	assert.Equal(t, float64(10), s.Score(id))
+
+	scores := s.Scores()
+	assert.Equal(t, 1, len(scores))
+	assert.Equal(t, id, scores[0].ID)
+}
+
+func TestScorer_Persistence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "scores", "peers.json")
+	s, _ := newTestScorer(file)
+	banned, reported, recovered := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t)
+
+	s.SetScore(banned, 2*BanThreshold)
+	s.Report(reported, SlowResponse)
+	s.Report(recovered, UselessGossip)
+	s.SetScore(recovered, 0.5)
+	assert.NoError(t, s.Save())
+
+	loaded, _ := newTestScorer(file)
+	assert.True(t, loaded.Contains(banned))
+	assert.Equal(t, float64(-5), loaded.Score(reported))
+	assert.Equal(t, 2, len(loaded.Scores()))
+}
+
+func newTestPeerID(t *testing.T) peer.ID {
+	_, pub, err := crypto.GenerateEd25519Key(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
@@ -13,6 +13,7 @@ import (
 type Security interface {
 	OnConnectCheck(net libp2p_network.Network, conn libp2p_network.Conn) error
 	OnDisconnectCheck(conn libp2p_network.Conn) error
+	SetMaxConnPerIP(maxConnPerIP int)
 }
 
 type Manager struct {
@@ -28,6 +29,15 @@ func NewManager(maxConnPerIP int) *Manager {
 	}
 }
 
+// SetMaxConnPerIP sets the maximum number of peers connected from the same IP. The
+// peers already connected are kept, the limit applies to the next connections.
+func (m *Manager) SetMaxConnPerIP(maxConnPerIP int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.maxConnPerIP = maxConnPerIP
+}
+
 func (m *Manager) OnConnectCheck(net libp2p_network.Network, conn libp2p_network.Conn) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -2,8 +2,11 @@ package sync
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
 	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
 	"github.com/pkg/errors"
@@ -15,6 +18,21 @@ type chainHelper interface {
 	getBlockHashes(bns []uint64) []common.Hash
 	getBlocksByNumber(bns []uint64) ([]*types.Block, error)
 	getBlocksByHashes(hs []common.Hash) ([]*types.Block, error)
+	getNodeData(hs []common.Hash) ([][]byte, error)
+	getAccountRange(root, origin, limit common.Hash, maxBytes uint64) (*trieRange, error)
+	getStorageRange(root, account, origin, limit common.Hash, maxBytes uint64) (*trieRange, error)
+}
+
+// nodeDataReader is the chain able to serve the trie nodes and contract codes
+// of its states.
+type nodeDataReader interface {
+	TrieNode(hash common.Hash) ([]byte, error)
+}
+
+// stateTrieReader is the chain able to serve the account and storage ranges of its
+// states.
+type stateTrieReader interface {
+	TrieDB() *trie.Database
 }
 
 type chainHelperImpl struct {
@@ -91,6 +109,73 @@ func (ch *chainHelperImpl) getBlocksByHashes(hs []common.Hash) ([]*types.Block,
 
 var errBlockNotFound = errors.New("block not found")
 
+// getNodeData returns the trie nodes and contract codes of the given hashes, with
+// an empty entry for the ones unknown.
+func (ch *chainHelperImpl) getNodeData(hs []common.Hash) ([][]byte, error) {
+	reader, ok := ch.chain.(nodeDataReader)
+	if !ok {
+		return nil, errors.New("node data not available")
+	}
+	data := make([][]byte, 0, len(hs))
+	for _, h := range hs {
+		blob, err := reader.TrieNode(h)
+		if err != nil {
+			blob = nil
+		}
+		data = append(data, blob)
+	}
+	return data, nil
+}
+
+var (
+	errStateNotFound   = errors.New("state not found")
+	errAccountNotFound = errors.New("account not found")
+)
+
+// getAccountRange returns the accounts of the state of the given root within the
+// hash range [origin, limit], with the proof of the range edges.
+func (ch *chainHelperImpl) getAccountRange(root, origin, limit common.Hash, maxBytes uint64) (*trieRange, error) {
+	reader, ok := ch.chain.(stateTrieReader)
+	if !ok {
+		return nil, errors.New("state ranges not available")
+	}
+	tr, err := trie.New(root, reader.TrieDB())
+	if err != nil {
+		return nil, errStateNotFound
+	}
+	return readTrieRange(tr, origin, limit, maxBytes)
+}
+
+// getStorageRange returns the storage slots of the account in the state of the
+// given root within the hash range [origin, limit], with the proof of the range
+// edges against the storage root of the account.
+func (ch *chainHelperImpl) getStorageRange(root, account, origin, limit common.Hash, maxBytes uint64) (*trieRange, error) {
+	reader, ok := ch.chain.(stateTrieReader)
+	if !ok {
+		return nil, errors.New("state ranges not available")
+	}
+	tr, err := trie.New(root, reader.TrieDB())
+	if err != nil {
+		return nil, errStateNotFound
+	}
+	enc, err := tr.TryGet(account[:])
+	if err != nil {
+		return nil, errStateNotFound
+	}
+	if len(enc) == 0 {
+		return nil, errAccountNotFound
+	}
+	var acc state.Account
+	if err := rlp.DecodeBytes(enc, &acc); err != nil {
+		return nil, err
+	}
+	st, err := trie.New(acc.Root, reader.TrieDB())
+	if err != nil {
+		return nil, errStateNotFound
+	}
+	return readTrieRange(st, origin, limit, maxBytes)
+}
+
 func (ch *chainHelperImpl) getBlockWithSigByHeader(header *block.Header) (*types.Block, error) {
 	b := ch.chain.GetBlock(header.Hash(), header.Number().Uint64())
 	if b == nil {
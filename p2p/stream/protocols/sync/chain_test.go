@@ -8,7 +8,10 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	protobuf "github.com/golang/protobuf/proto"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/core/types"
@@ -46,6 +49,36 @@ func (tch *testChainHelper) getBlocksByHashes(hs []common.Hash) ([]*types.Block,
 	return bs, nil
 }
 
+func (tch *testChainHelper) getNodeData(hs []common.Hash) ([][]byte, error) {
+	data := make([][]byte, 0, len(hs))
+	for _, h := range hs {
+		data = append(data, h.Bytes())
+	}
+	return data, nil
+}
+
+func (tch *testChainHelper) getAccountRange(root, origin, limit common.Hash, maxBytes uint64) (*trieRange, error) {
+	return readTrieRange(testStateTrie, origin, limit, maxBytes)
+}
+
+func (tch *testChainHelper) getStorageRange(root, account, origin, limit common.Hash, maxBytes uint64) (*trieRange, error) {
+	return readTrieRange(testStateTrie, origin, limit, maxBytes)
+}
+
+var testStateTrie, testStateRoot = makeTestStateTrie(100)
+
+// makeTestStateTrie makes a trie of the given size keyed by hashes, as the state
+// tries are.
+func makeTestStateTrie(size uint64) (*trie.Trie, common.Hash) {
+	tr, _ := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	for i := uint64(0); i != size; i++ {
+		value, _ := rlp.EncodeToBytes(i + 1)
+		tr.Update(crypto.Keccak256(numberToHash(i).Bytes()), value)
+	}
+	root, _ := tr.Commit(nil)
+	return tr, root
+}
+
 func numberToHash(bn uint64) common.Hash {
 	var h common.Hash
 	binary.LittleEndian.PutUint64(h[:], bn)
@@ -164,3 +197,39 @@ func checkBlocksByHashesResult(b []byte, hs []common.Hash) error {
 	}
 	return nil
 }
+
+func checkNodeDataResult(b []byte, hs []common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	ndResp, err := msg.GetNodeDataResponse()
+	if err != nil {
+		return err
+	}
+	if len(hs) != len(ndResp.Data) {
+		return errors.New("unexpected size")
+	}
+	for i, h := range hs {
+		if !bytes.Equal(ndResp.Data[i], h.Bytes()) {
+			return fmt.Errorf("unexpected node data %x", ndResp.Data[i])
+		}
+	}
+	return nil
+}
+
+func checkAccountRangeResult(b []byte, origin, limit common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	arResp, err := msg.GetAccountRangeResponse()
+	if err != nil {
+		return err
+	}
+	if len(arResp.Hashes) == 0 {
+		return errors.New("empty range")
+	}
+	hashes := bytesToHashes(arResp.Hashes)
+	return verifyTrieRange(testStateRoot, origin, limit, hashes, arResp.Accounts, arResp.Proof)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	protobuf "github.com/golang/protobuf/proto"
 	"github.com/harmony-one/harmony/core/types"
@@ -35,11 +36,13 @@ func (p *Protocol) GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...
 	if err != nil {
 		// At this point, error can be context canceled, context timed out, or waiting queue
 		// is already full.
+		p.reportRequestError(stid, err)
 		return
 	}
 
 	// Parse and return blocks
 	blocks, err = req.getBlocksFromResponse(resp)
+	p.reportResponseError(stid, err)
 	return
 }
 
@@ -52,10 +55,12 @@ func (p *Protocol) GetCurrentBlockNumber(ctx context.Context, opts ...Option) (b
 
 	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
 	if err != nil {
+		p.reportRequestError(stid, err)
 		return 0, stid, err
 	}
 
 	bn, err = req.getNumberFromResponse(resp)
+	p.reportResponseError(stid, err)
 	return
 }
 
@@ -77,9 +82,11 @@ func (p *Protocol) GetBlockHashes(ctx context.Context, bns []uint64, opts ...Opt
 	req := newGetBlockHashesRequest(bns)
 	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
 	if err != nil {
+		p.reportRequestError(stid, err)
 		return
 	}
 	hashes, err = req.getHashesFromResponse(resp)
+	p.reportResponseError(stid, err)
 	return
 }
 
@@ -99,12 +106,87 @@ func (p *Protocol) GetBlocksByHashes(ctx context.Context, hs []common.Hash, opts
 	req := newGetBlocksByHashesRequest(hs)
 	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
 	if err != nil {
+		p.reportRequestError(stid, err)
 		return
 	}
 	blocks, err = req.getBlocksFromResponse(resp)
+	p.reportResponseError(stid, err)
 	return
 }
 
+// GetNodeData do getNodeDataRequest through sync stream protocol. Return the trie
+// nodes and contract codes of the given hashes, with a nil entry for the ones
+// unknown to the remote node.
+func (p *Protocol) GetNodeData(ctx context.Context, hs []common.Hash, opts ...Option) (data [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getNodeData")
+	defer p.doMetricPostClientRequest("getNodeData", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero node hashes requested")
+		return
+	}
+	if len(hs) > GetNodeDataAmountCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetNodeDataRequest(hs)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		p.reportRequestError(stid, err)
+		return
+	}
+	data, err = req.getNodeDataFromResponse(resp)
+	p.reportResponseError(stid, err)
+	return
+}
+
+// GetAccountRange do getAccountRangeRequest through sync stream protocol. Return
+// the account hashes and RLP encoded accounts of the state of the given root within
+// the hash range [origin, limit], up to about the given bytes. The range edges are
+// verified against the root.
+func (p *Protocol) GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...Option) (hashes []common.Hash, accounts [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getAccountRange")
+	defer p.doMetricPostClientRequest("getAccountRange", err, timer)
+
+	req := newGetRangeRequest(syncpb.MakeGetAccountRangeRequest(root, origin, limit, bytes), root, origin, limit)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		p.reportRequestError(stid, err)
+		return
+	}
+	hashes, accounts, err = req.getRangeFromResponse(resp)
+	p.reportRangeError(stid, resp, err)
+	return
+}
+
+// GetStorageRange do getStorageRangeRequest through sync stream protocol. Return
+// the slot hashes and RLP encoded values of the storage of the account in the state
+// of the given root within the hash range [origin, limit], up to about the given
+// bytes. The range edges are verified against the storage root of the account.
+func (p *Protocol) GetStorageRange(ctx context.Context, root, account, storageRoot, origin, limit common.Hash, bytes uint64, opts ...Option) (hashes []common.Hash, slots [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getStorageRange")
+	defer p.doMetricPostClientRequest("getStorageRange", err, timer)
+
+	req := newGetRangeRequest(syncpb.MakeGetStorageRangeRequest(root, account, origin, limit, bytes), storageRoot, origin, limit)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		p.reportRequestError(stid, err)
+		return
+	}
+	hashes, slots, err = req.getRangeFromResponse(resp)
+	p.reportRangeError(stid, resp, err)
+	return
+}
+
+// reportRangeError reports the invalid range responses. The error responses are
+// not reported since the peers may have pruned the requested state.
+func (p *Protocol) reportRangeError(stid sttypes.StreamID, resp sttypes.Response, err error) {
+	if sResp, ok := resp.(*syncResponse); ok && sResp != nil && sResp.pb.GetErrorResponse() != nil {
+		return
+	}
+	p.reportResponseError(stid, err)
+}
+
 // getBlocksByNumberRequest is the request for get block by numbers which implements
 // sttypes.Request interface
 type getBlocksByNumberRequest struct {
@@ -357,3 +439,142 @@ func (req *getBlocksByHashesRequest) getBlocksFromResponse(resp sttypes.Response
 	}
 	return blocks, nil
 }
+
+type getNodeDataRequest struct {
+	hashes []common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetNodeDataRequest(hashes []common.Hash) *getNodeDataRequest {
+	pbReq := syncpb.MakeGetNodeDataRequest(hashes)
+	return &getNodeDataRequest{
+		hashes: hashes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getNodeDataRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getNodeDataRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getNodeDataRequest) String() string {
+	return fmt.Sprintf("REQUEST [GetNodeData: %v hashes]", len(req.hashes))
+}
+
+func (req *getNodeDataRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(MinVersion)
+}
+
+func (req *getNodeDataRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getNodeDataRequest) getNodeDataFromResponse(resp sttypes.Response) ([][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	ndResp := sResp.pb.GetGetNodeDataResponse()
+	if ndResp == nil {
+		return nil, errors.New("response not GetNodeData")
+	}
+	if len(ndResp.Data) != len(req.hashes) {
+		return nil, fmt.Errorf("node data size not expected: %v / %v", len(ndResp.Data), len(req.hashes))
+	}
+	data := make([][]byte, 0, len(ndResp.Data))
+	for i, blob := range ndResp.Data {
+		if len(blob) == 0 {
+			data = append(data, nil)
+			continue
+		}
+		if crypto.Keccak256Hash(blob) != req.hashes[i] {
+			return nil, fmt.Errorf("node data of %x not matching its hash", req.hashes[i])
+		}
+		data = append(data, blob)
+	}
+	return data, nil
+}
+
+// getRangeRequest is the request for the leaves of a state trie within a hash
+// range, either a GetAccountRange or a GetStorageRange request.
+type getRangeRequest struct {
+	root   common.Hash // root of the trie the range is proven against
+	origin common.Hash
+	limit  common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetRangeRequest(pbReq *syncpb.Request, root, origin, limit common.Hash) *getRangeRequest {
+	return &getRangeRequest{
+		root:   root,
+		origin: origin,
+		limit:  limit,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getRangeRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getRangeRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getRangeRequest) String() string {
+	if req.pbReq.GetGetStorageRangeRequest() != nil {
+		return fmt.Sprintf("REQUEST [GetStorageRange: %x-%x]", req.origin, req.limit)
+	}
+	return fmt.Sprintf("REQUEST [GetAccountRange: %x-%x]", req.origin, req.limit)
+}
+
+func (req *getRangeRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(MinVersion)
+}
+
+func (req *getRangeRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getRangeRequest) getRangeFromResponse(resp sttypes.Response) ([]common.Hash, [][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, errors.New(errResp.Error)
+	}
+	var hs, values, proof [][]byte
+	if req.pbReq.GetGetStorageRangeRequest() != nil {
+		srResp := sResp.pb.GetGetStorageRangeResponse()
+		if srResp == nil {
+			return nil, nil, errors.New("response not GetStorageRange")
+		}
+		hs, values, proof = srResp.Hashes, srResp.Slots, srResp.Proof
+	} else {
+		arResp := sResp.pb.GetGetAccountRangeResponse()
+		if arResp == nil {
+			return nil, nil, errors.New("response not GetAccountRange")
+		}
+		hs, values, proof = arResp.Hashes, arResp.Accounts, arResp.Proof
+	}
+	for _, h := range hs {
+		if len(h) != common.HashLength {
+			return nil, nil, fmt.Errorf("invalid leaf hash size %v", len(h))
+		}
+	}
+	hashes := bytesToHashes(hs)
+	if err := verifyTrieRange(req.root, req.origin, req.limit, hashes, values, proof); err != nil {
+		return nil, nil, err
+	}
+	return hashes, values, nil
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/block"
@@ -49,6 +50,10 @@ var (
 
 	testBlocksByHashesResponse = syncpb.MakeGetBlocksByHashesResponse(0, [][]byte{testBlockBytes}, make([][]byte, 1))
 
+	testNodeData         = []byte("test node")
+	testNodeHash         = crypto.Keccak256Hash(testNodeData)
+	testNodeDataResponse = syncpb.MakeGetNodeDataResponse(0, [][]byte{testNodeData})
+
 	testErrorResponse = syncpb.MakeErrorResponse(0, errors.New("test error"))
 )
 
@@ -289,6 +294,139 @@ func TestProtocol_GetBlocksByHashes(t *testing.T) {
 	}
 }
 
+func TestProtocol_GetNodeData(t *testing.T) {
+	tests := []struct {
+		hash        common.Hash
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			hash: testNodeHash,
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testNodeDataResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			hash: testHash,
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testNodeDataResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("not matching its hash"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			hash: testNodeHash,
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("not GetNodeData"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			hash: testNodeHash,
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		data, stid, err := protocol.GetNodeData(context.Background(), []common.Hash{test.hash})
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(data) != 1 || string(data[0]) != string(testNodeData) {
+				t.Errorf("Test %v: unexpected node data %x", i, data)
+			}
+		}
+	}
+}
+
+func TestProtocol_GetAccountRange(t *testing.T) {
+	limit := common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	res, err := readTrieRange(testStateTrie, common.Hash{}, limit, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.hashes) < 2 || len(res.hashes) == 100 {
+		t.Fatalf("unexpected range size %v", len(res.hashes))
+	}
+	tampered := make([][]byte, len(res.values))
+	copy(tampered, res.values)
+	tampered[len(tampered)-1] = []byte{0x01}
+	unsorted := make([]common.Hash, len(res.hashes))
+	copy(unsorted, res.hashes)
+	unsorted[0], unsorted[1] = unsorted[1], unsorted[0]
+
+	tests := []struct {
+		resp   *syncpb.Response
+		expErr error
+	}{
+		{
+			resp:   syncpb.MakeGetAccountRangeResponse(0, res.hashes, res.values, res.proof),
+			expErr: nil,
+		},
+		{
+			resp:   syncpb.MakeGetAccountRangeResponse(0, res.hashes, tampered, res.proof),
+			expErr: errors.New("not matching its proof"),
+		},
+		{
+			resp:   syncpb.MakeGetAccountRangeResponse(0, unsorted, res.values, res.proof),
+			expErr: errors.New("not in ascending order"),
+		},
+		{
+			resp:   syncpb.MakeGetAccountRangeResponse(0, res.hashes, res.values, nil),
+			expErr: errors.New("invalid proof"),
+		},
+		{
+			resp:   testNodeDataResponse,
+			expErr: errors.New("not GetAccountRange"),
+		},
+		{
+			resp:   testErrorResponse,
+			expErr: errors.New("test error"),
+		},
+	}
+
+	for i, test := range tests {
+		resp := test.resp
+		protocol := makeTestProtocol(func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+			return &syncResponse{pb: resp}, makeTestStreamID(0)
+		})
+		hashes, accounts, _, err := protocol.GetAccountRange(context.Background(), testStateRoot, common.Hash{}, limit, 1000)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if test.expErr == nil {
+			if len(hashes) != len(res.hashes) || len(accounts) != len(res.values) {
+				t.Errorf("Test %v: unexpected range size %v", i, len(hashes))
+			}
+		}
+	}
+}
+
 type getResponseFn func(request sttypes.Request) (sttypes.Response, sttypes.StreamID)
 
 type testHostRequestManager struct {
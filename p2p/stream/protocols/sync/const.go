@@ -17,6 +17,15 @@ const (
 	// See comments for GetBlocksByNumAmountCap.
 	GetBlocksByHashesAmountCap = 10
 
+	// GetNodeDataAmountCap is the cap of request of a single GetNodeData request.
+	// Trie nodes are small, but a contract code can be up to 24KB, which keeps the
+	// response within the 20MB maxMsgBytes.
+	GetNodeDataAmountCap = 384
+
+	// GetRangeBytesCap is the cap of the leaves bytes of a single GetAccountRange or
+	// GetStorageRange response. The proofs of the range edges are not counted.
+	GetRangeBytesCap = 512 * 1024
+
 	// minAdvertiseInterval is the minimum advertise interval
 	minAdvertiseInterval = 1 * time.Minute
 
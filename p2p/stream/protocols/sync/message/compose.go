@@ -46,6 +46,46 @@ func MakeGetBlocksByHashesRequest(hashes []common.Hash) *Request {
 	}
 }
 
+// MakeGetNodeDataRequest makes the GetNodeData request
+func MakeGetNodeDataRequest(hashes []common.Hash) *Request {
+	return &Request{
+		Request: &Request_GetNodeDataRequest{
+			GetNodeDataRequest: &GetNodeDataRequest{
+				NodeHashes: hashesToBytes(hashes),
+			},
+		},
+	}
+}
+
+// MakeGetAccountRangeRequest makes the GetAccountRange request
+func MakeGetAccountRangeRequest(root, origin, limit common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetAccountRangeRequest{
+			GetAccountRangeRequest: &GetAccountRangeRequest{
+				Root:   root.Bytes(),
+				Origin: origin.Bytes(),
+				Limit:  limit.Bytes(),
+				Bytes:  bytes,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangeRequest makes the GetStorageRange request
+func MakeGetStorageRangeRequest(root, account, origin, limit common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetStorageRangeRequest{
+			GetStorageRangeRequest: &GetStorageRangeRequest{
+				Root:    root.Bytes(),
+				Account: account.Bytes(),
+				Origin:  origin.Bytes(),
+				Limit:   limit.Bytes(),
+				Bytes:   bytes,
+			},
+		},
+	}
+}
+
 // MakeErrorResponse makes the error response
 func MakeErrorResponseMessage(rid uint64, err error) *Message {
 	resp := MakeErrorResponse(rid, err)
@@ -138,6 +178,64 @@ func MakeGetBlocksByHashesResponse(rid uint64, blocksBytes [][]byte, sigs [][]by
 	}
 }
 
+// MakeGetNodeDataResponseMessage makes the GetNodeDataResponse of Message type
+func MakeGetNodeDataResponseMessage(rid uint64, data [][]byte) *Message {
+	resp := MakeGetNodeDataResponse(rid, data)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetNodeDataResponse makes the GetNodeDataResponse of Response type
+func MakeGetNodeDataResponse(rid uint64, data [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetNodeDataResponse{
+			GetNodeDataResponse: &GetNodeDataResponse{
+				Data: data,
+			},
+		},
+	}
+}
+
+// MakeGetAccountRangeResponseMessage makes the GetAccountRangeResponse of Message type
+func MakeGetAccountRangeResponseMessage(rid uint64, hashes []common.Hash, accounts, proof [][]byte) *Message {
+	resp := MakeGetAccountRangeResponse(rid, hashes, accounts, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetAccountRangeResponse makes the GetAccountRangeResponse of Response type
+func MakeGetAccountRangeResponse(rid uint64, hashes []common.Hash, accounts, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetAccountRangeResponse{
+			GetAccountRangeResponse: &GetAccountRangeResponse{
+				Hashes:   hashesToBytes(hashes),
+				Accounts: accounts,
+				Proof:    proof,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangeResponseMessage makes the GetStorageRangeResponse of Message type
+func MakeGetStorageRangeResponseMessage(rid uint64, hashes []common.Hash, slots, proof [][]byte) *Message {
+	resp := MakeGetStorageRangeResponse(rid, hashes, slots, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetStorageRangeResponse makes the GetStorageRangeResponse of Response type
+func MakeGetStorageRangeResponse(rid uint64, hashes []common.Hash, slots, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetStorageRangeResponse{
+			GetStorageRangeResponse: &GetStorageRangeResponse{
+				Hashes: hashesToBytes(hashes),
+				Slots:  slots,
+				Proof:  proof,
+			},
+		},
+	}
+}
+
 // MakeMessageFromRequest makes a message from the request
 func MakeMessageFromRequest(req *Request) *Message {
 	return &Message{
@@ -79,3 +79,51 @@ func (msg *Message) GetBlocksByHashesResponse() (*GetBlocksByHashesResponse, err
 	}
 	return gbResp, nil
 }
+
+// GetNodeDataResponse parse the message to GetNodeDataResponse
+func (msg *Message) GetNodeDataResponse() (*GetNodeDataResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	ndResp := resp.GetGetNodeDataResponse()
+	if ndResp == nil {
+		return nil, errors.New("not GetNodeDataResponse")
+	}
+	return ndResp, nil
+}
+
+// GetAccountRangeResponse parse the message to GetAccountRangeResponse
+func (msg *Message) GetAccountRangeResponse() (*GetAccountRangeResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	arResp := resp.GetGetAccountRangeResponse()
+	if arResp == nil {
+		return nil, errors.New("not GetAccountRangeResponse")
+	}
+	return arResp, nil
+}
+
+// GetStorageRangeResponse parse the message to GetStorageRangeResponse
+func (msg *Message) GetStorageRangeResponse() (*GetStorageRangeResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	srResp := resp.GetGetStorageRangeResponse()
+	if srResp == nil {
+		return nil, errors.New("not GetStorageRangeResponse")
+	}
+	return srResp, nil
+}
@@ -11,6 +11,7 @@ import (
 	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/p2p/discovery"
+	"github.com/harmony-one/harmony/p2p/security"
 	"github.com/harmony-one/harmony/p2p/stream/common/ratelimiter"
 	"github.com/harmony-one/harmony/p2p/stream/common/requestmanager"
 	"github.com/harmony-one/harmony/p2p/stream/common/streammanager"
@@ -18,6 +19,8 @@ import (
 	"github.com/hashicorp/go-version"
 	libp2p_host "github.com/libp2p/go-libp2p-core/host"
 	libp2p_network "github.com/libp2p/go-libp2p-core/network"
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
 
@@ -61,6 +64,7 @@ type (
 		Discovery discovery.Discovery
 		ShardID   nodeconfig.ShardID
 		Network   nodeconfig.NetworkType
+		Reporter  security.PeerReporter // optional, scores the peers misbehaving on requests
 
 		// stream manager config
 		SmSoftLowCap int
@@ -234,6 +238,33 @@ func (p *Protocol) RemoveStream(stID sttypes.StreamID) {
 	}
 }
 
+// reportRequestError lowers the score of the peer of the stream if it did not
+// deliver the response in time.
+func (p *Protocol) reportRequestError(stID sttypes.StreamID, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		p.reportStream(stID, security.SlowResponse)
+	}
+}
+
+// reportResponseError lowers the score of the peer of the stream if it delivered
+// an invalid response.
+func (p *Protocol) reportResponseError(stID sttypes.StreamID, err error) {
+	if err != nil {
+		p.reportStream(stID, security.ProtocolViolation)
+	}
+}
+
+func (p *Protocol) reportStream(stID sttypes.StreamID, event security.PeerEvent) {
+	if p.config.Reporter == nil || stID == "" {
+		return
+	}
+	id, err := libp2p_peer.Decode(string(stID))
+	if err != nil {
+		return
+	}
+	p.config.Reporter.ReportPeer(id, event)
+}
+
 // NumStreams return the streams with minimum version.
 // Note: nodes with sync version smaller than minVersion is not counted.
 func (p *Protocol) NumStreams() int {
@@ -249,6 +280,18 @@ func (p *Protocol) NumStreams() int {
 	return res
 }
 
+// StreamIDs returns the IDs of the streams with minimum version.
+func (p *Protocol) StreamIDs() []sttypes.StreamID {
+	var res []sttypes.StreamID
+	for _, st := range p.sm.GetStreams() {
+		ps, _ := st.ProtoSpec()
+		if ps.Version.GreaterThanOrEqual(MinVersion) {
+			res = append(res, st.ID())
+		}
+	}
+	return res
+}
+
 // GetStreamManager get the underlying stream manager for upper level stream operations
 func (p *Protocol) GetStreamManager() streammanager.StreamManager {
 	return p.sm
@@ -0,0 +1,130 @@
+package sync
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/pkg/errors"
+)
+
+// trieRange is the leaves of a trie within a hash range, along with the proofs of
+// the first and the last leaf of the range.
+type trieRange struct {
+	hashes []common.Hash
+	values [][]byte
+	proof  [][]byte
+}
+
+// readTrieRange reads the leaves of the trie from origin to limit (both inclusive)
+// until the size of the leaves reaches maxBytes. The proofs of origin and of the
+// first and the last leaf are attached, so that an empty range can be checked as
+// well.
+func readTrieRange(tr *trie.Trie, origin, limit common.Hash, maxBytes uint64) (*trieRange, error) {
+	var (
+		res  = &trieRange{}
+		size uint64
+	)
+	it := trie.NewIterator(tr.NodeIterator(origin[:]))
+	for size < maxBytes && it.Next() {
+		if bytes.Compare(it.Key, limit[:]) > 0 {
+			break
+		}
+		res.hashes = append(res.hashes, common.BytesToHash(it.Key))
+		res.values = append(res.values, common.CopyBytes(it.Value))
+		size += uint64(common.HashLength + len(it.Value))
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+
+	proof := newProofSet()
+	if err := tr.Prove(origin[:], 0, proof); err != nil {
+		return nil, err
+	}
+	if n := len(res.hashes); n > 0 {
+		for _, hash := range []common.Hash{res.hashes[0], res.hashes[n-1]} {
+			if err := tr.Prove(hash[:], 0, proof); err != nil {
+				return nil, err
+			}
+		}
+	}
+	res.proof = proof.nodes
+	return res, nil
+}
+
+// verifyTrieRange checks the leaves delivered for the range [origin, limit] of the
+// trie of the given root. The leaves must be sorted within the range, and the first
+// and the last leaves are verified against the root with the proof. The leaves in
+// between can not be proven and are left to the trie healing.
+func verifyTrieRange(root, origin, limit common.Hash, hashes []common.Hash, values, proof [][]byte) error {
+	if len(hashes) != len(values) {
+		return fmt.Errorf("range size not expected: %v hashes / %v values", len(hashes), len(values))
+	}
+	for i, hash := range hashes {
+		if bytes.Compare(hash[:], origin[:]) < 0 || bytes.Compare(hash[:], limit[:]) > 0 {
+			return fmt.Errorf("leaf %x out of range", hash)
+		}
+		if i > 0 && bytes.Compare(hashes[i-1][:], hash[:]) >= 0 {
+			return fmt.Errorf("leaf %x not in ascending order", hash)
+		}
+		if len(values[i]) == 0 {
+			return fmt.Errorf("empty leaf %x", hash)
+		}
+	}
+
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return err
+		}
+	}
+	if len(hashes) == 0 {
+		if root == types.EmptyRootHash {
+			return nil
+		}
+		if _, _, err := trie.VerifyProof(root, origin[:], proofDB); err != nil {
+			return errors.Wrap(err, "invalid origin proof")
+		}
+		return nil
+	}
+	for _, i := range []int{0, len(hashes) - 1} {
+		value, _, err := trie.VerifyProof(root, hashes[i][:], proofDB)
+		if err != nil {
+			return errors.Wrapf(err, "invalid proof of leaf %x", hashes[i])
+		}
+		if !bytes.Equal(value, values[i]) {
+			return fmt.Errorf("leaf %x not matching its proof", hashes[i])
+		}
+	}
+	return nil
+}
+
+// proofSet collects the distinct trie nodes of the proofs
+type proofSet struct {
+	nodes [][]byte
+	known map[string]struct{}
+}
+
+func newProofSet() *proofSet {
+	return &proofSet{known: make(map[string]struct{})}
+}
+
+// Put adds the proof node, implementing ethdb.KeyValueWriter
+func (ps *proofSet) Put(key []byte, value []byte) error {
+	if _, ok := ps.known[string(key)]; ok {
+		return nil
+	}
+	ps.known[string(key)] = struct{}{}
+	ps.nodes = append(ps.nodes, common.CopyBytes(value))
+	return nil
+}
+
+// Delete implements ethdb.KeyValueWriter
+func (ps *proofSet) Delete(key []byte) error {
+	return errors.New("not supported")
+}
@@ -0,0 +1,68 @@
+package sync
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTrieRange(t *testing.T) {
+	limit := common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	// Read the whole trie range by range, as a syncing node does
+	var (
+		origin common.Hash
+		leaves int
+	)
+	for {
+		res, err := readTrieRange(testStateTrie, origin, limit, 500)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := verifyTrieRange(testStateRoot, origin, limit, res.hashes, res.values, res.proof); err != nil {
+			t.Fatalf("range from %x: %v", origin, err)
+		}
+		if len(res.hashes) == 0 {
+			break
+		}
+		leaves += len(res.hashes)
+		last := res.hashes[len(res.hashes)-1]
+		if last == limit {
+			break
+		}
+		origin = incHash(last)
+	}
+	if leaves != 100 {
+		t.Errorf("read %v leaves, expect 100", leaves)
+	}
+
+	// The range stops at the limit
+	mid := common.HexToHash("0x8000000000000000000000000000000000000000000000000000000000000000")
+	res, err := readTrieRange(testStateTrie, common.Hash{}, mid, GetRangeBytesCap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range res.hashes {
+		if bytes.Compare(h[:], mid[:]) > 0 {
+			t.Errorf("leaf %x beyond the limit", h)
+		}
+	}
+	if err := verifyTrieRange(testStateRoot, common.Hash{}, mid, res.hashes, res.values, res.proof); err != nil {
+		t.Error(err)
+	}
+	// The leaves are checked against the range
+	if err := verifyTrieRange(testStateRoot, incHash(res.hashes[0]), mid, res.hashes, res.values, res.proof); err == nil {
+		t.Error("leaf before origin not detected")
+	}
+}
+
+func incHash(h common.Hash) common.Hash {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i]++
+		if h[i] != 0 {
+			break
+		}
+	}
+	return h
+}
@@ -182,6 +182,15 @@ func (st *syncStream) handleReq(req *syncpb.Request) error {
 	if bhReq := req.GetGetBlocksByHashesRequest(); bhReq != nil {
 		return st.handleGetBlocksByHashesRequest(req.ReqId, bhReq)
 	}
+	if ndReq := req.GetGetNodeDataRequest(); ndReq != nil {
+		return st.handleGetNodeDataRequest(req.ReqId, ndReq)
+	}
+	if arReq := req.GetGetAccountRangeRequest(); arReq != nil {
+		return st.handleGetAccountRangeRequest(req.ReqId, arReq)
+	}
+	if srReq := req.GetGetStorageRangeRequest(); srReq != nil {
+		return st.handleGetStorageRangeRequest(req.ReqId, srReq)
+	}
 	// unsupported request type
 	return st.handleUnknownRequest(req.ReqId)
 }
@@ -260,6 +269,67 @@ func (st *syncStream) handleGetBlocksByHashesRequest(rid uint64, req *syncpb.Get
 	return errors.Wrap(err, "[GetBlocksByHashes]")
 }
 
+func (st *syncStream) handleGetNodeDataRequest(rid uint64, req *syncpb.GetNodeDataRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getNodeData",
+	}).Inc()
+
+	hashes := bytesToHashes(req.NodeHashes)
+	resp, err := st.computeRespFromNodeHashes(rid, hashes)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetNodeData]")
+}
+
+func (st *syncStream) handleGetAccountRangeRequest(rid uint64, req *syncpb.GetAccountRangeRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getAccountRange",
+	}).Inc()
+
+	resp, err := st.computeRespFromAccountRange(rid, req)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetAccountRange]")
+}
+
+func (st *syncStream) handleGetStorageRangeRequest(rid uint64, req *syncpb.GetStorageRangeRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getStorageRange",
+	}).Inc()
+
+	resp, err := st.computeRespFromStorageRange(rid, req)
+	if resp == nil && err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if writeErr := st.writeMsg(resp); writeErr != nil {
+		if err == nil {
+			err = writeErr
+		} else {
+			err = fmt.Errorf("%v; [writeMsg] %v", err.Error(), writeErr)
+		}
+	}
+	return errors.Wrap(err, "[GetStorageRange]")
+}
+
 func (st *syncStream) handleUnknownRequest(rid uint64) error {
 	serverRequestCounterVec.With(prometheus.Labels{
 		"topic":        string(st.ProtoID()),
@@ -367,6 +437,62 @@ func (st *syncStream) computeRespFromBlockHashes(rid uint64, hs []common.Hash) (
 	return syncpb.MakeGetBlocksByHashesResponseMessage(rid, blocksBytes, sigs), nil
 }
 
+func (st *syncStream) computeRespFromNodeHashes(rid uint64, hs []common.Hash) (*syncpb.Message, error) {
+	if len(hs) > GetNodeDataAmountCap {
+		err := fmt.Errorf("GetNodeData amount exceed cap: %v > %v", len(hs), GetNodeDataAmountCap)
+		return nil, err
+	}
+	data, err := st.chain.getNodeData(hs)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetNodeDataResponseMessage(rid, data), nil
+}
+
+func (st *syncStream) computeRespFromAccountRange(rid uint64, req *syncpb.GetAccountRangeRequest) (*syncpb.Message, error) {
+	root, origin, limit, err := parseRangeHashes(req.Root, req.Origin, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	res, err := st.chain.getAccountRange(root, origin, limit, capRangeBytes(req.Bytes))
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetAccountRangeResponseMessage(rid, res.hashes, res.values, res.proof), nil
+}
+
+func (st *syncStream) computeRespFromStorageRange(rid uint64, req *syncpb.GetStorageRangeRequest) (*syncpb.Message, error) {
+	root, origin, limit, err := parseRangeHashes(req.Root, req.Origin, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Account) != common.HashLength {
+		return nil, fmt.Errorf("invalid account hash size %v", len(req.Account))
+	}
+	account := common.BytesToHash(req.Account)
+	res, err := st.chain.getStorageRange(root, account, origin, limit, capRangeBytes(req.Bytes))
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetStorageRangeResponseMessage(rid, res.hashes, res.values, res.proof), nil
+}
+
+func parseRangeHashes(root, origin, limit []byte) (common.Hash, common.Hash, common.Hash, error) {
+	for _, b := range [][]byte{root, origin, limit} {
+		if len(b) != common.HashLength {
+			return common.Hash{}, common.Hash{}, common.Hash{}, fmt.Errorf("invalid hash size %v", len(b))
+		}
+	}
+	return common.BytesToHash(root), common.BytesToHash(origin), common.BytesToHash(limit), nil
+}
+
+func capRangeBytes(bytes uint64) uint64 {
+	if bytes == 0 || bytes > GetRangeBytesCap {
+		return GetRangeBytesCap
+	}
+	return bytes
+}
+
 func bytesToHashes(bs [][]byte) []common.Hash {
 	hs := make([]common.Hash, 0, len(bs))
 	for _, b := range bs {
@@ -40,6 +40,15 @@ var (
 	}
 	testGetBlocksByHashesRequest    = syncpb.MakeGetBlocksByHashesRequest(testGetBlockByHashes)
 	testGetBlocksByHashesRequestMsg = syncpb.MakeMessageFromRequest(testGetBlocksByHashesRequest)
+
+	testGetNodeDataHashes     = []common.Hash{numberToHash(1), numberToHash(2)}
+	testGetNodeDataRequest    = syncpb.MakeGetNodeDataRequest(testGetNodeDataHashes)
+	testGetNodeDataRequestMsg = syncpb.MakeMessageFromRequest(testGetNodeDataRequest)
+
+	testRangeOrigin               = common.Hash{}
+	testRangeLimit                = common.HexToHash("0x8000000000000000000000000000000000000000000000000000000000000000")
+	testGetAccountRangeRequest    = syncpb.MakeGetAccountRangeRequest(testStateRoot, testRangeOrigin, testRangeLimit, 0)
+	testGetAccountRangeRequestMsg = syncpb.MakeMessageFromRequest(testGetAccountRangeRequest)
 )
 
 func TestSyncStream_HandleGetBlocksByRequest(t *testing.T) {
@@ -126,6 +135,48 @@ func TestSyncStream_HandleGetBlocksByHashes(t *testing.T) {
 	}
 }
 
+func TestSyncStream_HandleGetNodeData(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetNodeDataRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkNodeDataResult(receivedBytes, testGetNodeDataHashes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetAccountRange(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetAccountRangeRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkAccountRangeResult(receivedBytes, testRangeOrigin, testRangeLimit); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func makeTestSyncStream() (*syncStream, *testRemoteBaseStream) {
 	localRaw, remoteRaw := makePairP2PStreams()
 	remote := newTestRemoteBaseStream(remoteRaw)
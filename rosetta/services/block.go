@@ -96,6 +96,18 @@ func (s *BlockAPI) Block(
 		transactions = append(transactions, tx)
 	}
 
+	// Report plain transactions in the block, along with the internal transfers of contract calls.
+	// Tracing needs the state the block was executed on, without it the plain transactions are
+	// left to the /block/transaction endpoint.
+	state := s.getParentState(ctx, blk)
+	if state != nil {
+		plainTransactions, rosettaError := s.getPlainTransactions(ctx, blk, state)
+		if rosettaError != nil {
+			return nil, rosettaError
+		}
+		transactions = append(transactions, plainTransactions...)
+	}
+
 	metadata, err := types.MarshalMap(BlockMetadata{
 		Epoch: blk.Epoch(),
 	})
@@ -113,10 +125,12 @@ func (s *BlockAPI) Block(
 	}
 
 	otherTransactions := []*types.TransactionIdentifier{}
-	for _, tx := range blk.Transactions() {
-		otherTransactions = append(otherTransactions, &types.TransactionIdentifier{
-			Hash: tx.Hash().String(),
-		})
+	if state == nil {
+		for _, tx := range blk.Transactions() {
+			otherTransactions = append(otherTransactions, &types.TransactionIdentifier{
+				Hash: tx.Hash().String(),
+			})
+		}
 	}
 	for _, tx := range blk.StakingTransactions() {
 		otherTransactions = append(otherTransactions, &types.TransactionIdentifier{
@@ -164,33 +178,16 @@ func (s *BlockAPI) BlockTransaction(
 		}
 		return response, rosettaError2
 	}
-	state, _, err := s.hmy.StateAndHeaderByNumber(ctx, rpc.BlockNumber(blk.NumberU64()))
-	if state == nil || err != nil {
+	state := s.getParentState(ctx, blk)
+	if state == nil {
 		return nil, common.NewError(common.BlockNotFoundError, map[string]interface{}{
-			"message": fmt.Sprintf("block state not found for block %v", blk.NumberU64()),
+			"message": fmt.Sprintf("block state not found for block %v", blk.NumberU64()-1),
 		})
 	}
 
 	var transaction *types.Transaction
 	if txInfo.tx != nil && txInfo.receipt != nil {
-		contractInfo := &ContractInfo{}
-		if _, ok := txInfo.tx.(*hmytypes.Transaction); ok {
-			// check for contract related operations, if it is a plain transaction.
-			if txInfo.tx.To() != nil {
-				// possible call to existing contract so fetch relevant data
-				contractInfo.ContractCode = state.GetCode(*txInfo.tx.To())
-				contractInfo.ContractAddress = txInfo.tx.To()
-			} else {
-				// contract creation, so address is in receipt
-				contractInfo.ContractCode = state.GetCode(txInfo.receipt.ContractAddress)
-				contractInfo.ContractAddress = &txInfo.receipt.ContractAddress
-			}
-			contractInfo.ExecutionResult, rosettaError = s.getTransactionTrace(ctx, blk, txInfo)
-			if rosettaError != nil {
-				return nil, rosettaError
-			}
-		}
-		transaction, rosettaError = FormatTransaction(txInfo.tx, txInfo.receipt, contractInfo, true)
+		transaction, rosettaError = s.formatTransaction(ctx, blk, state, txInfo)
 		if rosettaError != nil {
 			return nil, rosettaError
 		}
@@ -205,6 +202,78 @@ func (s *BlockAPI) BlockTransaction(
 	return &types.BlockTransactionResponse{Transaction: transaction}, nil
 }
 
+// getParentState returns the state the block was executed on, or nil if it is not available.
+// Historical states are only kept by archival nodes.
+func (s *BlockAPI) getParentState(ctx context.Context, blk *hmytypes.Block) *state.DB {
+	state, _, err := s.hmy.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(blk.ParentHash(), false))
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+// getPlainTransactions formats all plain transactions of the block executed on the given
+// state. Each transaction is traced, so that native transfers made internally by contracts
+// are reported as operations.
+func (s *BlockAPI) getPlainTransactions(
+	ctx context.Context, blk *hmytypes.Block, state *state.DB,
+) ([]*types.Transaction, *types.Error) {
+	transactions := []*types.Transaction{}
+	if blk.Transactions().Len() == 0 {
+		return transactions, nil
+	}
+	receipts, err := s.hmy.GetReceipts(ctx, blk.Hash())
+	if err != nil {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	if len(receipts) < blk.Transactions().Len() {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": fmt.Sprintf("missing receipts for block %#x", blk.Hash()),
+		})
+	}
+	for i, tx := range blk.Transactions() {
+		txInfo := &transactionInfo{
+			tx:      tx,
+			txIndex: uint64(i),
+			receipt: receipts[i],
+		}
+		transaction, rosettaError := s.formatTransaction(ctx, blk, state, txInfo)
+		if rosettaError != nil {
+			return nil, rosettaError
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// formatTransaction formats a transaction with a receipt. Plain transactions are traced
+// to report the native transfers made internally by the contracts they call.
+func (s *BlockAPI) formatTransaction(
+	ctx context.Context, blk *hmytypes.Block, state *state.DB, txInfo *transactionInfo,
+) (*types.Transaction, *types.Error) {
+	contractInfo := &ContractInfo{}
+	if _, ok := txInfo.tx.(*hmytypes.Transaction); ok {
+		// check for contract related operations, if it is a plain transaction.
+		if txInfo.tx.To() != nil {
+			// possible call to existing contract so fetch relevant data
+			contractInfo.ContractCode = state.GetCode(*txInfo.tx.To())
+			contractInfo.ContractAddress = txInfo.tx.To()
+		} else {
+			// contract creation, so address is in receipt
+			contractInfo.ContractCode = state.GetCode(txInfo.receipt.ContractAddress)
+			contractInfo.ContractAddress = &txInfo.receipt.ContractAddress
+		}
+		var rosettaError *types.Error
+		contractInfo.ExecutionResult, rosettaError = s.getTransactionTrace(ctx, blk, txInfo)
+		if rosettaError != nil {
+			return nil, rosettaError
+		}
+	}
+	return FormatTransaction(txInfo.tx, txInfo.receipt, contractInfo, true)
+}
+
 // transactionInfo stores all related information for any transaction on the Harmony chain
 // Note that some elements can be nil if not applicable
 type transactionInfo struct {
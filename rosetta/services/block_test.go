@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core"
+	hmytypes "github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/hmy"
+	chain2 "github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/node/worker"
+	"github.com/harmony-one/harmony/rosetta/common"
+	"github.com/harmony-one/harmony/shard"
+)
+
+var (
+	testKey, _  = crypto.GenerateKey()
+	testAddress = crypto.PubkeyToAddress(testKey.PublicKey)
+
+	// forwarderAddress holds a contract sending the value it receives to recipientAddress
+	forwarderAddress = ethcommon.HexToAddress("0x1000")
+	recipientAddress = ethcommon.HexToAddress("0x3000")
+)
+
+// newTestHarmony returns a Harmony backed by an in-memory chain holding only
+// the genesis block, which funds the test account and deploys the forwarder.
+func newTestHarmony(t *testing.T) *hmy.Harmony {
+	t.Helper()
+	forwarderCode := []byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.CALLVALUE), byte(vm.PUSH20),
+	}
+	forwarderCode = append(forwarderCode, recipientAddress.Bytes()...)
+	forwarderCode = append(forwarderCode, byte(vm.GAS), byte(vm.CALL), byte(vm.POP), byte(vm.STOP))
+	var (
+		database = rawdb.NewMemoryDatabase()
+		gspec    = core.Genesis{
+			Config:  params.TestChainConfig,
+			Factory: blockfactory.ForTest,
+			Alloc: core.GenesisAlloc{
+				testAddress:      {Balance: new(big.Int).Mul(big.NewInt(1000), oneBig)},
+				forwarderAddress: {Balance: new(big.Int), Code: forwarderCode},
+			},
+			GasLimit: params.TestGenesisGasLimit,
+			ShardID:  0,
+			// the test account proposes the blocks
+			ShardState: shard.State{
+				Epoch: big.NewInt(0),
+				Shards: []shard.Committee{{
+					ShardID: 0,
+					Slots:   shard.SlotList{{EcdsaAddress: testAddress}},
+				}},
+			},
+		}
+	)
+	gspec.MustCommit(database)
+	chain, err := core.NewBlockChain(database, nil, gspec.Config, chain2.NewEngine(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(chain.Stop)
+	return &hmy.Harmony{BlockChain: chain}
+}
+
+// addTestBlock inserts a block holding the given transactions of the test
+// account on top of the chain.
+func addTestBlock(t *testing.T, harmony *hmy.Harmony, txs ...*hmytypes.Transaction) *hmytypes.Block {
+	t.Helper()
+	chain := harmony.BlockChain
+	w := worker.New(chain.Config(), chain, chain.Engine())
+	signer := hmytypes.MakeSigner(chain.Config(), new(big.Int).Add(chain.CurrentBlock().Number(), ethcommon.Big1))
+	var signed hmytypes.Transactions
+	for _, tx := range txs {
+		signedTx, err := hmytypes.SignTx(tx, signer, testKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed = append(signed, signedTx)
+	}
+	if err := w.CommitTransactions(map[ethcommon.Address]hmytypes.Transactions{testAddress: signed}, nil, testAddress); err != nil {
+		t.Fatal(err)
+	}
+	commitSigs := make(chan []byte, 1)
+	commitSigs <- make([]byte, 96+1)
+	blk, err := w.FinalizeNewBlock(commitSigs, func() uint64 { return 0 }, testAddress, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.InsertChain(hmytypes.Blocks{blk}, false); err != nil {
+		t.Fatal(err)
+	}
+	return blk
+}
+
+// blockRequest returns a request of the block to the /block endpoint
+func blockRequest(t *testing.T, blk *hmytypes.Block) *types.BlockRequest {
+	t.Helper()
+	netID, err := common.GetNetwork(blk.ShardID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := blk.Hash().String()
+	return &types.BlockRequest{
+		NetworkIdentifier: netID,
+		BlockIdentifier:   &types.PartialBlockIdentifier{Hash: &hash},
+	}
+}
+
+func TestBlockPlainTransactions(t *testing.T) {
+	harmony := newTestHarmony(t)
+	value := big.NewInt(1e18)
+	blk := addTestBlock(t, harmony,
+		hmytypes.NewTransaction(0, recipientAddress, 0, value, 100000, big.NewInt(2e9), nil),
+		hmytypes.NewTransaction(1, forwarderAddress, 0, value, 100000, big.NewInt(2e9), nil),
+	)
+	s := NewBlockAPI(harmony).(*BlockAPI)
+	ctx := context.Background()
+
+	// the parent state is the one the block was executed on
+	state := s.getParentState(ctx, blk)
+	if state == nil {
+		t.Fatal("parent state not found")
+	}
+	if nonce := state.GetNonce(testAddress); nonce != 0 {
+		t.Errorf("have nonce %d in the parent state, want 0", nonce)
+	}
+
+	response, rosettaError := s.Block(ctx, blockRequest(t, blk))
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	if len(response.OtherTransactions) != 0 {
+		t.Errorf("have other transactions %v, want none", types.PrintStruct(response.OtherTransactions))
+	}
+	transactions := response.Block.Transactions
+	if len(transactions) != 2 {
+		t.Fatalf("have %d transactions, want 2", len(transactions))
+	}
+	recipientID, rosettaError := newAccountIdentifier(recipientAddress)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	// both the plain transfer and the internal transfer of the forwarder
+	// credit the recipient
+	for i, transaction := range transactions {
+		if transaction.TransactionIdentifier.Hash != blk.Transactions()[i].Hash().String() {
+			t.Errorf("transaction %d: have hash %v, want %v", i, transaction.TransactionIdentifier.Hash, blk.Transactions()[i].Hash().String())
+		}
+		credited := false
+		for _, op := range transaction.Operations {
+			if op.Type == common.NativeTransferOperation && types.Hash(op.Account) == types.Hash(recipientID) &&
+				op.Amount.Value == value.String() {
+				credited = true
+			}
+		}
+		if !credited {
+			t.Errorf("transaction %d: no transfer of %v to the recipient in %v", i, value, types.PrintStruct(transaction.Operations))
+		}
+	}
+}
+
+func TestBlockParentStateUnavailable(t *testing.T) {
+	harmony := newTestHarmony(t)
+	blk := addTestBlock(t, harmony,
+		hmytypes.NewTransaction(0, recipientAddress, 0, big.NewInt(1), 100000, big.NewInt(2e9), nil),
+	)
+	s := NewBlockAPI(harmony).(*BlockAPI)
+	ctx := context.Background()
+
+	// the genesis block has no parent
+	genesis := harmony.BlockChain.GetBlockByNumber(0)
+	if state := s.getParentState(ctx, genesis); state != nil {
+		t.Fatal("found a parent state of the genesis block")
+	}
+	// prune the state of the genesis block, as nodes keeping no historical
+	// states do
+	if err := harmony.BlockChain.ChainDb().Delete(genesis.Root().Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if state := s.getParentState(ctx, blk); state != nil {
+		t.Fatal("found a pruned parent state")
+	}
+
+	// the plain transactions are left to the /block/transaction endpoint
+	response, rosettaError := s.Block(ctx, blockRequest(t, blk))
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	if len(response.Block.Transactions) != 0 {
+		t.Errorf("have transactions %v, want none", types.PrintStruct(response.Block.Transactions))
+	}
+	if len(response.OtherTransactions) != 1 || response.OtherTransactions[0].Hash != blk.Transactions()[0].Hash().String() {
+		t.Errorf("have other transactions %v, want %v", types.PrintStruct(response.OtherTransactions), blk.Transactions()[0].Hash().String())
+	}
+}
@@ -0,0 +1,442 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/verify"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/p2p/security"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// VerifyTriesMaxBlocks is the maximum number of blocks checked by a single
+	// trie verification request
+	VerifyTriesMaxBlocks = 8192
+
+	// harmonyProtoName is the protocol name of the Harmony information in the
+	// admin_nodeInfo and admin_peers results
+	harmonyProtoName = "harmony"
+	// addPeerTimeout is the timeout to connect to a peer added by admin_addPeer
+	addPeerTimeout = 30 * time.Second
+)
+
+// PrivateAdminService offers the admin RPC methods maintaining and configuring the
+// node. These methods are not authenticated, so they are only served on the localhost
+// admin endpoint, which is disabled unless HTTP.AdminEnabled is set.
+type PrivateAdminService struct {
+	hmy *hmy.Harmony
+}
+
+// NewPrivateAdminAPI creates a new API for the RPC interface
+func NewPrivateAdminAPI(hmy *hmy.Harmony) rpc.API {
+	return rpc.API{
+		Namespace: adminNamespace,
+		Version:   APIVersion,
+		Service:   &PrivateAdminService{hmy},
+		Public:    false,
+	}
+}
+
+// VerifyTriesResult is the outcome of a trie verification.
+type VerifyTriesResult struct {
+	From       hexutil.Uint64         `json:"from"`
+	To         hexutil.Uint64         `json:"to"`
+	StateBlock hexutil.Uint64         `json:"stateBlock"`
+	StateRoot  common.Hash            `json:"stateRoot"`
+	Nodes      hexutil.Uint64         `json:"nodes"`
+	Healthy    bool                   `json:"healthy"`
+	Problems   []*VerifyProblemResult `json:"problems"`
+	Elapsed    string                 `json:"elapsed"`
+}
+
+// VerifyProblemResult is a missing or corrupt item found by a trie verification.
+type VerifyProblemResult struct {
+	Number   hexutil.Uint64 `json:"blockNumber"`
+	Kind     string         `json:"kind"`
+	Hash     common.Hash    `json:"hash"`
+	Corrupt  bool           `json:"corrupt"`
+	Repaired bool           `json:"repaired"`
+	Detail   string         `json:"detail"`
+}
+
+// VerifyTries checks the headers, bodies and receipt roots of the given number of
+// most recent blocks and walks the state trie of the newest block whose state is
+// available, reporting the missing and corrupt trie nodes. If repair is set, the
+// missing nodes are fetched from the sync peers and written to the database.
+func (s *PrivateAdminService) VerifyTries(
+	ctx context.Context, blocks uint64, repair bool,
+) (*VerifyTriesResult, error) {
+	timer := DoMetricRPCRequest(VerifyTries)
+	defer DoRPCRequestDuration(VerifyTries, timer)
+
+	if blocks == 0 || blocks > VerifyTriesMaxBlocks {
+		DoMetricRPCQueryInfo(VerifyTries, FailedNumber)
+		return nil, fmt.Errorf("number of blocks must be between 1 and %d", VerifyTriesMaxBlocks)
+	}
+	res, err := s.hmy.VerifyChain(ctx, blocks, repair)
+	if err != nil {
+		DoMetricRPCQueryInfo(VerifyTries, FailedNumber)
+		return nil, err
+	}
+	return newVerifyTriesResult(res), nil
+}
+
+// newVerifyTriesResult converts the given verification result into its RPC form.
+func newVerifyTriesResult(res *verify.Result) *VerifyTriesResult {
+	result := &VerifyTriesResult{
+		From:       hexutil.Uint64(res.From),
+		To:         hexutil.Uint64(res.To),
+		StateBlock: hexutil.Uint64(res.StateBlock),
+		StateRoot:  res.StateRoot,
+		Nodes:      hexutil.Uint64(res.Nodes),
+		Healthy:    res.Healthy(),
+		Problems:   make([]*VerifyProblemResult, 0, len(res.Problems)),
+		Elapsed:    res.Elapsed.String(),
+	}
+	for _, p := range res.Problems {
+		result.Problems = append(result.Problems, &VerifyProblemResult{
+			Number:   hexutil.Uint64(p.Number),
+			Kind:     p.Kind,
+			Hash:     p.Hash,
+			Corrupt:  p.Corrupt,
+			Repaired: p.Repaired,
+			Detail:   p.Detail,
+		})
+	}
+	return result
+}
+
+// PeerScoreResult is the score of a peer and its ban status.
+type PeerScoreResult struct {
+	Peer        string  `json:"peer"`
+	Score       float64 `json:"score"`
+	Banned      bool    `json:"banned"`
+	BannedUntil int64   `json:"bannedUntil"`
+}
+
+// GetPeerScores returns the scores of the known peers, lowest first. The score of
+// a peer is lowered by its protocol violations, invalid blocks, slow responses and
+// useless gossip, and decays back to zero over time. Peers are temporarily banned
+// when their score reaches the ban threshold.
+func (s *PrivateAdminService) GetPeerScores(ctx context.Context) []*PeerScoreResult {
+	timer := DoMetricRPCRequest(GetPeerScores)
+	defer DoRPCRequestDuration(GetPeerScores, timer)
+
+	var (
+		scores  = s.hmy.NodeAPI.PeerScores()
+		results = make([]*PeerScoreResult, 0, len(scores))
+		now     = time.Now()
+	)
+	for i := range scores {
+		results = append(results, newPeerScoreResult(&scores[i], now))
+	}
+	return results
+}
+
+// SetPeerScore overrides the score of the given peer. A score at or below the ban
+// threshold bans the peer, any other score lifts its ban.
+func (s *PrivateAdminService) SetPeerScore(ctx context.Context, peerID string, score float64) error {
+	timer := DoMetricRPCRequest(SetPeerScore)
+	defer DoRPCRequestDuration(SetPeerScore, timer)
+
+	id, err := peer.Decode(peerID)
+	if err != nil {
+		DoMetricRPCQueryInfo(SetPeerScore, FailedNumber)
+		return fmt.Errorf("invalid peer id: %v", err)
+	}
+	s.hmy.NodeAPI.SetPeerScore(id, score)
+	return nil
+}
+
+// newPeerScoreResult converts the given peer score into its RPC form.
+func newPeerScoreResult(ps *security.PeerScore, now time.Time) *PeerScoreResult {
+	result := &PeerScoreResult{
+		Peer:   ps.ID.Pretty(),
+		Score:  ps.Score,
+		Banned: ps.Banned(now),
+	}
+	if result.Banned {
+		result.BannedUntil = ps.BannedUntil.Unix()
+	}
+	return result
+}
+
+// SetConfigArgs are the runtime settings to change, the settings left out are
+// kept as they are.
+type SetConfigArgs struct {
+	LogVerbosity        *int            `json:"logVerbosity"`
+	RequestsPerSecond   *int            `json:"requestsPerSecond"`
+	MethodRateLimits    *map[string]int `json:"methodRateLimits"`
+	TraceTimeout        *string         `json:"traceTimeout"`
+	MaxConcurrentTraces *int            `json:"maxConcurrentTraces"`
+	GasCap              *uint64         `json:"gasCap"`
+	MaxConnsPerIP       *int            `json:"maxConnsPerIP"`
+}
+
+// SetConfig changes the runtime settings of the node without restarting it, and
+// returns the resulting settings. The requests per second only apply if the RPC
+// rate limiter was enabled at start, and the max connections per IP only apply
+// to the next connections.
+func (s *PrivateAdminService) SetConfig(ctx context.Context, args SetConfigArgs) (harmonyconfig.RuntimeConfig, error) {
+	timer := DoMetricRPCRequest(SetConfig)
+	defer DoRPCRequestDuration(SetConfig, timer)
+
+	cfg := s.hmy.NodeAPI.RuntimeConfig()
+	if args.LogVerbosity != nil {
+		cfg.LogVerbosity = *args.LogVerbosity
+	}
+	if args.RequestsPerSecond != nil {
+		cfg.RequestsPerSecond = *args.RequestsPerSecond
+	}
+	if args.MethodRateLimits != nil {
+		cfg.MethodRateLimits = *args.MethodRateLimits
+	}
+	if args.TraceTimeout != nil {
+		cfg.TraceTimeout = *args.TraceTimeout
+	}
+	if args.MaxConcurrentTraces != nil {
+		cfg.MaxConcurrentTraces = *args.MaxConcurrentTraces
+	}
+	if args.GasCap != nil {
+		cfg.GasCap = *args.GasCap
+	}
+	if args.MaxConnsPerIP != nil {
+		cfg.MaxConnsPerIP = *args.MaxConnsPerIP
+	}
+	if err := s.hmy.NodeAPI.SetRuntimeConfig(cfg); err != nil {
+		DoMetricRPCQueryInfo(SetConfig, FailedNumber)
+		return harmonyconfig.RuntimeConfig{}, err
+	}
+	return s.hmy.NodeAPI.RuntimeConfig(), nil
+}
+
+// NodeInfoResult is the identity of the node, in the layout of the admin_nodeInfo
+// method of go-ethereum. Harmony nodes have no enode URL, the enode field holds
+// the libp2p multiaddr of the node instead.
+type NodeInfoResult struct {
+	ID          string                      `json:"id"`
+	Name        string                      `json:"name"`
+	Enode       string                      `json:"enode"`
+	ListenAddrs []string                    `json:"listenAddrs"`
+	Protocols   map[string]*NodeProtoResult `json:"protocols"`
+}
+
+// NodeProtoResult is the Harmony protocol information of the node.
+type NodeProtoResult struct {
+	Network         string   `json:"network"`
+	ShardID         uint32   `json:"shardID"`
+	Role            string   `json:"role"`
+	BLSPublicKeys   []string `json:"blsKeys"`
+	IsLeader        bool     `json:"isLeader"`
+	IsBackup        bool     `json:"isBackup"`
+	Archival        bool     `json:"archival"`
+	BlockNumber     uint64   `json:"blockNumber"`
+	Epoch           uint64   `json:"epoch"`
+	Topics          []string `json:"topics"`
+	StreamProtocols []string `json:"streamProtocols"`
+}
+
+// PeerInfoResult is a connected peer, in the layout of the admin_peers method of
+// go-ethereum.
+type PeerInfoResult struct {
+	ID        string                      `json:"id"`
+	Name      string                      `json:"name"`
+	Enode     string                      `json:"enode"`
+	Caps      []string                    `json:"caps"`
+	Network   PeerNetworkResult           `json:"network"`
+	Protocols map[string]*PeerProtoResult `json:"protocols"`
+}
+
+// PeerNetworkResult is the connection information of a peer.
+type PeerNetworkResult struct {
+	LocalAddress  string `json:"localAddress"`
+	RemoteAddress string `json:"remoteAddress"`
+	Inbound       bool   `json:"inbound"`
+	Trusted       bool   `json:"trusted"`
+	Static        bool   `json:"static"`
+	Connections   int    `json:"connections"`
+	ConnectedAt   int64  `json:"connectedAt"`
+}
+
+// PeerProtoResult is the Harmony protocol state of a peer.
+type PeerProtoResult struct {
+	Topics     []string `json:"topics"`
+	SyncShards []uint32 `json:"syncShards"`
+	Score      float64  `json:"score"`
+}
+
+// NodeInfo returns the identity of the node, its shard and the p2p protocols and
+// topics it serves.
+func (s *PrivateAdminService) NodeInfo(ctx context.Context) *NodeInfoResult {
+	timer := DoMetricRPCRequest(NodeInfo)
+	defer DoRPCRequestDuration(NodeInfo, timer)
+
+	var (
+		info     = s.hmy.NodeAPI.NodeInfo()
+		metadata = s.hmy.GetNodeMetadata()
+		addrs    = make([]string, 0, len(info.ListenAddrs))
+		streams  []string
+	)
+	for _, addr := range info.ListenAddrs {
+		addrs = append(addrs, fmt.Sprintf("%v/p2p/%v", addr, info.ID.Pretty()))
+	}
+	for _, proto := range info.Protocols {
+		if strings.HasPrefix(proto, sttypes.ProtoIDCommonPrefix) {
+			streams = append(streams, proto)
+		}
+	}
+	result := &NodeInfoResult{
+		ID:          info.ID.Pretty(),
+		Name:        metadata.Version,
+		ListenAddrs: addrs,
+		Protocols: map[string]*NodeProtoResult{
+			harmonyProtoName: {
+				Network:         metadata.NetworkType,
+				ShardID:         metadata.ShardID,
+				Role:            metadata.Role,
+				BLSPublicKeys:   metadata.BLSPublicKey,
+				IsLeader:        metadata.IsLeader,
+				IsBackup:        metadata.IsBackup,
+				Archival:        metadata.Archival,
+				BlockNumber:     metadata.CurrentBlockNum,
+				Epoch:           metadata.CurrentEpoch,
+				Topics:          info.Topics,
+				StreamProtocols: streams,
+			},
+		},
+	}
+	if len(addrs) > 0 {
+		result.Enode = addrs[0]
+	}
+	return result
+}
+
+// Peers returns the connected peers with their protocols, topics and the shards
+// they serve sync streams for.
+func (s *PrivateAdminService) Peers(ctx context.Context) []*PeerInfoResult {
+	timer := DoMetricRPCRequest(Peers)
+	defer DoRPCRequestDuration(Peers, timer)
+
+	var (
+		infos      = s.hmy.NodeAPI.PeerInfos()
+		scores     = make(map[peer.ID]float64)
+		syncShards = make(map[peer.ID][]uint32)
+		results    = make([]*PeerInfoResult, 0, len(infos))
+	)
+	for _, ps := range s.hmy.NodeAPI.PeerScores() {
+		scores[ps.ID] = ps.Score
+	}
+	for shardID, ids := range s.hmy.NodeAPI.SyncStreamPeers() {
+		for _, id := range ids {
+			syncShards[id] = append(syncShards[id], shardID)
+		}
+	}
+	for i := range infos {
+		shards := syncShards[infos[i].ID]
+		sort.Slice(shards, func(a, b int) bool { return shards[a] < shards[b] })
+		results = append(results, newPeerInfoResult(&infos[i], shards, scores[infos[i].ID]))
+	}
+	return results
+}
+
+// AddPeer connects to the peer of the given multiaddr, which must end with the
+// /p2p/ component of the peer ID.
+func (s *PrivateAdminService) AddPeer(ctx context.Context, url string) (bool, error) {
+	timer := DoMetricRPCRequest(AddPeer)
+	defer DoRPCRequestDuration(AddPeer, timer)
+
+	addr, err := ma.NewMultiaddr(url)
+	if err != nil {
+		DoMetricRPCQueryInfo(AddPeer, FailedNumber)
+		return false, fmt.Errorf("invalid multiaddr: %v", err)
+	}
+	ai, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		DoMetricRPCQueryInfo(AddPeer, FailedNumber)
+		return false, fmt.Errorf("invalid peer multiaddr: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, addPeerTimeout)
+	defer cancel()
+	if err := s.hmy.NodeAPI.ConnectPeer(ctx, *ai); err != nil {
+		DoMetricRPCQueryInfo(AddPeer, FailedNumber)
+		return false, err
+	}
+	return true, nil
+}
+
+// RemovePeer disconnects the peer of the given ID or multiaddr.
+func (s *PrivateAdminService) RemovePeer(ctx context.Context, url string) (bool, error) {
+	timer := DoMetricRPCRequest(RemovePeer)
+	defer DoRPCRequestDuration(RemovePeer, timer)
+
+	id, err := parsePeerIDOrAddr(url)
+	if err != nil {
+		DoMetricRPCQueryInfo(RemovePeer, FailedNumber)
+		return false, err
+	}
+	if err := s.hmy.NodeAPI.DisconnectPeer(id); err != nil {
+		DoMetricRPCQueryInfo(RemovePeer, FailedNumber)
+		return false, err
+	}
+	return true, nil
+}
+
+// newPeerInfoResult converts the given peer information into its RPC form.
+func newPeerInfoResult(info *p2p.PeerInfo, syncShards []uint32, score float64) *PeerInfoResult {
+	result := &PeerInfoResult{
+		ID:   info.ID.Pretty(),
+		Name: info.Agent,
+		Caps: info.Protocols,
+		Network: PeerNetworkResult{
+			Connections: len(info.Conns),
+		},
+		Protocols: map[string]*PeerProtoResult{
+			harmonyProtoName: {
+				Topics:     info.Topics,
+				SyncShards: syncShards,
+				Score:      score,
+			},
+		},
+	}
+	if len(info.Conns) > 0 {
+		conn := info.Conns[0]
+		result.Enode = fmt.Sprintf("%v/p2p/%v", conn.RemoteAddr, info.ID.Pretty())
+		result.Network.LocalAddress = conn.LocalAddr.String()
+		result.Network.RemoteAddress = conn.RemoteAddr.String()
+		result.Network.Inbound = conn.Inbound
+		result.Network.ConnectedAt = conn.Opened.Unix()
+	}
+	return result
+}
+
+// parsePeerIDOrAddr returns the peer ID of the given ID or multiaddr.
+func parsePeerIDOrAddr(s string) (peer.ID, error) {
+	if !strings.HasPrefix(s, "/") {
+		id, err := peer.Decode(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid peer id: %v", err)
+		}
+		return id, nil
+	}
+	addr, err := ma.NewMultiaddr(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid multiaddr: %v", err)
+	}
+	ai, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid peer multiaddr: %v", err)
+	}
+	return ai.ID, nil
+}
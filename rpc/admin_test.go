@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/p2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const testPeerID = "QmZJJx6AdaoEkGLrYG4JeLCKeCKDjnFz2wfHNHxAqFSGA9"
+
+func TestParsePeerIDOrAddr(t *testing.T) {
+	expID, _ := peer.Decode(testPeerID)
+	tests := []struct {
+		s      string
+		expErr bool
+	}{
+		{s: testPeerID},
+		{s: "/ip4/1.2.3.4/tcp/9000/p2p/" + testPeerID},
+		{s: "/ip4/1.2.3.4/tcp/9000", expErr: true},
+		{s: "not a peer", expErr: true},
+	}
+	for i, test := range tests {
+		id, err := parsePeerIDOrAddr(test.s)
+		if (err != nil) != test.expErr {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+			continue
+		}
+		if err == nil && id != expID {
+			t.Errorf("Test %v: unexpected peer %v / %v", i, id, expID)
+		}
+	}
+}
+
+func TestNewPeerInfoResult(t *testing.T) {
+	id, _ := peer.Decode(testPeerID)
+	opened := time.Unix(1600000000, 0)
+	info := &p2p.PeerInfo{
+		ID:        id,
+		Agent:     "harmony/v1",
+		Protocols: []string{"/meshsub/1.1.0"},
+		Topics:    []string{"hmy/mainnet/0.0.1/node/shard/0"},
+		Conns: []p2p.ConnInfo{{
+			LocalAddr:  ma.StringCast("/ip4/10.0.0.1/tcp/9000"),
+			RemoteAddr: ma.StringCast("/ip4/1.2.3.4/tcp/9000"),
+			Inbound:    true,
+			Opened:     opened,
+		}},
+	}
+	result := newPeerInfoResult(info, []uint32{0, 1}, -3)
+
+	if exp := "/ip4/1.2.3.4/tcp/9000/p2p/" + testPeerID; result.Enode != exp {
+		t.Errorf("unexpected enode %v / %v", result.Enode, exp)
+	}
+	expNetwork := PeerNetworkResult{
+		LocalAddress:  "/ip4/10.0.0.1/tcp/9000",
+		RemoteAddress: "/ip4/1.2.3.4/tcp/9000",
+		Inbound:       true,
+		Connections:   1,
+		ConnectedAt:   opened.Unix(),
+	}
+	if !reflect.DeepEqual(result.Network, expNetwork) {
+		t.Errorf("unexpected network %+v / %+v", result.Network, expNetwork)
+	}
+	proto := result.Protocols[harmonyProtoName]
+	if proto == nil || !reflect.DeepEqual(proto.SyncShards, []uint32{0, 1}) || proto.Score != -3 {
+		t.Errorf("unexpected harmony protocol %+v", proto)
+	}
+}
+
+// servesMethod returns whether an endpoint serving the modules of the APIs has the
+// method
+func servesMethod(t *testing.T, apis []rpc.API, modules []string, method string) bool {
+	t.Helper()
+	server := rpc.NewServer()
+	defer server.Stop()
+	for _, api := range apis {
+		for _, module := range modules {
+			if api.Namespace != module {
+				continue
+			}
+			if err := server.RegisterName(api.Namespace, api.Service); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+	// the method is called without arguments, which fails on the missing ones
+	// if it is served
+	err := client.Call(nil, method)
+	rpcErr, ok := err.(rpc.Error)
+	return !ok || rpcErr.ErrorCode() != -32601
+}
+
+func TestAdminMethodsServedOnAdminEndpointOnly(t *testing.T) {
+	harmony := &hmy.Harmony{}
+	apis := append(getAPIs(harmony, true, false, 0), getAuthAPIs(harmony, true, false, 0)...)
+	if servesMethod(t, getAdminAPIs(harmony), AdminModules, "admin_unknown") {
+		t.Fatal("unknown method served")
+	}
+	methods := []string{
+		"admin_verifyTries",
+		"admin_getPeerScores",
+		"admin_setPeerScore",
+		"admin_setConfig",
+		"admin_nodeInfo",
+		"admin_peers",
+		"admin_addPeer",
+		"admin_removePeer",
+	}
+	for _, method := range methods {
+		if servesMethod(t, apis, HTTPModules, method) || servesMethod(t, apis, WSModules, method) {
+			t.Errorf("%v is served on the public and auth endpoints", method)
+		}
+		if !servesMethod(t, getAdminAPIs(harmony), AdminModules, method) {
+			t.Errorf("%v is not served on the admin endpoint", method)
+		}
+	}
+}
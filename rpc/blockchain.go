@@ -63,6 +63,7 @@ func NewPublicBlockchainAPI(hmy *hmy.Harmony, version Version, limiterEnable boo
 		rpcRateLimitCounterVec.With(prometheus.Labels{
 			"limiter_name": name,
 		}).Add(float64(0))
+		registerRequestLimiter(limiter)
 	}
 
 	s := &PublicBlockchainService{
@@ -465,7 +466,7 @@ func (s *PublicBlockchainService) GetBlockReceipts(
 			r, err = v2.NewReceipt(tx, blockHash, block.NumberU64(), index, rmap[tx.Hash()])
 		case Eth:
 			if tx, ok := tx.(*types.Transaction); ok {
-				r, err = eth.NewReceipt(tx.ConvertToEth(), blockHash, block.NumberU64(), index, rmap[tx.Hash()])
+				r, err = eth.NewReceipt(tx.ConvertToEth(), blockHash, block.NumberU64(), index, rmap[tx.Hash()], block.BaseFee())
 			}
 		default:
 			return nil, ErrUnknownRPCVersion
@@ -768,7 +769,7 @@ func (s *PublicBlockchainService) GetHeaderByNumber(
 	return nil, err
 }
 
-// Result structs for GetProof
+// AccountResult is the account proof returned by GetProof
 type AccountResult struct {
 	Address      common.Address  `json:"address"`
 	AccountProof []string        `json:"accountProof"`
@@ -779,15 +780,18 @@ type AccountResult struct {
 	StorageProof []StorageResult `json:"storageProof"`
 }
 
+// StorageResult is the storage slot proof returned by GetProof
 type StorageResult struct {
 	Key   string       `json:"key"`
 	Value *hexutil.Big `json:"value"`
 	Proof []string     `json:"proof"`
 }
 
-// GetHeaderByNumberRLPHex returns block header at given number by `hex(rlp(header))`
+// GetProof returns the Merkle proof of the account and the given storage keys
+// at the given block. Proofs of historical blocks require an archival node.
 func (s *PublicBlockchainService) GetProof(
-	ctx context.Context, address common.Address, storageKeys []string, blockNumber BlockNumber) (ret *AccountResult, err error) {
+	ctx context.Context, address common.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash,
+) (ret *AccountResult, err error) {
 	timer := DoMetricRPCRequest(GetProof)
 	defer DoRPCRequestDuration(GetProof, timer)
 
@@ -803,22 +807,19 @@ func (s *PublicBlockchainService) GetProof(
 		return
 	}
 
-	// Process number based on version
-	blockNum := blockNumber.EthBlockNumber()
-
 	// Ensure valid block number
-	if s.version != Eth && isBlockGreaterThanLatest(s.hmy, blockNum) {
+	if blockNum, ok := blockNrOrHash.Number(); ok && s.version != Eth && isBlockGreaterThanLatest(s.hmy, blockNum) {
 		err = ErrRequestedBlockTooHigh
 		return
 	}
 
-	// Fetch Header
-	header, err := s.hmy.HeaderByNumber(ctx, blockNum)
-	if header == nil && err != nil {
+	state, header, err := s.hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		err = errors.Wrap(err, "state not available, proofs of pruned blocks require an archival node")
 		return
 	}
-	state, err := s.hmy.BeaconChain.StateAt(header.Root())
-	if state == nil || err != nil {
+	if state == nil || header == nil {
+		err = errors.New("block not found")
 		return
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
 	hmyCommon "github.com/harmony-one/harmony/internal/common"
@@ -62,8 +63,11 @@ func (s *PublicContractService) wait(limiter *rate.Limiter, ctx context.Context)
 
 // Call executes the given transaction on the state for the given block number.
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
+// The state and the block header the call is executed against can be amended with
+// the optional overrides.
 func (s *PublicContractService) Call(
 	ctx context.Context, args CallArgs, blockNumber BlockNumber,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
 ) (hexutil.Bytes, error) {
 	timer := DoMetricRPCRequest(Call)
 	defer DoRPCRequestDuration(Call, timer)
@@ -78,7 +82,7 @@ func (s *PublicContractService) Call(
 	}
 
 	// Execute call
-	result, err := DoEVMCall(ctx, s.hmy, args, blockNum, CallTimeout)
+	result, err := DoEVMCall(ctx, s.hmy, args, blockNum, overrides, blockOverrides, CallTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +91,48 @@ func (s *PublicContractService) Call(
 	return result.ReturnData, nil
 }
 
+// AccessListResult is the result of CreateAccessList
+type AccessListResult struct {
+	AccessList *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// CreateAccessList simulates the call on the state of the given block and returns
+// the EIP-2930 access list it would use, along with the gas used when the access
+// list is supplied. Execution errors are reported in the result.
+func (s *PublicContractService) CreateAccessList(
+	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+) (*AccessListResult, error) {
+	timer := DoMetricRPCRequest(CreateAccessList)
+	defer DoRPCRequestDuration(CreateAccessList, timer)
+
+	err := s.wait(s.limiterCall, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, RateLimitedNumber)
+		return nil, err
+	}
+
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CallTimeout)
+	defer cancel()
+
+	acl, gasUsed, vmErr, err := s.hmy.CreateAccessList(ctx, args.ToMessage(s.hmy.RPCGasCap()), bNrOrHash)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, FailedNumber)
+		return nil, err
+	}
+	result := &AccessListResult{AccessList: &acl, GasUsed: hexutil.Uint64(gasUsed)}
+	if vmErr != nil {
+		result.Error = vmErr.Error()
+	}
+	return result, nil
+}
+
 // GetCode returns the code stored at the given address in the state for the given block number.
 func (s *PublicContractService) GetCode(
 	ctx context.Context, addr string, blockNumber BlockNumber,
@@ -142,10 +188,10 @@ func (s *PublicContractService) GetStorageAt(
 	return res[:], state.Error()
 }
 
-// DoEVMCall executes an EVM call
+// DoEVMCall executes an EVM call, with the given state and block overrides if any
 func DoEVMCall(
 	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNum rpc.BlockNumber,
-	timeout time.Duration,
+	overrides *StateOverride, blockOverrides *BlockOverrides, timeout time.Duration,
 ) (core.ExecutionResult, error) {
 	defer func(start time.Time) {
 		utils.Logger().Debug().
@@ -161,7 +207,7 @@ func DoEVMCall(
 	}
 
 	// Create new call message
-	msg := args.ToMessage(hmy.RPCGasCap)
+	msg := args.ToMessage(hmy.RPCGasCap())
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -183,6 +229,14 @@ func DoEVMCall(
 		return core.ExecutionResult{}, err
 	}
 
+	// Apply the overrides after the EVM is set up, so that an overridden
+	// sender balance is not replaced by the unmetered call allowance.
+	if err := overrides.Apply(state); err != nil {
+		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
+		return core.ExecutionResult{}, err
+	}
+	blockOverrides.Apply(&evm.Context)
+
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
 	go func() {
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+)
+
+const (
+	// AccountRangeMaxResults is the maximum number of results to be returned
+	// by a single state dump request
+	AccountRangeMaxResults = 256
+)
+
+// PublicDebugStateService offers the debug RPC methods iterating the state trie
+// of a block, for state analytics and offline snapshots.
+type PublicDebugStateService struct {
+	hmy *hmy.Harmony
+}
+
+// NewPublicDebugStateAPI creates a new API for the RPC interface
+func NewPublicDebugStateAPI(hmy *hmy.Harmony) rpc.API {
+	return rpc.API{
+		Namespace: Debug.Namespace(),
+		Version:   APIVersion,
+		Service:   &PublicDebugStateService{hmy},
+		Public:    true,
+	}
+}
+
+// DumpBlock returns the accounts of the state at the given block, up to
+// AccountRangeMaxResults of them. Use AccountRange to page through the rest.
+func (s *PublicDebugStateService) DumpBlock(ctx context.Context, blockNr rpc.BlockNumber) (state.Dump, error) {
+	timer := DoMetricRPCRequest(DumpBlock)
+	defer DoRPCRequestDuration(DumpBlock, timer)
+
+	if blockNr == rpc.PendingBlockNumber {
+		DoMetricRPCQueryInfo(DumpBlock, FailedNumber)
+		return state.Dump{}, errors.New("pending state is not available")
+	}
+	stateDb, err := s.stateAt(ctx, rpc.BlockNumberOrHashWithNumber(blockNr))
+	if err != nil {
+		DoMetricRPCQueryInfo(DumpBlock, FailedNumber)
+		return state.Dump{}, err
+	}
+	opts := &state.DumpConfig{
+		OnlyWithAddresses: true,
+		Max:               AccountRangeMaxResults,
+	}
+	return stateDb.RawDump(opts), nil
+}
+
+// AccountRange returns up to maxResults accounts of the state at the given block,
+// starting at the given address hash, with their balances, nonces, code hashes
+// and optionally code and storage. The Next field of the result is the start key
+// of the following page. Accounts without a known address preimage are only
+// included if incompletes is set.
+func (s *PublicDebugStateService) AccountRange(
+	ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start hexutil.Bytes,
+	maxResults int, nocode, nostorage, incompletes bool,
+) (*AccountRangeResult, error) {
+	timer := DoMetricRPCRequest(AccountRange)
+	defer DoRPCRequestDuration(AccountRange, timer)
+
+	if number, ok := blockNrOrHash.Number(); ok && number == rpc.PendingBlockNumber {
+		DoMetricRPCQueryInfo(AccountRange, FailedNumber)
+		return nil, errors.New("pending state is not available")
+	}
+	stateDb, err := s.stateAt(ctx, blockNrOrHash)
+	if err != nil {
+		DoMetricRPCQueryInfo(AccountRange, FailedNumber)
+		return nil, err
+	}
+	if maxResults <= 0 || maxResults > AccountRangeMaxResults {
+		maxResults = AccountRangeMaxResults
+	}
+	opts := &state.DumpConfig{
+		SkipCode:          nocode,
+		SkipStorage:       nostorage,
+		OnlyWithAddresses: !incompletes,
+		Start:             start,
+		Max:               uint64(maxResults),
+	}
+	result := &AccountRangeResult{Accounts: []state.DumpAccount{}}
+	next := stateDb.DumpToCollector(result, opts)
+	if len(next) > 0 {
+		result.Next = next
+	}
+	return result, nil
+}
+
+// AccountRangeResult is a page of accounts returned by AccountRange. Accounts are
+// listed in address hash order, the address is omitted if its preimage is unknown.
+type AccountRangeResult struct {
+	Root     string              `json:"root"`
+	Accounts []state.DumpAccount `json:"accounts"`
+	Next     hexutil.Bytes       `json:"next,omitempty"` // empty if no more accounts
+}
+
+// OnRoot implements the state.DumpCollector interface
+func (r *AccountRangeResult) OnRoot(root common.Hash) {
+	r.Root = root.Hex()
+}
+
+// OnAccountStart implements the state.DumpCollector interface
+func (r *AccountRangeResult) OnAccountStart(addr common.Address, account state.DumpAccount) {
+}
+
+// OnAccountState implements the state.DumpCollector interface
+func (r *AccountRangeResult) OnAccountState(_ common.Address, _ hexutil.Bytes, key, value []byte) {
+}
+
+// OnAccountEnd implements the state.DumpCollector interface
+func (r *AccountRangeResult) OnAccountEnd(addr common.Address, account state.DumpAccount) {
+	if addr != (common.Address{}) || bytes.Equal(account.SecureKey, crypto.Keccak256(addr[:])) {
+		address := addr
+		account.Address = &address
+	}
+	r.Accounts = append(r.Accounts, account)
+}
+
+// stateAt returns the state of the given block, which requires an archival node
+// for blocks whose state has been pruned.
+func (s *PublicDebugStateService) stateAt(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.DB, error) {
+	stateDb, _, err := s.hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, fmt.Errorf("state not available: %w", err)
+	}
+	if stateDb == nil {
+		return nil, errors.New("block not found")
+	}
+	return stateDb, nil
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+)
+
+func TestAccountRangePaging(t *testing.T) {
+	stateDb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	for i := byte(1); i <= 5; i++ {
+		stateDb.SetBalance(common.BytesToAddress([]byte{i}), big.NewInt(int64(i)))
+	}
+	root, _ := stateDb.Commit(false)
+	stateDb, _ = state.New(root, stateDb.Database())
+
+	seen := make(map[common.Address]bool)
+	var start []byte
+	for page := 0; ; page++ {
+		result := &AccountRangeResult{}
+		next := stateDb.DumpToCollector(result, &state.DumpConfig{
+			SkipCode: true, SkipStorage: true, OnlyWithAddresses: true, Start: start, Max: 2,
+		})
+		if len(result.Accounts) > 2 {
+			t.Fatalf("page %d: too many accounts: %d", page, len(result.Accounts))
+		}
+		for _, account := range result.Accounts {
+			if account.Address == nil {
+				t.Fatalf("page %d: account without address", page)
+			}
+			if seen[*account.Address] {
+				t.Fatalf("page %d: duplicate account %x", page, *account.Address)
+			}
+			seen[*account.Address] = true
+		}
+		if len(next) == 0 {
+			break
+		}
+		start = next
+	}
+	if len(seen) != 5 {
+		t.Fatalf("expected 5 accounts, got %d", len(seen))
+	}
+}
@@ -2,26 +2,28 @@ package filters
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
 	hmy_rpc "github.com/harmony-one/harmony/rpc"
+	"github.com/harmony-one/harmony/rpc/eth"
+	v1 "github.com/harmony-one/harmony/rpc/v1"
 )
 
 var (
 	deadline = 5 * time.Minute // consider a filter inactive if it has not been polled for within deadline
 )
 
-const (
-	rpcGetLogsLimit = 1024
-)
-
 // filter is a helper struct that holds meta information over the filter type
 // and associated subscription in the event system.
 type filter struct {
@@ -42,15 +44,23 @@ type PublicFilterAPI struct {
 	filtersMu sync.Mutex
 	filters   map[rpc.ID]*filter
 	namespace string
+	limits    LogsLimits
+}
+
+// LogsLimits bounds the work of a single log query.
+type LogsLimits struct {
+	BlockRange  int // Maximum number of blocks a query can span, 0 for no limit
+	ResultLimit int // Maximum number of logs a query can return, 0 for no limit
 }
 
 // NewPublicFilterAPI returns a new PublicFilterAPI instance.
-func NewPublicFilterAPI(backend Backend, lightMode bool, namespace string) rpc.API {
+func NewPublicFilterAPI(backend Backend, lightMode bool, namespace string, limits LogsLimits) rpc.API {
 	api := &PublicFilterAPI{
 		backend:   backend,
 		events:    NewEventSystem(backend, lightMode, namespace == "eth"),
 		filters:   make(map[rpc.ID]*filter),
 		namespace: namespace,
+		limits:    limits,
 	}
 	go api.timeoutLoop()
 
@@ -131,7 +141,9 @@ func (api *PublicFilterAPI) NewPendingTransactionFilter() rpc.ID {
 
 // NewPendingTransactions creates a subscription that is triggered each time a transaction
 // enters the transaction pool and was signed from one of the transactions this nodes manages.
-func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Subscription, error) {
+func (api *PublicFilterAPI) NewPendingTransactions(
+	ctx context.Context, opts *PendingTransactionsOptions,
+) (*rpc.Subscription, error) {
 	timer := hmy_rpc.DoMetricRPCRequest(hmy_rpc.NewPendingTransactions)
 	defer hmy_rpc.DoRPCRequestDuration(hmy_rpc.NewPendingTransactions, timer)
 
@@ -139,20 +151,35 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	if opts == nil {
+		opts = &PendingTransactionsOptions{}
+	}
 
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
-		txHashes := make(chan []common.Hash, 128)
-		pendingTxSub := api.events.SubscribePendingTxs(txHashes)
+		pendingTxs := make(chan []types.PoolTransaction, 128)
+		pendingTxSub := api.events.SubscribeFullPendingTxs(pendingTxs)
 
 		for {
 			select {
-			case hashes := <-txHashes:
-				// To keep the original behaviour, send a single tx hash in one notification.
-				// TODO(dm) Send a batch of tx hashes in one notification
-				for _, h := range hashes {
-					_ = notifier.Notify(rpcSub.ID, h)
+			case txs := <-pendingTxs:
+				// To keep the original behaviour, send a single tx in one notification.
+				for _, poolTx := range txs {
+					tx, isPlain := poolTx.(*types.Transaction)
+					if opts.hasFilters() && (!isPlain || !opts.matches(tx)) {
+						continue
+					}
+					if !opts.FullTransactions {
+						_ = notifier.Notify(rpcSub.ID, poolTx.Hash())
+						continue
+					}
+					if !isPlain {
+						continue
+					}
+					if rpcTx, err := api.newRPCPendingTransaction(tx); err == nil {
+						_ = notifier.Notify(rpcSub.ID, rpcTx)
+					}
 				}
 			case <-rpcSub.Err():
 				pendingTxSub.Unsubscribe()
@@ -167,6 +194,56 @@ func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Su
 	return rpcSub, nil
 }
 
+// PendingTransactionsOptions are the optional parameters of the newPendingTransactions
+// subscription. The address and shard filters only let plain transactions through, and
+// staking transactions are only reported by hash, when no filter is set.
+type PendingTransactionsOptions struct {
+	FullTransactions bool             `json:"fullTransactions"` // send transaction objects instead of hashes
+	Addresses        []common.Address `json:"addresses"`        // only transactions from or to these addresses
+	ToShardID        *uint32          `json:"toShardID"`        // only transactions to this shard
+}
+
+// UnmarshalJSON accepts either the options object or a single boolean, as sent by
+// Ethereum clients, setting FullTransactions.
+func (opts *PendingTransactionsOptions) UnmarshalJSON(data []byte) error {
+	var fullTx bool
+	if err := json.Unmarshal(data, &fullTx); err == nil {
+		*opts = PendingTransactionsOptions{FullTransactions: fullTx}
+		return nil
+	}
+	type options PendingTransactionsOptions
+	return json.Unmarshal(data, (*options)(opts))
+}
+
+func (opts *PendingTransactionsOptions) hasFilters() bool {
+	return len(opts.Addresses) > 0 || opts.ToShardID != nil
+}
+
+// matches returns whether the plain transaction passes the address and shard filters.
+func (opts *PendingTransactionsOptions) matches(tx *types.Transaction) bool {
+	if opts.ToShardID != nil && tx.ToShardID() != *opts.ToShardID {
+		return false
+	}
+	if len(opts.Addresses) == 0 {
+		return true
+	}
+	from, err := tx.SenderAddress()
+	for _, addr := range opts.Addresses {
+		if (err == nil && from == addr) || (tx.To() != nil && *tx.To() == addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRPCPendingTransaction formats a pending plain transaction for the namespace of the API.
+func (api *PublicFilterAPI) newRPCPendingTransaction(tx *types.Transaction) (interface{}, error) {
+	if api.isEth() {
+		return eth.NewTransaction(tx.ConvertToEth(), common.Hash{}, 0, 0, 0)
+	}
+	return v1.NewTransaction(tx, common.Hash{}, 0, 0, 0)
+}
+
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 //
@@ -392,31 +469,116 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([
 		// Block filter requested, construct a single-shot filter
 		filter = NewBlockFilter(api.backend, *crit.BlockHash, crit.Addresses, crit.Topics, api.isEth())
 	} else {
-		// Convert the RPC block numbers into internal representations
-		begin := rpc.LatestBlockNumber.Int64()
-		if crit.FromBlock != nil {
-			begin = crit.FromBlock.Int64()
-		}
-		end := rpc.LatestBlockNumber.Int64()
-		if crit.ToBlock != nil {
-			end = crit.ToBlock.Int64()
+		begin, end, err := api.resolveRange(ctx, crit)
+		if err != nil {
+			hmy_rpc.DoMetricRPCQueryInfo(hmy_rpc.GetLogs, hmy_rpc.FailedNumber)
+			return nil, err
 		}
-		if end >= begin && end-begin > rpcGetLogsLimit {
-			return nil, fmt.Errorf("GetLogs query must be smaller than size %v", rpcGetLogsLimit)
+		if limit := int64(api.limits.BlockRange); limit > 0 && end-begin >= limit {
+			hmy_rpc.DoMetricRPCQueryInfo(hmy_rpc.GetLogs, hmy_rpc.FailedNumber)
+			return nil, fmt.Errorf(
+				"GetLogs query must span at most %v blocks, use %s_getLogsPage to paginate", limit, api.namespace,
+			)
 		}
 
 		// Construct the range filter
 		filter = NewRangeFilter(api.backend, begin, end, crit.Addresses, crit.Topics, api.isEth())
 	}
+	// Stop scanning as soon as the result limit is exceeded
+	if api.limits.ResultLimit > 0 {
+		filter.SetLimit(api.limits.ResultLimit + 1)
+	}
 	// Run the filter and return all the logs
 	logs, err := filter.Logs(ctx)
 	if err != nil {
 		hmy_rpc.DoMetricRPCQueryInfo(hmy_rpc.GetLogs, hmy_rpc.FailedNumber)
 		return nil, err
 	}
+	if api.limits.ResultLimit > 0 && len(logs) > api.limits.ResultLimit {
+		hmy_rpc.DoMetricRPCQueryInfo(hmy_rpc.GetLogs, hmy_rpc.FailedNumber)
+		return nil, fmt.Errorf(
+			"GetLogs query returned more than %v logs, use %s_getLogsPage to paginate", api.limits.ResultLimit, api.namespace,
+		)
+	}
 	return returnLogs(logs), err
 }
 
+// LogsPage is a page of logs returned by GetLogsPage.
+type LogsPage struct {
+	Logs      []*types.Log    `json:"logs"`
+	NextBlock *hexutil.Uint64 `json:"nextBlock"` // first block of the next page, null on the last page
+}
+
+// GetLogsPage returns the logs matching the given filter criteria like GetLogs, but instead of
+// failing when the query exceeds the block range or result limits, it returns the logs of the
+// blocks scanned so far along with the block to resume from. Pages always end at a block
+// boundary, so a page can exceed the result limit by the logs of its last block.
+func (api *PublicFilterAPI) GetLogsPage(ctx context.Context, crit FilterCriteria) (*LogsPage, error) {
+	timer := hmy_rpc.DoMetricRPCRequest(hmy_rpc.GetLogsPage)
+	defer hmy_rpc.DoRPCRequestDuration(hmy_rpc.GetLogsPage, timer)
+
+	if crit.BlockHash != nil {
+		filter := NewBlockFilter(api.backend, *crit.BlockHash, crit.Addresses, crit.Topics, api.isEth())
+		logs, err := filter.Logs(ctx)
+		if err != nil {
+			hmy_rpc.DoMetricRPCQueryInfo(hmy_rpc.GetLogsPage, hmy_rpc.FailedNumber)
+			return nil, err
+		}
+		return &LogsPage{Logs: returnLogs(logs)}, nil
+	}
+
+	begin, end, err := api.resolveRange(ctx, crit)
+	if err != nil {
+		hmy_rpc.DoMetricRPCQueryInfo(hmy_rpc.GetLogsPage, hmy_rpc.FailedNumber)
+		return nil, err
+	}
+	page := &LogsPage{Logs: []*types.Log{}}
+	if end < begin {
+		return page, nil
+	}
+	scanEnd := end
+	if limit := int64(api.limits.BlockRange); limit > 0 && end-begin >= limit {
+		scanEnd = begin + limit - 1
+	}
+	filter := NewRangeFilter(api.backend, begin, scanEnd, crit.Addresses, crit.Topics, api.isEth())
+	filter.SetLimit(api.limits.ResultLimit)
+	logs, err := filter.Logs(ctx)
+	if err != nil {
+		hmy_rpc.DoMetricRPCQueryInfo(hmy_rpc.GetLogsPage, hmy_rpc.FailedNumber)
+		return nil, err
+	}
+	page.Logs = returnLogs(logs)
+
+	next, ok := filter.NextBlock()
+	if !ok && scanEnd < end {
+		next, ok = uint64(scanEnd+1), true
+	}
+	if ok {
+		page.NextBlock = (*hexutil.Uint64)(&next)
+	}
+	return page, nil
+}
+
+// resolveRange returns the block range of the given filter criteria, with missing
+// bounds and the latest and pending block numbers resolved to the current head.
+func (api *PublicFilterAPI) resolveRange(ctx context.Context, crit FilterCriteria) (int64, int64, error) {
+	header, err := api.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return 0, 0, err
+	}
+	if header == nil {
+		return 0, 0, errors.New("latest header not found")
+	}
+	head := header.Number().Int64()
+	resolve := func(number *big.Int) int64 {
+		if number == nil || number.Sign() < 0 {
+			return head
+		}
+		return number.Int64()
+	}
+	return resolve(crit.FromBlock), resolve(crit.ToBlock), nil
+}
+
 // UninstallFilter removes the filter with the given filter id.
 //
 // https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_uninstallfilter
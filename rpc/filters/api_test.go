@@ -0,0 +1,58 @@
+package filters
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func TestPendingTransactionsOptionsUnmarshal(t *testing.T) {
+	var opts PendingTransactionsOptions
+	if err := json.Unmarshal([]byte(`true`), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if !opts.FullTransactions || opts.hasFilters() {
+		t.Errorf("unexpected options from boolean: %+v", opts)
+	}
+	input := `{"fullTransactions":false,"addresses":["0x0000000000000000000000000000000000000001"],"toShardID":1}`
+	if err := json.Unmarshal([]byte(input), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.FullTransactions || len(opts.Addresses) != 1 || opts.ToShardID == nil || *opts.ToShardID != 1 {
+		t.Errorf("unexpected options from object: %+v", opts)
+	}
+}
+
+func TestPendingTransactionsOptionsMatches(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	recipient := common.HexToAddress("0x02")
+	signer := types.NewEIP155Signer(big.NewInt(2))
+	tx, err := types.SignTx(
+		types.NewCrossShardTransaction(0, &recipient, 0, 1, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shard0, shard1 := uint32(0), uint32(1)
+	tests := []struct {
+		opts PendingTransactionsOptions
+		exp  bool
+	}{
+		{PendingTransactionsOptions{}, true},
+		{PendingTransactionsOptions{Addresses: []common.Address{sender}}, true},
+		{PendingTransactionsOptions{Addresses: []common.Address{recipient}}, true},
+		{PendingTransactionsOptions{Addresses: []common.Address{common.HexToAddress("0x03")}}, false},
+		{PendingTransactionsOptions{ToShardID: &shard1}, true},
+		{PendingTransactionsOptions{ToShardID: &shard0, Addresses: []common.Address{sender}}, false},
+	}
+	for i, test := range tests {
+		if got := test.opts.matches(tx); got != test.exp {
+			t.Errorf("test %d: have %v, want %v", i, got, test.exp)
+		}
+	}
+}
@@ -63,6 +63,7 @@ type Filter struct {
 
 	matcher *bloombits.Matcher
 	isEth   bool // Whether this is used for eth_ rpc.
+	limit   int  // Number of logs after which a range scan stops at the next block boundary, 0 for none
 }
 
 // NewRangeFilter creates a new filter which uses a bloom filter on blocks to
@@ -118,6 +119,21 @@ func newFilter(backend Backend, addresses []common.Address, topics [][]common.Ha
 	}
 }
 
+// SetLimit makes a range filter stop scanning at the end of the first block at which
+// the number of matching logs reaches the given limit. Zero disables the limit.
+func (f *Filter) SetLimit(limit int) {
+	f.limit = limit
+}
+
+// NextBlock returns the first block a range filter has not scanned yet, and false
+// if the whole range was scanned or the filter is a block filter.
+func (f *Filter) NextBlock() (uint64, bool) {
+	if f.block != (common.Hash{}) || f.begin < 0 || f.begin > f.end {
+		return 0, false
+	}
+	return uint64(f.begin), true
+}
+
 // Logs searches the blockchain for matching log entries, returning all from the
 // first block that contains matches, updating the start of the filter accordingly.
 func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
@@ -145,6 +161,7 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	end := uint64(f.end)
 	if f.end == -1 {
 		end = head
+		f.end = int64(head)
 	}
 	// Gather all indexed logs, and finish with non indexed ones
 	var (
@@ -234,6 +251,10 @@ func (f *Filter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, e
 			return logs, err
 		}
 		logs = append(logs, found...)
+		if f.limit > 0 && len(logs) >= f.limit {
+			f.begin++
+			return logs, nil
+		}
 	}
 	return logs, nil
 }
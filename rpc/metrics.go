@@ -30,6 +30,7 @@ const (
 	GetHeaderByNumber        = "GetHeaderByNumber"
 	GetHeaderByNumberRLPHex  = "GetHeaderByNumberRLPHex"
 	GetProof                 = "GetProof"
+	GetStateDiff             = "GetStateDiff"
 	GetCurrentUtilityMetrics = "GetCurrentUtilityMetrics"
 	GetSuperCommittees       = "GetSuperCommittees"
 	GetCurrentBadBlocks      = "GetCurrentBadBlocks"
@@ -41,10 +42,12 @@ const (
 	SetNodeToBackupMode      = "SetNodeToBackupMode"
 
 	// contract
-	GetCode      = "GetCode"
-	GetStorageAt = "GetStorageAt"
-	Call         = "Call"
-	DoEvmCall    = "DoEVMCall"
+	GetCode          = "GetCode"
+	GetStorageAt     = "GetStorageAt"
+	Call             = "Call"
+	DoEvmCall        = "DoEVMCall"
+	CreateAccessList = "CreateAccessList"
+	SimulateV1       = "SimulateV1"
 
 	// net
 	PeerCount  = "PeerCount"
@@ -60,6 +63,12 @@ const (
 	GetCurrentStakingErrorSink     = "GetCurrentStakingErrorSink"
 	GetPendingCXReceipts           = "GetPendingCXReceipts"
 
+	// txpool
+	TxPoolContent      = "TxPoolContent"
+	TxPoolInspect      = "TxPoolInspect"
+	TxPoolStatus       = "TxPoolStatus"
+	TxPoolReplacements = "TxPoolReplacements"
+
 	// staking
 	GetTotalStaking                         = "GetTotalStaking"
 	GetMedianRawStakeSnapshot               = "GetMedianRawStakeSnapshot"
@@ -68,9 +77,14 @@ const (
 	GetAllValidatorAddresses                = "GetAllValidatorAddresses"
 	GetValidatorKeys                        = "GetValidatorKeys"
 	GetAllValidatorInformation              = "GetAllValidatorInformation"
+	GetValidatorInformationPage             = "GetValidatorInformationPage"
 	GetAllValidatorInformationByBlockNumber = "GetAllValidatorInformationByBlockNumber"
 	GetValidatorInformation                 = "GetValidatorInformation"
 	GetValidatorInformationByBlockNumber    = "GetValidatorInformationByBlockNumber"
+	GetValidatorPerformance                 = "GetValidatorPerformance"
+	GetSlashingHistory                      = "GetSlashingHistory"
+	GetPendingUndelegations                 = "GetPendingUndelegations"
+	GetRewardHistory                        = "GetRewardHistory"
 	GetValidatorSelfDelegation              = "GetValidatorSelfDelegation"
 	GetValidatorTotalDelegation             = "GetValidatorTotalDelegation"
 	GetAllDelegationInformation             = "GetAllDelegationInformation"
@@ -81,16 +95,36 @@ const (
 	GetAvailableRedelegationBalance         = "GetAvailableRedelegationBalance"
 
 	// tracer
-	TraceChain         = "TraceChain"
-	TraceBlockByNumber = "TraceBlockByNumber"
-	TraceBlockByHash   = "TraceBlockByHash"
-	TraceBlock         = "TraceBlock"
-	TraceTransaction   = "TraceTransaction"
-	TraceCall          = "TraceCall"
+	TraceChain                  = "TraceChain"
+	TraceBlockByNumber          = "TraceBlockByNumber"
+	TraceBlockByHash            = "TraceBlockByHash"
+	TraceBlock                  = "TraceBlock"
+	TraceTransaction            = "TraceTransaction"
+	TraceCall                   = "TraceCall"
+	StandardTraceBlockToFile    = "StandardTraceBlockToFile"
+	StandardTraceBadBlockToFile = "StandardTraceBadBlockToFile"
+	GetBadBlocks                = "GetBadBlocks"
+	DumpBlock                   = "DumpBlock"
+	AccountRange                = "AccountRange"
+	IntermediateRoots           = "IntermediateRoots"
+
+	// admin
+	VerifyTries   = "VerifyTries"
+	GetPeerScores = "GetPeerScores"
+	SetPeerScore  = "SetPeerScore"
+	SetConfig     = "SetConfig"
+	NodeInfo      = "NodeInfo"
+	Peers         = "Peers"
+	AddPeer       = "AddPeer"
+	RemovePeer    = "RemovePeer"
 
 	// tracer parity
-	Block       = "Block"
-	Transaction = "Transaction"
+	Block                   = "Block"
+	Transaction             = "Transaction"
+	CallMany                = "CallMany"
+	RawTransaction          = "RawTransaction"
+	ReplayBlockTransactions = "ReplayBlockTransactions"
+	TraceFilter             = "TraceFilter"
 
 	// transaction
 	GetAccountNonce                            = "GetAccountNonce"
@@ -123,6 +157,7 @@ const (
 	Logs                        = "Logs"
 	NewFilter                   = "NewFilter"
 	GetLogs                     = "GetLogs"
+	GetLogsPage                 = "GetLogsPage"
 	UninstallFilter             = "UninstallFilter"
 	GetFilterLogs               = "GetFilterLogs"
 
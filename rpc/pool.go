@@ -81,14 +81,14 @@ func (s *PublicPoolService) SendRawTransaction(
 
 	if s.version == Eth {
 		ethTx := new(types.EthTransaction)
-		if err := rlp.DecodeBytes(encodedTx, ethTx); err != nil {
+		if err := ethTx.UnmarshalBinary(encodedTx); err != nil {
 			return common.Hash{}, err
 		}
 		txHash = ethTx.Hash()
 		tx = ethTx.ConvertToHmy()
 	} else {
 		tx = new(types.Transaction)
-		if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		if err := tx.UnmarshalBinary(encodedTx); err != nil {
 			return common.Hash{}, err
 		}
 		txHash = tx.Hash()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/harmony-one/harmony/eth/rpc"
@@ -13,6 +14,7 @@ import (
 	eth "github.com/harmony-one/harmony/rpc/eth"
 	v1 "github.com/harmony-one/harmony/rpc/v1"
 	v2 "github.com/harmony-one/harmony/rpc/v2"
+	"golang.org/x/time/rate"
 )
 
 // Version enum
@@ -36,30 +38,46 @@ const (
 	// WSPortOffset ..
 	WSPortOffset = 800
 
-	netNamespace   = "net"
-	netV1Namespace = "netv1"
-	netV2Namespace = "netv2"
-	web3Namespace  = "web3"
+	netNamespace    = "net"
+	netV1Namespace  = "netv1"
+	netV2Namespace  = "netv2"
+	web3Namespace   = "web3"
+	txPoolNamespace = "txpool"
+	adminNamespace  = "admin"
 )
 
 var (
 	// HTTPModules ..
-	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "explorer"}
+	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "explorer", txPoolNamespace}
 	// WSModules ..
-	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "web3"}
+	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "web3", txPoolNamespace}
+	// AdminModules are the modules of the localhost admin endpoint
+	AdminModules = []string{adminNamespace}
 
 	httpListener     net.Listener
 	httpHandler      *rpc.Server
 	wsListener       net.Listener
 	wsHandler        *rpc.Server
+	adminListener    net.Listener
+	adminHandler     *rpc.Server
 	httpEndpoint     = ""
 	httpAuthEndpoint = ""
 	wsEndpoint       = ""
 	wsAuthEndpoint   = ""
+	adminEndpoint    = ""
 	httpVirtualHosts = []string{"*"}
 	httpTimeouts     = rpc.DefaultHTTPTimeouts
 	httpOrigins      = []string{"*"}
 	wsOrigins        = []string{"*"}
+	// the admin endpoint allows no CORS and only the localhost name, so that web
+	// pages cannot reach it through the browser or by DNS rebinding
+	adminVirtualHosts = []string{"localhost"}
+
+	// handlers and requestLimiters are kept to change the limits of the started
+	// servers at runtime
+	handlers        []*rpc.Server
+	requestLimiters []*rate.Limiter
+	limitsLock      sync.Mutex
 )
 
 // Version of the RPC
@@ -74,27 +92,40 @@ func (n Version) Namespace() string {
 func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerConfig) error {
 	apis = append(apis, getAPIs(hmy, config.DebugEnabled, config.RateLimiterEnabled, config.RequestsPerSecond)...)
 	authApis := append(apis, getAuthAPIs(hmy, config.DebugEnabled, config.RateLimiterEnabled, config.RequestsPerSecond)...)
+	limits := rpc.ServerLimits{
+		BatchRequestLimit: config.BatchRequestLimit,
+		MethodRateLimits:  config.MethodRateLimits,
+	}
 
 	if config.HTTPEnabled {
 		httpEndpoint = fmt.Sprintf("%v:%v", config.HTTPIp, config.HTTPPort)
-		if err := startHTTP(apis); err != nil {
+		if err := startHTTP(apis, limits); err != nil {
 			return err
 		}
 
 		httpAuthEndpoint = fmt.Sprintf("%v:%v", config.HTTPIp, config.HTTPAuthPort)
-		if err := startAuthHTTP(authApis); err != nil {
+		if err := startAuthHTTP(authApis, limits); err != nil {
 			return err
 		}
 	}
 
 	if config.WSEnabled {
 		wsEndpoint = fmt.Sprintf("%v:%v", config.WSIp, config.WSPort)
-		if err := startWS(apis); err != nil {
+		if err := startWS(apis, limits); err != nil {
 			return err
 		}
 
 		wsAuthEndpoint = fmt.Sprintf("%v:%v", config.WSIp, config.WSAuthPort)
-		if err := startAuthWS(authApis); err != nil {
+		if err := startAuthWS(authApis, limits); err != nil {
+			return err
+		}
+	}
+
+	if config.AdminEnabled {
+		// the admin endpoint has no authentication, so it never listens on the
+		// public ip of the rpc
+		adminEndpoint = fmt.Sprintf("%v:%v", nodeconfig.DefaultLocalListenIP, config.AdminPort)
+		if err := startAdminHTTP(getAdminAPIs(hmy), limits); err != nil {
 			return err
 		}
 	}
@@ -130,13 +161,74 @@ func StopServers() error {
 		wsHandler.Stop()
 		wsHandler = nil
 	}
+	if adminListener != nil {
+		if err := adminListener.Close(); err != nil {
+			return err
+		}
+		adminListener = nil
+		utils.Logger().Info().
+			Str("url", fmt.Sprintf("http://%s", adminEndpoint)).
+			Msg("Admin HTTP endpoint closed")
+	}
+	if adminHandler != nil {
+		adminHandler.Stop()
+		adminHandler = nil
+	}
+	limitsLock.Lock()
+	handlers, requestLimiters = nil, nil
+	limitsLock.Unlock()
 	return nil
 }
 
+// SetRequestsPerSecond sets the global rate limit of the blockchain APIs of the
+// started servers. It has no effect if the rate limiter is disabled.
+func SetRequestsPerSecond(rps int) {
+	limitsLock.Lock()
+	defer limitsLock.Unlock()
+
+	for _, limiter := range requestLimiters {
+		limiter.SetLimit(rate.Limit(rps))
+		limiter.SetBurst(rps)
+	}
+}
+
+// SetMethodRateLimits replaces the per method rate limits of the started servers.
+func SetMethodRateLimits(rateLimits map[string]int) {
+	limitsLock.Lock()
+	defer limitsLock.Unlock()
+
+	for _, handler := range handlers {
+		handler.SetMethodRateLimits(rateLimits)
+	}
+}
+
+func registerHandler(handler *rpc.Server) {
+	limitsLock.Lock()
+	defer limitsLock.Unlock()
+
+	handlers = append(handlers, handler)
+}
+
+func registerRequestLimiter(limiter *rate.Limiter) {
+	limitsLock.Lock()
+	defer limitsLock.Unlock()
+
+	requestLimiters = append(requestLimiters, limiter)
+}
+
 func getAuthAPIs(hmy *hmy.Harmony, debugEnable bool, rateLimiterEnable bool, ratelimit int) []rpc.API {
 	return []rpc.API{
 		NewPublicTraceAPI(hmy, Debug), // Debug version means geth trace rpc
 		NewPublicTraceAPI(hmy, Trace), // Trace version means parity trace rpc
+		NewPublicDebugStateAPI(hmy),
+	}
+}
+
+// getAdminAPIs returns the API methods changing the node, which are only served
+// on the localhost admin endpoint
+func getAdminAPIs(hmy *hmy.Harmony) []rpc.API {
+	return []rpc.API{
+		NewPrivateAdminAPI(hmy),
 	}
 }
 
@@ -161,6 +253,7 @@ func getAPIs(hmy *hmy.Harmony, debugEnable bool, rateLimiterEnable bool, ratelim
 		NewPublicPoolAPI(hmy, Eth),
 		NewPublicStakingAPI(hmy, V1),
 		NewPublicStakingAPI(hmy, V2),
+		NewPublicTxPoolAPI(hmy),
 		// Legacy methods (subject to removal)
 		v1.NewPublicLegacyAPI(hmy, "hmy"),
 		eth.NewPublicEthService(hmy, "eth"),
@@ -185,13 +278,14 @@ func getAPIs(hmy *hmy.Harmony, debugEnable bool, rateLimiterEnable bool, ratelim
 	return publicAPIs
 }
 
-func startHTTP(apis []rpc.API) (err error) {
+func startHTTP(apis []rpc.API, limits rpc.ServerLimits) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpEndpoint, apis, HTTPModules, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpEndpoint, apis, HTTPModules, httpOrigins, httpVirtualHosts, httpTimeouts, limits,
 	)
 	if err != nil {
 		return err
 	}
+	registerHandler(httpHandler)
 
 	utils.Logger().Info().
 		Str("url", fmt.Sprintf("http://%s", httpEndpoint)).
@@ -202,13 +296,14 @@ func startHTTP(apis []rpc.API) (err error) {
 	return nil
 }
 
-func startAuthHTTP(apis []rpc.API) (err error) {
+func startAuthHTTP(apis []rpc.API, limits rpc.ServerLimits) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpAuthEndpoint, apis, HTTPModules, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpAuthEndpoint, apis, HTTPModules, httpOrigins, httpVirtualHosts, httpTimeouts, limits,
 	)
 	if err != nil {
 		return err
 	}
+	registerHandler(httpHandler)
 
 	utils.Logger().Info().
 		Str("url", fmt.Sprintf("http://%s", httpAuthEndpoint)).
@@ -219,11 +314,28 @@ func startAuthHTTP(apis []rpc.API) (err error) {
 	return nil
 }
 
-func startWS(apis []rpc.API) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, wsOrigins, true)
+func startAdminHTTP(apis []rpc.API, limits rpc.ServerLimits) (err error) {
+	adminListener, adminHandler, err = rpc.StartHTTPEndpoint(
+		adminEndpoint, apis, AdminModules, nil, adminVirtualHosts, httpTimeouts, limits,
+	)
+	if err != nil {
+		return err
+	}
+	registerHandler(adminHandler)
+
+	utils.Logger().Info().
+		Str("url", fmt.Sprintf("http://%s", adminEndpoint)).
+		Msg("Admin HTTP endpoint opened")
+	fmt.Printf("Started Admin-RPC server at: %v\n", adminEndpoint)
+	return nil
+}
+
+func startWS(apis []rpc.API, limits rpc.ServerLimits) (err error) {
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, wsOrigins, true, limits)
 	if err != nil {
 		return err
 	}
+	registerHandler(wsHandler)
 
 	utils.Logger().Info().
 		Str("url", fmt.Sprintf("ws://%s", wsListener.Addr())).
@@ -232,11 +344,12 @@ func startWS(apis []rpc.API) (err error) {
 	return nil
 }
 
-func startAuthWS(apis []rpc.API) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, wsOrigins, true)
+func startAuthWS(apis []rpc.API, limits rpc.ServerLimits) (err error) {
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, wsOrigins, true, limits)
 	if err != nil {
 		return err
 	}
+	registerHandler(wsHandler)
 
 	utils.Logger().Info().
 		Str("url", fmt.Sprintf("ws://%s", wsListener.Addr())).
@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy/tracers"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks simulated by a single request
+	maxSimulateBlocks = 256
+	// simulateBlockTime is the time in seconds between simulated blocks without a time override
+	simulateBlockTime = 2
+
+	// error codes of failed simulated calls, as in eth_simulateV1
+	simulateErrCodeReverted = 3
+	simulateErrCodeVMError  = -32015
+)
+
+// SimulateOpts are the options of SimulateV1. The blocks are simulated in order on
+// top of the base block, each one seeing the state changes of the previous ones.
+type SimulateOpts struct {
+	BlockStateCalls []SimulateBlock `json:"blockStateCalls"`
+	TraceCalls      bool            `json:"traceCalls"` // return the call frames of each call
+}
+
+// SimulateBlock is a bundle of calls executed in a simulated block, after the
+// state and block overrides are applied.
+type SimulateBlock struct {
+	BlockOverrides *BlockOverrides `json:"blockOverrides"`
+	StateOverrides *StateOverride  `json:"stateOverrides"`
+	Calls          []CallArgs      `json:"calls"`
+}
+
+// SimulateBlockResult is the result of a simulated block.
+type SimulateBlockResult struct {
+	Number    hexutil.Uint64       `json:"number"`
+	Timestamp hexutil.Uint64       `json:"timestamp"`
+	GasLimit  hexutil.Uint64       `json:"gasLimit"`
+	GasUsed   hexutil.Uint64       `json:"gasUsed"`
+	Calls     []SimulateCallResult `json:"calls"`
+}
+
+// SimulateCallResult is the result of a simulated call.
+type SimulateCallResult struct {
+	ReturnData hexutil.Bytes      `json:"returnData"`
+	Logs       []*types.Log       `json:"logs"`
+	GasUsed    hexutil.Uint64     `json:"gasUsed"`
+	Status     hexutil.Uint64     `json:"status"`
+	Error      *SimulateCallError `json:"error,omitempty"`
+	Traces     []json.RawMessage  `json:"traces,omitempty"`
+}
+
+// SimulateCallError is the reason a simulated call failed.
+type SimulateCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// newSimulateCallError maps the VM error of a failed call to its simulation error code.
+func newSimulateCallError(vmErr error) *SimulateCallError {
+	code := simulateErrCodeVMError
+	if errors.Is(vmErr, vm.ErrExecutionReverted) {
+		code = simulateErrCodeReverted
+	}
+	return &SimulateCallError{Code: code, Message: vmErr.Error()}
+}
+
+// SimulateV1 executes bundles of calls in a sequence of simulated blocks on top of the
+// given block, returning the return data, logs and gas used of each call. Calls of
+// the same and later blocks see the state changes of the previous calls.
+func (s *PublicContractService) SimulateV1(
+	ctx context.Context, opts SimulateOpts, blockNrOrHash *rpc.BlockNumberOrHash,
+) ([]*SimulateBlockResult, error) {
+	timer := DoMetricRPCRequest(SimulateV1)
+	defer DoRPCRequestDuration(SimulateV1, timer)
+
+	err := s.wait(s.limiterCall, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(SimulateV1, RateLimitedNumber)
+		return nil, err
+	}
+	if len(opts.BlockStateCalls) == 0 {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, errors.New("empty input")
+	}
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, fmt.Errorf("too many blocks, at most %d can be simulated", maxSimulateBlocks)
+	}
+
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	statedb, header, err := s.hmy.StateAndHeaderByNumberOrHash(ctx, bNrOrHash)
+	if err != nil {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, err
+	}
+	if statedb == nil || header == nil {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, errors.New("block not found")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CallTimeout)
+	defer cancel()
+
+	results := make([]*SimulateBlockResult, 0, len(opts.BlockStateCalls))
+	number, timestamp := header.Number().Uint64(), header.Time().Uint64()
+	for _, blockCalls := range opts.BlockStateCalls {
+		overrides := BlockOverrides{}
+		if blockCalls.BlockOverrides != nil {
+			overrides = *blockCalls.BlockOverrides
+		}
+		if overrides.Number == nil {
+			overrides.Number = (*hexutil.Big)(new(big.Int).SetUint64(number + 1))
+		}
+		if overrides.Number.ToInt().Uint64() <= number {
+			DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+			return nil, fmt.Errorf("block numbers must be increasing, %d follows %d", overrides.Number.ToInt(), number)
+		}
+		if overrides.Time == nil {
+			time := hexutil.Uint64(timestamp + simulateBlockTime)
+			overrides.Time = &time
+		}
+		if overrides.GasLimit == nil {
+			gasLimit := hexutil.Uint64(header.GasLimit())
+			overrides.GasLimit = &gasLimit
+		}
+		number, timestamp = overrides.Number.ToInt().Uint64(), uint64(*overrides.Time)
+
+		if err := blockCalls.StateOverrides.Apply(statedb); err != nil {
+			DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+			return nil, err
+		}
+		result, err := s.simulateBlock(ctx, statedb, header, &overrides, blockCalls.Calls, opts.TraceCalls)
+		if err != nil {
+			DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// simulateBlock executes the calls of a simulated block on the given state, with the
+// base header amended by the given overrides.
+func (s *PublicContractService) simulateBlock(
+	ctx context.Context, statedb *state.DB, header *block.Header, overrides *BlockOverrides,
+	calls []CallArgs, traceCalls bool,
+) (*SimulateBlockResult, error) {
+	result := &SimulateBlockResult{
+		Number:    hexutil.Uint64(overrides.Number.ToInt().Uint64()),
+		Timestamp: *overrides.Time,
+		GasLimit:  *overrides.GasLimit,
+		Calls:     make([]SimulateCallResult, 0, len(calls)),
+	}
+	gp := new(core.GasPool).AddGas(uint64(*overrides.GasLimit))
+	for i, args := range calls {
+		// Calls without a gas limit get the remaining gas of the block
+		if args.Gas == nil {
+			remaining := hexutil.Uint64(gp.Gas())
+			args.Gas = &remaining
+		}
+		msg := args.ToMessage(s.hmy.RPCGasCap())
+
+		// Identify the call by the hash of the unsigned transaction it stands for
+		nonce := statedb.GetNonce(msg.From())
+		var tx *types.Transaction
+		if msg.To() == nil {
+			tx = types.NewContractCreation(nonce, s.hmy.ShardID, msg.Value(), msg.Gas(), msg.GasPrice(), msg.Data())
+		} else {
+			tx = types.NewTransaction(nonce, *msg.To(), s.hmy.ShardID, msg.Value(), msg.Gas(), msg.GasPrice(), msg.Data())
+		}
+		statedb.Prepare(tx.Hash(), common.Hash{}, i)
+
+		vmctx := core.NewEVMContext(msg, header, s.hmy.BlockChain, nil)
+		overrides.Apply(&vmctx)
+		vmCfg := *s.hmy.BlockChain.GetVMConfig()
+		vmCfg.NoBaseFee = true
+		var tracer *tracers.ParityBlockTracer
+		if traceCalls {
+			tracer = &tracers.ParityBlockTracer{}
+			vmCfg.Debug = true
+			vmCfg.Tracer = tracer
+		}
+		evm := vm.NewEVM(vmctx, statedb, s.hmy.BlockChain.Config(), vmCfg)
+		stop := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				evm.Cancel()
+			case <-stop:
+			}
+		}()
+		execResult, err := core.ApplyMessage(evm, msg, gp)
+		close(stop)
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", CallTimeout)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		statedb.Finalise(true)
+
+		callResult := SimulateCallResult{
+			ReturnData: execResult.Return(),
+			Logs:       statedb.GetLogs(tx.Hash()),
+			GasUsed:    hexutil.Uint64(execResult.UsedGas),
+			Status:     1,
+		}
+		if callResult.Logs == nil {
+			callResult.Logs = []*types.Log{}
+		}
+		for _, log := range callResult.Logs {
+			log.BlockNumber = uint64(result.Number)
+		}
+		if execResult.Failed() {
+			callResult.Status = 0
+			callResult.Error = newSimulateCallError(execResult.VMErr)
+			if errors.Is(execResult.VMErr, vm.ErrExecutionReverted) {
+				callResult.ReturnData = execResult.Revert()
+			}
+		}
+		if tracer != nil {
+			if callResult.Traces, err = tracer.GetResult(); err != nil {
+				return nil, err
+			}
+		}
+		result.GasUsed += callResult.GasUsed
+		result.Calls = append(result.Calls, callResult)
+	}
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func TestSimulateOpts_UnmarshalJSON(t *testing.T) {
+	input := `{
+		"blockStateCalls": [
+			{
+				"blockOverrides": {"number": "0x10", "time": "0x20"},
+				"stateOverrides": {"0x0000000000000000000000000000000000000001": {"balance": "0x1"}},
+				"calls": [{"to": "0x0000000000000000000000000000000000000002", "data": "0x01"}]
+			},
+			{"calls": [{"to": "0x0000000000000000000000000000000000000002"}, {}]}
+		],
+		"traceCalls": true
+	}`
+	var opts SimulateOpts
+	if err := json.Unmarshal([]byte(input), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if !opts.TraceCalls {
+		t.Errorf("traceCalls not set")
+	}
+	if len(opts.BlockStateCalls) != 2 {
+		t.Fatalf("unexpected block count %v", len(opts.BlockStateCalls))
+	}
+	first := opts.BlockStateCalls[0]
+	if first.BlockOverrides == nil || first.BlockOverrides.Number.ToInt().Uint64() != 0x10 || uint64(*first.BlockOverrides.Time) != 0x20 {
+		t.Errorf("unexpected block overrides %+v", first.BlockOverrides)
+	}
+	if first.StateOverrides == nil || len(*first.StateOverrides) != 1 {
+		t.Errorf("unexpected state overrides %+v", first.StateOverrides)
+	}
+	if len(first.Calls) != 1 || first.Calls[0].To == nil {
+		t.Errorf("unexpected calls %+v", first.Calls)
+	}
+	second := opts.BlockStateCalls[1]
+	if second.BlockOverrides != nil || second.StateOverrides != nil || len(second.Calls) != 2 {
+		t.Errorf("unexpected second block %+v", second)
+	}
+}
+
+func TestNewSimulateCallError(t *testing.T) {
+	tests := []struct {
+		err     error
+		expCode int
+	}{
+		{vm.ErrExecutionReverted, simulateErrCodeReverted},
+		{vm.ErrOutOfGas, simulateErrCodeVMError},
+		{vm.ErrDepth, simulateErrCodeVMError},
+	}
+	for i, test := range tests {
+		callErr := newSimulateCallError(test.err)
+		if callErr.Code != test.expCode {
+			t.Errorf("Test %v: unexpected code %v", i, callErr.Code)
+		}
+		if callErr.Message != test.err.Error() {
+			t.Errorf("Test %v: unexpected message %v", i, callErr.Message)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 	"reflect"
+	"sort"
+	"strings"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,6 +18,8 @@ import (
 	"github.com/harmony-one/harmony/hmy"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking/effective"
+	"github.com/harmony-one/harmony/staking/slash"
 	staking "github.com/harmony-one/harmony/staking/types"
 	"github.com/pkg/errors"
 )
@@ -23,6 +28,10 @@ const (
 	validatorsPageSize = 100
 
 	validatorInfoCacheSize = 128
+
+	// validatorPerformanceMaxEpochs is the maximum number of epochs measured by a
+	// single validator performance request
+	validatorPerformanceMaxEpochs = 100
 )
 
 // PublicStakingService provides an API to access Harmony's staking services.
@@ -378,6 +387,125 @@ func (s *PublicStakingService) getAllValidatorInformation(
 	return validators, nil
 }
 
+// ValidatorPageQuery selects a page of validators for GetValidatorInformationPage.
+// Validators are ordered by address, and Cursor is the address of the last validator
+// of the previous page, empty for the first page. Limit is capped to validatorsPageSize.
+type ValidatorPageQuery struct {
+	Cursor      string `json:"cursor"`
+	Limit       int    `json:"limit"`
+	ElectedOnly bool   `json:"electedOnly"` // only validators in the current committee
+	EPoSStatus  string `json:"eposStatus"`  // e.g. "eligible to be elected next epoch"
+	Keyword     string `json:"keyword"`     // case insensitive match on the name, identity, website or details
+}
+
+// ValidatorPage is a page of validators returned by GetValidatorInformationPage.
+type ValidatorPage struct {
+	Validators []*staking.ValidatorRPCEnhanced `json:"validators"`
+	NextCursor string                          `json:"nextCursor,omitempty"` // empty on the last page
+}
+
+// matches returns whether the validator passes the filters of the query at the given epoch.
+func (q *ValidatorPageQuery) matches(wrapper *staking.ValidatorWrapper, epoch *big.Int) bool {
+	inCommittee := wrapper.LastEpochInCommittee.Cmp(epoch) >= 0
+	if q.ElectedOnly && !inCommittee {
+		return false
+	}
+	if q.EPoSStatus != "" &&
+		!strings.EqualFold(effective.ValidatorStatus(inCommittee, wrapper.Status).String(), q.EPoSStatus) {
+		return false
+	}
+	if q.Keyword != "" {
+		keyword := strings.ToLower(q.Keyword)
+		desc := wrapper.Validator.Description
+		found := false
+		for _, field := range []string{desc.Name, desc.Identity, desc.Website, desc.Details} {
+			if strings.Contains(strings.ToLower(field), keyword) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// GetValidatorInformationPage returns information about the validators matching the filters
+// of the query at the latest block, a page at a time. The cursor of the next page is the
+// address of the last validator of the page, so pages stay stable as validators join.
+func (s *PublicStakingService) GetValidatorInformationPage(
+	ctx context.Context, query ValidatorPageQuery,
+) (*ValidatorPage, error) {
+	timer := DoMetricRPCRequest(GetValidatorInformationPage)
+	defer DoRPCRequestDuration(GetValidatorInformationPage, timer)
+
+	err := s.wait(s.limiterGetAllValidatorInformation, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetValidatorInformationPage, RateLimitedNumber)
+		return nil, err
+	}
+
+	if !isBeaconShard(s.hmy) {
+		DoMetricRPCQueryInfo(GetValidatorInformationPage, FailedNumber)
+		return nil, ErrNotBeaconShard
+	}
+	limit := query.Limit
+	if limit <= 0 || limit > validatorsPageSize {
+		limit = validatorsPageSize
+	}
+	var cursor *common.Address
+	if query.Cursor != "" {
+		addr, err := internal_common.ParseAddr(query.Cursor)
+		if err != nil {
+			DoMetricRPCQueryInfo(GetValidatorInformationPage, FailedNumber)
+			return nil, err
+		}
+		cursor = &addr
+	}
+
+	blk, err := s.hmy.BlockByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetValidatorInformationPage, FailedNumber)
+		return nil, errors.Wrapf(err, "could not retrieve the latest blk information")
+	}
+	addresses := append([]common.Address{}, s.hmy.GetAllValidatorAddresses()...)
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(addresses), func(i int) bool {
+			return bytes.Compare(addresses[i][:], cursor[:]) > 0
+		})
+	}
+
+	// Filter on the stored validator first, only the page is fully computed
+	page := &ValidatorPage{Validators: []*staking.ValidatorRPCEnhanced{}}
+	for _, addr := range addresses[start:] {
+		wrapper, err := s.hmy.BlockChain.ReadValidatorInformationAtRoot(addr, blk.Root())
+		if err != nil {
+			DoMetricRPCQueryInfo(GetValidatorInformationPage, FailedNumber)
+			return nil, err
+		}
+		if !query.matches(wrapper, blk.Epoch()) {
+			continue
+		}
+		if len(page.Validators) == limit {
+			last := page.Validators[limit-1].Wrapper.Address
+			page.NextCursor, _ = internal_common.AddressToBech32(last)
+			break
+		}
+		validatorInfo, err := s.hmy.GetValidatorInformation(addr, blk)
+		if err != nil {
+			DoMetricRPCQueryInfo(GetValidatorInformationPage, FailedNumber)
+			return nil, err
+		}
+		page.Validators = append(page.Validators, validatorInfo)
+	}
+	return page, nil
+}
+
 // GetValidatorInformation returns information about a validator.
 func (s *PublicStakingService) GetValidatorInformation(
 	ctx context.Context, address string,
@@ -454,6 +582,109 @@ func (s *PublicStakingService) GetValidatorInformationByBlockNumber(
 	return NewStructuredResponse(validatorInfo)
 }
 
+// GetValidatorPerformance returns the signing percentage, missed blocks, effective
+// stake, reward and APR of a validator for each epoch from fromEpoch to toEpoch,
+// inclusive.
+func (s *PublicStakingService) GetValidatorPerformance(
+	ctx context.Context, address string, fromEpoch, toEpoch int64,
+) ([]*staking.EpochPerformance, error) {
+	timer := DoMetricRPCRequest(GetValidatorPerformance)
+	defer DoRPCRequestDuration(GetValidatorPerformance, timer)
+
+	if !isBeaconShard(s.hmy) {
+		DoMetricRPCQueryInfo(GetValidatorPerformance, FailedNumber)
+		return nil, ErrNotBeaconShard
+	}
+	if fromEpoch < 0 || toEpoch < fromEpoch || toEpoch-fromEpoch >= validatorPerformanceMaxEpochs {
+		DoMetricRPCQueryInfo(GetValidatorPerformance, FailedNumber)
+		return nil, errors.Errorf(
+			"invalid epoch range [%d, %d], at most %d epochs are measured",
+			fromEpoch, toEpoch, validatorPerformanceMaxEpochs,
+		)
+	}
+	addr, err := internal_common.ParseAddr(address)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetValidatorPerformance, FailedNumber)
+		return nil, err
+	}
+	perfs, err := s.hmy.GetValidatorPerformance(addr, uint64(fromEpoch), uint64(toEpoch))
+	if err != nil {
+		DoMetricRPCQueryInfo(GetValidatorPerformance, FailedNumber)
+		return nil, err
+	}
+
+	// Response output is the same for all versions
+	return perfs, nil
+}
+
+// GetSlashingHistory returns the slashes applied from epoch fromEpoch to toEpoch,
+// inclusive, with their evidence and the amounts taken from each delegation. An
+// empty validator address returns the slashes of all the validators.
+func (s *PublicStakingService) GetSlashingHistory(
+	ctx context.Context, validator string, fromEpoch, toEpoch int64,
+) ([]*slash.HistoryEntry, error) {
+	timer := DoMetricRPCRequest(GetSlashingHistory)
+	defer DoRPCRequestDuration(GetSlashingHistory, timer)
+
+	if !isBeaconShard(s.hmy) {
+		DoMetricRPCQueryInfo(GetSlashingHistory, FailedNumber)
+		return nil, ErrNotBeaconShard
+	}
+	if fromEpoch < 0 || toEpoch < fromEpoch {
+		DoMetricRPCQueryInfo(GetSlashingHistory, FailedNumber)
+		return nil, errors.Errorf("invalid epoch range [%d, %d]", fromEpoch, toEpoch)
+	}
+	var addr *common.Address
+	if validator != "" {
+		parsed, err := internal_common.ParseAddr(validator)
+		if err != nil {
+			DoMetricRPCQueryInfo(GetSlashingHistory, FailedNumber)
+			return nil, err
+		}
+		addr = &parsed
+	}
+	history, err := s.hmy.GetSlashingHistory(addr, uint64(fromEpoch), uint64(toEpoch))
+	if err != nil {
+		DoMetricRPCQueryInfo(GetSlashingHistory, FailedNumber)
+		return nil, err
+	}
+
+	// Response output is the same for all versions
+	return history, nil
+}
+
+// GetRewardHistory returns the rewards paid to the given delegator from epoch
+// fromEpoch to toEpoch, inclusive, itemized by epoch and validator. The rewards are
+// only available on nodes indexing the reward history.
+func (s *PublicStakingService) GetRewardHistory(
+	ctx context.Context, delegator string, fromEpoch, toEpoch int64,
+) ([]*staking.RewardHistoryEntry, error) {
+	timer := DoMetricRPCRequest(GetRewardHistory)
+	defer DoRPCRequestDuration(GetRewardHistory, timer)
+
+	if !isBeaconShard(s.hmy) {
+		DoMetricRPCQueryInfo(GetRewardHistory, FailedNumber)
+		return nil, ErrNotBeaconShard
+	}
+	if fromEpoch < 0 || toEpoch < fromEpoch {
+		DoMetricRPCQueryInfo(GetRewardHistory, FailedNumber)
+		return nil, errors.Errorf("invalid epoch range [%d, %d]", fromEpoch, toEpoch)
+	}
+	addr, err := internal_common.ParseAddr(delegator)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetRewardHistory, FailedNumber)
+		return nil, err
+	}
+	history, err := s.hmy.GetRewardHistory(addr, uint64(fromEpoch), uint64(toEpoch))
+	if err != nil {
+		DoMetricRPCQueryInfo(GetRewardHistory, FailedNumber)
+		return nil, err
+	}
+
+	// Response output is the same for all versions
+	return history, nil
+}
+
 // GetValidatorSelfDelegation returns validator stake.
 func (s *PublicStakingService) GetValidatorSelfDelegation(
 	ctx context.Context, address string,
@@ -627,6 +858,51 @@ func (s *PublicStakingService) GetDelegationsByDelegator(
 	return result, nil
 }
 
+// GetPendingUndelegations returns the pending undelegations of a delegator address
+// across all validators, with the epoch, block and estimated unix time at which
+// their funds unlock.
+func (s *PublicStakingService) GetPendingUndelegations(
+	ctx context.Context, address string,
+) ([]StructuredResponse, error) {
+	timer := DoMetricRPCRequest(GetPendingUndelegations)
+	defer DoRPCRequestDuration(GetPendingUndelegations, timer)
+
+	if !isBeaconShard(s.hmy) {
+		DoMetricRPCQueryInfo(GetPendingUndelegations, FailedNumber)
+		return nil, ErrNotBeaconShard
+	}
+	delegatorAddress, err := internal_common.ParseAddr(address)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetPendingUndelegations, FailedNumber)
+		return nil, err
+	}
+	pending, err := s.hmy.GetPendingUndelegations(delegatorAddress)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetPendingUndelegations, FailedNumber)
+		return nil, err
+	}
+
+	result := []StructuredResponse{}
+	for _, undelegation := range pending {
+		valAddr, _ := internal_common.AddressToBech32(undelegation.Validator)
+		// Response output is the same for all versions
+		res, err := NewStructuredResponse(PendingUndelegation{
+			ValidatorAddress:    valAddr,
+			Amount:              undelegation.Amount,
+			Epoch:               undelegation.Epoch,
+			UnlockEpoch:         undelegation.UnlockEpoch,
+			UnlockBlock:         undelegation.UnlockBlock,
+			EstimatedUnlockTime: undelegation.UnlockTime,
+		})
+		if err != nil {
+			DoMetricRPCQueryInfo(GetPendingUndelegations, FailedNumber)
+			return nil, err
+		}
+		result = append(result, res)
+	}
+	return result, nil
+}
+
 // GetDelegationsByDelegatorByBlockNumber returns list of delegations for a delegator address at given block number
 func (s *PublicStakingService) GetDelegationsByDelegatorByBlockNumber(
 	ctx context.Context, aol AddressOrList, blockNumber BlockNumber,
@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/harmony-one/harmony/staking/effective"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+func TestValidatorPageQueryMatches(t *testing.T) {
+	newWrapper := func(name string, lastEpoch int64, status effective.Eligibility) *staking.ValidatorWrapper {
+		return &staking.ValidatorWrapper{Validator: staking.Validator{
+			Description:          staking.Description{Name: name, Website: "https://" + name + ".example"},
+			LastEpochInCommittee: big.NewInt(lastEpoch),
+			Status:               status,
+		}}
+	}
+	epoch := big.NewInt(10)
+	elected := newWrapper("Alpha Staking", 10, effective.Active)
+	candidate := newWrapper("beta", 8, effective.Active)
+	inactive := newWrapper("gamma", 5, effective.Inactive)
+
+	tests := []struct {
+		query ValidatorPageQuery
+		exp   []bool // elected, candidate, inactive
+	}{
+		{ValidatorPageQuery{}, []bool{true, true, true}},
+		{ValidatorPageQuery{ElectedOnly: true}, []bool{true, false, false}},
+		{ValidatorPageQuery{EPoSStatus: "eligible to be elected next epoch"}, []bool{false, true, false}},
+		{ValidatorPageQuery{EPoSStatus: "NOT ELIGIBLE TO BE ELECTED NEXT EPOCH"}, []bool{false, false, true}},
+		{ValidatorPageQuery{Keyword: "staking"}, []bool{true, false, false}},
+		{ValidatorPageQuery{Keyword: "example"}, []bool{true, true, true}},
+		{ValidatorPageQuery{Keyword: "beta", ElectedOnly: true}, []bool{false, false, false}},
+	}
+	for i, test := range tests {
+		for j, wrapper := range []*staking.ValidatorWrapper{elected, candidate, inactive} {
+			if got := test.query.matches(wrapper, epoch); got != test.exp[j] {
+				t.Errorf("test %d: validator %d: have %v, want %v", i, j, got, test.exp[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+)
+
+// StateDiffResult is the change of the state made by a block, as a whole and by
+// each of its transactions.
+type StateDiffResult struct {
+	BlockHash    common.Hash          `json:"blockHash"`
+	BlockNumber  hexutil.Uint64       `json:"blockNumber"`
+	Accounts     []*AccountDiffResult `json:"accounts"`
+	Transactions []*TxStateDiffResult `json:"transactions"`
+}
+
+// TxStateDiffResult is the change of the state made by a transaction. The changes
+// made by the block after its transactions have no transaction hash.
+type TxStateDiffResult struct {
+	TransactionHash *common.Hash         `json:"transactionHash"`
+	Accounts        []*AccountDiffResult `json:"accounts"`
+}
+
+// AccountDiffResult is the change of an account. The values of an account which
+// does not exist are zero.
+type AccountDiffResult struct {
+	Address        common.Address       `json:"address"`
+	Existed        bool                 `json:"existed"`
+	Exists         bool                 `json:"exists"`
+	BalanceBefore  *hexutil.Big         `json:"balanceBefore"`
+	BalanceAfter   *hexutil.Big         `json:"balanceAfter"`
+	NonceBefore    hexutil.Uint64       `json:"nonceBefore"`
+	NonceAfter     hexutil.Uint64       `json:"nonceAfter"`
+	CodeHashBefore common.Hash          `json:"codeHashBefore"`
+	CodeHashAfter  common.Hash          `json:"codeHashAfter"`
+	Storage        []*StorageDiffResult `json:"storage"`
+}
+
+// StorageDiffResult is the change of a storage slot.
+type StorageDiffResult struct {
+	Key    common.Hash `json:"key"`
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// newAccountDiffResults converts the given account changes into their RPC form.
+func newAccountDiffResults(accounts []*types.AccountDiff) []*AccountDiffResult {
+	results := make([]*AccountDiffResult, 0, len(accounts))
+	for _, account := range accounts {
+		result := &AccountDiffResult{
+			Address:        account.Address,
+			Existed:        account.Existed,
+			Exists:         account.Exists,
+			BalanceBefore:  (*hexutil.Big)(account.BalanceBefore),
+			BalanceAfter:   (*hexutil.Big)(account.BalanceAfter),
+			NonceBefore:    hexutil.Uint64(account.NonceBefore),
+			NonceAfter:     hexutil.Uint64(account.NonceAfter),
+			CodeHashBefore: account.CodeHashBefore,
+			CodeHashAfter:  account.CodeHashAfter,
+			Storage:        make([]*StorageDiffResult, 0, len(account.Storage)),
+		}
+		for _, slot := range account.Storage {
+			result.Storage = append(result.Storage, &StorageDiffResult{
+				Key: slot.Key, Before: slot.Before, After: slot.After,
+			})
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// GetStateDiff returns the accounts and storage slots changed by the given block,
+// with their values before and after the block and each of its transactions. The
+// changes are read from the state diff index if the block is indexed, or computed
+// by executing the block again otherwise.
+func (s *PublicBlockchainService) GetStateDiff(
+	ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash,
+) (*StateDiffResult, error) {
+	timer := DoMetricRPCRequest(GetStateDiff)
+	defer DoRPCRequestDuration(GetStateDiff, timer)
+
+	err := s.wait(s.limiter, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetStateDiff, RateLimitedNumber)
+		return nil, err
+	}
+	block, err := s.hmy.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetStateDiff, FailedNumber)
+		return nil, err
+	}
+	if block == nil {
+		DoMetricRPCQueryInfo(GetStateDiff, FailedNumber)
+		return nil, fmt.Errorf("block %v not found", blockNrOrHash)
+	}
+
+	_, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetStateDiff, FailedNumber)
+		return nil, err
+	}
+	defer release()
+	diffs, err := s.hmy.StateDiffs(block)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetStateDiff, FailedNumber)
+		return nil, err
+	}
+
+	result := &StateDiffResult{
+		BlockHash:    block.Hash(),
+		BlockNumber:  hexutil.Uint64(block.NumberU64()),
+		Accounts:     newAccountDiffResults(types.MergeStateDiffs(diffs)),
+		Transactions: make([]*TxStateDiffResult, 0, len(diffs)),
+	}
+	for _, diff := range diffs {
+		txResult := &TxStateDiffResult{Accounts: newAccountDiffResults(diff.Accounts)}
+		if diff.TxHash != (common.Hash{}) {
+			txHash := diff.TxHash
+			txResult.TransactionHash = &txHash
+		}
+		result.Transactions = append(result.Transactions, txResult)
+	}
+	return result, nil
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/rawdb"
@@ -104,8 +105,19 @@ func (s *PublicTracerService) TraceBlockByNumber(ctx context.Context, number rpc
 	timer := DoMetricRPCRequest(TraceBlockByNumber)
 	defer DoRPCRequestDuration(TraceBlockByNumber, timer)
 
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceBlockByNumber, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
 	// Fetch the block that we want to trace
 	block := s.hmy.BlockChain.GetBlockByNumber(uint64(number))
+	if block == nil {
+		DoMetricRPCQueryInfo(TraceBlockByNumber, FailedNumber)
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
 
 	return s.hmy.TraceBlock(ctx, block, config)
 }
@@ -116,6 +128,13 @@ func (s *PublicTracerService) TraceBlockByHash(ctx context.Context, hash common.
 	timer := DoMetricRPCRequest(TraceBlockByHash)
 	defer DoRPCRequestDuration(TraceBlockByHash, timer)
 
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceBlockByHash, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
 	block := s.hmy.BlockChain.GetBlockByHash(hash)
 	if block == nil {
 		DoMetricRPCQueryInfo(TraceBlockByHash, FailedNumber)
@@ -130,6 +149,13 @@ func (s *PublicTracerService) TraceBlock(ctx context.Context, blob []byte, confi
 	timer := DoMetricRPCRequest(TraceBlock)
 	defer DoRPCRequestDuration(TraceBlock, timer)
 
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceBlock, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
 	block := new(types.Block)
 	if err := rlp.Decode(bytes.NewReader(blob), block); err != nil {
 		DoMetricRPCQueryInfo(TraceBlock, FailedNumber)
@@ -144,6 +170,13 @@ func (s *PublicTracerService) TraceTransaction(ctx context.Context, hash common.
 	timer := DoMetricRPCRequest(TraceTransaction)
 	defer DoRPCRequestDuration(TraceTransaction, timer)
 
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceTransaction, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
 	// Retrieve the transaction and assemble its EVM context
 	tx, blockHash, _, index := rawdb.ReadTransaction(s.hmy.ChainDb(), hash)
 	if tx == nil {
@@ -154,6 +187,7 @@ func (s *PublicTracerService) TraceTransaction(ctx context.Context, hash common.
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
+	reexec = s.hmy.LimitTraceReexec(reexec)
 	// Retrieve the block
 	block := s.hmy.BlockChain.GetBlockByHash(blockHash)
 	if block == nil {
@@ -169,38 +203,179 @@ func (s *PublicTracerService) TraceTransaction(ctx context.Context, hash common.
 	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, config)
 }
 
+// IntermediateRoots re-executes the block with the given hash and returns the
+// intermediate state root after each transaction, which helps to find where the
+// state of diverging nodes starts to differ.
+func (s *PublicTracerService) IntermediateRoots(ctx context.Context, hash common.Hash, config *hmy.TraceConfig) ([]common.Hash, error) {
+	timer := DoMetricRPCRequest(IntermediateRoots)
+	defer DoRPCRequestDuration(IntermediateRoots, timer)
+
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(IntermediateRoots, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	block := s.hmy.BlockChain.GetBlockByHash(hash)
+	if block == nil {
+		DoMetricRPCQueryInfo(IntermediateRoots, FailedNumber)
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	return s.hmy.IntermediateRoots(ctx, block, config)
+}
+
+// StandardTraceBlockToFile dumps the structured logs created during the execution of
+// EVM to the local file system and returns a list of files to the caller.
+func (s *PublicTracerService) StandardTraceBlockToFile(ctx context.Context, hash common.Hash, config *hmy.StdTraceConfig) ([]string, error) {
+	timer := DoMetricRPCRequest(StandardTraceBlockToFile)
+	defer DoRPCRequestDuration(StandardTraceBlockToFile, timer)
+
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(StandardTraceBlockToFile, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	block := s.hmy.BlockChain.GetBlockByHash(hash)
+	if block == nil {
+		DoMetricRPCQueryInfo(StandardTraceBlockToFile, FailedNumber)
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	return s.hmy.StandardTraceBlockToFile(ctx, block, config)
+}
+
+// StandardTraceBadBlockToFile dumps the structured logs created during the execution of
+// EVM against a block pulled from the pool of bad ones to the local file system and
+// returns a list of files to the caller.
+func (s *PublicTracerService) StandardTraceBadBlockToFile(ctx context.Context, hash common.Hash, config *hmy.StdTraceConfig) ([]string, error) {
+	timer := DoMetricRPCRequest(StandardTraceBadBlockToFile)
+	defer DoRPCRequestDuration(StandardTraceBadBlockToFile, timer)
+
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(StandardTraceBadBlockToFile, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	for _, badBlock := range s.hmy.BlockChain.BadBlocks() {
+		if badBlock.Block != nil && badBlock.Block.Hash() == hash {
+			return s.hmy.StandardTraceBlockToFile(ctx, badBlock.Block, config)
+		}
+	}
+	DoMetricRPCQueryInfo(StandardTraceBadBlockToFile, FailedNumber)
+	return nil, fmt.Errorf("bad block %#x not found", hash)
+}
+
+// BadBlockArgs represents the entries in the list returned by GetBadBlocks.
+type BadBlockArgs struct {
+	Hash       common.Hash        `json:"hash"`
+	Number     uint64             `json:"number"`
+	Reason     string             `json:"reason"`
+	Root       common.Hash        `json:"root"`
+	LocalRoot  common.Hash        `json:"localRoot"`
+	RejectedAt time.Time          `json:"rejectedAt"`
+	Block      StructuredResponse `json:"block"`
+	RLP        string             `json:"rlp"`
+}
+
+// GetBadBlocks returns the most recent blocks that failed validation on this
+// node, with the reject reason, the received and the locally computed state root.
+func (s *PublicTracerService) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error) {
+	timer := DoMetricRPCRequest(GetBadBlocks)
+	defer DoRPCRequestDuration(GetBadBlocks, timer)
+
+	blocks := s.hmy.BlockChain.BadBlocks()
+	results := make([]*BadBlockArgs, 0, len(blocks))
+	for _, badBlock := range blocks {
+		blk := badBlock.Block
+		result := &BadBlockArgs{
+			Hash:       blk.Hash(),
+			Number:     blk.NumberU64(),
+			Reason:     badBlock.Reason.Error(),
+			Root:       blk.Root(),
+			LocalRoot:  badBlock.LocalRoot,
+			RejectedAt: badBlock.Time,
+		}
+		if enc, err := rlp.EncodeToBytes(blk); err == nil {
+			result.RLP = hexutil.Encode(enc)
+		} else {
+			result.RLP = err.Error()
+		}
+		rpcBlock, err := NewStructuredResponse(blk.Header())
+		if err != nil {
+			DoMetricRPCQueryInfo(GetBadBlocks, FailedNumber)
+			return nil, err
+		}
+		result.Block = rpcBlock
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// TraceCallConfig is the config for traceCall API. It holds two more
+// fields to override the state and the block header for tracing.
+type TraceCallConfig struct {
+	hmy.TraceConfig
+	StateOverrides *StateOverride
+	BlockOverrides *BlockOverrides
+}
+
 // TraceCall lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
-// You can provide -2 as a block number to trace on top of the pending block.
-// NOTE: Our version only supports block number as an input
-func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, config *hmy.TraceConfig) (interface{}, error) {
+// The block can be given either by number or by hash, and the state and block header it is executed
+// against can be amended with the optional overrides before the call is traced.
+func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
 	timer := DoMetricRPCRequest(TraceCall)
 	defer DoRPCRequestDuration(TraceCall, timer)
 
-	// First try to retrieve the state
-	statedb, header, err := s.hmy.StateAndHeaderByNumber(ctx, blockNr)
+	ctx, release, err := s.hmy.StartTrace(ctx)
 	if err != nil {
-		// Try to retrieve the specified block
-		block := s.hmy.BlockChain.GetBlockByNumber(uint64(blockNr))
-		if block == nil {
-			DoMetricRPCQueryInfo(TraceCall, FailedNumber)
-			return nil, fmt.Errorf("block %v not found: %v", blockNr, err)
-		}
-		// try to recompute the state
-		reexec := defaultTraceReexec
-		if config != nil && config.Reexec != nil {
-			reexec = *config.Reexec
-		}
-		_, _, statedb, err = s.hmy.ComputeTxEnv(block, 0, reexec)
-		if err != nil {
+		DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	// Try to retrieve the specified block
+	block, err := s.hmy.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+		return nil, err
+	}
+	if block == nil {
+		DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+		return nil, fmt.Errorf("block %v not found", blockNrOrHash)
+	}
+	// try to recompute the state
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	reexec = s.hmy.LimitTraceReexec(reexec)
+	statedb, err := s.hmy.ComputeStateDB(block, reexec)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+		return nil, err
+	}
+
+	var traceConfig *hmy.TraceConfig
+	if config != nil {
+		// Apply the customized state rules if required.
+		if err := config.StateOverrides.Apply(statedb); err != nil {
 			DoMetricRPCQueryInfo(TraceCall, FailedNumber)
 			return nil, err
 		}
+		traceConfig = &config.TraceConfig
 	}
 
 	// Execute the trace
-	msg := args.ToMessage(s.hmy.RPCGasCap)
-	vmctx := core.NewEVMContext(msg, header, s.hmy.BlockChain, nil)
+	msg := args.ToMessage(s.hmy.RPCGasCap())
+	vmctx := core.NewEVMContext(msg, block.Header(), s.hmy.BlockChain, nil)
+	if config != nil {
+		config.BlockOverrides.Apply(&vmctx)
+	}
 	// Trace the transaction and return
-	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, config)
+	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, traceConfig)
 }
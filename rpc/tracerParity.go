@@ -4,14 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
 )
 
+const (
+	// TraceFilterMaxBlocks is the maximum number of blocks spanned by a single
+	// trace_filter request
+	TraceFilterMaxBlocks = 10000
+)
+
 var (
 	parityTraceGO = "ParityBlockTracer"
+	emptyCodeHash = crypto.Keccak256Hash(nil)
 )
 
 type PublicParityTracerService struct {
@@ -29,6 +41,13 @@ func (s *PublicParityTracerService) Block(ctx context.Context, number rpc.BlockN
 	timer := DoMetricRPCRequest(Block)
 	defer DoRPCRequestDuration(Block, timer)
 
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(Block, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
 	block := s.hmy.BlockChain.GetBlockByNumber(uint64(number))
 	if block == nil {
 		return nil, nil
@@ -47,3 +66,497 @@ func (s *PublicParityTracerService) Block(ctx context.Context, number rpc.BlockN
 	}
 	return resultArray, nil
 }
+
+// TraceFilterArgs are the arguments of trace_filter. Empty address lists match
+// any address.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// matches reports whether a trace with the given sender and recipient matches
+// the address lists of the filter.
+func (args *TraceFilterArgs) matches(from, to common.Address) bool {
+	contains := func(addrs []common.Address, addr common.Address) bool {
+		if len(addrs) == 0 {
+			return true
+		}
+		for _, a := range addrs {
+			if a == addr {
+				return true
+			}
+		}
+		return false
+	}
+	return contains(args.FromAddress, from) && contains(args.ToAddress, to)
+}
+
+// resolveBlockNumber returns the number of the given block, the current block if
+// nil or latest.
+func (s *PublicParityTracerService) resolveBlockNumber(number *rpc.BlockNumber) uint64 {
+	if number == nil || *number == rpc.LatestBlockNumber || *number == rpc.PendingBlockNumber {
+		return s.hmy.CurrentBlock().NumberU64()
+	}
+	if *number == rpc.EarliestBlockNumber {
+		return 0
+	}
+	return uint64(*number)
+}
+
+// trace_filter RPC
+// Filter returns the traces of the blocks in the given range whose sender and
+// recipient match the given addresses, internal calls included. The blocks which
+// cannot match are skipped using the trace bloom index when it is enabled.
+func (s *PublicParityTracerService) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	timer := DoMetricRPCRequest(TraceFilter)
+	defer DoRPCRequestDuration(TraceFilter, timer)
+
+	begin, end := s.resolveBlockNumber(args.FromBlock), s.resolveBlockNumber(args.ToBlock)
+	if current := s.hmy.CurrentBlock().NumberU64(); end > current {
+		DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+		return nil, ErrRequestedBlockTooHigh
+	}
+	if begin > end {
+		DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+		return nil, errors.New("fromBlock can not be greater than toBlock")
+	}
+	if end-begin >= TraceFilterMaxBlocks {
+		DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+		return nil, fmt.Errorf("block range can not exceed %d blocks", TraceFilterMaxBlocks)
+	}
+
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	blocks, err := s.hmy.TraceBloomCandidates(ctx, begin, end, args.FromAddress, args.ToAddress)
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+		return nil, err
+	}
+	traces := make([]json.RawMessage, 0)
+	if args.Count != nil && *args.Count == 0 {
+		return traces, nil
+	}
+	var after uint64
+	if args.After != nil {
+		after = *args.After
+	}
+	for _, number := range blocks {
+		block := s.hmy.BlockChain.GetBlockByNumber(number)
+		if block == nil {
+			DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		results, err := s.hmy.TraceBlock(ctx, block, &hmy.TraceConfig{Tracer: &parityTraceGO})
+		if err != nil {
+			DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+			return nil, err
+		}
+		for _, result := range results {
+			raw, ok := result.Result.([]json.RawMessage)
+			if !ok {
+				DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+				return nil, errors.New("tracer bug:expected []json.RawMessage")
+			}
+			for _, trace := range raw {
+				from, to, err := hmy.ParityTraceEndpoints(trace)
+				if err != nil {
+					DoMetricRPCQueryInfo(TraceFilter, FailedNumber)
+					return nil, err
+				}
+				if !args.matches(from, to) {
+					continue
+				}
+				if after > 0 {
+					after--
+					continue
+				}
+				traces = append(traces, trace)
+				if args.Count != nil && uint64(len(traces)) >= *args.Count {
+					return traces, nil
+				}
+			}
+		}
+	}
+	return traces, nil
+}
+
+// ParityTraceResult is the result of tracing a simulated call or transaction.
+// The stateDiff is only supported by replayed transactions, vmTrace is always null.
+type ParityTraceResult struct {
+	Output          hexutil.Bytes     `json:"output"`
+	StateDiff       interface{}       `json:"stateDiff"`
+	Trace           []json.RawMessage `json:"trace"`
+	VMTrace         interface{}       `json:"vmTrace"`
+	TransactionHash *common.Hash      `json:"transactionHash,omitempty"`
+}
+
+// TraceCallRequest is a single call of trace_callMany, given as a [call, traceTypes] pair.
+type TraceCallRequest struct {
+	Args       CallArgs
+	TraceTypes []string
+}
+
+// UnmarshalJSON decodes the [call, traceTypes] pair of a trace_callMany call.
+func (r *TraceCallRequest) UnmarshalJSON(input []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(input, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return errors.New("expected a [call, traceTypes] pair")
+	}
+	if err := json.Unmarshal(pair[0], &r.Args); err != nil {
+		return err
+	}
+	return json.Unmarshal(pair[1], &r.TraceTypes)
+}
+
+// trace_callMany RPC
+// CallMany traces a sequence of calls on top of the state of the given block, each
+// call seeing the state changes of the calls before it.
+func (s *PublicParityTracerService) CallMany(
+	ctx context.Context, calls []TraceCallRequest, blockNrOrHash *rpc.BlockNumberOrHash,
+) ([]*ParityTraceResult, error) {
+	timer := DoMetricRPCRequest(CallMany)
+	defer DoRPCRequestDuration(CallMany, timer)
+
+	for _, call := range calls {
+		if err := checkTraceTypes(call.TraceTypes, "trace"); err != nil {
+			DoMetricRPCQueryInfo(CallMany, FailedNumber)
+			return nil, err
+		}
+	}
+
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(CallMany, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	block, err := s.hmy.BlockByNumberOrHash(ctx, *blockNrOrHash)
+	if err != nil {
+		DoMetricRPCQueryInfo(CallMany, FailedNumber)
+		return nil, err
+	}
+	if block == nil {
+		DoMetricRPCQueryInfo(CallMany, FailedNumber)
+		return nil, fmt.Errorf("block %v not found", *blockNrOrHash)
+	}
+	statedb, err := s.hmy.ComputeStateDB(block, defaultTraceReexec)
+	if err != nil {
+		DoMetricRPCQueryInfo(CallMany, FailedNumber)
+		return nil, err
+	}
+
+	results := make([]*ParityTraceResult, 0, len(calls))
+	for i, call := range calls {
+		msg := call.Args.ToMessage(s.hmy.RPCGasCap())
+		statedb.Prepare(common.Hash{}, block.Hash(), i)
+		vmctx := core.NewEVMContext(msg, block.Header(), s.hmy.BlockChain, nil)
+		frames, result, err := s.hmy.TraceParityCall(ctx, msg, vmctx, statedb)
+		if err != nil {
+			DoMetricRPCQueryInfo(CallMany, FailedNumber)
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		results = append(results, newParityTraceResult(frames, result, call.TraceTypes))
+	}
+	return results, nil
+}
+
+// trace_rawTransaction RPC
+// RawTransaction traces a signed transaction on top of the latest state without
+// submitting it. Both Ethereum and Harmony encoded transactions are accepted.
+func (s *PublicParityTracerService) RawTransaction(
+	ctx context.Context, encodedTx hexutil.Bytes, traceTypes []string,
+) (*ParityTraceResult, error) {
+	timer := DoMetricRPCRequest(RawTransaction)
+	defer DoRPCRequestDuration(RawTransaction, timer)
+
+	if err := checkTraceTypes(traceTypes, "trace"); err != nil {
+		DoMetricRPCQueryInfo(RawTransaction, FailedNumber)
+		return nil, err
+	}
+	tx, err := decodeRawTransaction(encodedTx)
+	if err != nil {
+		DoMetricRPCQueryInfo(RawTransaction, FailedNumber)
+		return nil, err
+	}
+
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(RawTransaction, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	block := s.hmy.BlockChain.CurrentBlock()
+	signer := types.MakeSigner(s.hmy.ChainConfig(), block.Epoch())
+	if tx.IsEthCompatible() {
+		signer = types.NewEIP155Signer(s.hmy.ChainConfig().EthCompatibleChainID)
+	}
+	msg, err := tx.AsMessage(signer)
+	if err != nil {
+		DoMetricRPCQueryInfo(RawTransaction, FailedNumber)
+		return nil, err
+	}
+	statedb, err := s.hmy.ComputeStateDB(block, defaultTraceReexec)
+	if err != nil {
+		DoMetricRPCQueryInfo(RawTransaction, FailedNumber)
+		return nil, err
+	}
+	statedb.Prepare(tx.Hash(), common.Hash{}, 0)
+	vmctx := core.NewEVMContext(msg, block.Header(), s.hmy.BlockChain, nil)
+	frames, result, err := s.hmy.TraceParityCall(ctx, msg, vmctx, statedb)
+	if err != nil {
+		DoMetricRPCQueryInfo(RawTransaction, FailedNumber)
+		return nil, err
+	}
+	return newParityTraceResult(frames, result, traceTypes), nil
+}
+
+// trace_replayBlockTransactions RPC
+// ReplayBlockTransactions returns the call traces and the state diffs of the
+// plain and staking transactions of the given block. The state diffs are read
+// from the state diff index if the block is indexed.
+func (s *PublicParityTracerService) ReplayBlockTransactions(
+	ctx context.Context, number rpc.BlockNumber, traceTypes []string,
+) ([]*ParityTraceResult, error) {
+	timer := DoMetricRPCRequest(ReplayBlockTransactions)
+	defer DoRPCRequestDuration(ReplayBlockTransactions, timer)
+
+	if err := checkTraceTypes(traceTypes, "trace", "stateDiff"); err != nil {
+		DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+		return nil, err
+	}
+
+	ctx, release, err := s.hmy.StartTrace(ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+		return nil, err
+	}
+	defer release()
+
+	block, err := s.hmy.BlockByNumber(ctx, number)
+	if err != nil {
+		DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+		return nil, err
+	}
+	if block == nil {
+		DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	var hashes, ethHashes []common.Hash
+	for _, tx := range block.Transactions() {
+		hashes = append(hashes, tx.Hash())
+		ethHashes = append(ethHashes, tx.ConvertToEth().Hash())
+	}
+	for _, tx := range block.StakingTransactions() {
+		hashes = append(hashes, tx.Hash())
+		ethHashes = append(ethHashes, tx.Hash())
+	}
+	results := make([]*ParityTraceResult, len(hashes))
+	for i := range results {
+		results[i] = &ParityTraceResult{Trace: []json.RawMessage{}, TransactionHash: &ethHashes[i]}
+	}
+
+	if hasTraceType(traceTypes, "trace") {
+		traces, err := s.hmy.TraceBlock(ctx, block, &hmy.TraceConfig{Tracer: &parityTraceGO})
+		if err != nil {
+			DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+			return nil, err
+		}
+		// The traces of the incoming cross-shard receipts follow the transactions
+		for i := range results {
+			frames, ok := traces[i].Result.([]json.RawMessage)
+			if !ok {
+				DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+				return nil, errors.New("tracer bug:expected []json.RawMessage")
+			}
+			results[i].Trace = frames
+			results[i].Output = parityTraceOutput(frames)
+		}
+	}
+	if hasTraceType(traceTypes, "stateDiff") {
+		diffs, err := s.hmy.StateDiffs(block)
+		if err != nil {
+			DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+			return nil, err
+		}
+		txDiffs := make(map[common.Hash]*types.StateDiff, len(diffs))
+		for _, diff := range diffs {
+			txDiffs[diff.TxHash] = diff
+		}
+		for i, hash := range hashes {
+			var accounts []*types.AccountDiff
+			if diff, ok := txDiffs[hash]; ok {
+				accounts = diff.Accounts
+			}
+			if results[i].StateDiff, err = s.newParityStateDiff(accounts); err != nil {
+				DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// parityTraceOutput returns the output of the top call frame of a transaction.
+func parityTraceOutput(frames []json.RawMessage) hexutil.Bytes {
+	if len(frames) == 0 {
+		return nil
+	}
+	var frame struct {
+		Result *struct {
+			Output hexutil.Bytes `json:"output"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(frames[0], &frame); err != nil || frame.Result == nil {
+		return nil
+	}
+	return frame.Result.Output
+}
+
+// parityAccountDiff is the change of an account in the stateDiff format of the
+// parity traces. Each value is either "=" if unchanged, {"+": value} if the
+// account was created, {"-": value} if the account was deleted, or
+// {"*": {"from": value, "to": value}} if changed.
+type parityAccountDiff struct {
+	Balance interface{}                 `json:"balance"`
+	Code    interface{}                 `json:"code"`
+	Nonce   interface{}                 `json:"nonce"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+// newParityStateDiff converts the given account changes into the stateDiff format
+// of the parity traces.
+func (s *PublicParityTracerService) newParityStateDiff(
+	accounts []*types.AccountDiff,
+) (map[common.Address]*parityAccountDiff, error) {
+	stateDiff := make(map[common.Address]*parityAccountDiff, len(accounts))
+	for _, account := range accounts {
+		codeBefore, err := s.contractCode(account.CodeHashBefore)
+		if err != nil {
+			return nil, err
+		}
+		codeAfter, err := s.contractCode(account.CodeHashAfter)
+		if err != nil {
+			return nil, err
+		}
+		diff := &parityAccountDiff{
+			Balance: newParityDiffValue(
+				account, (*hexutil.Big)(account.BalanceBefore), (*hexutil.Big)(account.BalanceAfter),
+				account.BalanceBefore.Cmp(account.BalanceAfter) != 0,
+			),
+			Code: newParityDiffValue(
+				account, codeBefore, codeAfter, account.CodeHashBefore != account.CodeHashAfter,
+			),
+			Nonce: newParityDiffValue(
+				account, hexutil.Uint64(account.NonceBefore), hexutil.Uint64(account.NonceAfter),
+				account.NonceBefore != account.NonceAfter,
+			),
+			Storage: make(map[common.Hash]interface{}, len(account.Storage)),
+		}
+		for _, slot := range account.Storage {
+			diff.Storage[slot.Key] = newParityDiffValue(account, slot.Before, slot.After, slot.Before != slot.After)
+		}
+		stateDiff[account.Address] = diff
+	}
+	return stateDiff, nil
+}
+
+// contractCode returns the contract code with the given hash, empty for accounts
+// without code.
+func (s *PublicParityTracerService) contractCode(hash common.Hash) (hexutil.Bytes, error) {
+	if hash == (common.Hash{}) || hash == emptyCodeHash {
+		return hexutil.Bytes{}, nil
+	}
+	code, err := s.hmy.BlockChain.ContractCode(hash)
+	if err != nil {
+		return nil, fmt.Errorf("code %s not found: %w", hash.Hex(), err)
+	}
+	return code, nil
+}
+
+// newParityDiffValue returns the change of a value of the given account in the
+// stateDiff format of the parity traces.
+func newParityDiffValue(account *types.AccountDiff, before, after interface{}, changed bool) interface{} {
+	switch {
+	case !account.Existed && account.Exists:
+		return map[string]interface{}{"+": after}
+	case account.Existed && !account.Exists:
+		return map[string]interface{}{"-": before}
+	case !changed:
+		return "="
+	default:
+		return map[string]interface{}{"*": map[string]interface{}{"from": before, "to": after}}
+	}
+}
+
+// decodeRawTransaction decodes an Ethereum encoded transaction, or a Harmony encoded
+// one if the former fails. The two encodings have a different number of fields.
+func decodeRawTransaction(encodedTx hexutil.Bytes) (*types.Transaction, error) {
+	if len(encodedTx) >= types.MaxEncodedPoolTransactionSize {
+		return nil, fmt.Errorf("%w: encoded tx size: %d", core.ErrOversizedData, len(encodedTx))
+	}
+	ethTx := new(types.EthTransaction)
+	if err := ethTx.UnmarshalBinary(encodedTx); err == nil {
+		return ethTx.ConvertToHmy(), nil
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(encodedTx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// checkTraceTypes ensures only the given supported trace types are requested.
+func checkTraceTypes(traceTypes []string, supported ...string) error {
+	for _, traceType := range traceTypes {
+		if !hasTraceType(supported, traceType) {
+			return fmt.Errorf("trace type %q is not supported", traceType)
+		}
+	}
+	return nil
+}
+
+// hasTraceType reports whether the given trace type is requested.
+func hasTraceType(traceTypes []string, traceType string) bool {
+	for _, t := range traceTypes {
+		if t == traceType {
+			return true
+		}
+	}
+	return false
+}
+
+// newParityTraceResult assembles the result of a traced call, including the call
+// frames only if they were requested.
+func newParityTraceResult(
+	frames []json.RawMessage, result *core.ExecutionResult, traceTypes []string,
+) *ParityTraceResult {
+	trace := []json.RawMessage{}
+	for _, traceType := range traceTypes {
+		if traceType == "trace" {
+			trace = frames
+		}
+	}
+	return &ParityTraceResult{
+		Output: result.ReturnData,
+		Trace:  trace,
+	}
+}
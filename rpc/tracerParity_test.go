@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy"
+)
+
+func TestTraceCallRequestUnmarshal(t *testing.T) {
+	var calls []TraceCallRequest
+	input := `[[{"from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","value":"0x1"},["trace"]],[{"to":"0x0000000000000000000000000000000000000003"},[]]]`
+	if err := json.Unmarshal([]byte(input), &calls); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("unexpected number of calls: %d", len(calls))
+	}
+	if calls[0].Args.To == nil || *calls[0].Args.To != common.HexToAddress("0x02") {
+		t.Errorf("unexpected to: %v", calls[0].Args.To)
+	}
+	if len(calls[0].TraceTypes) != 1 || calls[0].TraceTypes[0] != "trace" {
+		t.Errorf("unexpected trace types: %v", calls[0].TraceTypes)
+	}
+	if len(calls[1].TraceTypes) != 0 {
+		t.Errorf("unexpected trace types: %v", calls[1].TraceTypes)
+	}
+
+	var call TraceCallRequest
+	if err := json.Unmarshal([]byte(`[{}]`), &call); err == nil {
+		t.Error("expected error for a call without trace types")
+	}
+}
+
+func TestCheckTraceTypes(t *testing.T) {
+	if err := checkTraceTypes([]string{"trace"}, "trace"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, traceType := range []string{"vmTrace", "stateDiff"} {
+		if err := checkTraceTypes([]string{"trace", traceType}, "trace"); err == nil {
+			t.Errorf("expected error for trace type %s", traceType)
+		}
+	}
+	if err := checkTraceTypes([]string{"trace", "stateDiff"}, "trace", "stateDiff"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParityStateDiff(t *testing.T) {
+	s := &PublicParityTracerService{}
+	accounts := []*types.AccountDiff{
+		{
+			Address: common.HexToAddress("0x1"), Existed: true, Exists: true,
+			BalanceBefore: big.NewInt(2), BalanceAfter: big.NewInt(1), NonceBefore: 1, NonceAfter: 1,
+			CodeHashBefore: emptyCodeHash, CodeHashAfter: emptyCodeHash,
+			Storage: []*types.StorageDiff{{Key: common.Hash{1}, Before: common.Hash{}, After: common.Hash{2}}},
+		},
+		{
+			Address: common.HexToAddress("0x2"), Exists: true,
+			BalanceBefore: big.NewInt(0), BalanceAfter: big.NewInt(3), CodeHashAfter: emptyCodeHash,
+		},
+		{
+			Address: common.HexToAddress("0x3"), Existed: true,
+			BalanceBefore: big.NewInt(4), BalanceAfter: big.NewInt(0), CodeHashBefore: emptyCodeHash,
+		},
+	}
+	stateDiff, err := s.newParityStateDiff(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, _ := json.Marshal(stateDiff)
+	want := `{` +
+		`"0x0000000000000000000000000000000000000001":{"balance":{"*":{"from":"0x2","to":"0x1"}},"code":"=","nonce":"=",` +
+		`"storage":{"0x0100000000000000000000000000000000000000000000000000000000000000":{"*":{` +
+		`"from":"0x0000000000000000000000000000000000000000000000000000000000000000",` +
+		`"to":"0x0200000000000000000000000000000000000000000000000000000000000000"}}}},` +
+		`"0x0000000000000000000000000000000000000002":{"balance":{"+":"0x3"},"code":{"+":"0x"},"nonce":{"+":"0x0"},"storage":{}},` +
+		`"0x0000000000000000000000000000000000000003":{"balance":{"-":"0x4"},"code":{"-":"0x"},"nonce":{"-":"0x0"},"storage":{}}` +
+		`}`
+	if string(have) != want {
+		t.Errorf("unexpected state diff:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestParityTraceOutput(t *testing.T) {
+	frames := []json.RawMessage{
+		json.RawMessage(`{"action":{},"result":{"gasUsed":"0x1","output":"0x1234"}}`),
+		json.RawMessage(`{"action":{},"result":{"gasUsed":"0x1","output":"0x5678"}}`),
+	}
+	if output := parityTraceOutput(frames); output.String() != "0x1234" {
+		t.Errorf("unexpected output %v", output)
+	}
+	if output := parityTraceOutput([]json.RawMessage{json.RawMessage(`{"error":"Reverted"}`)}); output != nil {
+		t.Errorf("unexpected output %v", output)
+	}
+}
+
+func TestTraceFilterMatches(t *testing.T) {
+	var (
+		a = common.HexToAddress("0x000000000000000000000000000000000000000a")
+		b = common.HexToAddress("0x000000000000000000000000000000000000000b")
+		c = common.HexToAddress("0x000000000000000000000000000000000000000c")
+	)
+	tests := []struct {
+		trace    string
+		from, to common.Address
+	}{
+		{`{"type":"call","action":{"callType":"call","from":"0x000000000000000000000000000000000000000a","to":"0x000000000000000000000000000000000000000b"}}`, a, b},
+		{`{"type":"create","action":{"from":"0x000000000000000000000000000000000000000a"},"result":{"address":"0x000000000000000000000000000000000000000c"}}`, a, c},
+		{`{"type":"create","action":{"from":"0x000000000000000000000000000000000000000a"},"error":"Reverted"}`, a, common.Address{}},
+		{`{"type":"suicide","action":{"address":"0x000000000000000000000000000000000000000b","refundAddress":"0x000000000000000000000000000000000000000c"}}`, b, c},
+		{`{"type":"staking","action":{"directive":"Delegate","validator":"0x000000000000000000000000000000000000000c","delegator":"0x000000000000000000000000000000000000000a"}}`, a, c},
+	}
+	for i, test := range tests {
+		from, to, err := hmy.ParityTraceEndpoints(json.RawMessage(test.trace))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i, err)
+		}
+		if from != test.from || to != test.to {
+			t.Errorf("Test %d: unexpected endpoints %s %s", i, from.Hex(), to.Hex())
+		}
+	}
+	if _, _, err := hmy.ParityTraceEndpoints(json.RawMessage(`{"type":"unknown"}`)); err == nil {
+		t.Errorf("expected error on unknown trace type")
+	}
+
+	args := &TraceFilterArgs{}
+	if !args.matches(a, b) {
+		t.Errorf("empty filter should match any trace")
+	}
+	args = &TraceFilterArgs{FromAddress: []common.Address{a, c}}
+	if !args.matches(a, b) || args.matches(b, a) {
+		t.Errorf("unexpected from address match")
+	}
+	args = &TraceFilterArgs{FromAddress: []common.Address{a}, ToAddress: []common.Address{c}}
+	if args.matches(a, b) || !args.matches(a, c) || args.matches(b, c) {
+		t.Errorf("unexpected from and to address match")
+	}
+}
@@ -116,7 +116,7 @@ func (s *PublicTransactionService) GetTransactionCount(
 	}
 }
 
-// GetTransactionsCount returns the number of regular transactions from genesis of input type ("SENT", "RECEIVED", "ALL")
+// GetTransactionsCount returns the number of regular transactions from genesis of input type ("SENT", "RECEIVED", "ALL", "INTERNAL")
 func (s *PublicTransactionService) GetTransactionsCount(
 	ctx context.Context, address, txType string,
 ) (count uint64, err error) {
@@ -170,7 +170,7 @@ func (s *PublicTransactionService) EstimateGas(
 	timer := DoMetricRPCRequest(RpcEstimateGas)
 	defer DoRPCRequestDuration(RpcEstimateGas, timer)
 
-	gas, err := EstimateGas(ctx, s.hmy, args, nil)
+	gas, err := EstimateGas(ctx, s.hmy, args, s.hmy.RPCGasCap())
 	if err != nil {
 		return 0, err
 	}
@@ -762,7 +762,14 @@ func (s *PublicTransactionService) GetTransactionReceipt(
 		return NewStructuredResponse(RPCReceipt)
 	case Eth:
 		if tx != nil {
-			RPCReceipt, err = eth.NewReceipt(tx.ConvertToEth(), blockHash, blockNumber, index, receipt)
+			header, err := s.hmy.HeaderByHash(ctx, blockHash)
+			if err != nil {
+				return nil, err
+			}
+			RPCReceipt, err = eth.NewReceipt(tx.ConvertToEth(), blockHash, blockNumber, index, receipt, header.BaseFee())
+			if err != nil {
+				return nil, err
+			}
 		}
 		if err != nil {
 			return nil, err
@@ -773,31 +780,43 @@ func (s *PublicTransactionService) GetTransactionReceipt(
 	}
 }
 
-// GetCXReceiptByHash returns the transaction for the given hash
+// GetCXReceiptByHash returns the transaction for the given hash.
+// If withProof is set, the response also carries the inclusion proof of the receipt
+// in its source block under the "proof" key.
 func (s *PublicTransactionService) GetCXReceiptByHash(
-	ctx context.Context, hash common.Hash,
+	ctx context.Context, hash common.Hash, withProof *bool,
 ) (StructuredResponse, error) {
 	timer := DoMetricRPCRequest(GetCXReceiptByHash)
 	defer DoRPCRequestDuration(GetCXReceiptByHash, timer)
 
 	if cx, blockHash, blockNumber, _ := rawdb.ReadCXReceipt(s.hmy.ChainDb(), hash); cx != nil {
 		// Format response according to version
+		var rpcCx interface{}
+		var err error
 		switch s.version {
 		case V1, Eth:
-			tx, err := v1.NewCxReceipt(cx, blockHash, blockNumber)
-			if err != nil {
-				return nil, err
-			}
-			return NewStructuredResponse(tx)
+			rpcCx, err = v1.NewCxReceipt(cx, blockHash, blockNumber)
 		case V2:
-			tx, err := v2.NewCxReceipt(cx, blockHash, blockNumber)
+			rpcCx, err = v2.NewCxReceipt(cx, blockHash, blockNumber)
+		default:
+			return nil, ErrUnknownRPCVersion
+		}
+		if err != nil {
+			return nil, err
+		}
+		response, err := NewStructuredResponse(rpcCx)
+		if err != nil {
+			return nil, err
+		}
+		if withProof != nil && *withProof {
+			proof, err := s.hmy.GetCXReceiptProof(ctx, hash)
 			if err != nil {
+				DoMetricRPCQueryInfo(GetCXReceiptByHash, FailedNumber)
 				return nil, err
 			}
-			return NewStructuredResponse(tx)
-		default:
-			return nil, ErrUnknownRPCVersion
+			response["proof"] = proof
 		}
+		return response, nil
 	}
 	utils.Logger().Debug().
 		Err(fmt.Errorf("unable to found CX receipt for tx %v", hash.String())).
@@ -898,7 +917,7 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *b
 	executable := func(gas uint64) (bool, *core.ExecutionResult, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
 
-		result, err := DoEVMCall(ctx, hmy, args, blockNum, 0)
+		result, err := DoEVMCall(ctx, hmy, args, blockNum, nil, nil, 0)
 		if err != nil {
 			if errors.Is(err, core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -0,0 +1,244 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/internal/utils"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// PublicTxPoolService offers the standard txpool RPC methods, covering both plain
+// (including cross-shard) and staking transactions of the pool.
+type PublicTxPoolService struct {
+	hmy *hmy.Harmony
+}
+
+// NewPublicTxPoolAPI creates a new txpool API instance.
+func NewPublicTxPoolAPI(hmy *hmy.Harmony) rpc.API {
+	return rpc.API{
+		Namespace: txPoolNamespace,
+		Version:   APIVersion,
+		Service:   &PublicTxPoolService{hmy},
+		Public:    true,
+	}
+}
+
+// TxPoolAccountContent is the pool content of a single sender. NonceGaps lists the
+// missing nonces that keep the queued transactions of the sender from executing.
+type TxPoolAccountContent struct {
+	Pending   map[string]interface{} `json:"pending"`
+	Queued    map[string]interface{} `json:"queued"`
+	NonceGaps []hexutil.Uint64       `json:"nonceGaps"`
+}
+
+// Content returns the pending and queued transactions of the pool, grouped by
+// sender and keyed by nonce.
+func (s *PublicTxPoolService) Content(ctx context.Context) map[string]map[string]map[string]interface{} {
+	timer := DoMetricRPCRequest(TxPoolContent)
+	defer DoRPCRequestDuration(TxPoolContent, timer)
+
+	pending, queued := s.hmy.GetPoolContent()
+	return map[string]map[string]map[string]interface{}{
+		"pending": formatPoolContent(pending, newPoolTransaction),
+		"queued":  formatPoolContent(queued, newPoolTransaction),
+	}
+}
+
+// ContentFrom returns the pending and queued transactions of a single sender along
+// with the nonce gaps holding back its queued transactions.
+func (s *PublicTxPoolService) ContentFrom(ctx context.Context, address common.Address) (*TxPoolAccountContent, error) {
+	timer := DoMetricRPCRequest(TxPoolContent)
+	defer DoRPCRequestDuration(TxPoolContent, timer)
+
+	pending, queued := s.hmy.GetPoolContent()
+	nonce, err := s.hmy.GetPoolNonce(ctx, address)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolContent, FailedNumber)
+		return nil, err
+	}
+	return &TxPoolAccountContent{
+		Pending:   formatPoolTransactions(pending[address], newPoolTransaction),
+		Queued:    formatPoolTransactions(queued[address], newPoolTransaction),
+		NonceGaps: nonceGaps(nonce, queued[address]),
+	}, nil
+}
+
+// Inspect returns a textual summary of the pending and queued transactions of the
+// pool, grouped by sender and keyed by nonce, for quick manual inspection.
+func (s *PublicTxPoolService) Inspect(ctx context.Context) map[string]map[string]map[string]interface{} {
+	timer := DoMetricRPCRequest(TxPoolInspect)
+	defer DoRPCRequestDuration(TxPoolInspect, timer)
+
+	pending, queued := s.hmy.GetPoolContent()
+	return map[string]map[string]map[string]interface{}{
+		"pending": formatPoolContent(pending, inspectPoolTransaction),
+		"queued":  formatPoolContent(queued, inspectPoolTransaction),
+	}
+}
+
+// Status returns the number of pending and queued transactions of the pool.
+func (s *PublicTxPoolService) Status(ctx context.Context) map[string]hexutil.Uint {
+	timer := DoMetricRPCRequest(TxPoolStatus)
+	defer DoRPCRequestDuration(TxPoolStatus, timer)
+
+	pending, queued := s.hmy.GetPoolStats()
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(pending),
+		"queued":  hexutil.Uint(queued),
+	}
+}
+
+// TxPoolReplacement is an attempt to replace a pool transaction with another of
+// the same sender and nonce. Status is replaced, or underpriced if the gas price
+// was not bumped enough.
+type TxPoolReplacement struct {
+	From        common.Address `json:"from"`
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	Hash        common.Hash    `json:"hash"`
+	Replacement common.Hash    `json:"replacement"`
+	GasPrice    *hexutil.Big   `json:"gasPrice"`
+	NewGasPrice *hexutil.Big   `json:"newGasPrice"`
+	MinGasPrice *hexutil.Big   `json:"minGasPrice"`
+	Status      string         `json:"status"`
+	Timestamp   hexutil.Uint64 `json:"timestamp"`
+}
+
+// TxPoolReplacementsResult is the result of txpool_replacements
+type TxPoolReplacementsResult struct {
+	PriceBump    hexutil.Uint64       `json:"priceBump"`
+	Replacements []*TxPoolReplacement `json:"replacements"`
+}
+
+// Replacements returns the last attempts to replace a pool transaction, replaced
+// or rejected as underpriced, from the oldest to the newest, along with the minimum
+// price bump in percent. The attempts are filtered by sender if an address is given.
+func (s *PublicTxPoolService) Replacements(ctx context.Context, address *common.Address) *TxPoolReplacementsResult {
+	timer := DoMetricRPCRequest(TxPoolReplacements)
+	defer DoRPCRequestDuration(TxPoolReplacements, timer)
+
+	replacements, priceBump := s.hmy.GetPoolReplacements()
+	result := &TxPoolReplacementsResult{
+		PriceBump:    hexutil.Uint64(priceBump),
+		Replacements: []*TxPoolReplacement{},
+	}
+	for _, r := range replacements {
+		if address != nil && r.From != *address {
+			continue
+		}
+		result.Replacements = append(result.Replacements, newTxPoolReplacement(r))
+	}
+	return result
+}
+
+func newTxPoolReplacement(r core.TxReplacement) *TxPoolReplacement {
+	status := "replaced"
+	if !r.Replaced {
+		status = "underpriced"
+	}
+	return &TxPoolReplacement{
+		From:        r.From,
+		Nonce:       hexutil.Uint64(r.Nonce),
+		Hash:        r.Old,
+		Replacement: r.New,
+		GasPrice:    (*hexutil.Big)(r.OldPrice),
+		NewGasPrice: (*hexutil.Big)(r.NewPrice),
+		MinGasPrice: (*hexutil.Big)(r.MinPrice),
+		Status:      status,
+		Timestamp:   hexutil.Uint64(r.Time.Unix()),
+	}
+}
+
+// formatPoolContent formats the transactions of every sender with the given formatter.
+func formatPoolContent(
+	content map[common.Address]types.PoolTransactions, format func(types.PoolTransaction) (interface{}, error),
+) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{}, len(content))
+	for addr, txs := range content {
+		result[addr.Hex()] = formatPoolTransactions(txs, format)
+	}
+	return result
+}
+
+// formatPoolTransactions formats the transactions of a sender keyed by nonce,
+// skipping those that cannot be formatted.
+func formatPoolTransactions(
+	txs types.PoolTransactions, format func(types.PoolTransaction) (interface{}, error),
+) map[string]interface{} {
+	result := make(map[string]interface{}, len(txs))
+	for _, tx := range txs {
+		formatted, err := format(tx)
+		if err != nil {
+			utils.Logger().Debug().
+				Err(err).
+				Msgf("%v error at %v", LogTag, "TxPool")
+			continue
+		}
+		result[fmt.Sprintf("%d", tx.Nonce())] = formatted
+	}
+	return result
+}
+
+// newPoolTransaction returns the RPC representation of a pool transaction. The v2
+// representation is used as it carries the shard IDs of cross-shard transactions.
+func newPoolTransaction(tx types.PoolTransaction) (interface{}, error) {
+	switch tx := tx.(type) {
+	case *types.Transaction:
+		return v2.NewTransaction(tx, common.Hash{}, 0, 0, 0)
+	case *staking.StakingTransaction:
+		return v2.NewStakingTransaction(tx, common.Hash{}, 0, 0, 0, true)
+	}
+	return nil, types.ErrUnknownPoolTxType
+}
+
+// inspectPoolTransaction returns a one line summary of a pool transaction.
+func inspectPoolTransaction(tx types.PoolTransaction) (interface{}, error) {
+	switch tx := tx.(type) {
+	case *types.Transaction:
+		to := "contract creation"
+		if tx.To() != nil {
+			to = tx.To().Hex()
+		}
+		summary := fmt.Sprintf(
+			"%s: %v wei + %v gas × %v wei", to, tx.Value(), tx.GasLimit(), tx.GasPrice(),
+		)
+		if tx.ShardID() != tx.ToShardID() {
+			summary = fmt.Sprintf("shard %d -> %d %s", tx.ShardID(), tx.ToShardID(), summary)
+		}
+		return summary, nil
+	case *staking.StakingTransaction:
+		return fmt.Sprintf(
+			"%s: %v gas × %v wei", tx.StakingType(), tx.GasLimit(), tx.GasPrice(),
+		), nil
+	}
+	return nil, types.ErrUnknownPoolTxType
+}
+
+// nonceGaps returns the nonces missing between the next executable nonce of the
+// sender and its highest queued transaction.
+func nonceGaps(next uint64, queued types.PoolTransactions) []hexutil.Uint64 {
+	nonces := make([]uint64, 0, len(queued))
+	for _, tx := range queued {
+		nonces = append(nonces, tx.Nonce())
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+
+	gaps := []hexutil.Uint64{}
+	for _, nonce := range nonces {
+		for ; next < nonce; next++ {
+			gaps = append(gaps, hexutil.Uint64(next))
+		}
+		if next == nonce {
+			next++
+		}
+	}
+	return gaps
+}
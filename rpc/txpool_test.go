@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func TestNonceGaps(t *testing.T) {
+	queued := func(nonces ...uint64) types.PoolTransactions {
+		txs := types.PoolTransactions{}
+		for _, nonce := range nonces {
+			txs = append(txs, types.NewTransaction(nonce, common.Address{}, 0, big.NewInt(0), 21000, big.NewInt(1), nil))
+		}
+		return txs
+	}
+	tests := []struct {
+		next   uint64
+		queued types.PoolTransactions
+		gaps   []hexutil.Uint64
+	}{
+		{0, queued(), []hexutil.Uint64{}},
+		{3, queued(5), []hexutil.Uint64{3, 4}},
+		{3, queued(7, 4, 5), []hexutil.Uint64{3, 6}},
+		{5, queued(2, 6), []hexutil.Uint64{5}},
+	}
+	for i, test := range tests {
+		if gaps := nonceGaps(test.next, test.queued); !reflect.DeepEqual(gaps, test.gaps) {
+			t.Errorf("test %d: gaps mismatch: have %v, want %v", i, gaps, test.gaps)
+		}
+	}
+}
@@ -3,9 +3,14 @@ package rpc
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/vm"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	"github.com/pkg/errors"
 )
@@ -70,3 +75,82 @@ func checkAddressOrListEqual(a, b *AddressOrList) error {
 	}
 	return nil
 }
+
+func TestStateOverride_Apply(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.SetState(testAddr1, common.HexToHash("0x01"), common.HexToHash("0xaa"))
+	statedb.SetState(testAddr1, common.HexToHash("0x02"), common.HexToHash("0xbb"))
+
+	var override StateOverride
+	input := fmt.Sprintf(`{
+		"%v": {"nonce": "0x5", "balance": "0x64", "code": "0x6001", "stateDiff": {"0x0000000000000000000000000000000000000000000000000000000000000002": "0x00000000000000000000000000000000000000000000000000000000000000cc"}},
+		"%v": {"state": {"0x0000000000000000000000000000000000000000000000000000000000000003": "0x00000000000000000000000000000000000000000000000000000000000000dd"}}
+	}`, testAddr1.Hex(), testAddr2.Hex())
+	if err := json.Unmarshal([]byte(input), &override); err != nil {
+		t.Fatal(err)
+	}
+	if err := override.Apply(statedb); err != nil {
+		t.Fatal(err)
+	}
+
+	if nonce := statedb.GetNonce(testAddr1); nonce != 5 {
+		t.Errorf("unexpected nonce %v", nonce)
+	}
+	if bal := statedb.GetBalance(testAddr1); bal.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("unexpected balance %v", bal)
+	}
+	if code := statedb.GetCode(testAddr1); hexutil.Encode(code) != "0x6001" {
+		t.Errorf("unexpected code %x", code)
+	}
+	if val := statedb.GetState(testAddr1, common.HexToHash("0x01")); val != common.HexToHash("0xaa") {
+		t.Errorf("state diff should keep untouched slot, got %v", val.Hex())
+	}
+	if val := statedb.GetState(testAddr1, common.HexToHash("0x02")); val != common.HexToHash("0xcc") {
+		t.Errorf("state diff not applied, got %v", val.Hex())
+	}
+	if val := statedb.GetState(testAddr2, common.HexToHash("0x03")); val != common.HexToHash("0xdd") {
+		t.Errorf("state not applied, got %v", val.Hex())
+	}
+
+	both := StateOverride{testAddr1: OverrideAccount{
+		State:     &map[common.Hash]common.Hash{},
+		StateDiff: &map[common.Hash]common.Hash{},
+	}}
+	if err := both.Apply(statedb); err == nil {
+		t.Error("expected error when both state and stateDiff are given")
+	}
+}
+
+func TestBlockOverrides_Apply(t *testing.T) {
+	blockCtx := vm.Context{
+		BlockNumber: big.NewInt(10),
+		Time:        big.NewInt(1000),
+		GasLimit:    80000000,
+		Coinbase:    testAddr1,
+	}
+	var overrides BlockOverrides
+	input := fmt.Sprintf(`{"number": "0x14", "time": "0x7d0", "coinbase": "%v"}`, testAddr2.Hex())
+	if err := json.Unmarshal([]byte(input), &overrides); err != nil {
+		t.Fatal(err)
+	}
+	overrides.Apply(&blockCtx)
+
+	if blockCtx.BlockNumber.Uint64() != 20 {
+		t.Errorf("unexpected number %v", blockCtx.BlockNumber)
+	}
+	if blockCtx.Time.Uint64() != 2000 {
+		t.Errorf("unexpected time %v", blockCtx.Time)
+	}
+	if blockCtx.GasLimit != 80000000 {
+		t.Errorf("gas limit should be kept, got %v", blockCtx.GasLimit)
+	}
+	if blockCtx.Coinbase != testAddr2 {
+		t.Errorf("unexpected coinbase %v", blockCtx.Coinbase.Hex())
+	}
+
+	var none *BlockOverrides
+	none.Apply(&blockCtx)
+	if blockCtx.BlockNumber.Uint64() != 20 {
+		t.Errorf("nil overrides should be a no-op, got number %v", blockCtx.BlockNumber)
+	}
+}
@@ -0,0 +1,52 @@
+package availability
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/shard"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+var secondsInYear = numeric.NewDec(31557600)
+
+// ComputeEpochPerformance returns the performance of a validator over an epoch,
+// given its wrapper at the beginning and at the end of the epoch, the shard state
+// elected for the epoch and the duration of the epoch in seconds. The end of the
+// current epoch is the latest state of the validator.
+func ComputeEpochPerformance(
+	epoch *big.Int,
+	begin, end *staking.ValidatorWrapper,
+	shardState *shard.State,
+	duration int64,
+) *staking.EpochPerformance {
+	computed := ComputeCurrentSigning(begin, end)
+	perf := &staking.EpochPerformance{
+		Epoch:           epoch,
+		Signed:          computed.Signed,
+		ToSign:          computed.ToSign,
+		Missed:          new(big.Int).Sub(computed.ToSign, computed.Signed),
+		Percentage:      computed.Percentage,
+		EffectiveStake:  numeric.ZeroDec(),
+		TotalDelegation: begin.TotalDelegation(),
+		Reward:          new(big.Int).Sub(end.BlockReward, begin.BlockReward),
+		APR:             numeric.ZeroDec(),
+	}
+	if shardState != nil {
+		for _, committee := range shardState.Shards {
+			for _, slot := range committee.Slots {
+				if slot.EcdsaAddress == end.Address && slot.EffectiveStake != nil {
+					perf.EffectiveStake = perf.EffectiveStake.Add(*slot.EffectiveStake)
+				}
+			}
+		}
+	}
+	if duration > 0 && perf.TotalDelegation.Cmp(common.Big0) > 0 {
+		perf.APR = numeric.NewDecFromBigInt(perf.Reward).
+			Mul(secondsInYear).
+			QuoInt64(duration).
+			Quo(numeric.NewDecFromBigInt(perf.TotalDelegation))
+	}
+	return perf
+}
@@ -0,0 +1,51 @@
+package availability
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/harmony-one/harmony/shard"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+func TestComputeEpochPerformance(t *testing.T) {
+	addr := common.BigToAddress(big.NewInt(7))
+	begin := makeTestWrapper(addr, 100, 200)
+	begin.BlockReward = big.NewInt(1000)
+	begin.Delegations = staking.Delegations{
+		staking.NewDelegation(addr, big.NewInt(600000)),
+		staking.NewDelegation(common.BigToAddress(big.NewInt(8)), big.NewInt(400000)),
+	}
+	end := makeTestWrapper(addr, 225, 350)
+	end.BlockReward = big.NewInt(3000)
+
+	shardState := makeTestShardState(2, 4)
+	stake1, stake2 := numeric.NewDec(testStake), numeric.NewDec(2*testStake)
+	shardState.Shards[0].Slots = append(shardState.Shards[0].Slots, shard.Slot{EcdsaAddress: addr, EffectiveStake: &stake1})
+	shardState.Shards[1].Slots = append(shardState.Shards[1].Slots, shard.Slot{EcdsaAddress: addr, EffectiveStake: &stake2})
+
+	perf := ComputeEpochPerformance(big.NewInt(5), &begin, &end, shardState, secondsInYear.TruncateInt64()/400)
+	if perf.Signed.Cmp(big.NewInt(125)) != 0 || perf.ToSign.Cmp(big.NewInt(150)) != 0 || perf.Missed.Cmp(big.NewInt(25)) != 0 {
+		t.Errorf("unexpected counts: signed %v, to sign %v, missed %v", perf.Signed, perf.ToSign, perf.Missed)
+	}
+	if expPct := numeric.NewDec(5).Quo(numeric.NewDec(6)); !perf.Percentage.Equal(expPct) {
+		t.Errorf("unexpected percentage: %v / %v", perf.Percentage, expPct)
+	}
+	if expStake := numeric.NewDec(3 * testStake); !perf.EffectiveStake.Equal(expStake) {
+		t.Errorf("unexpected effective stake: %v / %v", perf.EffectiveStake, expStake)
+	}
+	if perf.TotalDelegation.Cmp(big.NewInt(1000000)) != 0 || perf.Reward.Cmp(big.NewInt(2000)) != 0 {
+		t.Errorf("unexpected delegation %v or reward %v", perf.TotalDelegation, perf.Reward)
+	}
+	// 2000 earned on 1000000 in a 400th of a year
+	if expAPR := numeric.NewDecWithPrec(8, 1); !perf.APR.Equal(expAPR) {
+		t.Errorf("unexpected apr: %v / %v", perf.APR, expAPR)
+	}
+
+	perf = ComputeEpochPerformance(big.NewInt(5), &end, &end, nil, 0)
+	if !perf.APR.IsZero() || !perf.EffectiveStake.IsZero() || perf.Missed.Sign() != 0 {
+		t.Errorf("unexpected performance of an empty epoch: %+v", perf)
+	}
+}
@@ -1,6 +1,7 @@
 package staking
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -11,6 +12,11 @@ const (
 	delegateStr           = "Harmony/Delegate"
 	unDelegateStr         = "Harmony/UnDelegate"
 	firstElectionEpochStr = "Harmony/FirstElectionEpoch/Key/v1"
+
+	delegateEventStr        = "Delegate(address,address,uint256)"
+	undelegateEventStr      = "Undelegate(address,address,uint256)"
+	collectRewardsEventStr  = "CollectRewards(address,uint256)"
+	validatorEditedEventStr = "ValidatorEdited(address)"
 )
 
 // keys used to retrieve staking related informatio
@@ -22,3 +28,14 @@ var (
 	UnDelegateTopic       = crypto.Keccak256Hash([]byte(unDelegateStr))
 	FirstElectionEpochKey = crypto.Keccak256Hash([]byte(firstElectionEpochStr))
 )
+
+// Well-known logs of the staking state transitions, emitted as the events of a
+// contract at the staking precompile address. The topics are the hashes of the
+// event signatures, the addresses are indexed and the amounts are the log data.
+var (
+	LogAddress                = common.BytesToAddress([]byte{252})
+	DelegateEventTopic        = crypto.Keccak256Hash([]byte(delegateEventStr))
+	UndelegateEventTopic      = crypto.Keccak256Hash([]byte(undelegateEventStr))
+	CollectRewardsEventTopic  = crypto.Keccak256Hash([]byte(collectRewardsEventStr))
+	ValidatorEditedEventTopic = crypto.Keccak256Hash([]byte(validatorEditedEventStr))
+)
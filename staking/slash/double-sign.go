@@ -88,8 +88,9 @@ type Record struct {
 
 // Application tracks the slash application to state
 type Application struct {
-	TotalSlashed           *big.Int `json:"total-slashed"`
-	TotalBeneficiaryReward *big.Int `json:"total-beneficiary-reward"`
+	TotalSlashed           *big.Int                  `json:"total-slashed"`
+	TotalBeneficiaryReward *big.Int                  `json:"total-beneficiary-reward"`
+	Delegations            []staking.DelegationSlash `json:"delegations,omitempty"`
 }
 
 func (a *Application) String() string {
@@ -108,6 +109,15 @@ func (e Evidence) MarshalJSON() ([]byte, error) {
 // Records ..
 type Records []Record
 
+// HistoryEntry is a slash applied to the state by a block of the beacon chain,
+// with its evidence and the amounts taken from the delegations of the offender
+type HistoryEntry struct {
+	Record      Record                   `json:"record"`
+	Epoch       *big.Int                 `json:"epoch"`
+	BlockNumber uint64                   `json:"block-number"`
+	Application staking.SlashApplication `json:"application"`
+}
+
 func (r Records) String() string {
 	s, _ := json.Marshal(r)
 	return string(s)
@@ -373,7 +383,7 @@ func delegatorSlashApply(
 
 	for _, delegationSnapshot := range snapshot.Delegations {
 		slashDebt := applySlashRate(delegationSnapshot.Amount, rate)
-		slashDiff := &Application{big.NewInt(0), big.NewInt(0)}
+		slashDiff := &Application{TotalSlashed: big.NewInt(0), TotalBeneficiaryReward: big.NewInt(0)}
 		snapshotAddr := delegationSnapshot.DelegatorAddress
 		for i := range current.Delegations {
 			delegationNow := current.Delegations[i]
@@ -459,6 +469,13 @@ func delegatorSlashApply(
 				)
 			}
 		}
+		if slashDiff.TotalSlashed.Sign() > 0 {
+			slashTrack.Delegations = append(slashTrack.Delegations, staking.DelegationSlash{
+				Delegator:         snapshotAddr,
+				Slashed:           slashDiff.TotalSlashed,
+				BeneficiaryReward: slashDiff.TotalBeneficiaryReward,
+			})
+		}
 		// after the loops, paid off as much as could
 		if slashDebt.Cmp(common.Big0) == -1 {
 			x1, _ := rlp.EncodeToBytes(snapshot)
@@ -473,12 +490,13 @@ func delegatorSlashApply(
 	return nil
 }
 
-// Apply ..
+// Apply applies the slashes to the state and records the application of each
+// record on it, for the slashing history.
 func Apply(
 	chain staking.ValidatorSnapshotReader, state *state.DB,
 	slashes Records, rate numeric.Dec, rewardBeneficiary common.Address,
 ) (*Application, error) {
-	slashDiff := &Application{big.NewInt(0), big.NewInt(0)}
+	slashDiff := &Application{TotalSlashed: big.NewInt(0), TotalBeneficiaryReward: big.NewInt(0)}
 	for _, slash := range slashes {
 		snapshot, err := chain.ReadValidatorSnapshotAtEpoch(
 			slash.Evidence.Epoch,
@@ -501,12 +519,25 @@ func Apply(
 		// NOTE invariant: first delegation is the validators own
 		// stake, rest are external delegations.
 		// Bottom line: everyone will be slashed under the same rule.
+		recordDiff := &Application{TotalSlashed: big.NewInt(0), TotalBeneficiaryReward: big.NewInt(0)}
 		if err := delegatorSlashApply(
 			snapshot.Validator, current, rate, state,
-			rewardBeneficiary, slash.Evidence.Epoch, slashDiff,
+			rewardBeneficiary, slash.Evidence.Epoch, recordDiff,
 		); err != nil {
 			return nil, err
 		}
+		slashDiff.TotalSlashed.Add(slashDiff.TotalSlashed, recordDiff.TotalSlashed)
+		slashDiff.TotalBeneficiaryReward.Add(
+			slashDiff.TotalBeneficiaryReward, recordDiff.TotalBeneficiaryReward,
+		)
+		slashDiff.Delegations = append(slashDiff.Delegations, recordDiff.Delegations...)
+		state.AddSlashApplication(&staking.SlashApplication{
+			Record:                 slash.Hash(),
+			Rate:                   rate,
+			TotalSlashed:           recordDiff.TotalSlashed,
+			TotalBeneficiaryReward: recordDiff.TotalBeneficiaryReward,
+			Delegations:            recordDiff.Delegations,
+		})
 
 		// finally, kick them off forever
 		current.Status = effective.Banned
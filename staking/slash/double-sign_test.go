@@ -617,6 +617,27 @@ type applyTestCase struct {
 	expErr               error
 }
 
+func (tc *applyTestCase) checkApplications() error {
+	apps := tc.state.SlashApplications()
+	if len(apps) != len(tc.slashes) {
+		return fmt.Errorf("unexpected number of applications %v / %v", len(apps), len(tc.slashes))
+	}
+	if apps[0].Record != tc.slashes[0].Hash() {
+		return fmt.Errorf("unexpected record hash %x / %x", apps[0].Record, tc.slashes[0].Hash())
+	}
+	if apps[0].TotalSlashed.Cmp(tc.expSlashed) != 0 {
+		return fmt.Errorf("unexpected total slash %v / %v", apps[0].TotalSlashed, tc.expSlashed)
+	}
+	slashed := big.NewInt(0)
+	for _, d := range apps[0].Delegations {
+		slashed.Add(slashed, d.Slashed)
+	}
+	if slashed.Cmp(tc.expSlashed) != 0 {
+		return fmt.Errorf("unexpected delegations slash %v / %v", slashed, tc.expSlashed)
+	}
+	return nil
+}
+
 func (tc *applyTestCase) makeData(t *testing.T) {
 	tc.chain = defaultFakeBlockChain()
 	if tc.snapshot != nil {
@@ -654,6 +675,9 @@ func (tc *applyTestCase) checkResult() error {
 		return fmt.Errorf("unexpected total slash %v / %v", tc.gotDiff.TotalSlashed,
 			tc.expSlashed)
 	}
+	if err := tc.checkApplications(); err != nil {
+		return fmt.Errorf("slash applications: %v", err)
+	}
 	if err := tc.checkState(); err != nil {
 		return fmt.Errorf("state check: %v", err)
 	}
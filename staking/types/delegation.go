@@ -175,6 +175,25 @@ func (d *Delegation) DeleteEntry(epoch *big.Int) {
 	}
 }
 
+// UnlockEpoch returns the epoch at the end of which the undelegation is paid out
+// by RemoveUnlockedUndelegations, assuming the validator is not elected again,
+// which would delay an early unlock. It is never before curEpoch.
+func (u Undelegation) UnlockEpoch(
+	curEpoch, lastEpochInCommittee *big.Int, lockPeriod int, noEarlyUnlock bool,
+) *big.Int {
+	lock := big.NewInt(int64(lockPeriod))
+	unlock := new(big.Int).Add(u.Epoch, lock)
+	if !noEarlyUnlock {
+		if early := new(big.Int).Add(lastEpochInCommittee, lock); early.Cmp(unlock) < 0 {
+			unlock = early
+		}
+	}
+	if unlock.Cmp(curEpoch) < 0 {
+		unlock.Set(curEpoch)
+	}
+	return unlock
+}
+
 // RemoveUnlockedUndelegations removes all fully unlocked
 // undelegations and returns the total sum
 func (d *Delegation) RemoveUnlockedUndelegations(
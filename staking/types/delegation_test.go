@@ -166,3 +166,44 @@ func TestNoEarlyUnlock(t *testing.T) {
 		t.Errorf("should not allow early unlock")
 	}
 }
+
+func TestUndelegationUnlockEpoch(t *testing.T) {
+	tests := []struct {
+		epoch, curEpoch, lastEpochInCommittee int64
+		lockPeriod                            int
+		noEarlyUnlock                         bool
+		expUnlock                             int64
+	}{
+		{21, 24, 22, 7, false, 28},
+		{21, 24, 17, 7, false, 24},
+		{21, 22, 17, 7, true, 28},
+		{21, 24, 15, 7, false, 24},
+		{21, 21, 20, 7, false, 27},
+		{44, 44, 44, 0, false, 44},
+	}
+	for i, test := range tests {
+		u := Undelegation{Amount: big.NewInt(1000), Epoch: big.NewInt(test.epoch)}
+		unlock := u.UnlockEpoch(
+			big.NewInt(test.curEpoch), big.NewInt(test.lastEpochInCommittee), test.lockPeriod, test.noEarlyUnlock,
+		)
+		if unlock.Cmp(big.NewInt(test.expUnlock)) != 0 {
+			t.Errorf("Test %v: unexpected unlock epoch %v / %v", i, unlock, test.expUnlock)
+		}
+		// The undelegation is paid out at the unlock epoch, not before
+		d := NewDelegation(delegatorAddr, delegationAmt)
+		d.Undelegate(u.Epoch, u.Amount)
+		if unlock.Cmp(big.NewInt(test.curEpoch)) > 0 {
+			before := new(big.Int).Sub(unlock, common.Big1)
+			if paid := d.RemoveUnlockedUndelegations(
+				before, big.NewInt(test.lastEpochInCommittee), test.lockPeriod, test.noEarlyUnlock,
+			); paid.Sign() != 0 {
+				t.Errorf("Test %v: paid out before unlock epoch", i)
+			}
+		}
+		if paid := d.RemoveUnlockedUndelegations(
+			unlock, big.NewInt(test.lastEpochInCommittee), test.lockPeriod, test.noEarlyUnlock,
+		); paid.Cmp(u.Amount) != 0 {
+			t.Errorf("Test %v: not paid out at unlock epoch", i)
+		}
+	}
+}
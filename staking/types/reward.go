@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	common2 "github.com/harmony-one/harmony/internal/common"
+)
+
+// DelegationReward is the share of a validator block reward paid to one of its
+// delegations. The commission is only paid to the self delegation of the validator.
+type DelegationReward struct {
+	Validator  common.Address
+	Delegator  common.Address
+	Reward     *big.Int
+	Commission *big.Int
+}
+
+// RewardHistoryEntry is the total reward paid to a delegation by a validator over
+// an epoch, up to the last block whose rewards were counted
+type RewardHistoryEntry struct {
+	Validator  common.Address
+	Delegator  common.Address
+	Epoch      *big.Int
+	LastBlock  uint64
+	Reward     *big.Int
+	Commission *big.Int
+}
+
+// Add adds the given reward paid by the given block to the entry.
+func (e *RewardHistoryEntry) Add(reward *DelegationReward, number uint64) {
+	e.Reward = new(big.Int).Add(e.Reward, reward.Reward)
+	e.Commission = new(big.Int).Add(e.Commission, reward.Commission)
+	e.LastBlock = number
+}
+
+// MarshalJSON ..
+func (e RewardHistoryEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Validator  string   `json:"validator-address"`
+		Delegator  string   `json:"delegator-address"`
+		Epoch      *big.Int `json:"epoch"`
+		LastBlock  uint64   `json:"last-block"`
+		Reward     *big.Int `json:"reward"`
+		Commission *big.Int `json:"commission"`
+	}{
+		common2.MustAddressToBech32(e.Validator), common2.MustAddressToBech32(e.Delegator),
+		e.Epoch, e.LastBlock, e.Reward, e.Commission,
+	})
+}
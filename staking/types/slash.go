@@ -0,0 +1,38 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// SlashApplication is the outcome of applying a double-sign slash record to the
+// delegations of the offending validator
+type SlashApplication struct {
+	// Record is the hash of the applied slash record
+	Record                 common.Hash       `json:"record-hash"`
+	Rate                   numeric.Dec       `json:"rate"`
+	TotalSlashed           *big.Int          `json:"total-slashed"`
+	TotalBeneficiaryReward *big.Int          `json:"total-beneficiary-reward"`
+	Delegations            []DelegationSlash `json:"delegations"`
+}
+
+// DelegationSlash is the amount taken from a delegation by a slash, half of
+// which went to the reporting beneficiary
+type DelegationSlash struct {
+	Delegator         common.Address
+	Slashed           *big.Int
+	BeneficiaryReward *big.Int
+}
+
+// MarshalJSON ..
+func (d DelegationSlash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Delegator         string   `json:"delegator-address"`
+		Slashed           *big.Int `json:"slashed"`
+		BeneficiaryReward *big.Int `json:"beneficiary-reward"`
+	}{common2.MustAddressToBech32(d.Delegator), d.Slashed, d.BeneficiaryReward})
+}
@@ -202,6 +202,26 @@ type EpochSigningEntry struct {
 	Blocks counters `json:"blocks"`
 }
 
+// EpochPerformance is the performance of a validator over an epoch, with the
+// stake and the reward its signing earned during the epoch
+type EpochPerformance struct {
+	Epoch *big.Int `json:"epoch"`
+	// The number of blocks signed, to sign and missed during the epoch
+	Signed *big.Int `json:"signed"`
+	ToSign *big.Int `json:"to-sign"`
+	Missed *big.Int `json:"missed"`
+	// Percentage is the share of the blocks to sign which were signed
+	Percentage numeric.Dec `json:"signing-percentage"`
+	// EffectiveStake is the effective stake of the slots elected for the epoch
+	EffectiveStake numeric.Dec `json:"effective-stake"`
+	// TotalDelegation is the total delegation at the beginning of the epoch
+	TotalDelegation *big.Int `json:"total-delegation"`
+	// Reward is the block reward earned during the epoch
+	Reward *big.Int `json:"reward"`
+	// APR is the reward over the total delegation, annualized over the epoch duration
+	APR numeric.Dec `json:"apr"`
+}
+
 // ValidatorStats to record validator's performance and history records
 type ValidatorStats struct {
 	// APRs is the APR history containing APR's of epochs
@@ -22,6 +22,7 @@ type AvailabilityHooks struct {
 // DoubleSignWebHooks ..
 type DoubleSignWebHooks struct {
 	OnNoticeDoubleSign string `yaml:"on-notice-double-sign"`
+	OnOwnKeyDoubleSign string `yaml:"on-own-key-double-sign"`
 }
 
 // BadBlockHooks ..